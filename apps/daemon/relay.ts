@@ -11,12 +11,20 @@
  * - Relays responses from OpenClaw → back to Mission Control
  * - Handles connection pooling and message queuing
  * - Logs all messages for auditing
+ * - Watchdog: pings OpenClaw and restarts a wedged connection
+ * - Focus: takes the directory tmux/editor hooks report (bin/mc-focus) and
+ *   hands the latest one to Mission Control TUIs (GET /focus) and clients
  * 
  * Usage:
  *   bun relay.ts [port] [openclaw-url]
  * 
  * Example:
  *   bun relay.ts 9999 ws://192.168.1.134:18789
+ *
+ * HTTP (port + 1000):
+ *   POST /focus   {"dir": "...", "source": "tmux"} from a focus hook
+ *   GET  /focus   the latest focus report
+ *   GET  /health  when HTTP_HEALTH_CHECK=true
  */
 
 import { WebSocketServer, WebSocket } from 'ws';
@@ -36,12 +44,27 @@ console.log(`   Host: ${HOSTNAME}\n`);
 const messageQueue: any[] = [];
 let openclawConnection: WebSocket | null = null;
 
+// Watchdog: a connection that stops answering pings (or never finishes
+// connecting) is torn down so the close handler reconnects it
+const WATCHDOG_INTERVAL_MS = 30_000;
+const CONNECT_TIMEOUT_MS = 15_000;
+// Health reports degraded once OpenClaw has been unreachable this long
+const UNHEALTHY_AFTER_MS = 60_000;
+
+const startedAt = new Date();
+let connectStartedAt = 0;
+let openclawAlive = false;
+let lastConnectedAt: Date | null = null;
+let lastDisconnectedAt: Date | null = startedAt;
+let lastOpenclawMessageAt: Date | null = null;
+let watchdogRestarts = 0;
+
 // Create WebSocket server
 const wss = new WebSocketServer({ port: PORT });
 
 interface RelayMessage {
   id: string;
-  type: 'message' | 'status' | 'error';
+  type: 'message' | 'status' | 'error' | 'focus';
   from: 'mission-control' | 'openclaw';
   sessionId: string;
   threadId?: string;
@@ -50,6 +73,29 @@ interface RelayMessage {
   retries?: number;
 }
 
+interface FocusReport {
+  dir: string;
+  source?: string; // tmux, nvim, shell, ...
+  at: Date;
+}
+
+// The latest focus report; it stays on this machine and never goes to OpenClaw
+let focus: FocusReport | null = null;
+
+/**
+ * Record a focus report and push it to connected clients
+ */
+function setFocus(dir: string, source?: string): FocusReport {
+  focus = { dir, source: source || undefined, at: new Date() };
+  const message = JSON.stringify({ type: 'focus', from: 'mission-control', content: dir, ...focus });
+  wss.clients.forEach((client) => {
+    if (client.readyState === WebSocket.OPEN) {
+      client.send(message);
+    }
+  });
+  return focus;
+}
+
 /**
  * Connect to OpenClaw gateway
  */
@@ -57,9 +103,13 @@ function connectToOpenClaw() {
   console.log(`📡 Connecting to OpenClaw at ${OPENCLAW_URL}...`);
 
   openclawConnection = new WebSocket(OPENCLAW_URL);
+  connectStartedAt = Date.now();
 
   openclawConnection.on('open', () => {
     console.log(`✅ Connected to OpenClaw`);
+    openclawAlive = true;
+    lastConnectedAt = new Date();
+    lastDisconnectedAt = null;
 
     // Drain message queue
     while (messageQueue.length > 0) {
@@ -70,7 +120,13 @@ function connectToOpenClaw() {
     }
   });
 
+  openclawConnection.on('pong', () => {
+    openclawAlive = true;
+  });
+
   openclawConnection.on('message', (data) => {
+    openclawAlive = true;
+    lastOpenclawMessageAt = new Date();
     try {
       const message: RelayMessage = JSON.parse(data.toString());
       logMessage(message, 'OPENCLAW_RECV');
@@ -89,6 +145,7 @@ function connectToOpenClaw() {
   openclawConnection.on('close', () => {
     console.warn(`⚠️  Disconnected from OpenClaw. Reconnecting in 5s...`);
     openclawConnection = null;
+    lastDisconnectedAt = lastDisconnectedAt ?? new Date();
     setTimeout(connectToOpenClaw, 5000);
   });
 
@@ -97,6 +154,37 @@ function connectToOpenClaw() {
   });
 }
 
+/**
+ * Watchdog: restart the OpenClaw connection if it is wedged
+ */
+function watchdog(): void {
+  const conn = openclawConnection;
+  if (!conn) {
+    return; // reconnect already scheduled
+  }
+
+  if (conn.readyState === WebSocket.CONNECTING && Date.now() - connectStartedAt > CONNECT_TIMEOUT_MS) {
+    console.warn(`🐕 Watchdog: connect to OpenClaw timed out, restarting`);
+    watchdogRestarts++;
+    conn.terminate();
+    return;
+  }
+  if (conn.readyState !== WebSocket.OPEN) {
+    return;
+  }
+
+  if (!openclawAlive) {
+    console.warn(`🐕 Watchdog: OpenClaw stopped answering pings, restarting connection`);
+    watchdogRestarts++;
+    conn.terminate(); // fires 'close', which reconnects
+    return;
+  }
+  openclawAlive = false;
+  conn.ping();
+}
+
+setInterval(watchdog, WATCHDOG_INTERVAL_MS);
+
 /**
  * Log message to file
  */
@@ -143,6 +231,11 @@ wss.on('connection', (ws, req) => {
       const message: RelayMessage = JSON.parse(data.toString());
       message.timestamp = new Date();
 
+      if (message.type === 'focus') {
+        setFocus(message.content, (message as any).source);
+        return;
+      }
+
       // Relay to OpenClaw
       relayToOpenClaw(message);
     } catch (error) {
@@ -170,35 +263,84 @@ wss.on('connection', (ws, req) => {
 });
 
 /**
- * Health check endpoint (via HTTP)
+ * Health check
  */
-if (Bun.env.HTTP_HEALTH_CHECK === 'true') {
-  Bun.serve({
-    port: PORT + 1000,
-    async fetch(req) {
-      if (req.url.endsWith('/health')) {
-        return new Response(
-          JSON.stringify({
-            status: 'ok',
-            relay: 'healthy',
-            hostname: HOSTNAME,
-            connectedClients: wss.clients.size,
-            openclawConnected: openclawConnection?.readyState === WebSocket.OPEN,
-            queueSize: messageQueue.length,
-          }),
-          { headers: { 'Content-Type': 'application/json' } }
-        );
+function health(): Response {
+  const connected = openclawConnection?.readyState === WebSocket.OPEN;
+  const healthy =
+    connected || !lastDisconnectedAt || Date.now() - lastDisconnectedAt.getTime() < UNHEALTHY_AFTER_MS;
+  return new Response(
+    JSON.stringify({
+      status: healthy ? 'ok' : 'degraded',
+      relay: healthy ? 'healthy' : 'openclaw unreachable',
+      hostname: HOSTNAME,
+      uptimeSeconds: Math.round((Date.now() - startedAt.getTime()) / 1000),
+      connectedClients: wss.clients.size,
+      openclawConnected: connected,
+      lastConnectedAt,
+      lastDisconnectedAt,
+      lastOpenclawMessageAt,
+      watchdogRestarts,
+      queueSize: messageQueue.length,
+    }),
+    { status: healthy ? 200 : 503, headers: { 'Content-Type': 'application/json' } }
+  );
+}
+
+/**
+ * Focus reports: hooks POST, TUIs GET. Bound to localhost, since the
+ * paths it carries are this machine's.
+ */
+async function handleFocus(req: Request): Promise<Response> {
+  const json = { headers: { 'Content-Type': 'application/json' } };
+  if (req.method === 'POST') {
+    try {
+      const body = await req.json();
+      if (typeof body?.dir !== 'string' || body.dir === '') {
+        return new Response('dir is required', { status: 400 });
       }
-      return new Response('Not found', { status: 404 });
-    },
-  });
+      return new Response(JSON.stringify(setFocus(body.dir, body.source)), json);
+    } catch {
+      return new Response('invalid JSON', { status: 400 });
+    }
+  }
+  if (req.method === 'GET') {
+    return new Response(JSON.stringify(focus ?? {}), json);
+  }
+  return new Response('Method not allowed', { status: 405 });
+}
 
+Bun.serve({
+  hostname: '127.0.0.1',
+  port: PORT + 1000,
+  async fetch(req) {
+    const { pathname } = new URL(req.url);
+    if (pathname === '/focus') {
+      return handleFocus(req);
+    }
+    if (pathname === '/health' && Bun.env.HTTP_HEALTH_CHECK === 'true') {
+      return health();
+    }
+    return new Response('Not found', { status: 404 });
+  },
+});
+
+console.log(`🎯 Focus reports on localhost:${PORT + 1000}/focus`);
+if (Bun.env.HTTP_HEALTH_CHECK === 'true') {
   console.log(`🏥 Health check endpoint on localhost:${PORT + 1000}/health`);
 }
 
 // Connect to OpenClaw immediately
 connectToOpenClaw();
 
+// Stay up through unexpected errors; the watchdog recovers the connection
+process.on('uncaughtException', (error) => {
+  console.error('Uncaught exception (relay continuing):', error);
+});
+process.on('unhandledRejection', (reason) => {
+  console.error('Unhandled rejection (relay continuing):', reason);
+});
+
 // Graceful shutdown
 process.on('SIGINT', () => {
   console.log('\n🛑 Shutting down relay gracefully...');
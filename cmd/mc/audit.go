@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+const auditUsage = `Usage: mc audit [--csv|--json] [project]
+
+Shows every change mc has made (pushes, merges, deploys, issues, config
+edits), oldest first. --csv and --json (one object per line) export to stdout.`
+
+// runAudit implements `mc audit`
+func runAudit(args []string) int {
+	format := ""
+	project := ""
+	for _, arg := range args {
+		switch {
+		case arg == "--csv" || arg == "--json":
+			format = strings.TrimPrefix(arg, "--")
+		case strings.HasPrefix(arg, "-") || project != "":
+			fmt.Fprintln(os.Stderr, auditUsage)
+			return 2
+		default:
+			project = arg
+		}
+	}
+
+	entries, err := audit.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if project != "" {
+		var kept []audit.Entry
+		for _, e := range entries {
+			if e.Project == project {
+				kept = append(kept, e)
+			}
+		}
+		entries = kept
+	}
+
+	switch format {
+	case "csv":
+		if err := audit.WriteCSV(os.Stdout, entries); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+		}
+	default:
+		if len(entries) == 0 {
+			fmt.Println("Nothing recorded yet.")
+			return 0
+		}
+		for _, e := range entries {
+			detail := e.Detail
+			if e.Error != "" && e.Error != e.Detail {
+				detail = strings.TrimSpace(detail + " (" + e.Error + ")")
+			}
+			fmt.Printf("%s  %-20s %-18s %-8s %-6s %s\n", e.Time.Format("2006-01-02 15:04"), e.Who, e.Project, e.Action, e.Result, detail)
+		}
+	}
+	return 0
+}
+
+// updateConfig applies fn with config.Update and records the change,
+// described by the command line that made it, in the audit log
+func updateConfig(project string, fn func(*config.Config)) error {
+	err := config.Update(fn)
+	audit.Record(project, "config", commandLine(), err)
+	return err
+}
+
+// commandLine returns how mc was invoked, e.g. `mc client set api Acme 150`
+func commandLine() string {
+	parts := []string{"mc"}
+	for _, arg := range os.Args[1:] {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+const autofetchUsage = `Usage: mc autofetch <command>
+
+  status               Show whether background fetching is on
+  on [interval]        Fetch each project in the background at most every interval (default 15m)
+  off                  Stop background fetching
+  skip <project>       Never auto-fetch a project
+  unskip <project>     Auto-fetch a project again`
+
+// runAutofetch implements `mc autofetch`
+func runAutofetch(args []string) int {
+	if len(args) == 0 {
+		args = []string{"status"}
+	}
+
+	var err error
+	switch args[0] {
+	case "status":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if cfg.AutoFetch == nil || !cfg.AutoFetch.Enabled {
+			fmt.Println("Auto-fetch is off.")
+		} else {
+			fmt.Printf("Auto-fetch is on, every %s per project.\n", cfg.AutoFetch.Interval())
+		}
+		var skipped []string
+		for name, p := range cfg.Projects {
+			if p != nil && p.NoAutoFetch {
+				skipped = append(skipped, name)
+			}
+		}
+		sort.Strings(skipped)
+		for _, name := range skipped {
+			fmt.Printf("  skipped: %s\n", name)
+		}
+		return 0
+
+	case "on":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, autofetchUsage)
+			return 2
+		}
+		every := ""
+		if len(args) == 2 {
+			if d, perr := time.ParseDuration(args[1]); perr != nil || d <= 0 {
+				fmt.Fprintf(os.Stderr, "Invalid interval %q (e.g. 10m, 1h)\n", args[1])
+				return 2
+			}
+			every = args[1]
+		}
+		err = updateConfig("", func(cfg *config.Config) {
+			cfg.AutoFetch = &config.AutoFetch{Enabled: true, Every: every}
+		})
+
+	case "off":
+		err = updateConfig("", func(cfg *config.Config) {
+			if cfg.AutoFetch != nil {
+				cfg.AutoFetch.Enabled = false
+			}
+		})
+
+	case "skip", "unskip":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, autofetchUsage)
+			return 2
+		}
+		skip := args[0] == "skip"
+		err = updateConfig(args[1], func(cfg *config.Config) {
+			cfg.EnsureProject(args[1]).NoAutoFetch = skip
+		})
+
+	default:
+		fmt.Fprintln(os.Stderr, autofetchUsage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
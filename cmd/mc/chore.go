@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/chores"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+const choreUsage = `Usage: mc chore <command>
+
+  list                                          Show chores by due date
+  add <project> <name> <every> [YYYY-MM-DD]     Add a recurring chore (first due date defaults to today)
+  done <project> <name>                         Mark a chore done
+  rm <project> <name>                           Remove a chore
+  history [project]                             Show completions
+
+Every: daily, weekly, biweekly, monthly, quarterly, yearly, or 10d / 2w / 6m / 1y`
+
+// runChore implements `mc chore`
+func runChore(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		done, err := chores.History()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+
+		schedule := chores.Schedule(cfg, done)
+		if len(schedule) == 0 {
+			fmt.Println("No chores configured.")
+			return 0
+		}
+		now := time.Now()
+		for _, d := range schedule {
+			state := ""
+			switch {
+			case d.Overdue(now):
+				state = "OVERDUE"
+			case d.Upcoming(now):
+				state = "soon"
+			}
+			fmt.Printf("%-10s %-7s %-20s %-28s every %s\n", d.Due.Format("2006-01-02"), state, d.Project, d.Chore.Name, d.Chore.Every)
+		}
+		return 0
+
+	case "add":
+		if len(args) < 4 || len(args) > 5 {
+			fmt.Fprintln(os.Stderr, choreUsage)
+			return 2
+		}
+		if _, _, err := chores.ParseEvery(args[3]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		start := time.Now().Format("2006-01-02")
+		if len(args) == 5 {
+			if _, err := time.Parse("2006-01-02", args[4]); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid date %q (want YYYY-MM-DD)\n", args[4])
+				return 2
+			}
+			start = args[4]
+		}
+
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.EnsureProject(args[1])
+			for i := range p.Chores {
+				if p.Chores[i].Name == args[2] {
+					p.Chores[i].Every = args[3]
+					p.Chores[i].Start = start
+					return
+				}
+			}
+			p.Chores = append(p.Chores, config.Chore{Name: args[2], Every: args[3], Start: start})
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "done":
+		if len(args) != 3 {
+			fmt.Fprintln(os.Stderr, choreUsage)
+			return 2
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if !hasChore(cfg, args[1], args[2]) {
+			fmt.Fprintf(os.Stderr, "No chore %q in %s\n", args[2], args[1])
+			return 1
+		}
+		err = chores.MarkDone(args[1], args[2], time.Now())
+		audit.Record(args[1], "chore", "done: "+args[2], err)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "rm":
+		if len(args) != 3 {
+			fmt.Fprintln(os.Stderr, choreUsage)
+			return 2
+		}
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.Project(args[1])
+			var kept []config.Chore
+			for _, c := range p.Chores {
+				if c.Name != args[2] {
+					kept = append(kept, c)
+				}
+			}
+			p.Chores = kept
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "history":
+		done, err := chores.History()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		for i := len(done) - 1; i >= 0; i-- {
+			c := done[i]
+			if len(args) > 1 && c.Project != args[1] {
+				continue
+			}
+			fmt.Printf("%s  %-20s %s\n", c.At.Format("2006-01-02 15:04"), c.Project, c.Chore)
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, choreUsage)
+	return 2
+}
+
+func hasChore(cfg *config.Config, project, name string) bool {
+	for _, c := range cfg.Project(project).Chores {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/invoice"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+)
+
+const clientUsage = `Usage: mc client <command>
+
+  list                                        Show clients per project
+  set <project> <client> <rate> [cadence]     Bill a project's time to a client
+  rm <project>                                Remove a project's client
+
+Rate is hourly (e.g. 150 or 87.50). Cadence: weekly, biweekly, monthly (default)`
+
+// runClient implements `mc client`
+func runClient(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		var names []string
+		for name, p := range cfg.Projects {
+			if p != nil && p.Client != nil {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			fmt.Println("No clients configured.")
+			return 0
+		}
+		for _, name := range names {
+			c := cfg.Projects[name].Client
+			fmt.Printf("%-24s %-24s %10s/h  %s\n", name, c.Name, revenue.Decimal(c.RateCents), c.Cadence)
+		}
+		return 0
+
+	case "set":
+		if len(args) < 4 || len(args) > 5 {
+			fmt.Fprintln(os.Stderr, clientUsage)
+			return 2
+		}
+		rate, err := invoice.ParseCents(args[3])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		cadence := config.CadenceMonthly
+		if len(args) == 5 {
+			cadence = args[4]
+		}
+		switch cadence {
+		case config.CadenceWeekly, config.CadenceBiweekly, config.CadenceMonthly:
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown cadence %q\n\n%s\n", cadence, clientUsage)
+			return 2
+		}
+
+		err = updateConfig(args[1], func(cfg *config.Config) {
+			cfg.EnsureProject(args[1]).Client = &config.Client{Name: args[2], RateCents: rate, Cadence: cadence}
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "rm":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, clientUsage)
+			return 2
+		}
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			if p, ok := cfg.Projects[args[1]]; ok && p != nil {
+				p.Client = nil
+			}
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, clientUsage)
+	return 2
+}
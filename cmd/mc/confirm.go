@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/totp"
+)
+
+const confirmUsage = `Usage: mc confirm [--profile <name>] <command>
+
+  show                        Show the confirmation policy in effect
+  require <action>...         Ask for a second factor before these actions
+  allow <action>...           Stop asking for one
+  method phrase [text]        Type a phrase (default: the project name)
+  method totp                 Enter a code from an authenticator app
+  totp-setup                  Generate the authenticator secret (tokens.totp)
+
+Actions: deploy, push, merge, dispatch, publish, rollback, rebase,
+cherry-pick, branch-cleanup, or "*" for everything the policy can hold.
+--profile edits that profile's policy, which replaces the global one
+while the profile is active ("profile" in config, or MC_PROFILE).`
+
+// runConfirm implements `mc confirm`
+func runConfirm(args []string) int {
+	profile := ""
+	if len(args) >= 2 && args[0] == "--profile" {
+		profile, args = args[1], args[2:]
+	}
+	if len(args) == 0 {
+		args = []string{"show"}
+	}
+
+	// policy returns the policy being edited, creating it
+	policy := func(cfg *config.Config) *config.Confirm {
+		if profile == "" {
+			if cfg.Confirm == nil {
+				cfg.Confirm = &config.Confirm{}
+			}
+			return cfg.Confirm
+		}
+		if cfg.Profiles == nil {
+			cfg.Profiles = make(map[string]*config.Profile)
+		}
+		p := cfg.Profiles[profile]
+		if p == nil {
+			p = &config.Profile{}
+			cfg.Profiles[profile] = p
+		}
+		if p.Confirm == nil {
+			p.Confirm = &config.Confirm{}
+		}
+		return p.Confirm
+	}
+
+	var edit func(*config.Config)
+	switch args[0] {
+	case "show":
+		return showConfirm()
+
+	case "require", "allow":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, confirmUsage)
+			return 2
+		}
+		actions := args[1:]
+		edit = func(cfg *config.Config) {
+			c := policy(cfg)
+			kept := []string{}
+			for _, a := range c.Actions {
+				if !slices.Contains(actions, a) {
+					kept = append(kept, a)
+				}
+			}
+			if args[0] == "require" {
+				kept = append(kept, actions...)
+			}
+			c.Actions = kept
+		}
+
+	case "method":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, confirmUsage)
+			return 2
+		}
+		method, phrase := args[1], strings.Join(args[2:], " ")
+		if method != config.ConfirmPhrase && (method != config.ConfirmTOTP || phrase != "") {
+			fmt.Fprintln(os.Stderr, confirmUsage)
+			return 2
+		}
+		edit = func(cfg *config.Config) {
+			c := policy(cfg)
+			c.Method, c.Phrase = method, phrase
+		}
+
+	case "totp-setup":
+		secret, err := totp.NewSecret()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		err = updateConfig("", func(cfg *config.Config) {
+			if cfg.Tokens == nil {
+				cfg.Tokens = make(map[string]string)
+			}
+			cfg.Tokens["totp"] = secret
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		host, _ := os.Hostname()
+		fmt.Println("Add this to your authenticator app (paste the link into a QR generator, or enter the key):")
+		fmt.Println()
+		fmt.Println("  " + totp.URI(secret, host))
+		fmt.Println("  Key: " + secret)
+		fmt.Println()
+		fmt.Println("Then turn it on with: mc confirm method totp")
+		return 0
+
+	default:
+		fmt.Fprintln(os.Stderr, confirmUsage)
+		return 2
+	}
+
+	if err := updateConfig("", edit); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// showConfirm prints the global and per-profile policies, marking the one
+// in effect
+func showConfirm() int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	active := cfg.ConfirmPolicy()
+
+	show := func(label string, c *config.Confirm) {
+		mark := " "
+		if c == active {
+			mark = "*"
+		}
+		if c == nil || len(c.Actions) == 0 {
+			fmt.Printf("%s %-20s nothing held\n", mark, label)
+			return
+		}
+		how := "type the project name"
+		switch {
+		case c.Method == config.ConfirmTOTP:
+			how = "TOTP code"
+			if cfg.Token("totp") == "" {
+				how += " (not set up: mc confirm totp-setup)"
+			}
+		case c.Phrase != "":
+			how = fmt.Sprintf("type %q", c.Phrase)
+		}
+		fmt.Printf("%s %-20s %s: %s\n", mark, label, strings.Join(c.Actions, ", "), how)
+	}
+
+	show("global", cfg.Confirm)
+	var names []string
+	for name, p := range cfg.Profiles {
+		if p != nil && p.Confirm != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		show("profile "+name, cfg.Profiles[name].Confirm)
+	}
+	if p := cfg.ActiveProfile(); p != "" {
+		fmt.Printf("Active profile: %s\n", p)
+	}
+	return 0
+}
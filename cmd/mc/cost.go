@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/cost"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+)
+
+const costUsage = `Usage: mc cost <command>
+
+  list                          Show cost sources and this month's spend
+  add <project> <kind> <id>     Attach a cloud resource to a project
+  rm <project> <kind> <id>      Detach a cloud resource
+
+Kinds: vercel (project, or team/project), fly (app name, estimated from
+       running machines), aws (cost allocation tag "key=value", or a
+       value for the "project" tag)
+Tokens: tokens.vercel / tokens.fly in ~/.hustlemc/config.json,
+        or MC_VERCEL_TOKEN / MC_FLY_TOKEN; aws uses the aws CLI's credentials`
+
+// runCost implements `mc cost`
+func runCost(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		var names []string
+		for name := range cfg.Projects {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		found := false
+		total := 0
+		for _, name := range names {
+			p := cfg.Projects[name]
+			if len(p.Costs) == 0 {
+				continue
+			}
+			found = true
+			report, _ := cost.ForProject(cfg, name, time.Now())
+			cents, estimate := 0, ""
+			if report != nil {
+				cents = report.TotalCents
+				if report.Estimated() {
+					estimate = " (estimated)"
+				}
+			}
+			total += cents
+			fmt.Printf("%s  %s this month%s\n", name, revenue.FormatCents(cents), estimate)
+			if report == nil {
+				continue
+			}
+			for _, src := range report.Sources {
+				line := fmt.Sprintf("  %-8s %-32s %s", src.Kind, src.ID, revenue.FormatCents(src.Cents))
+				if src.Err != "" {
+					line += "  error: " + src.Err
+				}
+				fmt.Println(line)
+			}
+		}
+		if !found {
+			fmt.Println("No cost sources configured.")
+			return 0
+		}
+		fmt.Printf("Total  %s this month\n", revenue.FormatCents(total))
+		return 0
+
+	case "add", "rm":
+		if len(args) != 4 {
+			fmt.Fprintln(os.Stderr, costUsage)
+			return 2
+		}
+		src := config.CostSource{Kind: args[2], ID: args[3]}
+		switch src.Kind {
+		case config.CostVercel, config.CostFly, config.CostAWS:
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown kind %q\n\n%s\n", src.Kind, costUsage)
+			return 2
+		}
+
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.EnsureProject(args[1])
+			var kept []config.CostSource
+			for _, existing := range p.Costs {
+				if existing != src {
+					kept = append(kept, existing)
+				}
+			}
+			if args[0] == "add" {
+				kept = append(kept, src)
+			}
+			p.Costs = kept
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, costUsage)
+	return 2
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/estimate"
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+)
+
+const estimateUsage = `Usage: mc estimate <command>
+
+  list [project]                    Show estimates vs tracked time
+  set <project> <task> <hours>      Estimate a milestone or issue ("#12")
+  done <project> <task>             Mark a task finished (counts toward accuracy)
+  rm <project> <task>               Remove an estimate
+
+Track time against a task with: mc timer start <project> <task>`
+
+// runEstimate implements `mc estimate`
+func runEstimate(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		entries, _ := timelog.Load()
+
+		var names []string
+		for name, p := range cfg.Projects {
+			if p != nil && len(p.Estimates) > 0 && (len(args) < 2 || name == args[1]) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			fmt.Println("No estimates recorded.")
+			return 0
+		}
+		for _, name := range names {
+			s := estimate.ForProject(cfg, entries, name)
+			if s.Samples > 0 {
+				fmt.Printf("%s  accuracy %.0f%% over %d tasks (actual/estimate %.2fx)\n", name, s.Accuracy*100, s.Samples, s.Bias)
+			} else {
+				fmt.Printf("%s  accuracy -\n", name)
+			}
+			for _, t := range s.Tasks {
+				mark := " "
+				if t.Done {
+					mark = "✔"
+				}
+				fmt.Printf("  %s %-28s %7.1fh est %7.1fh actual\n", mark, t.Name, t.Estimate.Hours(), t.Actual.Hours())
+			}
+		}
+		return 0
+
+	case "set":
+		if len(args) != 4 {
+			fmt.Fprintln(os.Stderr, estimateUsage)
+			return 2
+		}
+		hours, err := strconv.ParseFloat(args[3], 64)
+		if err != nil || hours <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid hours %q\n", args[3])
+			return 2
+		}
+		err = updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.EnsureProject(args[1])
+			for i := range p.Estimates {
+				if p.Estimates[i].Task == args[2] {
+					p.Estimates[i].Hours = hours
+					return
+				}
+			}
+			p.Estimates = append(p.Estimates, config.Estimate{Task: args[2], Hours: hours})
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "done", "rm":
+		if len(args) != 3 {
+			fmt.Fprintln(os.Stderr, estimateUsage)
+			return 2
+		}
+		found := false
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.Project(args[1])
+			var kept []config.Estimate
+			for _, est := range p.Estimates {
+				if est.Task == args[2] {
+					found = true
+					if args[0] == "rm" {
+						continue
+					}
+					est.Done = true
+				}
+				kept = append(kept, est)
+			}
+			p.Estimates = kept
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if !found {
+			fmt.Fprintf(os.Stderr, "No estimate for %q in %s\n", args[2], args[1])
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, estimateUsage)
+	return 2
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/michaelmonetized/mission-control/pkg/focus"
+)
+
+const focusUsage = `Usage: mc focus [--source <name>] [dir]
+       mc focus hooks
+
+Reports to the daemon relay (apps/daemon) that you're now working in dir
+(default: the current directory), so a TUI following focus (a, or
+focus_follow in config) selects that project. Hooks call the lighter
+bin/mc-focus script, which does the same; "hooks" prints ones to copy into
+your config. MC_DAEMON_URL overrides the daemon's address.`
+
+const focusHooks = `# ~/.tmux.conf
+set -g focus-events on
+set-hook -g pane-focus-in 'run-shell -b "mc focus --source tmux \"#{pane_current_path}\""'
+set-hook -g after-select-window 'run-shell -b "mc focus --source tmux \"#{pane_current_path}\""'
+
+-- ~/.config/nvim/init.lua
+vim.api.nvim_create_autocmd({ "VimEnter", "DirChanged", "FocusGained" }, {
+  callback = function()
+    vim.fn.jobstart({ "mc-focus", "--source", "nvim", vim.fn.getcwd() })
+  end,
+})
+
+# ~/.zshrc
+chpwd() { mc-focus --source shell "$PWD" &! }`
+
+// runFocus implements `mc focus`
+func runFocus(args []string) int {
+	if len(args) == 1 && args[0] == "hooks" {
+		fmt.Println(focusHooks)
+		return 0
+	}
+	source := ""
+	if len(args) >= 2 && args[0] == "--source" {
+		source, args = args[1], args[2:]
+	}
+	if len(args) > 1 {
+		fmt.Fprintln(os.Stderr, focusUsage)
+		return 2
+	}
+
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	if err := focus.Set(dir, source); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
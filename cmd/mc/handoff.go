@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/handoff"
+	"github.com/michaelmonetized/mission-control/pkg/openclaw"
+)
+
+const handoffUsage = `Usage: mc handoff <project> [output-file]
+
+Writes a markdown handoff document (default: HANDOFF-<project>-<date>.md
+in the current directory): an architecture summary drafted by OpenClaw
+when it's running, setup steps checked against this machine, open
+issues, environment variable names, and a deploy runbook. Use - to
+print it instead.`
+
+// runHandoff implements `mc handoff`
+func runHandoff(args []string) int {
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprintln(os.Stderr, handoffUsage)
+		return 2
+	}
+	name := args[0]
+	dir, ok := projectDirs()[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown project %q\n", name)
+		return 1
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	var complete func(string) (string, error)
+	if client, err := openclaw.NewClientFromConfig(); err == nil {
+		complete = client.Complete
+	}
+	doc := handoff.Assemble(cfg, name, dir, complete).Markdown()
+
+	out := handoff.Filename(name, time.Now())
+	if len(args) == 2 {
+		out = args[1]
+	}
+	if out == "-" {
+		fmt.Print(doc)
+		return 0
+	}
+	if err := os.WriteFile(out, []byte(doc), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Wrote %s\n", out)
+	return 0
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/invoice"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+)
+
+const invoiceUsage = `Usage: mc invoice [YYYY-MM] [output-dir]
+
+Writes a draft invoice CSV per client from the month's tracked time
+(default: last month, into the current directory). Clients are set
+with mc client set.`
+
+// runInvoice implements `mc invoice`
+func runInvoice(args []string) int {
+	if len(args) > 2 || (len(args) > 0 && (args[0] == "-h" || args[0] == "--help")) {
+		fmt.Fprintln(os.Stderr, invoiceUsage)
+		return 2
+	}
+
+	// Invoices usually go out for the month that just ended
+	month := time.Now().AddDate(0, -1, 0)
+	if len(args) > 0 {
+		t, err := time.ParseInLocation("2006-01", args[0], time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid month %q (want YYYY-MM)\n", args[0])
+			return 2
+		}
+		month = t
+	}
+	outDir := "."
+	if len(args) > 1 {
+		outDir = args[1]
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	entries, err := timelog.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	invoices := invoice.Drafts(cfg, entries, month)
+	if len(invoices) == 0 {
+		fmt.Printf("No billable time for %s.\n", month.Format("January 2006"))
+		return 0
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, inv := range invoices {
+		path := filepath.Join(outDir, inv.Filename())
+		f, err := os.Create(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		err = inv.WriteCSV(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			return 1
+		}
+		fmt.Printf("%-24s %7.2fh  %10s  %s\n", inv.Client, inv.TotalHours(), revenue.Decimal(inv.TotalCents()), path)
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+const localeUsage = `Usage: mc locale [code|auto]
+
+  (no args)   Show the UI language and the ones available
+  <code>      Use a language, e.g. es
+  auto        Follow $LANG again
+
+Add or override translations in ~/.hustlemc/locales/<code>.json.`
+
+// runLocale implements `mc locale`
+func runLocale(args []string) int {
+	switch len(args) {
+	case 0:
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		source := "config"
+		if cfg.Locale == "" {
+			source = "environment"
+		}
+		fmt.Printf("Locale: %s (from %s)\n", i18n.Detect(cfg.Locale), source)
+		fmt.Printf("Available: %s\n", strings.Join(i18n.Available(), ", "))
+		return 0
+
+	case 1:
+		code := args[0]
+		if code == "auto" {
+			code = ""
+		} else if err := i18n.SetLocale(code); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		err := updateConfig("", func(cfg *config.Config) {
+			cfg.Locale = code
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, localeUsage)
+	return 2
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+const loginUsage = `Usage: mc login [--client-id <id>]
+
+Logs in to GitHub with the OAuth device flow: enter the code shown at
+github.com/login/device and the token is saved to the keychain (macOS)
+or the secret service (secret-tool elsewhere), falling back to the
+config file. The native GitHub client uses it from then on.
+
+Device flow needs an OAuth app of your own with it enabled; --client-id
+saves the app's client ID as github_client_id.`
+
+// runLogin implements `mc login`
+func runLogin(args []string) int {
+	switch {
+	case len(args) == 2 && args[0] == "--client-id":
+		id := args[1]
+		if err := updateConfig("", func(cfg *config.Config) { cfg.GitHubClientID = id }); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	case len(args) != 0:
+		fmt.Fprintln(os.Stderr, loginUsage)
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	code, err := github.RequestDeviceCode(cfg.GitHubClientID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Enter %s at %s (expires in %s)\n", code.UserCode, code.VerificationURI, time.Duration(code.ExpiresIn)*time.Second)
+	fmt.Println("Waiting for approval...")
+
+	token, err := github.PollToken(cfg.GitHubClientID, code)
+	if err == nil {
+		var where string
+		if where, err = github.SaveToken(token); err == nil {
+			fmt.Println("Logged in; token saved to " + where)
+		}
+	}
+	audit.Record("", "login", "github", err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
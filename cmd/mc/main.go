@@ -5,6 +5,10 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/selfupdate"
 	"github.com/michaelmonetized/mission-control/pkg/ui"
 )
 
@@ -12,8 +16,59 @@ func main() {
 	// Check for subcommands first (fall back to shell scripts)
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
-		case "tui", "ui", "":
+		case "tui", "ui", "", "--dry-run":
 			// Continue to TUI
+		case "revenue":
+			os.Exit(runRevenue(os.Args[2:]))
+		case "cost", "costs":
+			os.Exit(runCost(os.Args[2:]))
+		case "client":
+			os.Exit(runClient(os.Args[2:]))
+		case "invoice":
+			os.Exit(runInvoice(os.Args[2:]))
+		case "estimate":
+			os.Exit(runEstimate(os.Args[2:]))
+		case "timer":
+			os.Exit(runTimer(os.Args[2:]))
+		case "todo":
+			os.Exit(runTodo(os.Args[2:]))
+		case "chore":
+			os.Exit(runChore(os.Args[2:]))
+		case "audit":
+			os.Exit(runAudit(os.Args[2:]))
+		case "autofetch":
+			os.Exit(runAutofetch(os.Args[2:]))
+		case "service", "services":
+			os.Exit(runService(os.Args[2:]))
+		case "ports":
+			os.Exit(runPorts(os.Args[2:]))
+		case "secrets", "secret":
+			os.Exit(runSecrets(os.Args[2:]))
+		case "sbom":
+			os.Exit(runSBOM(os.Args[2:]))
+		case "confirm":
+			os.Exit(runConfirm(os.Args[2:]))
+		case "handoff":
+			os.Exit(runHandoff(os.Args[2:]))
+		case "login":
+			os.Exit(runLogin(os.Args[2:]))
+		case "focus":
+			os.Exit(runFocus(os.Args[2:]))
+		case "maintenance":
+			os.Exit(runMaintenance(os.Args[2:]))
+		case "uptime":
+			os.Exit(runUptime(os.Args[2:]))
+		case "export-state":
+			os.Exit(runExportState(os.Args[2:]))
+		case "import-state":
+			os.Exit(runImportState(os.Args[2:]))
+		case "locale":
+			os.Exit(runLocale(os.Args[2:]))
+		case "upgrade":
+			os.Exit(runUpgrade(os.Args[2:]))
+		case "version", "--version":
+			fmt.Println("mc", selfupdate.Version)
+			os.Exit(0)
 		default:
 			// Delegate to shell scripts
 			fmt.Println("Use shell scripts for CLI commands: mc-discover, mc-git-status, etc.")
@@ -22,9 +77,27 @@ func main() {
 		}
 	}
 
+	// UI language: config, then the environment
+	cfg, _ := config.Load()
+	if err := i18n.SetLocale(i18n.Detect(cfg.Locale)); err != nil && cfg.Locale != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// --dry-run (or MC_DRY_RUN=1) starts with actions previewed, not run
+	dryRun := os.Getenv("MC_DRY_RUN") != ""
+	for _, arg := range os.Args[1:] {
+		if arg == "--dry-run" {
+			dryRun = true
+		}
+	}
+	// Replayed projects aren't on disk, so actions can only be previewed
+	if fixture.Replaying() {
+		dryRun = true
+	}
+
 	// Start TUI
 	p := tea.NewProgram(
-		ui.NewModel(),
+		ui.NewModel().WithDryRun(dryRun),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
@@ -33,4 +106,7 @@ func main() {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+	if err := fixture.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "fixture: %v\n", err)
+	}
 }
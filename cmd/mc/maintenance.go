@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/maintenance"
+	"github.com/michaelmonetized/mission-control/pkg/snooze"
+)
+
+const maintenanceUsage = `Usage: mc maintenance <command>
+
+  list                                        Show declared windows
+  add <project|all> <until> [--from <when>] [reason]
+                                              Declare a window (from now by default)
+  end <project|all>                           End a project's (or the global) windows now
+
+Times are durations (2h, 1d), "tomorrow", a weekday, or "YYYY-MM-DD [HH:MM]";
+<until> is counted from the window's start. During a window uptime
+failures don't count as downtime, deploy-failure and down alerts are
+muted, and GitHub notifications for the project are held back. Windows
+show on incident timelines and in what changed since you last looked.`
+
+// runMaintenance implements `mc maintenance`
+func runMaintenance(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	now := time.Now()
+
+	switch args[0] {
+	case "list", "ls":
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, maintenanceUsage)
+			return 2
+		}
+		windows, err := maintenance.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(windows) == 0 {
+			fmt.Println("No maintenance windows. Declare one with mc maintenance add <project|all> <until>.")
+			return 0
+		}
+		for _, w := range windows {
+			state := "ended"
+			switch {
+			case w.Active(now):
+				state = "ACTIVE"
+			case now.Before(w.Start):
+				state = "planned"
+			}
+			fmt.Printf("  %-8s %s\n", state, w.Label())
+		}
+		return 0
+
+	case "add":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, maintenanceUsage)
+			return 2
+		}
+		project := maintenanceTarget(args[1])
+		until, rest := args[2], args[3:]
+		start := now
+		if len(rest) >= 2 && rest[0] == "--from" {
+			t, err := snooze.ParseUntil(rest[1], now)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 2
+			}
+			start, rest = t, rest[2:]
+		}
+		end, err := snooze.ParseUntil(until, start)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		if _, ok := projectDirs()[project]; project != "" && !ok {
+			fmt.Fprintf(os.Stderr, "Warning: no project named %q here\n", project)
+		}
+
+		w := maintenance.Window{Project: project, Start: start, End: end, Reason: strings.Join(rest, " ")}
+		err = maintenance.Add(w)
+		audit.Record(project, "maintenance", "declared "+w.Label(), err)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println("Maintenance: " + w.Label())
+		return 0
+
+	case "end":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, maintenanceUsage)
+			return 2
+		}
+		project := maintenanceTarget(args[1])
+		n, err := maintenance.End(project, now)
+		audit.Record(project, "maintenance", "ended", err)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Ended %d window(s)\n", n)
+		return 0
+
+	default:
+		fmt.Fprintln(os.Stderr, maintenanceUsage)
+		return 2
+	}
+}
+
+// maintenanceTarget maps "all" to the project of global windows
+func maintenanceTarget(arg string) string {
+	if arg == "all" {
+		return ""
+	}
+	return arg
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/ports"
+)
+
+const portsUsage = `Usage: mc ports
+
+Lists ports claimed by services (their port setting or local health check)
+and the processes listening on them. ! marks a port two projects want, or
+one taken by something other than the project claiming it.`
+
+// runPorts implements `mc ports`
+func runPorts(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, portsUsage)
+		return 2
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	listeners, err := ports.Listening(projectDirs())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	rows := ports.Table(ports.Claims(cfg), listeners)
+	if len(rows) == 0 {
+		fmt.Println("No ports claimed or listening.")
+		return 0
+	}
+	for _, r := range rows {
+		mark := " "
+		if r.Conflict() {
+			mark = "!"
+		}
+		var claims, procs []string
+		for _, c := range r.Claims {
+			claims = append(claims, c.Project+"/"+c.Service)
+		}
+		for _, l := range r.Listeners {
+			procs = append(procs, l.String())
+		}
+		claimed, listening := strings.Join(claims, ", "), strings.Join(procs, ", ")
+		if claimed == "" {
+			claimed = "-"
+		}
+		if listening == "" {
+			listening = "free"
+		}
+		fmt.Printf("%s %-6d %-30s %s\n", mark, r.Port, claimed, listening)
+	}
+	return 0
+}
+
+// projectDirs maps discovered project names to their directories
+func projectDirs() map[string]string {
+	dirs := make(map[string]string)
+	projects, err := discover.LoadProjects()
+	if err != nil {
+		return dirs
+	}
+	home, _ := os.UserHomeDir()
+	for _, p := range projects {
+		dir := p.Path
+		if strings.HasPrefix(dir, "~/") {
+			dir = home + dir[1:]
+		}
+		dirs[p.Name] = dir
+	}
+	return dirs
+}
+
+// warnPortConflicts prints the ports a project's services would collide on
+func warnPortConflicts(cfg *config.Config, project string, svcs []config.Service) {
+	listeners, _ := ports.Listening(projectDirs())
+	for _, c := range ports.Check(project, svcs, ports.Claims(cfg), listeners) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", c)
+	}
+}
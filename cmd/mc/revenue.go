@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+)
+
+const revenueUsage = `Usage: mc revenue <command>
+
+  list                          Show revenue sources and this month's income
+  add <project> <kind> <id>     Attach a revenue source to a project
+  rm <project> <kind> <id>      Detach a revenue source
+
+Kinds: github_sponsors (login), stripe (product ID), gumroad (product ID)
+Tokens: tokens.stripe / tokens.gumroad in ~/.hustlemc/config.json,
+        or MC_STRIPE_TOKEN / MC_GUMROAD_TOKEN`
+
+// runRevenue implements `mc revenue`
+func runRevenue(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		var names []string
+		for name := range cfg.Projects {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		found := false
+		for _, name := range names {
+			p := cfg.Projects[name]
+			if len(p.Revenue) == 0 {
+				continue
+			}
+			found = true
+			report, _ := revenue.ForProject(cfg, name, time.Now())
+			total := 0
+			if report != nil {
+				total = report.TotalCents
+			}
+			fmt.Printf("%s  %s this month\n", name, revenue.FormatCents(total))
+			for _, src := range p.Revenue {
+				fmt.Printf("  %-16s %s\n", src.Kind, src.ID)
+			}
+		}
+		if !found {
+			fmt.Println("No revenue sources configured.")
+		}
+		return 0
+
+	case "add", "rm":
+		if len(args) != 4 {
+			fmt.Fprintln(os.Stderr, revenueUsage)
+			return 2
+		}
+		src := config.RevenueSource{Kind: args[2], ID: args[3]}
+		switch src.Kind {
+		case config.RevenueGitHubSponsors, config.RevenueStripe, config.RevenueGumroad:
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown kind %q\n\n%s\n", src.Kind, revenueUsage)
+			return 2
+		}
+
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.EnsureProject(args[1])
+			var kept []config.RevenueSource
+			for _, existing := range p.Revenue {
+				if existing != src {
+					kept = append(kept, existing)
+				}
+			}
+			if args[0] == "add" {
+				kept = append(kept, src)
+			}
+			p.Revenue = kept
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, revenueUsage)
+	return 2
+}
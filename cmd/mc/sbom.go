@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/sbom"
+)
+
+const sbomUsage = `Usage: mc sbom <command>
+
+  generate <project>                  Generate the SBOM and scan it for known vulnerabilities
+  export <project> [cyclonedx|spdx]   Print the SBOM (CycloneDX by default), generating it if needed
+  licenses [project]                  Components by license, with those the policy rules out flagged
+  vulns [project]                     Known vulnerabilities in the SBOM's components
+
+SBOMs come from syft, cdxgen, npm sbom, or cyclonedx-gomod, whichever is
+installed first, and are cached in the project's .hustlemc directory.
+grype or osv-scanner scans them. The license policy denies copyleft
+unless .mc.toml says otherwise:
+
+  [licenses]
+  deny = ["copyleft", "weak-copyleft"]
+  allow = ["LGPL-*"]`
+
+// runSBOM implements `mc sbom`
+func runSBOM(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, sbomUsage)
+		return 2
+	}
+	dirs := projectDirs()
+
+	switch args[0] {
+	case "generate", "gen":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, sbomUsage)
+			return 2
+		}
+		dir, ok := sbomProject(dirs, args[1])
+		if !ok {
+			return 2
+		}
+		info, err := discover.GenerateSBOM(dir)
+		if info == nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("%s: %d components (%s)\n", args[1], len(info.SBOM.Components), info.SBOM.Tool)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Vulnerability scan failed: %v\n", err)
+			return 1
+		case info.Scan == nil:
+			fmt.Println("No vulnerability scanner found (install grype or osv-scanner)")
+		default:
+			fmt.Printf("%d known vulnerabilities (%s)\n", len(info.Scan.Vulns), info.Scan.Scanner)
+		}
+		return 0
+
+	case "export":
+		if len(args) < 2 || len(args) > 3 {
+			fmt.Fprintln(os.Stderr, sbomUsage)
+			return 2
+		}
+		format := sbom.CycloneDX
+		if len(args) == 3 {
+			format = strings.ToLower(args[2])
+		}
+		if format != sbom.CycloneDX && format != sbom.SPDX {
+			fmt.Fprintf(os.Stderr, "Unknown format %q (cyclonedx or spdx)\n", format)
+			return 2
+		}
+		dir, ok := sbomProject(dirs, args[1])
+		if !ok {
+			return 2
+		}
+		info, err := cachedSBOM(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		var data []byte
+		if format == sbom.SPDX {
+			data, err = info.SBOM.SPDXJSON()
+		} else {
+			data, err = discover.SBOMDocument(dir)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		os.Stdout.Write(data)
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			fmt.Println()
+		}
+		return 0
+
+	case "licenses", "license":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, sbomUsage)
+			return 2
+		}
+		return forEachSBOM(dirs, args[1:], printLicenses)
+
+	case "vulns", "vulnerabilities":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, sbomUsage)
+			return 2
+		}
+		return forEachSBOM(dirs, args[1:], printVulns)
+	}
+
+	fmt.Fprintln(os.Stderr, sbomUsage)
+	return 2
+}
+
+// sbomProject looks up a project's directory, complaining when it's unknown
+func sbomProject(dirs map[string]string, name string) (string, bool) {
+	dir, ok := dirs[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown project %q\n", name)
+	}
+	return dir, ok
+}
+
+// cachedSBOM returns a project's SBOM, generating it when there is none
+// yet or its dependencies changed since
+func cachedSBOM(dir string) (*discover.SBOMInfo, error) {
+	info, err := discover.LoadSBOM(dir)
+	if err != nil || info == nil || info.Stale {
+		fmt.Fprintln(os.Stderr, "Generating SBOM...")
+		fresh, genErr := discover.GenerateSBOM(dir)
+		switch {
+		case fresh != nil:
+			return fresh, nil
+		case info != nil:
+			// Keep going with the one that's out of date
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", genErr)
+			return info, nil
+		}
+		return nil, genErr
+	}
+	return info, nil
+}
+
+// forEachSBOM prints the cached SBOM of one project, or of every project
+// that has one
+func forEachSBOM(dirs map[string]string, only []string, show func(name, dir string, info *discover.SBOMInfo) int) int {
+	if len(only) == 1 {
+		dir, ok := sbomProject(dirs, only[0])
+		if !ok {
+			return 2
+		}
+		info, err := cachedSBOM(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return show(only[0], dir, info)
+	}
+
+	var names []string
+	for name := range dirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	found, status := false, 0
+	for _, name := range names {
+		info, _ := discover.LoadSBOM(dirs[name])
+		if info == nil {
+			continue
+		}
+		found = true
+		status = max(status, show(name, dirs[name], info))
+	}
+	if !found {
+		fmt.Println("No SBOMs yet. Generate one with: mc sbom generate <project>")
+	}
+	return status
+}
+
+// printLicenses prints a project's components by license, flagged ones
+// first. It returns 1 when the policy rules any out, for CI.
+func printLicenses(name, dir string, info *discover.SBOMInfo) int {
+	report := info.SBOM.Report(discover.LicensePolicy(dir))
+	fmt.Printf("%s  (%d components, %d flagged)\n", name, len(info.SBOM.Components), report.Flagged())
+	for _, c := range report.Denied {
+		fmt.Printf("  ! %-40s %s\n", c.ID(), c.License())
+	}
+	for _, c := range report.Unlicensed {
+		fmt.Printf("  ? %-40s no license\n", c.ID())
+	}
+	for _, use := range report.Licenses {
+		fmt.Printf("    %-40s %-13s %d\n", use.License, use.Class, len(use.Components))
+	}
+	if len(report.Denied) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printVulns prints the known vulnerabilities in a project's components.
+// It returns 1 when any is critical or high.
+func printVulns(name, dir string, info *discover.SBOMInfo) int {
+	if info.Scan == nil {
+		fmt.Printf("%s  not scanned (install grype or osv-scanner, then mc sbom generate %s)\n", name, name)
+		return 0
+	}
+	scan := info.Scan
+	fmt.Printf("%s  (%d known vulnerabilities, %s, %s)\n", name, len(scan.Vulns), scan.Scanner, scan.ScannedAt.Format("2006-01-02"))
+	for _, v := range scan.Vulns {
+		severity := v.Severity
+		if severity == "" {
+			severity = "-"
+		}
+		fixed := ""
+		if v.FixedIn != "" {
+			fixed = "fixed in " + v.FixedIn
+		}
+		fmt.Printf("  %-8s %-20s %-36s %s\n", severity, v.ID, v.Package+"@"+v.Version, fixed)
+	}
+	if sev := scan.BySeverity(); sev[sbom.Critical]+sev[sbom.High] > 0 {
+		return 1
+	}
+	return 0
+}
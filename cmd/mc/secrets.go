@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/chores"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/secrets"
+)
+
+const secretsUsage = `Usage: mc secrets <command>
+
+  list [project]                          Show credentials, when they were rotated, and which are stale
+  rotated <project> <name> [YYYY-MM-DD]   Log that a credential was rotated (default: today)
+  policy [project] <interval>             Rotate every interval ("90d", "6m", quarterly; default 90d)
+  actions [project]                       GitHub Actions secrets and variables against what workflows use
+
+Rotation dates come from GitHub Actions secrets and Vercel environment
+variables (with tokens.vercel), plus rotations logged here for anything
+else: API keys, DB passwords, deploy tokens.`
+
+// runSecrets implements `mc secrets`
+func runSecrets(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, secretsUsage)
+			return 2
+		}
+		only := ""
+		if len(args) == 2 {
+			only = args[1]
+		}
+		return listSecrets(only)
+
+	case "actions":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, secretsUsage)
+			return 2
+		}
+		only := ""
+		if len(args) == 2 {
+			only = args[1]
+		}
+		return listActionsInventory(only)
+
+	case "rotated":
+		if len(args) < 3 || len(args) > 4 {
+			fmt.Fprintln(os.Stderr, secretsUsage)
+			return 2
+		}
+		at := time.Now()
+		if len(args) == 4 {
+			t, err := time.ParseInLocation("2006-01-02", args[3], time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid date %q (want YYYY-MM-DD)\n", args[3])
+				return 2
+			}
+			at = t
+		}
+		if err := secrets.Log(args[1], args[2], at); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Logged rotation of %s (%s) on %s\n", args[2], args[1], at.Format("2006-01-02"))
+		return 0
+
+	case "policy":
+		if len(args) < 2 || len(args) > 3 {
+			fmt.Fprintln(os.Stderr, secretsUsage)
+			return 2
+		}
+		project, every := "", args[len(args)-1]
+		if len(args) == 3 {
+			project = args[1]
+		}
+		if _, _, err := chores.ParseEvery(every); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid interval %q (e.g. 90d, 6m, quarterly)\n", every)
+			return 2
+		}
+		err := updateConfig(project, func(cfg *config.Config) {
+			if project == "" {
+				cfg.RotateEvery = every
+			} else {
+				cfg.EnsureProject(project).RotateEvery = every
+			}
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, secretsUsage)
+	return 2
+}
+
+// listSecrets prints each project's credentials, stale ones marked
+func listSecrets(only string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	log, err := secrets.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	dirs := projectDirs()
+	var names []string
+	for name := range dirs {
+		if only == "" || name == only {
+			names = append(names, name)
+		}
+	}
+	// Projects only known from the log still get listed
+	for _, r := range log {
+		if _, ok := dirs[r.Project]; !ok && (only == "" || r.Project == only) {
+			dirs[r.Project] = ""
+			names = append(names, r.Project)
+		}
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	found, stale := false, 0
+	for _, name := range names {
+		var reported []secrets.Secret
+		if dirs[name] != "" {
+			reported, _ = discover.ProviderSecrets(dirs[name])
+		}
+		inventory := secrets.Inventory(name, reported, log)
+		if len(inventory) == 0 {
+			continue
+		}
+		found = true
+		policy := secrets.Policy(cfg, name)
+		fmt.Printf("%s  (rotate every %s)\n", name, policy)
+		for _, s := range inventory {
+			mark, rotated := " ", "never"
+			if s.Stale(policy, now) {
+				mark = "!"
+				stale++
+			}
+			if !s.Rotated.IsZero() {
+				rotated = s.Rotated.Format("2006-01-02")
+			}
+			fmt.Printf("  %s %-32s %-7s %s\n", mark, s.Name, s.Source, rotated)
+		}
+	}
+	if !found {
+		fmt.Println("No secrets found. Log one with: mc secrets rotated <project> <name>")
+		return 0
+	}
+	if stale > 0 {
+		fmt.Printf("%d stale\n", stale)
+	}
+	return 0
+}
+
+// listActionsInventory prints each project's Actions secrets and variables
+// that workflows reference but aren't set (missing), or that are set but
+// nothing references (unused). A single project lists everything.
+func listActionsInventory(only string) int {
+	dirs := projectDirs()
+	if only != "" {
+		if _, ok := dirs[only]; !ok {
+			fmt.Fprintf(os.Stderr, "Unknown project %q\n", only)
+			return 2
+		}
+	}
+	var names []string
+	for name := range dirs {
+		if only == "" || name == only {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	missing, unused := 0, 0
+	for _, name := range names {
+		if _, err := discover.WorkflowRefs(dirs[name]); err != nil && only == "" {
+			continue // no workflows
+		}
+		entries, err := discover.ActionsInventory(dirs[name])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			continue
+		}
+		header := false
+		for _, e := range entries {
+			state := ""
+			switch {
+			case e.Missing():
+				state = "MISSING"
+				missing++
+			case e.Unused():
+				state = "unused"
+				unused++
+			case only == "":
+				continue
+			}
+			if !header {
+				fmt.Println(name)
+				header = true
+			}
+			where := strings.Join(e.Scopes, ",")
+			if where == "" {
+				where = "-"
+			}
+			updated := "-"
+			if !e.Updated.IsZero() {
+				updated = e.Updated.Format("2006-01-02")
+			}
+			fmt.Printf("  %-7s %-8s %-32s %-18s %-10s %s\n", state, e.Kind, e.Name, where, updated, strings.Join(e.Workflows, ", "))
+		}
+	}
+	fmt.Printf("%d missing, %d unused\n", missing, unused)
+	return 0
+}
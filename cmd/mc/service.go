@@ -0,0 +1,253 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+)
+
+const serviceUsage = `Usage: mc service <command>
+
+  list [project]                                     Show configured services
+  add <project> <name> [--dir d] [--health h]        Add a service (cmd runs with sh -c)
+      [--port n] <cmd>
+  rm <project> <name>                                Remove a service
+  up <project> [name...]                             Run services in the foreground with
+                                                     interleaved logs; Ctrl-C stops them
+
+Health is a URL to GET ("http://localhost:3000/health") or a port to dial ("5432").
+Port (or a local health check's port) is checked for conflicts before starting;
+see mc ports. In the TUI, F manages the selected project's services.`
+
+// serviceColors tell services apart in `mc service up` output
+var serviceColors = []string{"36", "33", "35", "32", "34", "31"}
+
+// runService implements `mc service`
+func runService(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, serviceUsage)
+			return 2
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		found := false
+		for _, name := range sortedProjects(cfg) {
+			if len(args) == 2 && name != args[1] {
+				continue
+			}
+			for _, s := range cfg.Project(name).Services {
+				found = true
+				extra := ""
+				if s.Dir != "" {
+					extra += "  dir=" + s.Dir
+				}
+				if s.Health != "" {
+					extra += "  health=" + s.Health
+				}
+				if s.Port != 0 {
+					extra += fmt.Sprintf("  port=%d", s.Port)
+				}
+				fmt.Printf("%-20s %-16s %s%s\n", name, s.Name, s.Command, extra)
+			}
+		}
+		if !found {
+			fmt.Println("No services configured.")
+		}
+		return 0
+
+	case "add":
+		fs := flag.NewFlagSet("add", flag.ContinueOnError)
+		dir := fs.String("dir", "", "directory relative to the project")
+		health := fs.String("health", "", "URL or port to check")
+		port := fs.Int("port", 0, "port the service listens on")
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, serviceUsage)
+			return 2
+		}
+		if err := fs.Parse(args[3:]); err != nil || fs.NArg() == 0 {
+			fmt.Fprintln(os.Stderr, serviceUsage)
+			return 2
+		}
+		svc := config.Service{Name: args[2], Command: strings.Join(fs.Args(), " "), Dir: *dir, Health: *health, Port: *port}
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.EnsureProject(args[1])
+			for i := range p.Services {
+				if p.Services[i].Name == svc.Name {
+					p.Services[i] = svc
+					return
+				}
+			}
+			p.Services = append(p.Services, svc)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "rm":
+		if len(args) != 3 {
+			fmt.Fprintln(os.Stderr, serviceUsage)
+			return 2
+		}
+		removed := false
+		err := updateConfig(args[1], func(cfg *config.Config) {
+			p := cfg.Project(args[1])
+			for i := range p.Services {
+				if p.Services[i].Name == args[2] {
+					p.Services = append(p.Services[:i], p.Services[i+1:]...)
+					removed = true
+					return
+				}
+			}
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if !removed {
+			fmt.Fprintf(os.Stderr, "No service %q in %s\n", args[2], args[1])
+			return 1
+		}
+		return 0
+
+	case "up":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, serviceUsage)
+			return 2
+		}
+		return serviceUp(args[1], args[2:])
+
+	default:
+		fmt.Fprintln(os.Stderr, serviceUsage)
+		return 2
+	}
+}
+
+// serviceUp runs a project's services (or the named ones) until they all
+// exit or the user interrupts, printing their output prefixed by name
+func serviceUp(project string, names []string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	svcs := cfg.Project(project).Services
+	if len(names) > 0 {
+		var picked []config.Service
+		for _, name := range names {
+			found := false
+			for _, s := range svcs {
+				if s.Name == name {
+					picked = append(picked, s)
+					found = true
+				}
+			}
+			if !found {
+				fmt.Fprintf(os.Stderr, "No service %q in %s\n", name, project)
+				return 1
+			}
+		}
+		svcs = picked
+	}
+	if len(svcs) == 0 {
+		fmt.Fprintf(os.Stderr, "No services configured for %s (mc service add)\n", project)
+		return 1
+	}
+	dir, err := projectDir(project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	width := 0
+	colors := make(map[string]string)
+	for i, s := range svcs {
+		width = max(width, len(s.Name))
+		colors[s.Name] = serviceColors[i%len(serviceColors)]
+	}
+	m := services.NewManager()
+	m.OnLine = func(l services.Line) {
+		fmt.Printf("\033[%sm%-*s |\033[0m %s\n", colors[l.Service], width, l.Service, l.Text)
+	}
+
+	warnPortConflicts(cfg, project, svcs)
+	if err := m.StartAll(project, dir, svcs); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sigs:
+			fmt.Fprintln(os.Stderr, "Stopping services...")
+			if err := m.StopAll(project); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+			return 0
+		case <-ticker.C:
+			if m.Running(project) > 0 {
+				continue
+			}
+			// Everything ended on its own
+			code := 0
+			for _, s := range m.Statuses(project, svcs) {
+				if s.State == services.Failed {
+					fmt.Fprintf(os.Stderr, "%s failed: %v\n", s.Service.Name, s.Err)
+					code = 1
+				}
+			}
+			return code
+		}
+	}
+}
+
+// sortedProjects returns the names of projects with settings, sorted
+func sortedProjects(cfg *config.Config) []string {
+	names := make([]string, 0, len(cfg.Projects))
+	for name := range cfg.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// projectDir finds a discovered project's directory by name
+func projectDir(name string) (string, error) {
+	projects, err := discover.LoadProjects()
+	if err != nil {
+		return "", err
+	}
+	for _, p := range projects {
+		if p.Name == name {
+			if strings.HasPrefix(p.Path, "~/") {
+				home, _ := os.UserHomeDir()
+				return home + p.Path[1:], nil
+			}
+			return p.Path, nil
+		}
+	}
+	return "", fmt.Errorf("no project named %q (run mc-discover?)", name)
+}
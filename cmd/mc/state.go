@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/state"
+)
+
+const exportStateUsage = `Usage: mc export-state [--with-tokens] [file]
+
+Bundles everything under ~/.hustlemc (config, snoozes, inbox, chores, time
+log, audit log, uptime history, locale overrides, ...) except caches mc
+rebuilds into one archive (default mc-state-YYYY-MM-DD.tar.gz). API tokens
+are left out unless --with-tokens is given.`
+
+const importStateUsage = `Usage: mc import-state <file>
+
+Restores a bundle from mc export-state. Replaced files are backed up
+under ~/.hustlemc first; tokens already on this machine are kept when the
+bundle has none.`
+
+// runExportState implements `mc export-state`
+func runExportState(args []string) int {
+	withTokens := false
+	path := ""
+	for _, arg := range args {
+		switch {
+		case arg == "--with-tokens":
+			withTokens = true
+		case strings.HasPrefix(arg, "-") || path != "":
+			fmt.Fprintln(os.Stderr, exportStateUsage)
+			return 2
+		default:
+			path = arg
+		}
+	}
+	if path == "" {
+		path = "mc-state-" + time.Now().Format("2006-01-02") + ".tar.gz"
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	m, err := state.Export(f, withTokens)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Exported %s to %s\n", strings.Join(m.Files, ", "), path)
+	if withTokens {
+		fmt.Println("This bundle contains API tokens; keep it private.")
+	}
+	return 0
+}
+
+// runImportState implements `mc import-state`
+func runImportState(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, importStateUsage)
+		return 2
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	m, backup, err := state.Import(f)
+	audit.Record("", "import-state", args[0], err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Imported %s (exported from %s on %s)\n", strings.Join(m.Files, ", "), m.Host, m.Created.Format("2006-01-02"))
+	if backup != "" {
+		fmt.Printf("Previous files saved in %s\n", backup)
+	}
+	return 0
+}
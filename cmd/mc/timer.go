@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+)
+
+const timerUsage = `Usage: mc timer <command>
+
+  status                     Show the running timer
+  start <project> [task]     Start tracking time (optionally on a milestone/issue)
+  stop                       Stop the running timer`
+
+// runTimer implements `mc timer`
+func runTimer(args []string) int {
+	if len(args) == 0 {
+		args = []string{"status"}
+	}
+
+	switch args[0] {
+	case "status":
+		active, err := timelog.Active()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if active == nil {
+			fmt.Println("No timer running.")
+			return 0
+		}
+		fmt.Printf("%s %s\n", timerLabel(*active), active.Duration().Round(time.Second))
+		return 0
+
+	case "start":
+		if len(args) < 2 || len(args) > 3 {
+			fmt.Fprintln(os.Stderr, timerUsage)
+			return 2
+		}
+		task := ""
+		if len(args) == 3 {
+			task = args[2]
+		}
+		if err := timelog.StartTask(args[1], task); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "stop":
+		stopped, err := timelog.Stop()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if stopped == nil {
+			fmt.Println("No timer running.")
+			return 0
+		}
+		fmt.Printf("Stopped %s after %s\n", timerLabel(*stopped), stopped.Duration().Round(time.Second))
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, timerUsage)
+	return 2
+}
+
+func timerLabel(e timelog.Entry) string {
+	if e.Task != "" {
+		return e.Project + " (" + e.Task + ")"
+	}
+	return e.Project
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/inbox"
+)
+
+const todoUsage = `Usage: mc todo ["text..."]
+
+  mc todo "call the registrar"     Capture a thought into the inbox
+  mc todo                          List the inbox
+
+Items captured inside a git repo remember that project. Process the
+inbox in the TUI with I.`
+
+// runTodo implements `mc todo`
+func runTodo(args []string) int {
+	if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Fprintln(os.Stderr, todoUsage)
+		return 2
+	}
+
+	if len(args) == 0 {
+		items, err := inbox.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(items) == 0 {
+			fmt.Println("Inbox zero.")
+			return 0
+		}
+		for _, item := range items {
+			project := ""
+			if item.Project != "" {
+				project = " [" + item.Project + "]"
+			}
+			fmt.Printf("%s  %s%s\n", item.Created.Format("Jan 02 15:04"), item.Text, project)
+		}
+		return 0
+	}
+
+	if _, err := inbox.Add(strings.Join(args, " "), currentProject()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// currentProject names the git repo containing the working directory, if any
+func currentProject() string {
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(strings.TrimSpace(string(output)))
+}
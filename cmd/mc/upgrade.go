@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/selfupdate"
+)
+
+const upgradeUsage = `Usage: mc upgrade [--check] [--force]
+
+  --check    Only report whether a newer release exists
+  --force    Install the latest release even if this build isn't older`
+
+// runUpgrade implements `mc upgrade`
+func runUpgrade(args []string) int {
+	checkOnly, force := false, false
+	for _, arg := range args {
+		switch arg {
+		case "--check":
+			checkOnly = true
+		case "--force":
+			force = true
+		default:
+			fmt.Fprintln(os.Stderr, upgradeUsage)
+			return 2
+		}
+	}
+
+	rel, err := selfupdate.Latest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	current := selfupdate.Version
+	if !force && !selfupdate.Newer(rel.Tag, current) {
+		if !selfupdate.IsRelease(current) {
+			fmt.Printf("This is a %s build; latest release is %s. Use --force to install it.\n", current, rel.Tag)
+		} else {
+			fmt.Printf("mc %s is up to date.\n", current)
+		}
+		return 0
+	}
+	if checkOnly {
+		fmt.Printf("mc %s is available (you have %s): %s\n", rel.Tag, current, rel.URL)
+		return 0
+	}
+
+	fmt.Printf("Downloading mc %s...\n", rel.Tag)
+	path, err := selfupdate.Apply(rel)
+	audit.Record("", "upgrade", current+" -> "+rel.Tag, err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Upgraded %s to %s.\n", path, rel.Tag)
+	return 0
+}
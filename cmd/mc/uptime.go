@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/maintenance"
+	"github.com/michaelmonetized/mission-control/pkg/uptime"
+)
+
+const uptimeUsage = `Usage: mc uptime <command>
+
+  list                            Show probed projects and this month's availability
+  add <project> [url]             Probe a project's production URL (default https://<project>)
+  rm <project>                    Stop probing a project
+  probe                           Probe every project once (run from cron when mc isn't open)
+  report [YYYY-MM]                Availability and downtime windows (default: this month)
+  export [YYYY-MM] [output-dir]   Write an SLA report CSV per project (default: last month)
+
+The TUI probes every 5 minutes while it runs. Probes are kept in
+~/.hustlemc/uptime/.`
+
+// runUptime implements `mc uptime`
+func runUptime(args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return uptimeReport(time.Now(), false)
+
+	case "add":
+		if len(args) < 2 || len(args) > 3 {
+			fmt.Fprintln(os.Stderr, uptimeUsage)
+			return 2
+		}
+		target := "https://" + args[1]
+		if len(args) == 3 {
+			target = args[2]
+		}
+		if err := updateConfig(args[1], func(cfg *config.Config) {
+			cfg.EnsureProject(args[1]).Uptime = target
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Probing %s at %s\n", args[1], target)
+		return 0
+
+	case "rm":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, uptimeUsage)
+			return 2
+		}
+		if err := updateConfig(args[1], func(cfg *config.Config) {
+			if p, ok := cfg.Projects[args[1]]; ok && p != nil {
+				p.Uptime = ""
+			}
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "probe":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		windows, _ := maintenance.Load()
+		status := 0
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for name, target := range uptime.Targets(cfg) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p := uptime.Check(target)
+				p.Maintenance = maintenance.Covering(windows, name, p.Time) != nil
+				err := uptime.Record(name, p)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error recording %s: %v\n", name, err)
+					status = 1
+				}
+				switch {
+				case p.Up:
+					fmt.Printf("up    %-28s %dms\n", name, p.Latency)
+				case p.Maintenance:
+					fmt.Printf("maint %-28s %s\n", name, p.Error)
+				default:
+					fmt.Printf("down  %-28s %s\n", name, p.Error)
+				}
+			}()
+		}
+		wg.Wait()
+		return status
+
+	case "report":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, uptimeUsage)
+			return 2
+		}
+		month := time.Now()
+		if len(args) == 2 {
+			t, ok := parseMonth(args[1])
+			if !ok {
+				return 2
+			}
+			month = t
+		}
+		return uptimeReport(month, true)
+
+	case "export":
+		if len(args) > 3 {
+			fmt.Fprintln(os.Stderr, uptimeUsage)
+			return 2
+		}
+		// SLA reports usually go out for the month that just ended
+		month := time.Now().AddDate(0, -1, 0)
+		if len(args) > 1 {
+			t, ok := parseMonth(args[1])
+			if !ok {
+				return 2
+			}
+			month = t
+		}
+		outDir := "."
+		if len(args) > 2 {
+			outDir = args[2]
+		}
+		return uptimeExport(month, outDir)
+
+	default:
+		fmt.Fprintln(os.Stderr, uptimeUsage)
+		return 2
+	}
+}
+
+// parseMonth reads a YYYY-MM argument, complaining when it isn't one
+func parseMonth(arg string) (time.Time, bool) {
+	t, err := time.ParseInLocation("2006-01", arg, time.Local)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid month %q (want YYYY-MM)\n", arg)
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// uptimeReports builds month's report for every probed project, by name
+func uptimeReports(month time.Time) ([]uptime.Report, map[string]string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	targets := uptime.Targets(cfg)
+	var names []string
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var reports []uptime.Report
+	for _, name := range names {
+		r, err := uptime.Monthly(name, month)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		reports = append(reports, r)
+	}
+	return reports, targets, nil
+}
+
+// uptimeReport prints month's availability per project, with its downtime
+// windows when windows is set
+func uptimeReport(month time.Time, windows bool) int {
+	reports, targets, err := uptimeReports(month)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(reports) == 0 {
+		fmt.Println("No projects are probed. Add one with mc uptime add <project>.")
+		return 0
+	}
+
+	fmt.Println(month.Format("January 2006"))
+	for _, r := range reports {
+		fmt.Printf("  %-28s %9s  %3d outages  %7s down  %s\n", r.Project, uptime.FormatAvailability(r.Availability()),
+			len(r.Windows), r.Downtime.Round(time.Minute), targets[r.Project])
+		if !windows {
+			continue
+		}
+		if r.Planned > 0 {
+			fmt.Printf("    %7s planned maintenance, not counted\n", r.Planned.Round(time.Minute))
+		}
+		for _, w := range r.Windows {
+			fmt.Printf("    %s – %s  %7s  %s\n", w.Start.Format("Jan 2 15:04"), w.End.Format("15:04"),
+				w.Duration().Round(time.Minute), strings.TrimSpace(w.Error))
+		}
+	}
+	return 0
+}
+
+// uptimeExport writes a report CSV per probed project into outDir
+func uptimeExport(month time.Time, outDir string) int {
+	reports, _, err := uptimeReports(month)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(reports) == 0 {
+		fmt.Println("No projects are probed. Add one with mc uptime add <project>.")
+		return 0
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, r := range reports {
+		path := filepath.Join(outDir, r.Filename())
+		f, err := os.Create(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		err = r.WriteCSV(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			return 1
+		}
+		fmt.Printf("%-28s %9s  %s\n", r.Project, uptime.FormatAvailability(r.Availability()), path)
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+// Package audit keeps an append-only record of every change mc makes on
+// someone's behalf (pushes, merges, deploys, issues, config edits) in
+// ~/.hustlemc/audit.jsonl, one JSON object per line.
+package audit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// Results recorded for an entry
+const (
+	ResultOK     = "ok"
+	ResultFailed = "failed"
+)
+
+var mu sync.Mutex
+
+// Entry is one audited action
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Who     string    `json:"who"`
+	Project string    `json:"project,omitempty"`
+	Action  string    `json:"action"` // push, merge, deploy, issue, config, ...
+	Detail  string    `json:"detail,omitempty"`
+	Result  string    `json:"result"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Path returns the audit log file path
+func Path() string {
+	return filepath.Join(config.Dir(), "audit.jsonl")
+}
+
+// Who identifies the person running mc as user@host
+func Who() string {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		return name + "@" + host
+	}
+	return name
+}
+
+// Record appends an entry for action on project. A nil err records
+// success; otherwise the error text is kept alongside a failed result.
+func Record(project, action, detail string, err error) error {
+	e := Entry{
+		Time:    time.Now(),
+		Who:     Who(),
+		Project: project,
+		Action:  action,
+		Detail:  detail,
+		Result:  ResultOK,
+	}
+	if err != nil {
+		e.Result = ResultFailed
+		e.Error = err.Error()
+	}
+	return Append(e)
+}
+
+// Append writes an entry to the end of the log
+func Append(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(Path(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads every entry, oldest first. Lines that don't parse (say, a
+// write cut short) are skipped.
+func Load() ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := os.Open(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// WriteCSV exports entries with a header row
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "who", "project", "action", "detail", "result", "error"})
+	for _, e := range entries {
+		cw.Write([]string{e.Time.Format(time.RFC3339), e.Who, e.Project, e.Action, e.Detail, e.Result, e.Error})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,464 @@
+// Package azuredevops talks to the Azure DevOps Services REST API, so
+// projects in Azure Repos show their Boards work items, active pull
+// requests, and Pipelines builds like GitHub ones.
+package azuredevops
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultBaseURL is Azure DevOps Services. Organizations still on
+// <org>.visualstudio.com answer here too.
+const DefaultBaseURL = "https://dev.azure.com"
+
+// apiVersion is the REST API version asked for
+const apiVersion = "7.1"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no Azure DevOps token (set tokens.azure-devops, MC_AZURE_DEVOPS_TOKEN, or AZURE_DEVOPS_EXT_PAT)")
+
+// Client makes authenticated API requests. Token is a personal access
+// token, or a Microsoft Entra access token (a JWT).
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for Azure DevOps Services using token
+func NewClient(token string) *Client {
+	c := httpapi.New("azure devops", DefaultBaseURL, token, ErrNoToken)
+	c.Authorize = func(req *http.Request, token string) {
+		if strings.HasPrefix(token, "eyJ") {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else {
+			req.SetBasicAuth("", token)
+		}
+	}
+	c.OnResponse = func(resp *http.Response) error {
+		// A token that's expired or lacks a scope gets the sign-in page
+		// with 203 rather than a 401
+		if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+			return &httpapi.Error{API: "azure devops", Status: http.StatusUnauthorized, Message: "the token was refused"}
+		}
+		return nil
+	}
+	return &Client{c}
+}
+
+// FindToken looks for a token for an organization, in order:
+// tokens.azure-devops-<org> (or MC_AZURE_DEVOPS_<ORG>_TOKEN), then
+// tokens.azure-devops or MC_AZURE_DEVOPS_TOKEN, then AZURE_DEVOPS_EXT_PAT
+// (which the az devops CLI reads) and SYSTEM_ACCESSTOKEN (set inside
+// Pipelines). It returns "" when there is none.
+func FindToken(cfg *config.Config, org string) string {
+	if cfg != nil {
+		if t := cfg.Token("azure-devops-" + strings.ToLower(org)); t != "" {
+			return t
+		}
+		if t := cfg.Token("azure-devops"); t != "" {
+			return t
+		}
+	}
+	for _, env := range []string{"AZURE_DEVOPS_EXT_PAT", "SYSTEM_ACCESSTOKEN"} {
+		if t := os.Getenv(env); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+// Default returns a client for an organization using the token FindToken
+// finds, or nil when there is none
+func Default(org string) *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg, org); token != "" {
+		return NewClient(token)
+	}
+	return nil
+}
+
+// Get fetches a REST path such as "org/project/_apis/git/repositories"
+// into out
+func (c *Client) Get(path string, out any) error {
+	return c.Client.Get(versioned(path), out)
+}
+
+// Post sends body as JSON to a REST path, decoding the reply into out
+func (c *Client) Post(path string, body, out any) error {
+	return c.Client.Post(versioned(path), body, out)
+}
+
+// Patch is Post with the PATCH method
+func (c *Client) Patch(path string, body, out any) error {
+	return c.Client.Patch(versioned(path), body, out)
+}
+
+// versioned adds the API version to a path's query
+func versioned(path string) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "api-version=" + apiVersion
+}
+
+// Repo names a Git repository in Azure Repos
+type Repo struct {
+	Org     string
+	Project string
+	Name    string
+}
+
+func (r Repo) String() string {
+	return r.Org + "/" + r.Project + "/" + r.Name
+}
+
+// projectPath is the API path of the repository's project
+func (r Repo) projectPath() string {
+	return url.PathEscape(r.Org) + "/" + url.PathEscape(r.Project) + "/_apis"
+}
+
+// WebURL is the repository's page
+func (r Repo) WebURL() string {
+	return DefaultBaseURL + "/" + url.PathEscape(r.Org) + "/" + url.PathEscape(r.Project) + "/_git/" + url.PathEscape(r.Name)
+}
+
+// ParseRemote extracts the repository from an Azure Repos remote URL in
+// any of the forms it hands out: https://dev.azure.com/org/project/_git/repo,
+// git@ssh.dev.azure.com:v3/org/project/repo, and the older
+// https://org.visualstudio.com/project/_git/repo and
+// org@vs-ssh.visualstudio.com:v3/org/project/repo
+func ParseRemote(remote string) (Repo, bool) {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Repo{}, false
+		}
+		host, path = strings.ToLower(u.Hostname()), u.Path
+	default:
+		// scp-like syntax: user@host:v3/org/project/repo
+		h, rest, ok := strings.Cut(remote, ":")
+		if !ok {
+			return Repo{}, false
+		}
+		if _, after, found := strings.Cut(h, "@"); found {
+			h = after
+		}
+		host, path = strings.ToLower(h), rest
+	}
+	if p, err := url.PathUnescape(path); err == nil {
+		path = p
+	}
+	parts := strings.Split(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+
+	var r Repo
+	switch {
+	case host == "ssh.dev.azure.com" || host == "vs-ssh.visualstudio.com":
+		// v3/org/project/repo
+		if len(parts) != 4 || parts[0] != "v3" {
+			return Repo{}, false
+		}
+		r = Repo{Org: parts[1], Project: parts[2], Name: parts[3]}
+	case host == "dev.azure.com":
+		if len(parts) == 0 {
+			return Repo{}, false
+		}
+		r.Org, parts = parts[0], parts[1:]
+	case strings.HasSuffix(host, ".visualstudio.com"):
+		r.Org = strings.TrimSuffix(host, ".visualstudio.com")
+		if len(parts) > 0 && strings.EqualFold(parts[0], "DefaultCollection") {
+			parts = parts[1:]
+		}
+	default:
+		return Repo{}, false
+	}
+	if r.Name == "" {
+		// project/_git/repo, or _git/repo for a repository named after
+		// its project
+		switch {
+		case len(parts) == 3 && parts[1] == "_git":
+			r.Project, r.Name = parts[0], parts[2]
+		case len(parts) == 2 && parts[0] == "_git":
+			r.Project, r.Name = parts[1], parts[1]
+		default:
+			return Repo{}, false
+		}
+	}
+	if r.Org == "" || r.Project == "" || r.Name == "" {
+		return Repo{}, false
+	}
+	return r, true
+}
+
+// Me returns the authenticated user's ID in an organization
+func (c *Client) Me(org string) (string, error) {
+	var data struct {
+		AuthenticatedUser struct {
+			ID string `json:"id"`
+		} `json:"authenticatedUser"`
+	}
+	if err := c.Get(url.PathEscape(org)+"/_apis/connectionData", &data); err != nil {
+		return "", err
+	}
+	return data.AuthenticatedUser.ID, nil
+}
+
+// closedStates are the work item states that count as done across the
+// Agile, Scrum, CMMI, and Basic processes
+const closedStates = "'Closed', 'Done', 'Removed', 'Resolved', 'Completed', 'Cut'"
+
+// WorkItemIDs lists the IDs of a project's open work items, newest first,
+// only those assigned to the authenticated user if mine
+func (c *Client) WorkItemIDs(r Repo, mine bool) ([]int, error) {
+	query := "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.State] NOT IN (" + closedStates + ")"
+	if mine {
+		query += " AND [System.AssignedTo] = @Me"
+	}
+	query += " ORDER BY [System.CreatedDate] DESC"
+	var result struct {
+		WorkItems []struct {
+			ID int `json:"id"`
+		} `json:"workItems"`
+	}
+	if err := c.Post(r.projectPath()+"/wit/wiql", map[string]string{"query": query}, &result); err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(result.WorkItems))
+	for i, w := range result.WorkItems {
+		ids[i] = w.ID
+	}
+	return ids, nil
+}
+
+// Identity is a user as work items and pull requests name them
+type Identity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"` // usually the sign-in email
+}
+
+// WorkItem is a Boards work item: a bug, task, user story, issue, ...
+type WorkItem struct {
+	ID     int `json:"id"`
+	Fields struct {
+		Title     string    `json:"System.Title"`
+		Type      string    `json:"System.WorkItemType"`
+		State     string    `json:"System.State"`
+		Tags      string    `json:"System.Tags"` // "a; b"
+		CreatedBy Identity  `json:"System.CreatedBy"`
+		CreatedAt time.Time `json:"System.CreatedDate"`
+	} `json:"fields"`
+}
+
+// URL is the work item's page
+func (w WorkItem) URL(r Repo) string {
+	return DefaultBaseURL + "/" + url.PathEscape(r.Org) + "/" + url.PathEscape(r.Project) + "/_workitems/edit/" + strconv.Itoa(w.ID)
+}
+
+// WorkItems reads work items by ID, at most 200
+func (c *Client) WorkItems(r Repo, ids []int) ([]WorkItem, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	list := make([]string, 0, len(ids))
+	for _, id := range ids[:min(len(ids), 200)] {
+		list = append(list, strconv.Itoa(id))
+	}
+	fields := "System.Id,System.Title,System.WorkItemType,System.State,System.Tags,System.CreatedBy,System.CreatedDate"
+	var page struct {
+		Value []WorkItem `json:"value"`
+	}
+	err := c.Get(r.projectPath()+"/wit/workitems?ids="+strings.Join(list, ",")+"&fields="+fields, &page)
+	return page.Value, err
+}
+
+// Reviewer is a pull request reviewer and their vote
+type Reviewer struct {
+	Identity
+	Vote       int  `json:"vote"` // 10 approved, 5 approved with suggestions, 0 none, -5 waiting for author, -10 rejected
+	IsRequired bool `json:"isRequired"`
+}
+
+// PullRequest is an active pull request
+type PullRequest struct {
+	ID          int        `json:"pullRequestId"`
+	Title       string     `json:"title"`
+	IsDraft     bool       `json:"isDraft"`
+	SourceRef   string     `json:"sourceRefName"` // refs/heads/<branch>
+	CreatedBy   Identity   `json:"createdBy"`
+	CreatedAt   time.Time  `json:"creationDate"`
+	MergeStatus string     `json:"mergeStatus"` // succeeded, conflicts, queued, notSet, failure, rejectedByPolicy
+	Reviewers   []Reviewer `json:"reviewers"`
+
+	// LastMergeSource is the source branch commit last merged for checks;
+	// completing the pull request must name it
+	LastMergeSource struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeSourceCommit"`
+}
+
+// URL is the pull request's page
+func (pr PullRequest) URL(r Repo) string {
+	return r.WebURL() + "/pullrequest/" + strconv.Itoa(pr.ID)
+}
+
+// PullRequests lists a repository's active pull requests, newest first
+func (c *Client) PullRequests(r Repo) ([]PullRequest, error) {
+	var page struct {
+		Value []PullRequest `json:"value"`
+	}
+	err := c.Get(r.projectPath()+"/git/repositories/"+url.PathEscape(r.Name)+"/pullrequests?searchCriteria.status=active&$top=200", &page)
+	return page.Value, err
+}
+
+// Counts are a repository's open work item and pull request totals, and
+// how many are waiting on the authenticated user
+type Counts struct {
+	WorkItems       int
+	PRs             int
+	Assigned        int
+	ReviewRequested int
+}
+
+// Counts reads a repository's Counts. Work items belong to the project,
+// so every repository in it shares them. A pull request waits on the
+// user while they're a reviewer who hasn't voted.
+func (c *Client) Counts(r Repo) (Counts, error) {
+	var counts Counts
+	prs, err := c.PullRequests(r)
+	if err != nil {
+		return counts, err
+	}
+	counts.PRs = len(prs)
+	if ids, err := c.WorkItemIDs(r, false); err == nil {
+		counts.WorkItems = len(ids)
+	}
+	if ids, err := c.WorkItemIDs(r, true); err == nil {
+		counts.Assigned = len(ids)
+	}
+	if me, err := c.Me(r.Org); err == nil && me != "" {
+		for _, pr := range prs {
+			for _, rv := range pr.Reviewers {
+				if rv.ID == me && rv.Vote == 0 {
+					counts.ReviewRequested++
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+// Build is one Pipelines run
+type Build struct {
+	ID         int64  `json:"id"`
+	Number     string `json:"buildNumber"`
+	Status     string `json:"status"` // notStarted, inProgress, cancelling, postponed, completed
+	Result     string `json:"result"` // succeeded, partiallySucceeded, failed, canceled
+	SourceRef  string `json:"sourceBranch"`
+	Definition struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"definition"`
+	QueuedAt time.Time `json:"queueTime"`
+	Links    struct {
+		Web struct {
+			Href string `json:"href"`
+		} `json:"web"`
+	} `json:"_links"`
+}
+
+// Builds lists the newest n pipeline runs of a repository, on branch
+// unless it is ""
+func (c *Client) Builds(r Repo, branch string, n int) ([]Build, error) {
+	// Builds are filtered by the repository's ID, not its name
+	var repo struct {
+		ID string `json:"id"`
+	}
+	if err := c.Get(r.projectPath()+"/git/repositories/"+url.PathEscape(r.Name), &repo); err != nil {
+		return nil, err
+	}
+	q := url.Values{
+		"repositoryId":   {repo.ID},
+		"repositoryType": {"TfsGit"},
+		"queryOrder":     {"queueTimeDescending"},
+		"$top":           {strconv.Itoa(n)},
+	}
+	if branch != "" {
+		q.Set("branchName", "refs/heads/"+branch)
+	}
+	var page struct {
+		Value []Build `json:"value"`
+	}
+	err := c.Get(r.projectPath()+"/build/builds?"+q.Encode(), &page)
+	return page.Value, err
+}
+
+// MergeStrategies maps GitHub's merge methods to Azure DevOps'
+var MergeStrategies = map[string]string{
+	"merge":  "noFastForward",
+	"squash": "squash",
+	"rebase": "rebase",
+}
+
+// CompletePull merges an active pull request with strategy, one of
+// MergeStrategies' values
+func (c *Client) CompletePull(r Repo, id int, strategy string) error {
+	path := r.projectPath() + "/git/repositories/" + url.PathEscape(r.Name) + "/pullrequests/" + strconv.Itoa(id)
+	var pr PullRequest
+	if err := c.Get(path, &pr); err != nil {
+		return err
+	}
+	return c.Patch(path, map[string]any{
+		"status":                "completed",
+		"lastMergeSourceCommit": map[string]string{"commitId": pr.LastMergeSource.CommitID},
+		"completionOptions":     map[string]string{"mergeStrategy": strategy},
+	}, nil)
+}
+
+// RetryBuild runs a build's failed jobs again
+func (c *Client) RetryBuild(r Repo, id int64) error {
+	return c.Patch(r.projectPath()+"/build/builds/"+strconv.FormatInt(id, 10)+"?retry=true", map[string]any{}, nil)
+}
+
+// QueueBuild starts a build of the same pipeline and branch as build id
+func (c *Client) QueueBuild(r Repo, id int64) error {
+	var b Build
+	if err := c.Get(r.projectPath()+"/build/builds/"+strconv.FormatInt(id, 10), &b); err != nil {
+		return err
+	}
+	return c.Post(r.projectPath()+"/build/builds", map[string]any{
+		"definition":   map[string]int{"id": b.Definition.ID},
+		"sourceBranch": b.SourceRef,
+	}, nil)
+}
+
+// CreateWorkItem files a Task, the one work item type every process has,
+// and returns its page
+func (c *Client) CreateWorkItem(r Repo, title, description string) (string, error) {
+	ops := []map[string]string{
+		{"op": "add", "path": "/fields/System.Title", "value": title},
+		{"op": "add", "path": "/fields/System.Description", "value": description},
+	}
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return "", err
+	}
+	var created WorkItem
+	body := httpapi.RawBody{ContentType: "application/json-patch+json", Data: data}
+	if err := c.Post(r.projectPath()+"/wit/workitems/$Task", body, &created); err != nil {
+		return "", err
+	}
+	return created.URL(r), nil
+}
@@ -0,0 +1,293 @@
+// Package bitbucket talks to the Bitbucket Cloud REST API, so projects
+// hosted on bitbucket.org show issue, pull request, and Pipelines stats
+// like GitHub ones.
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultBaseURL is the Bitbucket Cloud API
+const DefaultBaseURL = "https://api.bitbucket.org/2.0"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no Bitbucket token (set tokens.bitbucket or MC_BITBUCKET_TOKEN)")
+
+// Client makes authenticated API requests. Token is an access token, or
+// "username:app-password" for an app password.
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for the Bitbucket Cloud API using token
+func NewClient(token string) *Client {
+	c := httpapi.New("bitbucket", DefaultBaseURL, token, ErrNoToken)
+	c.Authorize = func(req *http.Request, token string) {
+		if user, password, ok := strings.Cut(token, ":"); ok {
+			req.SetBasicAuth(user, password)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	return &Client{c}
+}
+
+// FindToken looks for the token for a workspace's repositories, in order:
+// tokens.bitbucket-<workspace> (or MC_BITBUCKET_<WORKSPACE>_TOKEN), for
+// workspace or repository access tokens, then tokens.bitbucket or
+// MC_BITBUCKET_TOKEN, then BITBUCKET_TOKEN. It returns "" when there is
+// none.
+func FindToken(cfg *config.Config, workspace string) string {
+	if cfg != nil {
+		if t := cfg.Token("bitbucket-" + strings.ToLower(workspace)); t != "" {
+			return t
+		}
+		if t := cfg.Token("bitbucket"); t != "" {
+			return t
+		}
+	}
+	return os.Getenv("BITBUCKET_TOKEN")
+}
+
+// Default returns a client for a workspace's repositories using the token
+// FindToken finds, or nil when there is none
+func Default(workspace string) *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg, workspace); token != "" {
+		return NewClient(token)
+	}
+	return nil
+}
+
+// Count returns how many items a list path matches in all, from the size
+// of a one-item page
+func (c *Client) Count(path string, query url.Values) (int, error) {
+	q := url.Values{"pagelen": {"1"}, "fields": {"size"}}
+	for k, v := range query {
+		q[k] = v
+	}
+	var page struct {
+		Size int `json:"size"`
+	}
+	err := c.Get(path+"?"+q.Encode(), &page)
+	return page.Size, err
+}
+
+// Repo names a repository
+type Repo struct {
+	Workspace string
+	Slug      string
+}
+
+func (r Repo) String() string {
+	return r.Workspace + "/" + r.Slug
+}
+
+func (r Repo) path() string {
+	return "repositories/" + r.String()
+}
+
+// WebURL is the repository's page
+func (r Repo) WebURL() string {
+	return "https://bitbucket.org/" + r.String()
+}
+
+// ParseRemote extracts the repository from a bitbucket.org remote URL in
+// any of the forms git accepts: https://user@bitbucket.org/w/r.git,
+// git@bitbucket.org:w/r.git, or ssh://git@bitbucket.org/w/r
+func ParseRemote(remote string) (Repo, bool) {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Repo{}, false
+		}
+		host, path = u.Hostname(), u.Path
+	default:
+		// scp-like syntax: [user@]bitbucket.org:workspace/repo
+		h, rest, ok := strings.Cut(remote, ":")
+		if !ok {
+			return Repo{}, false
+		}
+		if _, after, found := strings.Cut(h, "@"); found {
+			h = after
+		}
+		host, path = h, rest
+	}
+	if !strings.EqualFold(host, "bitbucket.org") {
+		return Repo{}, false
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	workspace, slug, ok := strings.Cut(path, "/")
+	if !ok || workspace == "" || slug == "" || strings.Contains(slug, "/") {
+		return Repo{}, false
+	}
+	return Repo{Workspace: workspace, Slug: slug}, true
+}
+
+// Counts are a repository's open issue and pull request totals, and how
+// many are waiting on the authenticated user
+type Counts struct {
+	Issues          int
+	PRs             int
+	Assigned        int
+	ReviewRequested int
+	Watchers        int
+}
+
+// openIssues matches issues that aren't resolved, on hold, or closed
+const openIssues = `(state="new" OR state="open")`
+
+// Counts reads a repository's Counts. A repository without an issue
+// tracker has no issues.
+func (c *Client) Counts(r Repo) (Counts, error) {
+	var counts Counts
+	var err error
+	if counts.PRs, err = c.Count(r.path()+"/pullrequests", url.Values{"state": {"OPEN"}}); err != nil {
+		return counts, err
+	}
+	counts.Issues, _ = c.Count(r.path()+"/issues", url.Values{"q": {openIssues}})
+	counts.Watchers, _ = c.Count(r.path()+"/watchers", nil)
+
+	var me struct {
+		UUID string `json:"uuid"`
+	}
+	if c.Get("user", &me) == nil && me.UUID != "" {
+		counts.Assigned, _ = c.Count(r.path()+"/issues", url.Values{"q": {openIssues + ` AND assignee.uuid="` + me.UUID + `"`}})
+		counts.ReviewRequested, _ = c.Count(r.path()+"/pullrequests", url.Values{"q": {`state="OPEN" AND reviewers.uuid="` + me.UUID + `"`}})
+	}
+	return counts, nil
+}
+
+// Account is an issue reporter or pull request author
+type Account struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// Links holds an object's web page link
+type Links struct {
+	HTML struct {
+		Href string `json:"href"`
+	} `json:"html"`
+}
+
+// Issue is an open issue
+type Issue struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Kind      string    `json:"kind"`     // bug, enhancement, proposal, task
+	Priority  string    `json:"priority"` // trivial ... blocker
+	Reporter  Account   `json:"reporter"`
+	Links     Links     `json:"links"`
+	CreatedOn time.Time `json:"created_on"`
+}
+
+// Issues lists a repository's open issues, newest first
+func (c *Client) Issues(r Repo) ([]Issue, error) {
+	q := url.Values{"q": {openIssues}, "sort": {"-created_on"}, "pagelen": {"50"}}
+	var page struct {
+		Values []Issue `json:"values"`
+	}
+	err := c.Get(r.path()+"/issues?"+q.Encode(), &page)
+	return page.Values, err
+}
+
+// PullRequest is an open pull request
+type PullRequest struct {
+	ID     int     `json:"id"`
+	Title  string  `json:"title"`
+	Draft  bool    `json:"draft"`
+	Author Account `json:"author"`
+	Links  Links   `json:"links"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"source"`
+	UpdatedOn time.Time `json:"updated_on"`
+}
+
+// PullRequests lists a repository's open pull requests, recently updated
+// first
+func (c *Client) PullRequests(r Repo) ([]PullRequest, error) {
+	q := url.Values{"state": {"OPEN"}, "sort": {"-updated_on"}, "pagelen": {"50"}}
+	var page struct {
+		Values []PullRequest `json:"values"`
+	}
+	err := c.Get(r.path()+"/pullrequests?"+q.Encode(), &page)
+	return page.Values, err
+}
+
+// Pipeline is one Bitbucket Pipelines run
+type Pipeline struct {
+	UUID        string `json:"uuid"`
+	BuildNumber int64  `json:"build_number"`
+	State       struct {
+		Name   string `json:"name"` // PENDING, IN_PROGRESS, COMPLETED
+		Result struct {
+			Name string `json:"name"` // SUCCESSFUL, FAILED, ERROR, STOPPED, EXPIRED
+		} `json:"result"`
+	} `json:"state"`
+	Target struct {
+		RefName string `json:"ref_name"`
+	} `json:"target"`
+	CreatedOn time.Time `json:"created_on"`
+}
+
+// Pipelines lists a repository's newest n pipelines, on branch unless it
+// is ""
+func (c *Client) Pipelines(r Repo, branch string, n int) ([]Pipeline, error) {
+	q := url.Values{"sort": {"-created_on"}, "pagelen": {fmt.Sprint(n)}}
+	if branch != "" {
+		q.Set("target.branch", branch)
+	}
+	var page struct {
+		Values []Pipeline `json:"values"`
+	}
+	err := c.Get(r.path()+"/pipelines/?"+q.Encode(), &page)
+	return page.Values, err
+}
+
+// PipelineURL is a pipeline's results page
+func PipelineURL(r Repo, p Pipeline) string {
+	return fmt.Sprintf("%s/pipelines/results/%d", r.WebURL(), p.BuildNumber)
+}
+
+// MergeStrategies maps GitHub's merge methods to Bitbucket's
+var MergeStrategies = map[string]string{
+	"merge":  "merge_commit",
+	"squash": "squash",
+	"rebase": "rebase_fast_forward",
+}
+
+// MergePull merges a pull request with strategy, one of
+// MergeStrategies' values
+func (c *Client) MergePull(r Repo, id int, strategy string) error {
+	return c.Post(fmt.Sprintf("%s/pullrequests/%d/merge", r.path(), id), map[string]string{"merge_strategy": strategy}, nil)
+}
+
+// RunPipeline starts a new pipeline on the tip of branch
+func (c *Client) RunPipeline(r Repo, branch string) error {
+	body := map[string]any{"target": map[string]string{"type": "pipeline_ref_target", "ref_type": "branch", "ref_name": branch}}
+	return c.Post(r.path()+"/pipelines/", body, nil)
+}
+
+// CreateIssue opens an issue and returns its page
+func (c *Client) CreateIssue(r Repo, title, body string) (string, error) {
+	var created Issue
+	err := c.Post(r.path()+"/issues", map[string]any{"title": title, "content": map[string]string{"raw": body}}, &created)
+	return created.Links.HTML.Href, err
+}
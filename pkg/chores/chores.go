@@ -0,0 +1,182 @@
+// Package chores tracks recurring maintenance per project (rotate keys,
+// bump deps, renew domains). Chores are defined in config.json; each
+// completion is appended to ~/.hustlemc/chores.json.
+package chores
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// DueSoon is how far ahead a chore counts as upcoming
+const DueSoon = 7 * 24 * time.Hour
+
+var mu sync.Mutex
+
+// Completion is one time a chore was done
+type Completion struct {
+	Project string    `json:"project"`
+	Chore   string    `json:"chore"`
+	At      time.Time `json:"at"`
+}
+
+// Due is a chore's next occurrence
+type Due struct {
+	Project  string
+	Chore    config.Chore
+	Due      time.Time
+	LastDone time.Time // zero if never done
+}
+
+// Overdue reports whether the chore is past due at now
+func (d Due) Overdue(now time.Time) bool {
+	return now.After(d.Due)
+}
+
+// Upcoming reports whether the chore falls due within DueSoon of now
+func (d Due) Upcoming(now time.Time) bool {
+	return !d.Overdue(now) && d.Due.Sub(now) <= DueSoon
+}
+
+// ParseEvery parses an interval: weekly, monthly, quarterly, yearly, or
+// a count with a unit ("10d", "2w", "6m", "1y"). It returns the days
+// and months to add per occurrence.
+func ParseEvery(every string) (days, months int, err error) {
+	switch strings.ToLower(every) {
+	case "daily":
+		return 1, 0, nil
+	case "weekly":
+		return 7, 0, nil
+	case "biweekly":
+		return 14, 0, nil
+	case "monthly":
+		return 0, 1, nil
+	case "quarterly":
+		return 0, 3, nil
+	case "yearly", "annually":
+		return 0, 12, nil
+	}
+
+	if len(every) < 2 {
+		return 0, 0, fmt.Errorf("invalid interval %q", every)
+	}
+	n, err := strconv.Atoi(every[:len(every)-1])
+	if err != nil || n <= 0 {
+		return 0, 0, fmt.Errorf("invalid interval %q", every)
+	}
+	switch every[len(every)-1] {
+	case 'd':
+		return n, 0, nil
+	case 'w':
+		return 7 * n, 0, nil
+	case 'm':
+		return 0, n, nil
+	case 'y':
+		return 0, 12 * n, nil
+	}
+	return 0, 0, fmt.Errorf("invalid interval %q", every)
+}
+
+// next returns t advanced by one interval
+func next(t time.Time, every string) time.Time {
+	days, months, err := ParseEvery(every)
+	if err != nil {
+		return t
+	}
+	return t.AddDate(0, months, days)
+}
+
+// Path returns the completion history file path
+func Path() string {
+	return filepath.Join(config.Dir(), "chores.json")
+}
+
+// History reads all completions, oldest first
+func History() ([]Completion, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return history()
+}
+
+func history() ([]Completion, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var done []Completion
+	if err := json.Unmarshal(data, &done); err != nil {
+		return nil, err
+	}
+	return done, nil
+}
+
+// MarkDone records a completion of a project's chore
+func MarkDone(project, chore string, at time.Time) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	done, err := history()
+	if err != nil {
+		return err
+	}
+	done = append(done, Completion{Project: project, Chore: chore, At: at})
+
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(done, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Schedule computes every configured chore's next due date, soonest first.
+// A chore that's never been done is due on its start date.
+func Schedule(cfg *config.Config, done []Completion) []Due {
+	last := make(map[string]time.Time)
+	for _, c := range done {
+		key := c.Project + "\x00" + c.Chore
+		if c.At.After(last[key]) {
+			last[key] = c.At
+		}
+	}
+
+	var due []Due
+	for project, p := range cfg.Projects {
+		if p == nil {
+			continue
+		}
+		for _, chore := range p.Chores {
+			d := Due{Project: project, Chore: chore, LastDone: last[project+"\x00"+chore.Name]}
+			if !d.LastDone.IsZero() {
+				d.Due = next(d.LastDone, chore.Every)
+			} else if start, err := time.ParseInLocation("2006-01-02", chore.Start, time.Local); err == nil {
+				d.Due = start
+			} else {
+				d.Due = time.Now()
+			}
+			due = append(due, d)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].Due.Equal(due[j].Due) {
+			return due[i].Due.Before(due[j].Due)
+		}
+		return due[i].Project < due[j].Project
+	})
+	return due
+}
@@ -0,0 +1,397 @@
+// Package config loads and saves mission-control's user configuration
+// (~/.hustlemc/config.json), including per-project settings.
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mu serializes read-modify-write cycles on the config file
+var mu sync.Mutex
+
+// Config is the on-disk user configuration
+type Config struct {
+	Root string `json:"root,omitempty"` // project root, written by `mc init`
+
+	// Locale selects the UI language ("es"); empty follows $LANG
+	Locale string `json:"locale,omitempty"`
+
+	// Tokens holds API credentials keyed by service (stripe, gumroad, ...).
+	// MC_<SERVICE>_TOKEN environment variables take precedence.
+	Tokens map[string]string `json:"tokens,omitempty"`
+
+	// GitHubClientID is the OAuth app `mc login` signs in through; the
+	// app needs device flow enabled
+	GitHubClientID string `json:"github_client_id,omitempty"`
+
+	// Projects holds per-project settings keyed by project name
+	Projects map[string]*ProjectConfig `json:"projects,omitempty"`
+
+	// AutoFetch keeps ahead/behind counts current by fetching in the
+	// background. Off unless configured.
+	AutoFetch *AutoFetch `json:"auto_fetch,omitempty"`
+
+	// Columns are optional project list columns: stars, forks, watchers
+	Columns []string `json:"columns,omitempty"`
+
+	// StartView opens the TUI on another view than the project list:
+	// "priorities" lands on the "work on these next" ranking
+	StartView string `json:"start_view,omitempty"`
+
+	// Downloads is the folder artifacts and release assets are downloaded
+	// to, in a folder per project (default ~/Downloads)
+	Downloads string `json:"downloads,omitempty"`
+
+	// Board is a GitHub Projects board to triage from mc: "owner/3" or
+	// the board's URL
+	Board string `json:"board,omitempty"`
+
+	// Gitea lists self-hosted Gitea and Forgejo instances by base URL
+	// ("https://git.example.com"); Codeberg needs no entry. Tokens are
+	// tokens.gitea-<host>, falling back to tokens.gitea.
+	Gitea []string `json:"gitea,omitempty"`
+
+	// Priority weighs the signals behind the priorities ranking
+	Priority *Priority `json:"priority,omitempty"`
+
+	// FocusFollow starts the TUI following the project tmux or the
+	// editor reports through `mc focus` (toggle with a)
+	FocusFollow bool `json:"focus_follow,omitempty"`
+
+	// RotateEvery is how often credentials should be rotated, as a chore
+	// interval ("90d", "6m"); projects can set their own
+	RotateEvery string `json:"rotate_every,omitempty"`
+
+	// Confirm holds high-risk actions until a second factor is given
+	Confirm *Confirm `json:"confirm,omitempty"`
+
+	// Profile names the active profile; MC_PROFILE takes precedence
+	Profile string `json:"profile,omitempty"`
+
+	// Profiles hold settings that apply only while that profile is
+	// active, e.g. a stricter confirmation policy for client work
+	Profiles map[string]*Profile `json:"profiles,omitempty"`
+}
+
+// Profile is a named set of settings
+type Profile struct {
+	Confirm *Confirm `json:"confirm,omitempty"`
+}
+
+// Confirm is a second-factor policy: which actions need one and what
+// kind. Actions are named as in the audit log: deploy, push, merge,
+// dispatch, publish, rollback, rebase, cherry-pick, and branch-cleanup.
+type Confirm struct {
+	Actions []string `json:"actions"`
+	Method  string   `json:"method,omitempty"` // phrase (default) or totp
+	Phrase  string   `json:"phrase,omitempty"` // what to type; defaults to the project name
+}
+
+// Confirmation methods
+const (
+	ConfirmPhrase = "phrase" // type the project name (or Confirm.Phrase)
+	ConfirmTOTP   = "totp"   // enter the code from an authenticator app (tokens.totp)
+)
+
+// Requires reports whether action needs a second factor
+func (c *Confirm) Requires(action string) bool {
+	if c == nil {
+		return false
+	}
+	for _, a := range c.Actions {
+		if a == action || a == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// DownloadDir returns the folder a project's downloads go in
+func (c *Config) DownloadDir(project string) string {
+	home, _ := os.UserHomeDir()
+	dir := filepath.Join(home, "Downloads")
+	if c.Downloads != "" {
+		dir = c.Downloads
+		if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+			dir = filepath.Join(home, rest)
+		}
+	}
+	return filepath.Join(dir, project)
+}
+
+// ActiveProfile returns the name of the profile in effect, or ""
+func (c *Config) ActiveProfile() string {
+	if p := os.Getenv("MC_PROFILE"); p != "" {
+		return p
+	}
+	return c.Profile
+}
+
+// ConfirmPolicy returns the second-factor policy in effect: the active
+// profile's when it sets one, else the global one. Nil means none.
+func (c *Config) ConfirmPolicy() *Confirm {
+	if p, ok := c.Profiles[c.ActiveProfile()]; ok && p != nil && p.Confirm != nil {
+		return p.Confirm
+	}
+	return c.Confirm
+}
+
+// Priority weighs each signal the priorities ranking is built from.
+// Zero (or unset) weights count as 1; a negative weight ignores the signal.
+type Priority struct {
+	Health    float64 `json:"health,omitempty"`
+	Deadlines float64 `json:"deadlines,omitempty"`
+	Revenue   float64 `json:"revenue,omitempty"`
+	Issues    float64 `json:"issues,omitempty"`
+}
+
+// AutoFetch configures background `git fetch --prune`
+type AutoFetch struct {
+	Enabled bool   `json:"enabled"`
+	Every   string `json:"every,omitempty"` // minimum time between fetches of one project, e.g. "30m"
+}
+
+// DefaultFetchInterval is used when AutoFetch.Every is unset or invalid
+const DefaultFetchInterval = 15 * time.Minute
+
+// Interval returns how long a project goes between fetches
+func (a *AutoFetch) Interval() time.Duration {
+	if d, err := time.ParseDuration(a.Every); err == nil && d > 0 {
+		return d
+	}
+	return DefaultFetchInterval
+}
+
+// ProjectConfig holds settings for a single project
+type ProjectConfig struct {
+	Revenue   []RevenueSource `json:"revenue,omitempty"`
+	Client    *Client         `json:"client,omitempty"`
+	Estimates []Estimate      `json:"estimates,omitempty"`
+
+	// Costs are the cloud resources billed for the project
+	Costs []CostSource `json:"costs,omitempty"`
+
+	// LinearTeam is the Linear team ID inbox items are filed under
+	LinearTeam string `json:"linear_team,omitempty"`
+
+	Chores []Chore `json:"chores,omitempty"`
+
+	// NoAutoFetch opts a project out of background fetching
+	NoAutoFetch bool `json:"no_auto_fetch,omitempty"`
+
+	// Services are long-running commands managed as a group
+	Services []Service `json:"services,omitempty"`
+
+	// Logs is the command that tails production logs, for when it can't
+	// be told from the project, e.g. "kubectl logs -f deploy/api"
+	Logs string `json:"logs,omitempty"`
+
+	// Deploy is the command that deploys to production, for when it can't
+	// be told from the project, e.g. "make deploy"
+	Deploy string `json:"deploy,omitempty"`
+
+	// RotateEvery overrides the credential rotation policy for the project
+	RotateEvery string `json:"rotate_every,omitempty"`
+
+	// Deadlines are dated commitments that push the project up the
+	// priorities ranking as they near
+	Deadlines []Deadline `json:"deadlines,omitempty"`
+
+	// Uptime is the production URL (or host:port) probed for availability
+	// reports, e.g. "https://acme.com/health"
+	Uptime string `json:"uptime,omitempty"`
+
+	// Sentry is the Sentry project ("org/project") whose error spikes
+	// raise an incident alert
+	Sentry string `json:"sentry,omitempty"`
+
+	// Render is the Render service ID (srv-...) the project deploys to,
+	// for when its render.yaml doesn't say
+	Render string `json:"render,omitempty"`
+
+	// Railway is the Railway service ID the project deploys to, for when
+	// it isn't linked with railway link
+	Railway string `json:"railway,omitempty"`
+
+	// Flags is the feature flag service the project's flags live in
+	Flags *FlagSource `json:"flags,omitempty"`
+}
+
+// FlagSource is where a project's feature flags are managed
+type FlagSource struct {
+	Kind        string `json:"kind"`                  // launchdarkly, unleash, flagsmith
+	Project     string `json:"project,omitempty"`     // LaunchDarkly project key or Unleash project (default "default")
+	Environment string `json:"environment,omitempty"` // LaunchDarkly or Unleash environment (default "production"), or Flagsmith's environment key
+	URL         string `json:"url,omitempty"`         // a self-hosted Unleash or Flagsmith API
+}
+
+// Feature flag source kinds
+const (
+	FlagsLaunchDarkly = "launchdarkly"
+	FlagsUnleash      = "unleash"
+	FlagsFlagsmith    = "flagsmith"
+)
+
+// Service is a long-running command run for a project, such as
+// `npm run dev`, `docker compose up`, or `stripe listen`
+type Service struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`          // run with sh -c
+	Dir     string `json:"dir,omitempty"`    // relative to the project
+	Health  string `json:"health,omitempty"` // URL to GET or port to dial, e.g. "http://localhost:3000/health" or "5432"
+	Port    int    `json:"port,omitempty"`   // port it listens on, when Health doesn't say
+}
+
+// Chore is a recurring maintenance task
+type Chore struct {
+	Name  string `json:"name"`
+	Every string `json:"every"`           // weekly, monthly, quarterly, yearly, or "10d", "2w", "6m"
+	Start string `json:"start,omitempty"` // first due date (2006-01-02); defaults to now
+}
+
+// Deadline is something due on a date
+type Deadline struct {
+	Name string `json:"name"`
+	Date string `json:"date"` // 2006-01-02
+}
+
+// Estimate is the expected effort for a milestone or issue
+type Estimate struct {
+	Task  string  `json:"task"` // milestone title or issue ref ("#12")
+	Hours float64 `json:"hours"`
+	Done  bool    `json:"done,omitempty"`
+}
+
+// Client is who a project's tracked time is billed to
+type Client struct {
+	Name      string `json:"name"`
+	RateCents int    `json:"rate_cents"`        // hourly rate
+	Cadence   string `json:"cadence,omitempty"` // weekly, biweekly, monthly
+}
+
+// Invoice cadences
+const (
+	CadenceWeekly   = "weekly"
+	CadenceBiweekly = "biweekly"
+	CadenceMonthly  = "monthly"
+)
+
+// RevenueSource is an income stream attributed to a project
+type RevenueSource struct {
+	Kind string `json:"kind"` // github_sponsors, stripe, gumroad
+	ID   string `json:"id"`   // sponsors login, Stripe product ID, Gumroad product ID
+}
+
+// Revenue source kinds
+const (
+	RevenueGitHubSponsors = "github_sponsors"
+	RevenueStripe         = "stripe"
+	RevenueGumroad        = "gumroad"
+)
+
+// CostSource is a cloud resource whose spend is attributed to a project
+type CostSource struct {
+	Kind string `json:"kind"` // vercel, fly, aws
+	ID   string `json:"id"`   // Vercel project ("team/project" for a team), Fly app, AWS cost tag ("key=value")
+}
+
+// Cost source kinds
+const (
+	CostVercel = "vercel"
+	CostFly    = "fly"
+	CostAWS    = "aws"
+)
+
+// Dir returns the mission-control state directory
+func Dir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".hustlemc")
+}
+
+// Path returns the config file path
+func Path() string {
+	return filepath.Join(Dir(), "config.json")
+}
+
+// Load reads the config file. A missing file yields an empty config.
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return &Config{}, err
+	}
+	return cfg, nil
+}
+
+// Save writes the config file
+func Save(cfg *Config) error {
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Tokens live here, so keep the file private
+	return os.WriteFile(Path(), data, 0600)
+}
+
+// Update loads the config, applies fn, and saves it atomically with
+// respect to other Update calls in this process.
+func Update(fn func(*Config)) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	fn(cfg)
+	return Save(cfg)
+}
+
+// Project returns settings for a project, or an empty ProjectConfig
+func (c *Config) Project(name string) *ProjectConfig {
+	if p, ok := c.Projects[name]; ok && p != nil {
+		return p
+	}
+	return &ProjectConfig{}
+}
+
+// EnsureProject returns a project's settings, creating them if needed
+func (c *Config) EnsureProject(name string) *ProjectConfig {
+	if c.Projects == nil {
+		c.Projects = make(map[string]*ProjectConfig)
+	}
+	p, ok := c.Projects[name]
+	if !ok || p == nil {
+		p = &ProjectConfig{}
+		c.Projects[name] = p
+	}
+	return p
+}
+
+// Token returns the credential for a service, preferring the
+// MC_<SERVICE>_TOKEN environment variable over the config file.
+func (c *Config) Token(service string) string {
+	env := "MC_" + strings.ToUpper(strings.ReplaceAll(service, "-", "_")) + "_TOKEN"
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return c.Tokens[service]
+}
@@ -0,0 +1,301 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ProjectFileName is the settings file a project can check in alongside
+// its code, for what belongs with the project rather than with one
+// person's config.json
+const ProjectFileName = ".mc.toml"
+
+// ProjectFile is a project's .mc.toml
+type ProjectFile struct {
+	// Workers are the project's background workers and job queues
+	Workers *Workers
+
+	// Cron are scheduled jobs mc can't find on its own
+	Cron []CronJob
+
+	// Licenses is the dependency license policy
+	Licenses *Licenses
+
+	// Artifacts are built files whose size is tracked, or budgets for
+	// the ones mc finds on its own
+	Artifacts []Artifact
+}
+
+// Artifact is a build output whose size mc tracks, declared in .mc.toml.
+// Naming one mc already finds (the Go binary, "bundle") only sets its
+// budget.
+//
+//	[[artifact]]
+//	name = "bundle"
+//	path = "dist/assets"  # a file, a directory (its files summed), or a glob
+//	budget = "250KB"
+type Artifact struct {
+	Name   string
+	Path   string // relative to the project
+	Budget int64  // bytes; 0 for none
+}
+
+// Licenses says which dependency licenses a project accepts, by SPDX
+// identifier, pattern, or class ("copyleft", "weak-copyleft"). Allow wins
+// over deny.
+//
+//	[licenses]
+//	deny = ["copyleft", "weak-copyleft"]
+//	allow = ["LGPL-*"]
+type Licenses struct {
+	Allow []string
+	Deny  []string
+}
+
+// CronJob is a scheduled job declared in .mc.toml:
+//
+//	[[cron]]
+//	name = "nightly backup"
+//	schedule = "0 3 * * *"
+//	log = "/var/log/acme/backup.log"  # touched or appended to on each run
+type CronJob struct {
+	Name     string
+	Schedule string // cron expression, in local time
+	Log      string // file whose modification time is the last run
+}
+
+// Workers says how to tell whether a backend's background workers and
+// queues are healthy:
+//
+//	[workers]
+//	health = "https://api.acme.com/health/workers"
+//	queue = "https://api.acme.com/metrics"  # JSON or Prometheus text
+//	max_depth = 500
+type Workers struct {
+	Health   string // URL (or host:port) that answers while the workers are up
+	Queue    string // URL of queue metrics
+	MaxDepth int    // jobs waiting before the queue counts as backed up
+}
+
+// LoadProjectFile reads dir's .mc.toml, returning nil when it has none
+func LoadProjectFile(dir string) (*ProjectFile, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ProjectFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseTOML(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ProjectFileName, err)
+	}
+
+	pf := &ProjectFile{}
+	if t, ok := doc["workers"].(map[string]any); ok {
+		w := &Workers{}
+		w.Health, _ = t["health"].(string)
+		w.Queue, _ = t["queue"].(string)
+		if n, ok := t["max_depth"].(int64); ok {
+			w.MaxDepth = int(n)
+		}
+		pf.Workers = w
+	}
+	if t, ok := doc["licenses"].(map[string]any); ok {
+		pf.Licenses = &Licenses{Allow: stringList(t["allow"]), Deny: stringList(t["deny"])}
+	}
+	artifacts, _ := doc["artifact"].([]map[string]any)
+	for _, t := range artifacts {
+		var a Artifact
+		a.Name, _ = t["name"].(string)
+		a.Path, _ = t["path"].(string)
+		switch b := t["budget"].(type) {
+		case int64:
+			a.Budget = b
+		case string:
+			if a.Budget, err = ParseSize(b); err != nil {
+				return nil, fmt.Errorf("%s: artifact %q: %w", ProjectFileName, a.Name, err)
+			}
+		}
+		if a.Name == "" {
+			a.Name = filepath.Base(a.Path)
+		}
+		if a.Name != "" && a.Name != "." {
+			pf.Artifacts = append(pf.Artifacts, a)
+		}
+	}
+	jobs, _ := doc["cron"].([]map[string]any)
+	for _, t := range jobs {
+		var job CronJob
+		job.Name, _ = t["name"].(string)
+		job.Schedule, _ = t["schedule"].(string)
+		job.Log, _ = t["log"].(string)
+		if job.Schedule != "" {
+			pf.Cron = append(pf.Cron, job)
+		}
+	}
+	return pf, nil
+}
+
+// ParseSize reads a size such as "250KB", "1.5 MB", or "1048576" as
+// bytes. Units are powers of 1024.
+func ParseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for i, unit := range []string{"KB", "MB", "GB"} {
+		if num, ok := strings.CutSuffix(s, unit); ok {
+			s, mult = num, int64(1)<<(10*(i+1))
+			break
+		}
+	}
+	s = strings.TrimSpace(strings.TrimSuffix(s, "B"))
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("can't read size %q", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// stringList reads an array of strings, skipping anything else in it
+func stringList(v any) []string {
+	items, _ := v.([]any)
+	var list []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// parseTOML reads the part of TOML a settings file needs: [tables],
+// [[arrays of tables]], and key = value lines whose values are strings,
+// integers, floats, booleans, or one-line arrays of those. Dotted keys,
+// inline tables, and multi-line values aren't supported.
+func parseTOML(data string) (map[string]any, error) {
+	doc := make(map[string]any)
+	table := doc
+	for n, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(stripTOMLComment(line))
+		if line == "" {
+			continue
+		}
+		lineErr := func(msg string) error { return fmt.Errorf("line %d: %s", n+1, msg) }
+
+		switch {
+		case strings.HasPrefix(line, "[["):
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "[["), "]]"))
+			list, _ := doc[name].([]map[string]any)
+			table = make(map[string]any)
+			doc[name] = append(list, table)
+		case strings.HasPrefix(line, "["):
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"))
+			t, ok := doc[name].(map[string]any)
+			if !ok {
+				t = make(map[string]any)
+				doc[name] = t
+			}
+			table = t
+		default:
+			key, raw, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, lineErr("expected key = value")
+			}
+			value, err := parseTOMLValue(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, lineErr(err.Error())
+			}
+			table[strings.Trim(strings.TrimSpace(key), `"`)] = value
+		}
+	}
+	return doc, nil
+}
+
+// stripTOMLComment drops a # comment that isn't inside a string
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func parseTOMLValue(raw string) (any, error) {
+	switch {
+	case raw == "":
+		return nil, errors.New("missing value")
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	case strings.HasPrefix(raw, `"`):
+		return strconv.Unquote(raw)
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return nil, errors.New("unterminated string")
+		}
+		return raw[1 : len(raw)-1], nil
+	case strings.HasPrefix(raw, "["):
+		if !strings.HasSuffix(raw, "]") {
+			return nil, errors.New("unterminated array")
+		}
+		var values []any
+		for _, item := range splitTOMLArray(raw[1 : len(raw)-1]) {
+			v, err := parseTOMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	digits := strings.ReplaceAll(raw, "_", "")
+	if n, err := strconv.ParseInt(digits, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(digits, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("can't read value %q", raw)
+}
+
+// splitTOMLArray splits an array's items at commas outside strings
+func splitTOMLArray(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		items = append(items, last)
+	}
+	return items
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+// CacheEntry is what a Cache keeps per project
+type CacheEntry = discover.ProjectCache
+
+// Cache stores the last scan of each project, keyed by project path
+type Cache interface {
+	Load(path string) (*CacheEntry, error)
+	Save(path string, entry *CacheEntry) error
+}
+
+// FileCache is the cache the TUI uses: .hustlemc/status.json inside each
+// project, valid for discover.CacheTTL
+type FileCache struct{}
+
+func (FileCache) Load(path string) (*CacheEntry, error) {
+	return discover.LoadProjectCache(path)
+}
+
+func (FileCache) Save(path string, entry *CacheEntry) error {
+	return discover.SaveProjectCache(path, entry)
+}
+
+// cacheEntry captures a scanned project for a Cache
+func cacheEntry(p *Project) *CacheEntry {
+	e := &CacheEntry{
+		Language:     p.Language,
+		GitStatus:    p.Git,
+		GHStatus:     p.GitHub,
+		VercelState:  p.Vercel,
+		NetlifyState: p.Netlify,
+		RenderState:  p.Render,
+		RailwayState: p.Railway,
+		FlyStatus:    p.Fly,
+	}
+	if !p.FirstCommit.IsZero() {
+		e.FirstCommit = p.FirstCommit.Unix()
+	}
+	if !p.LastCommit.IsZero() {
+		e.LastCommit = p.LastCommit.Unix()
+	}
+	return e
+}
+
+// fromCache fills p in from a cache entry
+func fromCache(p *Project, e *CacheEntry) {
+	p.Git = e.GitStatus
+	p.GitHub = e.GHStatus
+	p.Vercel = e.VercelState
+	p.Netlify = e.NetlifyState
+	p.Render = e.RenderState
+	p.Railway = e.RailwayState
+	p.Fly = e.FlyStatus
+	p.Language = e.Language
+	if e.FirstCommit != 0 {
+		p.FirstCommit = time.Unix(e.FirstCommit, 0)
+	}
+	if e.LastCommit != 0 {
+		p.LastCommit = time.Unix(e.LastCommit, 0)
+	}
+}
@@ -0,0 +1,165 @@
+// Package core is mission-control's portfolio scanning as a library:
+// discover projects, read their git, GitHub, and deploy (Vercel,
+// Netlify, Fly.io, Render, Railway) state through providers, and run background
+// work against them, without the TUI.
+//
+//	s := core.NewScanner()
+//	projects, err := s.Scan(ctx)
+//	for _, p := range projects {
+//		if p.Git != nil && p.Git.Behind > 0 {
+//			fmt.Println(p.Name, "is behind")
+//		}
+//	}
+//
+// Everything here goes through the same code the TUI uses, including its
+// per-project cache and MC_RECORD/MC_REPLAY fixtures.
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// GitStatus, GitHubStatus, and FlyStatus are the provider results carried
+// on a Project
+type (
+	GitStatus    = discover.GitStatus
+	GitHubStatus = discover.GitHubStatus
+	FlyStatus    = discover.FlyStatus
+)
+
+// Project is a discovered project and whatever providers have filled in
+type Project struct {
+	Name string
+	Path string // may start with ~/
+	Type string // vercel, swift, cli
+
+	Git         *GitStatus    // nil outside a git repository
+	GitHub      *GitHubStatus // nil when not on GitHub
+	Vercel      string        // latest deployment state, "" when not deployed
+	Netlify     string        // latest production deploy state, "" when not on Netlify
+	Fly         *FlyStatus    // nil when not on Fly.io
+	Render      string        // latest deploy state, "" when not on Render
+	Railway     string        // latest deployment state, "" when not on Railway
+	Language    string
+	FirstCommit time.Time
+	LastCommit  time.Time
+
+	// Errors holds each provider's failure by provider name
+	Errors map[string]error
+}
+
+// DefaultConcurrency is how many projects a Scanner reads at once
+const DefaultConcurrency = 8
+
+// Scanner discovers projects and runs providers against them
+type Scanner struct {
+	Providers   []Provider
+	Cache       Cache
+	Concurrency int
+}
+
+// NewScanner returns a scanner using the default providers and the
+// on-disk project cache
+func NewScanner() *Scanner {
+	return &Scanner{
+		Providers:   DefaultProviders(),
+		Cache:       FileCache{},
+		Concurrency: DefaultConcurrency,
+	}
+}
+
+// Discover lists projects from the discovery cache, running discovery
+// when there is none
+func (s *Scanner) Discover() ([]Project, error) {
+	found, err := discover.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]Project, 0, len(found))
+	for _, d := range found {
+		projects = append(projects, Project{Name: d.Name, Path: d.Path, Type: d.Type})
+	}
+	return projects, nil
+}
+
+// Rediscover walks ~/Projects again before listing
+func (s *Scanner) Rediscover() ([]Project, error) {
+	if err := discover.RunDiscovery(); err != nil {
+		return nil, err
+	}
+	return s.Discover()
+}
+
+// Scan discovers projects and fills each one in from every provider.
+// Provider failures are recorded on the project rather than returned; the
+// error is for discovery failing or ctx ending.
+func (s *Scanner) Scan(ctx context.Context) ([]Project, error) {
+	projects, err := s.Discover()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := s.Concurrency
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range projects {
+		wg.Add(1)
+		go func(p *Project) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				s.ScanProject(ctx, p)
+			case <-ctx.Done():
+			}
+		}(&projects[i])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return projects, err
+	}
+	return projects, nil
+}
+
+// ScanProject fills p in from each provider in turn, then saves it to
+// the cache. It stops early, saving nothing, if ctx ends.
+func (s *Scanner) ScanProject(ctx context.Context, p *Project) {
+	for _, provider := range s.Providers {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := provider.Load(ctx, p); err != nil {
+			if p.Errors == nil {
+				p.Errors = make(map[string]error)
+			}
+			p.Errors[provider.Name()] = err
+		}
+	}
+	// Replayed projects aren't on disk to cache into
+	if s.Cache != nil && !fixture.Replaying() {
+		s.Cache.Save(p.Path, cacheEntry(p))
+	}
+}
+
+// Cached fills p in from the cache alone, as of the last scan. It
+// reports false when the cache has nothing (or nothing fresh) for it.
+func (s *Scanner) Cached(p *Project) bool {
+	if s.Cache == nil {
+		return false
+	}
+	c, err := s.Cache.Load(p.Path)
+	if err != nil || c == nil {
+		return false
+	}
+	fromCache(p, c)
+	return true
+}
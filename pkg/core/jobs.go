@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+// Job is a unit of background work, identified by key (usually the
+// project name) so the same project never has two jobs at once
+type Job struct {
+	Key      string
+	Name     string // what it's doing, e.g. "fetch"
+	Started  time.Time
+	Finished time.Time // zero while running
+	Err      error
+}
+
+// Jobs runs background work with bounded concurrency
+type Jobs struct {
+	// OnDone, when set, is called with each job as it finishes, from the
+	// job's goroutine (so possibly several at once)
+	OnDone func(Job)
+
+	sem     chan struct{}
+	mu      sync.Mutex
+	running map[string]*Job
+	wg      sync.WaitGroup
+}
+
+// NewJobs returns a job manager running at most concurrency jobs at once
+func NewJobs(concurrency int) *Jobs {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	return &Jobs{sem: make(chan struct{}, concurrency), running: make(map[string]*Job)}
+}
+
+// Start queues fn under key. It reports false, running nothing, when a
+// job with that key is already queued or running. A job still waiting
+// for a slot when ctx ends finishes with ctx's error.
+func (j *Jobs) Start(ctx context.Context, key, name string, fn func(context.Context) error) bool {
+	j.mu.Lock()
+	if _, busy := j.running[key]; busy {
+		j.mu.Unlock()
+		return false
+	}
+	job := &Job{Key: key, Name: name}
+	j.running[key] = job
+	j.mu.Unlock()
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+
+		var err error
+		select {
+		case j.sem <- struct{}{}:
+			j.mu.Lock()
+			job.Started = time.Now()
+			j.mu.Unlock()
+			err = fn(ctx)
+			<-j.sem
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		j.mu.Lock()
+		job.Finished = time.Now()
+		job.Err = err
+		delete(j.running, key)
+		done := *job
+		j.mu.Unlock()
+
+		if j.OnDone != nil {
+			j.OnDone(done)
+		}
+	}()
+	return true
+}
+
+// Busy reports whether a job with key is queued or running
+func (j *Jobs) Busy(key string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, ok := j.running[key]
+	return ok
+}
+
+// Running lists queued and running jobs by key. Queued jobs have a zero
+// Started time.
+func (j *Jobs) Running() []Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	out := make([]Job, 0, len(j.running))
+	for _, job := range j.running {
+		out = append(out, *job)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Key < out[b].Key })
+	return out
+}
+
+// Wait blocks until every started job has finished
+func (j *Jobs) Wait() {
+	j.wg.Wait()
+}
+
+// Fetch starts a `git fetch --all --prune` of p as a job keyed by its name
+func (j *Jobs) Fetch(ctx context.Context, p Project) bool {
+	return j.Start(ctx, p.Name, "fetch", func(context.Context) error {
+		return discover.Fetch(p.Path)
+	})
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"context"
+
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+// Provider fills in part of a Project from one source
+type Provider interface {
+	Name() string
+	Load(ctx context.Context, p *Project) error
+}
+
+// ProviderFunc adapts a function to a Provider
+func ProviderFunc(name string, load func(ctx context.Context, p *Project) error) Provider {
+	return providerFunc{name, load}
+}
+
+type providerFunc struct {
+	name string
+	load func(ctx context.Context, p *Project) error
+}
+
+func (f providerFunc) Name() string                               { return f.name }
+func (f providerFunc) Load(ctx context.Context, p *Project) error { return f.load(ctx, p) }
+
+// The built-in providers
+var (
+	// Git reads branch, working tree, remotes, and stash state
+	Git = ProviderFunc("git", func(_ context.Context, p *Project) (err error) {
+		p.Git, err = discover.GetGitStatus(p.Path)
+		return err
+	})
+
+	// History reads the first and last commit times
+	History = ProviderFunc("history", func(_ context.Context, p *Project) error {
+		p.FirstCommit, p.LastCommit = discover.GetGitTimes(p.Path)
+		return nil
+	})
+
+	// GitHub counts open issues and pull requests through the project's
+	// forge: the API with a token, or the gh CLI without one
+	GitHub = ProviderFunc("github", func(_ context.Context, p *Project) (err error) {
+		p.GitHub, err = discover.GetGitHubStatus(p.Path)
+		return err
+	})
+
+	// Vercel reads the latest deployment state from the API, or via the
+	// vercel CLI without a token
+	Vercel = ProviderFunc("vercel", func(_ context.Context, p *Project) (err error) {
+		if p.Type != "vercel" {
+			return nil
+		}
+		p.Vercel, err = discover.GetVercelStatus(p.Path)
+		return err
+	})
+
+	// Netlify reads the latest production deploy state of projects with a
+	// netlify.toml or .netlify link
+	Netlify = ProviderFunc("netlify", func(_ context.Context, p *Project) (err error) {
+		p.Netlify, err = discover.GetNetlifyStatus(p.Path)
+		return err
+	})
+
+	// Fly reads the current release and machine health of the app a
+	// fly.toml names
+	Fly = ProviderFunc("fly", func(_ context.Context, p *Project) (err error) {
+		p.Fly, err = discover.GetFlyStatus(p.Path)
+		return err
+	})
+
+	// Render reads the latest deploy state of the services a render.yaml
+	// defines or the project's settings name
+	Render = ProviderFunc("render", func(_ context.Context, p *Project) (err error) {
+		p.Render, err = discover.GetRenderStatus(p.Name, p.Path)
+		return err
+	})
+
+	// Railway reads the latest deployment state of a linked (or
+	// configured) Railway service
+	Railway = ProviderFunc("railway", func(_ context.Context, p *Project) (err error) {
+		p.Railway, err = discover.GetRailwayStatus(p.Name, p.Path)
+		return err
+	})
+
+	// Language detects the primary language
+	Language = ProviderFunc("language", func(_ context.Context, p *Project) error {
+		p.Language = discover.GetPrimaryLanguage(p.Path)
+		return nil
+	})
+)
+
+// DefaultProviders are the providers a new Scanner runs, cheapest first
+func DefaultProviders() []Provider {
+	return []Provider{Git, History, Language, GitHub, Vercel, Netlify, Fly, Render, Railway}
+}
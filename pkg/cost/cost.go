@@ -0,0 +1,301 @@
+// Package cost fetches monthly cloud spend per project from the cost
+// sources configured in config.json (Vercel, Fly.io, AWS), so projects
+// that are still billing can be weighed against what they earn.
+package cost
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// CacheTTL is how long fetched cost figures are reused
+const CacheTTL = 6 * time.Hour
+
+var (
+	cacheMu    sync.Mutex
+	httpClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// SourceAmount is one source's spend for the month
+type SourceAmount struct {
+	config.CostSource
+	Cents    int    `json:"cents"`
+	Estimate bool   `json:"estimate,omitempty"` // worked out from resources, not billed amounts
+	Err      string `json:"error,omitempty"`
+}
+
+// Report is a project's cloud spend for one month
+type Report struct {
+	Project    string         `json:"project"`
+	Month      string         `json:"month"` // 2006-01
+	FetchedAt  time.Time      `json:"fetched_at"`
+	Sources    []SourceAmount `json:"sources"`
+	TotalCents int            `json:"total_cents"`
+}
+
+// Estimated reports whether any part of the total is an estimate
+func (r *Report) Estimated() bool {
+	for _, src := range r.Sources {
+		if src.Estimate {
+			return true
+		}
+	}
+	return false
+}
+
+func cachePath() string {
+	return filepath.Join(config.Dir(), "costs.json")
+}
+
+func loadCache() map[string]*Report {
+	reports := make(map[string]*Report)
+	if data, err := os.ReadFile(cachePath()); err == nil {
+		json.Unmarshal(data, &reports)
+	}
+	return reports
+}
+
+func cacheKey(project, month string) string {
+	return project + "@" + month
+}
+
+// ForProject returns the project's cloud spend for the month containing
+// t, using the cached report when it's fresh.
+func ForProject(cfg *config.Config, project string, t time.Time) (*Report, error) {
+	sources := cfg.Project(project).Costs
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	month := t.Format("2006-01")
+	key := cacheKey(project, month)
+
+	cacheMu.Lock()
+	cached := loadCache()[key]
+	cacheMu.Unlock()
+	if cached != nil && time.Since(cached.FetchedAt) < CacheTTL && len(cached.Sources) == len(sources) {
+		return cached, nil
+	}
+
+	report := &Report{Project: project, Month: month, FetchedAt: time.Now()}
+	for _, src := range sources {
+		amount := SourceAmount{CostSource: src, Estimate: src.Kind == config.CostFly}
+		cents, err := fetch(cfg, src, t)
+		if err != nil {
+			amount.Err = err.Error()
+		}
+		amount.Cents = cents
+		report.TotalCents += cents
+		report.Sources = append(report.Sources, amount)
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	reports := loadCache()
+	reports[key] = report
+	if data, err := json.MarshalIndent(reports, "", "  "); err == nil {
+		os.MkdirAll(config.Dir(), 0755)
+		os.WriteFile(cachePath(), data, 0644)
+	}
+
+	return report, nil
+}
+
+func fetch(cfg *config.Config, src config.CostSource, t time.Time) (int, error) {
+	switch src.Kind {
+	case config.CostVercel:
+		return fetchVercel(cfg.Token("vercel"), src.ID, t)
+	case config.CostFly:
+		return fetchFly(cfg.Token("fly"), src.ID)
+	case config.CostAWS:
+		return fetchAWS(src.ID, t)
+	}
+	return 0, fmt.Errorf("unknown cost source %q", src.Kind)
+}
+
+// fetchVercel sums a project's charges in t's month from the billing API,
+// which streams FOCUS records (one JSON object per line) tagged with the
+// project they were billed to. id is "project" or "team/project".
+func fetchVercel(token, id string, t time.Time) (int, error) {
+	if token == "" {
+		return 0, fmt.Errorf("no vercel token (set tokens.vercel or MC_VERCEL_TOKEN)")
+	}
+
+	team, project, ok := strings.Cut(id, "/")
+	if !ok {
+		team, project = "", id
+	}
+	start, end := monthRange(t)
+	q := url.Values{}
+	q.Set("from", start.Format(time.RFC3339))
+	q.Set("to", end.Format(time.RFC3339))
+	if team != "" {
+		q.Set("teamId", team)
+	}
+
+	req, err := http.NewRequest("GET", "https://api.vercel.com/v1/billing/charges?"+q.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+
+	var dollars float64
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var charge struct {
+			BilledCost float64           `json:"BilledCost"`
+			Tags       map[string]string `json:"Tags"`
+		}
+		if json.Unmarshal(sc.Bytes(), &charge) != nil {
+			continue
+		}
+		if charge.Tags["ProjectName"] == project || charge.Tags["ProjectId"] == project {
+			dollars += charge.BilledCost
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+	return toCents(dollars), nil
+}
+
+// Fly.io monthly prices in cents, for estimating what an app's machines
+// cost while running all month
+const (
+	flySharedCPU      = 194  // per shared vCPU, 256MB included
+	flyPerformanceCPU = 2100 // per performance vCPU
+	flyMemoryGB       = 500  // per GB beyond what's included
+)
+
+// fetchFly estimates an app's monthly run rate from its started machines.
+// Fly doesn't expose billed amounts per app, so this is what the current
+// machines cost over a full month.
+func fetchFly(token, app string) (int, error) {
+	if token == "" {
+		return 0, fmt.Errorf("no fly token (set tokens.fly or MC_FLY_TOKEN)")
+	}
+
+	req, err := http.NewRequest("GET", "https://api.machines.dev/v1/apps/"+url.PathEscape(app)+"/machines", nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var machines []struct {
+		State  string `json:"state"`
+		Config struct {
+			Guest struct {
+				CPUKind  string `json:"cpu_kind"`
+				CPUs     int    `json:"cpus"`
+				MemoryMB int    `json:"memory_mb"`
+			} `json:"guest"`
+		} `json:"config"`
+	}
+	if err := getJSON(req, &machines); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, m := range machines {
+		if m.State != "started" {
+			continue
+		}
+		g := m.Config.Guest
+		cpus := max(g.CPUs, 1)
+		cpuCents, includedMB := flySharedCPU, 256
+		if g.CPUKind == "performance" {
+			cpuCents, includedMB = flyPerformanceCPU, 2048
+		}
+		total += cpus * cpuCents
+		if extra := g.MemoryMB - cpus*includedMB; extra > 0 {
+			total += extra * flyMemoryGB / 1024
+		}
+	}
+	return total, nil
+}
+
+// fetchAWS reads the month's unblended cost for resources carrying a cost
+// allocation tag through the aws CLI (Cost Explorer). tag is "key=value",
+// or just a value for the "project" key.
+func fetchAWS(tag string, t time.Time) (int, error) {
+	key, value, ok := strings.Cut(tag, "=")
+	if !ok {
+		key, value = "project", tag
+	}
+	start, end := monthRange(t)
+	filter, _ := json.Marshal(map[string]any{
+		"Tags": map[string]any{"Key": key, "Values": []string{value}},
+	})
+
+	cmd := exec.Command("aws", "ce", "get-cost-and-usage",
+		"--time-period", "Start="+start.Format("2006-01-02")+",End="+end.Format("2006-01-02"),
+		"--granularity", "MONTHLY",
+		"--metrics", "UnblendedCost",
+		"--filter", string(filter),
+		"--output", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("aws ce: %w", err)
+	}
+
+	var result struct {
+		ResultsByTime []struct {
+			Total map[string]struct {
+				Amount string `json:"Amount"`
+			} `json:"Total"`
+		} `json:"ResultsByTime"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return 0, err
+	}
+	var dollars float64
+	for _, r := range result.ResultsByTime {
+		var amount float64
+		fmt.Sscan(r.Total["UnblendedCost"].Amount, &amount)
+		dollars += amount
+	}
+	return toCents(dollars), nil
+}
+
+func getJSON(req *http.Request, out interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func monthRange(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+func toCents(dollars float64) int {
+	return int(math.Round(dollars * 100))
+}
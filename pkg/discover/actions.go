@@ -0,0 +1,204 @@
+package discover
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// Actions setting kinds
+const (
+	KindSecret   = "secret"
+	KindVariable = "variable"
+)
+
+// builtinSecrets are provided to every workflow run
+var builtinSecrets = map[string]bool{"GITHUB_TOKEN": true}
+
+// workflowRef matches secrets.NAME and vars.NAME in workflow expressions
+var workflowRef = regexp.MustCompile(`\b(secrets|vars)\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// ActionsEntry is a secret or variable that is configured, referenced by
+// a workflow, or both
+type ActionsEntry struct {
+	Kind      string
+	Name      string
+	Scopes    []string  // where it's set: "repo", "org", "env:<name>"; empty when missing
+	Updated   time.Time // newest change across scopes
+	Workflows []string  // workflow files that reference it
+}
+
+// Missing reports whether a workflow references it but it isn't set
+// anywhere the workflow can read
+func (e ActionsEntry) Missing() bool {
+	return len(e.Scopes) == 0
+}
+
+// Unused reports whether it's set on the repository or an environment
+// but no workflow references it. Organization settings are shared, so
+// they never count as unused.
+func (e ActionsEntry) Unused() bool {
+	if len(e.Workflows) > 0 {
+		return false
+	}
+	for _, s := range e.Scopes {
+		if s != "org" {
+			return true
+		}
+	}
+	return false
+}
+
+// ActionsInventory lists a project's Actions secrets and variables
+// (names and dates only) against what its workflows reference, missing
+// ones first, then unused ones
+func ActionsInventory(projectPath string) ([]ActionsEntry, error) {
+	return fixture.Do("github", "actions-inventory "+projectPath, func() ([]ActionsEntry, error) {
+		return actionsInventory(projectPath)
+	})
+}
+
+func actionsInventory(projectPath string) ([]ActionsEntry, error) {
+	expandedPath := expandPath(projectPath)
+	var cfg *github.ActionsConfig
+	if client, repo, ok := githubRepo(expandedPath); ok {
+		cfg, _ = client.ActionsConfig(repo)
+	}
+	if cfg == nil {
+		var err error
+		if cfg, err = github.ReadActionsConfig(ghGet(expandedPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	byKey := make(map[string]*ActionsEntry)
+	entry := func(kind, name string) *ActionsEntry {
+		name = strings.ToUpper(name)
+		e := byKey[kind+"\x00"+name]
+		if e == nil {
+			e = &ActionsEntry{Kind: kind, Name: name}
+			byKey[kind+"\x00"+name] = e
+		}
+		return e
+	}
+	add := func(kind string, settings []github.ActionsSetting) {
+		for _, s := range settings {
+			e := entry(kind, s.Name)
+			e.Scopes = append(e.Scopes, s.Scope)
+			if s.UpdatedAt.After(e.Updated) {
+				e.Updated = s.UpdatedAt
+			}
+		}
+	}
+	add(KindSecret, cfg.Secrets)
+	add(KindVariable, cfg.Variables)
+
+	refs, _ := WorkflowRefs(expandedPath)
+	for key, files := range refs {
+		kind, name, _ := strings.Cut(key, "\x00")
+		if kind == KindSecret && builtinSecrets[name] {
+			continue
+		}
+		entry(kind, name).Workflows = files
+	}
+
+	entries := make([]ActionsEntry, 0, len(byKey))
+	for _, e := range byKey {
+		entries = append(entries, *e)
+	}
+	rank := func(e ActionsEntry) int {
+		switch {
+		case e.Missing():
+			return 0
+		case e.Unused():
+			return 1
+		}
+		return 2
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return entries, nil
+}
+
+// WorkflowRefs finds the secrets and variables a project's workflows
+// reference, keyed by kind and upper-cased name joined with a NUL, each
+// with the workflow files that use it
+func WorkflowRefs(expandedPath string) (map[string][]string, error) {
+	dir := filepath.Join(expandedPath, ".github", "workflows")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string][]string)
+	for _, f := range files {
+		if ext := filepath.Ext(f.Name()); f.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, m := range workflowRef.FindAllStringSubmatch(string(data), -1) {
+			kind := KindSecret
+			if m[1] == "vars" {
+				kind = KindVariable
+			}
+			key := kind + "\x00" + strings.ToUpper(m[2])
+			if !seen[key] {
+				seen[key] = true
+				refs[key] = append(refs[key], f.Name())
+			}
+		}
+	}
+	return refs, nil
+}
+
+// ghGet fetches a path under the project's repository API URL with gh
+func ghGet(expandedPath string) func(path string, out any) error {
+	return func(path string, out any) error {
+		repo, ok := remoteRepo(expandedPath)
+		if !ok {
+			return errNotGitHub
+		}
+		output, err := exec.Command("gh", "api", "repos/"+repo.String()+"/"+path).Output()
+		if err != nil {
+			if exit, ok := err.(*exec.ExitError); ok && len(exit.Stderr) > 0 {
+				return errors.New(strings.TrimSpace(string(exit.Stderr)))
+			}
+			return err
+		}
+		return json.Unmarshal(output, out)
+	}
+}
+
+// ActionsSettingsURL is the repository settings page where a kind of
+// Actions setting is managed, or "" without a github.com remote
+func ActionsSettingsURL(projectPath, kind string) string {
+	repo, ok := remoteRepo(expandPath(projectPath))
+	if !ok {
+		return ""
+	}
+	page := "secrets"
+	if kind == KindVariable {
+		page = "variables"
+	}
+	return "https://github.com/" + repo.String() + "/settings/" + page + "/actions"
+}
@@ -0,0 +1,91 @@
+package discover
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// ActivityDays is how many days CommitActivity covers
+const ActivityDays = 30
+
+// CommitActivity returns commits per day reachable from HEAD over the
+// last ActivityDays days, oldest day first and today last
+func CommitActivity(projectPath string) ([]int, error) {
+	return fixture.Do("git", "activity "+projectPath, func() ([]int, error) {
+		return commitActivity(projectPath)
+	})
+}
+
+func commitActivity(projectPath string) ([]int, error) {
+	expandedPath := expandPath(projectPath)
+	now := time.Now()
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return commitActivityDirect(expandedPath, now)
+	}
+	defer repo.Close()
+
+	_, head, err := repo.Head()
+	if err != nil {
+		return commitActivityDirect(expandedPath, now)
+	}
+
+	counts := make([]int, ActivityDays)
+	err = repo.Walk(head, func(c *gitrepo.Commit) bool {
+		i, ok := activityDay(c.When, now)
+		if !ok {
+			// Committer time only decreases from here (give or take skew)
+			return !c.When.Before(now)
+		}
+		counts[i]++
+		return true
+	})
+	if err != nil {
+		return commitActivityDirect(expandedPath, now)
+	}
+	return counts, nil
+}
+
+// commitActivityDirect is a fallback using git log
+func commitActivityDirect(expandedPath string, now time.Time) ([]int, error) {
+	since := strconv.Itoa(ActivityDays) + ".days.ago"
+	output, err := exec.Command("git", "-C", expandedPath, "log", "--since="+since, "--format=%ct").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, ActivityDays)
+	for _, line := range strings.Fields(string(output)) {
+		sec, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			continue
+		}
+		if i, ok := activityDay(time.Unix(sec, 0), now); ok {
+			counts[i]++
+		}
+	}
+	return counts, nil
+}
+
+// activityDay maps a time to its slot in the activity window, by local
+// calendar day
+func activityDay(t, now time.Time) (int, bool) {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	t = t.In(now.Location())
+	y, m, d = t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+
+	// Round to absorb DST shifts in the day length
+	ago := int((today.Sub(day) + 12*time.Hour) / (24 * time.Hour))
+	if ago < 0 || ago >= ActivityDays {
+		return 0, false
+	}
+	return ActivityDays - 1 - ago, true
+}
@@ -0,0 +1,221 @@
+package discover
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// artifactsShown is how many recent workflow artifacts are listed
+const artifactsShown = 30
+
+// Checksum verification results
+const (
+	ChecksumOK       = "verified"
+	ChecksumMismatch = "mismatch"
+	ChecksumNone     = "unverified" // nothing published to check against
+)
+
+// checksumFiles are release assets that list other assets' SHA-256 sums,
+// in `sha256sum` format
+var checksumFiles = []string{"checksums.txt", "sha256sums", "sha256sums.txt", "sha256sum.txt"}
+
+// Download is a file fetched to disk and how its checksum compared. A
+// download whose checksum didn't match is discarded, so File isn't there.
+type Download struct {
+	File     string
+	SHA256   string
+	Expected string // "" when nothing was published to check against
+}
+
+// Result is ChecksumOK, ChecksumMismatch, or ChecksumNone
+func (d Download) Result() string {
+	switch {
+	case d.Expected == "":
+		return ChecksumNone
+	case strings.EqualFold(d.Expected, d.SHA256):
+		return ChecksumOK
+	}
+	return ChecksumMismatch
+}
+
+// Artifacts lists a project's newest workflow artifacts
+func Artifacts(projectPath string) ([]github.Artifact, error) {
+	return fixture.Do("github", "artifacts "+projectPath, func() ([]github.Artifact, error) {
+		expandedPath := expandPath(projectPath)
+		if client, repo, ok := githubRepo(expandedPath); ok {
+			if artifacts, err := client.Artifacts(repo, artifactsShown); err == nil {
+				return artifacts, nil
+			}
+		}
+		var page struct {
+			Artifacts []github.Artifact `json:"artifacts"`
+		}
+		err := ghGet(expandedPath)("actions/artifacts?per_page=30", &page)
+		return page.Artifacts, err
+	})
+}
+
+// LatestReleaseAssets reads a project's latest GitHub release with its
+// assets, nil when it has none
+func LatestReleaseAssets(projectPath string) (*github.Release, error) {
+	return fixture.Do("github", "release-assets "+projectPath, func() (*github.Release, error) {
+		expandedPath := expandPath(projectPath)
+		if client, repo, ok := githubRepo(expandedPath); ok {
+			rel, err := client.LatestRelease(repo)
+			var apiErr *github.APIError
+			if errors.As(err, &apiErr) && apiErr.Status == 404 {
+				return nil, nil
+			}
+			if err == nil {
+				return rel, nil
+			}
+		}
+		var rel github.Release
+		if err := ghGet(expandedPath)("releases/latest", &rel); err != nil {
+			if strings.Contains(err.Error(), "Not Found") {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &rel, nil
+	})
+}
+
+// DownloadArtifact fetches an artifact's zip into dir, checking it
+// against the digest GitHub recorded when there is one
+func DownloadArtifact(projectPath string, a github.Artifact, dir string) (Download, error) {
+	return download(expandPath(projectPath), func(repo string) string {
+		return github.ArtifactPath(repo, a.ID)
+	}, filepath.Join(dir, a.Name+".zip"), strings.TrimPrefix(a.Digest, "sha256:"))
+}
+
+// DownloadAsset fetches a release asset into dir, checking it against
+// its recorded digest or else a checksums file in the same release
+func DownloadAsset(projectPath string, rel *github.Release, asset github.ReleaseAsset, dir string) (Download, error) {
+	return download(expandPath(projectPath), func(repo string) string {
+		return github.AssetPath(repo, asset.ID)
+	}, filepath.Join(dir, asset.Name), AssetChecksum(projectPath, rel, asset))
+}
+
+// AssetChecksum finds the SHA-256 published for a release asset: its
+// digest, or its line in the release's checksums file. It is "" when
+// neither has one.
+func AssetChecksum(projectPath string, rel *github.Release, asset github.ReleaseAsset) string {
+	if sum, ok := strings.CutPrefix(asset.Digest, "sha256:"); ok {
+		return sum
+	}
+	for _, a := range rel.Assets {
+		name := strings.ToLower(a.Name)
+		isSums := name == strings.ToLower(asset.Name)+".sha256"
+		for _, f := range checksumFiles {
+			isSums = isSums || name == f || strings.HasSuffix(name, "_"+f) || strings.HasSuffix(name, "-"+f)
+		}
+		if !isSums || a.ID == asset.ID {
+			continue
+		}
+		var buf bytes.Buffer
+		err := fetch(expandPath(projectPath), func(repo string) string {
+			return github.AssetPath(repo, a.ID)
+		}, &buf)
+		if err != nil {
+			continue
+		}
+		if sum := findChecksum(buf.String(), asset.Name); sum != "" {
+			return sum
+		}
+	}
+	return ""
+}
+
+// findChecksum reads name's sum from `sha256sum` output; a .sha256 file
+// holding only the sum counts too
+func findChecksum(sums, name string) string {
+	scanner := bufio.NewScanner(strings.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 1 && len(fields[0]) == 64:
+			return strings.ToLower(fields[0])
+		// "<sha256>  <name>", with "*<name>" in binary mode
+		case len(fields) == 2 && filepath.Base(strings.TrimPrefix(fields[1], "*")) == name:
+			return strings.ToLower(fields[0])
+		}
+	}
+	return ""
+}
+
+// HashFile returns a file's SHA-256
+func HashFile(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// download fetches a REST path into file, hashing it on the way and
+// checking the hash against expected unless it is "". It is written
+// beside file first, so a failed download or a checksum mismatch leaves
+// nothing behind.
+func download(expandedPath string, path func(repo string) string, file, expected string) (Download, error) {
+	d := Download{File: file, Expected: expected}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return d, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".mc-download-*")
+	if err != nil {
+		return d, err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	err = fetch(expandedPath, path, io.MultiWriter(tmp, h))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return d, err
+	}
+	d.SHA256 = hex.EncodeToString(h.Sum(nil))
+	if d.Result() == ChecksumMismatch {
+		return d, nil
+	}
+	return d, os.Rename(tmp.Name(), file)
+}
+
+// fetch streams a REST path's raw content into w, through the API or gh
+func fetch(expandedPath string, path func(repo string) string, w io.Writer) error {
+	if client, repo, ok := githubRepo(expandedPath); ok {
+		return client.Download(path(repo.String()), w)
+	}
+	repo, ok := remoteRepo(expandedPath)
+	if !ok {
+		return errNotGitHub
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("gh", "api", "-H", "Accept: application/octet-stream", path(repo.String()))
+	cmd.Stdout, cmd.Stderr = w, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
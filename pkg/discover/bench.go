@@ -0,0 +1,268 @@
+package discover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// ErrNoBenchmarks means a project isn't a Go or Rust one
+var ErrNoBenchmarks = errors.New("benchmarks run for Go and Rust projects")
+
+// benchRegression is how much slower than its baseline a benchmark has to
+// get to be flagged; less is run-to-run noise on a laptop
+const benchRegression = 0.10
+
+// benchRuns caps how many runs bench.json keeps
+const benchRuns = 100
+
+// BenchResult is one benchmark's time in a run, and in the baseline
+type BenchResult struct {
+	Name     string
+	NsPerOp  float64
+	Baseline float64 // 0 when the baseline didn't run it
+}
+
+// Change is how much slower (positive) or faster (negative) the benchmark
+// got than its baseline, as a fraction: 0.25 is 25% slower
+func (r BenchResult) Change() float64 {
+	if r.Baseline == 0 {
+		return 0
+	}
+	return r.NsPerOp/r.Baseline - 1
+}
+
+// Regressed reports whether the benchmark got significantly slower
+func (r BenchResult) Regressed() bool {
+	return r.Change() > benchRegression
+}
+
+// BenchReport is a project's latest benchmark run, compared with the
+// latest run at an earlier commit
+type BenchReport struct {
+	Tool     string // go or cargo
+	Commit   string // "" before the first run
+	At       time.Time
+	Baseline string // the baseline's commit, "" when there is none
+	Results  []BenchResult
+}
+
+// Regressions are the benchmarks that got significantly slower, worst
+// first
+func (r *BenchReport) Regressions() []BenchResult {
+	var slower []BenchResult
+	for _, res := range r.Results {
+		if res.Regressed() {
+			slower = append(slower, res)
+		}
+	}
+	sort.Slice(slower, func(i, j int) bool { return slower[i].Change() > slower[j].Change() })
+	return slower
+}
+
+// benchRun is one recorded run: ns/op by benchmark name
+type benchRun struct {
+	At      time.Time          `json:"at"`
+	Commit  string             `json:"commit"`
+	Tool    string             `json:"tool"`
+	Results map[string]float64 `json:"results"`
+}
+
+func benchFile(projectPath string) string {
+	return filepath.Join(ProjectCacheDir(projectPath), "bench.json")
+}
+
+// BenchCommand is the command RunBenchmarks runs: go test's benchmarks
+// for a Go module, cargo bench for a Rust crate
+func BenchCommand(projectPath string) ([]string, error) {
+	root := expandPath(projectPath)
+	if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+		return []string{"go", "test", "-run", "^$", "-bench", ".", "./..."}, nil
+	}
+	if _, err := os.Stat(filepath.Join(root, "Cargo.toml")); err == nil {
+		return []string{"cargo", "bench"}, nil
+	}
+	return nil, ErrNoBenchmarks
+}
+
+// LoadBenchmarks reads a project's latest recorded benchmark run without
+// running anything. It returns an empty report for a Go or Rust project
+// that has none yet, and nil for other projects.
+func LoadBenchmarks(projectPath string) (*BenchReport, error) {
+	return fixture.Do("tools", "bench "+projectPath, func() (*BenchReport, error) {
+		if _, err := BenchCommand(projectPath); err != nil {
+			return nil, nil
+		}
+		return benchReport(readBenchRuns(projectPath)), nil
+	})
+}
+
+// RunBenchmarks runs a project's benchmarks and records the results
+// against HEAD, replacing an earlier run at the same commit. The last
+// run at another commit is the baseline it's compared with.
+func RunBenchmarks(ctx context.Context, projectPath string) (*BenchReport, error) {
+	args, err := BenchCommand(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	root := expandPath(projectPath)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = root
+	out, err := cmd.CombinedOutput()
+	results := parseBenchOutput(string(out))
+	if err != nil {
+		lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+		if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+			return nil, errors.New(last)
+		}
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, errors.New("no benchmarks found")
+	}
+
+	run := benchRun{At: time.Now(), Tool: args[0], Results: results}
+	if out, err := exec.Command("git", "-C", root, "rev-parse", "--short", "HEAD").Output(); err == nil {
+		run.Commit = strings.TrimSpace(string(out))
+	}
+	runs := readBenchRuns(projectPath)
+	if n := len(runs); n > 0 && runs[n-1].Commit == run.Commit {
+		runs = runs[:n-1]
+	}
+	runs = append(runs, run)
+	if len(runs) > benchRuns {
+		runs = runs[len(runs)-benchRuns:]
+	}
+	if data, err := json.MarshalIndent(runs, "", "  "); err == nil {
+		if os.MkdirAll(ProjectCacheDir(projectPath), 0755) == nil {
+			os.WriteFile(benchFile(projectPath), data, 0644)
+		}
+	}
+	return benchReport(runs), nil
+}
+
+func readBenchRuns(projectPath string) []benchRun {
+	var runs []benchRun
+	if data, err := os.ReadFile(benchFile(projectPath)); err == nil {
+		json.Unmarshal(data, &runs)
+	}
+	return runs
+}
+
+// benchReport compares the latest run with the latest at another commit
+func benchReport(runs []benchRun) *BenchReport {
+	report := &BenchReport{}
+	if len(runs) == 0 {
+		return report
+	}
+	latest := runs[len(runs)-1]
+	report.Tool, report.Commit, report.At = latest.Tool, latest.Commit, latest.At
+	var baseline benchRun
+	for i := len(runs) - 2; i >= 0; i-- {
+		if runs[i].Commit != latest.Commit {
+			baseline = runs[i]
+			report.Baseline = baseline.Commit
+			break
+		}
+	}
+	for name, ns := range latest.Results {
+		report.Results = append(report.Results, BenchResult{Name: name, NsPerOp: ns, Baseline: baseline.Results[name]})
+	}
+	sort.Slice(report.Results, func(i, j int) bool { return report.Results[i].Name < report.Results[j].Name })
+	return report
+}
+
+// parseBenchOutput reads ns/op from go test's benchmark lines, libtest's
+// "bench:" lines, and criterion's "time:" estimates
+func parseBenchOutput(out string) map[string]float64 {
+	results := make(map[string]float64)
+	pkg, prev := "", ""
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		fields := strings.Fields(line)
+		switch {
+		case strings.HasPrefix(line, "pkg: "):
+			pkg = filepath.Base(strings.TrimPrefix(line, "pkg: "))
+
+		// BenchmarkParse-8   	  52813	     22541 ns/op
+		case strings.HasPrefix(line, "Benchmark") && len(fields) >= 4:
+			for i := 2; i+1 < len(fields); i++ {
+				if fields[i+1] != "ns/op" {
+					continue
+				}
+				if ns, err := strconv.ParseFloat(fields[i], 64); err == nil {
+					name := fields[0]
+					// Drop the GOMAXPROCS suffix so runs on other machines compare
+					if i := strings.LastIndex(name, "-"); i > 0 {
+						if _, err := strconv.Atoi(name[i+1:]); err == nil {
+							name = name[:i]
+						}
+					}
+					if pkg != "" {
+						name = pkg + "." + name
+					}
+					results[name] = ns
+				}
+				break
+			}
+
+		// test parse_large ... bench:      12,345 ns/iter (+/- 678)
+		case strings.HasPrefix(line, "test ") && strings.Contains(line, " bench: "):
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line[:strings.Index(line, " bench: ")], "test "), "..."))
+			rest := strings.Fields(line[strings.Index(line, " bench: ")+len(" bench: "):])
+			if len(rest) >= 2 && rest[1] == "ns/iter" {
+				if ns, err := strconv.ParseFloat(strings.ReplaceAll(rest[0], ",", ""), 64); err == nil {
+					results[name] = ns
+				}
+			}
+
+		// fib 20   time:   [26.029 µs 26.251 µs 26.505 µs]
+		case strings.Contains(line, "time:") && strings.Contains(line, "["):
+			name := strings.TrimSpace(line[:strings.Index(line, "time:")])
+			if name == "" {
+				// Long names get a line of their own
+				name = prev
+			}
+			est := strings.Fields(strings.Trim(line[strings.Index(line, "[")+1:], "]"))
+			if name != "" && len(est) == 6 {
+				if ns, ok := benchNanos(est[2], est[3]); ok {
+					results[name] = ns
+				}
+			}
+		}
+		if line != "" {
+			prev = line
+		}
+	}
+	return results
+}
+
+// benchNanos converts criterion's "26.251" "µs" to nanoseconds
+func benchNanos(value, unit string) (float64, bool) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch unit {
+	case "ps":
+		return v / 1e3, true
+	case "ns":
+		return v, true
+	case "µs", "us":
+		return v * 1e3, true
+	case "ms":
+		return v * 1e6, true
+	case "s":
+		return v * 1e9, true
+	}
+	return 0, false
+}
@@ -0,0 +1,67 @@
+package discover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// Board reads a GitHub Projects board ("owner/3" or its URL) from the
+// API, or via gh when no token is available
+func Board(ref string) (*github.Board, error) {
+	return fixture.Do("github", "board "+ref, func() (*github.Board, error) {
+		owner, number, ok := github.ParseBoard(ref)
+		if !ok {
+			return nil, fmt.Errorf("invalid board %q (want owner/number or the project URL)", ref)
+		}
+		if client := github.Default(); client != nil {
+			if b, err := client.Board(owner, number); err == nil {
+				return b, nil
+			}
+		}
+		return github.ReadBoard(ghGraphQL, owner, number)
+	})
+}
+
+// MoveBoardItem puts a board item in another Status column
+func MoveBoardItem(b *github.Board, itemID, columnID string) error {
+	if client := github.Default(); client != nil {
+		if err := client.MoveBoardItem(b, itemID, columnID); err == nil {
+			return nil
+		}
+	}
+	return github.MoveBoardItem(ghGraphQL, b, itemID, columnID)
+}
+
+// ghGraphQL runs a GraphQL query with gh, for when there's no token
+func ghGraphQL(query string, vars map[string]any, out any) error {
+	args := []string{"api", "graphql", "-f", "query=" + query}
+	for k, v := range vars {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			args = append(args, "-f", k+"="+v)
+		default:
+			args = append(args, "-F", fmt.Sprintf("%s=%v", k, v))
+		}
+	}
+	output, err := exec.Command("gh", args...).Output()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok && len(exit.Stderr) > 0 {
+			return errors.New(strings.TrimSpace(string(exit.Stderr)))
+		}
+		return err
+	}
+	var result struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return err
+	}
+	return json.Unmarshal(result.Data, out)
+}
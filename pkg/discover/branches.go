@@ -0,0 +1,109 @@
+package discover
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Branch is a local or remote-tracking branch
+type Branch struct {
+	Name    string // short name: "main" or "origin/main"
+	Remote  bool   // remote-tracking branch
+	Current bool   // checked out in the worktree
+}
+
+// LocalName returns the branch name without its remote prefix
+func (b Branch) LocalName() string {
+	if !b.Remote {
+		return b.Name
+	}
+	if _, name, ok := strings.Cut(b.Name, "/"); ok {
+		return name
+	}
+	return b.Name
+}
+
+// ListBranches returns local branches followed by remote-tracking
+// branches, each sorted by name.
+func ListBranches(projectPath string) ([]Branch, error) {
+	return fixture.Do("git", "branches "+projectPath, func() ([]Branch, error) {
+		return listBranches(projectPath)
+	})
+}
+
+func listBranches(projectPath string) ([]Branch, error) {
+	expandedPath := expandPath(projectPath)
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return listBranchesDirect(expandedPath)
+	}
+	defer repo.Close()
+
+	current, _ := repo.Branch()
+	local, err := repo.Refs("refs/heads/")
+	if err != nil {
+		return listBranchesDirect(expandedPath)
+	}
+	remote, err := repo.Refs("refs/remotes/")
+	if err != nil {
+		return listBranchesDirect(expandedPath)
+	}
+
+	var branches []Branch
+	for ref := range local {
+		name := strings.TrimPrefix(ref, "refs/heads/")
+		branches = append(branches, Branch{Name: name, Current: name == current})
+	}
+	for ref := range remote {
+		name := strings.TrimPrefix(ref, "refs/remotes/")
+		if strings.HasSuffix(name, "/HEAD") {
+			continue
+		}
+		branches = append(branches, Branch{Name: name, Remote: true})
+	}
+
+	sortBranches(branches)
+	return branches, nil
+}
+
+// listBranchesDirect is a fallback using git for-each-ref
+func listBranchesDirect(expandedPath string) ([]Branch, error) {
+	cmd := exec.Command("git", "-C", expandedPath, "for-each-ref",
+		"--format=%(HEAD)%(refname)", "refs/heads", "refs/remotes")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []Branch
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		current := line[0] == '*'
+		ref := line[1:]
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			branches = append(branches, Branch{Name: strings.TrimPrefix(ref, "refs/heads/"), Current: current})
+		case strings.HasPrefix(ref, "refs/remotes/") && !strings.HasSuffix(ref, "/HEAD"):
+			branches = append(branches, Branch{Name: strings.TrimPrefix(ref, "refs/remotes/"), Remote: true})
+		}
+	}
+
+	sortBranches(branches)
+	return branches, nil
+}
+
+func sortBranches(branches []Branch) {
+	sort.Slice(branches, func(i, j int) bool {
+		if branches[i].Remote != branches[j].Remote {
+			return !branches[i].Remote
+		}
+		return branches[i].Name < branches[j].Name
+	})
+}
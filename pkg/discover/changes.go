@@ -0,0 +1,65 @@
+package discover
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// FileChange is one path from `git status --porcelain`
+type FileChange struct {
+	Path     string
+	OrigPath string // rename/copy source
+	Index    byte   // X: staged state ('M', 'A', 'D', 'R', ' ', '?')
+	Worktree byte   // Y: unstaged state
+}
+
+// Untracked reports whether the file isn't known to git
+func (f FileChange) Untracked() bool {
+	return f.Index == '?'
+}
+
+// Staged reports whether the file has staged changes
+func (f FileChange) Staged() bool {
+	return f.Index != ' ' && f.Index != '?'
+}
+
+// Unstaged reports whether the file has changes not yet staged
+func (f FileChange) Unstaged() bool {
+	return f.Worktree != ' '
+}
+
+// ChangedFiles lists a project's changed and untracked files
+func ChangedFiles(projectPath string) ([]FileChange, error) {
+	return fixture.Do("git", "changes "+projectPath, func() ([]FileChange, error) {
+		return changedFiles(projectPath)
+	})
+}
+
+func changedFiles(projectPath string) ([]FileChange, error) {
+	cmd := exec.Command("git", "-C", expandPath(projectPath), "status", "--porcelain=v1", "-z")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FileChange
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i < len(fields); i++ {
+		entry := fields[i]
+		if len(entry) < 4 {
+			continue
+		}
+		c := FileChange{Index: entry[0], Worktree: entry[1], Path: entry[3:]}
+		// Renames and copies are followed by their source path
+		if c.Index == 'R' || c.Index == 'C' {
+			if i+1 < len(fields) {
+				c.OrigPath = fields[i+1]
+				i++
+			}
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
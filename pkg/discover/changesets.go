@@ -0,0 +1,234 @@
+package discover
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// Release tools a ReleasePlan can come from
+const (
+	ReleaseChangesets = "changesets"
+	ReleaseNx         = "nx"
+	ReleaseLerna      = "lerna"
+)
+
+// Steps of a release flow, as ReleaseFlowCommand takes them
+const (
+	ReleaseStepVersion = "version"
+	ReleaseStepPublish = "publish"
+)
+
+// ReleasePlan is what a JS monorepo's release tool would release next:
+// the packages its changesets or nx version plans bump, or, for lerna and
+// nx without version plans, the packages changed since their last tag
+type ReleasePlan struct {
+	Tool    string
+	Plans   int    // changeset or version plan files waiting
+	Pre     string // the prerelease tag while changesets is in pre mode
+	Pending []PendingRelease
+}
+
+// PendingRelease is one package a ReleasePlan would release
+type PendingRelease struct {
+	Package string
+	Bump    string // major, minor, patch, ...; "" when the tool decides from commits
+}
+
+// bumpRank orders bumps so a package gets the largest any plan asks for
+var bumpRank = map[string]int{"none": 0, "prerelease": 1, "patch": 2, "prepatch": 2, "minor": 3, "preminor": 3, "major": 4, "premajor": 4}
+
+// LoadReleasePlan reads a project's pending releases from changesets
+// (.changeset/*.md), nx version plans (.nx/version-plans/*.md), or, with
+// lerna.json or nx release and no plans, the packages changed since their
+// last release. It returns nil when the project uses none of them.
+func LoadReleasePlan(projectPath string) (*ReleasePlan, error) {
+	return fixture.Do("git", "releaseplan "+projectPath, func() (*ReleasePlan, error) {
+		return loadReleasePlan(expandPath(projectPath))
+	})
+}
+
+func loadReleasePlan(root string) (*ReleasePlan, error) {
+	if _, err := os.Stat(filepath.Join(root, ".changeset", "config.json")); err == nil {
+		plan := &ReleasePlan{Tool: ReleaseChangesets}
+		var pre struct {
+			Mode string `json:"mode"`
+			Tag  string `json:"tag"`
+		}
+		if data, err := os.ReadFile(filepath.Join(root, ".changeset", "pre.json")); err == nil {
+			if json.Unmarshal(data, &pre) == nil && pre.Mode == "pre" {
+				plan.Pre = pre.Tag
+			}
+		}
+		return plan, readPlanFiles(plan, filepath.Join(root, ".changeset"))
+	}
+
+	var nx struct {
+		Release *struct {
+			VersionPlans json.RawMessage `json:"versionPlans"`
+		} `json:"release"`
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "nx.json")); err == nil {
+		json.Unmarshal(data, &nx)
+	}
+	if nx.Release != nil {
+		plan := &ReleasePlan{Tool: ReleaseNx}
+		// versionPlans is true or an object of options
+		if v := strings.TrimSpace(string(nx.Release.VersionPlans)); v != "" && v != "false" && v != "null" {
+			return plan, readPlanFiles(plan, filepath.Join(root, ".nx", "version-plans"))
+		}
+		return plan, changedPackages(plan, root)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "lerna.json")); err == nil {
+		plan := &ReleasePlan{Tool: ReleaseLerna}
+		return plan, changedPackages(plan, root)
+	}
+	return nil, nil
+}
+
+// readPlanFiles adds up the bumps in a directory of changesets or version
+// plans: Markdown files whose front matter maps packages to bumps,
+//
+//	---
+//	"@acme/ui": minor
+//	"@acme/api": patch
+//	---
+func readPlanFiles(plan *ReleasePlan, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	bumps := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") || strings.EqualFold(e.Name(), "README.md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		plan.Plans++
+		for pkg, bump := range planFrontMatter(string(data)) {
+			if cur, ok := bumps[pkg]; !ok || bumpRank[bump] > bumpRank[cur] {
+				bumps[pkg] = bump
+			}
+		}
+	}
+	for pkg, bump := range bumps {
+		if bump != "none" {
+			plan.Pending = append(plan.Pending, PendingRelease{Package: pkg, Bump: bump})
+		}
+	}
+	sort.Slice(plan.Pending, func(i, j int) bool { return plan.Pending[i].Package < plan.Pending[j].Package })
+	return nil
+}
+
+// planFrontMatter reads the package: bump lines between a plan file's
+// --- fences
+func planFrontMatter(text string) map[string]string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil
+	}
+	bumps := make(map[string]string)
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "---" {
+			break
+		}
+		pkg, bump, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		pkg = unquoteYAML(strings.TrimSpace(pkg))
+		if bump = strings.ToLower(unquoteYAML(strings.TrimSpace(bump))); pkg != "" && bump != "" {
+			bumps[pkg] = bump
+		}
+	}
+	return bumps
+}
+
+// changedPackages marks every npm package changed since its last release
+// tag as pending, the tool deciding the bump from the commits
+func changedPackages(plan *ReleasePlan, root string) error {
+	pkgs, err := packages(root)
+	if err != nil {
+		return err
+	}
+	for _, p := range pkgs {
+		if p.Ecosystem == "npm" && p.Changed > 0 {
+			plan.Pending = append(plan.Pending, PendingRelease{Package: p.Name})
+		}
+	}
+	return nil
+}
+
+// ReleaseFlowCommand is the command a step of the plan's release flow
+// runs, from the repository root: `changeset version` or `changeset
+// publish`, `nx release --skip-publish` or `nx release publish`, and
+// `lerna version --no-push` or `lerna publish from-git`
+func ReleaseFlowCommand(projectPath string, plan *ReleasePlan, step string) []string {
+	root := expandPath(projectPath)
+	var run []string
+	switch jsPackageManager(root) {
+	case "pnpm":
+		run = []string{"pnpm", "exec"}
+	case "yarn":
+		run = []string{"yarn"}
+	case "bun":
+		run = []string{"bunx"}
+	default:
+		run = []string{"npx", "--no-install"}
+	}
+
+	publish := step == ReleaseStepPublish
+	switch plan.Tool {
+	case ReleaseChangesets:
+		if publish {
+			return append(run, "changeset", "publish")
+		}
+		return append(run, "changeset", "version")
+	case ReleaseNx:
+		if publish {
+			return append(run, "nx", "release", "publish")
+		}
+		return append(run, "nx", "release", "--skip-publish", "--yes")
+	case ReleaseLerna:
+		if publish {
+			return append(run, "lerna", "publish", "from-git", "--yes")
+		}
+		// Pushing is left to the user, after a look at the bumps
+		return append(run, "lerna", "version", "--no-push", "--yes")
+	}
+	return nil
+}
+
+// RunReleaseFlow runs a step of a project's release flow, returning the
+// tail of its output
+func RunReleaseFlow(projectPath string, plan *ReleasePlan, step string) (string, error) {
+	args := ReleaseFlowCommand(projectPath, plan, step)
+	if args == nil {
+		return "", errors.New("no release tool")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = expandPath(projectPath)
+	// Nothing can answer a prompt (an npm one-time password, say) behind
+	// the TUI, so tools should fail rather than wait
+	cmd.Env = append(os.Environ(), "CI=1")
+	out, err := cmd.CombinedOutput()
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	if len(lines) > checkOutputLines {
+		lines = lines[len(lines)-checkOutputLines:]
+	}
+	return strings.Join(lines, "\n"), err
+}
@@ -0,0 +1,51 @@
+package discover
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// conflictsDirect is a fallback using git directly. It returns the
+// operation in progress and the paths left unmerged.
+func conflictsDirect(expandedPath string) (string, []string) {
+	var conflicts []string
+	if out, err := exec.Command("git", "-C", expandedPath, "diff", "--name-only", "--diff-filter=U").Output(); err == nil {
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			if line != "" {
+				conflicts = append(conflicts, line)
+			}
+		}
+	}
+
+	out, err := exec.Command("git", "-C", expandedPath, "rev-parse", "--absolute-git-dir").Output()
+	if err != nil {
+		return "", conflicts
+	}
+	gitDir := strings.TrimSpace(string(out))
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+
+	op := ""
+	switch {
+	case exists("rebase-merge"):
+		op = gitrepo.OpRebase
+	case exists("rebase-apply"):
+		op = gitrepo.OpRebase
+		if exists(filepath.Join("rebase-apply", "applying")) {
+			op = gitrepo.OpAm
+		}
+	case exists("MERGE_HEAD"):
+		op = gitrepo.OpMerge
+	case exists("CHERRY_PICK_HEAD"):
+		op = gitrepo.OpCherryPick
+	case exists("REVERT_HEAD"):
+		op = gitrepo.OpRevert
+	}
+	return op, conflicts
+}
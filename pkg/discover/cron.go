@@ -0,0 +1,302 @@
+package discover
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/schedule"
+)
+
+// cronGrace is how late a job's run may be before it counts as stopped,
+// for runners such as GitHub Actions that start scheduled runs late
+const cronGrace = time.Hour
+
+// Sources of scheduled jobs
+const (
+	CronGitHub  = "github"  // a workflow's on.schedule
+	CronVercel  = "vercel"  // vercel.json crons
+	CronProject = "mc.toml" // .mc.toml [[cron]]
+	CronTab     = "crontab" // the user's crontab, for lines mentioning the project
+)
+
+// CronJob is one of a project's scheduled jobs
+type CronJob struct {
+	Name      string
+	Schedule  string // the cron expression
+	Source    string // CronGitHub, CronVercel, CronProject, or CronTab
+	LastRun   time.Time
+	LastState string // success, failure, ... ("" when unknown)
+	URL       string // the last run's page
+	Next      time.Time
+	Stopped   bool   // a run it should have had since its last one never came
+	Err       string // why its schedule or last run couldn't be read
+}
+
+// CronJobs lists a project's scheduled jobs: GitHub scheduled workflows,
+// Vercel crons, jobs declared in .mc.toml, and crontab lines that mention
+// the project, with when each last ran and runs next
+func CronJobs(projectPath string) ([]CronJob, error) {
+	return fixture.Do("github", "cron "+projectPath, func() ([]CronJob, error) {
+		return cronJobs(projectPath, time.Now())
+	})
+}
+
+func cronJobs(projectPath string, now time.Time) ([]CronJob, error) {
+	expandedPath := expandPath(projectPath)
+	var jobs []CronJob
+	jobs = append(jobs, githubCronJobs(expandedPath)...)
+	jobs = append(jobs, vercelCronJobs(expandedPath)...)
+	pf, err := config.LoadProjectFile(expandedPath)
+	if pf != nil {
+		for _, c := range pf.Cron {
+			job := CronJob{Name: c.Name, Schedule: c.Schedule, Source: CronProject}
+			if job.Name == "" {
+				job.Name = c.Schedule
+			}
+			if c.Log != "" {
+				job.LastRun = modTime(expandPath(c.Log))
+			}
+			jobs = append(jobs, job)
+		}
+	}
+	jobs = append(jobs, crontabJobs(expandedPath)...)
+
+	for i := range jobs {
+		scheduleJob(&jobs[i], now)
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].Stopped && !jobs[j].Stopped })
+	return jobs, err
+}
+
+// scheduleJob works out a job's next run and whether it has stopped.
+// GitHub and Vercel run schedules in UTC, crontab in local time.
+func scheduleJob(job *CronJob, now time.Time) {
+	s, err := schedule.Parse(job.Schedule)
+	if err != nil {
+		if job.Err == "" {
+			job.Err = err.Error()
+		}
+		return
+	}
+	if job.Source == CronGitHub || job.Source == CronVercel {
+		now = now.UTC()
+	}
+	job.Next = s.Next(now)
+	if !job.LastRun.IsZero() {
+		// The run after the last one is due; the one after that proves
+		// it was missed rather than just late
+		due := s.Next(s.Next(job.LastRun.In(now.Location())))
+		job.Stopped = job.Stopped || (!due.IsZero() && due.Add(cronGrace).Before(now))
+	}
+}
+
+// githubCronJobs lists the project's workflows with a schedule trigger,
+// one job per cron expression, with its last scheduled run
+func githubCronJobs(expandedPath string) []CronJob {
+	dir := filepath.Join(expandedPath, ".github", "workflows")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var get github.GetFunc
+	var jobs []CronJob
+	for _, f := range files {
+		if ext := filepath.Ext(f.Name()); f.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		name, crons := parseWorkflowSchedule(f.Name(), string(data))
+		if len(crons) == 0 {
+			continue
+		}
+
+		if get == nil {
+			get = repoGet(expandedPath)
+		}
+		var last CronJob
+		if run, err := github.LatestScheduledRun(get, f.Name()); err != nil {
+			last.Err = err.Error()
+		} else if run != nil {
+			last.LastRun, last.URL = run.CreatedAt, run.URL
+			last.LastState = run.Conclusion
+			if last.LastState == "" {
+				last.LastState = run.Status
+			}
+		}
+		// GitHub turns off schedules in repositories without activity for
+		// 60 days, which is the usual way they stop without anyone noticing
+		if state, err := github.WorkflowState(get, f.Name()); err == nil && strings.HasPrefix(state, "disabled") {
+			last.Stopped = true
+			last.Err = state
+		}
+		for _, cron := range crons {
+			job := last
+			job.Name, job.Schedule, job.Source = name, cron, CronGitHub
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// parseWorkflowSchedule reads a workflow's name and on.schedule cron
+// expressions
+func parseWorkflowSchedule(file, data string) (name string, crons []string) {
+	root := parseYAML(data)
+	name = file
+	if n := root.child("name"); n != nil && n.value != "" {
+		name = n.value
+	}
+	sched := root.child("on").child("schedule")
+	if sched == nil {
+		return name, nil
+	}
+	// Items come through as the raw `cron: "..."` mapping
+	for _, item := range sched.items {
+		if expr, ok := strings.CutPrefix(item, "cron:"); ok {
+			crons = append(crons, unquoteYAML(expr))
+		}
+	}
+	return name, crons
+}
+
+// vercelCronJobs lists the crons in a project's vercel.json. Vercel
+// doesn't report their runs outside its dashboard, so only the next run
+// is known.
+func vercelCronJobs(expandedPath string) []CronJob {
+	data, err := os.ReadFile(filepath.Join(expandedPath, "vercel.json"))
+	if err != nil {
+		return nil
+	}
+	var cfg struct {
+		Crons []struct {
+			Path     string `json:"path"`
+			Schedule string `json:"schedule"`
+		} `json:"crons"`
+	}
+	if json.Unmarshal(data, &cfg) != nil {
+		return nil
+	}
+	jobs := make([]CronJob, 0, len(cfg.Crons))
+	for _, c := range cfg.Crons {
+		jobs = append(jobs, CronJob{Name: c.Path, Schedule: c.Schedule, Source: CronVercel})
+	}
+	return jobs
+}
+
+// crontabJobs lists the user's crontab lines that mention the project's
+// directory. A line appending its output to a file (>> run.log) last ran
+// when that file last changed.
+func crontabJobs(expandedPath string) []CronJob {
+	out, err := exec.Command("crontab", "-l").Output()
+	if err != nil {
+		return nil
+	}
+	var jobs []CronJob
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || !mentionsDir(line, expandedPath) {
+			continue
+		}
+		fields := strings.Fields(line)
+		n := 5
+		if strings.HasPrefix(fields[0], "@") {
+			n = 1
+		}
+		if len(fields) <= n {
+			continue
+		}
+		job := CronJob{
+			Schedule: strings.Join(fields[:n], " "),
+			Name:     truncateCommand(strings.Join(fields[n:], " ")),
+			Source:   CronTab,
+		}
+		if log := redirectTarget(fields[n:]); log != "" {
+			if !filepath.IsAbs(log) && !strings.HasPrefix(log, "~") {
+				log = filepath.Join(expandedPath, log)
+			}
+			job.LastRun = modTime(expandPath(log))
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// mentionsDir reports whether a crontab line refers to dir, spelled out
+// or with ~
+func mentionsDir(line, dir string) bool {
+	if containsPath(line, dir) {
+		return true
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, ok := strings.CutPrefix(dir, home); ok && containsPath(line, "~"+rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPath reports whether path appears in line as a whole path or a
+// parent of one, so ~/code/app doesn't match ~/code/app-old
+func containsPath(line, path string) bool {
+	for i := 0; ; {
+		j := strings.Index(line[i:], path)
+		if j < 0 {
+			return false
+		}
+		end := i + j + len(path)
+		if end == len(line) || strings.ContainsRune("/ \t'\"", rune(line[end])) {
+			return true
+		}
+		i += j + 1
+	}
+}
+
+// redirectTarget returns the file a command's output is written to
+func redirectTarget(args []string) string {
+	for i, arg := range args {
+		for _, op := range []string{">>", "1>>", ">", "1>", "&>>", "&>"} {
+			target, ok := strings.CutPrefix(arg, op)
+			if !ok {
+				continue
+			}
+			if target == "" && i+1 < len(args) {
+				target = args[i+1]
+			}
+			if target != "" && !strings.HasPrefix(target, "&") && target != "/dev/null" {
+				return target
+			}
+		}
+	}
+	return ""
+}
+
+// truncateCommand shortens a crontab command to a name
+func truncateCommand(cmd string) string {
+	if i := strings.IndexAny(cmd, "><|;"); i > 0 {
+		cmd = strings.TrimSpace(cmd[:i])
+	}
+	if len(cmd) > 48 {
+		cmd = cmd[:47] + "…"
+	}
+	return cmd
+}
+
+// modTime is a file's modification time, zero when it can't be read
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
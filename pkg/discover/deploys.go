@@ -0,0 +1,235 @@
+package discover
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/netlify"
+	"github.com/michaelmonetized/mission-control/pkg/railway"
+	"github.com/michaelmonetized/mission-control/pkg/render"
+	"github.com/michaelmonetized/mission-control/pkg/vercel"
+)
+
+// Deployment is one deployment on a hosting provider
+type Deployment struct {
+	Provider string // vercel, netlify, fly, render, or railway
+	Service  string // which of the project's services, when it has several
+	Site     string // the Netlify site or Render service ID, or the Fly.io app
+	ID       string
+	URL      string // host name, without https://
+	LogsURL  string // the provider's page for it, with its build logs
+	State    string // ready, building, queued, failed, canceled
+	Target   string // production, or "" for a preview
+	Created  time.Time
+	Duration time.Duration // building and deploying, 0 until it's done
+	SHA      string        // commit deployed, when built from git
+	Ref      string
+	PR       int    // the pull request a preview was built for, when the provider says
+	Message  string // the commit's subject, or what the deploy says it is
+	Author   string
+	Image    string // the image a Fly.io release runs
+}
+
+// deployProviders read a project's deployments on each provider, nil for
+// projects not on it
+var deployProviders = []func(name, projectPath string, n int) ([]Deployment, error){
+	func(_, projectPath string, n int) ([]Deployment, error) { return RecentDeploys(projectPath, n) },
+	netlifyDeploys,
+	flyDeploys,
+	renderDeploys,
+	railwayDeploys,
+}
+
+// DeployHistory returns a project's newest n deployments across every
+// provider it deploys to, newest first. One provider failing doesn't hide
+// the others; its error is returned with what was read.
+func DeployHistory(name, projectPath string, n int) ([]Deployment, error) {
+	var deploys []Deployment
+	var firstErr error
+	for _, read := range deployProviders {
+		more, err := read(name, projectPath, n)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		deploys = append(deploys, more...)
+	}
+	sort.SliceStable(deploys, func(i, j int) bool { return deploys[i].Created.After(deploys[j].Created) })
+	if len(deploys) > n {
+		deploys = deploys[:n]
+	}
+	return deploys, firstErr
+}
+
+// RecentDeploys returns a Vercel project's newest n deployments, newest
+// first, or nil for projects that don't deploy to Vercel. They come from
+// the API when there is a token, and the vercel CLI otherwise.
+func RecentDeploys(projectPath string, n int) ([]Deployment, error) {
+	return fixture.Do("vercel", fmt.Sprintf("deploys %d %s", n, projectPath), func() ([]Deployment, error) {
+		return recentDeploys(projectPath, n)
+	})
+}
+
+func recentDeploys(projectPath string, n int) ([]Deployment, error) {
+	expandedPath := expandPath(projectPath)
+	if _, err := os.Stat(filepath.Join(expandedPath, ".vercel")); err != nil {
+		return nil, nil
+	}
+	if client := vercel.Default(); client != nil {
+		if link, err := vercel.ReadLink(expandedPath); err == nil {
+			raw, err := client.Deployments(link, n)
+			if err != nil {
+				return nil, err
+			}
+			return vercelDeployments(raw), nil
+		}
+	}
+
+	cmd := exec.Command("vercel", "ls", "--json", "-n", fmt.Sprint(n))
+	cmd.Dir = expandedPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("vercel ls: %w", err)
+	}
+
+	// vercel ls prints the API's deployments as they are
+	var raw []vercel.Deployment
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, err
+	}
+	return vercelDeployments(raw), nil
+}
+
+// Rollback puts an earlier production deployment back live: Vercel
+// promotes it, Netlify restores it, and Render and Railway redeploy it.
+// Fly.io apps roll back by deploying the release's image again, which
+// takes fly deploy, so they aren't handled here.
+func Rollback(name, projectPath string, d Deployment) error {
+	switch d.Provider {
+	case "vercel":
+		client := vercel.Default()
+		if client == nil {
+			return vercel.ErrNoToken
+		}
+		link, err := vercel.ReadLink(expandPath(projectPath))
+		if err != nil {
+			return err
+		}
+		return client.Promote(link, d.ID)
+	case "netlify":
+		client := netlify.Default()
+		if client == nil {
+			return netlify.ErrNoToken
+		}
+		return client.RestoreDeploy(d.Site, d.ID)
+	case "render":
+		client := render.Default()
+		if client == nil {
+			return render.ErrNoToken
+		}
+		return client.Rollback(d.Site, d.ID)
+	case "railway":
+		client := railway.Default()
+		if client == nil {
+			return railway.ErrNoToken
+		}
+		return client.Rollback(d.ID)
+	}
+	return fmt.Errorf("can't roll back %s deploys here", d.Provider)
+}
+
+// vercelDeployments converts the API's deployments
+func vercelDeployments(raw []vercel.Deployment) []Deployment {
+	deploys := make([]Deployment, 0, len(raw))
+	for _, d := range raw {
+		deploy := Deployment{
+			Provider: "vercel",
+			ID:       d.UID,
+			URL:      d.URL,
+			LogsURL:  d.InspectorURL,
+			State:    vercelState(d.State),
+			Target:   d.Target,
+			Created:  time.UnixMilli(d.Created),
+			SHA:      d.Meta.SHA,
+			Ref:      d.Meta.Ref,
+			Message:  firstLine(d.Meta.Message),
+			Author:   d.Meta.Author,
+		}
+		if deploy.Author == "" {
+			deploy.Author = d.Creator.Username
+		}
+		deploy.PR, _ = strconv.Atoi(d.Meta.PR)
+		if d.Ready > 0 && d.BuildingAt > 0 {
+			deploy.Duration = time.UnixMilli(d.Ready).Sub(time.UnixMilli(d.BuildingAt))
+		}
+		deploys = append(deploys, deploy)
+	}
+	return deploys
+}
+
+// vercelState is a deployment's state as mc shows it: ready, building,
+// queued, failed, or canceled
+func vercelState(state string) string {
+	switch state = strings.ToLower(state); state {
+	case "error":
+		return "failed"
+	case "initializing":
+		return "building"
+	}
+	return state
+}
+
+// firstLine is a commit message's subject
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(line)
+}
+
+// hostOf strips the scheme from a URL, as Deployment.URL holds it
+func hostOf(u string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://"), "/")
+}
+
+// PreviewDeploys returns a project's newest n preview deployments on the
+// providers that build one per branch or pull request, Vercel and
+// Netlify, newest first
+func PreviewDeploys(name, projectPath string, n int) ([]Deployment, error) {
+	var previews []Deployment
+	var firstErr error
+	readers := []func(name, projectPath string, n int) ([]Deployment, error){
+		func(_, projectPath string, n int) ([]Deployment, error) { return RecentDeploys(projectPath, n) },
+		netlifyDeploys,
+	}
+	for _, read := range readers {
+		deploys, err := read(name, projectPath, n)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		for _, d := range deploys {
+			if d.Target != "production" {
+				previews = append(previews, d)
+			}
+		}
+	}
+	sort.SliceStable(previews, func(i, j int) bool { return previews[i].Created.After(previews[j].Created) })
+	return previews, firstErr
+}
+
+// PreviewFor returns a pull request's newest preview among deploys (newest
+// first): the one built for it, by the number the provider recorded, else
+// the newest built from its branch
+func PreviewFor(deploys []Deployment, pr PullRequest) (Deployment, bool) {
+	for _, d := range deploys {
+		if d.PR == pr.Number || (d.PR == 0 && pr.HeadRef != "" && d.Ref == pr.HeadRef) {
+			return d, true
+		}
+	}
+	return Deployment{}, false
+}
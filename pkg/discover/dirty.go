@@ -0,0 +1,59 @@
+package discover
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DirtySince returns the modification time of a project's oldest
+// uncommitted change, or zero when the tree is clean. Deleted files have
+// no mtime and don't count.
+func DirtySince(projectPath string) time.Time {
+	expandedPath := expandPath(projectPath)
+	changes, err := ChangedFiles(expandedPath)
+	if err != nil {
+		return time.Time{}
+	}
+	paths := make([]string, len(changes))
+	for i, c := range changes {
+		paths[i] = c.Path
+	}
+	return dirtySince(expandedPath, paths)
+}
+
+// dirtySince is DirtySince over changed paths already listed, relative
+// to the work tree with untracked directories ending in "/"
+func dirtySince(expandedPath string, paths []string) time.Time {
+	var oldest time.Time
+	note := func(t time.Time) {
+		if oldest.IsZero() || t.Before(oldest) {
+			oldest = t
+		}
+	}
+
+	for _, path := range paths {
+		full := filepath.Join(expandedPath, filepath.FromSlash(strings.TrimSuffix(path, "/")))
+		info, err := os.Lstat(full)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			note(info.ModTime())
+			continue
+		}
+		// An untracked directory: its oldest file is the oldest change
+		filepath.WalkDir(full, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				note(info.ModTime())
+			}
+			return nil
+		})
+	}
+	return oldest
+}
@@ -2,6 +2,7 @@ package discover
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,6 +10,11 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+	"github.com/michaelmonetized/mission-control/pkg/vercel"
 )
 
 // cacheMutex protects concurrent updates to project cache files
@@ -29,12 +35,47 @@ type GitStatus struct {
 	Branch    string
 	Ahead     int
 	Behind    int
+	Stashes   int
+
+	// Submodules and how many have uncommitted changes or a checkout
+	// that doesn't match the recorded commit (uninitialized included)
+	Submodules          []Submodule
+	SubmodulesDirty     int
+	SubmodulesOutOfDate int
+
+	// Merge, rebase, cherry-pick, etc. in progress ("" when none) and
+	// the files it left conflicted
+	Operation string
+	Conflicts []string
+
+	// Remotes, with ahead/behind of the current branch against each
+	Remotes []Remote
+
+	// DirtySince is the mtime of the oldest uncommitted change (zero
+	// when clean)
+	DirtySince time.Time
+
+	// Release is the newest tag on the default branch, nil when nothing
+	// has been tagged
+	Release *Release
 }
 
 // GitHubStatus holds GitHub repo status
 type GitHubStatus struct {
 	Issues int
 	PRs    int
+
+	// Work waiting on me: open issues assigned to me and open pull
+	// requests asking for my review
+	Assigned        int
+	ReviewRequested int
+
+	// Audience, for open source projects: stars (and how many came this
+	// week), forks, and watchers
+	Stars     int
+	StarsWeek int
+	Forks     int
+	Watchers  int
 }
 
 // ProjectCache holds cached status for a project
@@ -44,6 +85,10 @@ type ProjectCache struct {
 	GitStatus   *GitStatus  `json:"git_status,omitempty"`
 	GHStatus    *GitHubStatus `json:"gh_status,omitempty"`
 	VercelState string      `json:"vercel_state,omitempty"`
+	NetlifyState string     `json:"netlify_state,omitempty"`
+	RenderState string      `json:"render_state,omitempty"`
+	RailwayState string     `json:"railway_state,omitempty"`
+	FlyStatus   *FlyStatus  `json:"fly_status,omitempty"`
 	FirstCommit int64       `json:"first_commit,omitempty"` // Unix timestamp
 	LastCommit  int64       `json:"last_commit,omitempty"`  // Unix timestamp
 }
@@ -122,30 +167,32 @@ func UpdateProjectCache(projectPath string, updates func(*ProjectCache)) error {
 	return SaveProjectCache(projectPath, cache)
 }
 
-// LoadProjects loads projects from cache or runs discovery
+// LoadProjects loads projects from cache or runs discovery.
+// Older cache formats are migrated in place; caches that can't be
+// migrated (corrupt, or written by a newer mc) are regenerated.
 func LoadProjects() ([]Project, error) {
-	cacheFile := filepath.Join(CacheDir(), "projects.json")
-	
-	// Check if cache exists
-	if _, err := os.Stat(cacheFile); os.IsNotExist(err) {
-		// Run discovery
-		if err := RunDiscovery(); err != nil {
-			return nil, err
-		}
+	return fixture.Do("projects", "list", func() ([]Project, error) {
+		return loadProjects()
+	})
+}
+
+func loadProjects() ([]Project, error) {
+	projects, err := readProjectsCache()
+	if err == nil {
+		return projects, nil
 	}
-	
-	// Read cache
-	data, err := os.ReadFile(cacheFile)
-	if err != nil {
+
+	var incompatible *errIncompatibleCache
+	if !os.IsNotExist(err) && !errors.As(err, &incompatible) {
 		return nil, err
 	}
-	
-	var projects []Project
-	if err := json.Unmarshal(data, &projects); err != nil {
+
+	// Missing or incompatible cache - run discovery to regenerate it
+	if err := RunDiscovery(); err != nil {
 		return nil, err
 	}
-	
-	return projects, nil
+
+	return readProjectsCache()
 }
 
 // RunDiscovery runs the mc-discover script
@@ -159,6 +206,12 @@ func RunDiscovery() error {
 
 // GetGitStatus returns git status for a project using mc-git-status script
 func GetGitStatus(projectPath string) (*GitStatus, error) {
+	return fixture.Do("git", "status "+projectPath, func() (*GitStatus, error) {
+		return getGitStatus(projectPath)
+	})
+}
+
+func getGitStatus(projectPath string) (*GitStatus, error) {
 	expandedPath := expandPath(projectPath)
 	
 	// Check if it's a git repo
@@ -170,38 +223,24 @@ func GetGitStatus(projectPath string) (*GitStatus, error) {
 	// Git status changes frequently, so we always fetch fresh
 	// But we still save to cache for reference
 	
-	// Use mc-git-status script (PATH lookup with fallback)
-	binPath := getBinPath("mc-git-status")
-	
-	cmd := exec.Command(binPath, expandedPath, "--json")
-	output, err := cmd.Output()
-	
-	var status *GitStatus
+	// Read the repo natively first so a refresh doesn't spawn a process
+	// per project; fall back to the script/CLI for layouts it can't handle
+	status, err := getGitStatusNative(expandedPath)
 	if err != nil {
-		// Fallback to direct git
-		status, _ = getGitStatusDirect(expandedPath)
-	} else {
-		var result struct {
-			Branch    string `json:"branch"`
-			Untracked int    `json:"untracked"`
-			Modified  int    `json:"modified"`
-			Staged    int    `json:"staged"`
-			Ahead     int    `json:"ahead"`
-			Behind    int    `json:"behind"`
-		}
-		if err := json.Unmarshal(output, &result); err != nil {
-			status, _ = getGitStatusDirect(expandedPath)
-		} else {
-			status = &GitStatus{
-				Branch:    result.Branch,
-				Untracked: result.Untracked,
-				Modified:  result.Modified,
-				Staged:    result.Staged,
-				Ahead:     result.Ahead,
-				Behind:    result.Behind,
+		status = getGitStatusExec(expandedPath)
+		if status != nil {
+			status.Submodules, _ = ListSubmodules(expandedPath)
+			status.Operation, status.Conflicts = conflictsDirect(expandedPath)
+			status.Remotes, _ = listRemotesDirect(expandedPath)
+			if status.Staged+status.Modified+status.Untracked > 0 {
+				status.DirtySince = DirtySince(expandedPath)
 			}
 		}
 	}
+	if status != nil {
+		status.SubmodulesDirty, status.SubmodulesOutOfDate = countSubmodules(status.Submodules)
+		status.Release, _ = latestRelease(expandedPath)
+	}
 	
 	// Update cache
 	if status != nil {
@@ -213,6 +252,82 @@ func GetGitStatus(projectPath string) (*GitStatus, error) {
 	return status, nil
 }
 
+// getGitStatusExec gets status via the mc-git-status script, then git itself
+func getGitStatusExec(expandedPath string) *GitStatus {
+	// Use mc-git-status script (PATH lookup with fallback)
+	binPath := getBinPath("mc-git-status")
+
+	cmd := exec.Command(binPath, expandedPath, "--json")
+	output, err := cmd.Output()
+	if err != nil {
+		// Fallback to direct git
+		status, _ := getGitStatusDirect(expandedPath)
+		return status
+	}
+
+	var result struct {
+		Branch    string `json:"branch"`
+		Untracked int    `json:"untracked"`
+		Modified  int    `json:"modified"`
+		Staged    int    `json:"staged"`
+		Ahead     int    `json:"ahead"`
+		Behind    int    `json:"behind"`
+		Stashes   int    `json:"stashes"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		status, _ := getGitStatusDirect(expandedPath)
+		return status
+	}
+
+	return &GitStatus{
+		Branch:    result.Branch,
+		Untracked: result.Untracked,
+		Modified:  result.Modified,
+		Staged:    result.Staged,
+		Ahead:     result.Ahead,
+		Behind:    result.Behind,
+		Stashes:   result.Stashes,
+	}
+}
+
+// getGitStatusNative reads status straight from .git without running git
+func getGitStatusNative(expandedPath string) (*GitStatus, error) {
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return nil, err
+	}
+	defer repo.Close()
+
+	s, err := repo.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	subs, err := submodulesNative(repo)
+	if err != nil {
+		subs, _ = listSubmodulesDirect(expandedPath)
+	}
+	remotes, err := remotesNative(repo)
+	if err != nil {
+		remotes, _ = listRemotesDirect(expandedPath)
+	}
+
+	return &GitStatus{
+		Branch:     s.Branch,
+		Untracked:  s.Untracked,
+		Modified:   s.Modified,
+		Staged:     s.Staged,
+		Ahead:      s.Ahead,
+		Behind:     s.Behind,
+		Stashes:    s.Stashes,
+		Submodules: subs,
+		Operation:  s.Operation,
+		Conflicts:  s.Conflicts,
+		Remotes:    remotes,
+		DirtySince: dirtySince(expandedPath, s.Changed),
+	}, nil
+}
+
 // getGitStatusDirect is a fallback using git directly
 func getGitStatusDirect(expandedPath string) (*GitStatus, error) {
 	status := &GitStatus{}
@@ -248,64 +363,72 @@ func getGitStatusDirect(expandedPath string) (*GitStatus, error) {
 			status.Modified++
 		}
 	}
+
+	if out, err := exec.Command("git", "-C", expandedPath, "stash", "list").Output(); err == nil {
+		status.Stashes = strings.Count(string(out), "\n")
+	}
 	
 	return status, nil
 }
 
-// GetGitHubStatus returns GitHub status (issues/PRs) for a project using mc-gh-status script
+// GetGitHubStatus returns the issue and PR status of a project from its forge, or for
+// GitHub projects the forge can't read the mc-gh-status script. Merge requests count as PRs.
 func GetGitHubStatus(projectPath string) (*GitHubStatus, error) {
+	return fixture.Do("github", "status "+projectPath, func() (*GitHubStatus, error) {
+		return getGitHubStatus(projectPath)
+	})
+}
+
+func getGitHubStatus(projectPath string) (*GitHubStatus, error) {
 	expandedPath := expandPath(projectPath)
+
+	f, ok := forge.Open(expandedPath)
+	if !ok {
+		return &GitHubStatus{}, nil
+	}
+	c, err := f.Counts()
+	if err == nil {
+		return statusFromCounts(c), nil
+	}
+	if f.Remote().Forge != forge.GitHub {
+		return nil, err
+	}
 	
 	// Use mc-gh-status script (PATH lookup with fallback)
 	binPath := getBinPath("mc-gh-status")
 	
 	cmd := exec.Command(binPath, expandedPath, "--json")
-	output, err := cmd.Output()
-	if err != nil {
-		return getGitHubStatusDirect(expandedPath)
+	output, scriptErr := cmd.Output()
+	if scriptErr != nil {
+		return nil, err
 	}
 	
 	var result struct {
-		Issues int `json:"issues"`
-		PRs    int `json:"prs"`
+		Issues          int `json:"issues"`
+		PRs             int `json:"prs"`
+		Assigned        int `json:"assigned"`
+		ReviewRequested int `json:"review_requested"`
 	}
-	if err := json.Unmarshal(output, &result); err != nil {
-		return getGitHubStatusDirect(expandedPath)
+	if json.Unmarshal(output, &result) != nil {
+		return nil, err
 	}
 	
 	return &GitHubStatus{
-		Issues: result.Issues,
-		PRs:    result.PRs,
+		Issues:          result.Issues,
+		PRs:             result.PRs,
+		Assigned:        result.Assigned,
+		ReviewRequested: result.ReviewRequested,
 	}, nil
 }
 
-// getGitHubStatusDirect is a fallback using gh directly
-func getGitHubStatusDirect(expandedPath string) (*GitHubStatus, error) {
-	status := &GitHubStatus{}
-	
-	cmd := exec.Command("gh", "issue", "list", "--state", "open", "--json", "number", "-q", "length")
-	cmd.Dir = expandedPath
-	output, err := cmd.Output()
-	if err == nil {
-		var count int
-		json.Unmarshal(output, &count)
-		status.Issues = count
-	}
-	
-	cmd = exec.Command("gh", "pr", "list", "--state", "open", "--json", "number", "-q", "length")
-	cmd.Dir = expandedPath
-	output, err = cmd.Output()
-	if err == nil {
-		var count int
-		json.Unmarshal(output, &count)
-		status.PRs = count
-	}
-	
-	return status, nil
-}
-
 // GetVercelStatus returns the latest deployment status using mc-vl-status script
 func GetVercelStatus(projectPath string) (string, error) {
+	return fixture.Do("vercel", "status "+projectPath, func() (string, error) {
+		return getVercelStatus(projectPath)
+	})
+}
+
+func getVercelStatus(projectPath string) (string, error) {
 	expandedPath := expandPath(projectPath)
 	
 	// Check if it's a Vercel project
@@ -313,6 +436,20 @@ func GetVercelStatus(projectPath string) (string, error) {
 	if _, err := os.Stat(vercelDir); os.IsNotExist(err) {
 		return "", nil
 	}
+
+	// The API is quicker and steadier than the CLI, so try it first
+	if client := vercel.Default(); client != nil {
+		if link, err := vercel.ReadLink(expandedPath); err == nil {
+			deploys, err := client.Deployments(link, 1)
+			if err != nil {
+				return "unknown", err
+			}
+			if len(deploys) == 0 {
+				return "ready", nil
+			}
+			return vercelState(deploys[0].State), nil
+		}
+	}
 	
 	// Use mc-vl-status script (PATH lookup with fallback)
 	binPath := getBinPath("mc-vl-status")
@@ -370,6 +507,13 @@ func getVercelStatusDirect(expandedPath string) (string, error) {
 
 // GetPrimaryLanguage uses mc-tokei-lang-perc to detect the primary language
 func GetPrimaryLanguage(projectPath string) string {
+	lang, _ := fixture.Do("git", "language "+projectPath, func() (string, error) {
+		return getPrimaryLanguage(projectPath), nil
+	})
+	return lang
+}
+
+func getPrimaryLanguage(projectPath string) string {
 	expandedPath := expandPath(projectPath)
 
 	// Check cache first (language doesn't change often)
@@ -410,6 +554,15 @@ func GetPrimaryLanguage(projectPath string) string {
 
 // GetGitTimes returns the first commit time (project age) and last commit time
 func GetGitTimes(projectPath string) (firstCommit, lastCommit time.Time) {
+	type times struct{ First, Last time.Time }
+	t, _ := fixture.Do("git", "times "+projectPath, func() (times, error) {
+		first, last := getGitTimes(projectPath)
+		return times{first, last}, nil
+	})
+	return t.First, t.Last
+}
+
+func getGitTimes(projectPath string) (firstCommit, lastCommit time.Time) {
 	expandedPath := expandPath(projectPath)
 
 	// Check if it's a git repo
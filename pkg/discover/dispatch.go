@@ -0,0 +1,296 @@
+package discover
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// WorkflowInput is one input a workflow_dispatch workflow takes
+type WorkflowInput struct {
+	Name        string
+	Description string
+	Type        string // string, choice, boolean, number, environment
+	Default     string
+	Required    bool
+	Options     []string // for choice inputs
+}
+
+// DispatchWorkflow is a workflow that can be started by hand
+type DispatchWorkflow struct {
+	File   string // file name under .github/workflows
+	Name   string // the workflow's name, as its runs are listed
+	Inputs []WorkflowInput
+}
+
+// DispatchWorkflows lists a project's workflows with a workflow_dispatch
+// trigger, by file name
+func DispatchWorkflows(projectPath string) ([]DispatchWorkflow, error) {
+	dir := filepath.Join(expandPath(projectPath), ".github", "workflows")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var workflows []DispatchWorkflow
+	for _, f := range files {
+		if ext := filepath.Ext(f.Name()); f.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		if wf, ok := parseDispatch(f.Name(), string(data)); ok {
+			workflows = append(workflows, wf)
+		}
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].File < workflows[j].File })
+	return workflows, nil
+}
+
+// yamlNode is a mapping key with its value, list items, and nested keys.
+// It covers the shape of a workflow's triggers, not YAML in general.
+type yamlNode struct {
+	key      string
+	value    string
+	items    []string
+	children []*yamlNode
+}
+
+func (n *yamlNode) child(key string) *yamlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.children {
+		if c.key == key {
+			return c
+		}
+	}
+	return nil
+}
+
+// parseYAML reads block mappings, "- item" lists, [a, b] flow lists, and
+// | or > block scalars; anything else is kept as a plain value
+func parseYAML(data string) *yamlNode {
+	type open struct {
+		indent int
+		node   *yamlNode
+	}
+	root := &yamlNode{}
+	stack := []open{{-1, root}}
+	lines := strings.Split(data, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := stripYAMLComment(strings.TrimRight(lines[i], " \t\r"))
+		text := strings.TrimSpace(line)
+		if text == "" || text == "---" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		for len(stack) > 1 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1].node
+
+		if item, ok := strings.CutPrefix(text, "- "); ok || text == "-" {
+			parent.items = append(parent.items, unquoteYAML(item))
+			continue
+		}
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			continue
+		}
+		node := &yamlNode{key: unquoteYAML(key), value: strings.TrimSpace(value)}
+		switch {
+		case strings.HasPrefix(node.value, "|") || strings.HasPrefix(node.value, ">"):
+			// Block scalar: the more-indented lines that follow
+			var text []string
+			for i+1 < len(lines) {
+				next := strings.TrimRight(lines[i+1], " \t\r")
+				if strings.TrimSpace(next) != "" && len(next)-len(strings.TrimLeft(next, " ")) <= indent {
+					break
+				}
+				text = append(text, strings.TrimSpace(next))
+				i++
+			}
+			sep := "\n"
+			if node.value[0] == '>' {
+				sep = " "
+			}
+			node.value = strings.TrimSpace(strings.Join(text, sep))
+		case strings.HasPrefix(node.value, "["):
+			for _, item := range strings.Split(strings.Trim(node.value, "[]"), ",") {
+				if item = unquoteYAML(item); item != "" {
+					node.items = append(node.items, item)
+				}
+			}
+		default:
+			node.value = unquoteYAML(node.value)
+		}
+		parent.children = append(parent.children, node)
+		stack = append(stack, open{indent, node})
+	}
+	return root
+}
+
+// stripYAMLComment drops a trailing # comment outside quotes
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return line
+}
+
+func unquoteYAML(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// parseDispatch reads a workflow file's name and workflow_dispatch inputs;
+// ok is false when it can't be started by hand
+func parseDispatch(file, data string) (DispatchWorkflow, bool) {
+	root := parseYAML(data)
+	wf := DispatchWorkflow{File: file, Name: file}
+	if name := root.child("name"); name != nil && name.value != "" {
+		wf.Name = name.value
+	}
+
+	on := root.child("on")
+	if on == nil {
+		return wf, false
+	}
+	dispatch := on.child("workflow_dispatch")
+	if dispatch == nil {
+		found := strings.Contains(on.value, "workflow_dispatch")
+		for _, item := range on.items {
+			found = found || item == "workflow_dispatch"
+		}
+		return wf, found
+	}
+	if inputs := dispatch.child("inputs"); inputs != nil {
+		for _, n := range inputs.children {
+			in := WorkflowInput{Name: n.key, Type: "string"}
+			if c := n.child("description"); c != nil {
+				in.Description = c.value
+			}
+			if c := n.child("type"); c != nil && c.value != "" {
+				in.Type = c.value
+			}
+			if c := n.child("default"); c != nil {
+				in.Default = c.value
+			}
+			if c := n.child("required"); c != nil {
+				in.Required = c.value == "true"
+			}
+			if c := n.child("options"); c != nil {
+				in.Options = c.items
+			}
+			wf.Inputs = append(wf.Inputs, in)
+		}
+	}
+	return wf, true
+}
+
+// RunWorkflow starts a workflow_dispatch workflow on ref with inputs,
+// through the API or gh
+func RunWorkflow(projectPath, file, ref string, inputs map[string]string) error {
+	expandedPath := expandPath(projectPath)
+	if client, repo, ok := githubRepo(expandedPath); ok {
+		return client.DispatchWorkflow(repo, file, ref, inputs)
+	}
+
+	repo, ok := remoteRepo(expandedPath)
+	if !ok {
+		return errNotGitHub
+	}
+	cmd := exec.Command("gh", append(RunWorkflowArgs(file, ref, inputs), "-R", repo.String())...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// RunWorkflowArgs are the gh arguments that start a workflow, inputs in
+// name order
+func RunWorkflowArgs(file, ref string, inputs map[string]string) []string {
+	args := []string{"workflow", "run", file, "--ref", ref}
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "-f", name+"="+inputs[name])
+	}
+	return args
+}
+
+// DispatchedRun finds the run a dispatch started: the newest run of the
+// workflow on ref created since then. It's nil until GitHub lists it.
+func DispatchedRun(projectPath, workflow, ref string, since time.Time) (*WorkflowRun, error) {
+	runs, err := listRuns(expandPath(projectPath), ref, 10)
+	if err != nil {
+		return nil, err
+	}
+	// Allow for the clock here running ahead of GitHub's
+	since = since.Add(-10 * time.Second)
+	for _, r := range runs {
+		if r.Name == workflow && !r.CreatedAt.Before(since) {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+// RunJobs lists a run's jobs and their steps, from the API or via gh when
+// no token is available
+func RunJobs(projectPath string, id int64) ([]github.RunJob, error) {
+	expandedPath := expandPath(projectPath)
+	if client, repo, ok := githubRepo(expandedPath); ok {
+		if jobs, err := client.RunJobs(repo, id); err == nil {
+			return jobs, nil
+		}
+	}
+
+	repo, ok := remoteRepo(expandedPath)
+	if !ok {
+		return nil, errNotGitHub
+	}
+	output, err := exec.Command("gh", "run", "view", strconv.FormatInt(id, 10), "-R", repo.String(), "--json", "jobs").Output()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok && len(exit.Stderr) > 0 {
+			return nil, errors.New(strings.TrimSpace(string(exit.Stderr)))
+		}
+		return nil, err
+	}
+	var view struct {
+		Jobs []github.RunJob `json:"jobs"`
+	}
+	if err := json.Unmarshal(output, &view); err != nil {
+		return nil, err
+	}
+	return view.Jobs, nil
+}
@@ -0,0 +1,176 @@
+package discover
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// deploymentsRead is how many recent GitHub deployments are searched for
+// each environment's latest
+const deploymentsRead = 100
+
+// EnvDeploy is what an environment is running: its latest deployment
+type EnvDeploy struct {
+	Environment string
+	SHA         string
+	Ref         string // branch or tag deployed, when the provider says
+	Created     time.Time
+	State       string // success, failure, in_progress, ... ("" when unknown)
+	URL         string
+	Source      string // "github" deployments, or "vercel"
+}
+
+// EnvDrift is how far one environment's commit is from the next one down
+// the pipeline's
+type EnvDrift struct {
+	From, To string // environment names, From earlier in the pipeline
+	Ahead    int    // commits From has that To doesn't
+	Behind   int
+}
+
+// EnvMatrix is a project's environments in pipeline order, with the drift
+// between neighbours and each one's distance from the default branch
+type EnvMatrix struct {
+	Branch string
+	Envs   []EnvDeploy
+	Drift  []EnvDrift
+	Behind map[string]int // commits on Branch not yet in each environment
+}
+
+// envRank orders environments the way changes flow through them
+func envRank(name string) int {
+	n := strings.ToLower(name)
+	switch {
+	case strings.Contains(n, "prev"), strings.Contains(n, "dev"), strings.Contains(n, "test"):
+		return 0
+	case strings.Contains(n, "stag"), strings.Contains(n, "qa"), strings.Contains(n, "uat"):
+		return 1
+	case strings.Contains(n, "prod"), n == "live":
+		return 3
+	}
+	return 2
+}
+
+// EnvironmentMatrix reads each environment's latest deployment from
+// GitHub deployments (which Vercel, Netlify, Render, and others record),
+// adding Vercel's own when GitHub has none for its targets
+func EnvironmentMatrix(projectPath string) (*EnvMatrix, error) {
+	return fixture.Do("github", "environments "+projectPath, func() (*EnvMatrix, error) {
+		return environmentMatrix(projectPath)
+	})
+}
+
+func environmentMatrix(projectPath string) (*EnvMatrix, error) {
+	expandedPath := expandPath(projectPath)
+	get := repoGet(expandedPath)
+	latest := make(map[string]*EnvDeploy)
+
+	deploys, err := github.ReadDeployments(get, deploymentsRead)
+	if err == nil {
+		// Newest first, so the first seen per environment is its latest
+		for _, d := range deploys {
+			key := strings.ToLower(d.Environment)
+			if latest[key] != nil {
+				continue
+			}
+			env := &EnvDeploy{Environment: d.Environment, SHA: d.SHA, Ref: d.Ref, Created: d.CreatedAt, Source: "github"}
+			if st, err := github.LatestDeploymentStatus(get, d.ID); err == nil && st != nil {
+				env.State, env.URL = st.State, st.EnvURL
+			}
+			latest[key] = env
+		}
+	}
+	if vercel, verr := RecentDeploys(projectPath, 20); verr == nil {
+		for _, d := range vercel {
+			name := "Preview"
+			if d.Target == "production" {
+				name = "Production"
+			}
+			key := strings.ToLower(name)
+			if latest[key] != nil || d.SHA == "" {
+				continue
+			}
+			latest[key] = &EnvDeploy{Environment: name, SHA: d.SHA, Ref: d.Ref, Created: d.Created,
+				State: d.State, URL: "https://" + d.URL, Source: "vercel"}
+		}
+	}
+	if len(latest) == 0 {
+		return nil, err
+	}
+
+	m := &EnvMatrix{Branch: defaultBranch(projectPath), Behind: make(map[string]int)}
+	for _, env := range latest {
+		m.Envs = append(m.Envs, *env)
+	}
+	sort.Slice(m.Envs, func(i, j int) bool {
+		a, b := m.Envs[i], m.Envs[j]
+		if envRank(a.Environment) != envRank(b.Environment) {
+			return envRank(a.Environment) < envRank(b.Environment)
+		}
+		return a.Environment < b.Environment
+	})
+
+	repo, _ := gitrepo.Open(expandedPath)
+	if repo != nil {
+		defer repo.Close()
+	}
+	count := commitCounter(repo, get)
+	for i := 0; i+1 < len(m.Envs); i++ {
+		from, to := m.Envs[i], m.Envs[i+1]
+		if ahead, behind, err := count(to.SHA, from.SHA); err == nil {
+			m.Drift = append(m.Drift, EnvDrift{From: from.Environment, To: to.Environment, Ahead: ahead, Behind: behind})
+		}
+	}
+	if m.Branch != "" {
+		for _, env := range m.Envs {
+			if ahead, _, err := count(env.SHA, m.Branch); err == nil {
+				m.Behind[env.Environment] = ahead
+			}
+		}
+	}
+	return m, nil
+}
+
+// commitCounter returns a function counting the commits head has that
+// base doesn't and vice versa, from the local clone (repo, when not nil)
+// if it has both, or else GitHub's compare API
+func commitCounter(repo *gitrepo.Repo, get github.GetFunc) func(base, head string) (ahead, behind int, err error) {
+	resolve := func(rev string) (gitrepo.Hash, error) {
+		if h, err := gitrepo.ParseHash(rev); err == nil {
+			return h, nil
+		}
+		return repo.ResolveRef("refs/remotes/origin/" + rev)
+	}
+	return func(base, head string) (int, int, error) {
+		if repo != nil {
+			b, berr := resolve(base)
+			h, herr := resolve(head)
+			if berr == nil && herr == nil {
+				if ahead, behind, err := repo.AheadBehind(h, b); err == nil {
+					return ahead, behind, nil
+				}
+			}
+		}
+		cmp, err := github.Compare(get, base, head)
+		if err != nil {
+			return 0, 0, err
+		}
+		return cmp.Ahead, cmp.Behind, nil
+	}
+}
+
+// repoGet fetches paths under the project's repository API URL through
+// the API, or gh when no token is available
+func repoGet(expandedPath string) github.GetFunc {
+	if client, repo, ok := githubRepo(expandedPath); ok {
+		return func(path string, out any) error {
+			return client.Get("repos/"+repo.String()+"/"+path, out)
+		}
+	}
+	return ghGet(expandedPath)
+}
@@ -0,0 +1,72 @@
+package discover
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// fetchTimeout bounds a fetch against a slow or unreachable remote
+const fetchTimeout = 2 * time.Minute
+
+// Fetch runs `git fetch --prune` for all remotes. It never prompts for
+// credentials, so a remote that needs them fails instead of hanging.
+func Fetch(projectPath string) error {
+	if fixture.Replaying() {
+		// Nothing to fetch into; replayed status stays as recorded
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "-C", expandPath(projectPath), "fetch", "--all", "--prune", "--quiet")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n"); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// LastFetch returns when a project was last fetched, from FETCH_HEAD's
+// modification time. It is zero if the project has never been fetched.
+func LastFetch(projectPath string) time.Time {
+	t, _ := fixture.Do("git", "last-fetch "+projectPath, func() (time.Time, error) {
+		return lastFetch(projectPath), nil
+	})
+	return t
+}
+
+func lastFetch(projectPath string) time.Time {
+	expandedPath := expandPath(projectPath)
+
+	var candidates []string
+	if repo, err := gitrepo.Open(expandedPath); err == nil {
+		candidates = []string{
+			filepath.Join(repo.GitDir, "FETCH_HEAD"),
+			filepath.Join(repo.CommonDir, "FETCH_HEAD"),
+		}
+		repo.Close()
+	} else if out, err := exec.Command("git", "-C", expandedPath, "rev-parse", "--path-format=absolute", "--git-path", "FETCH_HEAD").Output(); err == nil {
+		candidates = []string{strings.TrimSpace(string(out))}
+	}
+
+	var last time.Time
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last
+}
@@ -0,0 +1,217 @@
+package discover
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// testRuns caps how many test runs tests.json keeps
+const testRuns = 500
+
+// testRun is the outcome of one package's test check: the tests that
+// failed, and those (or, for Go, the packages) that passed
+type testRun struct {
+	At      time.Time `json:"at"`
+	Package string    `json:"package"`
+	Tree    string    `json:"tree"` // HEAD and uncommitted changes, hashed
+	OK      bool      `json:"ok"`
+	Failed  []string  `json:"failed,omitempty"`
+	Passed  []string  `json:"passed,omitempty"`
+}
+
+// outcome is whether test passed in the run; known is false when the run
+// says nothing about it, as when the build failed
+func (r testRun) outcome(test string) (passed, known bool) {
+	for _, t := range r.Failed {
+		if t == test {
+			return false, true
+		}
+	}
+	if r.OK {
+		return true, true
+	}
+	// A Go test's package passing means it passed; cargo test names have
+	// no space, so for them group is the test itself
+	group, _, _ := strings.Cut(test, " ")
+	for _, t := range r.Passed {
+		if t == test || t == group {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// FlakyTest is a test that both passed and failed on the same code
+type FlakyTest struct {
+	Package  string
+	Test     string
+	Runs     int // runs that said whether it passed
+	Failures int
+	Flips    int // times it changed between pass and fail with the code unchanged
+	Last     time.Time
+}
+
+func testRunsFile(projectPath string) string {
+	return filepath.Join(ProjectCacheDir(projectPath), "tests.json")
+}
+
+func readTestRuns(projectPath string) []testRun {
+	var runs []testRun
+	if data, err := os.ReadFile(testRunsFile(projectPath)); err == nil {
+		json.Unmarshal(data, &runs)
+	}
+	return runs
+}
+
+// treeFingerprint identifies the code a run tested: HEAD plus any
+// uncommitted changes to tracked files and the untracked files git
+// doesn't ignore, so adding a new test file counts as a change
+func treeFingerprint(root string) string {
+	head, err := exec.Command("git", "-C", root, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	h := sha256.New()
+	h.Write(head)
+	diff, _ := exec.Command("git", "-C", root, "diff", "HEAD", "--binary").Output()
+	h.Write(diff)
+
+	untracked, _ := exec.Command("git", "-C", root, "ls-files", "--others", "--exclude-standard", "-z").Output()
+	for _, name := range strings.Split(strings.TrimRight(string(untracked), "\x00"), "\x00") {
+		if name == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+		h.Write([]byte(name + "\x00"))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// recordTestRun adds a test check's outcome to the project's
+// tests.json. The caller holds packageChecksMu.
+func recordTestRun(projectPath string, p Package, ok bool, output string) {
+	run := testRun{At: time.Now(), Package: p.Name, Tree: treeFingerprint(expandPath(projectPath)), OK: ok}
+	if run.Tree == "" {
+		return
+	}
+	run.Failed, run.Passed = parseTestOutcomes(p, output)
+	if !ok && len(run.Failed) == 0 && p.Ecosystem == "npm" {
+		// npm test scripts run anything; the package is the finest grain
+		run.Failed = []string{"npm test"}
+	}
+
+	runs := append(readTestRuns(projectPath), run)
+	if len(runs) > testRuns {
+		runs = runs[len(runs)-testRuns:]
+	}
+	if data, err := json.MarshalIndent(runs, "", "  "); err == nil {
+		os.WriteFile(testRunsFile(projectPath), data, 0644)
+	}
+}
+
+// parseTestOutcomes reads which tests failed and passed from go test's
+// "--- FAIL" and "ok <package>" lines or cargo test's "test name ... ok".
+// A Go test is named after its package's full import path, as
+// "example.com/mod/parse TestLex", so same-named packages in different
+// directories don't share results; a passing Go package is recorded as
+// its import path alone.
+func parseTestOutcomes(p Package, output string) (failed, passed []string) {
+	var pending []string
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch p.Ecosystem {
+		case "go":
+			if name, ok := strings.CutPrefix(trimmed, "--- FAIL: "); ok {
+				pending = append(pending, strings.Fields(name)[0])
+				continue
+			}
+			// ok  	example.com/mod/parse	0.01s, and FAIL	example.com/mod/parse	0.01s
+			fields := strings.Fields(line)
+			if len(fields) < 2 || (fields[0] != "ok" && fields[0] != "FAIL") {
+				continue
+			}
+			group := fields[1]
+			if fields[0] == "ok" {
+				passed = append(passed, group)
+			}
+			for _, t := range pending {
+				failed = append(failed, group+" "+t)
+			}
+			pending = nil
+		case "cargo":
+			name, result, ok := strings.Cut(strings.TrimPrefix(trimmed, "test "), " ... ")
+			if !ok || !strings.HasPrefix(trimmed, "test ") {
+				continue
+			}
+			switch result {
+			case "ok":
+				passed = append(passed, name)
+			case "FAILED":
+				failed = append(failed, name)
+			}
+		}
+	}
+	return append(failed, pending...), passed
+}
+
+// FlakyTests lists the tests that alternated between passing and failing
+// in a project's test checks without the code changing, flakiest first
+func FlakyTests(projectPath string) []FlakyTest {
+	type key struct{ pkg, test string }
+	runs := readTestRuns(projectPath)
+
+	// Every test that ever failed, and the runs of its package
+	byPackage := make(map[string][]testRun)
+	candidates := make(map[key]bool)
+	for _, r := range runs {
+		byPackage[r.Package] = append(byPackage[r.Package], r)
+		for _, t := range r.Failed {
+			candidates[key{r.Package, t}] = true
+		}
+	}
+
+	var flaky []FlakyTest
+	for k := range candidates {
+		f := FlakyTest{Package: k.pkg, Test: k.test}
+		last := make(map[string]bool) // tree -> its previous outcome
+		for _, r := range byPackage[k.pkg] {
+			passed, known := r.outcome(k.test)
+			if !known {
+				continue
+			}
+			f.Runs++
+			if !passed {
+				f.Failures++
+			}
+			if prev, seen := last[r.Tree]; seen && prev != passed {
+				f.Flips++
+				f.Last = r.At
+			}
+			last[r.Tree] = passed
+		}
+		if f.Flips > 0 {
+			flaky = append(flaky, f)
+		}
+	}
+	sort.Slice(flaky, func(i, j int) bool {
+		if flaky[i].Flips != flaky[j].Flips {
+			return flaky[i].Flips > flaky[j].Flips
+		}
+		if flaky[i].Failures != flaky[j].Failures {
+			return flaky[i].Failures > flaky[j].Failures
+		}
+		return flaky[i].Package+flaky[i].Test < flaky[j].Package+flaky[j].Test
+	})
+	return flaky
+}
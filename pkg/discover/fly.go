@@ -0,0 +1,155 @@
+package discover
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/fly"
+)
+
+// FlyStatus is a Fly.io app's deployed release and the health of its
+// machines
+type FlyStatus struct {
+	App        string
+	Status     string // deployed, pending, suspended
+	Version    int    // the current release, 0 before the first deploy
+	Release    string // its status: complete, failed, running, ...
+	ReleasedAt time.Time
+	Machines   int
+	Started    int
+	Checks     int // health checks across started machines
+	Passing    int
+	Regions    []string
+}
+
+// Healthy reports whether every machine is started and passing its
+// checks
+func (s *FlyStatus) Healthy() bool {
+	return s.Machines > 0 && s.Started == s.Machines && s.Passing == s.Checks
+}
+
+// IsFly reports whether a project deploys to Fly.io: it has a fly.toml
+func IsFly(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(expandPath(projectPath), "fly.toml"))
+	return err == nil
+}
+
+// GetFlyStatus reads the app a project's fly.toml names. It returns nil
+// for projects without one.
+func GetFlyStatus(projectPath string) (*FlyStatus, error) {
+	return fixture.Do("fly", "status "+projectPath, func() (*FlyStatus, error) {
+		return getFlyStatus(projectPath)
+	})
+}
+
+// flyDeploys returns a Fly.io app's newest n releases, newest first, or
+// nil for projects without a fly.toml
+func flyDeploys(_, projectPath string, n int) ([]Deployment, error) {
+	return fixture.Do("fly", fmt.Sprintf("deploys %d %s", n, projectPath), func() ([]Deployment, error) {
+		if !IsFly(projectPath) {
+			return nil, nil
+		}
+		name, err := fly.AppName(expandPath(projectPath))
+		if err != nil {
+			return nil, err
+		}
+		client := fly.Default()
+		if client == nil {
+			return nil, fly.ErrNoToken
+		}
+		app, err := client.App(name)
+		if err != nil {
+			return nil, err
+		}
+		releases, err := client.Releases(name, n)
+		if err != nil {
+			return nil, err
+		}
+		deploys := make([]Deployment, 0, len(releases))
+		for _, r := range releases {
+			deploy := Deployment{
+				Provider: "fly",
+				Site:     name,
+				ID:       r.ID,
+				URL:      app.Hostname,
+				LogsURL:  fly.MonitoringURL(name),
+				State:    flyState(r.Status),
+				Target:   "production",
+				Created:  r.CreatedAt,
+				Message:  fmt.Sprintf("v%d %s", r.Version, r.Description),
+				Image:    r.ImageRef,
+			}
+			if r.User != nil {
+				deploy.Author = r.User.Email
+			}
+			deploys = append(deploys, deploy)
+		}
+		return deploys, nil
+	})
+}
+
+// flyState names a release's status as Vercel's are named
+func flyState(status string) string {
+	switch status {
+	case "complete", "succeeded":
+		return "ready"
+	case "failed", "interrupted":
+		return "failed"
+	case "pending":
+		return "queued"
+	case "running":
+		return "building"
+	}
+	return status
+}
+
+func getFlyStatus(projectPath string) (*FlyStatus, error) {
+	if !IsFly(projectPath) {
+		return nil, nil
+	}
+	name, err := fly.AppName(expandPath(projectPath))
+	if err != nil {
+		return nil, err
+	}
+	client := fly.Default()
+	if client == nil {
+		return &FlyStatus{App: name}, fly.ErrNoToken
+	}
+
+	status := &FlyStatus{App: name}
+	app, err := client.App(name)
+	if err != nil {
+		return status, err
+	}
+	status.Status = app.Status
+	if r := app.CurrentRelease; r != nil {
+		status.Version, status.Release, status.ReleasedAt = r.Version, r.Status, r.CreatedAt
+	}
+
+	machines, err := client.Machines(name)
+	if err != nil {
+		return status, err
+	}
+	seen := make(map[string]bool)
+	for _, m := range machines {
+		status.Machines++
+		if !seen[m.Region] {
+			seen[m.Region] = true
+			status.Regions = append(status.Regions, m.Region)
+		}
+		if m.State != "started" {
+			continue
+		}
+		status.Started++
+		for _, c := range m.Checks {
+			status.Checks++
+			if c.Status == "passing" {
+				status.Passing++
+			}
+		}
+	}
+	return status, nil
+}
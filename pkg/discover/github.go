@@ -0,0 +1,314 @@
+package discover
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// PullRequest is an open pull request
+type PullRequest = forge.PullRequest
+
+// errNotGitHub is what GitHub-only features (Actions secrets, traffic,
+// security alerts, ...) return for a project hosted elsewhere
+var errNotGitHub = errors.New("not a GitHub project")
+
+// Issue is an open issue
+type Issue = forge.Issue
+
+// WorkflowRun is one CI run: a GitHub Actions workflow run, or another
+// forge's pipeline
+type WorkflowRun = forge.Run
+
+// CI states, from a run or a branch's latest runs combined
+const (
+	CIPassing = forge.CIPassing
+	CIFailing = forge.CIFailing
+	CIRunning = forge.CIRunning
+)
+
+// BranchCI is a branch's CI state: the latest run of each workflow
+type BranchCI struct {
+	Branch string
+	Runs   []WorkflowRun // newest first
+}
+
+// State combines the runs: failing if any failed, else running if any
+// are, else passing. It is "" with no runs.
+func (c *BranchCI) State() string {
+	if c == nil || len(c.Runs) == 0 {
+		return ""
+	}
+	state := CIPassing
+	for _, r := range c.Runs {
+		switch r.State() {
+		case CIFailing:
+			return CIFailing
+		case CIRunning:
+			state = CIRunning
+		}
+	}
+	return state
+}
+
+// githubRepo returns an API client and the GitHub repository a project
+// pushes to (see remoteRepo). ok is false when there is no token or no
+// github.com remote, and callers fall back to gh.
+func githubRepo(expandedPath string) (client *github.Client, repo github.Repo, ok bool) {
+	client = github.Default()
+	if client == nil {
+		return nil, repo, false
+	}
+	repo, ok = remoteRepo(expandedPath)
+	if !ok {
+		return nil, repo, false
+	}
+	return client, repo, true
+}
+
+// BatchGitHubStatus returns the GitHub status of many projects at once,
+// keyed by project path, from a few batched GraphQL queries instead of
+// one per project. Projects it can't cover (no token, no github.com
+// remote, a repository the API wouldn't return) are left out for the
+// caller to load one by one with GetGitHubStatus.
+func BatchGitHubStatus(projectPaths []string) map[string]*GitHubStatus {
+	// Replays only know per-project responses
+	if fixture.Replaying() {
+		return nil
+	}
+	client := github.Default()
+	if client == nil {
+		return nil
+	}
+
+	paths := make(map[github.Repo][]string)
+	var repos []github.Repo
+	for _, path := range projectPaths {
+		repo, ok := remoteRepo(expandPath(path))
+		if !ok {
+			continue
+		}
+		if _, seen := paths[repo]; !seen {
+			repos = append(repos, repo)
+		}
+		paths[repo] = append(paths[repo], path)
+	}
+	if len(repos) == 0 {
+		return nil
+	}
+	counts, err := client.BatchCounts(repos)
+	if err != nil {
+		return nil
+	}
+
+	found := make(map[string]*GitHubStatus, len(projectPaths))
+	for repo, c := range counts {
+		for _, path := range paths[repo] {
+			status := statusFromCounts(forge.GitHubCounts(c))
+			// Recorded under GetGitHubStatus's key so a replay finds it
+			fixture.Do("github", "status "+path, func() (*GitHubStatus, error) { return status, nil })
+			found[path] = status
+		}
+	}
+	return found
+}
+
+// statusFromCounts converts a forge's counts
+func statusFromCounts(c forge.Counts) *GitHubStatus {
+	return &GitHubStatus{
+		Issues:          c.Issues,
+		PRs:             c.PRs,
+		Assigned:        c.Assigned,
+		ReviewRequested: c.ReviewRequested,
+		Stars:           c.Stars,
+		StarsWeek:       c.StarsWeek,
+		Forks:           c.Forks,
+		Watchers:        c.Watchers,
+	}
+}
+
+// GitHubRepo returns the "owner/name" of the GitHub repository a project
+// pushes to, or "" when it has no github.com remote
+func GitHubRepo(projectPath string) string {
+	if repo, ok := remoteRepo(expandPath(projectPath)); ok {
+		return repo.String()
+	}
+	return ""
+}
+
+// ForgeRepo returns the "host/owner/name" of the repository a project
+// pushes to on any forge, or "" when it has no hosted remote
+func ForgeRepo(projectPath string) string {
+	cfg, _ := config.Load()
+	if r, ok := forge.PreferredRemote(expandPath(projectPath), cfg); ok {
+		return r.Host + "/" + r.String()
+	}
+	return ""
+}
+
+// CommitURL returns the page of one of a project's commits on its forge,
+// or "" when it has no hosted remote
+func CommitURL(projectPath, hash string) string {
+	cfg, _ := config.Load()
+	if r, ok := forge.PreferredRemote(expandPath(projectPath), cfg); ok {
+		return r.CommitURL(hash)
+	}
+	return ""
+}
+
+// remoteRepo returns the GitHub repository a project pushes to: its
+// preferred forge remote (see forge.PreferredRemote), when that is on
+// github.com. GitHub-only features (secrets, traffic, Actions settings,
+// ...) go through it so they never act on a project hosted elsewhere.
+func remoteRepo(expandedPath string) (repo github.Repo, ok bool) {
+	cfg, _ := config.Load()
+	r, ok := forge.PreferredRemote(expandedPath, cfg)
+	if !ok || r.Forge != forge.GitHub {
+		return repo, false
+	}
+	return github.Repo{Owner: r.Owner, Name: r.Name}, true
+}
+
+// ListPullRequests returns a project's open pull requests from its
+// forge (merge requests on GitLab)
+func ListPullRequests(projectPath string) ([]PullRequest, error) {
+	return fixture.Do("github", "prs "+projectPath, func() ([]PullRequest, error) {
+		f, ok := forge.Open(expandPath(projectPath))
+		if !ok {
+			return nil, forge.ErrNoForge
+		}
+		return f.PullRequests()
+	})
+}
+
+// ListIssues returns a project's open issues, newest first, from its
+// forge
+func ListIssues(projectPath string) ([]Issue, error) {
+	return fixture.Do("github", "issues "+projectPath, func() ([]Issue, error) {
+		f, ok := forge.Open(expandPath(projectPath))
+		if !ok {
+			return nil, forge.ErrNoForge
+		}
+		return f.Issues()
+	})
+}
+
+// FailingRuns returns workflows whose latest run on a branch failed,
+// newest first
+func FailingRuns(projectPath string) ([]WorkflowRun, error) {
+	return fixture.Do("github", "failing-runs "+projectPath, func() ([]WorkflowRun, error) {
+		return failingRuns(projectPath)
+	})
+}
+
+func failingRuns(projectPath string) ([]WorkflowRun, error) {
+	runs, err := listRuns(expandPath(projectPath), "", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	// Runs are listed newest first, so the first run seen per workflow and
+	// branch is its current state
+	seen := make(map[string]bool)
+	var failing []WorkflowRun
+	for _, r := range runs {
+		key := r.Name + "\x00" + r.Branch
+		if seen[key] || r.Status != "completed" {
+			continue
+		}
+		seen[key] = true
+		if r.Failed() {
+			failing = append(failing, r)
+		}
+	}
+	return failing, nil
+}
+
+// DefaultBranchCI returns the latest run of each workflow on the
+// project's default branch
+func DefaultBranchCI(projectPath string) (*BranchCI, error) {
+	return fixture.Do("github", "ci "+projectPath, func() (*BranchCI, error) {
+		return defaultBranchCI(projectPath)
+	})
+}
+
+func defaultBranchCI(projectPath string) (*BranchCI, error) {
+	branch := defaultBranch(projectPath)
+	if branch == "" {
+		return nil, nil
+	}
+	runs, err := listRuns(expandPath(projectPath), branch, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first, so the first run seen per workflow is its state.
+	// Skipped runs say nothing about the branch.
+	ci := &BranchCI{Branch: branch}
+	seen := make(map[string]bool)
+	for _, r := range runs {
+		if seen[r.Name] || r.Conclusion == "skipped" {
+			continue
+		}
+		seen[r.Name] = true
+		ci.Runs = append(ci.Runs, r)
+	}
+	return ci, nil
+}
+
+// RecentRuns returns a project's newest n workflow runs on any branch
+func RecentRuns(projectPath string, n int) ([]WorkflowRun, error) {
+	return fixture.Do("github", fmt.Sprintf("runs %s %d", projectPath, n), func() ([]WorkflowRun, error) {
+		return listRuns(expandPath(projectPath), "", n)
+	})
+}
+
+// RerunWorkflow starts a run again on the project's forge, only its
+// failed jobs if failedOnly
+func RerunWorkflow(projectPath string, run WorkflowRun, failedOnly bool) error {
+	f, ok := forge.Open(expandPath(projectPath))
+	if !ok {
+		return forge.ErrNoForge
+	}
+	err := f.Rerun(run, failedOnly)
+	if errors.Is(err, forge.ErrUnsupported) && failedOnly {
+		return fmt.Errorf("%s can't re-run only a run's failed jobs", f.Remote().Forge)
+	}
+	if errors.Is(err, forge.ErrUnsupported) {
+		return fmt.Errorf("%s has no CI runs to re-run", f.Remote().Forge)
+	}
+	return err
+}
+
+// MergeMethods are the ways a pull request can be merged, as GitHub names
+// them
+var MergeMethods = forge.MergeMethods
+
+// MergePullRequest merges a pull request on the project's forge with one
+// of MergeMethods
+func MergePullRequest(projectPath string, number int, method string) error {
+	f, ok := forge.Open(expandPath(projectPath))
+	if !ok {
+		return forge.ErrNoForge
+	}
+	err := f.Merge(number, method)
+	if errors.Is(err, forge.ErrUnsupported) {
+		return fmt.Errorf("%s can't %s pull requests", f.Remote().Forge, method)
+	}
+	return err
+}
+
+// listRuns returns the newest n CI runs, on branch unless it is "", from
+// the project's forge. GitLab, Bitbucket, and Azure DevOps pipelines and
+// builds.sr.ht jobs stand in for workflow runs.
+func listRuns(expandedPath, branch string, n int) ([]WorkflowRun, error) {
+	f, ok := forge.Open(expandedPath)
+	if !ok {
+		return nil, forge.ErrNoForge
+	}
+	return f.Runs(branch, n)
+}
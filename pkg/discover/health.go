@@ -0,0 +1,259 @@
+package discover
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+const (
+	// LargeFileThreshold is the size over which a tracked file is reported
+	LargeFileThreshold = 10 << 20
+
+	// LooseObjectLimit is where git's own `gc --auto` would step in
+	LooseObjectLimit = 6700
+
+	// maxLargeFiles caps how many large files a report lists
+	maxLargeFiles = 10
+)
+
+// LargeFile is a tracked file over LargeFileThreshold
+type LargeFile struct {
+	Path string
+	Size int64
+}
+
+// RepoHealth is a project's repository housekeeping report
+type RepoHealth struct {
+	LooseObjects int
+	LooseSize    int64 // bytes in loose objects
+	PackSize     int64 // bytes in packs
+	LargeFiles   []LargeFile
+	BrokenHooks  []BrokenHook
+	Branch       string
+	NoUpstream   bool   // the checked-out branch tracks nothing
+	Remote       string // where NoUpstream suggests pushing
+}
+
+// BrokenHook is a hook git will fail to run
+type BrokenHook struct {
+	Name   string
+	Reason string
+	Fix    string
+}
+
+// Size is the repository's total object storage
+func (h *RepoHealth) Size() int64 {
+	return h.LooseSize + h.PackSize
+}
+
+// Problem is one health finding with what to do about it
+type Problem struct {
+	Summary string
+	Fix     string
+}
+
+// Problems lists the report's findings, each with a suggested fix
+func (h *RepoHealth) Problems() []Problem {
+	var out []Problem
+	if h.LooseObjects >= LooseObjectLimit {
+		out = append(out, Problem{
+			Summary: strconv.Itoa(h.LooseObjects) + " loose objects",
+			Fix:     "git gc",
+		})
+	}
+	for _, f := range h.LargeFiles {
+		out = append(out, Problem{
+			Summary: f.Path + " is " + FormatBytes(f.Size),
+			Fix:     "git lfs track " + strconv.Quote(f.Path),
+		})
+	}
+	for _, hook := range h.BrokenHooks {
+		out = append(out, Problem{Summary: hook.Name + " hook " + hook.Reason, Fix: hook.Fix})
+	}
+	if h.NoUpstream && h.Branch != "" {
+		out = append(out, Problem{
+			Summary: h.Branch + " has no upstream",
+			Fix:     "git push -u " + h.Remote + " " + h.Branch,
+		})
+	}
+	return out
+}
+
+// FormatBytes renders a size the way `du -h` does
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + "B"
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return strconv.FormatFloat(float64(n)/float64(div), 'f', 1, 64) + string("KMGTPE"[exp])
+}
+
+// CheckRepoHealth inspects a project's object store, tracked file sizes,
+// hooks, and upstream configuration
+func CheckRepoHealth(projectPath string) (*RepoHealth, error) {
+	return fixture.Do("git", "health "+projectPath, func() (*RepoHealth, error) {
+		return checkRepoHealth(projectPath)
+	})
+}
+
+func checkRepoHealth(projectPath string) (*RepoHealth, error) {
+	dir := expandPath(projectPath)
+	h := &RepoHealth{}
+
+	output, err := exec.Command("git", "-C", dir, "count-objects", "-v").Output()
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		switch key {
+		case "count":
+			h.LooseObjects = int(n)
+		case "size":
+			h.LooseSize = n << 10
+		case "size-pack":
+			h.PackSize = n << 10
+		}
+	}
+
+	h.LargeFiles = largeFiles(dir)
+	h.BrokenHooks = brokenHooks(dir)
+
+	// A branch without upstream only matters when there's somewhere to push
+	remotes, _ := exec.Command("git", "-C", dir, "remote").Output()
+	if names := strings.Fields(string(remotes)); len(names) > 0 {
+		h.Remote = names[0]
+		if slices.Contains(names, "origin") {
+			h.Remote = "origin"
+		}
+		if branch, err := exec.Command("git", "-C", dir, "symbolic-ref", "-q", "--short", "HEAD").Output(); err == nil {
+			h.Branch = strings.TrimSpace(string(branch))
+			err := exec.Command("git", "-C", dir, "rev-parse", "-q", "--verify", "@{upstream}").Run()
+			h.NoUpstream = err != nil
+		}
+	}
+	return h, nil
+}
+
+// largeFiles returns the biggest tracked files over LargeFileThreshold.
+// Files git-lfs already manages are pointers on disk and never show up.
+func largeFiles(dir string) []LargeFile {
+	output, err := exec.Command("git", "-C", dir, "ls-files", "-z").Output()
+	if err != nil {
+		return nil
+	}
+	var files []LargeFile
+	for _, path := range bytes.Split(output, []byte{0}) {
+		if len(path) == 0 {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(dir, string(path)))
+		if err != nil || !info.Mode().IsRegular() || info.Size() < LargeFileThreshold {
+			continue
+		}
+		files = append(files, LargeFile{Path: string(path), Size: info.Size()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Size > files[j].Size })
+	if len(files) > maxLargeFiles {
+		files = files[:maxLargeFiles]
+	}
+	return files
+}
+
+// brokenHooks finds hooks git would skip or fail to run: not executable,
+// or naming an interpreter that isn't installed
+func brokenHooks(dir string) []BrokenHook {
+	// --git-path honors core.hooksPath and linked worktrees
+	output, err := exec.Command("git", "-C", dir, "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return nil
+	}
+	hooksDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+	entries, err := os.ReadDir(hooksDir)
+	if err != nil {
+		return nil
+	}
+
+	// Fixes are run from the project, so keep paths short when inside it
+	display := func(path string) string {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+		return path
+	}
+
+	var broken []BrokenHook
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), ".sample") {
+			continue
+		}
+		path := filepath.Join(hooksDir, e.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			broken = append(broken, BrokenHook{Name: e.Name(), Reason: "is a dangling link", Fix: "rm " + display(path)})
+			continue
+		}
+		if info.Mode()&0o111 == 0 {
+			broken = append(broken, BrokenHook{Name: e.Name(), Reason: "isn't executable", Fix: "chmod +x " + display(path)})
+			continue
+		}
+		if interp := hookInterpreter(path); interp != "" {
+			if _, err := exec.LookPath(interp); err != nil {
+				broken = append(broken, BrokenHook{
+					Name:   e.Name(),
+					Reason: "needs " + interp + ", which isn't installed",
+					Fix:    "install " + interp + " or remove " + display(path),
+				})
+			}
+		}
+	}
+	return broken
+}
+
+// hookInterpreter returns the program a script's #! line runs, following
+// `/usr/bin/env x` to x
+func hookInterpreter(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	line, _ := bufio.NewReader(f).ReadString('\n')
+	if !strings.HasPrefix(line, "#!") {
+		return ""
+	}
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+	if filepath.Base(fields[0]) == "env" {
+		for _, f := range fields[1:] {
+			if !strings.HasPrefix(f, "-") {
+				return f
+			}
+		}
+		return ""
+	}
+	return fields[0]
+}
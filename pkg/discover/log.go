@@ -0,0 +1,102 @@
+package discover
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Commit is one entry of a project's history
+type Commit struct {
+	Hash    string
+	Author  string
+	When    time.Time
+	Subject string
+}
+
+// ShortHash returns the abbreviated hash git prints by default
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// RecentCommits returns up to n commits reachable from HEAD, newest first
+func RecentCommits(projectPath string, n int) ([]Commit, error) {
+	return fixture.Do("git", "log "+projectPath+" "+strconv.Itoa(n), func() ([]Commit, error) {
+		return recentCommits(projectPath, n)
+	})
+}
+
+func recentCommits(projectPath string, n int) ([]Commit, error) {
+	expandedPath := expandPath(projectPath)
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return recentCommitsDirect(expandedPath, n)
+	}
+	defer repo.Close()
+
+	_, head, err := repo.Head()
+	if err != nil {
+		return recentCommitsDirect(expandedPath, n)
+	}
+	log, err := repo.Log(head, n)
+	if err != nil {
+		return recentCommitsDirect(expandedPath, n)
+	}
+
+	commits := make([]Commit, 0, len(log))
+	for _, c := range log {
+		commits = append(commits, Commit{Hash: c.Hash.String(), Author: c.Author, When: c.When, Subject: c.Subject()})
+	}
+	return commits, nil
+}
+
+// logFormat is the git log --format that parseLog reads
+const logFormat = "--format=%H%x09%an%x09%ct%x09%s"
+
+// recentCommitsDirect is a fallback using git log
+func recentCommitsDirect(expandedPath string, n int) ([]Commit, error) {
+	cmd := exec.Command("git", "-C", expandedPath, "log", "-n", strconv.Itoa(n), logFormat)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(output), nil
+}
+
+// CommitsNotInHead returns the commits on branch that HEAD doesn't have
+// (git log HEAD..branch), newest first: the candidates for cherry-picking
+func CommitsNotInHead(projectPath, branch string) ([]Commit, error) {
+	return fixture.Do("git", "log "+projectPath+" HEAD.."+branch, func() ([]Commit, error) {
+		cmd := exec.Command("git", "-C", expandPath(projectPath), "log", "--no-merges", logFormat, "HEAD.."+branch, "--")
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, err
+		}
+		return parseLog(output), nil
+	})
+}
+
+// parseLog reads logFormat lines
+func parseLog(output []byte) []Commit {
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\t", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		c := Commit{Hash: parts[0], Author: parts[1], Subject: parts[3]}
+		if sec, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+			c.When = time.Unix(sec, 0)
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
@@ -0,0 +1,113 @@
+package discover
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// DefaultBranch returns the local branch work is merged into: the one
+// origin/HEAD names, else main or master. It is "" when none exist.
+func DefaultBranch(projectPath string) string {
+	branch, _ := fixture.Do("git", "default-branch "+projectPath, func() (string, error) {
+		return defaultBranch(projectPath), nil
+	})
+	return branch
+}
+
+func defaultBranch(projectPath string) string {
+	expandedPath := expandPath(projectPath)
+
+	candidates := []string{"main", "master"}
+	exists := func(name string) bool {
+		return exec.Command("git", "-C", expandedPath, "show-ref", "--verify", "--quiet", "refs/heads/"+name).Run() == nil
+	}
+	if repo, err := gitrepo.Open(expandedPath); err == nil {
+		defer repo.Close()
+		if target, err := repo.SymbolicRef("refs/remotes/origin/HEAD"); err == nil {
+			candidates = append([]string{strings.TrimPrefix(target, "refs/remotes/origin/")}, candidates...)
+		}
+		exists = func(name string) bool {
+			_, err := repo.ResolveRef("refs/heads/" + name)
+			return err == nil
+		}
+	} else if out, err := exec.Command("git", "-C", expandedPath, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD").Output(); err == nil {
+		candidates = append([]string{strings.TrimPrefix(strings.TrimSpace(string(out)), "origin/")}, candidates...)
+	}
+
+	for _, name := range candidates {
+		if exists(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// MergedBranches lists local branches whose commits are all in base,
+// leaving out base itself and the checked-out branch
+func MergedBranches(projectPath, base string) ([]string, error) {
+	return fixture.Do("git", "merged "+projectPath+" "+base, func() ([]string, error) {
+		return mergedBranches(projectPath, base)
+	})
+}
+
+func mergedBranches(projectPath, base string) ([]string, error) {
+	expandedPath := expandPath(projectPath)
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return mergedBranchesDirect(expandedPath, base)
+	}
+	defer repo.Close()
+
+	current, _ := repo.Branch()
+	target, err := repo.ResolveRef("refs/heads/" + base)
+	if err != nil {
+		return mergedBranchesDirect(expandedPath, base)
+	}
+	local, err := repo.Refs("refs/heads/")
+	if err != nil {
+		return mergedBranchesDirect(expandedPath, base)
+	}
+
+	var merged []string
+	for ref, tip := range local {
+		name := strings.TrimPrefix(ref, "refs/heads/")
+		if name == base || name == current {
+			continue
+		}
+		ahead, _, err := repo.AheadBehind(tip, target)
+		if err != nil {
+			return mergedBranchesDirect(expandedPath, base)
+		}
+		if ahead == 0 {
+			merged = append(merged, name)
+		}
+	}
+	sort.Strings(merged)
+	return merged, nil
+}
+
+// mergedBranchesDirect is a fallback using git for-each-ref --merged
+func mergedBranchesDirect(expandedPath, base string) ([]string, error) {
+	output, err := exec.Command("git", "-C", expandedPath, "for-each-ref",
+		"--merged=refs/heads/"+base, "--format=%(HEAD)%(refname:short)", "refs/heads").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []string
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if len(line) < 2 || line[0] == '*' {
+			continue
+		}
+		if name := line[1:]; name != base {
+			merged = append(merged, name)
+		}
+	}
+	sort.Strings(merged)
+	return merged, nil
+}
@@ -0,0 +1,122 @@
+package discover
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// RepoMetadata is a project's GitHub repository and how it presents
+// itself there
+type RepoMetadata struct {
+	Path string // the project's
+	Repo string // owner/name
+	github.Metadata
+}
+
+// Missing names what the repository doesn't fill in: description, topics,
+// homepage, and social preview
+func (md RepoMetadata) Missing() []string {
+	var missing []string
+	if strings.TrimSpace(md.Description) == "" {
+		missing = append(missing, "description")
+	}
+	if len(md.Topics) == 0 {
+		missing = append(missing, "topics")
+	}
+	if strings.TrimSpace(md.Homepage) == "" {
+		missing = append(missing, "homepage")
+	}
+	if !md.SocialPreview {
+		missing = append(missing, "social preview")
+	}
+	return missing
+}
+
+// Public reports whether the repository is an open source project of the
+// owner's own: public, not a fork, and not archived
+func (md RepoMetadata) Public() bool {
+	return !md.Private && !md.Fork && !md.Archived
+}
+
+// AuditMetadata reads the GitHub metadata of every project with a
+// github.com remote, in a few batched requests. Projects sharing a
+// repository get one entry, under the first one's path; they come back
+// sorted by repository.
+func AuditMetadata(projectPaths []string) ([]RepoMetadata, error) {
+	return fixture.Do("github", "metadata "+strings.Join(projectPaths, ","), func() ([]RepoMetadata, error) {
+		return auditMetadata(projectPaths)
+	})
+}
+
+func auditMetadata(projectPaths []string) ([]RepoMetadata, error) {
+	client := github.Default()
+	if client == nil {
+		return nil, github.ErrNoToken
+	}
+	first := make(map[github.Repo]string)
+	var repos []github.Repo
+	for _, path := range projectPaths {
+		repo, ok := remoteRepo(expandPath(path))
+		if !ok {
+			continue
+		}
+		if _, seen := first[repo]; !seen {
+			first[repo] = path
+			repos = append(repos, repo)
+		}
+	}
+	if len(repos) == 0 {
+		return nil, nil
+	}
+	found, err := client.BatchMetadata(repos)
+	if err != nil {
+		return nil, err
+	}
+	audit := make([]RepoMetadata, 0, len(found))
+	for repo, md := range found {
+		audit = append(audit, RepoMetadata{Path: first[repo], Repo: repo.String(), Metadata: md})
+	}
+	sort.Slice(audit, func(i, j int) bool { return strings.ToLower(audit[i].Repo) < strings.ToLower(audit[j].Repo) })
+	return audit, nil
+}
+
+// EditMetadata sets one field of a project's repository on GitHub.
+// Topics are given comma- or space-separated (see ParseTopics). The rest
+// of md is sent back as it was, since the API sets description and
+// homepage together.
+func EditMetadata(md RepoMetadata, field, value string) error {
+	client, repo, ok := githubRepo(expandPath(md.Path))
+	if !ok {
+		return fmt.Errorf("%s: no GitHub token or github.com remote", md.Path)
+	}
+	value = strings.TrimSpace(value)
+	switch field {
+	case "description":
+		return client.UpdateRepo(repo, value, md.Homepage)
+	case "homepage":
+		return client.UpdateRepo(repo, md.Description, value)
+	case "topics":
+		return client.SetTopics(repo, ParseTopics(value))
+	}
+	return fmt.Errorf("unknown repository field %q", field)
+}
+
+// ParseTopics splits topics typed as a comma- or space-separated list,
+// lowercased as GitHub requires, dropping duplicates
+func ParseTopics(s string) []string {
+	var topics []string
+	seen := make(map[string]bool)
+	for _, t := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		t = strings.Trim(strings.ToLower(t), "-")
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		topics = append(topics, t)
+	}
+	return topics
+}
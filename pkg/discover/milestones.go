@@ -0,0 +1,55 @@
+package discover
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// Milestones returns a project's open milestones, soonest due first and
+// undated ones last, from the API or via gh when no token is available
+func Milestones(projectPath string) ([]github.Milestone, error) {
+	return fixture.Do("github", "milestones "+projectPath, func() ([]github.Milestone, error) {
+		return milestones(projectPath)
+	})
+}
+
+func milestones(projectPath string) ([]github.Milestone, error) {
+	expandedPath := expandPath(projectPath)
+	found, err := milestonesNative(expandedPath)
+	if err != nil {
+		found = nil
+		if err = ghGet(expandedPath)("milestones?state=open&per_page=100", &found); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i].DueOn, found[j].DueOn
+		switch {
+		case a == nil || b == nil:
+			return b == nil && a != nil
+		default:
+			return a.Before(*b)
+		}
+	})
+	return found, nil
+}
+
+func milestonesNative(expandedPath string) ([]github.Milestone, error) {
+	client, repo, ok := githubRepo(expandedPath)
+	if !ok {
+		return nil, errors.New("no GitHub token or remote")
+	}
+	return client.Milestones(repo)
+}
+
+// ActiveMilestone is the milestone being worked toward: the open one due
+// soonest, else the first undated one. It is nil with none open.
+func ActiveMilestone(milestones []github.Milestone) *github.Milestone {
+	if len(milestones) == 0 {
+		return nil
+	}
+	return &milestones[0]
+}
@@ -0,0 +1,412 @@
+package discover
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// Package checks, as Package.Checks and CheckResult name them
+const (
+	CheckBuild = "build"
+	CheckTest  = "test"
+	CheckLint  = "lint"
+)
+
+// PackageChecks are the checks in the order they're run and shown
+var PackageChecks = []string{CheckBuild, CheckTest, CheckLint}
+
+// Package is one package of a monorepo: an npm, pnpm, yarn, or bun
+// workspace, a Go module in go.work, or a Cargo workspace member
+type Package struct {
+	Name      string
+	Dir       string   // relative to the repository root
+	Ecosystem string   // npm, go, or cargo
+	Version   string   // from its manifest, when it has one
+	Checks    []string // which of build, test, and lint it can run
+
+	Release string // its newest release tag, "" when it was never released
+	Changed int    // commits touching it since that release (or ever)
+}
+
+// Packages lists the packages of a monorepo with what changed in each
+// since its last release. It returns nil for a repository with fewer
+// than two packages.
+func Packages(projectPath string) ([]Package, error) {
+	return fixture.Do("git", "packages "+projectPath, func() ([]Package, error) {
+		return packages(expandPath(projectPath))
+	})
+}
+
+func packages(root string) ([]Package, error) {
+	var pkgs []Package
+	seen := make(map[string]bool)
+	for _, find := range []func(string) []Package{npmPackages, goPackages, cargoPackages} {
+		for _, p := range find(root) {
+			if key := p.Ecosystem + " " + p.Dir; !seen[key] {
+				seen[key] = true
+				pkgs = append(pkgs, p)
+			}
+		}
+	}
+	if len(pkgs) < 2 {
+		return nil, nil
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Dir < pkgs[j].Dir })
+
+	var wg sync.WaitGroup
+	for i := range pkgs {
+		wg.Add(1)
+		go func(p *Package) {
+			defer wg.Done()
+			p.Release = packageReleaseTag(root, *p)
+			p.Changed = commitsTouching(root, p.Release, p.Dir)
+		}(&pkgs[i])
+	}
+	wg.Wait()
+	return pkgs, nil
+}
+
+// expandWorkspaceGlobs resolves workspace patterns such as "packages/*"
+// to the directories holding manifest. "**" is read as one level, and
+// "!pattern" entries exclude.
+func expandWorkspaceGlobs(root string, patterns []string, manifest string) []string {
+	var dirs, excluded []string
+	for _, pat := range patterns {
+		pat = strings.TrimSuffix(strings.ReplaceAll(pat, "**", "*"), "/")
+		if rest, ok := strings.CutPrefix(pat, "!"); ok {
+			excluded = append(excluded, strings.TrimPrefix(rest, "./"))
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(root, filepath.FromSlash(pat)))
+		for _, m := range matches {
+			if _, err := os.Stat(filepath.Join(m, manifest)); err != nil {
+				continue
+			}
+			if rel, err := filepath.Rel(root, m); err == nil {
+				dirs = append(dirs, filepath.ToSlash(rel))
+			}
+		}
+	}
+	kept := dirs[:0]
+	for _, d := range dirs {
+		skip := false
+		for _, ex := range excluded {
+			if ok, _ := path.Match(ex, d); ok {
+				skip = true
+			}
+		}
+		if !skip {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// npmPackages reads the workspaces of package.json, pnpm-workspace.yaml,
+// or lerna.json
+func npmPackages(root string) []Package {
+	var patterns []string
+	if data, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		var manifest struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		json.Unmarshal(data, &manifest)
+		// Either a list or {"packages": [...]} (yarn's nohoist form)
+		if json.Unmarshal(manifest.Workspaces, &patterns) != nil {
+			var ws struct {
+				Packages []string `json:"packages"`
+			}
+			json.Unmarshal(manifest.Workspaces, &ws)
+			patterns = ws.Packages
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml")); err == nil {
+		patterns = append(patterns, parseYAML(string(data)).child("packages").items...)
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "lerna.json")); err == nil {
+		var lerna struct {
+			Packages []string `json:"packages"`
+		}
+		json.Unmarshal(data, &lerna)
+		patterns = append(patterns, lerna.Packages...)
+	}
+
+	var pkgs []Package
+	for _, dir := range expandWorkspaceGlobs(root, patterns, "package.json") {
+		data, err := os.ReadFile(filepath.Join(root, dir, "package.json"))
+		if err != nil {
+			continue
+		}
+		var manifest struct {
+			Name    string            `json:"name"`
+			Version string            `json:"version"`
+			Scripts map[string]string `json:"scripts"`
+		}
+		if json.Unmarshal(data, &manifest) != nil {
+			continue
+		}
+		p := Package{Name: manifest.Name, Dir: dir, Ecosystem: "npm", Version: manifest.Version}
+		if p.Name == "" {
+			p.Name = dir
+		}
+		for _, check := range PackageChecks {
+			if _, ok := manifest.Scripts[check]; ok {
+				p.Checks = append(p.Checks, check)
+			}
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs
+}
+
+// goPackages reads the modules a go.work uses
+func goPackages(root string) []Package {
+	data, err := os.ReadFile(filepath.Join(root, "go.work"))
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	inUse := false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case inUse && line == ")":
+			inUse = false
+		case inUse && line != "":
+			dirs = append(dirs, line)
+		case line == "use (":
+			inUse = true
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, strings.TrimSpace(strings.TrimPrefix(line, "use ")))
+		}
+	}
+
+	var pkgs []Package
+	for _, dir := range dirs {
+		dir = path.Clean(strings.Trim(dir, `"`))
+		mod, err := os.ReadFile(filepath.Join(root, dir, "go.mod"))
+		if err != nil {
+			continue
+		}
+		name := dir
+		for _, line := range strings.Split(string(mod), "\n") {
+			if m, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+				name = strings.Trim(strings.TrimSpace(m), `"`)
+				break
+			}
+		}
+		pkgs = append(pkgs, Package{Name: name, Dir: dir, Ecosystem: "go", Checks: PackageChecks})
+	}
+	return pkgs
+}
+
+var (
+	tomlString     = regexp.MustCompile(`"([^"]*)"`)
+	cargoNameLine  = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
+	cargoVersLine  = regexp.MustCompile(`(?m)^\s*version\s*=\s*"([^"]+)"`)
+	tomlTableStart = regexp.MustCompile(`(?m)^\s*\[`)
+)
+
+// cargoPackages reads the members of a Cargo workspace
+func cargoPackages(root string) []Package {
+	data, err := os.ReadFile(filepath.Join(root, "Cargo.toml"))
+	if err != nil {
+		return nil
+	}
+	workspace := tomlTable(string(data), "workspace")
+	start := strings.Index(workspace, "members")
+	if start < 0 {
+		return nil
+	}
+	open := strings.Index(workspace[start:], "[")
+	end := strings.Index(workspace[start:], "]")
+	if open < 0 || end < open {
+		return nil
+	}
+	var patterns []string
+	for _, m := range tomlString.FindAllStringSubmatch(workspace[start+open:start+end], -1) {
+		patterns = append(patterns, m[1])
+	}
+
+	var pkgs []Package
+	for _, dir := range expandWorkspaceGlobs(root, patterns, "Cargo.toml") {
+		manifest, err := os.ReadFile(filepath.Join(root, dir, "Cargo.toml"))
+		if err != nil {
+			continue
+		}
+		pkg := tomlTable(string(manifest), "package")
+		p := Package{Name: dir, Dir: dir, Ecosystem: "cargo", Checks: PackageChecks}
+		if m := cargoNameLine.FindStringSubmatch(pkg); m != nil {
+			p.Name = m[1]
+		}
+		if m := cargoVersLine.FindStringSubmatch(pkg); m != nil {
+			p.Version = m[1]
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs
+}
+
+// tomlTable returns the text of a [table] up to the next table header
+func tomlTable(data, name string) string {
+	i := strings.Index(data, "["+name+"]")
+	if i < 0 {
+		return ""
+	}
+	body := data[i+len(name)+2:]
+	if loc := tomlTableStart.FindStringIndex(body); loc != nil {
+		// A members array spans lines starting with "[" only when it
+		// holds nested arrays, which Cargo doesn't use
+		body = body[:loc[0]]
+	}
+	return body
+}
+
+// packageReleaseTag finds a package's newest tag in the conventions
+// monorepo release tools use: name@1.2.0 (changesets, lerna), dir/v1.2.0
+// (Go submodules), and name-v1.2.0 (release-please)
+func packageReleaseTag(root string, p Package) string {
+	short := path.Base(p.Name)
+	for _, pattern := range []string{p.Name + "@*", p.Dir + "/v*", short + "-v*", short + "/v*", short + "@*"} {
+		out, err := exec.Command("git", "-C", root, "describe", "--tags", "--abbrev=0", "--match", pattern, "HEAD").Output()
+		if err == nil {
+			return strings.TrimSpace(string(out))
+		}
+	}
+	return ""
+}
+
+// commitsTouching counts the commits since tag ("" for all of history)
+// that changed files under dir
+func commitsTouching(root, tag, dir string) int {
+	rev := "HEAD"
+	if tag != "" {
+		rev = tag + "..HEAD"
+	}
+	out, err := exec.Command("git", "-C", root, "rev-list", "--count", rev, "--", dir).Output()
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+	return n
+}
+
+// CheckResult is the outcome of running one check of one package
+type CheckResult struct {
+	Package  string        `json:"package"`
+	Check    string        `json:"check"`
+	OK       bool          `json:"ok"`
+	Output   string        `json:"output,omitempty"` // the last lines, when it failed
+	Commit   string        `json:"commit"`           // HEAD when it ran
+	At       time.Time     `json:"at"`
+	Duration time.Duration `json:"duration"`
+}
+
+// checkOutputLines is how much of a failed check's output is kept
+const checkOutputLines = 20
+
+func packageChecksFile(projectPath string) string {
+	return filepath.Join(ProjectCacheDir(projectPath), "packages.json")
+}
+
+// PackageCheckResults reads the last result of each package's checks,
+// keyed by package name and then check
+func PackageCheckResults(projectPath string) map[string]map[string]CheckResult {
+	results := make(map[string]map[string]CheckResult)
+	if data, err := os.ReadFile(packageChecksFile(projectPath)); err == nil {
+		json.Unmarshal(data, &results)
+	}
+	return results
+}
+
+// PackageCheckCommand is the command a check runs and the directory it
+// runs in
+func PackageCheckCommand(projectPath string, p Package, check string) (dir string, args []string) {
+	root := expandPath(projectPath)
+	switch p.Ecosystem {
+	case "npm":
+		return filepath.Join(root, p.Dir), []string{jsPackageManager(root), "run", check}
+	case "go":
+		tool := map[string]string{CheckBuild: "build", CheckTest: "test", CheckLint: "vet"}[check]
+		return filepath.Join(root, p.Dir), []string{"go", tool, "./..."}
+	case "cargo":
+		tool := map[string]string{CheckBuild: "build", CheckTest: "test", CheckLint: "clippy"}[check]
+		return root, []string{"cargo", tool, "-p", p.Name}
+	}
+	return root, nil
+}
+
+// jsPackageManager picks the JavaScript package manager from the root
+// lockfile
+func jsPackageManager(root string) string {
+	for _, lock := range []struct{ file, pm string }{
+		{"bun.lockb", "bun"}, {"bun.lock", "bun"}, {"pnpm-lock.yaml", "pnpm"}, {"yarn.lock", "yarn"},
+	} {
+		if _, err := os.Stat(filepath.Join(root, lock.file)); err == nil {
+			return lock.pm
+		}
+	}
+	return "npm"
+}
+
+// packageChecksMu serializes writes to packages.json between checks
+// running at once
+var packageChecksMu sync.Mutex
+
+// RunPackageCheck runs one check of a package and records its result.
+// A test check's outcome is also added to the history FlakyTests reads.
+func RunPackageCheck(projectPath string, p Package, check string) (CheckResult, error) {
+	dir, args := PackageCheckCommand(projectPath, p, check)
+	r := CheckResult{Package: p.Name, Check: check, At: time.Now()}
+	if out, err := exec.Command("git", "-C", expandPath(projectPath), "rev-parse", "--short", "HEAD").Output(); err == nil {
+		r.Commit = strings.TrimSpace(string(out))
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	r.Duration = time.Since(r.At).Round(time.Second)
+	r.OK = err == nil
+	if !r.OK {
+		lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+		if len(lines) > checkOutputLines {
+			lines = lines[len(lines)-checkOutputLines:]
+		}
+		r.Output = strings.Join(lines, "\n")
+		if strings.TrimSpace(r.Output) == "" {
+			r.Output = err.Error()
+		}
+	}
+
+	packageChecksMu.Lock()
+	defer packageChecksMu.Unlock()
+	results := PackageCheckResults(projectPath)
+	if results[p.Name] == nil {
+		results[p.Name] = make(map[string]CheckResult)
+	}
+	results[p.Name][check] = r
+	if err := os.MkdirAll(ProjectCacheDir(projectPath), 0755); err != nil {
+		return r, err
+	}
+	if check == CheckTest {
+		recordTestRun(projectPath, p, r.OK, string(out))
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return r, err
+	}
+	return r, os.WriteFile(packageChecksFile(projectPath), data, 0644)
+}
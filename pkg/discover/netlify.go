@@ -0,0 +1,137 @@
+package discover
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/netlify"
+)
+
+// IsNetlify reports whether a project deploys to Netlify: it has a
+// netlify.toml or a .netlify link
+func IsNetlify(projectPath string) bool {
+	return netlify.Linked(expandPath(projectPath))
+}
+
+// GetNetlifyStatus returns the state of a Netlify project's latest
+// production deploy, as GetVercelStatus names them: ready, building,
+// queued, or failed. It returns "" for projects that don't deploy to
+// Netlify, and "unknown" when the state can't be read.
+func GetNetlifyStatus(projectPath string) (string, error) {
+	return fixture.Do("netlify", "status "+projectPath, func() (string, error) {
+		return getNetlifyStatus(projectPath)
+	})
+}
+
+func getNetlifyStatus(projectPath string) (string, error) {
+	expandedPath := expandPath(projectPath)
+	if !netlify.Linked(expandedPath) {
+		return "", nil
+	}
+	client := netlify.Default()
+	if client == nil {
+		return "unknown", netlify.ErrNoToken
+	}
+	site, err := netlifySite(client, expandedPath)
+	if site == "" {
+		return "unknown", err
+	}
+
+	deploys, err := client.Deploys(site, 10)
+	if err != nil {
+		return "unknown", err
+	}
+	// Deploy previews and branch deploys don't say whether the site is up
+	for _, d := range deploys {
+		if d.Context == "" || d.Context == "production" {
+			return netlifyState(d.State), nil
+		}
+	}
+	if len(deploys) == 0 {
+		return "", nil
+	}
+	return netlifyState(deploys[0].State), nil
+}
+
+// netlifySite returns the ID of the site a project builds, "" when it
+// can't be found
+func netlifySite(client *netlify.Client, expandedPath string) (string, error) {
+	if site := netlify.LinkedSite(expandedPath); site != "" {
+		return site, nil
+	}
+	// Only a netlify.toml: find the site by the repository it builds
+	cfg, _ := config.Load()
+	r, ok := forge.PreferredRemote(expandedPath, cfg)
+	if !ok {
+		return "", nil
+	}
+	s, found, err := client.SiteForRepo(r.String())
+	if err != nil || !found {
+		return "", err
+	}
+	return s.ID, nil
+}
+
+// netlifyDeploys returns a Netlify project's newest n deploys, newest
+// first, or nil for projects not on Netlify
+func netlifyDeploys(_, projectPath string, n int) ([]Deployment, error) {
+	return fixture.Do("netlify", fmt.Sprintf("deploys %d %s", n, projectPath), func() ([]Deployment, error) {
+		expandedPath := expandPath(projectPath)
+		if !netlify.Linked(expandedPath) {
+			return nil, nil
+		}
+		client := netlify.Default()
+		if client == nil {
+			return nil, netlify.ErrNoToken
+		}
+		site, err := netlifySite(client, expandedPath)
+		if site == "" {
+			return nil, err
+		}
+		raw, err := client.Deploys(site, n)
+		if err != nil {
+			return nil, err
+		}
+		deploys := make([]Deployment, 0, len(raw))
+		for _, d := range raw {
+			deploy := Deployment{
+				Provider: "netlify",
+				Site:     site,
+				ID:       d.ID,
+				URL:      hostOf(d.URL),
+				LogsURL:  d.AdminURL + "/deploys/" + d.ID,
+				State:    netlifyState(d.State),
+				Created:  d.CreatedAt,
+				Duration: time.Duration(d.DeployTime) * time.Second,
+				SHA:      d.CommitRef,
+				Ref:      d.Branch,
+				PR:       d.ReviewID,
+				Message:  firstLine(d.Title),
+				Author:   d.Committer,
+			}
+			if d.Context == "" || d.Context == "production" {
+				deploy.Target = "production"
+			}
+			deploys = append(deploys, deploy)
+		}
+		return deploys, nil
+	})
+}
+
+// netlifyState names a deploy's state as Vercel's are named
+func netlifyState(state string) string {
+	switch state {
+	case "ready":
+		return "ready"
+	case "error", "rejected":
+		return "failed"
+	case "new", "pending_review", "accepted", "enqueued":
+		return "queued"
+	case "building", "uploading", "uploaded", "preparing", "prepared", "processing", "processed", "retrying":
+		return "building"
+	}
+	return state
+}
@@ -0,0 +1,54 @@
+package discover
+
+import (
+	"sort"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+)
+
+// ListNotifications returns the user's unread notifications, newest
+// first: GitHub's, and those of every other forge the projects (by path)
+// are hosted on
+func ListNotifications(projectPaths []string) ([]forge.Notification, error) {
+	return fixture.Do("github", "notifications", func() ([]forge.Notification, error) {
+		return listNotifications(projectPaths)
+	})
+}
+
+func listNotifications(projectPaths []string) ([]forge.Notification, error) {
+	cfg, _ := config.Load()
+	var ns []forge.Notification
+	if f, ok := forge.ForHost(forge.GitHub, "github.com", cfg); ok {
+		var err error
+		if ns, err = f.Notifications(); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := map[string]bool{forge.GitHub + " github.com": true}
+	for _, path := range projectPaths {
+		r, ok := forge.PreferredRemote(expandPath(path), cfg)
+		if !ok || seen[r.Forge+" "+r.Host] {
+			continue
+		}
+		seen[r.Forge+" "+r.Host] = true
+		f, ok := forge.ForHost(r.Forge, r.Host, cfg)
+		if !ok {
+			continue
+		}
+		// One forge being down or without notifications shouldn't hide
+		// the rest
+		if more, err := f.Notifications(); err == nil {
+			ns = append(ns, more...)
+		}
+	}
+	sort.SliceStable(ns, func(i, j int) bool { return ns[i].UpdatedAt.After(ns[j].UpdatedAt) })
+	return ns, nil
+}
+
+// MarkNotificationRead marks a notification read on its forge
+func MarkNotificationRead(n forge.Notification) error {
+	return n.MarkRead()
+}
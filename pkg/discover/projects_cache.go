@@ -0,0 +1,149 @@
+package discover
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ProjectsCacheVersion is the current schema version of projects.json.
+// Bump it whenever the on-disk format changes and add a matching case
+// to migrateProjectsCache.
+const ProjectsCacheVersion = 2
+
+// ProjectsCache is the on-disk format of ~/.hustlemc/projects.json
+type ProjectsCache struct {
+	Version     int       `json:"version"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Root        string    `json:"root,omitempty"`
+	Projects    []Project `json:"projects"`
+}
+
+// errIncompatibleCache signals that the cache must be regenerated
+type errIncompatibleCache struct {
+	version int
+	reason  string
+}
+
+func (e *errIncompatibleCache) Error() string {
+	return fmt.Sprintf("incompatible projects cache (version %d): %s", e.version, e.reason)
+}
+
+// ProjectsCacheFile returns the path of the projects cache
+func ProjectsCacheFile() string {
+	return filepath.Join(CacheDir(), "projects.json")
+}
+
+// readProjectsCache reads projects.json, migrating older formats in place.
+// Returns errIncompatibleCache when the file cannot be used as-is.
+func readProjectsCache() ([]Project, error) {
+	data, err := os.ReadFile(ProjectsCacheFile())
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := projectsCacheVersion(data)
+	if err != nil {
+		return nil, &errIncompatibleCache{version: 0, reason: err.Error()}
+	}
+
+	switch {
+	case version == ProjectsCacheVersion:
+		var cache ProjectsCache
+		if err := json.Unmarshal(data, &cache); err != nil {
+			return nil, &errIncompatibleCache{version: version, reason: err.Error()}
+		}
+		return cache.Projects, nil
+	case version > ProjectsCacheVersion:
+		// Written by a newer mc - don't guess at the format
+		return nil, &errIncompatibleCache{version: version, reason: "written by a newer version"}
+	}
+
+	projects, err := migrateProjectsCache(version, data)
+	if err != nil {
+		return nil, &errIncompatibleCache{version: version, reason: err.Error()}
+	}
+
+	// Persist the migrated format so we only migrate once. Version 1
+	// didn't record the directory scanned.
+	if err := SaveProjects("", projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// projectsCacheVersion sniffs the schema version of raw cache data.
+// Version 1 caches were a bare JSON array with no version field.
+func projectsCacheVersion(data []byte) (int, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return 0, fmt.Errorf("empty cache")
+	}
+
+	switch trimmed[0] {
+	case '[':
+		return 1, nil
+	case '{':
+		var header struct {
+			Version int `json:"version"`
+		}
+		if err := json.Unmarshal(trimmed, &header); err != nil {
+			return 0, err
+		}
+		if header.Version < 1 {
+			return 0, fmt.Errorf("missing version field")
+		}
+		return header.Version, nil
+	}
+
+	return 0, fmt.Errorf("unrecognized cache format")
+}
+
+// migrateProjectsCache upgrades an older cache format to the current one
+func migrateProjectsCache(version int, data []byte) ([]Project, error) {
+	switch version {
+	case 1:
+		// v1: bare array of projects
+		var projects []Project
+		if err := json.Unmarshal(data, &projects); err != nil {
+			return nil, err
+		}
+		return projects, nil
+	}
+
+	return nil, fmt.Errorf("no migration from version %d", version)
+}
+
+// SaveProjects writes the project list in the current cache format. root
+// is the directory scanned for them, "" when it isn't known.
+func SaveProjects(root string, projects []Project) error {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	if err := os.MkdirAll(CacheDir(), 0755); err != nil {
+		return err
+	}
+
+	cache := ProjectsCache{
+		Version:     ProjectsCacheVersion,
+		GeneratedAt: time.Now(),
+		Root:        root,
+		Projects:    projects,
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename so readers never see a partial cache
+	tmp := ProjectsCacheFile() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ProjectsCacheFile())
+}
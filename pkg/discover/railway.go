@@ -0,0 +1,113 @@
+package discover
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/railway"
+)
+
+// GetRailwayStatus returns the state of a project's latest Railway
+// deployment, named as GetVercelStatus names them: ready, building,
+// queued, or failed. What it deploys to is the service ID set as
+// "railway" in the project's settings, else the project railway link
+// saved. It returns "" for projects not on Railway.
+func GetRailwayStatus(name, projectPath string) (string, error) {
+	return fixture.Do("railway", "status "+projectPath, func() (string, error) {
+		return getRailwayStatus(name, projectPath)
+	})
+}
+
+func getRailwayStatus(name, projectPath string) (string, error) {
+	link, ok := railwayLink(name, projectPath)
+	if !ok {
+		if railway.Configured(expandPath(projectPath)) {
+			// A railway.json, but nothing says which service it builds
+			return "unknown", nil
+		}
+		return "", nil
+	}
+	client := railway.Default()
+	if client == nil {
+		return "unknown", railway.ErrNoToken
+	}
+	deployments, err := client.Deployments(link, 1)
+	if err != nil {
+		return "unknown", err
+	}
+	if len(deployments) == 0 {
+		return "", nil
+	}
+	return railwayState(deployments[0].Status), nil
+}
+
+// railwayLink is what a project deploys to: the service ID set as
+// "railway" in its settings, else the project railway link saved
+func railwayLink(name, projectPath string) (railway.Link, bool) {
+	cfg, _ := config.Load()
+	if cfg != nil {
+		if id := cfg.Project(name).Railway; id != "" {
+			return railway.Link{Service: id}, true
+		}
+	}
+	return railway.Linked(expandPath(projectPath))
+}
+
+// railwayDeploys returns a project's newest n Railway deployments, newest
+// first, or nil for projects not linked to Railway
+func railwayDeploys(name, projectPath string, n int) ([]Deployment, error) {
+	return fixture.Do("railway", fmt.Sprintf("deploys %d %s", n, projectPath), func() ([]Deployment, error) {
+		link, ok := railwayLink(name, projectPath)
+		if !ok {
+			return nil, nil
+		}
+		client := railway.Default()
+		if client == nil {
+			return nil, railway.ErrNoToken
+		}
+		raw, err := client.Deployments(link, n)
+		if err != nil {
+			return nil, err
+		}
+		deploys := make([]Deployment, 0, len(raw))
+		for _, d := range raw {
+			deploy := Deployment{
+				Provider: "railway",
+				ID:       d.ID,
+				URL:      d.StaticURL,
+				LogsURL:  d.LogsURL(),
+				State:    railwayState(d.Status),
+				Target:   "production",
+				Created:  d.CreatedAt,
+				SHA:      d.Meta.CommitHash,
+				Message:  firstLine(d.Meta.CommitMessage),
+				Author:   d.Meta.CommitAuthor,
+			}
+			if deploy.Author == "" && d.Creator != nil {
+				deploy.Author = d.Creator.Name
+			}
+			if s := deploy.State; (s == "ready" || s == "failed") && d.UpdatedAt.After(d.CreatedAt) {
+				deploy.Duration = d.UpdatedAt.Sub(d.CreatedAt)
+			}
+			deploys = append(deploys, deploy)
+		}
+		return deploys, nil
+	})
+}
+
+// railwayState names a deployment's status as Vercel's are named
+func railwayState(status string) string {
+	switch status {
+	case "SUCCESS", "SLEEPING":
+		return "ready"
+	case "FAILED", "CRASHED":
+		return "failed"
+	case "QUEUED", "WAITING":
+		return "queued"
+	case "BUILDING", "DEPLOYING", "INITIALIZING":
+		return "building"
+	}
+	return strings.ToLower(status)
+}
@@ -0,0 +1,248 @@
+package discover
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// readmeNames are the README files looked for, in order
+var readmeNames = []string{"README.md", "readme.md", "Readme.md", "README"}
+
+var (
+	// A version pinned where it's meant to be the latest: go install
+	// x@v1.2.3, npx x@1.2.3, releases/download/v1.2.3/, and version or
+	// release badges
+	readmeVersionPattern = regexp.MustCompile(`(@|releases/download/|badge/(?:version|release)-)v?(\d+\.\d+\.\d+)\b`)
+	// go get / go install of a module path
+	readmeGoGetPattern = regexp.MustCompile(`\bgo (?:get|install) (?:-u )?([\w.-]+\.[a-z]+/[\w./-]+?)(?:@[\w.-]+)?(?:\s|$|` + "`" + `)`)
+	// github.com/owner/repo in links, badges, and clone commands
+	readmeRepoPattern = regexp.MustCompile(`github\.com[/:]([\w.-]+)/([\w.-]+?)(?:\.git)?(?:[/)\s"'#?]|$)`)
+	// an Actions workflow's status badge
+	readmeWorkflowBadgePattern = regexp.MustCompile(`actions/workflows/([\w.-]+\.ya?ml)/badge\.svg`)
+	// a hand-written badge claiming the build or tests pass
+	readmeStaticPassingPattern = regexp.MustCompile(`img\.shields\.io/badge/(?:build|tests?|ci)-passing`)
+)
+
+// ReadmeFinding is one way a README disagrees with the repository
+type ReadmeFinding struct {
+	Kind   string // version, module, repo, badge, or ci
+	Line   int
+	Found  string // what the README says
+	Actual string // what's true
+}
+
+// String describes the finding in a phrase
+func (f ReadmeFinding) String() string {
+	switch f.Kind {
+	case "version":
+		return fmt.Sprintf("line %d pins %s, latest is %s", f.Line, f.Found, f.Actual)
+	case "module":
+		return fmt.Sprintf("line %d installs %s, the module is %s", f.Line, f.Found, f.Actual)
+	case "repo":
+		return fmt.Sprintf("line %d links %s, the repository is %s", f.Line, f.Found, f.Actual)
+	case "badge":
+		return fmt.Sprintf("line %d badges workflow %s, which no longer exists", f.Line, f.Found)
+	case "ci":
+		return fmt.Sprintf("line %d claims %s, CI is failing", f.Line, f.Found)
+	}
+	return fmt.Sprintf("line %d: %s (%s)", f.Line, f.Found, f.Actual)
+}
+
+// ReadmeCheck is what a README claims that demonstrably isn't so
+type ReadmeCheck struct {
+	Path     string // the README, relative to the project
+	Findings []ReadmeFinding
+	Checked  time.Time
+}
+
+// Stale reports whether the README says anything untrue
+func (c *ReadmeCheck) Stale() bool {
+	return c != nil && len(c.Findings) > 0
+}
+
+// CheckReadme compares a project's README with the repository: pinned
+// versions against the latest tag, go get paths against go.mod, GitHub
+// links against the remote, workflow badges against .github/workflows,
+// and hand-written "passing" badges against CI (ciFailing). It returns
+// nil for projects without a README.
+func CheckReadme(projectPath string, ciFailing bool) (*ReadmeCheck, error) {
+	return fixture.Do("git", fmt.Sprintf("readme %t %s", ciFailing, projectPath), func() (*ReadmeCheck, error) {
+		return checkReadme(projectPath, ciFailing)
+	})
+}
+
+func checkReadme(projectPath string, ciFailing bool) (*ReadmeCheck, error) {
+	expandedPath := expandPath(projectPath)
+	name := ""
+	for _, n := range readmeNames {
+		if _, err := os.Stat(filepath.Join(expandedPath, n)); err == nil {
+			name = n
+			break
+		}
+	}
+	if name == "" {
+		return nil, nil
+	}
+	f, err := os.Open(filepath.Join(expandedPath, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	latest := ""
+	if r, err := LatestRelease(projectPath); err == nil && r != nil {
+		latest = strings.TrimPrefix(r.Tag, "v")
+		if len(versionParts(latest)) < 3 {
+			latest = ""
+		}
+	}
+	module := goModulePath(expandedPath)
+	repo := GitHubRepo(projectPath)
+	_, repoName, _ := strings.Cut(repo, "/")
+	ownName := strings.ToLower(repoName)
+	if ownName == "" {
+		ownName = strings.ToLower(filepath.Base(expandedPath))
+	}
+
+	check := &ReadmeCheck{Path: name, Checked: time.Now()}
+	add := func(kind string, line int, found, actual string) {
+		for _, f := range check.Findings {
+			if f.Kind == kind && f.Found == found {
+				return
+			}
+		}
+		check.Findings = append(check.Findings, ReadmeFinding{Kind: kind, Line: line, Found: found, Actual: actual})
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if latest != "" {
+			for _, m := range readmeVersionPattern.FindAllStringSubmatch(line, -1) {
+				// Pins are only this project's when the line names it;
+				// others are dependencies
+				own := strings.HasPrefix(m[1], "badge/") || strings.Contains(strings.ToLower(line), ownName)
+				if own && versionOlder(m[2], latest) {
+					add("version", n, m[2], latest)
+				}
+			}
+		}
+		if module != "" {
+			for _, m := range readmeGoGetPattern.FindAllStringSubmatch(line, -1) {
+				if moved(m[1], module) {
+					add("module", n, m[1], module)
+				}
+			}
+		}
+		if repo != "" {
+			for _, m := range readmeRepoPattern.FindAllStringSubmatch(line, -1) {
+				found := m[1] + "/" + m[2]
+				// Only links to this project under an old owner or name
+				if strings.EqualFold(m[2], repoName) && !strings.EqualFold(found, repo) {
+					add("repo", n, found, repo)
+				}
+			}
+			for _, m := range readmeWorkflowBadgePattern.FindAllStringSubmatch(line, -1) {
+				if _, err := os.Stat(filepath.Join(expandedPath, ".github", "workflows", m[1])); err != nil {
+					add("badge", n, m[1], "")
+				}
+			}
+		}
+		if ciFailing && readmeStaticPassingPattern.MatchString(line) {
+			add("ci", n, "a passing build", "failing")
+		}
+	}
+	return check, scanner.Err()
+}
+
+// ReadReadme returns the README a check read
+func ReadReadme(projectPath string, check *ReadmeCheck) (string, error) {
+	data, err := os.ReadFile(filepath.Join(expandPath(projectPath), check.Path))
+	return string(data), err
+}
+
+// ProposeReadme opens a pull request replacing a project's README with
+// content, listing what the check found, and returns its URL. It goes
+// through the API, so it needs a GitHub token.
+func ProposeReadme(projectPath string, check *ReadmeCheck, content string) (string, error) {
+	client, repo, ok := githubRepo(expandPath(projectPath))
+	if !ok {
+		if github.Default() == nil {
+			return "", github.ErrNoToken
+		}
+		return "", fmt.Errorf("no github.com remote")
+	}
+	base := DefaultBranch(projectPath)
+	if base == "" {
+		base = "main"
+	}
+	var body strings.Builder
+	body.WriteString("The README disagreed with the repository:\n\n")
+	for _, f := range check.Findings {
+		fmt.Fprintf(&body, "- %s\n", f)
+	}
+	return client.ProposeFile(repo, github.FileChange{
+		Base:    base,
+		Branch:  "mc/readme-" + time.Now().Format("20060102-150405"),
+		Path:    check.Path,
+		Content: content,
+		Message: "Update stale README",
+		Title:   "Update stale README",
+		Body:    body.String(),
+	})
+}
+
+// goModulePath reads the module path from a directory's go.mod
+func goModulePath(expandedPath string) string {
+	data, err := os.ReadFile(filepath.Join(expandedPath, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// moved reports whether path is the module under an old name: the same
+// repository name but a different module, ignoring packages within it
+// and major version suffixes
+func moved(path, module string) bool {
+	if path == module || strings.HasPrefix(path, module+"/") {
+		return false
+	}
+	root := func(p string) []string {
+		parts := strings.Split(p, "/")
+		if len(parts) > 3 {
+			parts = parts[:3]
+		}
+		return parts
+	}
+	a, b := root(path), root(module)
+	if len(a) < 3 || len(b) < 3 {
+		return false
+	}
+	return strings.EqualFold(a[2], b[2]) && !strings.EqualFold(strings.Join(a, "/"), strings.Join(b, "/"))
+}
+
+// versionOlder reports whether version a comes before b
+func versionOlder(a, b string) bool {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if pa[i] != pb[i] {
+			return pa[i] < pb[i]
+		}
+	}
+	return len(pa) < len(pb)
+}
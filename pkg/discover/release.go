@@ -0,0 +1,117 @@
+package discover
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Release is the newest tag on a project's default branch. GitHub
+// releases are tags too, so a fetched release shows up here.
+type Release struct {
+	Tag   string
+	Date  time.Time // when the tagged commit was made
+	Since int       // commits on the branch after the tag
+}
+
+// Unreleased reports whether commits have landed since the release
+func (r *Release) Unreleased() bool {
+	return r != nil && r.Since > 0
+}
+
+// LatestRelease returns the newest tag on the default branch (HEAD when
+// there isn't one) and how far the branch has moved past it, or nil when
+// nothing has been tagged
+func LatestRelease(projectPath string) (*Release, error) {
+	return fixture.Do("git", "release "+projectPath, func() (*Release, error) {
+		return latestRelease(projectPath)
+	})
+}
+
+func latestRelease(projectPath string) (*Release, error) {
+	expandedPath := expandPath(projectPath)
+	branch := defaultBranch(projectPath)
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return latestReleaseDirect(expandedPath, branch)
+	}
+	defer repo.Close()
+
+	var from gitrepo.Hash
+	if branch != "" {
+		from, err = repo.ResolveRef("refs/heads/" + branch)
+	}
+	if branch == "" || err != nil {
+		if _, from, err = repo.Head(); err != nil {
+			return latestReleaseDirect(expandedPath, branch)
+		}
+	}
+
+	tag, commit, err := repo.LatestTag(from)
+	if errors.Is(err, gitrepo.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return latestReleaseDirect(expandedPath, branch)
+	}
+	since, _, err := repo.AheadBehind(from, commit)
+	if err != nil {
+		return latestReleaseDirect(expandedPath, branch)
+	}
+	r := &Release{Tag: tag, Since: since}
+	if c, err := repo.ReadCommit(commit); err == nil {
+		r.Date = c.When
+	}
+	return r, nil
+}
+
+// latestReleaseDirect is a fallback using git describe
+func latestReleaseDirect(expandedPath, branch string) (*Release, error) {
+	from := branch
+	if from == "" {
+		from = "HEAD"
+	}
+	out, err := exec.Command("git", "-C", expandedPath, "describe", "--tags", "--abbrev=0", from).Output()
+	if err != nil {
+		// describe fails when there are no tags
+		return nil, nil
+	}
+	r := &Release{Tag: strings.TrimSpace(string(out))}
+	if out, err := exec.Command("git", "-C", expandedPath, "rev-list", "--count", r.Tag+".."+from).Output(); err == nil {
+		r.Since, _ = strconv.Atoi(strings.TrimSpace(string(out)))
+	}
+	if out, err := exec.Command("git", "-C", expandedPath, "log", "-1", "--format=%ct", r.Tag+"^{commit}").Output(); err == nil {
+		if sec, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64); err == nil {
+			r.Date = time.Unix(sec, 0)
+		}
+	}
+	return r, nil
+}
+
+// ListReleases returns a project's newest n releases published on its
+// forge. It is nil, not an error, for forges without releases.
+func ListReleases(projectPath string, n int) ([]forge.Release, error) {
+	return fixture.Do("github", fmt.Sprintf("releases %s %d", projectPath, n), func() ([]forge.Release, error) {
+		return listReleases(expandPath(projectPath), n)
+	})
+}
+
+func listReleases(expandedPath string, n int) ([]forge.Release, error) {
+	f, ok := forge.Open(expandedPath)
+	if !ok {
+		return nil, forge.ErrNoForge
+	}
+	releases, err := f.Releases(n)
+	if errors.Is(err, forge.ErrUnsupported) {
+		return nil, nil
+	}
+	return releases, err
+}
@@ -0,0 +1,103 @@
+package discover
+
+import (
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Remote is one of a project's remotes and where the current branch
+// stands against it
+type Remote struct {
+	Name        string
+	URL         string
+	Upstream    bool // the branch's configured upstream
+	PushDefault bool // where a plain `git push` goes
+	Tracked     bool // the remote has a copy of the branch
+	Ahead       int
+	Behind      int
+}
+
+// remotesNative reads remotes from an open repository
+func remotesNative(repo *gitrepo.Repo) ([]Remote, error) {
+	entries, err := repo.Remotes()
+	if err != nil {
+		return nil, err
+	}
+	remotes := make([]Remote, 0, len(entries))
+	for _, e := range entries {
+		remotes = append(remotes, Remote{
+			Name:        e.Name,
+			URL:         e.URL,
+			Upstream:    e.Upstream,
+			PushDefault: e.PushDefault,
+			Tracked:     e.Tracking != "",
+			Ahead:       e.Ahead,
+			Behind:      e.Behind,
+		})
+	}
+	return remotes, nil
+}
+
+// listRemotesDirect is a fallback using git directly
+func listRemotesDirect(expandedPath string) ([]Remote, error) {
+	git := func(args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", expandedPath}, args...)...).Output()
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	names := strings.Fields(git("remote"))
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	branch := git("symbolic-ref", "--quiet", "--short", "HEAD")
+	upstream := ""
+	if branch != "" {
+		upstream = git("config", "branch."+branch+".remote")
+	}
+	// Same precedence as push: pushRemote, pushDefault, remote, origin
+	// (@{push} can't resolve when it differs from the upstream)
+	var candidates []string
+	if branch != "" {
+		candidates = append(candidates, git("config", "branch."+branch+".pushRemote"))
+	}
+	candidates = append(candidates, git("config", "remote.pushDefault"), upstream, "origin")
+	push := ""
+	for _, c := range candidates {
+		if c != "" && slices.Contains(names, c) {
+			push = c
+			break
+		}
+	}
+
+	remotes := make([]Remote, 0, len(names))
+	for _, name := range names {
+		rm := Remote{
+			Name:        name,
+			URL:         git("remote", "get-url", name),
+			Upstream:    name == upstream,
+			PushDefault: name == push,
+		}
+		if branch != "" {
+			tracking := "refs/remotes/" + name + "/" + branch
+			if rm.Upstream {
+				tracking = "@{upstream}"
+			}
+			counts := strings.Fields(git("rev-list", "--left-right", "--count", "HEAD..."+tracking))
+			if len(counts) == 2 {
+				rm.Tracked = true
+				rm.Ahead, _ = strconv.Atoi(counts[0])
+				rm.Behind, _ = strconv.Atoi(counts[1])
+			}
+		}
+		remotes = append(remotes, rm)
+	}
+	return remotes, nil
+}
@@ -0,0 +1,152 @@
+package discover
+
+import (
+	"fmt"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/render"
+)
+
+// GetRenderStatus returns the state of a project's latest Render deploy,
+// named as GetVercelStatus names them: ready, building, queued, or
+// failed. The services are the ones its render.yaml defines, or the
+// service ID set as "render" in the project's settings; with several,
+// the worst state wins. It returns "" for projects not on Render.
+func GetRenderStatus(name, projectPath string) (string, error) {
+	return fixture.Do("render", "status "+projectPath, func() (string, error) {
+		return getRenderStatus(name, projectPath)
+	})
+}
+
+func getRenderStatus(name, projectPath string) (string, error) {
+	client, services, err := renderServices(name, projectPath)
+	if client == nil && err == nil {
+		return "", nil
+	}
+	if err != nil {
+		return "unknown", err
+	}
+	if len(services) == 0 {
+		// Not created from the Blueprint yet
+		return "unknown", nil
+	}
+
+	var states []string
+	for _, s := range services {
+		deploys, err := client.Deploys(s.ID, 1)
+		if err != nil {
+			return "unknown", err
+		}
+		if len(deploys) > 0 {
+			states = append(states, renderState(deploys[0].Status))
+		}
+	}
+	return worstDeployState(states), nil
+}
+
+// renderServices finds a project's Render services: the one set as
+// "render" in its settings, and those its render.yaml defines. The client
+// is nil for projects not on Render.
+func renderServices(name, projectPath string) (*render.Client, []render.Service, error) {
+	cfg, _ := config.Load()
+	id := ""
+	if cfg != nil {
+		id = cfg.Project(name).Render
+	}
+	blueprint := render.BlueprintServices(expandPath(projectPath))
+	if id == "" && len(blueprint) == 0 {
+		return nil, nil, nil
+	}
+	client := render.Default()
+	if client == nil {
+		return nil, nil, render.ErrNoToken
+	}
+	var services []render.Service
+	if id != "" {
+		s, err := client.Service(id)
+		if err != nil {
+			return client, nil, err
+		}
+		services = append(services, s)
+	}
+	for _, service := range blueprint {
+		found, err := client.ServicesNamed(service)
+		if err != nil {
+			return client, nil, err
+		}
+		services = append(services, found...)
+	}
+	return client, services, nil
+}
+
+// renderDeploys returns the newest n deploys of a project's Render
+// services, newest first, or nil for projects not on Render
+func renderDeploys(name, projectPath string, n int) ([]Deployment, error) {
+	return fixture.Do("render", fmt.Sprintf("deploys %d %s", n, projectPath), func() ([]Deployment, error) {
+		client, services, err := renderServices(name, projectPath)
+		if client == nil || err != nil {
+			return nil, err
+		}
+		var deploys []Deployment
+		for _, s := range services {
+			raw, err := client.Deploys(s.ID, n)
+			if err != nil {
+				return deploys, err
+			}
+			for _, d := range raw {
+				deploy := Deployment{
+					Provider: "render",
+					Site:     s.ID,
+					ID:       d.ID,
+					URL:      hostOf(s.ServiceDetails.URL),
+					LogsURL:  s.DashboardURL + "/deploys/" + d.ID,
+					State:    renderState(d.Status),
+					Target:   "production",
+					Created:  d.CreatedAt,
+					SHA:      d.Commit.ID,
+					Message:  firstLine(d.Commit.Message),
+				}
+				if len(services) > 1 {
+					deploy.Service = s.Name
+				}
+				if !d.FinishedAt.IsZero() {
+					deploy.Duration = d.FinishedAt.Sub(d.CreatedAt)
+				}
+				deploys = append(deploys, deploy)
+			}
+		}
+		return deploys, nil
+	})
+}
+
+// renderState names a deploy's status as Vercel's are named
+func renderState(status string) string {
+	switch status {
+	case "live":
+		return "ready"
+	case "build_failed", "update_failed", "pre_deploy_failed":
+		return "failed"
+	case "created":
+		return "queued"
+	case "build_in_progress", "update_in_progress", "pre_deploy_in_progress":
+		return "building"
+	}
+	return status
+}
+
+// worstDeployState is the state of several services' deploys taken
+// together: any failure, else any build, else any queued, else ready
+func worstDeployState(states []string) string {
+	if len(states) == 0 {
+		return ""
+	}
+	rank := map[string]int{"failed": 4, "building": 3, "queued": 2, "ready": 1}
+	worst := states[0]
+	for _, s := range states[1:] {
+		if rank[s] > rank[worst] {
+			worst = s
+		}
+	}
+	return worst
+}
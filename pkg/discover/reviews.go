@@ -0,0 +1,31 @@
+package discover
+
+import (
+	"sort"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// ReviewComments lists the unresolved pull request review threads waiting
+// on the user across GitHub, oldest first, from the API or via gh when no
+// token is available
+func ReviewComments() ([]github.ReviewComment, error) {
+	return fixture.Do("github", "review comments", func() ([]github.ReviewComment, error) {
+		comments, err := reviewComments()
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(comments, func(i, j int) bool { return comments[i].At.Before(comments[j].At) })
+		return comments, nil
+	})
+}
+
+func reviewComments() ([]github.ReviewComment, error) {
+	if client := github.Default(); client != nil {
+		if comments, err := client.ReviewComments(); err == nil {
+			return comments, nil
+		}
+	}
+	return github.ReadReviewComments(ghGraphQL)
+}
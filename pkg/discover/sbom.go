@@ -0,0 +1,266 @@
+package discover
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/sbom"
+)
+
+// ErrNoSBOMTool means none of the SBOM generators mc knows is installed
+// for a project's ecosystems
+var ErrNoSBOMTool = errors.New("no SBOM generator found (install syft, cdxgen, or cyclonedx-gomod; npm 10 has npm sbom)")
+
+// sbomGenerator is a tool that writes a CycloneDX SBOM
+type sbomGenerator struct {
+	tool    string
+	applies func(dir string) bool // nil when it reads any project
+	args    func(dir, out string) []string
+	stdout  bool // the SBOM comes on stdout rather than in out
+}
+
+// sbomGenerators are tried in order: syft and cdxgen read every
+// ecosystem, the rest only their own
+var sbomGenerators = []sbomGenerator{
+	{tool: "syft", args: func(dir, out string) []string {
+		return []string{"dir:" + dir, "-q", "-o", "cyclonedx-json=" + out}
+	}},
+	{tool: "cdxgen", args: func(dir, out string) []string {
+		return []string{"-o", out, dir}
+	}},
+	{tool: "npm", applies: hasFile("package-lock.json"), stdout: true, args: func(dir, out string) []string {
+		return []string{"sbom", "--sbom-format", "cyclonedx"}
+	}},
+	{tool: "cyclonedx-gomod", applies: hasFile("go.mod"), args: func(dir, out string) []string {
+		return []string{"mod", "-json", "-licenses", "-output", out, dir}
+	}},
+}
+
+// sbomInputs are the lockfiles and manifests an SBOM is read from; one
+// changing makes the cached SBOM stale
+var sbomInputs = []string{
+	"package-lock.json", "pnpm-lock.yaml", "yarn.lock", "bun.lock", "bun.lockb",
+	"go.mod", "go.sum", "Cargo.lock", "poetry.lock", "uv.lock", "requirements.txt",
+	"Gemfile.lock", "composer.lock", "Package.resolved",
+}
+
+func hasFile(name string) func(string) bool {
+	return func(dir string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+}
+
+// SBOMInfo is a project's cached SBOM and the vulnerabilities found in it
+type SBOMInfo struct {
+	SBOM  *sbom.SBOM
+	Scan  *sbom.Scan // nil when no scanner was installed
+	Stale bool       // a lockfile changed since it was generated
+}
+
+func sbomFile(projectPath string) string {
+	return filepath.Join(ProjectCacheDir(projectPath), "sbom.cdx.json")
+}
+
+func vulnsFile(projectPath string) string {
+	return filepath.Join(ProjectCacheDir(projectPath), "vulns.json")
+}
+
+// LoadSBOM reads a project's cached SBOM and vulnerability scan without
+// generating anything. It returns nil when none has been generated.
+func LoadSBOM(projectPath string) (*SBOMInfo, error) {
+	return fixture.Do("tools", "sbom "+projectPath, func() (*SBOMInfo, error) {
+		return loadSBOM(projectPath)
+	})
+}
+
+func loadSBOM(projectPath string) (*SBOMInfo, error) {
+	file := sbomFile(projectPath)
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bom, err := sbom.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	info := &SBOMInfo{SBOM: bom}
+	if stat, err := os.Stat(file); err == nil {
+		if bom.Generated.IsZero() {
+			bom.Generated = stat.ModTime()
+		}
+		for _, name := range sbomInputs {
+			if modTime(filepath.Join(expandPath(projectPath), name)).After(stat.ModTime()) {
+				info.Stale = true
+			}
+		}
+	}
+	if data, err := os.ReadFile(vulnsFile(projectPath)); err == nil {
+		var scan sbom.Scan
+		if json.Unmarshal(data, &scan) == nil {
+			info.Scan = &scan
+		}
+	}
+	return info, nil
+}
+
+// SBOMDocument returns a project's cached CycloneDX SBOM as generated
+func SBOMDocument(projectPath string) ([]byte, error) {
+	return os.ReadFile(sbomFile(projectPath))
+}
+
+// sbomGeneratorFor picks the first installed generator that reads the
+// project
+func sbomGeneratorFor(expandedPath string) (sbomGenerator, bool) {
+	for _, g := range sbomGenerators {
+		if g.applies != nil && !g.applies(expandedPath) {
+			continue
+		}
+		if _, err := exec.LookPath(g.tool); err == nil {
+			return g, true
+		}
+	}
+	return sbomGenerator{}, false
+}
+
+// SBOMCommand is the command GenerateSBOM would run, for a dry run
+func SBOMCommand(projectPath string) ([]string, error) {
+	expandedPath := expandPath(projectPath)
+	g, ok := sbomGeneratorFor(expandedPath)
+	if !ok {
+		return nil, ErrNoSBOMTool
+	}
+	return append([]string{g.tool}, g.args(expandedPath, sbomFile(projectPath))...), nil
+}
+
+// GenerateSBOM writes a project's SBOM with the first generator that's
+// installed, caches it in the project's .hustlemc directory, and scans it
+// for known vulnerabilities with grype or osv-scanner when one is
+// installed
+func GenerateSBOM(projectPath string) (*SBOMInfo, error) {
+	expandedPath := expandPath(projectPath)
+	g, ok := sbomGeneratorFor(expandedPath)
+	if !ok {
+		return nil, ErrNoSBOMTool
+	}
+	if err := os.MkdirAll(ProjectCacheDir(projectPath), 0755); err != nil {
+		return nil, err
+	}
+
+	// Write beside the cache and rename, so a failed run keeps the last
+	// good SBOM
+	file := sbomFile(projectPath)
+	tmp := file + ".tmp"
+	defer os.Remove(tmp)
+	cmd := exec.Command(g.tool, g.args(expandedPath, tmp)...)
+	cmd.Dir = expandedPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, commandError(g.tool, err)
+	}
+	if g.stdout {
+		if err := os.WriteFile(tmp, out, 0644); err != nil {
+			return nil, err
+		}
+	}
+	data, err := os.ReadFile(tmp)
+	if err != nil {
+		return nil, err
+	}
+	bom, err := sbom.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return nil, err
+	}
+	if bom.Generated.IsZero() {
+		bom.Generated = time.Now()
+	}
+	if bom.Tool == "" {
+		bom.Tool = g.tool
+	}
+
+	info := &SBOMInfo{SBOM: bom}
+	scan, err := scanSBOM(file)
+	if err != nil {
+		return info, err
+	}
+	info.Scan = scan
+	if scan != nil {
+		data, err := json.MarshalIndent(scan, "", "  ")
+		if err != nil {
+			return info, err
+		}
+		return info, os.WriteFile(vulnsFile(projectPath), data, 0644)
+	}
+	return info, nil
+}
+
+// scanSBOM looks up an SBOM's components in a vulnerability database,
+// returning nil when neither grype nor osv-scanner is installed
+func scanSBOM(file string) (*sbom.Scan, error) {
+	scanners := []struct {
+		tool  string
+		args  []string
+		parse func([]byte) ([]sbom.Vuln, error)
+	}{
+		{"grype", []string{"sbom:" + file, "-q", "-o", "json"}, sbom.ParseGrype},
+		{"osv-scanner", []string{"--sbom=" + file, "--format", "json"}, sbom.ParseOSV},
+	}
+	for _, s := range scanners {
+		if _, err := exec.LookPath(s.tool); err != nil {
+			continue
+		}
+		out, err := exec.Command(s.tool, s.args...).Output()
+		// osv-scanner exits 1 when it finds something
+		var exitErr *exec.ExitError
+		if err != nil && !(errors.As(err, &exitErr) && len(out) > 0) {
+			return nil, commandError(s.tool, err)
+		}
+		vulns, err := s.parse(out)
+		if err != nil {
+			return nil, err
+		}
+		return &sbom.Scan{Scanner: s.tool, ScannedAt: time.Now(), Vulns: vulns}, nil
+	}
+	return nil, nil
+}
+
+// commandError puts a failed command's stderr in its error
+func commandError(tool string, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+			if i := strings.LastIndex(msg, "\n"); i >= 0 {
+				msg = msg[i+1:]
+			}
+			return errors.New(tool + ": " + msg)
+		}
+	}
+	return err
+}
+
+// LicensePolicy is a project's license policy from .mc.toml, or
+// sbom.DefaultPolicy
+func LicensePolicy(projectPath string) sbom.Policy {
+	pf, _ := config.LoadProjectFile(expandPath(projectPath))
+	if pf == nil || pf.Licenses == nil {
+		return sbom.DefaultPolicy
+	}
+	p := sbom.Policy{Allow: pf.Licenses.Allow, Deny: pf.Licenses.Deny}
+	if len(p.Deny) == 0 {
+		p.Deny = sbom.DefaultPolicy.Deny
+	}
+	return p
+}
@@ -0,0 +1,94 @@
+package discover
+
+import (
+	"encoding/json"
+	"os/exec"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/secrets"
+	"github.com/michaelmonetized/mission-control/pkg/vercel"
+)
+
+// ProviderSecrets returns the secrets a project's providers hold and when
+// each last changed: GitHub Actions secrets (from the API, or via gh) and,
+// for Vercel projects with a token configured, encrypted environment
+// variables. A provider that can't be read is skipped; the error is only
+// returned when none could be.
+func ProviderSecrets(projectPath string) ([]secrets.Secret, error) {
+	return fixture.Do("github", "secrets "+projectPath, func() ([]secrets.Secret, error) {
+		return providerSecrets(projectPath)
+	})
+}
+
+func providerSecrets(projectPath string) ([]secrets.Secret, error) {
+	expandedPath := expandPath(projectPath)
+
+	found, ghErr := actionsSecrets(expandedPath)
+	vercel, vercelErr := vercelSecrets(expandedPath)
+	found = append(found, vercel...)
+	if ghErr != nil && vercelErr != nil && len(found) == 0 {
+		return nil, ghErr
+	}
+	return found, nil
+}
+
+// actionsSecrets lists a project's GitHub Actions secrets
+func actionsSecrets(expandedPath string) ([]secrets.Secret, error) {
+	if client, repo, ok := githubRepo(expandedPath); ok {
+		if native, err := client.ActionsSecrets(repo); err == nil {
+			found := make([]secrets.Secret, len(native))
+			for i, s := range native {
+				found[i] = secrets.Secret{Name: s.Name, Source: secrets.SourceGitHub, Rotated: s.UpdatedAt}
+			}
+			return found, nil
+		}
+	}
+
+	repo, ok := remoteRepo(expandedPath)
+	if !ok {
+		return nil, errNotGitHub
+	}
+	output, err := exec.Command("gh", "secret", "list", "-R", repo.String(), "--json", "name,updatedAt").Output()
+	if err != nil {
+		return nil, err
+	}
+	var listed []struct {
+		Name      string    `json:"name"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil {
+		return nil, err
+	}
+	found := make([]secrets.Secret, len(listed))
+	for i, s := range listed {
+		found[i] = secrets.Secret{Name: s.Name, Source: secrets.SourceGitHub, Rotated: s.UpdatedAt}
+	}
+	return found, nil
+}
+
+// vercelSecrets lists a linked Vercel project's encrypted and sensitive
+// environment variables. The vercel CLI doesn't report when a variable
+// changed, so this needs a token.
+func vercelSecrets(expandedPath string) ([]secrets.Secret, error) {
+	link, err := vercel.ReadLink(expandedPath)
+	if err != nil {
+		return nil, err
+	}
+	client := vercel.Default()
+	if client == nil {
+		return nil, vercel.ErrNoToken
+	}
+	envs, err := client.EnvVars(link)
+	if err != nil {
+		return nil, err
+	}
+	var found []secrets.Secret
+	for _, e := range envs {
+		if e.Type == "plain" || e.Type == "system" {
+			continue
+		}
+		found = append(found, secrets.Secret{Name: e.Key, Source: secrets.SourceVercel, Rotated: time.UnixMilli(e.UpdatedAt)})
+	}
+	return found, nil
+}
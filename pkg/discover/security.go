@@ -0,0 +1,45 @@
+package discover
+
+import (
+	"encoding/json"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// SecurityAlerts returns a project's open Dependabot, code scanning, and
+// secret scanning alert counts from the API, or via gh when no token is
+// available
+func SecurityAlerts(projectPath string) (github.SecurityAlerts, error) {
+	return fixture.Do("github", "security "+projectPath, func() (github.SecurityAlerts, error) {
+		return securityAlerts(projectPath)
+	})
+}
+
+func securityAlerts(projectPath string) (github.SecurityAlerts, error) {
+	expandedPath := expandPath(projectPath)
+	if client, repo, ok := githubRepo(expandedPath); ok {
+		if alerts, err := client.SecurityAlerts(repo); err == nil {
+			return alerts, nil
+		}
+	}
+
+	var alerts github.SecurityAlerts
+	get := ghGet(expandedPath)
+	dependabot, err := countAlertsDirect(get, "dependabot")
+	if err != nil {
+		return alerts, err
+	}
+	alerts.Dependabot = dependabot
+	// Scanning that isn't enabled answers with an error; count it as none
+	alerts.CodeScanning, _ = countAlertsDirect(get, "code-scanning")
+	alerts.SecretScanning, _ = countAlertsDirect(get, "secret-scanning")
+	return alerts, nil
+}
+
+// countAlertsDirect counts open alerts of a kind with gh
+func countAlertsDirect(get github.GetFunc, kind string) (int, error) {
+	var alerts []json.RawMessage
+	err := get(kind+"/alerts?state=open&per_page=100", &alerts)
+	return len(alerts), err
+}
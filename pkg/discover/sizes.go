@@ -0,0 +1,271 @@
+package discover
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// sizeSamples caps how many builds of history sizes.json keeps
+const sizeSamples = 200
+
+// ArtifactSize is a built artifact's size, and its size in earlier
+// builds
+type ArtifactSize struct {
+	Name    string
+	Path    string // relative to the project
+	Size    int64
+	Built   time.Time // when its newest file was written
+	Budget  int64     // bytes, from .mc.toml; 0 for none
+	History []int64   // its size in each recorded build, oldest first, ending with Size
+}
+
+// Previous is the artifact's size in the build before, or 0 when this is
+// the first one recorded
+func (a ArtifactSize) Previous() int64 {
+	if len(a.History) < 2 {
+		return 0
+	}
+	return a.History[len(a.History)-2]
+}
+
+// OverBudget reports whether the artifact is bigger than its budget
+func (a ArtifactSize) OverBudget() bool {
+	return a.Budget > 0 && a.Size > a.Budget
+}
+
+// SizeReport is a project's artifacts, and which went over budget in a
+// build recorded just now
+type SizeReport struct {
+	Artifacts []ArtifactSize
+	Crossed   []string
+}
+
+// sizeSample is one recorded build's sizes, by artifact name
+type sizeSample struct {
+	At     time.Time            `json:"at"`
+	Commit string               `json:"commit,omitempty"`
+	Sizes  map[string]int64     `json:"sizes"`
+	Built  map[string]time.Time `json:"built"`
+}
+
+func sizesFile(projectPath string) string {
+	return filepath.Join(ProjectCacheDir(projectPath), "sizes.json")
+}
+
+// ArtifactSizes measures a project's built artifacts: Go binaries left in
+// the project root or bin/, the JS bundle in .next/static, dist, build,
+// or out, and any [[artifact]] in .mc.toml. Whenever one was rebuilt
+// since it was last measured, the sizes are recorded in the project's
+// .hustlemc/sizes.json, building up their history. It returns nil for a
+// project with nothing built.
+func ArtifactSizes(projectPath string) (*SizeReport, error) {
+	return fixture.Do("tools", "sizes "+projectPath, func() (*SizeReport, error) {
+		return artifactSizes(projectPath)
+	})
+}
+
+func artifactSizes(projectPath string) (*SizeReport, error) {
+	root := expandPath(projectPath)
+	found := append(goBinaries(root), jsBundle(root)...)
+
+	pf, err := config.LoadProjectFile(root)
+	if pf != nil {
+		for _, want := range pf.Artifacts {
+			i := 0
+			for i < len(found) && found[i].Name != want.Name {
+				i++
+			}
+			if want.Path != "" {
+				a, ok := measureArtifact(root, want.Name, want.Path)
+				if !ok {
+					continue
+				}
+				if i == len(found) {
+					found = append(found, a)
+				}
+				found[i] = a
+			}
+			if i < len(found) {
+				found[i].Budget = want.Budget
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil, err
+	}
+
+	var samples []sizeSample
+	if data, err := os.ReadFile(sizesFile(projectPath)); err == nil {
+		json.Unmarshal(data, &samples)
+	}
+	var last sizeSample
+	if len(samples) > 0 {
+		last = samples[len(samples)-1]
+	}
+
+	report := &SizeReport{}
+	rebuilt := false
+	current := sizeSample{At: time.Now(), Sizes: make(map[string]int64), Built: make(map[string]time.Time)}
+	for _, a := range found {
+		current.Sizes[a.Name] = a.Size
+		current.Built[a.Name] = a.Built
+		if !last.Built[a.Name].Equal(a.Built) || last.Sizes[a.Name] != a.Size {
+			rebuilt = true
+			if prev, ok := last.Sizes[a.Name]; a.OverBudget() && (!ok || prev <= a.Budget) {
+				report.Crossed = append(report.Crossed, a.Name)
+			}
+		}
+	}
+	if rebuilt {
+		if out, err := exec.Command("git", "-C", root, "rev-parse", "--short", "HEAD").Output(); err == nil {
+			current.Commit = strings.TrimSpace(string(out))
+		}
+		samples = append(samples, current)
+		if len(samples) > sizeSamples {
+			samples = samples[len(samples)-sizeSamples:]
+		}
+		if data, err := json.MarshalIndent(samples, "", "  "); err == nil {
+			if os.MkdirAll(ProjectCacheDir(projectPath), 0755) == nil {
+				os.WriteFile(sizesFile(projectPath), data, 0644)
+			}
+		}
+	}
+
+	for _, a := range found {
+		for _, s := range samples {
+			if size, ok := s.Sizes[a.Name]; ok {
+				a.History = append(a.History, size)
+			}
+		}
+		report.Artifacts = append(report.Artifacts, a)
+	}
+	return report, err
+}
+
+// measureArtifact sizes the file, directory, or glob at rel
+func measureArtifact(root, name, rel string) (ArtifactSize, bool) {
+	matches, _ := filepath.Glob(filepath.Join(root, filepath.FromSlash(rel)))
+	a := ArtifactSize{Name: name, Path: rel}
+	for _, m := range matches {
+		filepath.WalkDir(m, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				a.Size += info.Size()
+				if info.ModTime().After(a.Built) {
+					a.Built = info.ModTime()
+				}
+			}
+			return nil
+		})
+	}
+	return a, len(matches) > 0 && a.Size > 0
+}
+
+// executableMagic are the first bytes of ELF, Mach-O, and PE executables
+var executableMagic = [][]byte{
+	{0x7f, 'E', 'L', 'F'},
+	{0xcf, 0xfa, 0xed, 0xfe}, {0xce, 0xfa, 0xed, 0xfe}, {0xca, 0xfe, 0xba, 0xbe},
+	{'M', 'Z'},
+}
+
+// isExecutable reports whether path is a compiled binary
+func isExecutable(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	head := make([]byte, 4)
+	n, _ := f.Read(head)
+	for _, magic := range executableMagic {
+		if bytes.HasPrefix(head[:n], magic) {
+			return true
+		}
+	}
+	return false
+}
+
+// goBinaries finds a Go module's built binaries: named after the module
+// or a cmd/ directory, in the project root or bin/
+func goBinaries(root string) []ArtifactSize {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if mod, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			names = append(names, filepath.Base(strings.Trim(strings.TrimSpace(mod), `"`)))
+			break
+		}
+	}
+	if entries, err := os.ReadDir(filepath.Join(root, "cmd")); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				names = append(names, e.Name())
+			}
+		}
+	}
+
+	var found []ArtifactSize
+	seen := make(map[string]bool)
+	for _, name := range names {
+		for _, rel := range []string{name, "bin/" + name} {
+			if seen[name] || !isExecutable(filepath.Join(root, rel)) {
+				continue
+			}
+			if a, ok := measureArtifact(root, name, rel); ok {
+				seen[name] = true
+				found = append(found, a)
+			}
+		}
+	}
+	return found
+}
+
+// jsBundle sums the JavaScript and CSS a web project's build wrote to
+// the first of .next/static, dist, build, or out, as "bundle"
+func jsBundle(root string) []ArtifactSize {
+	if _, err := os.Stat(filepath.Join(root, "package.json")); err != nil {
+		return nil
+	}
+	for _, rel := range []string{".next/static", "dist", "build", "out"} {
+		dir := filepath.Join(root, filepath.FromSlash(rel))
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		a := ArtifactSize{Name: "bundle", Path: rel}
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			switch filepath.Ext(path) {
+			case ".js", ".mjs", ".cjs", ".css":
+			default:
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				a.Size += info.Size()
+				if info.ModTime().After(a.Built) {
+					a.Built = info.ModTime()
+				}
+			}
+			return nil
+		})
+		if a.Size > 0 {
+			return []ArtifactSize{a}
+		}
+	}
+	return nil
+}
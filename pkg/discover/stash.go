@@ -0,0 +1,74 @@
+package discover
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Stash is one entry of `git stash list`
+type Stash struct {
+	Index   int    // N in stash@{N}
+	Message string // e.g. "WIP on main: 1a2b3c4 subject"
+	When    time.Time
+}
+
+// Ref returns the stash's revision name, e.g. "stash@{0}"
+func (s Stash) Ref() string {
+	return "stash@{" + strconv.Itoa(s.Index) + "}"
+}
+
+// ListStashes returns a project's stashes, newest first
+func ListStashes(projectPath string) ([]Stash, error) {
+	return fixture.Do("git", "stashes "+projectPath, func() ([]Stash, error) {
+		return listStashes(projectPath)
+	})
+}
+
+func listStashes(projectPath string) ([]Stash, error) {
+	expandedPath := expandPath(projectPath)
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return listStashesDirect(expandedPath)
+	}
+	defer repo.Close()
+
+	entries, err := repo.Stashes()
+	if err != nil {
+		return listStashesDirect(expandedPath)
+	}
+
+	stashes := make([]Stash, 0, len(entries))
+	for _, e := range entries {
+		stashes = append(stashes, Stash{Index: e.Index, Message: e.Message, When: e.When})
+	}
+	return stashes, nil
+}
+
+// listStashesDirect is a fallback using git stash list
+func listStashesDirect(expandedPath string) ([]Stash, error) {
+	cmd := exec.Command("git", "-C", expandedPath, "stash", "list", "--format=%ct%x09%gs")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var stashes []Stash
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		ts, msg, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		s := Stash{Index: len(stashes), Message: msg}
+		if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			s.When = time.Unix(sec, 0)
+		}
+		stashes = append(stashes, s)
+	}
+	return stashes, nil
+}
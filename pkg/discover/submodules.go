@@ -0,0 +1,115 @@
+package discover
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Submodule is one of a project's submodules
+type Submodule struct {
+	Path        string
+	Initialized bool
+	OutOfSync   bool // checkout differs from the commit the project records
+	Dirty       bool // uncommitted changes inside the submodule
+}
+
+// ListSubmodules returns a project's submodules, sorted by path
+func ListSubmodules(projectPath string) ([]Submodule, error) {
+	return fixture.Do("git", "submodules "+projectPath, func() ([]Submodule, error) {
+		return listSubmodules(projectPath)
+	})
+}
+
+func listSubmodules(projectPath string) ([]Submodule, error) {
+	expandedPath := expandPath(projectPath)
+	if _, err := os.Stat(filepath.Join(expandedPath, ".gitmodules")); err != nil {
+		return nil, nil
+	}
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return listSubmodulesDirect(expandedPath)
+	}
+	defer repo.Close()
+
+	subs, err := submodulesNative(repo)
+	if err != nil {
+		return listSubmodulesDirect(expandedPath)
+	}
+	return subs, nil
+}
+
+func submodulesNative(repo *gitrepo.Repo) ([]Submodule, error) {
+	entries, err := repo.Submodules()
+	if err != nil {
+		return nil, err
+	}
+	subs := make([]Submodule, 0, len(entries))
+	for _, e := range entries {
+		subs = append(subs, Submodule{
+			Path:        e.Path,
+			Initialized: e.Initialized,
+			OutOfSync:   e.OutOfSync(),
+			Dirty:       e.Dirty,
+		})
+	}
+	return subs, nil
+}
+
+// listSubmodulesDirect is a fallback using git submodule status for the
+// checkout state and porcelain v2 status for dirtiness
+func listSubmodulesDirect(expandedPath string) ([]Submodule, error) {
+	output, err := exec.Command("git", "-C", expandedPath, "submodule", "status").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	// Porcelain v2 marks submodules with "S<c><m><u>": m for tracked
+	// changes, u for untracked files
+	dirty := make(map[string]bool)
+	if out, err := exec.Command("git", "-C", expandedPath, "status", "--porcelain=v2").Output(); err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 9 || fields[0] != "1" || !strings.HasPrefix(fields[2], "S") || len(fields[2]) != 4 {
+				continue
+			}
+			if fields[2][2] == 'M' || fields[2][3] == 'U' {
+				dirty[fields[8]] = true
+			}
+		}
+	}
+
+	var subs []Submodule
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		// "<flag><sha> <path> (<describe>)"
+		fields := strings.Fields(line[1:])
+		if len(fields) < 2 {
+			continue
+		}
+		s := Submodule{Path: fields[1], Initialized: line[0] != '-', OutOfSync: line[0] == '+'}
+		s.Dirty = dirty[s.Path]
+		subs = append(subs, s)
+	}
+	return subs, nil
+}
+
+// countSubmodules returns how many submodules are dirty and out of sync
+func countSubmodules(subs []Submodule) (dirty, outOfSync int) {
+	for _, s := range subs {
+		if s.Dirty {
+			dirty++
+		}
+		if s.OutOfSync || !s.Initialized {
+			outOfSync++
+		}
+	}
+	return dirty, outOfSync
+}
@@ -0,0 +1,240 @@
+package discover
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// ToolRequirement is a runtime version a project pins, and what is
+// installed in its place
+type ToolRequirement struct {
+	Tool   string // node, go, rust, python, ruby, or an asdf plugin name
+	Want   string // as written, e.g. "20", "1.22", "stable"
+	Source string // file it was read from
+	Have   string // installed version; "" when the tool isn't installed
+}
+
+// Missing reports whether the tool isn't installed at all
+func (r ToolRequirement) Missing() bool {
+	return r.Have == ""
+}
+
+// Mismatched reports whether the installed version isn't the one asked for
+func (r ToolRequirement) Mismatched() bool {
+	return !r.Missing() && !versionSatisfies(r.Tool, r.Have, r.Want)
+}
+
+// OK reports whether the requirement is met
+func (r ToolRequirement) OK() bool {
+	return !r.Missing() && !r.Mismatched()
+}
+
+// versionFiles are the single-version pin files, by tool
+var versionFiles = []struct {
+	file string
+	tool string
+}{
+	{".nvmrc", "node"},
+	{".node-version", "node"},
+	{".python-version", "python"},
+	{".ruby-version", "ruby"},
+	{"rust-toolchain", "rust"},
+}
+
+// toolAliases maps asdf/mise plugin names to the tool they install
+var toolAliases = map[string]string{
+	"nodejs": "node",
+	"golang": "go",
+}
+
+// toolVersionCmds is how each tool reports its version, when it isn't
+// `<tool> --version`
+var toolVersionCmds = map[string][]string{
+	"go":     {"go", "env", "GOVERSION"},
+	"rust":   {"rustc", "--version"},
+	"python": {"python3", "--version"},
+}
+
+// CheckToolchain reads the runtime versions a project pins (.nvmrc,
+// go.mod, .tool-versions, rust-toolchain, ...) and compares each against
+// what is installed when run from the project
+func CheckToolchain(projectPath string) ([]ToolRequirement, error) {
+	return fixture.Do("tools", "toolchain "+projectPath, func() ([]ToolRequirement, error) {
+		return checkToolchain(projectPath), nil
+	})
+}
+
+func checkToolchain(projectPath string) []ToolRequirement {
+	dir := expandPath(projectPath)
+	reqs := pinnedVersions(dir)
+	for i := range reqs {
+		reqs[i].Have = installedVersion(dir, reqs[i].Tool)
+	}
+	return reqs
+}
+
+// pinnedVersions lists the versions a project pins, one per tool. The
+// dedicated files win over .tool-versions, as they are more specific.
+func pinnedVersions(dir string) []ToolRequirement {
+	var reqs []ToolRequirement
+	seen := make(map[string]bool)
+	add := func(tool, want, source string) {
+		if want == "" || seen[tool] {
+			return
+		}
+		seen[tool] = true
+		reqs = append(reqs, ToolRequirement{Tool: tool, Want: want, Source: source})
+	}
+
+	for _, vf := range versionFiles {
+		data, err := os.ReadFile(filepath.Join(dir, vf.file))
+		if err != nil {
+			continue
+		}
+		add(vf.tool, firstWord(string(data)), vf.file)
+	}
+	if want := rustToolchainTOML(filepath.Join(dir, "rust-toolchain.toml")); want != "" {
+		add("rust", want, "rust-toolchain.toml")
+	}
+	if want := goDirective(filepath.Join(dir, "go.mod")); want != "" {
+		add("go", want, "go.mod")
+	}
+
+	if f, err := os.Open(filepath.Join(dir, ".tool-versions")); err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line, _, _ := strings.Cut(scanner.Text(), "#")
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			tool := fields[0]
+			if alias, ok := toolAliases[tool]; ok {
+				tool = alias
+			}
+			add(tool, fields[1], ".tool-versions")
+		}
+	}
+	return reqs
+}
+
+// firstWord returns the first non-comment token of a pin file
+func firstWord(data string) string {
+	for _, line := range strings.Split(data, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		if fields := strings.Fields(line); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// goDirective returns the version in go.mod's go line
+func goDirective(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "go" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+var channelPattern = regexp.MustCompile(`(?m)^\s*channel\s*=\s*"([^"]+)"`)
+
+// rustToolchainTOML returns the channel from rust-toolchain.toml
+func rustToolchainTOML(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	if m := channelPattern.FindSubmatch(data); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// installedVersion asks the tool for its version from the project's
+// directory, so version-manager shims answer for that project. A shim
+// that errors (the pinned version isn't installed) counts as missing.
+func installedVersion(dir, tool string) string {
+	args, ok := toolVersionCmds[tool]
+	if !ok {
+		args = []string{tool, "--version"}
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return ""
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return versionPattern.FindString(string(output))
+}
+
+// versionSatisfies reports whether have meets want. Numeric pins match as
+// a prefix ("20" accepts 20.11.1), except go.mod's go line, which is a
+// minimum. Channels and aliases (stable, lts/*, latest) accept any version.
+func versionSatisfies(tool, have, want string) bool {
+	want = strings.TrimPrefix(strings.TrimPrefix(want, "go"), "v")
+	if !versionPattern.MatchString(want) && !isNumber(want) {
+		return true
+	}
+	// rust-toolchain pins like 1.75.0-x86_64-unknown-linux-gnu
+	want, _, _ = strings.Cut(want, "-")
+
+	h, w := versionParts(have), versionParts(want)
+	if tool == "go" {
+		for i := range w {
+			if i >= len(h) {
+				return false
+			}
+			if h[i] != w[i] {
+				return h[i] > w[i]
+			}
+		}
+		return true
+	}
+	if len(h) < len(w) {
+		return false
+	}
+	for i := range w {
+		if h[i] != w[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func versionParts(v string) []int {
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
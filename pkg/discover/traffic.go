@@ -0,0 +1,234 @@
+package discover
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+)
+
+// TrafficRefreshInterval is how often GitHub traffic data is re-pulled.
+// GitHub only retains 14 days, so weekly pulls keep the history gap-free.
+const TrafficRefreshInterval = 7 * 24 * time.Hour
+
+// trafficHistoryDays caps how much accumulated history we keep
+const trafficHistoryDays = 365
+
+// TrafficDay holds one day of GitHub traffic counts
+type TrafficDay struct {
+	Date         time.Time `json:"date"`
+	Views        int       `json:"views"`
+	UniqueViews  int       `json:"unique_views"`
+	Clones       int       `json:"clones"`
+	UniqueClones int       `json:"unique_clones"`
+}
+
+// Referrer is a top referring site from the traffic API
+type Referrer struct {
+	Source  string `json:"source"`
+	Count   int    `json:"count"`
+	Uniques int    `json:"uniques"`
+}
+
+// TrafficStats holds accumulated traffic history for a repo
+type TrafficStats struct {
+	FetchedAt time.Time    `json:"fetched_at"`
+	Days      []TrafficDay `json:"days"` // oldest first
+	Referrers []Referrer   `json:"referrers,omitempty"`
+}
+
+// Totals sums views and clones over the last n days
+func (t *TrafficStats) Totals(days int) (views, clones int) {
+	cutoff := time.Now().AddDate(0, 0, -days)
+	for _, d := range t.Days {
+		if d.Date.Before(cutoff) {
+			continue
+		}
+		views += d.Views
+		clones += d.Clones
+	}
+	return views, clones
+}
+
+// Series returns daily views and clones for the last n days (oldest first),
+// with zeros for days GitHub reported no traffic.
+func (t *TrafficStats) Series(days int) (views, clones []int) {
+	byDate := make(map[string]TrafficDay, len(t.Days))
+	for _, d := range t.Days {
+		byDate[d.Date.UTC().Format("2006-01-02")] = d
+	}
+
+	views = make([]int, days)
+	clones = make([]int, days)
+	today := time.Now().UTC()
+	for i := 0; i < days; i++ {
+		key := today.AddDate(0, 0, i-days+1).Format("2006-01-02")
+		views[i] = byDate[key].Views
+		clones[i] = byDate[key].Clones
+	}
+	return views, clones
+}
+
+// trafficFile returns the path of the per-project traffic history.
+// Kept out of status.json because that cache expires and is rebuilt.
+func trafficFile(projectPath string) string {
+	return filepath.Join(ProjectCacheDir(projectPath), "traffic.json")
+}
+
+// LoadTrafficStats reads stored traffic history without fetching
+func LoadTrafficStats(projectPath string) (*TrafficStats, error) {
+	data, err := os.ReadFile(trafficFile(projectPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var stats TrafficStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetTrafficStats returns traffic history for a project, pulling fresh
+// data from the GitHub traffic API when the stored copy is a week old.
+// Returns nil when the repo has no traffic data (not on GitHub, or no
+// push access - the traffic API requires it).
+func GetTrafficStats(projectPath string) (*TrafficStats, error) {
+	return fixture.Do("github", "traffic "+projectPath, func() (*TrafficStats, error) {
+		return getTrafficStats(projectPath)
+	})
+}
+
+func getTrafficStats(projectPath string) (*TrafficStats, error) {
+	stored, _ := LoadTrafficStats(projectPath)
+	if stored != nil && time.Since(stored.FetchedAt) < TrafficRefreshInterval {
+		return stored, nil
+	}
+
+	fresh, err := fetchTraffic(expandPath(projectPath))
+	if err != nil {
+		// Keep showing what we have if GitHub is unreachable
+		return stored, nil
+	}
+
+	merged := mergeTraffic(stored, fresh)
+	if err := saveTrafficStats(projectPath, merged); err != nil {
+		return merged, err
+	}
+	return merged, nil
+}
+
+func saveTrafficStats(projectPath string, stats *TrafficStats) error {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	if err := os.MkdirAll(ProjectCacheDir(projectPath), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(trafficFile(projectPath), data, 0644)
+}
+
+// mergeTraffic folds freshly fetched days into the stored history.
+// Fresh values win for overlapping days since GitHub revises recent counts.
+func mergeTraffic(stored, fresh *TrafficStats) *TrafficStats {
+	byDate := make(map[string]TrafficDay)
+	if stored != nil {
+		for _, d := range stored.Days {
+			byDate[d.Date.UTC().Format("2006-01-02")] = d
+		}
+	}
+	for _, d := range fresh.Days {
+		byDate[d.Date.UTC().Format("2006-01-02")] = d
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -trafficHistoryDays)
+	merged := &TrafficStats{
+		FetchedAt: fresh.FetchedAt,
+		Referrers: fresh.Referrers,
+	}
+	for _, d := range byDate {
+		if d.Date.After(cutoff) {
+			merged.Days = append(merged.Days, d)
+		}
+	}
+	sort.Slice(merged.Days, func(i, j int) bool {
+		return merged.Days[i].Date.Before(merged.Days[j].Date)
+	})
+
+	return merged
+}
+
+// fetchTraffic pulls views, clones, and referrers through the API or gh
+func fetchTraffic(expandedPath string) (*TrafficStats, error) {
+	get := repoGet(expandedPath)
+	type point struct {
+		Timestamp time.Time `json:"timestamp"`
+		Count     int       `json:"count"`
+		Uniques   int       `json:"uniques"`
+	}
+
+	var views struct {
+		Views []point `json:"views"`
+	}
+	if err := get("traffic/views", &views); err != nil {
+		return nil, err
+	}
+
+	var clones struct {
+		Clones []point `json:"clones"`
+	}
+	if err := get("traffic/clones", &clones); err != nil {
+		return nil, err
+	}
+
+	var referrers []struct {
+		Referrer string `json:"referrer"`
+		Count    int    `json:"count"`
+		Uniques  int    `json:"uniques"`
+	}
+	// Referrers are optional - an error here shouldn't drop the counts
+	_ = get("traffic/popular/referrers", &referrers)
+
+	byDate := make(map[string]*TrafficDay)
+	day := func(ts time.Time) *TrafficDay {
+		key := ts.UTC().Format("2006-01-02")
+		if d, ok := byDate[key]; ok {
+			return d
+		}
+		d := &TrafficDay{Date: ts.UTC().Truncate(24 * time.Hour)}
+		byDate[key] = d
+		return d
+	}
+	for _, v := range views.Views {
+		d := day(v.Timestamp)
+		d.Views = v.Count
+		d.UniqueViews = v.Uniques
+	}
+	for _, c := range clones.Clones {
+		d := day(c.Timestamp)
+		d.Clones = c.Count
+		d.UniqueClones = c.Uniques
+	}
+
+	stats := &TrafficStats{FetchedAt: time.Now()}
+	for _, d := range byDate {
+		stats.Days = append(stats.Days, *d)
+	}
+	for _, r := range referrers {
+		stats.Referrers = append(stats.Referrers, Referrer{
+			Source:  r.Referrer,
+			Count:   r.Count,
+			Uniques: r.Uniques,
+		})
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,107 @@
+package discover
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Worktree is one working tree of a project's repository
+type Worktree struct {
+	Path    string
+	Branch  string // "" when detached
+	Main    bool   // the repository's original working tree
+	Current bool   // the worktree the project itself points at
+	Missing bool   // registered but its directory is gone
+	Dirty   bool
+}
+
+// ListWorktrees returns every worktree of a project's repository, main
+// first
+func ListWorktrees(projectPath string) ([]Worktree, error) {
+	return fixture.Do("git", "worktrees "+projectPath, func() ([]Worktree, error) {
+		return listWorktrees(projectPath)
+	})
+}
+
+func listWorktrees(projectPath string) ([]Worktree, error) {
+	expandedPath := expandPath(projectPath)
+
+	repo, err := gitrepo.Open(expandedPath)
+	if err != nil {
+		return listWorktreesDirect(expandedPath)
+	}
+	defer repo.Close()
+
+	entries, err := repo.Worktrees()
+	if err != nil {
+		return listWorktreesDirect(expandedPath)
+	}
+
+	wts := make([]Worktree, 0, len(entries))
+	for _, e := range entries {
+		wts = append(wts, Worktree{
+			Path:    e.Path,
+			Branch:  e.Branch,
+			Main:    e.Main,
+			Current: sameDir(e.Path, expandedPath),
+			Missing: e.Missing,
+			Dirty:   e.Dirty,
+		})
+	}
+	return wts, nil
+}
+
+// listWorktreesDirect is a fallback using git worktree list
+func listWorktreesDirect(expandedPath string) ([]Worktree, error) {
+	output, err := exec.Command("git", "-C", expandedPath, "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var wts []Worktree
+	for i, block := range strings.Split(strings.TrimSpace(string(output)), "\n\n") {
+		var wt Worktree
+		bare := false
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				wt.Path = value
+			case "branch":
+				wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				bare = true
+			case "prunable":
+				wt.Missing = true
+			}
+		}
+		if wt.Path == "" || bare {
+			continue
+		}
+		wt.Main = i == 0 // git lists the main worktree first
+		wt.Current = sameDir(wt.Path, expandedPath)
+		if !wt.Missing {
+			if out, err := exec.Command("git", "-C", wt.Path, "status", "--porcelain").Output(); err == nil {
+				wt.Dirty = len(strings.TrimSpace(string(out))) > 0
+			}
+		}
+		wts = append(wts, wt)
+	}
+	return wts, nil
+}
+
+// sameDir reports whether two paths name the same directory, resolving
+// symlinks where possible
+func sameDir(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
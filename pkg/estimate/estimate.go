@@ -0,0 +1,79 @@
+// Package estimate compares effort estimates on milestones and issues
+// against the time actually tracked for them.
+package estimate
+
+import (
+	"math"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+)
+
+// Task is one estimated milestone or issue with its tracked time
+type Task struct {
+	Name     string
+	Estimate time.Duration
+	Actual   time.Duration
+	Done     bool
+}
+
+// Ratio returns actual / estimate (>1 means it ran over)
+func (t Task) Ratio() float64 {
+	if t.Estimate <= 0 {
+		return 0
+	}
+	return float64(t.Actual) / float64(t.Estimate)
+}
+
+// Summary is a project's estimates and how well they held up
+type Summary struct {
+	Tasks []Task
+
+	// Accuracy is the mean of min(est, actual) / max(est, actual) over
+	// finished tasks, from 0 to 1. Samples is how many tasks it covers.
+	Accuracy float64
+	Samples  int
+
+	// Bias is total actual / total estimate over finished tasks
+	Bias float64
+}
+
+// ForProject matches the project's estimates with tracked time, in the
+// order the estimates were recorded.
+func ForProject(cfg *config.Config, entries []timelog.Entry, project string) *Summary {
+	actual := make(map[string]time.Duration)
+	for _, e := range entries {
+		if e.Project == project && e.Task != "" {
+			actual[e.Task] += e.Duration()
+		}
+	}
+
+	s := &Summary{}
+	var totalEst, totalActual time.Duration
+	for _, est := range cfg.Project(project).Estimates {
+		t := Task{
+			Name:     est.Task,
+			Estimate: time.Duration(est.Hours * float64(time.Hour)),
+			Actual:   actual[est.Task],
+			Done:     est.Done,
+		}
+		s.Tasks = append(s.Tasks, t)
+
+		if !t.Done || t.Estimate <= 0 || t.Actual <= 0 {
+			continue
+		}
+		lo := math.Min(float64(t.Estimate), float64(t.Actual))
+		hi := math.Max(float64(t.Estimate), float64(t.Actual))
+		s.Accuracy += lo / hi
+		s.Samples++
+		totalEst += t.Estimate
+		totalActual += t.Actual
+	}
+
+	if s.Samples > 0 {
+		s.Accuracy /= float64(s.Samples)
+		s.Bias = float64(totalActual) / float64(totalEst)
+	}
+	return s
+}
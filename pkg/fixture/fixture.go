@@ -0,0 +1,182 @@
+// Package fixture records provider responses (git, GitHub, Vercel) and
+// replays them, so the UI can be developed against a captured portfolio
+// without network access or the projects themselves on disk.
+//
+//	MC_RECORD=~/mc-fixtures mc   # run normally, saving every response
+//	MC_REPLAY=~/mc-fixtures mc   # answer only from saved responses
+//
+// Responses are stored as one JSON file per provider, keyed by call and
+// project path. Replay never falls through to the real provider: a call
+// that wasn't recorded fails with ErrNotRecorded.
+package fixture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode is whether responses are being recorded or replayed
+type Mode int
+
+const (
+	Off Mode = iota
+	Record
+	Replay
+)
+
+// ErrNotRecorded is returned in replay mode for calls with no fixture
+var ErrNotRecorded = errors.New("no recorded response")
+
+// entry is one recorded response
+type entry struct {
+	Value json.RawMessage `json:"value"`
+	Error string          `json:"error,omitempty"`
+}
+
+var (
+	setupOnce sync.Once
+	mode      Mode
+	dir       string
+
+	mu      sync.Mutex
+	files   = make(map[string]map[string]entry) // provider -> key -> entry
+	saveErr error                               // the latest failure to save a recording
+)
+
+func setup() {
+	setupOnce.Do(func() {
+		if d := os.Getenv("MC_REPLAY"); d != "" {
+			mode, dir = Replay, expand(d)
+		} else if d := os.Getenv("MC_RECORD"); d != "" {
+			mode, dir = Record, expand(d)
+		}
+	})
+}
+
+func expand(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
+
+// Active returns the current mode and fixture directory
+func Active() (Mode, string) {
+	setup()
+	return mode, dir
+}
+
+// Replaying reports whether responses come from fixtures
+func Replaying() bool {
+	m, _ := Active()
+	return m == Replay
+}
+
+// Err returns the latest error saving a recorded response, or nil if
+// every one was saved. A failed save doesn't fail the call it records,
+// so the TUI shows this instead.
+func Err() error {
+	mu.Lock()
+	defer mu.Unlock()
+	return saveErr
+}
+
+// Do runs fn, the real provider call identified by provider and key.
+// When recording its result is saved; when replaying the saved result is
+// returned instead and fn is never called.
+func Do[T any](provider, key string, fn func() (T, error)) (T, error) {
+	switch m, _ := Active(); m {
+	case Replay:
+		return load[T](provider, key)
+	case Record:
+		v, err := fn()
+		if serr := save(provider, key, v, err); serr != nil {
+			mu.Lock()
+			saveErr = fmt.Errorf("recording %s %s: %w", provider, key, serr)
+			mu.Unlock()
+		}
+		return v, err
+	default:
+		return fn()
+	}
+}
+
+func load[T any](provider, key string) (T, error) {
+	var v T
+	mu.Lock()
+	entries, err := read(provider)
+	e, ok := entries[key]
+	mu.Unlock()
+	if err != nil {
+		return v, err
+	}
+	if !ok {
+		return v, fmt.Errorf("%s %s: %w", provider, key, ErrNotRecorded)
+	}
+	if err := json.Unmarshal(e.Value, &v); err != nil {
+		return v, fmt.Errorf("%s %s: %w", provider, key, err)
+	}
+	if e.Error != "" {
+		return v, errors.New(e.Error)
+	}
+	return v, nil
+}
+
+func save(provider, key string, v any, callErr error) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	e := entry{Value: data}
+	if callErr != nil {
+		e.Error = callErr.Error()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	entries, err := read(provider)
+	if err != nil {
+		return err
+	}
+	entries[key] = e
+
+	out, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, provider+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, out, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// read returns a provider's entries, loading them on first use. The
+// caller holds mu.
+func read(provider string) (map[string]entry, error) {
+	if entries, ok := files[provider]; ok {
+		return entries, nil
+	}
+	entries := make(map[string]entry)
+	data, err := os.ReadFile(filepath.Join(dir, provider+".json"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("%s fixtures: %w", provider, err)
+		}
+	}
+	files[provider] = entries
+	return entries, nil
+}
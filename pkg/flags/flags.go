@@ -0,0 +1,277 @@
+// Package flags reads a project's feature flags from the flag service
+// configured in config.json (LaunchDarkly, Unleash, or Flagsmith) and
+// picks out the stale ones, so flag debt shows up alongside code debt.
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// StaleAfter is how long a flag can go unchanged before it counts as
+// stale: by then it should be in the code for good, or gone
+const StaleAfter = 30 * 24 * time.Hour
+
+var httpClient = &http.Client{Timeout: 20 * time.Second}
+
+// Flag is one feature flag in the configured environment
+type Flag struct {
+	Key       string
+	Name      string
+	Enabled   bool
+	Temporary bool      // meant to be removed once rolled out
+	Changed   time.Time // last change, or creation when the service doesn't say
+	Stale     bool
+}
+
+// Report is a project's flags, stale ones first
+type Report struct {
+	Source config.FlagSource
+	Flags  []Flag
+}
+
+// Enabled counts the flags switched on
+func (r *Report) Enabled() int {
+	n := 0
+	for _, f := range r.Flags {
+		if f.Enabled {
+			n++
+		}
+	}
+	return n
+}
+
+// Stale counts the stale flags
+func (r *Report) Stale() int {
+	n := 0
+	for _, f := range r.Flags {
+		if f.Stale {
+			n++
+		}
+	}
+	return n
+}
+
+// ForProject reads the project's flags, or returns nil when it has no
+// flag service configured
+func ForProject(cfg *config.Config, project string) (*Report, error) {
+	src := cfg.Project(project).Flags
+	if src == nil {
+		return nil, nil
+	}
+	if src.Environment == "" && src.Kind != config.FlagsFlagsmith {
+		src.Environment = "production"
+	}
+
+	var flags []Flag
+	var err error
+	switch src.Kind {
+	case config.FlagsLaunchDarkly:
+		flags, err = fetchLaunchDarkly(cfg.Token("launchdarkly"), *src)
+	case config.FlagsUnleash:
+		flags, err = fetchUnleash(cfg.Token("unleash"), *src)
+	case config.FlagsFlagsmith:
+		flags, err = fetchFlagsmith(*src)
+	default:
+		err = fmt.Errorf("unknown flag source %q", src.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(flags, func(i, j int) bool {
+		if flags[i].Stale != flags[j].Stale {
+			return flags[i].Stale
+		}
+		return flags[i].Changed.Before(flags[j].Changed)
+	})
+	return &Report{Source: *src, Flags: flags}, nil
+}
+
+// unchanged reports whether t is more than StaleAfter ago
+func unchanged(t time.Time) bool {
+	return !t.IsZero() && time.Since(t) > StaleAfter
+}
+
+// getJSON fetches url into out, sending header (name, value) when name
+// isn't ""
+func getJSON(rawURL, name, value string, out any) error {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		req.Header.Set(name, value)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// fetchLaunchDarkly lists a project's flags in one environment. A flag
+// is stale when LaunchDarkly says it has been serving one variation to
+// everyone ("launched") or no traffic ("inactive"), or when a temporary
+// flag hasn't changed in StaleAfter.
+func fetchLaunchDarkly(token string, src config.FlagSource) ([]Flag, error) {
+	if token == "" {
+		return nil, fmt.Errorf("no launchdarkly token (set tokens.launchdarkly or MC_LAUNCHDARKLY_TOKEN)")
+	}
+	if src.Project == "" {
+		return nil, fmt.Errorf("launchdarkly needs flags.project set to a project key")
+	}
+	base := "https://app.launchdarkly.com/api/v2"
+	if src.URL != "" {
+		base = strings.TrimSuffix(src.URL, "/")
+	}
+
+	var list struct {
+		Items []struct {
+			Key          string `json:"key"`
+			Name         string `json:"name"`
+			Temporary    bool   `json:"temporary"`
+			Archived     bool   `json:"archived"`
+			CreationDate int64  `json:"creationDate"` // milliseconds
+			Environments map[string]struct {
+				On           bool  `json:"on"`
+				LastModified int64 `json:"lastModified"`
+			} `json:"environments"`
+		} `json:"items"`
+	}
+	q := url.Values{"env": {src.Environment}, "summary": {"0"}}
+	if err := getJSON(base+"/flags/"+url.PathEscape(src.Project)+"?"+q.Encode(), "Authorization", token, &list); err != nil {
+		return nil, err
+	}
+
+	// Statuses are a separate call; without them, age alone decides
+	status := make(map[string]string)
+	var statuses struct {
+		Items []struct {
+			Name  string `json:"name"` // new, active, inactive, launched
+			Links struct {
+				Parent struct {
+					Href string `json:"href"`
+				} `json:"parent"`
+			} `json:"_links"`
+		} `json:"items"`
+	}
+	if getJSON(base+"/flag-statuses/"+url.PathEscape(src.Project)+"/"+url.PathEscape(src.Environment), "Authorization", token, &statuses) == nil {
+		for _, s := range statuses.Items {
+			href := s.Links.Parent.Href
+			status[href[strings.LastIndex(href, "/")+1:]] = s.Name
+		}
+	}
+
+	var flags []Flag
+	for _, item := range list.Items {
+		if item.Archived {
+			continue
+		}
+		env := item.Environments[src.Environment]
+		changed := time.UnixMilli(env.LastModified)
+		if env.LastModified == 0 {
+			changed = time.UnixMilli(item.CreationDate)
+		}
+		f := Flag{Key: item.Key, Name: item.Name, Enabled: env.On, Temporary: item.Temporary, Changed: changed}
+		switch status[item.Key] {
+		case "launched", "inactive":
+			f.Stale = true
+		default:
+			f.Stale = f.Temporary && unchanged(changed)
+		}
+		flags = append(flags, f)
+	}
+	return flags, nil
+}
+
+// fetchUnleash lists a project's flags with their state in one
+// environment, taking Unleash's own stale and potentially-stale marks
+func fetchUnleash(token string, src config.FlagSource) ([]Flag, error) {
+	if token == "" {
+		return nil, fmt.Errorf("no unleash token (set tokens.unleash or MC_UNLEASH_TOKEN)")
+	}
+	if src.URL == "" {
+		return nil, fmt.Errorf("unleash needs flags.url set to the Unleash server")
+	}
+	project := src.Project
+	if project == "" {
+		project = "default"
+	}
+
+	var list struct {
+		Features []struct {
+			Name             string    `json:"name"`
+			Description      string    `json:"description"`
+			Type             string    `json:"type"` // release, experiment, operational, kill-switch, permission
+			Stale            bool      `json:"stale"`
+			PotentiallyStale bool      `json:"potentiallyStale"`
+			CreatedAt        time.Time `json:"createdAt"`
+			Environments     []struct {
+				Name    string `json:"name"`
+				Enabled bool   `json:"enabled"`
+			} `json:"environments"`
+		} `json:"features"`
+	}
+	api := strings.TrimSuffix(src.URL, "/") + "/api/admin/projects/" + url.PathEscape(project) + "/features"
+	if err := getJSON(api, "Authorization", token, &list); err != nil {
+		return nil, err
+	}
+
+	flags := make([]Flag, 0, len(list.Features))
+	for _, feature := range list.Features {
+		f := Flag{Key: feature.Name, Name: feature.Description, Changed: feature.CreatedAt,
+			Temporary: feature.Type == "release" || feature.Type == "experiment",
+			Stale:     feature.Stale || feature.PotentiallyStale}
+		for _, env := range feature.Environments {
+			if env.Name == src.Environment {
+				f.Enabled = env.Enabled
+			}
+		}
+		flags = append(flags, f)
+	}
+	return flags, nil
+}
+
+// fetchFlagsmith lists an environment's flags through its environment
+// key. Flagsmith doesn't say when a flag last changed, so a flag counts
+// as stale once it is older than StaleAfter.
+func fetchFlagsmith(src config.FlagSource) ([]Flag, error) {
+	if src.Environment == "" {
+		return nil, fmt.Errorf("flagsmith needs flags.environment set to an environment key")
+	}
+	base := "https://edge.api.flagsmith.com/api/v1"
+	if src.URL != "" {
+		base = strings.TrimSuffix(src.URL, "/")
+	}
+
+	var list []struct {
+		Enabled bool `json:"enabled"`
+		Feature struct {
+			Name        string    `json:"name"`
+			Description string    `json:"description"`
+			CreatedDate time.Time `json:"created_date"`
+		} `json:"feature"`
+	}
+	if err := getJSON(base+"/flags/", "X-Environment-Key", src.Environment, &list); err != nil {
+		return nil, err
+	}
+
+	flags := make([]Flag, 0, len(list))
+	for _, item := range list {
+		flags = append(flags, Flag{Key: item.Feature.Name, Name: item.Feature.Description, Enabled: item.Enabled,
+			Changed: item.Feature.CreatedDate, Stale: unchanged(item.Feature.CreatedDate)})
+	}
+	return flags, nil
+}
@@ -0,0 +1,213 @@
+// Package fly talks to the Fly.io APIs, so apps with a fly.toml show
+// their deployed release and machine health.
+package fly
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+const (
+	// GraphQLURL is the platform API, for apps and releases
+	GraphQLURL = "https://api.fly.io/graphql"
+	// MachinesURL is the Machines API
+	MachinesURL = "https://api.machines.dev/v1"
+)
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no fly token (set tokens.fly, MC_FLY_TOKEN, or FLY_API_TOKEN, or log in with fly)")
+
+// ErrNoApp means a fly.toml names no app
+var ErrNoApp = errors.New("fly.toml has no app name")
+
+// Client makes authenticated API requests: REST ones to the Machines API
+// (its BaseURL), GraphQL ones to GraphQLURL
+type Client struct {
+	*httpapi.Client
+	GraphQLURL string
+}
+
+// NewClient returns a client for the Fly.io APIs using token
+func NewClient(token string) *Client {
+	c := httpapi.New("fly", MachinesURL, token, ErrNoToken)
+	c.Authorize = func(req *http.Request, token string) {
+		// Macaroon tokens from fly tokens create carry their own "FlyV1"
+		// scheme
+		if !strings.HasPrefix(token, "FlyV1 ") {
+			token = "Bearer " + token
+		}
+		req.Header.Set("Authorization", token)
+	}
+	return &Client{Client: c, GraphQLURL: GraphQLURL}
+}
+
+// FindToken looks for a token, in order: tokens.fly (or MC_FLY_TOKEN),
+// FLY_API_TOKEN and FLY_ACCESS_TOKEN (which flyctl reads), then the one
+// flyctl saved at login. It returns "" when there is none.
+func FindToken(cfg *config.Config) string {
+	if cfg != nil {
+		if t := cfg.Token("fly"); t != "" {
+			return t
+		}
+	}
+	for _, env := range []string{"FLY_API_TOKEN", "FLY_ACCESS_TOKEN"} {
+		if t := os.Getenv(env); t != "" {
+			return t
+		}
+	}
+	return cliToken()
+}
+
+// cliToken reads access_token from flyctl's ~/.fly/config.yml
+func cliToken() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open(filepath.Join(home, ".fly", "config.yml"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(scanner.Text(), "access_token:"); ok {
+			return strings.Trim(strings.TrimSpace(v), `"'`)
+		}
+	}
+	return ""
+}
+
+// Default returns a client using the token FindToken finds, or nil when
+// there is none
+func Default() *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg); token != "" {
+		return NewClient(token)
+	}
+	return nil
+}
+
+// Query runs a GraphQL query against the platform API and decodes its
+// data into out
+func (c *Client) Query(query string, vars map[string]any, out any) error {
+	return c.GraphQL(c.GraphQLURL, query, vars, out)
+}
+
+// AppName reads the app a directory deploys to from its fly.toml
+func AppName(dir string) (string, error) {
+	f, err := os.Open(filepath.Join(dir, "fly.toml"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			// app is a top-level key, before any table
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == "app" {
+			if name := strings.Trim(strings.TrimSpace(value), `"'`); name != "" {
+				return name, nil
+			}
+		}
+	}
+	return "", ErrNoApp
+}
+
+// Release is one release of an app
+type Release struct {
+	ID          string    `json:"id"`
+	Version     int       `json:"version"`
+	Status      string    `json:"status"` // complete, failed, running, ...
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ImageRef    string    `json:"imageRef"` // the image it runs, which deploying again rolls back to
+	User        *struct {
+		Email string `json:"email"`
+	} `json:"user"`
+}
+
+// App is an app's state on the platform
+type App struct {
+	Name           string   `json:"name"`
+	Status         string   `json:"status"` // deployed, pending, suspended
+	Deployed       bool     `json:"deployed"`
+	Hostname       string   `json:"hostname"`
+	CurrentRelease *Release `json:"currentRelease"`
+}
+
+// App reads an app with its current release
+func (c *Client) App(name string) (App, error) {
+	var data struct {
+		App App `json:"app"`
+	}
+	err := c.Query(`query($name: String!) {
+		app(name: $name) {
+			name status deployed hostname
+			currentRelease { version status description createdAt }
+		}
+	}`, map[string]any{"name": name}, &data)
+	return data.App, err
+}
+
+// Releases lists an app's newest n releases, newest first
+func (c *Client) Releases(app string, n int) ([]Release, error) {
+	var data struct {
+		App struct {
+			Releases struct {
+				Nodes []Release `json:"nodes"`
+			} `json:"releases"`
+		} `json:"app"`
+	}
+	err := c.Query(`query($name: String!, $n: Int!) {
+		app(name: $name) {
+			releases(first: $n) { nodes { id version status description createdAt imageRef user { email } } }
+		}
+	}`, map[string]any{"name": app, "n": n}, &data)
+	return data.App.Releases.Nodes, err
+}
+
+// MonitoringURL is an app's dashboard page with its logs
+func MonitoringURL(app string) string {
+	return "https://fly.io/apps/" + url.PathEscape(app) + "/monitoring"
+}
+
+// Check is one of a machine's health checks
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // passing, warning, critical
+	Output string `json:"output"`
+}
+
+// Machine is one of an app's machines
+type Machine struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	State  string  `json:"state"` // started, stopped, suspended, created, ...
+	Region string  `json:"region"`
+	Checks []Check `json:"checks"`
+	Config struct {
+		Metadata map[string]string `json:"metadata"` // fly_release_version, fly_process_group, ...
+	} `json:"config"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Machines lists an app's machines
+func (c *Client) Machines(app string) ([]Machine, error) {
+	var machines []Machine
+	err := c.Get("apps/"+url.PathEscape(app)+"/machines", &machines)
+	return machines, err
+}
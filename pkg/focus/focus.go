@@ -0,0 +1,87 @@
+// Package focus follows the directory you're working in. tmux, editor,
+// and shell hooks report it to the daemon relay (apps/daemon) with
+// bin/mc-focus or `mc focus`; the TUI asks the daemon for the latest
+// report and selects the matching project.
+package focus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultDaemonURL is where the relay serves focus reports: its HTTP
+// port, one thousand above the WebSocket port
+const DefaultDaemonURL = "http://localhost:10999"
+
+// client is short on patience: hooks and the TUI's poll must not hang on
+// a daemon that isn't running
+var client = &http.Client{Timeout: time.Second}
+
+// Report is the latest focus change
+type Report struct {
+	Dir    string    `json:"dir"`
+	Source string    `json:"source,omitempty"` // tmux, nvim, shell, ...
+	At     time.Time `json:"at"`
+}
+
+// URL returns the daemon's focus endpoint; MC_DAEMON_URL overrides the
+// daemon's address
+func URL() string {
+	base := os.Getenv("MC_DAEMON_URL")
+	if base == "" {
+		base = DefaultDaemonURL
+	}
+	return strings.TrimSuffix(base, "/") + "/focus"
+}
+
+// Set reports to the daemon that focus moved to dir
+func Set(dir, source string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = real
+	}
+	body, err := json.Marshal(Report{Dir: abs, Source: source})
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(URL(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("daemon unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("daemon: %s", resp.Status)
+	}
+	return nil
+}
+
+// Get asks the daemon for the latest report, a zero Report if there's
+// none yet
+func Get() (Report, error) {
+	var r Report
+	resp, err := client.Get(URL())
+	if err != nil {
+		return r, fmt.Errorf("daemon unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return r, fmt.Errorf("daemon: %s", resp.Status)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	return r, err
+}
+
+// Within reports whether dir is root or somewhere under it
+func Within(dir, root string) bool {
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,188 @@
+package forge
+
+import (
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/azuredevops"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// azureDevOpsForge reads a repository in Azure Repos, Boards work items
+// standing in for issues and Pipelines builds for runs. Azure DevOps has
+// no releases or notifications API.
+type azureDevOpsForge struct {
+	client *azuredevops.Client
+	remote Remote
+	repo   azuredevops.Repo
+}
+
+func parseAzureDevOps(remote string, _ *config.Config) (Remote, bool) {
+	repo, ok := azuredevops.ParseRemote(remote)
+	if !ok {
+		return Remote{}, false
+	}
+	return Remote{Forge: AzureDevOps, Host: "dev.azure.com", Owner: repo.Org, Name: repo.Project + "/" + repo.Name,
+		URL: repo.WebURL(), native: repo}, true
+}
+
+func openAzureDevOps(r Remote, _ *config.Config) Forge {
+	client := azuredevops.Default(r.Owner)
+	if client == nil {
+		return nil
+	}
+	repo, _ := r.native.(azuredevops.Repo)
+	return &azureDevOpsForge{client: client, remote: r, repo: repo}
+}
+
+func (f *azureDevOpsForge) Remote() Remote { return f.remote }
+
+func (f *azureDevOpsForge) Counts() (Counts, error) {
+	c, err := f.client.Counts(f.repo)
+	return Counts{
+		Issues:          c.WorkItems,
+		PRs:             c.PRs,
+		Assigned:        c.Assigned,
+		ReviewRequested: c.ReviewRequested,
+	}, err
+}
+
+// Issues are the project's open work items, labelled with their type
+// (Bug, Task, User Story, ...) and tags
+func (f *azureDevOpsForge) Issues() ([]Issue, error) {
+	ids, err := f.client.WorkItemIDs(f.repo, false)
+	if err != nil {
+		return nil, err
+	}
+	native, err := f.client.WorkItems(f.repo, ids)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, len(native))
+	for i, w := range native {
+		issues[i] = Issue{Number: w.ID, Title: w.Fields.Title, URL: w.URL(f.repo), CreatedAt: w.Fields.CreatedAt}
+		issues[i].Author.Login = w.Fields.CreatedBy.DisplayName
+		if w.Fields.Type != "" {
+			issues[i].Labels = append(issues[i].Labels, Label{w.Fields.Type})
+		}
+		for _, tag := range strings.Split(w.Fields.Tags, ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				issues[i].Labels = append(issues[i].Labels, Label{tag})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// PullRequests take their review decision from reviewers' votes. Azure
+// DevOps doesn't report when a pull request was last updated, so
+// UpdatedAt is when it was opened, and branch policies aren't read, so
+// Checks is left empty.
+func (f *azureDevOpsForge) PullRequests() ([]PullRequest, error) {
+	native, err := f.client.PullRequests(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(native))
+	for i, pr := range native {
+		prs[i] = PullRequest{Number: pr.ID, Title: pr.Title, URL: pr.URL(f.repo), IsDraft: pr.IsDraft, HeadRef: strings.TrimPrefix(pr.SourceRef, "refs/heads/"), UpdatedAt: pr.CreatedAt,
+			ReviewDecision: reviewDecision(pr.Reviewers)}
+		prs[i].Author.Login = pr.CreatedBy.DisplayName
+		switch pr.MergeStatus {
+		case "succeeded":
+			prs[i].Mergeable = "MERGEABLE"
+		case "conflicts":
+			prs[i].Mergeable = "CONFLICTING"
+		default:
+			prs[i].Mergeable = "UNKNOWN"
+		}
+	}
+	return prs, nil
+}
+
+// reviewDecision reads GitHub's review decision from Azure DevOps votes:
+// any rejection or "waiting for author" asks for changes, a required
+// reviewer yet to approve leaves review required, and otherwise any
+// approval approves
+func reviewDecision(reviewers []azuredevops.Reviewer) string {
+	decision := ""
+	for _, rv := range reviewers {
+		switch {
+		case rv.Vote < 0:
+			return "CHANGES_REQUESTED"
+		case rv.IsRequired && rv.Vote == 0:
+			decision = "REVIEW_REQUIRED"
+		case rv.Vote > 0 && decision == "":
+			decision = "APPROVED"
+		}
+	}
+	return decision
+}
+
+func (f *azureDevOpsForge) Runs(branch string, n int) ([]Run, error) {
+	builds, err := f.client.Builds(f.repo, branch, n)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]Run, len(builds))
+	for i, b := range builds {
+		runs[i] = Run{ID: b.ID, Name: b.Definition.Name, Branch: strings.TrimPrefix(b.SourceRef, "refs/heads/"),
+			URL: b.Links.Web.Href, CreatedAt: b.QueuedAt, Status: "in_progress"}
+		switch b.Status {
+		case "notStarted", "postponed":
+			runs[i].Status = "queued"
+			continue
+		case "completed":
+		default:
+			continue
+		}
+		runs[i].Status = "completed"
+		switch b.Result {
+		case "succeeded":
+			runs[i].Conclusion = "success"
+		case "failed", "partiallySucceeded":
+			// Partially succeeded means a step marked continueOnError
+			// failed, usually the tests
+			runs[i].Conclusion = "failure"
+		case "canceled":
+			runs[i].Conclusion = "cancelled"
+		default:
+			runs[i].Conclusion = "neutral"
+		}
+	}
+	return runs, nil
+}
+
+func (f *azureDevOpsForge) Releases(int) ([]Release, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *azureDevOpsForge) Notifications() ([]Notification, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *azureDevOpsForge) MarkRead(Notification) error {
+	return ErrUnsupported
+}
+
+// Merge completes the pull request
+func (f *azureDevOpsForge) Merge(number int, method string) error {
+	strategy, ok := azuredevops.MergeStrategies[method]
+	if !ok {
+		return ErrUnsupported
+	}
+	return f.client.CompletePull(f.repo, number, strategy)
+}
+
+// Rerun retries a build's failed jobs, or queues a new build of the same
+// pipeline and branch
+func (f *azureDevOpsForge) Rerun(run Run, failedOnly bool) error {
+	if failedOnly {
+		return f.client.RetryBuild(f.repo, run.ID)
+	}
+	return f.client.QueueBuild(f.repo, run.ID)
+}
+
+// CreateIssue files a Task work item
+func (f *azureDevOpsForge) CreateIssue(title, body string) (string, error) {
+	return f.client.CreateWorkItem(f.repo, title, body)
+}
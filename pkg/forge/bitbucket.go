@@ -0,0 +1,141 @@
+package forge
+
+import (
+	"github.com/michaelmonetized/mission-control/pkg/bitbucket"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// bitbucketForge reads a Bitbucket Cloud repository, Pipelines standing
+// in for runs. Bitbucket has no releases or notifications API.
+type bitbucketForge struct {
+	client *bitbucket.Client
+	remote Remote
+	repo   bitbucket.Repo
+}
+
+func parseBitbucket(remote string, _ *config.Config) (Remote, bool) {
+	repo, ok := bitbucket.ParseRemote(remote)
+	if !ok {
+		return Remote{}, false
+	}
+	return Remote{Forge: Bitbucket, Host: "bitbucket.org", Owner: repo.Workspace, Name: repo.Slug, URL: repo.WebURL(), native: repo}, true
+}
+
+func openBitbucket(r Remote, _ *config.Config) Forge {
+	client := bitbucket.Default(r.Owner)
+	if client == nil {
+		return nil
+	}
+	repo, _ := r.native.(bitbucket.Repo)
+	return &bitbucketForge{client: client, remote: r, repo: repo}
+}
+
+func (f *bitbucketForge) Remote() Remote { return f.remote }
+
+func (f *bitbucketForge) Counts() (Counts, error) {
+	c, err := f.client.Counts(f.repo)
+	return Counts{
+		Issues:          c.Issues,
+		PRs:             c.PRs,
+		Assigned:        c.Assigned,
+		ReviewRequested: c.ReviewRequested,
+		Watchers:        c.Watchers,
+	}, err
+}
+
+// Issues are labelled with their kind and priority
+func (f *bitbucketForge) Issues() ([]Issue, error) {
+	native, err := f.client.Issues(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, len(native))
+	for i, is := range native {
+		issues[i] = Issue{Number: is.ID, Title: is.Title, URL: is.Links.HTML.Href, CreatedAt: is.CreatedOn}
+		issues[i].Author.Login = is.Reporter.Nickname
+		for _, name := range []string{is.Kind, is.Priority} {
+			if name != "" {
+				issues[i].Labels = append(issues[i].Labels, Label{name})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// PullRequests can't say whether they merge cleanly, so they show as
+// unknown
+func (f *bitbucketForge) PullRequests() ([]PullRequest, error) {
+	native, err := f.client.PullRequests(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(native))
+	for i, pr := range native {
+		prs[i] = PullRequest{Number: pr.ID, Title: pr.Title, URL: pr.Links.HTML.Href, IsDraft: pr.Draft, HeadRef: pr.Source.Branch.Name, UpdatedAt: pr.UpdatedOn,
+			Mergeable: "UNKNOWN"}
+		prs[i].Author.Login = pr.Author.Nickname
+	}
+	return prs, nil
+}
+
+func (f *bitbucketForge) Runs(branch string, n int) ([]Run, error) {
+	pipelines, err := f.client.Pipelines(f.repo, branch, n)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]Run, len(pipelines))
+	for i, p := range pipelines {
+		runs[i] = Run{ID: p.BuildNumber, Name: "pipeline", Branch: p.Target.RefName, URL: bitbucket.PipelineURL(f.repo, p),
+			CreatedAt: p.CreatedOn, Status: "in_progress"}
+		if p.State.Name != "COMPLETED" {
+			continue
+		}
+		runs[i].Status = "completed"
+		switch p.State.Result.Name {
+		case "SUCCESSFUL":
+			runs[i].Conclusion = "success"
+		case "FAILED", "ERROR":
+			runs[i].Conclusion = "failure"
+		case "STOPPED":
+			runs[i].Conclusion = "cancelled"
+		case "EXPIRED":
+			runs[i].Conclusion = "timed_out"
+		default:
+			runs[i].Conclusion = "neutral"
+		}
+	}
+	return runs, nil
+}
+
+func (f *bitbucketForge) Releases(int) ([]Release, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *bitbucketForge) Notifications() ([]Notification, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *bitbucketForge) MarkRead(Notification) error {
+	return ErrUnsupported
+}
+
+func (f *bitbucketForge) Merge(number int, method string) error {
+	strategy, ok := bitbucket.MergeStrategies[method]
+	if !ok {
+		return ErrUnsupported
+	}
+	return f.client.MergePull(f.repo, number, strategy)
+}
+
+// Rerun runs a new pipeline on the run's branch. Pipelines can't rerun
+// only the failed steps of one through the API.
+func (f *bitbucketForge) Rerun(run Run, failedOnly bool) error {
+	if failedOnly {
+		return ErrUnsupported
+	}
+	return f.client.RunPipeline(f.repo, run.Branch)
+}
+
+func (f *bitbucketForge) CreateIssue(title, body string) (string, error) {
+	return f.client.CreateIssue(f.repo, title, body)
+}
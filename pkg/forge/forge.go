@@ -0,0 +1,345 @@
+// Package forge puts the code hosts mc works with (GitHub, GitLab,
+// Bitbucket, Azure DevOps, sourcehut, and Gitea, Forgejo, or Codeberg)
+// behind one interface, picked from a project's git remote, so issues,
+// pull requests, CI runs, releases, and notifications read the same, and
+// merges, re-runs, and new issues go to the right host, wherever a
+// project lives.
+//
+// Supporting another forge means writing its Forge and adding a provider
+// for it to providers; nothing that shows the data needs to change.
+package forge
+
+import (
+	"errors"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/gitrepo"
+)
+
+// Forge names, as Remote.Forge and Notification.Forge report them
+const (
+	GitHub      = "github"
+	GitLab      = "gitlab"
+	Bitbucket   = "bitbucket"
+	AzureDevOps = "azure-devops"
+	Sourcehut   = "sourcehut"
+	Gitea       = "gitea" // Gitea, Forgejo, and Codeberg
+)
+
+// ErrUnsupported means a forge has no API for what was asked, such as
+// Bitbucket Cloud's notifications
+var ErrUnsupported = errors.New("forge: not supported")
+
+// ErrNoForge means a project has no remote on a known forge, or no token
+// (or gh, for GitHub) to reach it with: what Open's ok being false means
+// to a caller that has to report it
+var ErrNoForge = errors.New("forge: no GitHub, GitLab, Bitbucket, Azure DevOps, sourcehut, or Gitea remote with a token")
+
+// Forge is one repository on a code host, read through the host's API
+type Forge interface {
+	// Remote is the repository the forge was opened on
+	Remote() Remote
+
+	// Counts reads the repository's open issue and pull request totals,
+	// those waiting on the signed-in user, and its audience
+	Counts() (Counts, error)
+
+	// Issues lists the open issues, newest first
+	Issues() ([]Issue, error)
+
+	// PullRequests lists the open pull (or merge) requests, recently
+	// updated first
+	PullRequests() ([]PullRequest, error)
+
+	// Runs lists the newest n CI runs (workflow runs, pipelines), on
+	// branch unless it is ""
+	Runs(branch string, n int) ([]Run, error)
+
+	// Releases lists the newest n published releases
+	Releases(n int) ([]Release, error)
+
+	// Notifications lists the signed-in user's unread notifications on
+	// the forge's host, across all repositories
+	Notifications() ([]Notification, error)
+
+	// MarkRead marks one of Notifications read
+	MarkRead(n Notification) error
+
+	// Merge merges an open pull request with one of MergeMethods
+	Merge(number int, method string) error
+
+	// Rerun starts one of Runs again, only its failed jobs if failedOnly
+	Rerun(run Run, failedOnly bool) error
+
+	// CreateIssue opens an issue (a work item, a ticket) and returns its
+	// page
+	CreateIssue(title, body string) (url string, err error)
+}
+
+// MergeMethods are the ways a pull request can be merged, as GitHub names
+// them; each forge maps them to its own
+var MergeMethods = []string{"merge", "squash", "rebase"}
+
+// Remote is a repository on a forge, as read from a git remote URL
+type Remote struct {
+	Forge string // GitHub, GitLab, Bitbucket, AzureDevOps, Sourcehut, or Gitea
+	Host  string
+	Owner string // user, organization, group, or workspace
+	Name  string // the rest of the path, subgroups included
+	URL   string // the repository's page
+
+	// native is the forge package's own name for the repository
+	native any
+}
+
+func (r Remote) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// CommitURL is the page of one of the repository's commits
+func (r Remote) CommitURL(hash string) string {
+	switch r.Forge {
+	case GitLab:
+		return r.URL + "/-/commit/" + hash
+	case Bitbucket:
+		return r.URL + "/commits/" + hash
+	}
+	return r.URL + "/commit/" + hash
+}
+
+// Counts are a repository's open issue and pull request totals, how many
+// are waiting on the signed-in user, and its audience. Forges that don't
+// track one leave it zero.
+type Counts struct {
+	Issues          int
+	PRs             int
+	Assigned        int
+	ReviewRequested int
+	Stars           int
+	StarsWeek       int // stars in the last 7 days
+	Forks           int
+	Watchers        int
+}
+
+// PullRequest is an open pull request (a merge request on GitLab)
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	IsDraft bool   `json:"isDraft"`
+	Author  struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	HeadRef        string    `json:"headRefName"` // the branch it merges
+	UpdatedAt      time.Time `json:"updatedAt"`
+	ReviewDecision string    `json:"reviewDecision"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, or "" when no review is required
+	Mergeable      string    `json:"mergeable"`      // MERGEABLE, CONFLICTING, UNKNOWN (the forge is still computing)
+	Checks         string    `json:"-"`              // SUCCESS, FAILURE, PENDING, or "" with no CI
+}
+
+// Ready reports whether the pull request could be merged now: not a
+// draft, approved or not needing review, no conflicts, and CI not
+// failing or running
+func (pr PullRequest) Ready() bool {
+	return !pr.IsDraft &&
+		(pr.ReviewDecision == "" || pr.ReviewDecision == "APPROVED") &&
+		pr.Mergeable == "MERGEABLE" &&
+		(pr.Checks == "" || pr.Checks == "SUCCESS")
+}
+
+// Label is an issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Issue is an open issue
+type Issue struct {
+	Number int     `json:"number"`
+	Title  string  `json:"title"`
+	URL    string  `json:"url"`
+	Labels []Label `json:"labels"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// LabelNames returns the issue's label names
+func (is Issue) LabelNames() []string {
+	names := make([]string, len(is.Labels))
+	for i, l := range is.Labels {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// Run is one CI run: a GitHub Actions workflow run, or a GitLab or
+// Bitbucket pipeline, in GitHub's terms
+type Run struct {
+	ID         int64     `json:"databaseId"`
+	Name       string    `json:"workflowName"`
+	Branch     string    `json:"headBranch"`
+	Status     string    `json:"status"`     // queued, in_progress, completed
+	Conclusion string    `json:"conclusion"` // success, failure, cancelled, ...
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Failed reports whether the run finished unsuccessfully
+func (r Run) Failed() bool {
+	return r.Status == "completed" && (r.Conclusion == "failure" || r.Conclusion == "timed_out")
+}
+
+// CI states, from a run or a branch's latest runs combined
+const (
+	CIPassing = "passing"
+	CIFailing = "failing"
+	CIRunning = "running"
+)
+
+// State is the run's CI state: passing, failing, running, or the
+// conclusion as is (cancelled, skipped, ...)
+func (r Run) State() string {
+	switch {
+	case r.Status != "completed":
+		return CIRunning
+	case r.Failed():
+		return CIFailing
+	case r.Conclusion == "success":
+		return CIPassing
+	}
+	return r.Conclusion
+}
+
+// Release is a published release
+type Release struct {
+	Tag         string
+	Name        string
+	URL         string
+	PublishedAt time.Time
+	Draft       bool
+	Prerelease  bool
+}
+
+// Notification is an unread notification (a to-do on GitLab)
+type Notification struct {
+	ID        string
+	Forge     string
+	Host      string
+	Repo      string // the repository's full name
+	Reason    string // mention, team_mention, review_requested, assign, ... as GitHub names them
+	Type      string // Issue, PullRequest, Commit, Release, ...
+	Title     string
+	URL       string // the page it's about
+	UpdatedAt time.Time
+
+	// ReadStep is the request MarkRead makes, as a dry run shows it
+	ReadStep string
+
+	from Forge
+}
+
+// MarkRead marks the notification read on the forge it came from
+func (n Notification) MarkRead() error {
+	if n.from == nil {
+		return ErrUnsupported
+	}
+	return n.from.MarkRead(n)
+}
+
+// provider recognizes one forge's remotes and opens its API
+type provider struct {
+	name  string
+	parse func(remote string, cfg *config.Config) (Remote, bool)
+	// open returns nil when there is no token for the remote's host. A
+	// Remote with only Forge and Host set is opened for host-wide calls.
+	open func(r Remote, cfg *config.Config) Forge
+}
+
+// providers are tried in order on each remote URL
+var providers = []provider{
+	{GitHub, parseGitHub, openGitHub},
+	{GitLab, parseGitLab, openGitLab},
+	{Bitbucket, parseBitbucket, openBitbucket},
+	{AzureDevOps, parseAzureDevOps, openAzureDevOps},
+	{Sourcehut, parseSourcehut, openSourcehut},
+	{Gitea, parseGitea, openGitea},
+}
+
+// ParseRemote reads which forge and repository a git remote URL points
+// at. It needs no token. cfg may be nil, leaving out Gitea instances
+// other than Codeberg.
+func ParseRemote(remote string, cfg *config.Config) (Remote, bool) {
+	for _, p := range providers {
+		if r, ok := p.parse(remote, cfg); ok {
+			return r, true
+		}
+	}
+	return Remote{}, false
+}
+
+// ForRemote opens the forge of a Remote from ParseRemote. ok is false
+// when there is no token for it.
+func ForRemote(r Remote, cfg *config.Config) (Forge, bool) {
+	for _, p := range providers {
+		if p.name == r.Forge {
+			f := p.open(r, cfg)
+			return f, f != nil
+		}
+	}
+	return nil, false
+}
+
+// ForHost opens a forge on host without a repository, for Notifications
+// and MarkRead alone
+func ForHost(forge, host string, cfg *config.Config) (Forge, bool) {
+	return ForRemote(Remote{Forge: forge, Host: host}, cfg)
+}
+
+// Open returns the forge of the remote a project most likely pushes to:
+// its upstream remote, then origin, then any other a provider recognizes.
+// ok is false when there is none, or no token for its host.
+func Open(dir string) (Forge, bool) {
+	cfg, _ := config.Load()
+	r, ok := PreferredRemote(dir, cfg)
+	if !ok {
+		return nil, false
+	}
+	return ForRemote(r, cfg)
+}
+
+// PreferredRemote returns the forge repository a project most likely
+// pushes to, as Open picks it
+func PreferredRemote(dir string, cfg *config.Config) (Remote, bool) {
+	repo, err := gitrepo.Open(dir)
+	if err != nil {
+		return Remote{}, false
+	}
+	defer repo.Close()
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return Remote{}, false
+	}
+
+	rank := func(rm gitrepo.Remote) int {
+		switch {
+		case rm.Upstream:
+			return 0
+		case rm.Name == "origin":
+			return 1
+		}
+		return 2
+	}
+	var found Remote
+	best := 3
+	for _, rm := range remotes {
+		if rank(rm) >= best {
+			continue
+		}
+		if r, ok := ParseRemote(rm.URL, cfg); ok {
+			found, best = r, rank(rm)
+		}
+	}
+	return found, best < 3
+}
@@ -0,0 +1,183 @@
+package forge
+
+import (
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// ghForge reads a GitHub repository through a logged-in gh CLI, for
+// machines without a token mc can find
+type ghForge struct {
+	remote Remote
+	repo   github.Repo
+}
+
+// gh runs the gh CLI, returning its output or its first line of stderr as
+// the error
+func gh(args ...string) ([]byte, error) {
+	output, err := exec.Command("gh", args...).Output()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			if msg, _, _ := strings.Cut(strings.TrimSpace(string(exit.Stderr)), "\n"); msg != "" {
+				return nil, errors.New(msg)
+			}
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+// ghJSON runs gh and decodes its output into out
+func ghJSON(out any, args ...string) error {
+	output, err := gh(args...)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(output, out)
+}
+
+func (f *ghForge) Remote() Remote { return f.remote }
+
+// Counts leaves StarsWeek zero; it needs the API
+func (f *ghForge) Counts() (Counts, error) {
+	var c Counts
+	repo := f.repo.String()
+	if err := ghJSON(&c.Issues, "issue", "list", "-R", repo, "--state", "open", "--limit", "1000", "--json", "number", "-q", "length"); err != nil {
+		return c, err
+	}
+	ghJSON(&c.PRs, "pr", "list", "-R", repo, "--state", "open", "--limit", "1000", "--json", "number", "-q", "length")
+	ghJSON(&c.Assigned, "issue", "list", "-R", repo, "--state", "open", "--assignee", "@me", "--json", "number", "-q", "length")
+	ghJSON(&c.ReviewRequested, "pr", "list", "-R", repo, "--state", "open", "--search", "user-review-requested:@me", "--json", "number", "-q", "length")
+
+	var audience struct {
+		StargazerCount int `json:"stargazerCount"`
+		ForkCount      int `json:"forkCount"`
+		Watchers       struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"watchers"`
+	}
+	if ghJSON(&audience, "repo", "view", repo, "--json", "stargazerCount,forkCount,watchers") == nil {
+		c.Stars, c.Forks, c.Watchers = audience.StargazerCount, audience.ForkCount, audience.Watchers.TotalCount
+	}
+	return c, nil
+}
+
+func (f *ghForge) Issues() ([]Issue, error) {
+	var issues []Issue
+	err := ghJSON(&issues, "issue", "list", "-R", f.repo.String(), "--state", "open", "--limit", "100",
+		"--json", "number,title,url,labels,author,createdAt")
+	return issues, err
+}
+
+func (f *ghForge) PullRequests() ([]PullRequest, error) {
+	var listed []struct {
+		PullRequest
+		StatusCheckRollup []ghCheck `json:"statusCheckRollup"`
+	}
+	err := ghJSON(&listed, "pr", "list", "-R", f.repo.String(), "--state", "open", "--limit", "100",
+		"--json", "number,title,url,isDraft,headRefName,author,updatedAt,reviewDecision,mergeable,statusCheckRollup")
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(listed))
+	for i, l := range listed {
+		prs[i] = l.PullRequest
+		prs[i].Checks = rollupState(l.StatusCheckRollup)
+	}
+	return prs, nil
+}
+
+// ghCheck is one entry of gh's statusCheckRollup: a check run (Status,
+// Conclusion) or a commit status (State)
+type ghCheck struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	State      string `json:"state"`
+}
+
+// rollupState reduces gh's statusCheckRollup list (check runs and commit
+// statuses) to one state the way GitHub's own rollup does
+func rollupState(checks []ghCheck) string {
+	if len(checks) == 0 {
+		return ""
+	}
+	state := "SUCCESS"
+	for _, c := range checks {
+		switch {
+		case c.Conclusion == "FAILURE" || c.Conclusion == "TIMED_OUT" || c.Conclusion == "CANCELLED" ||
+			c.Conclusion == "ACTION_REQUIRED" || c.Conclusion == "STARTUP_FAILURE" ||
+			c.State == "FAILURE" || c.State == "ERROR":
+			return "FAILURE"
+		case c.State == "PENDING" || c.State == "EXPECTED" ||
+			(c.Status != "" && c.Status != "COMPLETED"):
+			state = "PENDING"
+		}
+	}
+	return state
+}
+
+func (f *ghForge) Runs(branch string, n int) ([]Run, error) {
+	args := []string{"run", "list", "-R", f.repo.String(), "--limit", strconv.Itoa(n),
+		"--json", "databaseId,workflowName,headBranch,status,conclusion,url,createdAt"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	var runs []Run
+	err := ghJSON(&runs, args...)
+	return runs, err
+}
+
+func (f *ghForge) Releases(n int) ([]Release, error) {
+	var native []github.Release
+	if err := ghJSON(&native, "api", "repos/"+f.repo.String()+"/releases?per_page="+strconv.Itoa(n)); err != nil {
+		return nil, err
+	}
+	releases := make([]Release, len(native))
+	for i, r := range native {
+		releases[i] = gitHubRelease(r)
+	}
+	return releases, nil
+}
+
+func (f *ghForge) Notifications() ([]Notification, error) {
+	var native []github.Notification
+	if err := ghJSON(&native, "api", "notifications?per_page=50"); err != nil {
+		return nil, err
+	}
+	ns := make([]Notification, len(native))
+	for i, n := range native {
+		ns[i] = gitHubNotification(n)
+		ns[i].ReadStep = "gh api -X PATCH notifications/threads/" + n.ID
+		ns[i].from = f
+	}
+	return ns, nil
+}
+
+func (f *ghForge) MarkRead(n Notification) error {
+	_, err := gh("api", "-X", "PATCH", "notifications/threads/"+n.ID)
+	return err
+}
+
+func (f *ghForge) Merge(number int, method string) error {
+	_, err := gh("pr", "merge", strconv.Itoa(number), "-R", f.repo.String(), "--"+method)
+	return err
+}
+
+func (f *ghForge) Rerun(run Run, failedOnly bool) error {
+	args := []string{"run", "rerun", strconv.FormatInt(run.ID, 10), "-R", f.repo.String()}
+	if failedOnly {
+		args = append(args, "--failed")
+	}
+	_, err := gh(args...)
+	return err
+}
+
+func (f *ghForge) CreateIssue(title, body string) (string, error) {
+	output, err := gh("issue", "create", "-R", f.repo.String(), "--title", title, "--body", body)
+	return strings.TrimSpace(string(output)), err
+}
@@ -0,0 +1,164 @@
+package forge
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/gitea"
+)
+
+// giteaForge reads a repository on Codeberg or a configured Gitea or
+// Forgejo instance. Gitea has no CI API of its own.
+type giteaForge struct {
+	client *gitea.Client
+	remote Remote
+	repo   gitea.Repo
+}
+
+func parseGitea(remote string, cfg *config.Config) (Remote, bool) {
+	repo, ok := gitea.ParseRemote(remote, gitea.Instances(cfg))
+	if !ok {
+		return Remote{}, false
+	}
+	return Remote{Forge: Gitea, Host: repo.Host, Owner: repo.Owner, Name: repo.Name,
+		URL: repo.BaseURL + "/" + repo.String(), native: repo}, true
+}
+
+func openGitea(r Remote, cfg *config.Config) Forge {
+	repo, ok := r.native.(gitea.Repo)
+	if !ok {
+		// Host-wide: find the instance
+		for _, base := range gitea.Instances(cfg) {
+			if u, err := url.Parse(base); err == nil && strings.EqualFold(u.Hostname(), r.Host) {
+				repo = gitea.Repo{BaseURL: base, Host: r.Host}
+			}
+		}
+		if repo.BaseURL == "" {
+			return nil
+		}
+	}
+	client := gitea.Default(repo)
+	if client == nil {
+		return nil
+	}
+	return &giteaForge{client: client, remote: r, repo: repo}
+}
+
+func (f *giteaForge) Remote() Remote { return f.remote }
+
+func (f *giteaForge) Counts() (Counts, error) {
+	c, err := f.client.Counts(f.repo)
+	return Counts{
+		Issues:          c.Issues,
+		PRs:             c.PRs,
+		Assigned:        c.Assigned,
+		ReviewRequested: c.ReviewRequested,
+		Stars:           c.Stars,
+		Forks:           c.Forks,
+		Watchers:        c.Watchers,
+	}, err
+}
+
+func (f *giteaForge) Issues() ([]Issue, error) {
+	native, err := f.client.Issues(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, len(native))
+	for i, is := range native {
+		issues[i] = Issue{Number: is.Number, Title: is.Title, URL: is.HTMLURL, CreatedAt: is.CreatedAt}
+		issues[i].Author.Login = is.User.Login
+		for _, l := range is.Labels {
+			issues[i].Labels = append(issues[i].Labels, Label{l.Name})
+		}
+	}
+	return issues, nil
+}
+
+// PullRequests counts a "WIP:" title as a draft, which is how older
+// instances without a draft flag mark them
+func (f *giteaForge) PullRequests() ([]PullRequest, error) {
+	native, err := f.client.PullRequests(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(native))
+	for i, pr := range native {
+		prs[i] = PullRequest{Number: pr.Number, Title: pr.Title, URL: pr.HTMLURL, HeadRef: pr.Head.Ref, UpdatedAt: pr.UpdatedAt, Mergeable: "CONFLICTING",
+			IsDraft: pr.Draft || strings.HasPrefix(strings.ToUpper(pr.Title), "WIP:")}
+		if pr.Mergeable {
+			prs[i].Mergeable = "MERGEABLE"
+		}
+		prs[i].Author.Login = pr.User.Login
+	}
+	return prs, nil
+}
+
+func (f *giteaForge) Runs(string, int) ([]Run, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *giteaForge) Releases(n int) ([]Release, error) {
+	native, err := f.client.Releases(f.repo, n)
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]Release, len(native))
+	for i, r := range native {
+		releases[i] = Release{Tag: r.Tag, Name: r.Name, URL: r.HTMLURL, PublishedAt: r.PublishedAt, Draft: r.Draft, Prerelease: r.Prerelease}
+	}
+	return releases, nil
+}
+
+// Notifications have no reason on Gitea; they arrive for what the user
+// watches or takes part in
+func (f *giteaForge) Notifications() ([]Notification, error) {
+	native, err := f.client.Notifications()
+	if err != nil {
+		return nil, err
+	}
+	ns := make([]Notification, len(native))
+	for i, n := range native {
+		id := strconv.FormatInt(n.ID, 10)
+		link := n.Subject.HTMLURL
+		if link == "" {
+			link = n.Repository.HTMLURL
+		}
+		ns[i] = Notification{
+			ID:        id,
+			Forge:     Gitea,
+			Host:      f.repo.Host,
+			Repo:      n.Repository.FullName,
+			Reason:    "subscribed",
+			Type:      n.Subject.Type,
+			Title:     n.Subject.Title,
+			URL:       link,
+			UpdatedAt: n.UpdatedAt,
+			ReadStep:  "PATCH " + f.client.BaseURL + "/notifications/threads/" + id,
+			from:      f,
+		}
+	}
+	return ns, nil
+}
+
+func (f *giteaForge) MarkRead(n Notification) error {
+	id, err := strconv.ParseInt(n.ID, 10, 64)
+	if err != nil {
+		return err
+	}
+	return f.client.MarkRead(id)
+}
+
+func (f *giteaForge) Merge(number int, method string) error {
+	return f.client.MergePull(f.repo, number, method)
+}
+
+func (f *giteaForge) Rerun(Run, bool) error {
+	return ErrUnsupported
+}
+
+func (f *giteaForge) CreateIssue(title, body string) (string, error) {
+	return f.client.CreateIssue(f.repo, title, body)
+}
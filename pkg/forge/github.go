@@ -0,0 +1,170 @@
+package forge
+
+import (
+	"os/exec"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+)
+
+// gitHubForge reads a GitHub repository through the REST and GraphQL
+// APIs. Without a token it's read through gh instead (see ghForge).
+type gitHubForge struct {
+	client *github.Client
+	remote Remote
+	repo   github.Repo
+}
+
+func parseGitHub(remote string, _ *config.Config) (Remote, bool) {
+	repo, ok := github.ParseRemote(remote)
+	if !ok {
+		return Remote{}, false
+	}
+	return Remote{Forge: GitHub, Host: "github.com", Owner: repo.Owner, Name: repo.Name,
+		URL: "https://github.com/" + repo.String(), native: repo}, true
+}
+
+func openGitHub(r Remote, _ *config.Config) Forge {
+	repo, _ := r.native.(github.Repo)
+	client := github.Default()
+	if client == nil {
+		if _, err := exec.LookPath("gh"); err != nil {
+			return nil
+		}
+		return &ghForge{remote: r, repo: repo}
+	}
+	return &gitHubForge{client: client, remote: r, repo: repo}
+}
+
+func (f *gitHubForge) Remote() Remote { return f.remote }
+
+func (f *gitHubForge) Counts() (Counts, error) {
+	c, err := f.client.Counts(f.repo)
+	return GitHubCounts(c), err
+}
+
+// GitHubCounts converts counts as the GitHub client reads them, one
+// repository at a time or batched
+func GitHubCounts(c github.Counts) Counts {
+	return Counts{
+		Issues:          c.Issues,
+		PRs:             c.PRs,
+		Assigned:        c.Assigned,
+		ReviewRequested: c.ReviewRequested,
+		Stars:           c.Stars,
+		StarsWeek:       c.StarsWeek,
+		Forks:           c.Forks,
+		Watchers:        c.Watchers,
+	}
+}
+
+func (f *gitHubForge) Issues() ([]Issue, error) {
+	native, err := f.client.Issues(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, len(native))
+	for i, is := range native {
+		issues[i] = Issue{Number: is.Number, Title: is.Title, URL: is.URL, CreatedAt: is.CreatedAt}
+		issues[i].Author.Login = is.User.Login
+		for _, l := range is.Labels {
+			issues[i].Labels = append(issues[i].Labels, Label{l.Name})
+		}
+	}
+	return issues, nil
+}
+
+func (f *gitHubForge) PullRequests() ([]PullRequest, error) {
+	native, err := f.client.PullRequests(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(native))
+	for i, pr := range native {
+		prs[i] = PullRequest{Number: pr.Number, Title: pr.Title, URL: pr.URL, IsDraft: pr.IsDraft, HeadRef: pr.HeadRef, UpdatedAt: pr.UpdatedAt,
+			ReviewDecision: pr.ReviewDecision, Mergeable: pr.Mergeable, Checks: pr.Checks()}
+		prs[i].Author.Login = pr.Author.Login
+		switch prs[i].Checks {
+		case "ERROR":
+			prs[i].Checks = "FAILURE"
+		case "EXPECTED":
+			prs[i].Checks = "PENDING"
+		}
+	}
+	return prs, nil
+}
+
+func (f *gitHubForge) Runs(branch string, n int) ([]Run, error) {
+	native, err := f.client.WorkflowRuns(f.repo, branch, n)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]Run, len(native))
+	for i, r := range native {
+		runs[i] = Run(r)
+	}
+	return runs, nil
+}
+
+func (f *gitHubForge) Releases(n int) ([]Release, error) {
+	native, err := f.client.Releases(f.repo, n)
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]Release, len(native))
+	for i, r := range native {
+		releases[i] = gitHubRelease(r)
+	}
+	return releases, nil
+}
+
+// gitHubRelease converts a release as GitHub's API returns it
+func gitHubRelease(r github.Release) Release {
+	return Release{Tag: r.Tag, Name: r.Name, URL: r.URL, PublishedAt: r.PublishedAt, Draft: r.Draft, Prerelease: r.Prerelease}
+}
+
+func (f *gitHubForge) Notifications() ([]Notification, error) {
+	native, err := f.client.Notifications()
+	if err != nil {
+		return nil, err
+	}
+	ns := make([]Notification, len(native))
+	for i, n := range native {
+		ns[i] = gitHubNotification(n)
+		ns[i].ReadStep = "PATCH " + f.client.URL("notifications/threads/"+n.ID)
+		ns[i].from = f
+	}
+	return ns, nil
+}
+
+func (f *gitHubForge) MarkRead(n Notification) error {
+	return f.client.MarkRead(n.ID)
+}
+
+// gitHubNotification converts a notification as GitHub's API returns it
+func gitHubNotification(n github.Notification) Notification {
+	return Notification{
+		ID:        n.ID,
+		Forge:     GitHub,
+		Host:      "github.com",
+		Repo:      n.Repository.FullName,
+		Reason:    n.Reason,
+		Type:      n.Subject.Type,
+		Title:     n.Subject.Title,
+		URL:       n.HTMLURL(),
+		UpdatedAt: n.UpdatedAt,
+		ReadStep:  "gh api -X PATCH notifications/threads/" + n.ID,
+	}
+}
+
+func (f *gitHubForge) Merge(number int, method string) error {
+	return f.client.MergePull(f.repo, number, method)
+}
+
+func (f *gitHubForge) Rerun(run Run, failedOnly bool) error {
+	return f.client.Rerun(f.repo, run.ID, failedOnly)
+}
+
+func (f *gitHubForge) CreateIssue(title, body string) (string, error) {
+	return f.client.CreateIssue(f.repo, title, body)
+}
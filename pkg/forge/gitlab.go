@@ -0,0 +1,214 @@
+package forge
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/gitlab"
+)
+
+// gitLabForge reads a project on gitlab.com or a self-managed GitLab,
+// merge requests standing in for pull requests and pipelines for runs
+type gitLabForge struct {
+	client  *gitlab.Client
+	remote  Remote
+	project gitlab.Project
+}
+
+func parseGitLab(remote string, _ *config.Config) (Remote, bool) {
+	project, ok := gitlab.ParseRemote(remote)
+	if !ok {
+		return Remote{}, false
+	}
+	group, name, _ := strings.Cut(project.Path, "/")
+	return Remote{Forge: GitLab, Host: project.Host, Owner: group, Name: name, URL: project.WebURL(), native: project}, true
+}
+
+func openGitLab(r Remote, _ *config.Config) Forge {
+	client := gitlab.Default(r.Host)
+	if client == nil {
+		return nil
+	}
+	project, ok := r.native.(gitlab.Project)
+	if !ok {
+		project = gitlab.Project{Host: r.Host}
+	}
+	return &gitLabForge{client: client, remote: r, project: project}
+}
+
+func (f *gitLabForge) Remote() Remote { return f.remote }
+
+func (f *gitLabForge) Counts() (Counts, error) {
+	c, err := f.client.Counts(f.project)
+	return Counts{
+		Issues:          c.Issues,
+		PRs:             c.MergeRequests,
+		Assigned:        c.Assigned,
+		ReviewRequested: c.ReviewRequested,
+		Stars:           c.Stars,
+		Forks:           c.Forks,
+	}, err
+}
+
+func (f *gitLabForge) Issues() ([]Issue, error) {
+	native, err := f.client.Issues(f.project)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, len(native))
+	for i, is := range native {
+		issues[i] = Issue{Number: is.IID, Title: is.Title, URL: is.WebURL, CreatedAt: is.CreatedAt}
+		issues[i].Author.Login = is.Author.Username
+		for _, name := range is.Labels {
+			issues[i].Labels = append(issues[i].Labels, Label{name})
+		}
+	}
+	return issues, nil
+}
+
+// PullRequests reads review and CI state from what blocks each merge
+func (f *gitLabForge) PullRequests() ([]PullRequest, error) {
+	native, err := f.client.MergeRequests(f.project)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(native))
+	for i, mr := range native {
+		prs[i] = PullRequest{Number: mr.IID, Title: mr.Title, URL: mr.WebURL, IsDraft: mr.Draft, HeadRef: mr.Source, UpdatedAt: mr.UpdatedAt,
+			Mergeable: "MERGEABLE"}
+		prs[i].Author.Login = mr.Author.Username
+		switch mr.MergeStatus {
+		case "conflict", "need_rebase":
+			prs[i].Mergeable = "CONFLICTING"
+		case "checking", "unchecked", "preparing", "approvals_syncing":
+			prs[i].Mergeable = "UNKNOWN"
+		case "not_approved":
+			prs[i].ReviewDecision = "REVIEW_REQUIRED"
+		case "requested_changes":
+			prs[i].ReviewDecision = "CHANGES_REQUESTED"
+		case "ci_must_pass":
+			prs[i].Checks = "FAILURE"
+		case "ci_still_running":
+			prs[i].Checks = "PENDING"
+		}
+	}
+	return prs, nil
+}
+
+func (f *gitLabForge) Runs(branch string, n int) ([]Run, error) {
+	pipelines, err := f.client.Pipelines(f.project, branch, n)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]Run, len(pipelines))
+	for i, p := range pipelines {
+		name := p.Name
+		if name == "" {
+			name = "pipeline"
+		}
+		runs[i] = Run{ID: p.ID, Name: name, Branch: p.Ref, URL: p.WebURL, CreatedAt: p.CreatedAt, Status: "completed"}
+		switch p.Status {
+		case "success":
+			runs[i].Conclusion = "success"
+		case "failed":
+			runs[i].Conclusion = "failure"
+		case "canceled":
+			runs[i].Conclusion = "cancelled"
+		case "skipped":
+			runs[i].Conclusion = "skipped"
+		case "manual":
+			runs[i].Conclusion = "action_required"
+		default:
+			runs[i].Status = "in_progress"
+		}
+	}
+	return runs, nil
+}
+
+func (f *gitLabForge) Releases(n int) ([]Release, error) {
+	native, err := f.client.Releases(f.project, n)
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]Release, len(native))
+	for i, r := range native {
+		releases[i] = Release{Tag: r.Tag, Name: r.Name, URL: r.Links.Self, PublishedAt: r.ReleasedAt, Prerelease: r.Upcoming}
+	}
+	return releases, nil
+}
+
+// todoReasons maps GitLab's to-do actions to GitHub's notification
+// reasons
+var todoReasons = map[string]string{
+	"mentioned":          "mention",
+	"directly_addressed": "mention",
+	"assigned":           "assign",
+	"review_requested":   "review_requested",
+	"approval_required":  "review_requested",
+	"build_failed":       "ci_activity",
+}
+
+// Notifications are the user's pending to-dos
+func (f *gitLabForge) Notifications() ([]Notification, error) {
+	todos, err := f.client.Todos()
+	if err != nil {
+		return nil, err
+	}
+	ns := make([]Notification, len(todos))
+	for i, t := range todos {
+		reason := todoReasons[t.ActionName]
+		if reason == "" {
+			reason = t.ActionName
+		}
+		title := t.Target.Title
+		if title == "" {
+			title = t.Body
+		}
+		id := strconv.Itoa(t.ID)
+		ns[i] = Notification{
+			ID:        id,
+			Forge:     GitLab,
+			Host:      f.remote.Host,
+			Repo:      t.Project.PathWithNamespace,
+			Reason:    reason,
+			Type:      t.TargetType,
+			Title:     title,
+			URL:       t.TargetURL,
+			UpdatedAt: t.CreatedAt,
+			ReadStep:  "POST " + f.client.BaseURL + "/todos/" + id + "/mark_as_done",
+			from:      f,
+		}
+	}
+	return ns, nil
+}
+
+func (f *gitLabForge) MarkRead(n Notification) error {
+	id, err := strconv.Atoi(n.ID)
+	if err != nil {
+		return err
+	}
+	return f.client.MarkTodoDone(id)
+}
+
+// Merge can squash but not rebase: whether a merge request lands as a
+// merge commit or fast-forward is the project's setting
+func (f *gitLabForge) Merge(number int, method string) error {
+	if method == "rebase" {
+		return ErrUnsupported
+	}
+	return f.client.Merge(f.project, number, method == "squash")
+}
+
+// Rerun retries a pipeline's failed jobs, or runs a new pipeline on its
+// ref
+func (f *gitLabForge) Rerun(run Run, failedOnly bool) error {
+	if failedOnly {
+		return f.client.RetryPipeline(f.project, run.ID)
+	}
+	return f.client.RunPipeline(f.project, run.Branch)
+}
+
+func (f *gitLabForge) CreateIssue(title, body string) (string, error) {
+	return f.client.CreateIssue(f.project, title, body)
+}
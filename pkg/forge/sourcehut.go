@@ -0,0 +1,137 @@
+package forge
+
+import (
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/sourcehut"
+)
+
+// sourcehutForge reads a repository on git.sr.ht, the todo.sr.ht tracker
+// of the same name standing in for issues and builds.sr.ht jobs for
+// runs. sourcehut takes patches by email, so there are no pull requests,
+// and it has no releases or notifications API.
+type sourcehutForge struct {
+	client *sourcehut.Client
+	remote Remote
+	repo   sourcehut.Repo
+}
+
+func parseSourcehut(remote string, _ *config.Config) (Remote, bool) {
+	repo, ok := sourcehut.ParseRemote(remote)
+	if !ok {
+		return Remote{}, false
+	}
+	return Remote{Forge: Sourcehut, Host: "git." + repo.Domain, Owner: repo.Owner, Name: repo.Name,
+		URL: repo.WebURL(), native: repo}, true
+}
+
+func openSourcehut(r Remote, _ *config.Config) Forge {
+	client := sourcehut.Default(sourcehut.Domain)
+	if client == nil {
+		return nil
+	}
+	repo, _ := r.native.(sourcehut.Repo)
+	return &sourcehutForge{client: client, remote: r, repo: repo}
+}
+
+func (f *sourcehutForge) Remote() Remote { return f.remote }
+
+// Counts counts the open tickets and those assigned to the signed-in
+// user
+func (f *sourcehutForge) Counts() (Counts, error) {
+	tickets, err := f.client.Tickets(f.repo)
+	if err != nil {
+		return Counts{}, err
+	}
+	c := Counts{Issues: len(tickets)}
+	if me, err := f.client.Me(); err == nil {
+		for _, t := range tickets {
+			for _, a := range t.Assignees {
+				if a.CanonicalName == me {
+					c.Assigned++
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+// Issues are the open tickets, labelled with their labels and a status
+// other than the default "reported"
+func (f *sourcehutForge) Issues() ([]Issue, error) {
+	tickets, err := f.client.Tickets(f.repo)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, len(tickets))
+	for i, t := range tickets {
+		issues[i] = Issue{Number: t.ID, Title: t.Subject, URL: t.URL(f.repo), CreatedAt: t.Created}
+		issues[i].Author.Login = t.Submitter.CanonicalName
+		for _, l := range t.Labels {
+			issues[i].Labels = append(issues[i].Labels, Label{l.Name})
+		}
+		if t.Status != "REPORTED" {
+			issues[i].Labels = append(issues[i].Labels, Label{strings.ToLower(strings.ReplaceAll(t.Status, "_", " "))})
+		}
+	}
+	return issues, nil
+}
+
+func (f *sourcehutForge) PullRequests() ([]PullRequest, error) {
+	return nil, nil
+}
+
+func (f *sourcehutForge) Runs(branch string, n int) ([]Run, error) {
+	jobs, err := f.client.Jobs(f.repo, branch, n)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]Run, len(jobs))
+	for i, j := range jobs {
+		runs[i] = Run{ID: j.ID, Name: j.Name(), Branch: j.Branch(), URL: j.URL(f.repo.Domain), CreatedAt: j.Created, Status: "completed"}
+		switch j.Status {
+		case "PENDING", "QUEUED":
+			runs[i].Status = "queued"
+		case "RUNNING":
+			runs[i].Status = "in_progress"
+		case "SUCCESS":
+			runs[i].Conclusion = "success"
+		case "FAILED":
+			runs[i].Conclusion = "failure"
+		case "TIMEOUT":
+			runs[i].Conclusion = "timed_out"
+		case "CANCELLED":
+			runs[i].Conclusion = "cancelled"
+		default:
+			runs[i].Conclusion = "neutral"
+		}
+	}
+	return runs, nil
+}
+
+func (f *sourcehutForge) Releases(int) ([]Release, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *sourcehutForge) Notifications() ([]Notification, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *sourcehutForge) MarkRead(Notification) error {
+	return ErrUnsupported
+}
+
+func (f *sourcehutForge) Merge(int, string) error {
+	return ErrUnsupported
+}
+
+// Rerun submits the job again; a job has no failed part to rerun alone
+func (f *sourcehutForge) Rerun(run Run, _ bool) error {
+	return f.client.Resubmit(run.ID)
+}
+
+// CreateIssue files a ticket on the tracker named after the repository
+func (f *sourcehutForge) CreateIssue(title, body string) (string, error) {
+	return f.client.SubmitTicket(f.repo, title, body)
+}
@@ -0,0 +1,311 @@
+// Package gitea talks to the Gitea API, which Forgejo and Codeberg serve
+// too, so projects hosted there show issue and pull request stats like
+// GitHub ones.
+package gitea
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// Codeberg is the public Forgejo instance, known without configuration
+const Codeberg = "https://codeberg.org"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no Gitea token (set tokens.gitea-<host>, tokens.gitea, or GITEA_TOKEN)")
+
+// Client makes authenticated API requests to one instance, whose BaseURL
+// is https://<host>/api/v1
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for the instance at baseURL using token
+func NewClient(baseURL, token string) *Client {
+	c := httpapi.New("gitea", strings.TrimSuffix(baseURL, "/")+"/api/v1", token, ErrNoToken)
+	c.Authorize = func(req *http.Request, token string) {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	return &Client{c}
+}
+
+// Instances returns the base URLs of the known instances: Codeberg and
+// any configured
+func Instances(cfg *config.Config) []string {
+	instances := []string{Codeberg}
+	if cfg != nil {
+		for _, u := range cfg.Gitea {
+			if !strings.Contains(u, "://") {
+				u = "https://" + u
+			}
+			instances = append(instances, strings.TrimSuffix(u, "/"))
+		}
+	}
+	return instances
+}
+
+// FindToken looks for a token for host, in order: tokens.gitea-<host>
+// (or MC_GITEA_<HOST>_TOKEN), tokens.gitea or MC_GITEA_TOKEN, then
+// GITEA_TOKEN. It returns "" when there is none.
+func FindToken(cfg *config.Config, host string) string {
+	if cfg != nil {
+		if t := cfg.Token("gitea-" + strings.ToLower(host)); t != "" {
+			return t
+		}
+		if t := cfg.Token("gitea"); t != "" {
+			return t
+		}
+	}
+	return os.Getenv("GITEA_TOKEN")
+}
+
+// Default returns a client for the instance hosting repo using the token
+// FindToken finds, or nil when there is none
+func Default(repo Repo) *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg, repo.Host); token != "" {
+		return NewClient(repo.BaseURL, token)
+	}
+	return nil
+}
+
+// Count returns how many items a list path has in all, from the
+// X-Total-Count header of a one-item page
+func (c *Client) Count(path string) (int, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	header, err := c.Do("GET", path+sep+"limit=1", nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(header.Get("X-Total-Count"))
+}
+
+// Repo names a repository on an instance
+type Repo struct {
+	BaseURL string // the instance, e.g. https://codeberg.org
+	Host    string
+	Owner   string
+	Name    string
+}
+
+func (r Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+func (r Repo) path() string {
+	return "repos/" + url.PathEscape(r.Owner) + "/" + url.PathEscape(r.Name)
+}
+
+// ParseRemote extracts the repository from a remote URL on one of
+// instances (base URLs, see Instances) in any of the forms git accepts:
+// https://codeberg.org/o/r.git, git@codeberg.org:o/r.git, or
+// ssh://git@git.example.com:2222/o/r
+func ParseRemote(remote string, instances []string) (Repo, bool) {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Repo{}, false
+		}
+		host, path = u.Hostname(), u.Path
+	default:
+		// scp-like syntax: [user@]host:owner/repo
+		h, rest, ok := strings.Cut(remote, ":")
+		if !ok {
+			return Repo{}, false
+		}
+		if _, after, found := strings.Cut(h, "@"); found {
+			h = after
+		}
+		host, path = h, rest
+	}
+
+	for _, base := range instances {
+		u, err := url.Parse(base)
+		if err != nil || !strings.EqualFold(u.Hostname(), host) {
+			continue
+		}
+		// An instance under a subpath serves repositories beneath it
+		path = strings.TrimPrefix(strings.Trim(path, "/"), strings.Trim(u.Path, "/"))
+		path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+		owner, name, ok := strings.Cut(path, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return Repo{}, false
+		}
+		return Repo{BaseURL: base, Host: strings.ToLower(host), Owner: owner, Name: name}, true
+	}
+	return Repo{}, false
+}
+
+// Counts are a repository's open issue and pull request totals, how many
+// are waiting on the authenticated user, and its audience
+type Counts struct {
+	Issues          int
+	PRs             int
+	Assigned        int
+	ReviewRequested int
+	Stars           int
+	Forks           int
+	Watchers        int
+}
+
+// Counts reads a repository's Counts
+func (c *Client) Counts(r Repo) (Counts, error) {
+	var info struct {
+		OpenIssues int `json:"open_issues_count"`
+		OpenPRs    int `json:"open_pr_counter"`
+		Stars      int `json:"stars_count"`
+		Forks      int `json:"forks_count"`
+		Watchers   int `json:"watchers_count"`
+	}
+	if err := c.Get(r.path(), &info); err != nil {
+		return Counts{}, err
+	}
+	counts := Counts{Issues: info.OpenIssues, PRs: info.OpenPRs, Stars: info.Stars, Forks: info.Forks, Watchers: info.Watchers}
+
+	var me struct {
+		Login string `json:"login"`
+	}
+	if c.Get("user", &me) != nil || me.Login == "" {
+		return counts, nil
+	}
+	counts.Assigned, _ = c.Count(r.path() + "/issues?state=open&type=issues&assigned_by=" + url.QueryEscape(me.Login))
+
+	// Review requests can only be searched for by owner
+	var requested []struct {
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	q := url.Values{"state": {"open"}, "type": {"pulls"}, "review_requested": {"true"}, "owner": {r.Owner}, "limit": {"50"}}
+	if c.Get("repos/issues/search?"+q.Encode(), &requested) == nil {
+		for _, pr := range requested {
+			if strings.EqualFold(pr.Repository.FullName, r.String()) {
+				counts.ReviewRequested++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// User is an issue or pull request author
+type User struct {
+	Login string `json:"login"`
+}
+
+// Label is an issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Issue is an open issue
+type Issue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	HTMLURL   string    `json:"html_url"`
+	Labels    []Label   `json:"labels"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Issues lists a repository's open issues, newest first, leaving out
+// pull requests
+func (c *Client) Issues(r Repo) ([]Issue, error) {
+	var issues []Issue
+	err := c.Get(r.path()+"/issues?state=open&type=issues&limit=50", &issues)
+	return issues, err
+}
+
+// PullRequest is an open pull request
+type PullRequest struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	HTMLURL   string `json:"html_url"`
+	Draft     bool   `json:"draft"`
+	Mergeable bool   `json:"mergeable"`
+	Head      struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	User      User      `json:"user"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PullRequests lists a repository's open pull requests, recently updated
+// first
+func (c *Client) PullRequests(r Repo) ([]PullRequest, error) {
+	var prs []PullRequest
+	err := c.Get(r.path()+"/pulls?state=open&sort=recentupdate&limit=50", &prs)
+	return prs, err
+}
+
+// Release is a published release
+type Release struct {
+	Tag         string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Releases lists a repository's newest n releases
+func (c *Client) Releases(r Repo, n int) ([]Release, error) {
+	var releases []Release
+	err := c.Get(fmt.Sprintf("%s/releases?limit=%d", r.path(), n), &releases)
+	return releases, err
+}
+
+// Notification is an unread notification thread
+type Notification struct {
+	ID        int64     `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Subject   struct {
+		Title   string `json:"title"`
+		Type    string `json:"type"` // Issue, Pull, Commit, Repository
+		HTMLURL string `json:"html_url"`
+	} `json:"subject"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+}
+
+// Notifications lists the authenticated user's unread notification
+// threads, newest first
+func (c *Client) Notifications() ([]Notification, error) {
+	var out []Notification
+	err := c.Get("notifications?status-types=unread&limit=50", &out)
+	return out, err
+}
+
+// MarkRead marks a notification thread as read
+func (c *Client) MarkRead(id int64) error {
+	return c.Patch(fmt.Sprintf("notifications/threads/%d", id), nil, nil)
+}
+
+// MergePull merges a pull request with method "merge", "squash", or
+// "rebase", which Gitea names as GitHub does
+func (c *Client) MergePull(r Repo, number int, method string) error {
+	return c.Post(fmt.Sprintf("%s/pulls/%d/merge", r.path(), number), map[string]string{"Do": method}, nil)
+}
+
+// CreateIssue opens an issue and returns its page
+func (c *Client) CreateIssue(r Repo, title, body string) (string, error) {
+	var created Issue
+	err := c.Post(r.path()+"/issues", map[string]string{"title": title, "body": body}, &created)
+	return created.HTMLURL, err
+}
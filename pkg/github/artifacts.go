@@ -0,0 +1,111 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Artifact is a file a workflow run uploaded
+type Artifact struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Size      int64     `json:"size_in_bytes"`
+	Expired   bool      `json:"expired"`
+	Digest    string    `json:"digest"` // "sha256:<hex>", on artifacts uploaded since 2025
+	CreatedAt time.Time `json:"created_at"`
+	Run       struct {
+		ID     int64  `json:"id"`
+		Branch string `json:"head_branch"`
+	} `json:"workflow_run"`
+}
+
+// Release is a published release with its assets
+type Release struct {
+	Tag         string         `json:"tag_name"`
+	Name        string         `json:"name"`
+	URL         string         `json:"html_url"`
+	Draft       bool           `json:"draft"`
+	Prerelease  bool           `json:"prerelease"`
+	PublishedAt time.Time      `json:"published_at"`
+	Assets      []ReleaseAsset `json:"assets"`
+}
+
+// ReleaseAsset is a file attached to a release
+type ReleaseAsset struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	Downloads int       `json:"download_count"`
+	Digest    string    `json:"digest"` // "sha256:<hex>", on assets uploaded since 2025
+	UpdatedAt time.Time `json:"updated_at"`
+	URL       string    `json:"browser_download_url"`
+}
+
+// Artifacts lists a repository's newest n workflow artifacts
+func (c *Client) Artifacts(repo Repo, n int) ([]Artifact, error) {
+	var page struct {
+		Artifacts []Artifact `json:"artifacts"`
+	}
+	err := c.Get(fmt.Sprintf("repos/%s/actions/artifacts?per_page=%d", repo, n), &page)
+	return page.Artifacts, err
+}
+
+// LatestRelease reads a repository's latest published release
+func (c *Client) LatestRelease(repo Repo) (*Release, error) {
+	var rel Release
+	if err := c.Get("repos/"+repo.String()+"/releases/latest", &rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// Releases lists a repository's newest n releases, drafts included when
+// the token can see them
+func (c *Client) Releases(repo Repo, n int) ([]Release, error) {
+	var releases []Release
+	err := c.Get(fmt.Sprintf("repos/%s/releases?per_page=%d", repo, n), &releases)
+	return releases, err
+}
+
+// ArtifactPath is the REST path of an artifact's zip
+func ArtifactPath(repo string, id int64) string {
+	return fmt.Sprintf("repos/%s/actions/artifacts/%d/zip", repo, id)
+}
+
+// AssetPath is the REST path of a release asset, which serves its content
+// when asked for application/octet-stream
+func AssetPath(repo string, id int64) string {
+	return fmt.Sprintf("repos/%s/releases/assets/%d", repo, id)
+}
+
+// Download streams a REST path's raw content into w, following the
+// redirect to where GitHub stores it. It has no timeout, as files can
+// be large.
+func (c *Client) Download(path string, w io.Writer) error {
+	if c.Token == "" {
+		return ErrNoToken
+	}
+	req, err := http.NewRequest("GET", c.URL(path), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/octet-stream")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	// The redirect leaves api.github.com, so the token isn't sent on
+	client := &http.Client{Transport: c.HTTP.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	c.noteRate(resp)
+	if resp.StatusCode >= 300 {
+		return &APIError{API: "github", Status: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
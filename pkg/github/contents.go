@@ -0,0 +1,76 @@
+package github
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FileChange is one file rewritten on a new branch and proposed as a pull
+// request (see ProposeFile)
+type FileChange struct {
+	Base    string // the branch it's proposed against
+	Branch  string // the new branch, created from Base
+	Path    string
+	Content string
+	Message string // the commit message
+	Title   string // the pull request's
+	Body    string
+}
+
+// ProposeFile commits a file's new content to a new branch and opens a
+// pull request for it, returning the pull request's URL. Nothing is
+// checked out or pushed locally.
+func (c *Client) ProposeFile(repo Repo, change FileChange) (string, error) {
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := c.Get(fmt.Sprintf("repos/%s/git/ref/heads/%s", repo, change.Base), &ref); err != nil {
+		return "", err
+	}
+	if err := c.Post(fmt.Sprintf("repos/%s/git/refs", repo), map[string]string{
+		"ref": "refs/heads/" + change.Branch,
+		"sha": ref.Object.SHA,
+	}, nil); err != nil {
+		return "", err
+	}
+
+	path := escapePath(change.Path)
+	var file struct {
+		SHA string `json:"sha"`
+	}
+	if err := c.Get(fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, url.QueryEscape(change.Branch)), &file); err != nil {
+		return "", err
+	}
+	if err := c.Put(fmt.Sprintf("repos/%s/contents/%s", repo, path), map[string]string{
+		"message": change.Message,
+		"content": base64.StdEncoding.EncodeToString([]byte(change.Content)),
+		"sha":     file.SHA,
+		"branch":  change.Branch,
+	}, nil); err != nil {
+		return "", err
+	}
+
+	var pr struct {
+		HTMLURL string `json:"html_url"`
+	}
+	err := c.Post(fmt.Sprintf("repos/%s/pulls", repo), map[string]string{
+		"title": change.Title,
+		"body":  change.Body,
+		"head":  change.Branch,
+		"base":  change.Base,
+	}, &pr)
+	return pr.HTMLURL, err
+}
+
+// escapePath escapes each element of a slash-separated path
+func escapePath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
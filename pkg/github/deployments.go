@@ -0,0 +1,65 @@
+package github
+
+import (
+	"fmt"
+	"time"
+)
+
+// Deployment is a deployment a provider recorded on GitHub
+type Deployment struct {
+	ID          int64     `json:"id"`
+	SHA         string    `json:"sha"`
+	Ref         string    `json:"ref"`
+	Environment string    `json:"environment"`
+	CreatedAt   time.Time `json:"created_at"`
+	Creator     struct {
+		Login string `json:"login"`
+	} `json:"creator"`
+}
+
+// DeploymentStatus is a deployment's state at some point
+type DeploymentStatus struct {
+	State  string `json:"state"` // success, failure, error, inactive, in_progress, queued, pending
+	EnvURL string `json:"environment_url"`
+}
+
+// Comparison is how far apart two commits are
+type Comparison struct {
+	Status string `json:"status"` // ahead, behind, diverged, identical
+	Ahead  int    `json:"ahead_by"`
+	Behind int    `json:"behind_by"`
+}
+
+// Deployments lists a repository's newest n deployments
+func (c *Client) Deployments(repo Repo, n int) ([]Deployment, error) {
+	return ReadDeployments(c.repoGet(repo), n)
+}
+
+// ReadDeployments lists the newest n deployments through get
+func ReadDeployments(get GetFunc, n int) ([]Deployment, error) {
+	var deploys []Deployment
+	err := get(fmt.Sprintf("deployments?per_page=%d", n), &deploys)
+	return deploys, err
+}
+
+// LatestDeploymentStatus reads a deployment's current status through
+// get, nil when it has none yet
+func LatestDeploymentStatus(get GetFunc, id int64) (*DeploymentStatus, error) {
+	var statuses []DeploymentStatus
+	if err := get(fmt.Sprintf("deployments/%d/statuses?per_page=1", id), &statuses); err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+	return &statuses[0], nil
+}
+
+// Compare counts the commits head is ahead of and behind base, through get
+func Compare(get GetFunc, base, head string) (*Comparison, error) {
+	var cmp Comparison
+	if err := get(fmt.Sprintf("compare/%s...%s?per_page=1", base, head), &cmp); err != nil {
+		return nil, err
+	}
+	return &cmp, nil
+}
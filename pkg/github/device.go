@@ -0,0 +1,132 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LoginScope is what a device-flow token is granted: private repos, org
+// membership for the org summary, notifications, and project boards
+const LoginScope = "repo read:org notifications project"
+
+// loginBaseURL is where device-flow requests go; it's not the API host
+var loginBaseURL = "https://github.com"
+
+// ErrNoClientID means no OAuth app is configured for device-flow login
+var ErrNoClientID = errors.New("no GitHub OAuth app: register one with device flow enabled and set github_client_id in ~/.hustlemc/config.json")
+
+// DeviceCode is the first step of the OAuth device flow: the user enters
+// UserCode at VerificationURI while the app polls for the token
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"` // seconds
+	Interval        int    `json:"interval"`   // seconds between polls
+}
+
+// RequestDeviceCode starts a device-flow login for the OAuth app clientID
+func RequestDeviceCode(clientID string) (*DeviceCode, error) {
+	if clientID == "" {
+		return nil, ErrNoClientID
+	}
+	var code DeviceCode
+	var fail oauthError
+	if err := oauthPost("/login/device/code", url.Values{
+		"client_id": {clientID},
+		"scope":     {LoginScope},
+	}, &code, &fail); err != nil {
+		return nil, err
+	}
+	if fail.Code != "" {
+		return nil, fail
+	}
+	if code.Interval <= 0 {
+		code.Interval = 5
+	}
+	return &code, nil
+}
+
+// PollToken waits for the user to approve code, polling at the interval
+// GitHub asks for, and returns the access token. It gives up when the
+// code expires or the user denies access.
+func PollToken(clientID string, code *DeviceCode) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		var result struct {
+			AccessToken string `json:"access_token"`
+		}
+		var fail oauthError
+		if err := oauthPost("/login/oauth/access_token", url.Values{
+			"client_id":   {clientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		}, &result, &fail); err != nil {
+			return "", err
+		}
+		switch fail.Code {
+		case "":
+			if result.AccessToken == "" {
+				return "", errors.New("github: login returned no token")
+			}
+			return result.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			// expired_token, access_denied, and misconfigured apps
+			return "", fail
+		}
+	}
+	return "", errors.New("github: login code expired")
+}
+
+// oauthError is how the OAuth endpoints report failure, with a 200 status
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e oauthError) Error() string {
+	if e.Description == "" {
+		return "github: " + e.Code
+	}
+	return "github: " + e.Description
+}
+
+// oauthPost sends a form to the login host, decoding the JSON response
+// into out and, if it describes an error, into fail
+func oauthPost(path string, form url.Values, out any, fail *oauthError) error {
+	req, err := http.NewRequest("POST", loginBaseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := (&http.Client{Timeout: 20 * time.Second}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return &APIError{API: "github", Status: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return fmt.Errorf("github: %w", err)
+	}
+	if err := json.Unmarshal(raw, fail); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,699 @@
+// Package github talks to the GitHub REST and GraphQL APIs directly, so
+// counts and lists work on machines without a logged-in gh CLI. Without a
+// token, pkg/forge and the GitHub-only features fall back to gh.
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultBaseURL is the public GitHub API
+const DefaultBaseURL = "https://api.github.com"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no GitHub token (set tokens.github or MC_GITHUB_TOKEN, or log in with gh)")
+
+// APIError is a non-2xx response
+type APIError = httpapi.Error
+
+// Client makes authenticated API requests. BaseURL is DefaultBaseURL
+// unless talking to GitHub Enterprise.
+type Client struct {
+	*httpapi.Client
+
+	// Rate limits as the last responses reported them (see ratelimit.go)
+	mu      sync.Mutex
+	rates   map[string]RateLimit
+	retryAt time.Time
+}
+
+// NewClient returns a client for api.github.com using token
+func NewClient(token string) *Client {
+	c := &Client{Client: httpapi.New("github", DefaultBaseURL, token, ErrNoToken)}
+	c.Header.Set("Accept", "application/vnd.github+json")
+	c.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	c.OnResponse = func(resp *http.Response) error {
+		c.noteRate(resp)
+		return nil
+	}
+	return c
+}
+
+// GetFunc fetches a REST path under a repository's API URL into out;
+// see Client.repoGet. gh can stand in for one.
+type GetFunc func(path string, out any) error
+
+// repoGet binds Get to a repository's REST path
+func (c *Client) repoGet(repo Repo) GetFunc {
+	return func(path string, out any) error {
+		return c.Get("repos/"+repo.String()+"/"+path, out)
+	}
+}
+
+// GraphQL runs a query with variables, decoding its data into out
+func (c *Client) GraphQL(query string, vars map[string]any, out any) error {
+	errs, err := c.graphQL(query, vars, out)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("github: %s", errs[0])
+	}
+	return nil
+}
+
+// graphQL runs a query, decoding whatever data came back into out and
+// returning the query's errors separately, for batched queries where one
+// failing field shouldn't discard the rest
+func (c *Client) graphQL(query string, vars map[string]any, out any) (errs []string, err error) {
+	return c.Client.GraphQLPartial("graphql", query, vars, out)
+}
+
+// Repo names a repository
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+func (r Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRemote extracts the repository from a github.com remote URL in
+// any of the forms git accepts: https://github.com/o/r.git,
+// git@github.com:o/r.git, or ssh://git@github.com/o/r
+func ParseRemote(remote string) (Repo, bool) {
+	remote = strings.TrimSpace(remote)
+	var path string
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil || !strings.EqualFold(u.Hostname(), "github.com") {
+			return Repo{}, false
+		}
+		path = u.Path
+	default:
+		// scp-like syntax: [user@]github.com:owner/repo
+		host, rest, ok := strings.Cut(remote, ":")
+		if !ok {
+			return Repo{}, false
+		}
+		if _, h, found := strings.Cut(host, "@"); found {
+			host = h
+		}
+		if !strings.EqualFold(host, "github.com") {
+			return Repo{}, false
+		}
+		path = rest
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	owner, name, ok := strings.Cut(path, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return Repo{}, false
+	}
+	return Repo{Owner: owner, Name: name}, true
+}
+
+// Counts are a repository's open issue and pull request totals, and how
+// many of them are waiting on the authenticated user
+type Counts struct {
+	Issues          int
+	PRs             int
+	Assigned        int // open issues assigned to me
+	ReviewRequested int // open pull requests asking for my review
+
+	Stars     int
+	Forks     int
+	Watchers  int
+	StarsWeek int // stars in the last 7 days, of the newest 100
+}
+
+// repoFields are the repository fields Counts reads. Only the newest 100
+// stars are fetched to count the week's.
+const repoFields = `issues(states: OPEN) { totalCount } pullRequests(states: OPEN) { totalCount } stargazerCount forkCount watchers { totalCount } stargazers(last: 100) { edges { starredAt } }`
+
+// repoCounts is the repository part of a counts query's response
+type repoCounts struct {
+	Issues         struct{ TotalCount int } `json:"issues"`
+	PullRequests   struct{ TotalCount int } `json:"pullRequests"`
+	StargazerCount int                      `json:"stargazerCount"`
+	ForkCount      int                      `json:"forkCount"`
+	Watchers       struct{ TotalCount int } `json:"watchers"`
+	Stargazers     struct {
+		Edges []struct {
+			StarredAt time.Time `json:"starredAt"`
+		} `json:"edges"`
+	} `json:"stargazers"`
+}
+
+// counts fills in Counts from the repository fields, leaving the searches
+// to the caller
+func (r *repoCounts) counts(now time.Time) Counts {
+	c := Counts{
+		Issues:   r.Issues.TotalCount,
+		PRs:      r.PullRequests.TotalCount,
+		Stars:    r.StargazerCount,
+		Forks:    r.ForkCount,
+		Watchers: r.Watchers.TotalCount,
+	}
+	weekAgo := now.AddDate(0, 0, -7)
+	for _, e := range r.Stargazers.Edges {
+		if e.StarredAt.After(weekAgo) {
+			c.StarsWeek++
+		}
+	}
+	return c
+}
+
+// Counts returns a repository's open issue and pull request counts, and
+// its star, fork, and watcher counts, in one GraphQL request
+func (c *Client) Counts(repo Repo) (Counts, error) {
+	const query = `query($owner: String!, $name: String!, $assigned: String!, $reviews: String!) {
+  repository(owner: $owner, name: $name) { ` + repoFields + ` }
+  assigned: search(query: $assigned, type: ISSUE) { issueCount }
+  reviews: search(query: $reviews, type: ISSUE) { issueCount }
+}`
+	var data struct {
+		Repository *repoCounts              `json:"repository"`
+		Assigned   struct{ IssueCount int } `json:"assigned"`
+		Reviews    struct{ IssueCount int } `json:"reviews"`
+	}
+	vars := map[string]any{
+		"owner":    repo.Owner,
+		"name":     repo.Name,
+		"assigned": "repo:" + repo.String() + " is:issue is:open assignee:@me",
+		"reviews":  "repo:" + repo.String() + " is:pr is:open user-review-requested:@me",
+	}
+	if err := c.GraphQL(query, vars, &data); err != nil {
+		return Counts{}, err
+	}
+	if data.Repository == nil {
+		return Counts{}, fmt.Errorf("github: no repository %s", repo)
+	}
+	counts := data.Repository.counts(time.Now())
+	counts.Assigned, counts.ReviewRequested = data.Assigned.IssueCount, data.Reviews.IssueCount
+	return counts, nil
+}
+
+// batchSize caps the repositories in one batched query, keeping each
+// request well under GraphQL's node and complexity limits
+const batchSize = 25
+
+// BatchCounts returns Counts for many repositories, batchSize of them per
+// GraphQL request rather than one request each. Repositories that can't be
+// read (renamed, deleted, no access) are left out of the result; an error
+// is only returned when no request succeeded.
+func (c *Client) BatchCounts(repos []Repo) (map[Repo]Counts, error) {
+	counts := make(map[Repo]Counts, len(repos))
+	var lastErr error
+	for start := 0; start < len(repos); start += batchSize {
+		batch := repos[start:min(start+batchSize, len(repos))]
+		if err := c.batchCounts(batch, counts); err != nil {
+			lastErr = err
+		}
+	}
+	if len(counts) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return counts, nil
+}
+
+// batchCounts runs one batched query, adding what it finds to counts.
+// Each repository gets its own aliases: rN for the repository, aN and vN
+// for its assigned-issue and review-request searches.
+func (c *Client) batchCounts(batch []Repo, counts map[Repo]Counts) error {
+	var params, fields []string
+	vars := make(map[string]any, 4*len(batch))
+	for i, repo := range batch {
+		params = append(params, fmt.Sprintf("$o%d: String!, $n%d: String!, $a%d: String!, $v%d: String!", i, i, i, i))
+		fields = append(fields,
+			fmt.Sprintf("  r%d: repository(owner: $o%d, name: $n%d) { %s }", i, i, i, repoFields),
+			fmt.Sprintf("  a%d: search(query: $a%d, type: ISSUE) { issueCount }", i, i),
+			fmt.Sprintf("  v%d: search(query: $v%d, type: ISSUE) { issueCount }", i, i))
+		vars[fmt.Sprintf("o%d", i)] = repo.Owner
+		vars[fmt.Sprintf("n%d", i)] = repo.Name
+		vars[fmt.Sprintf("a%d", i)] = "repo:" + repo.String() + " is:issue is:open assignee:@me"
+		vars[fmt.Sprintf("v%d", i)] = "repo:" + repo.String() + " is:pr is:open user-review-requested:@me"
+	}
+	query := "query(" + strings.Join(params, ", ") + ") {\n" + strings.Join(fields, "\n") + "\n}"
+
+	type search struct {
+		IssueCount int `json:"issueCount"`
+	}
+	var data map[string]json.RawMessage
+	errs, err := c.graphQL(query, vars, &data)
+	if err != nil {
+		return err
+	}
+
+	found := 0
+	now := time.Now()
+	for i, repo := range batch {
+		var r *repoCounts
+		if json.Unmarshal(data[fmt.Sprintf("r%d", i)], &r) != nil || r == nil {
+			continue
+		}
+		var assigned, reviews search
+		json.Unmarshal(data[fmt.Sprintf("a%d", i)], &assigned)
+		json.Unmarshal(data[fmt.Sprintf("v%d", i)], &reviews)
+		c := r.counts(now)
+		c.Assigned, c.ReviewRequested = assigned.IssueCount, reviews.IssueCount
+		counts[repo] = c
+		found++
+	}
+	if found == 0 && len(errs) > 0 {
+		return fmt.Errorf("github: %s", errs[0])
+	}
+	return nil
+}
+
+// SecurityAlerts are a repository's open security alert counts
+type SecurityAlerts struct {
+	Dependabot     int
+	CodeScanning   int
+	SecretScanning int
+}
+
+// Total sums the alerts of every kind
+func (a SecurityAlerts) Total() int {
+	return a.Dependabot + a.CodeScanning + a.SecretScanning
+}
+
+// SecurityAlerts counts a repository's open Dependabot, code scanning,
+// and secret scanning alerts. Code and secret scanning count as none when
+// they aren't enabled or the token can't see them; Dependabot alerts the
+// token can't read are an error.
+func (c *Client) SecurityAlerts(repo Repo) (SecurityAlerts, error) {
+	const query = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    vulnerabilityAlerts(states: OPEN) { totalCount }
+  }
+}`
+	var data struct {
+		Repository *struct {
+			VulnerabilityAlerts struct{ TotalCount int } `json:"vulnerabilityAlerts"`
+		} `json:"repository"`
+	}
+	if err := c.GraphQL(query, map[string]any{"owner": repo.Owner, "name": repo.Name}, &data); err != nil {
+		return SecurityAlerts{}, err
+	}
+	if data.Repository == nil {
+		return SecurityAlerts{}, fmt.Errorf("github: no repository %s", repo)
+	}
+
+	alerts := SecurityAlerts{Dependabot: data.Repository.VulnerabilityAlerts.TotalCount}
+	var open []struct {
+		Number int `json:"number"`
+	}
+	if err := c.Get("repos/"+repo.String()+"/code-scanning/alerts?state=open&per_page=100", &open); err == nil {
+		alerts.CodeScanning = len(open)
+	}
+	open = nil
+	if err := c.Get("repos/"+repo.String()+"/secret-scanning/alerts?state=open&per_page=100", &open); err == nil {
+		alerts.SecretScanning = len(open)
+	}
+	return alerts, nil
+}
+
+// Issue is an open issue as the REST API reports it
+type Issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"html_url"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// PullRequest is set on the pull requests the issues endpoint
+	// also returns
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// Issues lists a repository's open issues, newest first, leaving out
+// pull requests
+func (c *Client) Issues(repo Repo) ([]Issue, error) {
+	var all []Issue
+	if err := c.Get("repos/"+repo.String()+"/issues?state=open&per_page=100", &all); err != nil {
+		return nil, err
+	}
+	issues := all[:0]
+	for _, is := range all {
+		if is.PullRequest == nil {
+			issues = append(issues, is)
+		}
+	}
+	return issues, nil
+}
+
+// Milestone is a repository milestone with how many of its issues are
+// closed
+type Milestone struct {
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	URL          string     `json:"html_url"`
+	OpenIssues   int        `json:"open_issues"`
+	ClosedIssues int        `json:"closed_issues"`
+	DueOn        *time.Time `json:"due_on"`
+}
+
+// Total is how many issues the milestone holds
+func (ms Milestone) Total() int {
+	return ms.OpenIssues + ms.ClosedIssues
+}
+
+// Milestones lists a repository's open milestones
+func (c *Client) Milestones(repo Repo) ([]Milestone, error) {
+	var milestones []Milestone
+	err := c.Get("repos/"+repo.String()+"/milestones?state=open&sort=due_on&direction=asc&per_page=100", &milestones)
+	return milestones, err
+}
+
+// PullRequest is an open pull request with its review, mergeability,
+// and CI state, as the GraphQL API reports it
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	IsDraft bool   `json:"isDraft"`
+	Author  struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	HeadRef        string    `json:"headRefName"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	ReviewDecision string    `json:"reviewDecision"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, or ""
+	Mergeable      string    `json:"mergeable"`      // MERGEABLE, CONFLICTING, UNKNOWN
+	Commits        struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"` // SUCCESS, FAILURE, ERROR, PENDING, EXPECTED
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// Checks returns the head commit's combined CI state, "" when it has none
+func (pr PullRequest) Checks() string {
+	if len(pr.Commits.Nodes) == 0 || pr.Commits.Nodes[0].Commit.StatusCheckRollup == nil {
+		return ""
+	}
+	return pr.Commits.Nodes[0].Commit.StatusCheckRollup.State
+}
+
+// PullRequests lists a repository's open pull requests, most recently
+// updated first
+func (c *Client) PullRequests(repo Repo) ([]PullRequest, error) {
+	const query = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(states: OPEN, first: 100, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      nodes {
+        number title url isDraft headRefName updatedAt reviewDecision mergeable
+        author { login }
+        commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+      }
+    }
+  }
+}`
+	var data struct {
+		Repository *struct {
+			PullRequests struct {
+				Nodes []PullRequest `json:"nodes"`
+			} `json:"pullRequests"`
+		} `json:"repository"`
+	}
+	if err := c.GraphQL(query, map[string]any{"owner": repo.Owner, "name": repo.Name}, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository == nil {
+		return nil, fmt.Errorf("github: no repository %s", repo)
+	}
+	return data.Repository.PullRequests.Nodes, nil
+}
+
+// WorkflowRun is one GitHub Actions run as the REST API reports it
+type WorkflowRun struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Branch     string    `json:"head_branch"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	URL        string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WorkflowRuns lists a repository's newest n workflow runs, on branch
+// unless it is ""
+func (c *Client) WorkflowRuns(repo Repo, branch string, n int) ([]WorkflowRun, error) {
+	var page struct {
+		Runs []WorkflowRun `json:"workflow_runs"`
+	}
+	path := fmt.Sprintf("repos/%s/actions/runs?per_page=%d", repo, n)
+	if branch != "" {
+		path += "&branch=" + url.QueryEscape(branch)
+	}
+	err := c.Get(path, &page)
+	return page.Runs, err
+}
+
+// LatestScheduledRun reads a workflow's (by file name) newest run
+// started by its schedule through get, nil when it has none
+func LatestScheduledRun(get GetFunc, file string) (*WorkflowRun, error) {
+	var page struct {
+		Runs []WorkflowRun `json:"workflow_runs"`
+	}
+	if err := get("actions/workflows/"+url.PathEscape(file)+"/runs?event=schedule&per_page=1", &page); err != nil {
+		return nil, err
+	}
+	if len(page.Runs) == 0 {
+		return nil, nil
+	}
+	return &page.Runs[0], nil
+}
+
+// WorkflowState reads whether a workflow (by file name) is active, or
+// disabled_inactivity, which GitHub does to scheduled workflows in
+// repositories without activity for 60 days, or disabled_manually
+func WorkflowState(get GetFunc, file string) (string, error) {
+	var wf struct {
+		State string `json:"state"`
+	}
+	err := get("actions/workflows/"+url.PathEscape(file), &wf)
+	return wf.State, err
+}
+
+// Rerun starts a workflow run again, only its failed jobs if failedOnly
+func (c *Client) Rerun(repo Repo, id int64, failedOnly bool) error {
+	endpoint := "rerun"
+	if failedOnly {
+		endpoint = "rerun-failed-jobs"
+	}
+	return c.Post(fmt.Sprintf("repos/%s/actions/runs/%d/%s", repo, id, endpoint), nil, nil)
+}
+
+// DispatchWorkflow starts a workflow_dispatch workflow (by file name) on
+// ref with inputs
+func (c *Client) DispatchWorkflow(repo Repo, file, ref string, inputs map[string]string) error {
+	body := map[string]any{"ref": ref}
+	if len(inputs) > 0 {
+		body["inputs"] = inputs
+	}
+	return c.Post(fmt.Sprintf("repos/%s/actions/workflows/%s/dispatches", repo, url.PathEscape(file)), body, nil)
+}
+
+// RunStep is one step of a workflow job
+type RunStep struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// RunJob is one job of a workflow run with its steps
+type RunJob struct {
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	Steps      []RunStep `json:"steps"`
+}
+
+// RunJobs lists a workflow run's jobs
+func (c *Client) RunJobs(repo Repo, id int64) ([]RunJob, error) {
+	var page struct {
+		Jobs []RunJob `json:"jobs"`
+	}
+	err := c.Get(fmt.Sprintf("repos/%s/actions/runs/%d/jobs?per_page=100", repo, id), &page)
+	return page.Jobs, err
+}
+
+// MergePull merges a pull request with method "merge", "squash", or
+// "rebase"
+func (c *Client) MergePull(repo Repo, number int, method string) error {
+	return c.Put(fmt.Sprintf("repos/%s/pulls/%d/merge", repo, number), map[string]string{"merge_method": method}, nil)
+}
+
+// CreateIssue opens an issue and returns its page
+func (c *Client) CreateIssue(repo Repo, title, body string) (string, error) {
+	var created Issue
+	err := c.Post("repos/"+repo.String()+"/issues", map[string]string{"title": title, "body": body}, &created)
+	return created.URL, err
+}
+
+// ActionsSecret is a repository's GitHub Actions secret. Only names and
+// dates are readable; values never are.
+type ActionsSecret struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ActionsSecrets lists a repository's Actions secrets
+func (c *Client) ActionsSecrets(repo Repo) ([]ActionsSecret, error) {
+	var page struct {
+		Secrets []ActionsSecret `json:"secrets"`
+	}
+	if err := c.Get("repos/"+repo.String()+"/actions/secrets?per_page=100", &page); err != nil {
+		return nil, err
+	}
+	return page.Secrets, nil
+}
+
+// ActionsSetting is a secret or variable Actions workflows can read. Only
+// its name and when it changed are kept; variable values are dropped.
+type ActionsSetting struct {
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Scope     string    `json:"-"` // "repo", "org", or "env:<environment>"
+}
+
+// ActionsConfig is every secret and variable a repository's workflows
+// can draw on: its own, its environments', and its organization's
+type ActionsConfig struct {
+	Secrets   []ActionsSetting
+	Variables []ActionsSetting
+}
+
+// actionsPage is the most settings the variables endpoints return at once
+const actionsPage = 30
+
+// ActionsConfig reads what a repository's workflows can draw on
+func (c *Client) ActionsConfig(repo Repo) (*ActionsConfig, error) {
+	return ReadActionsConfig(c.repoGet(repo))
+}
+
+// ReadActionsConfig reads Actions settings through get, which fetches a
+// path under the repository's API URL, so gh can stand in for a Client.
+// Only the repository's own secrets are required; organization and
+// environment settings the caller can't see are left out.
+func ReadActionsConfig(get GetFunc) (*ActionsConfig, error) {
+	list := func(path, field, scope string) ([]ActionsSetting, error) {
+		var all []ActionsSetting
+		for page := 1; ; page++ {
+			var resp map[string]json.RawMessage
+			if err := get(fmt.Sprintf("%s?per_page=%d&page=%d", path, actionsPage, page), &resp); err != nil {
+				return all, err
+			}
+			var settings []ActionsSetting
+			if err := json.Unmarshal(resp[field], &settings); err != nil {
+				return all, err
+			}
+			for i := range settings {
+				settings[i].Scope = scope
+			}
+			all = append(all, settings...)
+			if len(settings) < actionsPage {
+				return all, nil
+			}
+		}
+	}
+
+	cfg := &ActionsConfig{}
+	var err error
+	if cfg.Secrets, err = list("actions/secrets", "secrets", "repo"); err != nil {
+		return nil, err
+	}
+	cfg.Variables, _ = list("actions/variables", "variables", "repo")
+	if org, err := list("actions/organization-secrets", "secrets", "org"); err == nil {
+		cfg.Secrets = append(cfg.Secrets, org...)
+	}
+	if org, err := list("actions/organization-variables", "variables", "org"); err == nil {
+		cfg.Variables = append(cfg.Variables, org...)
+	}
+
+	var envs struct {
+		Environments []struct {
+			Name string `json:"name"`
+		} `json:"environments"`
+	}
+	if err := get("environments", &envs); err == nil {
+		for _, env := range envs.Environments {
+			base := "environments/" + url.PathEscape(env.Name)
+			if found, err := list(base+"/secrets", "secrets", "env:"+env.Name); err == nil {
+				cfg.Secrets = append(cfg.Secrets, found...)
+			}
+			if found, err := list(base+"/variables", "variables", "env:"+env.Name); err == nil {
+				cfg.Variables = append(cfg.Variables, found...)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// Notification is one thread in the signed-in user's notifications
+type Notification struct {
+	ID        string    `json:"id"`
+	Reason    string    `json:"reason"` // mention, review_requested, assign, ...
+	Unread    bool      `json:"unread"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Subject   struct {
+		Title string `json:"title"`
+		URL   string `json:"url"` // API URL, empty for some types
+		Type  string `json:"type"`
+	} `json:"subject"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+}
+
+// HTMLURL returns the page the notification is about: the issue, pull
+// request, or commit, else the repository
+func (n Notification) HTMLURL() string {
+	repoURL := n.Repository.HTMLURL
+	i := strings.Index(n.Subject.URL, "/repos/")
+	if i < 0 || strings.Contains(n.Subject.URL, "/releases/") {
+		if n.Subject.Type == "Release" {
+			return repoURL + "/releases"
+		}
+		return repoURL
+	}
+	web := strings.TrimSuffix(repoURL, "/"+n.Repository.FullName)
+	path := strings.NewReplacer("/pulls/", "/pull/", "/commits/", "/commit/").Replace(n.Subject.URL[i+len("/repos/"):])
+	return web + "/" + path
+}
+
+// Notifications lists the user's unread notification threads, newest
+// first
+func (c *Client) Notifications() ([]Notification, error) {
+	var out []Notification
+	err := c.Get("notifications?per_page=50", &out)
+	return out, err
+}
+
+// MarkRead marks a notification thread as read
+func (c *Client) MarkRead(id string) error {
+	return c.Patch("notifications/threads/"+url.PathEscape(id), nil, nil)
+}
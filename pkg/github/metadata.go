@@ -0,0 +1,129 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Metadata is how a repository presents itself on GitHub: the fields an
+// open source project is found and judged by
+type Metadata struct {
+	URL           string
+	Description   string
+	Homepage      string
+	Topics        []string
+	SocialPreview bool // a custom social preview image is uploaded
+	Private       bool
+	Fork          bool
+	Archived      bool
+}
+
+// metadataFields are the repository fields Metadata reads
+const metadataFields = `url description homepageUrl isPrivate isFork isArchived usesCustomOpenGraphImage repositoryTopics(first: 20) { nodes { topic { name } } }`
+
+// repoMetadata is the repository part of a metadata query's response
+type repoMetadata struct {
+	URL                      string `json:"url"`
+	Description              string `json:"description"`
+	HomepageURL              string `json:"homepageUrl"`
+	IsPrivate                bool   `json:"isPrivate"`
+	IsFork                   bool   `json:"isFork"`
+	IsArchived               bool   `json:"isArchived"`
+	UsesCustomOpenGraphImage bool   `json:"usesCustomOpenGraphImage"`
+	RepositoryTopics         struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+}
+
+func (r *repoMetadata) metadata() Metadata {
+	md := Metadata{
+		URL:           r.URL,
+		Description:   r.Description,
+		Homepage:      r.HomepageURL,
+		SocialPreview: r.UsesCustomOpenGraphImage,
+		Private:       r.IsPrivate,
+		Fork:          r.IsFork,
+		Archived:      r.IsArchived,
+	}
+	for _, n := range r.RepositoryTopics.Nodes {
+		md.Topics = append(md.Topics, n.Topic.Name)
+	}
+	return md
+}
+
+// BatchMetadata returns Metadata for many repositories, batchSize of them
+// per GraphQL request. Like BatchCounts, repositories that can't be read
+// are left out, and an error is only returned when no request succeeded.
+func (c *Client) BatchMetadata(repos []Repo) (map[Repo]Metadata, error) {
+	found := make(map[Repo]Metadata, len(repos))
+	var lastErr error
+	for start := 0; start < len(repos); start += batchSize {
+		batch := repos[start:min(start+batchSize, len(repos))]
+		if err := c.batchMetadata(batch, found); err != nil {
+			lastErr = err
+		}
+	}
+	if len(found) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return found, nil
+}
+
+// batchMetadata runs one batched query, each repository aliased rN
+func (c *Client) batchMetadata(batch []Repo, found map[Repo]Metadata) error {
+	var params, fields []string
+	vars := make(map[string]any, 2*len(batch))
+	for i, repo := range batch {
+		params = append(params, fmt.Sprintf("$o%d: String!, $n%d: String!", i, i))
+		fields = append(fields, fmt.Sprintf("  r%d: repository(owner: $o%d, name: $n%d) { %s }", i, i, i, metadataFields))
+		vars[fmt.Sprintf("o%d", i)] = repo.Owner
+		vars[fmt.Sprintf("n%d", i)] = repo.Name
+	}
+	query := "query(" + strings.Join(params, ", ") + ") {\n" + strings.Join(fields, "\n") + "\n}"
+
+	var data map[string]json.RawMessage
+	errs, err := c.graphQL(query, vars, &data)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for i, repo := range batch {
+		var r *repoMetadata
+		if json.Unmarshal(data[fmt.Sprintf("r%d", i)], &r) != nil || r == nil {
+			continue
+		}
+		found[repo] = r.metadata()
+		n++
+	}
+	if n == 0 && len(errs) > 0 {
+		return fmt.Errorf("github: %s", errs[0])
+	}
+	return nil
+}
+
+// UpdateRepo sets a repository's description and homepage
+func (c *Client) UpdateRepo(repo Repo, description, homepage string) error {
+	return c.Patch("repos/"+repo.String(), map[string]string{
+		"description": description,
+		"homepage":    homepage,
+	}, nil)
+}
+
+// SetTopics replaces a repository's topics
+func (c *Client) SetTopics(repo Repo, topics []string) error {
+	if topics == nil {
+		topics = []string{}
+	}
+	return c.Put("repos/"+repo.String()+"/topics", map[string][]string{"names": topics}, nil)
+}
+
+// SettingsURL is a repository's settings page, where its social preview
+// image is uploaded: the API has no way to set one
+func SettingsURL(repo Repo) string {
+	return "https://github.com/" + repo.String() + "/settings"
+}
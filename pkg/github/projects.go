@@ -0,0 +1,215 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// boardPages caps how many pages of 100 items are read from a board
+const boardPages = 10
+
+// QueryFunc runs a GraphQL query, decoding its data into out; Client.GraphQL
+// is one, and gh can stand in for it
+type QueryFunc func(query string, vars map[string]any, out any) error
+
+// Board is a GitHub Projects board with its Status columns and items
+type Board struct {
+	ID      string
+	Title   string
+	URL     string
+	FieldID string        // the Status field
+	Columns []BoardColumn // in board order
+	Items   []BoardItem
+}
+
+// BoardColumn is one Status option
+type BoardColumn struct {
+	ID   string
+	Name string
+}
+
+// BoardItem is an issue, pull request, or draft on a board
+type BoardItem struct {
+	ID     string
+	Status string // column name, "" when unset
+	Type   string // Issue, PullRequest, DraftIssue
+	Number int
+	Title  string
+	URL    string
+	Repo   string // owner/name, "" for drafts
+	Closed bool
+}
+
+// Column returns a column's index by name, -1 when there's none
+func (b *Board) Column(name string) int {
+	for i, c := range b.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseBoard reads a board reference: "owner/3", or the board's URL
+// (https://github.com/orgs/acme/projects/3, .../users/me/projects/3)
+func ParseBoard(ref string) (owner string, number int, ok bool) {
+	ref = strings.TrimSuffix(strings.TrimPrefix(ref, "https://github.com/"), "/")
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) >= 4 && (parts[0] == "orgs" || parts[0] == "users") && parts[2] == "projects":
+		owner, parts = parts[1], parts[3:4]
+	case len(parts) == 2:
+		owner, parts = parts[0], parts[1:]
+	default:
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(parts[0])
+	if err != nil || owner == "" || n <= 0 {
+		return "", 0, false
+	}
+	return owner, n, true
+}
+
+const boardQuery = `query($owner: String!, $number: Int!, $after: String) {
+  %s(login: $owner) {
+    projectV2(number: $number) {
+      id
+      title
+      url
+      field(name: "Status") {
+        ... on ProjectV2SingleSelectField { id options { id name } }
+      }
+      items(first: 100, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          fieldValueByName(name: "Status") {
+            ... on ProjectV2ItemFieldSingleSelectValue { name }
+          }
+          content {
+            __typename
+            ... on Issue { number title url closed repository { nameWithOwner } }
+            ... on PullRequest { number title url closed repository { nameWithOwner } }
+            ... on DraftIssue { title }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// Board reads a user's or organization's project board
+func (c *Client) Board(owner string, number int) (*Board, error) {
+	return ReadBoard(c.GraphQL, owner, number)
+}
+
+// ReadBoard reads a project board through query, trying owner as an
+// organization and then as a user
+func ReadBoard(query QueryFunc, owner string, number int) (*Board, error) {
+	b, err := readBoard(query, "organization", owner, number)
+	if err != nil {
+		if b, err = readBoard(query, "user", owner, number); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func readBoard(query QueryFunc, kind, owner string, number int) (*Board, error) {
+	b := &Board{}
+	var after any
+	for page := 0; page < boardPages; page++ {
+		var data map[string]*struct {
+			Project *struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+				URL   string `json:"url"`
+				Field *struct {
+					ID      string `json:"id"`
+					Options []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"options"`
+				} `json:"field"`
+				Items struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID     string `json:"id"`
+						Status *struct {
+							Name string `json:"name"`
+						} `json:"fieldValueByName"`
+						Content *struct {
+							Type       string `json:"__typename"`
+							Number     int    `json:"number"`
+							Title      string `json:"title"`
+							URL        string `json:"url"`
+							Closed     bool   `json:"closed"`
+							Repository *struct {
+								NameWithOwner string `json:"nameWithOwner"`
+							} `json:"repository"`
+						} `json:"content"`
+					} `json:"nodes"`
+				} `json:"items"`
+			} `json:"projectV2"`
+		}
+		vars := map[string]any{"owner": owner, "number": number, "after": after}
+		if err := query(fmt.Sprintf(boardQuery, kind), vars, &data); err != nil {
+			return nil, err
+		}
+		o := data[kind]
+		if o == nil || o.Project == nil {
+			return nil, fmt.Errorf("github: no project %s/%d", owner, number)
+		}
+		p := o.Project
+		if page == 0 {
+			b.ID, b.Title, b.URL = p.ID, p.Title, p.URL
+			if p.Field == nil {
+				return nil, errors.New("github: the project has no Status field")
+			}
+			b.FieldID = p.Field.ID
+			for _, opt := range p.Field.Options {
+				b.Columns = append(b.Columns, BoardColumn{ID: opt.ID, Name: opt.Name})
+			}
+		}
+		for _, n := range p.Items.Nodes {
+			item := BoardItem{ID: n.ID}
+			if n.Status != nil {
+				item.Status = n.Status.Name
+			}
+			if c := n.Content; c != nil {
+				item.Type, item.Number, item.Title, item.URL, item.Closed = c.Type, c.Number, c.Title, c.URL, c.Closed
+				if c.Repository != nil {
+					item.Repo = c.Repository.NameWithOwner
+				}
+			}
+			b.Items = append(b.Items, item)
+		}
+		if !p.Items.PageInfo.HasNextPage {
+			break
+		}
+		after = p.Items.PageInfo.EndCursor
+	}
+	return b, nil
+}
+
+const moveQuery = `mutation($project: ID!, $item: ID!, $field: ID!, $option: String!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: {singleSelectOptionId: $option}}) {
+    projectV2Item { id }
+  }
+}`
+
+// MoveBoardItem puts an item in another Status column
+func (c *Client) MoveBoardItem(b *Board, itemID, columnID string) error {
+	return MoveBoardItem(c.GraphQL, b, itemID, columnID)
+}
+
+// MoveBoardItem puts an item in another Status column through query
+func MoveBoardItem(query QueryFunc, b *Board, itemID, columnID string) error {
+	var out struct{}
+	return query(moveQuery, map[string]any{"project": b.ID, "item": itemID, "field": b.FieldID, "option": columnID}, &out)
+}
@@ -0,0 +1,261 @@
+package github
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is one API resource's quota (core, graphql, search, ...) as
+// of the last response that reported it
+type RateLimit struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Seen      time.Time
+}
+
+// Low reports whether the quota is down to its last tenth
+func (r RateLimit) Low() bool {
+	return r.Limit > 0 && r.Remaining*10 < r.Limit
+}
+
+// noteRate records the rate-limit headers of a response, and how long
+// GitHub asked to wait when it refused one
+func (c *Client) noteRate(resp *http.Response) {
+	h := resp.Header
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		remaining, _ := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+		reset, _ := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+		resource := h.Get("X-RateLimit-Resource")
+		if resource == "" {
+			resource = "core"
+		}
+		if c.rates == nil {
+			c.rates = make(map[string]RateLimit)
+		}
+		c.rates[resource] = RateLimit{Resource: resource, Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0), Seen: time.Now()}
+	}
+
+	// Secondary limits come back as 403 or 429 with Retry-After
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+			c.retryAt = time.Now().Add(time.Duration(secs) * time.Second)
+		}
+	}
+}
+
+// RateLimits returns the quotas seen so far, by resource name
+func (c *Client) RateLimits() []RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	limits := make([]RateLimit, 0, len(c.rates))
+	for _, r := range c.rates {
+		limits = append(limits, r)
+	}
+	sort.Slice(limits, func(i, j int) bool { return limits[i].Resource < limits[j].Resource })
+	return limits
+}
+
+// throttle says how to pace requests at now. hold is how long nothing may
+// be sent: until a spent quota resets, or as long as GitHub asked. gap is
+// the spacing that makes the tightest low quota last until it resets:
+// the time left spread over the requests left.
+func (c *Client) throttle(now time.Time) (hold, gap time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.retryAt.After(now) {
+		hold = c.retryAt.Sub(now)
+	}
+	for _, r := range c.rates {
+		if !r.Low() || !r.Reset.After(now) {
+			continue
+		}
+		left := r.Reset.Sub(now)
+		if r.Remaining == 0 {
+			hold = max(hold, left)
+		} else {
+			gap = max(gap, left/time.Duration(r.Remaining+1))
+		}
+	}
+	return hold, gap
+}
+
+// DefaultConcurrency is how many scheduled jobs run at once
+const DefaultConcurrency = 4
+
+// Scheduler runs jobs that call GitHub (through the API or gh, which
+// shares the quota) a few at a time, highest priority first, spacing them
+// out as the rate limit runs low
+type Scheduler struct {
+	client *Client // nil without a token: only concurrency is limited
+	max    int
+
+	mu        sync.Mutex
+	queue     []*job
+	inFlight  int
+	rank      map[string]int // priority by key, lower first
+	notBefore time.Time      // backing off until
+	timer     *time.Timer
+	seq       int
+}
+
+type job struct {
+	key   string
+	seq   int // submission order
+	ready chan struct{}
+}
+
+// SchedulerStats is a snapshot of a Scheduler, for the debug view
+type SchedulerStats struct {
+	Queued       int
+	InFlight     int
+	BackoffUntil time.Time // zero when not backing off
+}
+
+// NewScheduler returns a scheduler pacing requests by client's quota
+func NewScheduler(client *Client, concurrency int) *Scheduler {
+	return &Scheduler{client: client, max: max(concurrency, 1), rank: make(map[string]int)}
+}
+
+var (
+	schedOnce    sync.Once
+	defaultSched *Scheduler
+)
+
+// DefaultScheduler returns the process-wide scheduler for Default()
+func DefaultScheduler() *Scheduler {
+	schedOnce.Do(func() {
+		defaultSched = NewScheduler(Default(), DefaultConcurrency)
+	})
+	return defaultSched
+}
+
+// Do runs fn once a slot is free and the quota allows it. key (usually a
+// project name) decides its place in the queue; see Prioritize.
+func (s *Scheduler) Do(key string, fn func()) {
+	s.mu.Lock()
+	s.seq++
+	j := &job{key: key, seq: s.seq, ready: make(chan struct{})}
+	s.queue = append(s.queue, j)
+	s.dispatch()
+	s.mu.Unlock()
+
+	<-j.ready
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.dispatch()
+		s.mu.Unlock()
+	}()
+	fn()
+}
+
+// Prioritize moves jobs for keys to the front of the queue, in the order
+// given, e.g. the selected project then the others on screen. Keys not
+// listed keep their submission order behind them.
+func (s *Scheduler) Prioritize(keys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rank = make(map[string]int, len(keys))
+	for i, k := range keys {
+		if _, ok := s.rank[k]; !ok {
+			s.rank[k] = i
+		}
+	}
+}
+
+// Stats returns the queue's current state
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := SchedulerStats{Queued: len(s.queue), InFlight: s.inFlight}
+	if s.notBefore.After(time.Now()) {
+		stats.BackoffUntil = s.notBefore
+	}
+	return stats
+}
+
+// RateLimits returns the quotas the scheduler's client has seen
+func (s *Scheduler) RateLimits() []RateLimit {
+	s.mu.Lock()
+	client := s.client
+	s.mu.Unlock()
+	if client == nil {
+		return nil
+	}
+	return client.RateLimits()
+}
+
+// setClient switches the client whose quota paces the queue
+func (s *Scheduler) setClient(client *Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client = client
+}
+
+// dispatch starts queued jobs while slots are free, or arms a timer for
+// when the backoff ends. The caller holds mu.
+func (s *Scheduler) dispatch() {
+	for s.inFlight < s.max && len(s.queue) > 0 {
+		now := time.Now()
+		if s.client != nil {
+			if hold, _ := s.client.throttle(now); hold > 0 && now.Add(hold).After(s.notBefore) {
+				// A little past the reset, so waiting clients don't all
+				// retry on the same second
+				s.notBefore = now.Add(hold + rand.N(hold/10+time.Second))
+			}
+		}
+		if wait := s.notBefore.Sub(now); wait > 0 {
+			if s.timer == nil {
+				s.timer = time.AfterFunc(wait, func() {
+					s.mu.Lock()
+					s.timer = nil
+					s.dispatch()
+					s.mu.Unlock()
+				})
+			}
+			return
+		}
+
+		next := 0
+		for i, j := range s.queue {
+			if s.before(j, s.queue[next]) {
+				next = i
+			}
+		}
+		j := s.queue[next]
+		s.queue = append(s.queue[:next], s.queue[next+1:]...)
+		s.inFlight++
+		close(j.ready)
+
+		// While the quota is low the next job waits its turn, give or take
+		// half a turn so jobs don't fire in lockstep
+		if s.client != nil {
+			if _, gap := s.client.throttle(now); gap > 0 {
+				s.notBefore = now.Add(gap/2 + rand.N(gap))
+			}
+		}
+	}
+}
+
+// before reports whether job a should run ahead of b
+func (s *Scheduler) before(a, b *job) bool {
+	ra, aok := s.rank[a.key]
+	rb, bok := s.rank[b.key]
+	switch {
+	case aok && bok && ra != rb:
+		return ra < rb
+	case aok != bok:
+		return aok
+	}
+	return a.seq < b.seq
+}
@@ -0,0 +1,150 @@
+package github
+
+import (
+	"strings"
+	"time"
+)
+
+// reviewSearch finds the open pull requests review comments can be
+// waiting on the viewer in: theirs, and those they were mentioned in,
+// commented on, or asked to review
+const reviewSearch = "is:pr is:open archived:false involves:@me"
+
+const reviewQuery = `query($q: String!) {
+  viewer { login }
+  search(query: $q, type: ISSUE, first: 50) {
+    nodes {
+      ... on PullRequest {
+        number title url
+        author { login }
+        repository { nameWithOwner }
+        reviewThreads(first: 50) {
+          nodes {
+            isResolved isOutdated path line
+            comments(last: 10) {
+              totalCount
+              nodes { author { login } body url createdAt }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// ReviewComment is an unresolved review thread waiting on the viewer,
+// as its latest comment
+type ReviewComment struct {
+	Repo     string // owner/name
+	Number   int
+	PRTitle  string
+	PRURL    string
+	Path     string
+	Line     int // 0 when the thread is on the whole file
+	Outdated bool
+	Author   string
+	Body     string
+	URL      string
+	At       time.Time
+	Comments int // in the whole thread
+}
+
+// ReviewComments lists the unresolved review threads waiting on the
+// viewer across every repository
+func (c *Client) ReviewComments() ([]ReviewComment, error) {
+	return ReadReviewComments(c.GraphQL)
+}
+
+// ReadReviewComments lists the unresolved review threads waiting on the
+// viewer through query: those on their pull requests, ones they took
+// part in, and ones mentioning them, where someone else spoke last
+func ReadReviewComments(query QueryFunc) ([]ReviewComment, error) {
+	type comment struct {
+		Author *struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Body      string    `json:"body"`
+		URL       string    `json:"url"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+	var data struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+		Search struct {
+			Nodes []struct {
+				Number int    `json:"number"`
+				Title  string `json:"title"`
+				URL    string `json:"url"`
+				Author *struct {
+					Login string `json:"login"`
+				} `json:"author"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+				ReviewThreads struct {
+					Nodes []struct {
+						IsResolved bool   `json:"isResolved"`
+						IsOutdated bool   `json:"isOutdated"`
+						Path       string `json:"path"`
+						Line       int    `json:"line"`
+						Comments   struct {
+							TotalCount int       `json:"totalCount"`
+							Nodes      []comment `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"nodes"`
+		} `json:"search"`
+	}
+	if err := query(reviewQuery, map[string]any{"q": reviewSearch}, &data); err != nil {
+		return nil, err
+	}
+
+	me := strings.ToLower(data.Viewer.Login)
+	login := func(c comment) string {
+		if c.Author == nil {
+			return "ghost"
+		}
+		return c.Author.Login
+	}
+	var out []ReviewComment
+	for _, pr := range data.Search.Nodes {
+		mine := pr.Author != nil && strings.EqualFold(pr.Author.Login, me)
+		for _, t := range pr.ReviewThreads.Nodes {
+			cs := t.Comments.Nodes
+			if t.IsResolved || len(cs) == 0 {
+				continue
+			}
+			last := cs[len(cs)-1]
+			if strings.EqualFold(login(last), me) {
+				// Answered; the ball is in their court
+				continue
+			}
+			involved := mine
+			for _, c := range cs {
+				if strings.EqualFold(login(c), me) || strings.Contains(strings.ToLower(c.Body), "@"+me) {
+					involved = true
+				}
+			}
+			if !involved {
+				continue
+			}
+			out = append(out, ReviewComment{
+				Repo:     pr.Repository.NameWithOwner,
+				Number:   pr.Number,
+				PRTitle:  pr.Title,
+				PRURL:    pr.URL,
+				Path:     t.Path,
+				Line:     t.Line,
+				Outdated: t.IsOutdated,
+				Author:   login(last),
+				Body:     last.Body,
+				URL:      last.URL,
+				At:       last.CreatedAt,
+				Comments: t.Comments.TotalCount,
+			})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// KeychainService is the service name mc's token is stored under in the
+// macOS keychain or the freedesktop secret service, with account "github":
+//
+//	security add-generic-password -s mission-control -a github -w <token>
+//	secret-tool store --label mc service mission-control account github
+const KeychainService = "mission-control"
+
+// FindToken looks for a GitHub token, in order: tokens.github or
+// MC_GITHUB_TOKEN, GITHUB_TOKEN, GH_TOKEN, the system keychain, gh's
+// stored login, and git's credential helper for github.com. It returns ""
+// when there is none.
+func FindToken(cfg *config.Config) string {
+	if cfg != nil {
+		if t := cfg.Token("github"); t != "" {
+			return t
+		}
+	}
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if t := os.Getenv(env); t != "" {
+			return t
+		}
+	}
+	for _, source := range []func() string{keychainToken, ghToken, gitCredentialToken} {
+		if t := source(); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultClient *Client
+)
+
+// Default returns a client using the token FindToken finds, looked up
+// once per process, or nil when there is none
+func Default() *Client {
+	defaultOnce.Do(func() {
+		cfg, _ := config.Load()
+		if token := FindToken(cfg); token != "" {
+			defaultClient = NewClient(token)
+		}
+	})
+	return defaultClient
+}
+
+// UseToken makes token the Default() client's, e.g. after logging in, and
+// points the default scheduler at it
+func UseToken(token string) *Client {
+	Default()
+	client := NewClient(token)
+	defaultClient = client
+	DefaultScheduler().setClient(client)
+	return client
+}
+
+// StoreToken saves a token where FindToken looks for it: the macOS
+// keychain, or the freedesktop secret service (GNOME Keyring, KWallet)
+// through secret-tool
+func StoreToken(token string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// -U replaces an existing item. -w given last prompts for the
+		// password, twice, so the token comes in on stdin rather than in
+		// an argument any process can read from ps.
+		cmd = exec.Command("security", "add-generic-password", "-U", "-s", KeychainService, "-a", "github", "-w")
+		cmd.Stdin = strings.NewReader(token + "\n" + token + "\n")
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "store", "--label", "mission-control GitHub token", "service", KeychainService, "account", "github")
+		cmd.Stdin = strings.NewReader(token)
+	default:
+		return errors.New("no supported keychain on " + runtime.GOOS + "; set tokens.github in ~/.hustlemc/config.json")
+	}
+	if _, err := exec.LookPath(cmd.Path); err != nil {
+		return fmt.Errorf("%s not found; install it or set tokens.github in ~/.hustlemc/config.json", filepath.Base(cmd.Path))
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// SaveToken stores a token from logging in: in the keychain when there is
+// one, otherwise as tokens.github in the config file, which only its
+// owner can read. It returns where the token went.
+func SaveToken(token string) (string, error) {
+	keychainErr := StoreToken(token)
+	if keychainErr == nil {
+		return "the keychain", nil
+	}
+	err := config.Update(func(cfg *config.Config) {
+		if cfg.Tokens == nil {
+			cfg.Tokens = make(map[string]string)
+		}
+		cfg.Tokens["github"] = token
+	})
+	if err != nil {
+		return "", fmt.Errorf("keychain: %v; config: %w", keychainErr, err)
+	}
+	return config.Path(), nil
+}
+
+func keychainToken() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return output("security", "find-generic-password", "-s", KeychainService, "-a", "github", "-w")
+	case "linux", "freebsd", "openbsd":
+		return output("secret-tool", "lookup", "service", KeychainService, "account", "github")
+	}
+	return ""
+}
+
+func ghToken() string {
+	return output("gh", "auth", "token", "--hostname", "github.com")
+}
+
+// gitCredentialToken asks git's credential helper (osxkeychain,
+// credential-manager, libsecret) for the password it would send to
+// github.com, which is a token for HTTPS remotes
+func gitCredentialToken() string {
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=github.com\n\n")
+	// Never prompt: no helper means no token
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=true", "SSH_ASKPASS=true")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if v, ok := strings.CutPrefix(line, "password="); ok {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// output runs a command, returning its trimmed stdout or "" on failure
+func output(name string, args ...string) string {
+	if _, err := exec.LookPath(name); err != nil {
+		return ""
+	}
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
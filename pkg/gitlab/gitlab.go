@@ -0,0 +1,346 @@
+// Package gitlab talks to the GitLab REST API, so projects hosted on
+// gitlab.com or a self-managed GitLab show the same issue, merge request,
+// and pipeline stats as GitHub ones.
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultHost is the public GitLab
+const DefaultHost = "gitlab.com"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no GitLab token (set tokens.gitlab, MC_GITLAB_TOKEN, or GITLAB_TOKEN)")
+
+// Client makes authenticated API requests to one GitLab host, whose
+// BaseURL is https://<host>/api/v4
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for host's API using token
+func NewClient(host, token string) *Client {
+	return &Client{httpapi.New("gitlab", "https://"+host+"/api/v4", token, ErrNoToken)}
+}
+
+// FindToken looks for a GitLab token, in order: tokens.gitlab or
+// MC_GITLAB_TOKEN, GITLAB_TOKEN, and GL_TOKEN. It returns "" when there
+// is none.
+func FindToken(cfg *config.Config) string {
+	if cfg != nil {
+		if t := cfg.Token("gitlab"); t != "" {
+			return t
+		}
+	}
+	for _, env := range []string{"GITLAB_TOKEN", "GL_TOKEN"} {
+		if t := os.Getenv(env); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+var (
+	clientsMu sync.Mutex
+	clients   = make(map[string]*Client)
+	token     *string
+)
+
+// Default returns a client for host using the token FindToken finds,
+// looked up once per process, or nil when there is none
+func Default(host string) *Client {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if token == nil {
+		cfg, _ := config.Load()
+		t := FindToken(cfg)
+		token = &t
+	}
+	if *token == "" {
+		return nil
+	}
+	if clients[host] == nil {
+		clients[host] = NewClient(host, *token)
+	}
+	return clients[host]
+}
+
+// Count returns how many items a list path has in all, from the
+// X-Total header of a one-item page
+func (c *Client) Count(path string) (int, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	header, err := c.Do("GET", path+sep+"per_page=1", nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	total := header.Get("X-Total")
+	if total == "" {
+		// Left out past 10,000 items
+		return 10000, nil
+	}
+	return strconv.Atoi(total)
+}
+
+// Project names a GitLab project: its host and full path, which may
+// include subgroups ("group/sub/name")
+type Project struct {
+	Host string
+	Path string
+}
+
+func (p Project) String() string {
+	return p.Path
+}
+
+// id is the project's URL-encoded path, which the API takes in place of
+// its numeric ID
+func (p Project) id() string {
+	return "projects/" + url.PathEscape(p.Path)
+}
+
+// WebURL is the project's page
+func (p Project) WebURL() string {
+	return "https://" + p.Host + "/" + p.Path
+}
+
+// IsHost reports whether a remote host is a GitLab: gitlab.com, or a
+// self-managed instance named gitlab.<domain>
+func IsHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == DefaultHost || strings.HasPrefix(host, "gitlab.")
+}
+
+// ParseRemote extracts the project from a GitLab remote URL in any of the
+// forms git accepts: https://gitlab.com/g/p.git, git@gitlab.com:g/sub/p.git,
+// or ssh://git@gitlab.example.com/g/p
+func ParseRemote(remote string) (Project, bool) {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Project{}, false
+		}
+		host, path = u.Hostname(), u.Path
+	default:
+		// scp-like syntax: [user@]gitlab.com:group/project
+		h, rest, ok := strings.Cut(remote, ":")
+		if !ok {
+			return Project{}, false
+		}
+		if _, after, found := strings.Cut(h, "@"); found {
+			h = after
+		}
+		host, path = h, rest
+	}
+	if !IsHost(host) {
+		return Project{}, false
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if !strings.Contains(path, "/") || strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return Project{}, false
+	}
+	return Project{Host: strings.ToLower(host), Path: path}, true
+}
+
+// Counts are a project's open issue and merge request totals, how many
+// are waiting on the authenticated user, and its stars and forks
+type Counts struct {
+	Issues          int
+	MergeRequests   int
+	Assigned        int
+	ReviewRequested int
+	Stars           int
+	Forks           int
+}
+
+// user returns the authenticated user's ID
+func (c *Client) user() (id int, err error) {
+	var u struct {
+		ID int `json:"id"`
+	}
+	if err := c.Get("user", &u); err != nil {
+		return 0, err
+	}
+	return u.ID, nil
+}
+
+// Counts reads a project's Counts, one request each
+func (c *Client) Counts(p Project) (Counts, error) {
+	var counts Counts
+	var info struct {
+		Stars int `json:"star_count"`
+		Forks int `json:"forks_count"`
+	}
+	if err := c.Get(p.id(), &info); err != nil {
+		return counts, err
+	}
+	counts.Stars, counts.Forks = info.Stars, info.Forks
+
+	var err error
+	if counts.Issues, err = c.Count(p.id() + "/issues?state=opened"); err != nil {
+		return counts, err
+	}
+	if counts.MergeRequests, err = c.Count(p.id() + "/merge_requests?state=opened"); err != nil {
+		return counts, err
+	}
+	if me, err := c.user(); err == nil {
+		counts.Assigned, _ = c.Count(fmt.Sprintf("%s/issues?state=opened&assignee_id=%d", p.id(), me))
+		counts.ReviewRequested, _ = c.Count(fmt.Sprintf("%s/merge_requests?state=opened&reviewer_id=%d", p.id(), me))
+	}
+	return counts, nil
+}
+
+// User is an issue or merge request author
+type User struct {
+	Username string `json:"username"`
+}
+
+// Issue is an open issue
+type Issue struct {
+	IID       int       `json:"iid"`
+	Title     string    `json:"title"`
+	WebURL    string    `json:"web_url"`
+	Labels    []string  `json:"labels"`
+	Author    User      `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Issues lists a project's open issues, newest first
+func (c *Client) Issues(p Project) ([]Issue, error) {
+	var issues []Issue
+	err := c.Get(p.id()+"/issues?state=opened&order_by=created_at&sort=desc&per_page=100", &issues)
+	return issues, err
+}
+
+// MergeRequest is an open merge request
+type MergeRequest struct {
+	IID       int       `json:"iid"`
+	Title     string    `json:"title"`
+	WebURL    string    `json:"web_url"`
+	Draft     bool      `json:"draft"`
+	Source    string    `json:"source_branch"`
+	Author    User      `json:"author"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// MergeStatus says what, if anything, blocks merging: mergeable,
+	// conflict, not_approved, ci_must_pass, ci_still_running, checking, ...
+	MergeStatus string `json:"detailed_merge_status"`
+}
+
+// MergeRequests lists a project's open merge requests, recently updated
+// first
+func (c *Client) MergeRequests(p Project) ([]MergeRequest, error) {
+	var mrs []MergeRequest
+	err := c.Get(p.id()+"/merge_requests?state=opened&order_by=updated_at&sort=desc&per_page=100", &mrs)
+	return mrs, err
+}
+
+// Pipeline is one CI pipeline run
+type Pipeline struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"` // "" unless the pipeline sets workflow:name
+	Ref       string    `json:"ref"`
+	Status    string    `json:"status"` // created, pending, running, success, failed, canceled, skipped, manual, scheduled, ...
+	Source    string    `json:"source"` // push, schedule, merge_request_event, web, ...
+	WebURL    string    `json:"web_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Pipelines lists a project's newest n pipelines, on ref unless it is ""
+func (c *Client) Pipelines(p Project, ref string, n int) ([]Pipeline, error) {
+	path := fmt.Sprintf("%s/pipelines?per_page=%d", p.id(), n)
+	if ref != "" {
+		path += "&ref=" + url.QueryEscape(ref)
+	}
+	var pipelines []Pipeline
+	err := c.Get(path, &pipelines)
+	return pipelines, err
+}
+
+// Release is a published release
+type Release struct {
+	Tag        string    `json:"tag_name"`
+	Name       string    `json:"name"`
+	ReleasedAt time.Time `json:"released_at"`
+	Upcoming   bool      `json:"upcoming_release"`
+	Links      struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+// Releases lists a project's newest n releases
+func (c *Client) Releases(p Project, n int) ([]Release, error) {
+	var releases []Release
+	err := c.Get(fmt.Sprintf("%s/releases?per_page=%d", p.id(), n), &releases)
+	return releases, err
+}
+
+// Todo is a pending item on the authenticated user's to-do list, GitLab's
+// notifications
+type Todo struct {
+	ID         int    `json:"id"`
+	ActionName string `json:"action_name"` // mentioned, assigned, review_requested, ...
+	TargetType string `json:"target_type"` // Issue, MergeRequest, Commit, ...
+	TargetURL  string `json:"target_url"`
+	Body       string `json:"body"`
+	Target     struct {
+		Title string `json:"title"`
+	} `json:"target"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	} `json:"project"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Todos lists the authenticated user's pending to-dos, newest first
+func (c *Client) Todos() ([]Todo, error) {
+	var todos []Todo
+	err := c.Get("todos?state=pending&per_page=50", &todos)
+	return todos, err
+}
+
+// MarkTodoDone marks a to-do as done
+func (c *Client) MarkTodoDone(id int) error {
+	return c.Post(fmt.Sprintf("todos/%d/mark_as_done", id), nil, nil)
+}
+
+// Merge merges a merge request, squashing its commits if squash. How it
+// lands (merge commit, fast-forward) is the project's setting.
+func (c *Client) Merge(p Project, iid int, squash bool) error {
+	return c.Put(fmt.Sprintf("%s/merge_requests/%d/merge", p.id(), iid), map[string]bool{"squash": squash}, nil)
+}
+
+// RetryPipeline runs a pipeline's failed and canceled jobs again
+func (c *Client) RetryPipeline(p Project, id int64) error {
+	return c.Post(fmt.Sprintf("%s/pipelines/%d/retry", p.id(), id), nil, nil)
+}
+
+// RunPipeline starts a new pipeline on the tip of ref
+func (c *Client) RunPipeline(p Project, ref string) error {
+	return c.Post(p.id()+"/pipeline?ref="+url.QueryEscape(ref), nil, nil)
+}
+
+// CreateIssue opens an issue and returns its page
+func (c *Client) CreateIssue(p Project, title, description string) (string, error) {
+	var created Issue
+	err := c.Post(p.id()+"/issues", map[string]string{"title": title, "description": description}, &created)
+	return created.WebURL, err
+}
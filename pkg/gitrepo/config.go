@@ -0,0 +1,85 @@
+package gitrepo
+
+import (
+	"os"
+	"sort"
+
+	"github.com/go-git/go-git/v5/plumbing/format/config"
+)
+
+// Config is a parsed git config file. Section and key names are
+// case-insensitive; subsection names are case-sensitive, as in git.
+type Config struct {
+	raw *config.Config
+}
+
+// readConfig parses a git config file. Includes are not followed.
+func readConfig(path string) (*Config, error) {
+	cfg := &Config{raw: config.New()}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return cfg, err
+	}
+	defer f.Close()
+
+	return cfg, config.NewDecoder(f).Decode(cfg.raw)
+}
+
+// options returns a section's or subsection's options, without adding
+// either to the config the way config.Section does
+func (c *Config) options(section, subsection string) config.Options {
+	if c == nil {
+		return nil
+	}
+	for _, s := range c.raw.Sections {
+		if !s.IsName(section) {
+			continue
+		}
+		if subsection == "" {
+			return s.Options
+		}
+		for _, sub := range s.Subsections {
+			if sub.IsName(subsection) {
+				return sub.Options
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns the last value for a key, or "" if unset
+func (c *Config) Get(section, subsection, key string) string {
+	return c.options(section, subsection).Get(key)
+}
+
+// GetAll returns every value for a multi-valued key
+func (c *Config) GetAll(section, subsection, key string) []string {
+	vals := c.options(section, subsection).GetAll(key)
+	if len(vals) == 0 {
+		return nil
+	}
+	return vals
+}
+
+// Subsections lists subsection names of a section (e.g. remote names)
+func (c *Config) Subsections(section string) []string {
+	if c == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var subs []string
+	for _, s := range c.raw.Sections {
+		if !s.IsName(section) {
+			continue
+		}
+		for _, sub := range s.Subsections {
+			if !seen[sub.Name] {
+				seen[sub.Name] = true
+				subs = append(subs, sub.Name)
+			}
+		}
+	}
+	sort.Strings(subs)
+	return subs
+}
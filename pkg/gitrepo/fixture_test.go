@@ -0,0 +1,109 @@
+package gitrepo
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fixture is a scratch repository built with the git CLI, to check what
+// the package reads against what git itself says
+type fixture struct {
+	t   *testing.T
+	dir string
+	env []string
+}
+
+// newFixture runs git init in a temporary directory, with git's global
+// and system config shut out so the host's settings can't leak in
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	f := &fixture{t: t, dir: t.TempDir(), env: append(os.Environ(),
+		"HOME="+home,
+		"GIT_CONFIG_GLOBAL="+filepath.Join(home, ".gitconfig"),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)}
+	f.git("init", "-q", "-b", "main")
+	return f
+}
+
+// git runs a git command in the fixture and returns its trimmed output
+func (f *fixture) git(args ...string) string {
+	f.t.Helper()
+	return f.gitEnv(nil, args...)
+}
+
+func (f *fixture) gitEnv(env []string, args ...string) string {
+	f.t.Helper()
+	out, err := f.run(env, args...)
+	if err != nil {
+		f.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return out
+}
+
+// tryGit is git for commands expected to fail, such as a conflicting merge
+func (f *fixture) tryGit(args ...string) (string, error) {
+	return f.run(nil, args...)
+}
+
+func (f *fixture) run(env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = f.dir
+	cmd.Env = append(f.env, env...)
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// write creates or overwrites a worktree file, making its directories
+func (f *fixture) write(path, content string) {
+	f.t.Helper()
+	full := filepath.Join(f.dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		f.t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// commit commits everything at a fixed time (Unix seconds), so commit
+// order can be arranged, and returns the new commit's hash
+func (f *fixture) commit(msg string, when int64) Hash {
+	f.t.Helper()
+	date := "@" + strconv.FormatInt(when, 10) + " +0000"
+	f.git("add", "-A")
+	f.gitEnv([]string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date}, "commit", "-q", "--allow-empty", "-m", msg)
+	return f.hash("HEAD")
+}
+
+// hash resolves a revision with git rev-parse
+func (f *fixture) hash(rev string) Hash {
+	f.t.Helper()
+	h, err := ParseHash(f.git("rev-parse", rev))
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return h
+}
+
+// open opens the fixture with this package
+func (f *fixture) open() *Repo {
+	f.t.Helper()
+	r, err := Open(f.dir)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.t.Cleanup(func() { r.Close() })
+	return r
+}
@@ -0,0 +1,68 @@
+package gitrepo
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// ignoreStack holds the patterns in effect with git's precedence: later
+// patterns (deeper .gitignore files, later lines) override earlier ones.
+type ignoreStack []gitignore.Pattern
+
+// readIgnoreFile parses a gitignore-format file. base is the directory the
+// patterns are relative to.
+func readIgnoreFile(file, base string) []gitignore.Pattern {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var domain []string
+	if base != "" {
+		domain = strings.Split(base, "/")
+	}
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+	return patterns
+}
+
+// ignored reports whether rel is excluded by the stack
+func (s ignoreStack) ignored(rel string, isDir bool) bool {
+	return gitignore.NewMatcher(s).Match(strings.Split(rel, "/"), isDir)
+}
+
+// baseIgnores returns the repo-wide exclude sources: core.excludesFile
+// (or the XDG default) and .git/info/exclude.
+func (r *Repo) baseIgnores() ignoreStack {
+	var stack ignoreStack
+
+	excludes := r.config.Get("core", "", "excludesfile")
+	if excludes == "" {
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			excludes = filepath.Join(xdg, "git", "ignore")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			excludes = filepath.Join(home, ".config", "git", "ignore")
+		}
+	} else if strings.HasPrefix(excludes, "~/") {
+		home, _ := os.UserHomeDir()
+		excludes = filepath.Join(home, excludes[2:])
+	}
+	if excludes != "" {
+		stack = append(stack, readIgnoreFile(excludes, "")...)
+	}
+
+	stack = append(stack, readIgnoreFile(filepath.Join(r.CommonDir, "info", "exclude"), "")...)
+	return stack
+}
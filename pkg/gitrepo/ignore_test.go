@@ -0,0 +1,71 @@
+package gitrepo
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreStack(t *testing.T) {
+	f := newFixture(t)
+	f.write(".gitignore", strings.Join([]string{
+		"# comment",
+		"*.log",
+		"!keep.log",
+		"/build",
+		"doc/*.txt",
+		"**/tmp",
+		"vendor/**",
+		"cache/",
+		`\!bang`,
+		"trailing   ",
+	}, "\n")+"\n")
+	f.write("sub/.gitignore", "!*.log\nlocal\n")
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{path: "app.log", want: true},
+		{path: "keep.log", want: false},          // negated
+		{path: "deep/keep.log", want: false},     // negation matches the basename too
+		{path: "sub/app.log", want: false},       // a deeper list overrides
+		{path: "build", isDir: true, want: true}, // anchored to the root
+		{path: "sub/build", isDir: true, want: false},
+		{path: "doc/a.txt", want: true},      // a slash anchors the pattern
+		{path: "doc/sub/a.txt", want: false}, // * stops at a slash
+		{path: "x/doc/a.txt", want: false},
+		{path: "tmp", isDir: true, want: true},     // **/ matches no directories
+		{path: "a/b/tmp", isDir: true, want: true}, // or several
+		{path: "vendor/x/y.go", want: true},        // /** matches everything inside
+		{path: "cache", isDir: true, want: true},
+		{path: "sub/cache", want: false}, // a trailing slash matches directories only
+		{path: "!bang", want: true},
+		{path: "trailing", want: true},
+		{path: "sub/local", want: true},
+		{path: "local", want: false}, // sub's patterns stay in sub
+		{path: "other.go", want: false},
+	}
+
+	stack := append(ignoreStack(readIgnoreFile(filepath.Join(f.dir, ".gitignore"), "")),
+		readIgnoreFile(filepath.Join(f.dir, "sub", ".gitignore"), "sub")...)
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := stack.ignored(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("ignored(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+			}
+
+			// Hold the table to what git itself decides
+			if tt.isDir {
+				f.write(tt.path+"/.keep", "")
+			} else {
+				f.write(tt.path, "")
+			}
+			_, err := f.tryGit("check-ignore", "-q", tt.path)
+			if gitIgnored := err == nil; gitIgnored != tt.want {
+				t.Errorf("git check-ignore %s: ignored = %v, want %v", tt.path, gitIgnored, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+)
+
+// IndexEntry is one path in the staging area
+type IndexEntry struct {
+	Path         string
+	Hash         Hash
+	Mode         uint32
+	Size         uint32
+	MTime        time.Time
+	Stage        int  // 0 normally; 1-3 for unmerged conflict entries
+	SkipWorktree bool // sparse checkout: not present in the worktree
+	IntentToAdd  bool // git add -N
+}
+
+// Index is a parsed .git/index
+type Index struct {
+	Entries []IndexEntry
+	ModTime time.Time // mtime of the index file itself (for racy-clean checks)
+}
+
+// ReadIndex parses the repository's index file. A missing index (fresh
+// repo) returns an empty Index.
+func (r *Repo) ReadIndex() (*Index, error) {
+	path := filepath.Join(r.GitDir, "index")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Index{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := parseIndex(data)
+	if err != nil {
+		return nil, err
+	}
+	idx.ModTime = info.ModTime()
+	return idx, nil
+}
+
+func parseIndex(data []byte) (*Index, error) {
+	var gi index.Index
+	if err := index.NewDecoder(bytes.NewReader(data)).Decode(&gi); err != nil {
+		if errors.Is(err, index.ErrUnsupportedVersion) || errors.Is(err, index.ErrUnknownExtension) {
+			// Including split index and sparse directory entries
+			return nil, ErrUnsupported
+		}
+		return nil, fmt.Errorf("gitrepo: %w", err)
+	}
+
+	idx := &Index{Entries: make([]IndexEntry, len(gi.Entries))}
+	for i, e := range gi.Entries {
+		idx.Entries[i] = IndexEntry{
+			Path:         e.Name,
+			Hash:         e.Hash,
+			Mode:         uint32(e.Mode),
+			Size:         e.Size,
+			MTime:        e.ModifiedAt,
+			Stage:        int(e.Stage),
+			SkipWorktree: e.SkipWorktree,
+			IntentToAdd:  e.IntentToAdd,
+		}
+	}
+	return idx, nil
+}
@@ -0,0 +1,145 @@
+package gitrepo
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// lsFiles renders entries the way git ls-files -s does
+func lsFiles(entries []IndexEntry) string {
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = fmt.Sprintf("%06o %s %d\t%s", e.Mode, e.Hash, e.Stage, e.Path)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestParseIndex(t *testing.T) {
+	tests := []struct {
+		version int
+		flags   bool // mark entries intent-to-add and skip-worktree
+	}{
+		// git writes v3 only when an entry needs its extended flags
+		{version: 2},
+		{version: 3, flags: true},
+		{version: 4},
+		{version: 4, flags: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("v%d flags=%v", tt.version, tt.flags), func(t *testing.T) {
+			f := newFixture(t)
+			// Shared prefixes exercise v4's path compression
+			for _, path := range []string{"README.md", "cmd/mc/main.go", "cmd/mc/main_test.go", "cmd/other.go", "pkg/a/a.go", "pkg/ab.go", "run.sh"} {
+				f.write(path, "// "+path+"\n")
+			}
+			f.git("update-index", "--chmod=+x", "--add", "run.sh")
+			f.commit("initial", 1700000000)
+			if tt.flags {
+				f.write("new.txt", "not added yet\n")
+				f.git("add", "-N", "new.txt")
+				f.git("update-index", "--skip-worktree", "pkg/ab.go")
+			}
+			f.git("update-index", "--index-version", fmt.Sprint(tt.version))
+
+			data, err := os.ReadFile(filepath.Join(f.dir, ".git", "index"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := binary.BigEndian.Uint32(data[4:8]); v != uint32(tt.version) {
+				t.Fatalf("git wrote index version %d, want %d", v, tt.version)
+			}
+
+			idx, err := parseIndex(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := lsFiles(idx.Entries), f.git("ls-files", "-s"); got != want {
+				t.Errorf("entries:\n%s\nwant (git ls-files -s):\n%s", got, want)
+			}
+			for _, e := range idx.Entries {
+				if want := tt.flags && e.Path == "new.txt"; e.IntentToAdd != want {
+					t.Errorf("%s: IntentToAdd = %v, want %v", e.Path, e.IntentToAdd, want)
+				}
+				if want := tt.flags && e.Path == "pkg/ab.go"; e.SkipWorktree != want {
+					t.Errorf("%s: SkipWorktree = %v, want %v", e.Path, e.SkipWorktree, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseIndexConflicts(t *testing.T) {
+	f := newFixture(t)
+	f.write("file.txt", "base\n")
+	f.commit("base", 1700000000)
+	f.git("checkout", "-q", "-b", "other")
+	f.write("file.txt", "theirs\n")
+	f.commit("theirs", 1700000060)
+	f.git("checkout", "-q", "main")
+	f.write("file.txt", "ours\n")
+	f.commit("ours", 1700000120)
+	if out, err := f.tryGit("merge", "-q", "other"); err == nil {
+		t.Fatalf("merge succeeded, want a conflict:\n%s", out)
+	}
+
+	idx, err := f.open().ReadIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lsFiles(idx.Entries), f.git("ls-files", "-s"); got != want {
+		t.Errorf("entries:\n%s\nwant (git ls-files -s):\n%s", got, want)
+	}
+	var stages []int
+	for _, e := range idx.Entries {
+		stages = append(stages, e.Stage)
+	}
+	if fmt.Sprint(stages) != "[1 2 3]" {
+		t.Errorf("stages = %v, want [1 2 3]", stages)
+	}
+}
+
+func TestParseIndexErrors(t *testing.T) {
+	header := func(version, count uint32) []byte {
+		b := append([]byte("DIRC"), make([]byte, 8)...)
+		binary.BigEndian.PutUint32(b[4:], version)
+		binary.BigEndian.PutUint32(b[8:], count)
+		return b
+	}
+	// index signs off with the SHA-1 of everything before it
+	index := func(parts ...string) []byte {
+		b := []byte(strings.Join(parts, ""))
+		sum := sha1.Sum(b)
+		return append(b, sum[:]...)
+	}
+
+	tests := []struct {
+		name        string
+		data        []byte
+		unsupported bool
+	}{
+		{name: "bad signature", data: index("DIRX\x00\x00\x00\x02\x00\x00\x00\x00")},
+		{name: "too short", data: []byte("DIRC")},
+		{name: "version 1", data: index(string(header(1, 0))), unsupported: true},
+		{name: "version 5", data: index(string(header(5, 0))), unsupported: true},
+		{name: "truncated entry", data: index(string(header(2, 1)), string(make([]byte, 30)))},
+		{name: "bad checksum", data: append(header(2, 0), make([]byte, 20)...)},
+		{name: "split index", data: index(string(header(2, 0)), "link\x00\x00\x00\x00"), unsupported: true},
+		{name: "sparse directories", data: index(string(header(2, 0)), "sdir\x00\x00\x00\x00"), unsupported: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIndex(tt.data)
+			if err == nil {
+				t.Fatal("parseIndex succeeded, want an error")
+			}
+			if (err == ErrUnsupported) != tt.unsupported {
+				t.Errorf("parseIndex error = %v, want ErrUnsupported: %v", err, tt.unsupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package gitrepo
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// Log returns up to n commits reachable from from, newest first by
+// committer time (the order plain `git log` uses).
+func (r *Repo) Log(from Hash, n int) ([]*Commit, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	var out []*Commit
+	err := r.Walk(from, func(c *Commit) bool {
+		out = append(out, c)
+		return len(out) < n
+	})
+	return out, err
+}
+
+// Walk visits commits reachable from from, newest first by committer
+// time, until fn returns false.
+func (r *Repo) Walk(from Hash, fn func(*Commit) bool) error {
+	if from.IsZero() {
+		return nil
+	}
+
+	start, err := r.ReadCommit(from)
+	if err != nil {
+		return err
+	}
+
+	seen := map[Hash]bool{from: true}
+	q := &commitQueue{start}
+
+	for q.Len() > 0 {
+		c := heap.Pop(q).(*Commit)
+		if !fn(c) {
+			return nil
+		}
+
+		for _, p := range c.Parents {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			parent, err := r.ReadCommit(p)
+			if err != nil {
+				// Shallow clones end at missing parents
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return err
+			}
+			heap.Push(q, parent)
+		}
+	}
+	return nil
+}
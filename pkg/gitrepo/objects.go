@@ -0,0 +1,99 @@
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Commit is a parsed commit object
+type Commit struct {
+	Hash    Hash
+	Tree    Hash
+	Parents []Hash
+	Author  string
+	Email   string
+	When    time.Time // committer time
+	Message string
+}
+
+// Subject returns the first line of the commit message
+func (c *Commit) Subject() string {
+	subject, _, _ := bytes.Cut([]byte(c.Message), []byte("\n"))
+	return string(subject)
+}
+
+// TreeEntry is one entry of a tree object
+type TreeEntry struct {
+	Mode uint32
+	Name string
+	Hash Hash
+}
+
+// IsDir reports whether the entry is a subtree
+func (e TreeEntry) IsDir() bool {
+	return filemode.FileMode(e.Mode) == filemode.Dir
+}
+
+// ReadCommit reads and parses a commit object
+func (r *Repo) ReadCommit(h Hash) (*Commit, error) {
+	c, err := object.GetCommit(r.storage, h)
+	if err != nil {
+		return nil, objectError(h, err)
+	}
+	return &Commit{
+		Hash:    h,
+		Tree:    c.TreeHash,
+		Parents: c.ParentHashes,
+		Author:  c.Author.Name,
+		Email:   c.Author.Email,
+		When:    c.Committer.When,
+		Message: c.Message,
+	}, nil
+}
+
+// ReadTree reads and parses a tree object
+func (r *Repo) ReadTree(h Hash) ([]TreeEntry, error) {
+	t, err := object.GetTree(r.storage, h)
+	if err != nil {
+		return nil, objectError(h, err)
+	}
+	entries := make([]TreeEntry, len(t.Entries))
+	for i, e := range t.Entries {
+		entries[i] = TreeEntry{Mode: uint32(e.Mode), Name: e.Name, Hash: e.Hash}
+	}
+	return entries, nil
+}
+
+// objectError maps go-git's missing object error to ErrNotFound, which
+// also covers an object of the wrong type
+func objectError(h Hash, err error) error {
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return fmt.Errorf("%w: object %s", ErrNotFound, h)
+	}
+	return err
+}
+
+// flattenTree maps every blob path under a tree to its entry
+func (r *Repo) flattenTree(h Hash, prefix string, out map[string]TreeEntry) error {
+	entries, err := r.ReadTree(h)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		path := prefix + e.Name
+		if e.IsDir() {
+			if err := r.flattenTree(e.Hash, path+"/", out); err != nil {
+				return err
+			}
+			continue
+		}
+		out[path] = e
+	}
+	return nil
+}
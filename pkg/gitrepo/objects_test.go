@@ -0,0 +1,58 @@
+package gitrepo
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPackedDeltas reads commits and trees out of a pack git delta-
+// compressed, checking each against git rev-parse
+func TestPackedDeltas(t *testing.T) {
+	f := newFixture(t)
+	content := strings.Repeat("a line that stays the same in every version\n", 200)
+	var commits []Hash
+	for i := 0; i < 5; i++ {
+		content += "line " + strings.Repeat("x", i) + "\n"
+		f.write("file.txt", content)
+		f.write("dir/other.txt", "version "+strings.Repeat("y", i))
+		commits = append(commits, f.commit("version", int64(1700000000+i*60)))
+	}
+	f.git("repack", "-adq", "--depth=50", "--window=10")
+	f.git("prune-packed")
+	if verify := f.git("count-objects", "-v"); !strings.Contains(verify, "count: 0") {
+		t.Fatalf("objects left loose after repack:\n%s", verify)
+	}
+	packs, _ := filepath.Glob(filepath.Join(f.dir, ".git", "objects", "pack", "*.idx"))
+	if len(packs) != 1 {
+		t.Fatalf("got %d packs, want 1", len(packs))
+	}
+	if verify := f.git("verify-pack", "-v", packs[0]); !strings.Contains(verify, "chain length") {
+		t.Fatalf("git stored no deltas:\n%s", verify)
+	}
+
+	r := f.open()
+	for _, h := range commits {
+		c, err := r.ReadCommit(h)
+		if err != nil {
+			t.Fatalf("ReadCommit(%s): %v", h, err)
+		}
+		if want := f.hash(h.String() + "^{tree}"); c.Tree != want {
+			t.Errorf("commit %s tree = %s, want %s", h, c.Tree, want)
+		}
+
+		tree := make(map[string]TreeEntry)
+		if err := r.flattenTree(c.Tree, "", tree); err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range []string{"file.txt", "dir/other.txt"} {
+			e, ok := tree[path]
+			if !ok {
+				t.Fatalf("commit %s: %s missing from tree", h, path)
+			}
+			if want := f.hash(h.String() + ":" + path); e.Hash != want {
+				t.Errorf("commit %s: %s = %s, want %s", h, path, e.Hash, want)
+			}
+		}
+	}
+}
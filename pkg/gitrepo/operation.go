@@ -0,0 +1,42 @@
+package gitrepo
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Operations that can be left in progress, as git status names them
+const (
+	OpRebase     = "rebase"
+	OpAm         = "am"
+	OpMerge      = "merge"
+	OpCherryPick = "cherry-pick"
+	OpRevert     = "revert"
+)
+
+// Operation reports which multi-step operation is in progress in this
+// worktree, checking in the same order git status does. It returns ""
+// when none is.
+func (r *Repo) Operation() string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(r.GitDir, name))
+		return err == nil
+	}
+
+	switch {
+	case exists("rebase-merge"):
+		return OpRebase
+	case exists("rebase-apply"):
+		if exists(filepath.Join("rebase-apply", "applying")) {
+			return OpAm
+		}
+		return OpRebase
+	case exists("MERGE_HEAD"):
+		return OpMerge
+	case exists("CHERRY_PICK_HEAD"):
+		return OpCherryPick
+	case exists("REVERT_HEAD"):
+		return OpRevert
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package gitrepo
+
+import (
+	"errors"
+	"strings"
+)
+
+// Remote is a configured remote and how the current branch compares to
+// it
+type Remote struct {
+	Name    string
+	URL     string
+	PushURL string // "" when pushes go to URL
+
+	// Upstream marks the branch's configured upstream remote and
+	// PushDefault the remote a plain `git push` uses
+	Upstream    bool
+	PushDefault bool
+
+	// Tracking is the remote-tracking ref compared against ("" when the
+	// remote has no copy of the branch); Ahead/Behind count against it
+	Tracking string
+	Ahead    int
+	Behind   int
+}
+
+// Remotes lists the configured remotes, sorted by name, comparing the
+// checked-out branch against each one's copy of it
+func (r *Repo) Remotes() ([]Remote, error) {
+	names := r.config.Subsections("remote")
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ref, head, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+	branch := strings.TrimPrefix(ref, "refs/heads/")
+
+	upstreamRemote := ""
+	if branch != "" {
+		upstreamRemote = r.config.Get("branch", branch, "remote")
+	}
+	pushRemote := r.pushRemote(branch, names)
+
+	remotes := make([]Remote, 0, len(names))
+	for _, name := range names {
+		rm := Remote{
+			Name:        name,
+			URL:         r.config.Get("remote", name, "url"),
+			PushURL:     r.config.Get("remote", name, "pushurl"),
+			Upstream:    name == upstreamRemote,
+			PushDefault: name == pushRemote,
+		}
+
+		if branch != "" && !head.IsZero() {
+			tracking := "refs/remotes/" + name + "/" + branch
+			if rm.Upstream {
+				tracking = r.Upstream(branch)
+			}
+			theirs, err := r.ResolveRef(tracking)
+			switch {
+			case err == nil:
+				rm.Tracking = tracking
+				if rm.Ahead, rm.Behind, err = r.AheadBehind(head, theirs); err != nil {
+					return nil, err
+				}
+			case !errors.Is(err, ErrNotFound):
+				return nil, err
+			}
+		}
+		remotes = append(remotes, rm)
+	}
+	return remotes, nil
+}
+
+// pushRemote picks the remote `git push` would use, the way git does:
+// branch.<name>.pushRemote, remote.pushDefault, branch.<name>.remote,
+// then origin
+func (r *Repo) pushRemote(branch string, names []string) string {
+	var candidates []string
+	if branch != "" {
+		candidates = append(candidates, r.config.Get("branch", branch, "pushremote"))
+	}
+	candidates = append(candidates, r.config.Get("remote", "", "pushdefault"))
+	if branch != "" {
+		candidates = append(candidates, r.config.Get("branch", branch, "remote"))
+	}
+	candidates = append(candidates, "origin")
+
+	for _, c := range candidates {
+		for _, n := range names {
+			if c != "" && c == n {
+				return c
+			}
+		}
+	}
+	return ""
+}
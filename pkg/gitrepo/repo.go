@@ -0,0 +1,221 @@
+// Package gitrepo reads git repositories with go-git so status refreshes
+// don't need to spawn a git process per project. It covers the subset
+// mission-control needs (refs, commits, trees, the index, ignore rules)
+// and returns ErrUnsupported for anything else, letting callers fall back
+// to the git CLI.
+package gitrepo
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/go-git/go-git/v5/storage/filesystem/dotgit"
+)
+
+// ErrUnsupported is returned for repository layouts this package doesn't
+// handle (sha256 object format, split index, etc). Callers should fall
+// back to the git CLI.
+var ErrUnsupported = errors.New("gitrepo: unsupported repository format")
+
+// ErrNotFound is returned when a ref or object doesn't exist
+var ErrNotFound = errors.New("gitrepo: not found")
+
+// Hash is a SHA-1 object id
+type Hash = plumbing.Hash
+
+// ParseHash parses a 40-char hex object id
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	if len(s) != 40 {
+		return h, fmt.Errorf("gitrepo: invalid hash %q", s)
+	}
+	if _, err := hex.Decode(h[:], []byte(s)); err != nil {
+		return h, fmt.Errorf("gitrepo: invalid hash %q", s)
+	}
+	return h, nil
+}
+
+// Repo is an open repository
+type Repo struct {
+	WorkDir   string // working tree root
+	GitDir    string // .git dir (per-worktree for linked worktrees)
+	CommonDir string // shared dir holding objects/refs/config
+
+	config  *Config
+	storage *filesystem.Storage
+}
+
+// Open opens the repository whose working tree is at path
+func Open(path string) (*Repo, error) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return nil, err
+	}
+
+	gitDir := dotGit
+	if !info.IsDir() {
+		// Linked worktree or submodule: ".git" is a file pointing elsewhere
+		data, err := os.ReadFile(dotGit)
+		if err != nil {
+			return nil, err
+		}
+		line := strings.TrimSpace(string(data))
+		if !strings.HasPrefix(line, "gitdir: ") {
+			return nil, ErrUnsupported
+		}
+		gitDir = strings.TrimPrefix(line, "gitdir: ")
+		if !filepath.IsAbs(gitDir) {
+			gitDir = filepath.Join(path, gitDir)
+		}
+	}
+
+	commonDir := gitDir
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(data))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	}
+
+	r := &Repo{
+		WorkDir:   path,
+		GitDir:    gitDir,
+		CommonDir: commonDir,
+	}
+
+	cfg, err := readConfig(filepath.Join(commonDir, "config"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	r.config = cfg
+
+	if format := cfg.Get("extensions", "", "objectformat"); format != "" && format != "sha1" {
+		return nil, ErrUnsupported
+	}
+
+	// Per-worktree files (HEAD, index) come from GitDir, shared ones
+	// (objects, refs) from CommonDir
+	fs := dotgit.NewRepositoryFilesystem(osfs.New(gitDir), osfs.New(commonDir))
+	r.storage = filesystem.NewStorage(fs, cache.NewObjectLRUDefault())
+	return r, nil
+}
+
+// Close releases the pack files the repository has open
+func (r *Repo) Close() error {
+	return r.storage.Close()
+}
+
+// Config returns the repository's parsed config
+func (r *Repo) Config() *Config {
+	return r.config
+}
+
+// Head returns the symbolic ref HEAD points to ("" when detached) and
+// the commit it resolves to (zero for an unborn branch).
+func (r *Repo) Head() (ref string, hash Hash, err error) {
+	head, err := r.storage.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", Hash{}, err
+	}
+	if head.Type() != plumbing.SymbolicReference {
+		return "", head.Hash(), nil
+	}
+
+	ref = head.Target().String()
+	hash, err = r.ResolveRef(ref)
+	if errors.Is(err, ErrNotFound) {
+		// Unborn branch (fresh repo with no commits)
+		return ref, Hash{}, nil
+	}
+	return ref, hash, err
+}
+
+// Branch returns the current branch short name, or "" when detached
+func (r *Repo) Branch() (string, error) {
+	ref, _, err := r.Head()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(ref, "refs/heads/"), nil
+}
+
+// ResolveRef resolves a full ref name (refs/heads/main) to a commit hash,
+// following symbolic refs.
+func (r *Repo) ResolveRef(ref string) (Hash, error) {
+	resolved, err := storer.ResolveReference(r.storage, plumbing.ReferenceName(ref))
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return Hash{}, ErrNotFound
+	}
+	if err != nil {
+		return Hash{}, err
+	}
+	return resolved.Hash(), nil
+}
+
+// SymbolicRef returns the ref a symbolic ref points to, e.g.
+// refs/remotes/origin/HEAD -> refs/remotes/origin/main
+func (r *Repo) SymbolicRef(ref string) (string, error) {
+	target, err := r.storage.Reference(plumbing.ReferenceName(ref))
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if target.Type() != plumbing.SymbolicReference {
+		return "", fmt.Errorf("gitrepo: %s is not a symbolic ref", ref)
+	}
+	return target.Target().String(), nil
+}
+
+// Refs lists all refs under prefix (e.g. "refs/heads/") with their hashes.
+// Loose refs shadow packed ones.
+func (r *Repo) Refs(prefix string) (map[string]Hash, error) {
+	iter, err := r.storage.IterReferences()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	refs := make(map[string]Hash)
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().String()
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		if ref.Type() == plumbing.SymbolicReference {
+			if h, err := r.ResolveRef(name); err == nil {
+				refs[name] = h
+			}
+			return nil
+		}
+		refs[name] = ref.Hash()
+		return nil
+	})
+	return refs, err
+}
+
+// Upstream returns the remote-tracking ref configured for a branch
+// (e.g. refs/remotes/origin/main), or "" if none is set.
+func (r *Repo) Upstream(branch string) string {
+	remote := r.config.Get("branch", branch, "remote")
+	merge := r.config.Get("branch", branch, "merge")
+	if remote == "" || merge == "" {
+		return ""
+	}
+	if remote == "." {
+		// Tracking a local branch
+		return merge
+	}
+	return "refs/remotes/" + remote + "/" + strings.TrimPrefix(merge, "refs/heads/")
+}
@@ -0,0 +1,57 @@
+package gitrepo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// hashHexLen is the length of a hex-encoded Hash
+const hashHexLen = 40
+
+// StashEntry is one entry of `git stash list`
+type StashEntry struct {
+	Index   int // N in stash@{N}
+	Hash    Hash
+	Message string // e.g. "WIP on main: 1a2b3c4 subject"
+	When    time.Time
+}
+
+// Stashes lists stash entries newest first, read from the refs/stash reflog
+func (r *Repo) Stashes() ([]StashEntry, error) {
+	data, err := os.ReadFile(filepath.Join(r.CommonDir, "logs", "refs", "stash"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Reflog lines: "<old> <new> <name> <<email>> <time> <tz>\t<message>"
+	var entries []StashEntry
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		head, msg, _ := bytes.Cut(line, []byte("\t"))
+		if len(head) < 2*hashHexLen+2 {
+			continue
+		}
+		h, err := ParseHash(string(head[hashHexLen+1 : 2*hashHexLen+1]))
+		if err != nil {
+			continue
+		}
+		var sig object.Signature
+		sig.Decode(head[2*hashHexLen+2:])
+		entries = append(entries, StashEntry{Hash: h, Message: string(msg), When: sig.When})
+	}
+
+	// The reflog is oldest first; stash@{0} is the newest
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	for i := range entries {
+		entries[i].Index = i
+	}
+	return entries, nil
+}
@@ -0,0 +1,485 @@
+package gitrepo
+
+import (
+	"container/heap"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+)
+
+// Status mirrors the counts `git status --porcelain -b` reports
+type Status struct {
+	Branch    string // "" when detached
+	Upstream  string // e.g. refs/remotes/origin/main
+	Staged    int
+	Modified  int
+	Untracked int
+	Ahead     int
+	Behind    int
+	Stashes   int
+
+	// Operation is the merge, rebase, etc. in progress ("" when none) and
+	// Conflicts the unmerged paths it left behind, in index order
+	Operation string
+	Conflicts []string
+
+	// Changed lists every path the counts cover, relative to the work
+	// tree: untracked directories end in "/"
+	Changed []string
+}
+
+// Status computes branch, ahead/behind, and working tree counts
+func (r *Repo) Status() (*Status, error) {
+	ref, head, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Status{Branch: strings.TrimPrefix(ref, "refs/heads/")}
+
+	if s.Branch != "" && !head.IsZero() {
+		if upstream := r.Upstream(s.Branch); upstream != "" {
+			s.Upstream = upstream
+			if up, err := r.ResolveRef(upstream); err == nil {
+				if s.Ahead, s.Behind, err = r.AheadBehind(head, up); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	idx, err := r.ReadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	headTree := make(map[string]TreeEntry)
+	if !head.IsZero() {
+		commit, err := r.ReadCommit(head)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.flattenTree(commit.Tree, "", headTree); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.countIndexChanges(s, idx, headTree); err != nil {
+		return nil, err
+	}
+
+	untracked, err := r.listUntracked(idx)
+	if err != nil {
+		return nil, err
+	}
+	s.Untracked = len(untracked)
+	s.Changed = append(s.Changed, untracked...)
+
+	stashes, err := r.Stashes()
+	if err != nil {
+		return nil, err
+	}
+	s.Stashes = len(stashes)
+	s.Operation = r.Operation()
+
+	return s, nil
+}
+
+// countIndexChanges fills Staged (HEAD vs index) and Modified (index vs
+// worktree), noting each changed path
+func (r *Repo) countIndexChanges(s *Status, idx *Index, headTree map[string]TreeEntry) error {
+	inIndex := make(map[string]bool, len(idx.Entries))
+	conflicted := make(map[string]bool)
+
+	for _, e := range idx.Entries {
+		inIndex[e.Path] = true
+
+		if e.Stage != 0 {
+			// Unmerged paths show as "UU": count once on each side
+			if !conflicted[e.Path] {
+				conflicted[e.Path] = true
+				s.Conflicts = append(s.Conflicts, e.Path)
+				s.Staged++
+				s.Modified++
+				s.Changed = append(s.Changed, e.Path)
+			}
+			continue
+		}
+
+		if e.IntentToAdd {
+			// "git add -N" shows as " A" - a worktree-side change
+			s.Modified++
+			s.Changed = append(s.Changed, e.Path)
+			continue
+		}
+		staged := false
+		if h, ok := headTree[e.Path]; !ok || h.Hash != e.Hash || h.Mode != e.Mode {
+			s.Staged++
+			staged = true
+		}
+
+		changed, err := r.worktreeChanged(e, idx.ModTime)
+		if err != nil {
+			return err
+		}
+		if changed {
+			s.Modified++
+		}
+		if staged || changed {
+			s.Changed = append(s.Changed, e.Path)
+		}
+	}
+
+	// Deleted from the index but present in HEAD
+	for path := range headTree {
+		if !inIndex[path] {
+			s.Staged++
+			s.Changed = append(s.Changed, path)
+		}
+	}
+
+	return nil
+}
+
+// worktreeChanged compares an index entry with the file on disk, using
+// stat data first and hashing only when it differs (as git does).
+func (r *Repo) worktreeChanged(e IndexEntry, indexTime time.Time) (bool, error) {
+	if e.SkipWorktree || e.Mode == 0o160000 {
+		// Sparse-checkout paths and submodules aren't compared here
+		return false, nil
+	}
+
+	full := filepath.Join(r.WorkDir, filepath.FromSlash(e.Path))
+	info, err := os.Lstat(full)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	isLink := info.Mode()&fs.ModeSymlink != 0
+	if isLink != (e.Mode == 0o120000) {
+		return true, nil
+	}
+	if !isLink && r.config.Get("core", "", "filemode") != "false" {
+		exec := info.Mode()&0o111 != 0
+		if exec != (e.Mode == 0o100755) {
+			return true, nil
+		}
+	}
+
+	sameStat := uint32(info.Size()) == e.Size && info.ModTime().Equal(e.MTime)
+	// Racy-clean: a file written in the same instant as the index may
+	// have changed without its stat data changing
+	racy := !info.ModTime().Before(indexTime)
+	if sameStat && !racy {
+		return false, nil
+	}
+
+	var content []byte
+	if isLink {
+		target, err := os.Readlink(full)
+		if err != nil {
+			return false, err
+		}
+		content = []byte(target)
+	} else {
+		if content, err = os.ReadFile(full); err != nil {
+			return false, err
+		}
+	}
+
+	if plumbing.ComputeHash(plumbing.BlobObject, content) == e.Hash {
+		return false, nil
+	}
+	if !isLink && r.contentFiltered(e.Path) {
+		// autocrlf/LFS/clean filters mean the blob and the file differ
+		// even when unchanged - only git itself can tell
+		return false, ErrUnsupported
+	}
+	return true, nil
+}
+
+// filterAttributes make git convert a file between its blob and the
+// worktree
+var filterAttributes = []string{"text", "eol", "filter", "ident", "working-tree-encoding"}
+
+// binaryMacro is git's built-in "binary" attribute
+var binaryMacro, _ = gitattributes.ParseAttributesLine("[attr]binary -diff -merge -text", nil, true)
+
+// contentFiltered reports whether checkout conversion may apply to path:
+// core.autocrlf is on, or info/attributes or a .gitattributes along the
+// path gives it one of filterAttributes
+func (r *Repo) contentFiltered(path string) bool {
+	if v := strings.ToLower(r.config.Get("core", "", "autocrlf")); v == "true" || v == "input" {
+		return true
+	}
+
+	// In increasing priority: the root .gitattributes, the ones below it
+	// down to path's directory, then info/attributes
+	parts := strings.Split(path, "/")
+	stack := []gitattributes.MatchAttribute{binaryMacro}
+	for i := range parts {
+		file := filepath.Join(r.WorkDir, filepath.Join(parts[:i]...), ".gitattributes")
+		stack = append(stack, readAttributes(file, parts[:i], i == 0)...)
+	}
+	stack = append(stack, readAttributes(filepath.Join(r.CommonDir, "info", "attributes"), nil, true)...)
+
+	macros := make(map[string][]gitattributes.Attribute)
+	state := make(map[string]gitattributes.Attribute)
+	for _, m := range stack {
+		if m.Pattern == nil {
+			macros[m.Name] = m.Attributes
+			continue
+		}
+		if !m.Pattern.Match(parts) {
+			continue
+		}
+		for _, a := range m.Attributes {
+			if a.IsSet() {
+				for _, expanded := range macros[a.Name()] {
+					state[expanded.Name()] = expanded
+				}
+			}
+			state[a.Name()] = a
+		}
+	}
+	for _, name := range filterAttributes {
+		if a, ok := state[name]; ok && (a.IsSet() || a.IsValueSet()) {
+			return true
+		}
+	}
+	return false
+}
+
+// readAttributes parses a gitattributes file, skipping lines git would
+// reject; macros are only allowed at the top level
+func readAttributes(file string, domain []string, allowMacro bool) []gitattributes.MatchAttribute {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var attrs []gitattributes.MatchAttribute
+	for _, line := range strings.Split(string(data), "\n") {
+		m, err := gitattributes.ParseAttributesLine(strings.TrimRight(line, "\r"), domain, allowMacro)
+		if err == nil && m.Name != "" {
+			attrs = append(attrs, m)
+		}
+	}
+	return attrs
+}
+
+// listUntracked walks the worktree the way `git status` does with
+// showUntrackedFiles=normal: a directory with no tracked files is listed
+// once, with a trailing "/", and ignored paths are skipped.
+func (r *Repo) listUntracked(idx *Index) ([]string, error) {
+	tracked := make(map[string]bool, len(idx.Entries))
+	trackedDirs := make(map[string]bool)
+	for _, e := range idx.Entries {
+		tracked[e.Path] = true
+		for dir := filepath.ToSlash(filepath.Dir(e.Path)); dir != "."; dir = filepath.ToSlash(filepath.Dir(dir)) {
+			if trackedDirs[dir] {
+				break
+			}
+			trackedDirs[dir] = true
+		}
+	}
+
+	var untracked []string
+	var walk func(rel string, ignores ignoreStack) error
+	walk = func(rel string, ignores ignoreStack) error {
+		dir := filepath.Join(r.WorkDir, filepath.FromSlash(rel))
+		ignores = append(ignores, readIgnoreFile(filepath.Join(dir, ".gitignore"), rel)...)
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if name == ".git" {
+				continue
+			}
+			path := name
+			if rel != "" {
+				path = rel + "/" + name
+			}
+			if tracked[path] {
+				continue
+			}
+
+			isDir := entry.IsDir()
+			if ignores.ignored(path, isDir) {
+				continue
+			}
+			if !isDir {
+				untracked = append(untracked, path)
+				continue
+			}
+			if trackedDirs[path] {
+				if err := walk(path, ignores); err != nil {
+					return err
+				}
+				continue
+			}
+			if r.hasUntrackedContent(path, ignores) {
+				untracked = append(untracked, path+"/")
+			}
+		}
+		return nil
+	}
+
+	if err := walk("", r.baseIgnores()); err != nil {
+		return nil, err
+	}
+	return untracked, nil
+}
+
+// hasUntrackedContent reports whether an untracked directory holds any
+// non-ignored file (git doesn't list empty or fully-ignored directories).
+func (r *Repo) hasUntrackedContent(rel string, ignores ignoreStack) bool {
+	dir := filepath.Join(r.WorkDir, filepath.FromSlash(rel))
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		// Nested repository
+		return true
+	}
+	ignores = append(ignores, readIgnoreFile(filepath.Join(dir, ".gitignore"), rel)...)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		path := rel + "/" + entry.Name()
+		if ignores.ignored(path, entry.IsDir()) {
+			continue
+		}
+		if !entry.IsDir() || r.hasUntrackedContent(path, ignores) {
+			return true
+		}
+	}
+	return false
+}
+
+// AheadBehind counts commits reachable from local but not upstream
+// (ahead) and vice versa (behind), like `git rev-list --left-right --count`.
+func (r *Repo) AheadBehind(local, upstream Hash) (ahead, behind int, err error) {
+	if local == upstream {
+		return 0, 0, nil
+	}
+
+	const (
+		fromLocal    = 1
+		fromUpstream = 2
+		both         = fromLocal | fromUpstream
+	)
+
+	flags := make(map[Hash]uint8)
+	queued := make(map[Hash]bool)
+	walked := make(map[Hash]*Commit)
+	q := &commitQueue{}
+
+	// undecided counts queued commits not yet reachable from both sides,
+	// so the walk knows when to stop without rescanning the queue
+	undecided := 0
+
+	push := func(h Hash, f uint8) error {
+		old := flags[h]
+		flags[h] |= f
+		if queued[h] {
+			if old != both && flags[h] == both {
+				undecided--
+			}
+			return nil
+		}
+		c := walked[h]
+		if c != nil {
+			if flags[h] == old {
+				return nil
+			}
+			// Reached from the other side after being walked (commits
+			// with equal timestamps can come out in either order), so
+			// walk it again to carry the new flag to its ancestors
+		} else {
+			var err error
+			if c, err = r.ReadCommit(h); err != nil {
+				return err
+			}
+		}
+		queued[h] = true
+		heap.Push(q, c)
+		if flags[h] != both {
+			undecided++
+		}
+		return nil
+	}
+
+	if err := push(local, fromLocal); err != nil {
+		return 0, 0, err
+	}
+	if err := push(upstream, fromUpstream); err != nil {
+		return 0, 0, err
+	}
+
+	// Walk newest-first; stop once everything left is reachable from both
+	for undecided > 0 {
+		c := heap.Pop(q).(*Commit)
+		delete(queued, c.Hash)
+		if flags[c.Hash] != both {
+			undecided--
+		}
+		walked[c.Hash] = c
+
+		for _, p := range c.Parents {
+			if err := push(p, flags[c.Hash]); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+
+	// A commit dated before its ancestors (clock skew) may have been
+	// walked before a flag reached them: carry what's left in the queue
+	// down through the commits already walked
+	stack := append([]*Commit{}, *q...)
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p := range c.Parents {
+			if pc := walked[p]; pc != nil && flags[p]|flags[c.Hash] != flags[p] {
+				flags[p] |= flags[c.Hash]
+				stack = append(stack, pc)
+			}
+		}
+	}
+
+	// Count once flags have settled
+	for h := range walked {
+		switch flags[h] {
+		case fromLocal:
+			ahead++
+		case fromUpstream:
+			behind++
+		}
+	}
+	return ahead, behind, nil
+}
+
+// commitQueue is a max-heap of commits by committer time
+type commitQueue []*Commit
+
+func (q commitQueue) Len() int            { return len(q) }
+func (q commitQueue) Less(i, j int) bool  { return q[i].When.After(q[j].When) }
+func (q commitQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(x interface{}) { *q = append(*q, x.(*Commit)) }
+func (q *commitQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,190 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestAheadBehind(t *testing.T) {
+	f := newFixture(t)
+	const t0 = 1700000000
+	f.commit("base", t0)
+	f.git("branch", "feature")
+	f.git("branch", "same")
+	f.commit("m1", t0+100)
+
+	f.git("checkout", "-q", "feature")
+	f.commit("f1", t0+50)
+	f.commit("f2", t0+150)
+	f.git("branch", "skew")
+
+	f.git("checkout", "-q", "-b", "merged", "main")
+	date := fmt.Sprintf("@%d +0000", t0+200)
+	f.gitEnv([]string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date}, "merge", "-q", "--no-ff", "-m", "merge", "feature")
+
+	// Every commit at the same second as base, so time order can't tell
+	// them apart
+	f.git("checkout", "-q", "same")
+	for i := 1; i <= 3; i++ {
+		f.commit(fmt.Sprintf("s%d", i), t0)
+	}
+
+	// A commit dated before everything it descends from
+	f.git("checkout", "-q", "skew")
+	f.commit("k1", t0-1000)
+
+	tests := []struct {
+		local, upstream string
+		ahead, behind   int
+	}{
+		{local: "main", upstream: "main"},
+		{local: "main~1", upstream: "main", behind: 1},
+		{local: "feature", upstream: "main~1", ahead: 2},
+		{local: "feature", upstream: "main", ahead: 2, behind: 1},
+		{local: "merged", upstream: "feature", ahead: 2},
+		{local: "merged", upstream: "main", ahead: 3},
+		{local: "main", upstream: "merged", behind: 3},
+		{local: "same", upstream: "main", ahead: 3, behind: 1},
+		{local: "same~1", upstream: "same~2", ahead: 1},
+		{local: "skew", upstream: "main", ahead: 3, behind: 1},
+		{local: "skew", upstream: "merged", ahead: 1, behind: 2},
+	}
+
+	r := f.open()
+	for _, tt := range tests {
+		t.Run(tt.local+"..."+tt.upstream, func(t *testing.T) {
+			ahead, behind, err := r.AheadBehind(f.hash(tt.local), f.hash(tt.upstream))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ahead != tt.ahead || behind != tt.behind {
+				t.Errorf("AheadBehind = %d, %d; want %d, %d", ahead, behind, tt.ahead, tt.behind)
+			}
+			want := fmt.Sprintf("%d\t%d", tt.ahead, tt.behind)
+			if got := f.git("rev-list", "--left-right", "--count", tt.local+"..."+tt.upstream); got != want {
+				t.Errorf("git rev-list --left-right --count = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	f := newFixture(t)
+	f.write(".gitignore", "*.log\n")
+	for _, path := range []string{"staged.txt", "modified.txt", "both.txt", "deleted.txt", "removed.txt", "dir/kept.txt"} {
+		f.write(path, path+"\n")
+	}
+	f.commit("initial", 1700000000)
+
+	f.write("staged.txt", "staged change\n")
+	f.write("both.txt", "staged change\n")
+	f.git("add", "staged.txt", "both.txt")
+	f.write("both.txt", "and a worktree change\n")
+	f.write("modified.txt", "worktree change\n")
+	f.write("added.txt", "new\n")
+	f.git("add", "added.txt")
+	f.write("intent.txt", "new\n")
+	f.git("add", "-N", "intent.txt")
+	if err := os.Remove(filepath.Join(f.dir, "deleted.txt")); err != nil {
+		t.Fatal(err)
+	}
+	f.git("rm", "-q", "removed.txt")
+	f.write("untracked.txt", "")
+	f.write("dir/untracked.txt", "")
+	f.write("newdir/a/b.txt", "")
+	f.write("debug.log", "")
+	f.write("logs/only.log", "")
+
+	s, err := f.open().Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var staged, modified, untracked int
+	var changed []string
+	for _, line := range strings.Split(f.git("status", "--porcelain", "--no-renames", "--untracked-files=normal"), "\n") {
+		x, y, path := line[0], line[1], line[3:]
+		changed = append(changed, path)
+		if x == '?' {
+			untracked++
+			continue
+		}
+		if x != ' ' {
+			staged++
+		}
+		if y != ' ' {
+			modified++
+		}
+	}
+	if s.Staged != staged || s.Modified != modified || s.Untracked != untracked {
+		t.Errorf("Status = %d staged, %d modified, %d untracked; git status says %d, %d, %d",
+			s.Staged, s.Modified, s.Untracked, staged, modified, untracked)
+	}
+	got := append([]string{}, s.Changed...)
+	sort.Strings(got)
+	sort.Strings(changed)
+	if strings.Join(got, "\n") != strings.Join(changed, "\n") {
+		t.Errorf("Changed:\n%s\ngit status lists:\n%s", strings.Join(got, "\n"), strings.Join(changed, "\n"))
+	}
+}
+
+func TestContentFiltered(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string // relative to the worktree; .git/info/attributes too
+		autocrlf bool
+		path     string
+		want     bool
+	}{
+		{name: "no attributes", path: "a.txt"},
+		{name: "autocrlf", autocrlf: true, path: "a.txt", want: true},
+		{name: "text", files: map[string]string{".gitattributes": "*.txt text\n"}, path: "a.txt", want: true},
+		{name: "text=auto", files: map[string]string{".gitattributes": "* text=auto\n"}, path: "a.go", want: true},
+		{name: "other extension", files: map[string]string{".gitattributes": "*.txt text\n"}, path: "a.go"},
+		{name: "unset text", files: map[string]string{".gitattributes": "*.bin -text\n"}, path: "a.bin"},
+		{name: "binary macro", files: map[string]string{".gitattributes": "* text=auto\n*.png binary\n"}, path: "a.png"},
+		{name: "user macro", files: map[string]string{".gitattributes": "[attr]normalized text eol=lf\n*.sh normalized\n"}, path: "run.sh", want: true},
+		{name: "text in a comment", files: map[string]string{".gitattributes": "# keep text files as they are\n"}, path: "a.txt"},
+		{name: "text in a name", files: map[string]string{".gitattributes": "*.txt linguist-textual\ncontext.md -diff\n"}, path: "context.md"},
+		{name: "eol", files: map[string]string{".gitattributes": "*.bat eol=crlf\n"}, path: "x.bat", want: true},
+		{name: "lfs", files: map[string]string{".gitattributes": "*.psd filter=lfs diff=lfs merge=lfs -text\n"}, path: "art/a.psd", want: true},
+		{name: "ident", files: map[string]string{".gitattributes": "*.c ident\n"}, path: "a.c", want: true},
+		{name: "nested overrides root", files: map[string]string{".gitattributes": "*.txt text\n", "sub/.gitattributes": "*.txt -text\n"}, path: "sub/a.txt"},
+		{name: "nested stays in its directory", files: map[string]string{"sub/.gitattributes": "*.txt text\n"}, path: "a.txt"},
+		{name: "info overrides all", files: map[string]string{".gitattributes": "*.txt text\n", ".git/info/attributes": "*.txt -text\n"}, path: "a.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			for path, content := range tt.files {
+				f.write(path, content)
+			}
+			if tt.autocrlf {
+				f.git("config", "core.autocrlf", "true")
+			}
+
+			if got := f.open().contentFiltered(tt.path); got != tt.want {
+				t.Errorf("contentFiltered(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+
+			if tt.autocrlf {
+				return
+			}
+			// Hold the table to what git itself decides
+			out := f.git(append([]string{"check-attr"}, append(filterAttributes, "--", tt.path)...)...)
+			gitFiltered := false
+			for _, line := range strings.Split(out, "\n") {
+				if v := line[strings.LastIndex(line, ": ")+2:]; v != "unset" && v != "unspecified" {
+					gitFiltered = true
+				}
+			}
+			if gitFiltered != tt.want {
+				t.Errorf("git check-attr %s: filtered = %v, want %v\n%s", tt.path, gitFiltered, tt.want, out)
+			}
+		})
+	}
+}
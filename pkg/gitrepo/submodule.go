@@ -0,0 +1,97 @@
+package gitrepo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Submodule is one entry of .gitmodules and its checkout state
+type Submodule struct {
+	Name        string
+	Path        string // relative to the superproject's working tree
+	URL         string
+	Recorded    Hash // commit the superproject's index points at
+	Head        Hash // commit checked out in the submodule (zero if not initialized)
+	Initialized bool
+	Dirty       bool // staged, modified, or untracked files inside the submodule
+}
+
+// OutOfSync reports whether the submodule's checkout differs from the
+// commit the superproject records (git shows this as "new commits")
+func (s Submodule) OutOfSync() bool {
+	return s.Initialized && s.Head != s.Recorded
+}
+
+// Submodules lists the submodules declared in .gitmodules, sorted by path.
+// Each initialized submodule is opened to compare its HEAD and working
+// tree; those it can't read natively return ErrUnsupported.
+func (r *Repo) Submodules() ([]Submodule, error) {
+	modules, err := readConfig(filepath.Join(r.WorkDir, ".gitmodules"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := r.ReadIndex()
+	if err != nil {
+		return nil, err
+	}
+	gitlinks := make(map[string]Hash)
+	for _, e := range idx.Entries {
+		if e.Mode == 0o160000 && e.Stage == 0 {
+			gitlinks[e.Path] = e.Hash
+		}
+	}
+
+	var subs []Submodule
+	for _, name := range modules.Subsections("submodule") {
+		path := modules.Get("submodule", name, "path")
+		if path == "" {
+			continue
+		}
+		s := Submodule{
+			Name:     name,
+			Path:     path,
+			URL:      modules.Get("submodule", name, "url"),
+			Recorded: gitlinks[path],
+		}
+
+		if err := s.inspect(filepath.Join(r.WorkDir, path)); err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Path < subs[j].Path })
+	return subs, nil
+}
+
+// inspect fills the checkout state of a submodule at dir. A directory
+// without a .git entry is an uninitialized submodule, not an error.
+func (s *Submodule) inspect(dir string) error {
+	sub, err := Open(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	_, head, err := sub.Head()
+	if err != nil {
+		return err
+	}
+	st, err := sub.Status()
+	if err != nil {
+		return err
+	}
+
+	s.Initialized = true
+	s.Head = head
+	s.Dirty = st.Staged+st.Modified+st.Untracked > 0
+	return nil
+}
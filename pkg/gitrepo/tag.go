@@ -0,0 +1,68 @@
+package gitrepo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Peel follows annotated tags to the object they point at. Anything that
+// isn't a tag is returned as is.
+func (r *Repo) Peel(h Hash) (Hash, error) {
+	for depth := 0; depth < 5; depth++ {
+		obj, err := r.storage.EncodedObject(plumbing.AnyObject, h)
+		if err != nil {
+			return Hash{}, objectError(h, err)
+		}
+		if obj.Type() != plumbing.TagObject {
+			return h, nil
+		}
+		tag, err := object.DecodeTag(r.storage, obj)
+		if err != nil {
+			return Hash{}, err
+		}
+		h = tag.Target
+	}
+	return Hash{}, fmt.Errorf("gitrepo: tag chain too deep at %s", h)
+}
+
+// LatestTag returns the tag nearest to from in its history, newest first
+// by committer time, like `git describe --tags --abbrev=0`. When several
+// tags share the commit the greatest name wins. ErrNotFound means no tag
+// is reachable.
+func (r *Repo) LatestTag(from Hash) (name string, commit Hash, err error) {
+	refs, err := r.Refs("refs/tags/")
+	if err != nil {
+		return "", Hash{}, err
+	}
+	tagged := make(map[Hash][]string)
+	for ref, h := range refs {
+		c, err := r.Peel(h)
+		if err != nil {
+			continue
+		}
+		tagged[c] = append(tagged[c], strings.TrimPrefix(ref, "refs/tags/"))
+	}
+	if len(tagged) == 0 {
+		return "", Hash{}, ErrNotFound
+	}
+
+	err = r.Walk(from, func(c *Commit) bool {
+		if names, ok := tagged[c.Hash]; ok {
+			sort.Strings(names)
+			name, commit = names[len(names)-1], c.Hash
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return "", Hash{}, err
+	}
+	if name == "" {
+		return "", Hash{}, ErrNotFound
+	}
+	return name, commit, nil
+}
@@ -0,0 +1,89 @@
+package gitrepo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Worktree is one working tree attached to a repository
+type Worktree struct {
+	Path    string
+	Branch  string // short name; "" when detached
+	Head    Hash
+	Main    bool // the repository's original working tree
+	Missing bool // registered but its directory is gone (prunable)
+	Dirty   bool // staged, modified, or untracked files
+}
+
+// Worktrees lists the main working tree followed by linked worktrees
+// (sorted by path), the way `git worktree list` does. Each existing
+// worktree is opened to read its branch and dirty state.
+func (r *Repo) Worktrees() ([]Worktree, error) {
+	var paths []string
+	var mains []bool
+
+	// A bare repository has no main working tree
+	if !strings.EqualFold(r.config.Get("core", "", "bare"), "true") {
+		paths = append(paths, filepath.Dir(r.CommonDir))
+		mains = append(mains, true)
+	}
+
+	dir := filepath.Join(r.CommonDir, "worktrees")
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var linked []string
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name(), "gitdir"))
+		if err != nil {
+			continue
+		}
+		// gitdir points at the worktree's ".git" file
+		linked = append(linked, filepath.Dir(strings.TrimSpace(string(data))))
+	}
+	sort.Strings(linked)
+	for _, p := range linked {
+		paths = append(paths, p)
+		mains = append(mains, false)
+	}
+
+	wts := make([]Worktree, 0, len(paths))
+	for i, p := range paths {
+		wt := Worktree{Path: p, Main: mains[i]}
+		if err := wt.inspect(); err != nil {
+			return nil, err
+		}
+		wts = append(wts, wt)
+	}
+	return wts, nil
+}
+
+// inspect fills a worktree's branch, HEAD, and dirty state
+func (w *Worktree) inspect() error {
+	repo, err := Open(w.Path)
+	if os.IsNotExist(err) {
+		w.Missing = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	st, err := repo.Status()
+	if err != nil {
+		return err
+	}
+	_, head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+
+	w.Branch = st.Branch
+	w.Head = head
+	w.Dirty = st.Staged+st.Modified+st.Untracked > 0
+	return nil
+}
@@ -0,0 +1,427 @@
+// Package handoff assembles a markdown handoff document for passing a
+// project to someone else: an architecture summary, setup steps checked
+// against this machine, open issues, the environment variables it needs
+// (names only), and a deploy runbook.
+package handoff
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+// Bundle is everything that goes into a handoff document
+type Bundle struct {
+	Project   string
+	Generated time.Time
+	Language  string
+	Summary   string   // architecture summary, AI-drafted; "" without an assistant
+	Layout    []string // top-level directories and files
+	Setup     []string // commands to get a working checkout, in order
+	Checks    []Check  // the setup verified against this machine
+	Issues    []discover.Issue
+	Env       []EnvVar
+	Runbook   []Step
+}
+
+// Check is one verified setup prerequisite
+type Check struct {
+	Label  string
+	OK     bool
+	Detail string
+}
+
+// EnvVar is an environment variable the project needs. Values are never
+// read into the bundle.
+type EnvVar struct {
+	Name   string
+	Source string // .env.example, github, vercel, ...
+}
+
+// Step is one runbook entry, e.g. "Deploy" with the command that does it
+type Step struct {
+	Label   string
+	Command string // "" when there's nothing to run
+	Note    string
+}
+
+// envFiles hold variable names; the checked-in templates come first
+var envFiles = []string{".env.example", ".env.sample", ".env.template", ".env", ".env.local", ".env.production"}
+
+// summaryReadme caps how much of the README goes into the summary prompt
+const summaryReadme = 60
+
+// Assemble gathers a project's bundle. complete drafts the architecture
+// summary and may be nil; every other source is best-effort, so a
+// section it couldn't read is left empty rather than failing the bundle.
+func Assemble(cfg *config.Config, name, path string, complete func(prompt string) (string, error)) *Bundle {
+	dir := expandPath(path)
+	b := &Bundle{Project: name, Generated: time.Now(), Language: discover.GetPrimaryLanguage(path)}
+	b.Layout = layout(dir)
+	b.Setup = setupSteps(cfg, name, dir)
+	b.Checks = checks(dir, b.Setup)
+	b.Issues, _ = discover.ListIssues(path)
+	b.Env = envVars(dir, path)
+	b.Runbook = runbook(cfg, name, dir)
+
+	if complete != nil {
+		if summary, err := complete(summaryPrompt(b, dir)); err == nil {
+			b.Summary = strings.TrimSpace(summary)
+		}
+	}
+	return b
+}
+
+// Filename is the default name for a bundle written on day t
+func Filename(project string, t time.Time) string {
+	return fmt.Sprintf("HANDOFF-%s-%s.md", project, t.Format("2006-01-02"))
+}
+
+func expandPath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
+
+func exists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// layout lists the top level of the checkout, directories first, leaving
+// out dependencies, build output, and dotfiles
+func layout(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	skip := map[string]bool{"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true, "out": true}
+	var dirs, files []string
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") || skip[name] {
+			continue
+		}
+		if e.IsDir() {
+			dirs = append(dirs, name+"/")
+		} else {
+			files = append(files, name)
+		}
+	}
+	return append(dirs, files...)
+}
+
+// packageManager picks the JavaScript package manager from the lockfile
+func packageManager(dir string) string {
+	switch {
+	case exists(dir, "bun.lockb"), exists(dir, "bun.lock"):
+		return "bun"
+	case exists(dir, "pnpm-lock.yaml"):
+		return "pnpm"
+	case exists(dir, "yarn.lock"):
+		return "yarn"
+	}
+	return "npm"
+}
+
+// setupSteps works out how to get from a fresh clone to a running
+// project from the manifests it has
+func setupSteps(cfg *config.Config, name, dir string) []string {
+	var steps []string
+	for _, f := range envFiles[:3] {
+		if exists(dir, f) {
+			steps = append(steps, "cp "+f+" .env   # then fill in the variables listed below")
+			break
+		}
+	}
+	if exists(dir, "package.json") {
+		pm := packageManager(dir)
+		steps = append(steps, pm+" install")
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+			json.Unmarshal(data, &pkg)
+		}
+		for _, script := range []string{"build", "test", "dev"} {
+			if _, ok := pkg.Scripts[script]; ok {
+				steps = append(steps, pm+" run "+script)
+			}
+		}
+	}
+	if exists(dir, "go.mod") {
+		steps = append(steps, "go build ./...", "go test ./...")
+	}
+	if exists(dir, "Cargo.toml") {
+		steps = append(steps, "cargo build", "cargo test")
+	}
+	switch {
+	case exists(dir, "pyproject.toml"):
+		steps = append(steps, "pip install -e .")
+	case exists(dir, "requirements.txt"):
+		steps = append(steps, "pip install -r requirements.txt")
+	}
+	if exists(dir, "Gemfile") {
+		steps = append(steps, "bundle install")
+	}
+	if exists(dir, "Package.swift") {
+		steps = append(steps, "swift build")
+	}
+	for _, f := range []string{"docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml"} {
+		if exists(dir, f) {
+			steps = append(steps, "docker compose up -d")
+			break
+		}
+	}
+	if len(steps) == 0 && exists(dir, "Makefile") {
+		steps = append(steps, "make")
+	}
+	for _, s := range cfg.Project(name).Services {
+		steps = append(steps, s.Command+"   # service "+s.Name)
+	}
+	return steps
+}
+
+// checks verifies setup the way a doctor command would: the runtimes the
+// project pins are installed at the right versions, and every tool the
+// setup steps run is on PATH
+func checks(dir string, setup []string) []Check {
+	var out []Check
+	reqs, _ := discover.CheckToolchain(dir)
+	pinned := make(map[string]bool)
+	for _, r := range reqs {
+		pinned[r.Tool] = true
+		c := Check{Label: fmt.Sprintf("%s %s (%s)", r.Tool, r.Want, r.Source), OK: r.OK()}
+		switch {
+		case r.Missing():
+			c.Detail = "not installed"
+		case r.Mismatched():
+			c.Detail = "have " + r.Have
+		default:
+			c.Detail = r.Have
+		}
+		out = append(out, c)
+	}
+
+	seen := make(map[string]bool)
+	for _, step := range setup {
+		fields := strings.Fields(step)
+		if len(fields) == 0 {
+			continue
+		}
+		tool := fields[0]
+		if tool == "cp" || seen[tool] || pinned[tool] {
+			continue
+		}
+		seen[tool] = true
+		c := Check{Label: tool, Detail: "not on PATH"}
+		if p, err := exec.LookPath(tool); err == nil {
+			c.OK, c.Detail = true, p
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// envVars collects variable names from env files and the providers that
+// hold the project's secrets, each listed once under where it was first
+// seen
+func envVars(dir, path string) []EnvVar {
+	var vars []EnvVar
+	seen := make(map[string]bool)
+	add := func(name, source string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			vars = append(vars, EnvVar{Name: name, Source: source})
+		}
+	}
+	for _, f := range envFiles {
+		file, err := os.Open(filepath.Join(dir, f))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			line = strings.TrimPrefix(line, "export ")
+			if name, _, ok := strings.Cut(line, "="); ok {
+				add(strings.TrimSpace(name), f)
+			}
+		}
+		file.Close()
+	}
+	reported, _ := discover.ProviderSecrets(path)
+	for _, s := range reported {
+		add(s.Name, s.Source)
+	}
+	sort.SliceStable(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+// runbook lists how the project is deployed, rolled back, and watched,
+// from its config and the platform files it has
+func runbook(cfg *config.Config, name, dir string) []Step {
+	pc := cfg.Project(name)
+	var steps []Step
+	switch {
+	case exists(dir, ".vercel"):
+		steps = append(steps,
+			Step{"Deploy", "vercel --prod", "or the deploy button in mc"},
+			Step{"Preview", "vercel", ""},
+			Step{"Roll back", "vercel rollback", ""},
+			Step{"Logs", "vercel logs " + name, ""})
+	case exists(dir, "fly.toml"):
+		steps = append(steps,
+			Step{"Deploy", "fly deploy", ""},
+			Step{"Roll back", "fly deploy --image <previous image>", "images are listed by fly releases --image"},
+			Step{"Logs", "fly logs", ""},
+			Step{"Status", "fly status", ""})
+	case exists(dir, "netlify.toml"):
+		steps = append(steps,
+			Step{"Deploy", "netlify deploy --prod", ""},
+			Step{"Roll back", "netlify rollback", ""})
+	case exists(dir, "render.yaml"):
+		steps = append(steps, Step{"Deploy", "", "push to the branch Render deploys (see render.yaml)"})
+	case exists(dir, "Dockerfile"):
+		steps = append(steps, Step{"Build", "docker build -t " + name + " .", ""})
+	}
+	if pc.Logs != "" {
+		steps = append(steps, Step{"Logs", pc.Logs, ""})
+	}
+	if pc.Uptime != "" {
+		steps = append(steps, Step{"Health check", "", pc.Uptime})
+	}
+	if workflows, _ := filepath.Glob(filepath.Join(dir, ".github", "workflows", "*.y*ml")); len(workflows) > 0 {
+		names := make([]string, len(workflows))
+		for i, w := range workflows {
+			names[i] = filepath.Base(w)
+		}
+		steps = append(steps, Step{"CI", "", "GitHub Actions: " + strings.Join(names, ", ")})
+	}
+	return steps
+}
+
+// summaryPrompt asks for an architecture overview from the layout, the
+// manifests, and the start of the README
+func summaryPrompt(b *Bundle, dir string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Write an architecture summary of %s for a developer taking it over: what it does, how the code is organized, the main components and how they fit together, and the external services it relies on. Markdown, no heading, under 250 words.\n", b.Project)
+	if b.Language != "" {
+		fmt.Fprintf(&sb, "\nPrimary language: %s\n", b.Language)
+	}
+	sb.WriteString("\nTop-level layout:\n")
+	for _, entry := range b.Layout {
+		sb.WriteString("- " + entry + "\n")
+	}
+	for _, f := range []string{"package.json", "go.mod", "Cargo.toml", "pyproject.toml"} {
+		if data, err := os.ReadFile(filepath.Join(dir, f)); err == nil {
+			fmt.Fprintf(&sb, "\n%s:\n%s\n", f, truncateLines(string(data), 40))
+		}
+	}
+	for _, f := range []string{"README.md", "readme.md", "README"} {
+		if data, err := os.ReadFile(filepath.Join(dir, f)); err == nil {
+			fmt.Fprintf(&sb, "\nREADME (start):\n%s\n", truncateLines(string(data), summaryReadme))
+			break
+		}
+	}
+	return sb.String()
+}
+
+func truncateLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Markdown renders the bundle as a handoff document
+func (b *Bundle) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s handoff\n\nGenerated %s by mission-control.\n", b.Project, b.Generated.Format("2006-01-02"))
+
+	sb.WriteString("\n## Architecture\n\n")
+	if b.Summary != "" {
+		sb.WriteString(b.Summary + "\n")
+	} else {
+		sb.WriteString("_No summary drafted (OpenClaw isn't running). Describe the main components here._\n")
+	}
+	if b.Language != "" {
+		fmt.Fprintf(&sb, "\nPrimary language: %s\n", b.Language)
+	}
+	if len(b.Layout) > 0 {
+		sb.WriteString("\nLayout:\n\n")
+		for _, entry := range b.Layout {
+			fmt.Fprintf(&sb, "- `%s`\n", entry)
+		}
+	}
+
+	sb.WriteString("\n## Setup\n\n")
+	if len(b.Setup) == 0 {
+		sb.WriteString("_No manifest found to derive setup steps from._\n")
+	} else {
+		sb.WriteString("```sh\n" + strings.Join(b.Setup, "\n") + "\n```\n")
+	}
+	if len(b.Checks) > 0 {
+		fmt.Fprintf(&sb, "\nChecked on %s:\n\n", b.Generated.Format("2006-01-02"))
+		for _, c := range b.Checks {
+			mark := "x"
+			if !c.OK {
+				mark = " "
+			}
+			fmt.Fprintf(&sb, "- [%s] %s — %s\n", mark, c.Label, c.Detail)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n## Open issues (%d)\n\n", len(b.Issues))
+	if len(b.Issues) == 0 {
+		sb.WriteString("None.\n")
+	}
+	for _, is := range b.Issues {
+		labels := ""
+		if names := is.LabelNames(); len(names) > 0 {
+			labels = " (" + strings.Join(names, ", ") + ")"
+		}
+		fmt.Fprintf(&sb, "- [#%d](%s) %s%s\n", is.Number, is.URL, is.Title, labels)
+	}
+
+	sb.WriteString("\n## Environment variables\n\nNames only; get the values from the current owner.\n\n")
+	if len(b.Env) == 0 {
+		sb.WriteString("None found.\n")
+	} else {
+		sb.WriteString("| Name | Where |\n|---|---|\n")
+		for _, v := range b.Env {
+			fmt.Fprintf(&sb, "| `%s` | %s |\n", v.Name, v.Source)
+		}
+	}
+
+	sb.WriteString("\n## Deploy runbook\n\n")
+	if len(b.Runbook) == 0 {
+		sb.WriteString("_No deploy target detected._\n")
+	}
+	for _, s := range b.Runbook {
+		var parts []string
+		if s.Command != "" {
+			parts = append(parts, "`"+s.Command+"`")
+		}
+		if s.Note != "" {
+			parts = append(parts, s.Note)
+		}
+		fmt.Fprintf(&sb, "- **%s:** %s\n", s.Label, strings.Join(parts, " — "))
+	}
+	return sb.String()
+}
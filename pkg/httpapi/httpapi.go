@@ -0,0 +1,269 @@
+// Package httpapi is the plumbing the API clients (github, gitlab, vercel,
+// fly, ...) share: authenticated JSON requests to a base URL, GraphQL
+// queries, and error responses turned into an *Error. Each client
+// supplies its endpoints and how it authenticates.
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Error is a non-2xx response, or a GraphQL error in a 200 one
+type Error struct {
+	API     string // who answered, e.g. "vercel"
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: HTTP %d", e.API, e.Status)
+	}
+	return fmt.Sprintf("%s: HTTP %d: %s", e.API, e.Status, e.Message)
+}
+
+// Client makes authenticated requests to one API
+type Client struct {
+	API     string // names the API in errors
+	BaseURL string // paths are relative to it; absolute URLs are used as is
+	Token   string
+	HTTP    *http.Client
+
+	// NoToken is returned, without sending anything, when Token is empty
+	NoToken error
+
+	// Authorize sets a request's credentials; nil sends Token as a bearer
+	// token
+	Authorize func(req *http.Request, token string)
+
+	// Header is sent with every request
+	Header http.Header
+
+	// OnResponse sees every response before its status is checked, for
+	// headers such as rate limits; an error it returns is the request's
+	OnResponse func(resp *http.Response) error
+}
+
+// New returns a client for the API at baseURL, sending Accept:
+// application/json and token as a bearer token
+func New(api, baseURL, token string, noToken error) *Client {
+	return &Client{
+		API:     api,
+		BaseURL: baseURL,
+		Token:   token,
+		HTTP:    &http.Client{Timeout: 20 * time.Second},
+		NoToken: noToken,
+		Header:  http.Header{"Accept": {"application/json"}},
+	}
+}
+
+// URL resolves a path against BaseURL
+func (c *Client) URL(path string) string {
+	if strings.Contains(path, "://") {
+		return path
+	}
+	if path == "" {
+		return c.BaseURL
+	}
+	return strings.TrimSuffix(c.BaseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// Get fetches a path into out
+func (c *Client) Get(path string, out any) error {
+	_, err := c.Do("GET", path, nil, out)
+	return err
+}
+
+// Post sends body (nil for none) as JSON to a path, decoding the reply
+// into out unless it is nil
+func (c *Client) Post(path string, body, out any) error {
+	_, err := c.Do("POST", path, body, out)
+	return err
+}
+
+// Patch is Post with the PATCH method
+func (c *Client) Patch(path string, body, out any) error {
+	_, err := c.Do("PATCH", path, body, out)
+	return err
+}
+
+// Put is Post with the PUT method
+func (c *Client) Put(path string, body, out any) error {
+	_, err := c.Do("PUT", path, body, out)
+	return err
+}
+
+// RawBody is a request body sent as is under its own content type, for
+// the few endpoints that don't take plain JSON
+type RawBody struct {
+	ContentType string
+	Data        []byte
+}
+
+// Do sends body (nil for none) as JSON, or a RawBody as is, to a path
+// with method, decoding the reply into out unless it is nil, and returns
+// the reply's headers
+func (c *Client) Do(method, path string, body, out any) (http.Header, error) {
+	var r io.Reader
+	contentType := "application/json"
+	if raw, ok := body.(RawBody); ok {
+		r, contentType = bytes.NewReader(raw.Data), raw.ContentType
+	} else if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.URL(path), r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, data, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, &Error{API: c.API, Status: resp.StatusCode, Message: errorMessage(resp, data)}
+	}
+	if out == nil {
+		return resp.Header, nil
+	}
+	return resp.Header, json.Unmarshal(data, out)
+}
+
+// GraphQL runs a query against the endpoint at path, decoding its data
+// into out; the first error the query reports is returned as an *Error
+func (c *Client) GraphQL(path, query string, vars map[string]any, out any) error {
+	errs, err := c.GraphQLPartial(path, query, vars, out)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return &Error{API: c.API, Status: http.StatusOK, Message: errs[0]}
+	}
+	return nil
+}
+
+// GraphQLPartial is GraphQL for batched queries, where one failing field
+// shouldn't discard the rest: whatever data came back is decoded into
+// out, and the query's errors are returned separately
+func (c *Client) GraphQLPartial(path, query string, vars map[string]any, out any) (errs []string, err error) {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", c.URL(path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, data, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	json.Unmarshal(data, &result)
+	for _, e := range result.Errors {
+		errs = append(errs, e.Message)
+	}
+	if resp.StatusCode >= 300 {
+		msg := errorMessage(resp, data)
+		if len(errs) > 0 {
+			msg = errs[0]
+		}
+		return nil, &Error{API: c.API, Status: resp.StatusCode, Message: msg}
+	}
+	if len(result.Data) == 0 || string(result.Data) == "null" {
+		if len(errs) == 0 {
+			return nil, fmt.Errorf("%s: empty GraphQL response", c.API)
+		}
+		return errs, nil
+	}
+	if out == nil {
+		return errs, nil
+	}
+	return errs, json.Unmarshal(result.Data, out)
+}
+
+// send authorizes and sends a request, reading the whole reply
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	if c.Token == "" {
+		if c.NoToken != nil {
+			return nil, nil, c.NoToken
+		}
+		return nil, nil, errors.New(c.API + ": no token")
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	if c.Authorize != nil {
+		c.Authorize(req, c.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if c.OnResponse != nil {
+		if err := c.OnResponse(resp); err != nil {
+			return nil, nil, err
+		}
+	}
+	limit := int64(16 << 20)
+	if resp.StatusCode >= 300 {
+		limit = 64 << 10
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, data, nil
+}
+
+// errorMessage finds the message in an error response, which the APIs
+// spell {"message": "..."}, {"error": "..."}, or {"error": {"message":
+// "..."}}; a plain text one is quoted from
+func errorMessage(resp *http.Response, data []byte) string {
+	var body struct {
+		Message any `json:"message"` // GitLab sends a map of field errors here
+		Error   any `json:"error"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+			return strings.TrimSpace(string(data[:min(len(data), 200)]))
+		}
+		return ""
+	}
+	if s, ok := body.Message.(string); ok && s != "" {
+		return s
+	}
+	switch e := body.Error.(type) {
+	case string:
+		return e
+	case map[string]any:
+		if s, ok := e["message"].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
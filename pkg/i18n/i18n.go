@@ -0,0 +1,157 @@
+// Package i18n translates user-facing strings. Messages are keyed by
+// their English text (format verbs included), so untranslated strings
+// fall back to English as written in the code.
+//
+// Built-in catalogs live in locales/<code>.json; a file of the same name
+// in ~/.hustlemc/locales adds to or overrides one, or adds a new locale.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// Default is the locale the code is written in
+const Default = "en"
+
+//go:embed locales/*.json
+var builtin embed.FS
+
+var (
+	mu      sync.RWMutex
+	locale  = Default
+	catalog map[string]string
+)
+
+// SetLocale selects the catalog used by T. Unknown locales fall back to
+// English and return an error.
+func SetLocale(code string) error {
+	code = normalize(code)
+	if code == "" || code == Default {
+		mu.Lock()
+		locale, catalog = Default, nil
+		mu.Unlock()
+		return nil
+	}
+
+	cat, err := load(code)
+	mu.Lock()
+	defer mu.Unlock()
+	if err != nil {
+		locale, catalog = Default, nil
+		return err
+	}
+	locale, catalog = code, cat
+	return nil
+}
+
+// Locale returns the active locale code
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// Detect picks a locale: the configured one if set, otherwise the
+// language of MC_LANG, LC_ALL, LC_MESSAGES, or LANG
+func Detect(configured string) string {
+	if configured != "" {
+		return normalize(configured)
+	}
+	for _, env := range []string{"MC_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := normalize(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	return Default
+}
+
+// normalize turns "es_MX.UTF-8" into "es"; "C" and "POSIX" mean English
+func normalize(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "_-.@"); i >= 0 {
+		code = code[:i]
+	}
+	if code == "c" || code == "posix" {
+		return Default
+	}
+	return code
+}
+
+// Available lists the locales with a catalog, built in or user-supplied
+func Available() []string {
+	seen := map[string]bool{Default: true}
+	if entries, err := builtin.ReadDir("locales"); err == nil {
+		for _, e := range entries {
+			seen[strings.TrimSuffix(e.Name(), ".json")] = true
+		}
+	}
+	if entries, err := os.ReadDir(userDir()); err == nil {
+		for _, e := range entries {
+			if strings.HasSuffix(e.Name(), ".json") {
+				seen[strings.TrimSuffix(e.Name(), ".json")] = true
+			}
+		}
+	}
+
+	codes := make([]string, 0, len(seen))
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func userDir() string {
+	return filepath.Join(config.Dir(), "locales")
+}
+
+// load merges the built-in catalog for code with the user's
+func load(code string) (map[string]string, error) {
+	cat := make(map[string]string)
+	found := false
+
+	if data, err := builtin.ReadFile("locales/" + code + ".json"); err == nil {
+		if err := json.Unmarshal(data, &cat); err != nil {
+			return nil, fmt.Errorf("built-in %s catalog: %w", code, err)
+		}
+		found = true
+	}
+	if data, err := os.ReadFile(filepath.Join(userDir(), code+".json")); err == nil {
+		var user map[string]string
+		if err := json.Unmarshal(data, &user); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(userDir(), code+".json"), err)
+		}
+		for k, v := range user {
+			cat[k] = v
+		}
+		found = true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no catalog for locale %q (have %s)", code, strings.Join(Available(), ", "))
+	}
+	return cat, nil
+}
+
+// T translates msg and, given args, formats it like fmt.Sprintf
+func T(msg string, args ...interface{}) string {
+	mu.RLock()
+	if s, ok := catalog[msg]; ok && s != "" {
+		msg = s
+	}
+	mu.RUnlock()
+
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
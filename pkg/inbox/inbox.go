@@ -0,0 +1,209 @@
+// Package inbox is a quick-capture list of thoughts (~/.hustlemc/inbox.json)
+// that are later routed to a project's TODO.md, an issue on its forge, or Linear.
+package inbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+)
+
+var mu sync.Mutex
+
+// Item is one captured thought
+type Item struct {
+	ID      string    `json:"id"`
+	Text    string    `json:"text"`
+	Project string    `json:"project,omitempty"` // project in focus when captured
+	Created time.Time `json:"created"`
+}
+
+// Path returns the inbox file path
+func Path() string {
+	return filepath.Join(config.Dir(), "inbox.json")
+}
+
+// Load reads all items, oldest first
+func Load() ([]Item, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() ([]Item, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func save(items []Item) error {
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Add captures a new item
+func Add(text, project string) (Item, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Item{}, fmt.Errorf("nothing to capture")
+	}
+
+	items, err := load()
+	if err != nil {
+		return Item{}, err
+	}
+
+	now := time.Now()
+	item := Item{
+		ID:      strconv.FormatInt(now.UnixNano(), 36),
+		Text:    text,
+		Project: project,
+		Created: now,
+	}
+	return item, save(append(items, item))
+}
+
+// Remove deletes an item by ID
+func Remove(id string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	items, err := load()
+	if err != nil {
+		return err
+	}
+
+	var kept []Item
+	for _, item := range items {
+		if item.ID != id {
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == len(items) {
+		return fmt.Errorf("no inbox item %s", id)
+	}
+	return save(kept)
+}
+
+// ToTodo appends the item as an unchecked task to the project's TODO.md
+func ToTodo(item Item, projectPath string) error {
+	path := filepath.Join(projectPath, "TODO.md")
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) == 0 {
+		data = []byte("# TODO\n")
+	}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, []byte("- [ ] "+item.Text+"\n")...)
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// ToIssue opens an issue on the project's forge and returns its URL
+func ToIssue(item Item, projectPath string) (string, error) {
+	f, ok := forge.Open(projectPath)
+	if !ok {
+		return "", forge.ErrNoForge
+	}
+	return f.CreateIssue(item.Text, IssueBody(item))
+}
+
+// IssueBody is the body of the issue ToIssue opens
+func IssueBody(item Item) string {
+	return "Captured with mc on " + item.Created.Format("2006-01-02")
+}
+
+// ToLinear creates a Linear issue in the project's team (linear_team in
+// config.json) and returns its identifier, e.g. "ENG-123"
+func ToLinear(cfg *config.Config, item Item, project string) (string, error) {
+	token := cfg.Token("linear")
+	if token == "" {
+		return "", fmt.Errorf("no linear token (set tokens.linear or MC_LINEAR_TOKEN)")
+	}
+	team := cfg.Project(project).LinearTeam
+	if team == "" {
+		return "", fmt.Errorf("no linear_team set for %s in config.json", project)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"query": `mutation($input: IssueCreateInput!) {
+  issueCreate(input: $input) { success issue { identifier url } }
+}`,
+		"variables": map[string]interface{}{
+			"input": map[string]string{"teamId": team, "title": item.Text},
+		},
+	})
+
+	req, err := http.NewRequest("POST", "https://api.linear.app/graphql", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	client := &http.Client{Timeout: 20 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			IssueCreate struct {
+				Success bool `json:"success"`
+				Issue   struct {
+					Identifier string `json:"identifier"`
+					URL        string `json:"url"`
+				} `json:"issue"`
+			} `json:"issueCreate"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("linear returned status %d", resp.StatusCode)
+	}
+	if len(result.Errors) > 0 {
+		return "", fmt.Errorf("linear: %s", result.Errors[0].Message)
+	}
+	if !result.Data.IssueCreate.Success {
+		return "", fmt.Errorf("linear: issue not created")
+	}
+	return result.Data.IssueCreate.Issue.Identifier, nil
+}
@@ -0,0 +1,161 @@
+// Package incident keeps a timeline per production incident
+// (~/.hustlemc/incidents.json): what set it off, notes taken while it
+// unfolds, and when it was resolved. A project has at most one open
+// incident at a time.
+package incident
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+var mu sync.Mutex
+
+// Event is one entry on an incident's timeline
+type Event struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// Incident is a project's outage, from the alert that started it to its
+// resolution
+type Incident struct {
+	ID       string    `json:"id"`
+	Project  string    `json:"project"`
+	Trigger  string    `json:"trigger"` // e.g. "deploy failed"
+	Started  time.Time `json:"started"`
+	Resolved time.Time `json:"resolved,omitempty"`
+	Timeline []Event   `json:"timeline,omitempty"`
+}
+
+// Open reports whether the incident hasn't been resolved
+func (i Incident) Open() bool {
+	return i.Resolved.IsZero()
+}
+
+// Duration is how long the incident lasted, or has lasted so far
+func (i Incident) Duration() time.Duration {
+	if i.Open() {
+		return time.Since(i.Started)
+	}
+	return i.Resolved.Sub(i.Started)
+}
+
+// Path returns the incidents file path
+func Path() string {
+	return filepath.Join(config.Dir(), "incidents.json")
+}
+
+// Load reads all incidents, oldest first
+func Load() ([]Incident, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() ([]Incident, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []Incident
+	if err := json.Unmarshal(data, &incidents); err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+func save(incidents []Incident) error {
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(incidents, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Active returns the open incidents by project
+func Active(incidents []Incident) map[string]Incident {
+	open := make(map[string]Incident)
+	for _, i := range incidents {
+		if i.Open() {
+			open[i.Project] = i
+		}
+	}
+	return open
+}
+
+// Start opens an incident for a project, or returns the one already open
+func Start(project, trigger string) (Incident, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	incidents, err := load()
+	if err != nil {
+		return Incident{}, err
+	}
+	if i, ok := Active(incidents)[project]; ok {
+		return i, nil
+	}
+
+	now := time.Now()
+	i := Incident{
+		ID:       strconv.FormatInt(now.UnixNano(), 36),
+		Project:  project,
+		Trigger:  trigger,
+		Started:  now,
+		Timeline: []Event{{Time: now, Text: "Opened: " + trigger}},
+	}
+	return i, save(append(incidents, i))
+}
+
+// update applies fn to an open incident and saves it
+func update(id string, fn func(*Incident)) (Incident, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	incidents, err := load()
+	if err != nil {
+		return Incident{}, err
+	}
+	for n := range incidents {
+		if incidents[n].ID == id && incidents[n].Open() {
+			fn(&incidents[n])
+			return incidents[n], save(incidents)
+		}
+	}
+	return Incident{}, fmt.Errorf("no open incident %s", id)
+}
+
+// Note adds a note to an open incident's timeline
+func Note(id, text string) (Incident, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Incident{}, fmt.Errorf("empty note")
+	}
+	return update(id, func(i *Incident) {
+		i.Timeline = append(i.Timeline, Event{Time: time.Now(), Text: text})
+	})
+}
+
+// Resolve closes an incident
+func Resolve(id string) (Incident, error) {
+	return update(id, func(i *Incident) {
+		i.Resolved = time.Now()
+		i.Timeline = append(i.Timeline, Event{Time: i.Resolved, Text: "Resolved"})
+	})
+}
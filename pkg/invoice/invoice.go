@@ -0,0 +1,174 @@
+// Package invoice turns tracked time into draft invoices, one per client
+// per month, using the client metadata in config.json.
+package invoice
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+)
+
+// Line is one billable line item: a project's time on one day
+type Line struct {
+	Date        time.Time
+	Project     string
+	Description string
+	Minutes     int
+	RateCents   int
+}
+
+// Hours returns the line's billable hours
+func (l Line) Hours() float64 {
+	return float64(l.Minutes) / 60
+}
+
+// AmountCents returns hours × rate, rounded to the cent
+func (l Line) AmountCents() int {
+	return (l.Minutes*l.RateCents + 30) / 60
+}
+
+// Invoice is a client's draft invoice for one month
+type Invoice struct {
+	Client  string
+	Cadence string
+	Month   time.Time
+	Lines   []Line
+}
+
+// TotalCents sums the invoice's line amounts
+func (inv Invoice) TotalCents() int {
+	total := 0
+	for _, l := range inv.Lines {
+		total += l.AmountCents()
+	}
+	return total
+}
+
+// TotalHours sums the invoice's billable hours
+func (inv Invoice) TotalHours() float64 {
+	minutes := 0
+	for _, l := range inv.Lines {
+		minutes += l.Minutes
+	}
+	return float64(minutes) / 60
+}
+
+// Drafts builds one invoice per client from the finished time entries
+// that started in t's month. Projects without a client are skipped;
+// running timers aren't billed until stopped.
+func Drafts(cfg *config.Config, entries []timelog.Entry, t time.Time) []Invoice {
+	from, to := timelog.MonthRange(t)
+
+	type lineKey struct {
+		client  string
+		project string
+		day     string
+	}
+	lines := make(map[lineKey]*Line)
+	notes := make(map[lineKey][]string)
+	cadences := make(map[string]string)
+
+	for _, e := range entries {
+		if e.Running() || e.Start.Before(from) || !e.Start.Before(to) {
+			continue
+		}
+		client := cfg.Project(e.Project).Client
+		if client == nil || client.Name == "" {
+			continue
+		}
+		cadences[client.Name] = client.Cadence
+
+		key := lineKey{client.Name, e.Project, e.Start.Format("2006-01-02")}
+		l, ok := lines[key]
+		if !ok {
+			day := time.Date(e.Start.Year(), e.Start.Month(), e.Start.Day(), 0, 0, 0, 0, e.Start.Location())
+			l = &Line{Date: day, Project: e.Project, RateCents: client.RateCents}
+			lines[key] = l
+		}
+		l.Minutes += int(e.Duration().Round(time.Minute) / time.Minute)
+		if e.Note != "" {
+			notes[key] = append(notes[key], e.Note)
+		}
+	}
+
+	byClient := make(map[string]*Invoice)
+	for key, l := range lines {
+		if l.Minutes == 0 {
+			continue
+		}
+		l.Description = strings.Join(notes[key], "; ")
+		if l.Description == "" {
+			l.Description = "Development — " + l.Project
+		}
+
+		inv, ok := byClient[key.client]
+		if !ok {
+			inv = &Invoice{Client: key.client, Cadence: cadences[key.client], Month: from}
+			byClient[key.client] = inv
+		}
+		inv.Lines = append(inv.Lines, *l)
+	}
+
+	var invoices []Invoice
+	for _, inv := range byClient {
+		sort.Slice(inv.Lines, func(i, j int) bool {
+			if !inv.Lines[i].Date.Equal(inv.Lines[j].Date) {
+				return inv.Lines[i].Date.Before(inv.Lines[j].Date)
+			}
+			return inv.Lines[i].Project < inv.Lines[j].Project
+		})
+		invoices = append(invoices, *inv)
+	}
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].Client < invoices[j].Client })
+
+	return invoices
+}
+
+// WriteCSV writes the invoice's line items followed by a total row
+func (inv Invoice) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"date", "project", "description", "hours", "rate", "amount"})
+	for _, l := range inv.Lines {
+		cw.Write([]string{
+			l.Date.Format("2006-01-02"),
+			l.Project,
+			l.Description,
+			strconv.FormatFloat(l.Hours(), 'f', 2, 64),
+			revenue.Decimal(l.RateCents),
+			revenue.Decimal(l.AmountCents()),
+		})
+	}
+	cw.Write([]string{"", "", "Total", strconv.FormatFloat(inv.TotalHours(), 'f', 2, 64), "", revenue.Decimal(inv.TotalCents())})
+	cw.Flush()
+	return cw.Error()
+}
+
+var slugRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Filename returns e.g. "invoice-acme-corp-2026-10.csv"
+func (inv Invoice) Filename() string {
+	slug := strings.Trim(slugRe.ReplaceAllString(strings.ToLower(inv.Client), "-"), "-")
+	if slug == "" {
+		slug = "client"
+	}
+	return fmt.Sprintf("invoice-%s-%s.csv", slug, inv.Month.Format("2006-01"))
+}
+
+// ParseCents parses an amount like "150" or "150.5" into cents
+func ParseCents(s string) (int, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "$")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return int(f*100 + 0.5), nil
+}
@@ -0,0 +1,184 @@
+// Package maintenance keeps declared maintenance windows
+// (~/.hustlemc/maintenance.json): planned downtime for a project, or for
+// every project, during which uptime failures, deploy-failure alerts, and
+// notifications are held back.
+package maintenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/snooze"
+)
+
+// Alerts are the alerts a window mutes
+var Alerts = []string{snooze.AlertDeploy, snooze.AlertDown}
+
+// keepFor is how long ended windows are kept, so outages and incidents
+// from the last month can still be annotated with them
+const keepFor = 45 * 24 * time.Hour
+
+var mu sync.Mutex
+
+// Window is one declared maintenance window
+type Window struct {
+	Project string    `json:"project,omitempty"` // "" for every project
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// Active reports whether the window is in effect at t
+func (w Window) Active(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Covers reports whether the window applies to project at t
+func (w Window) Covers(project string, t time.Time) bool {
+	return (w.Project == "" || w.Project == project) && w.Active(t)
+}
+
+// Overlaps reports whether the window applies to project at any point
+// between from and to
+func (w Window) Overlaps(project string, from, to time.Time) bool {
+	return (w.Project == "" || w.Project == project) && w.Start.Before(to) && from.Before(w.End)
+}
+
+// Label describes the window, e.g. "all projects, Oct 20 22:00-23:30: db upgrade"
+func (w Window) Label() string {
+	who := w.Project
+	if who == "" {
+		who = "all projects"
+	}
+	end := w.End.Format("15:04")
+	if w.End.YearDay() != w.Start.YearDay() || w.End.Year() != w.Start.Year() {
+		end = w.End.Format("Jan 2 15:04")
+	}
+	s := fmt.Sprintf("%s, %s-%s", who, w.Start.Format("Jan 2 15:04"), end)
+	if w.Reason != "" {
+		s += ": " + w.Reason
+	}
+	return s
+}
+
+// Path returns the maintenance windows file
+func Path() string {
+	return filepath.Join(config.Dir(), "maintenance.json")
+}
+
+// Load reads all windows, earliest first
+func Load() ([]Window, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() ([]Window, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var windows []Window
+	if err := json.Unmarshal(data, &windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func save(windows []Window) error {
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(windows, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Add declares a window, dropping any that ended long enough ago
+func Add(w Window) error {
+	if !w.End.After(w.Start) {
+		return fmt.Errorf("maintenance window ends (%s) before it starts (%s)", w.End.Format("Jan 2 15:04"), w.Start.Format("Jan 2 15:04"))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	windows, err := load()
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-keepFor)
+	kept := windows[:0]
+	for _, old := range windows {
+		if old.End.After(cutoff) {
+			kept = append(kept, old)
+		}
+	}
+	kept = append(kept, w)
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Start.Before(kept[j].Start) })
+	return save(kept)
+}
+
+// End cuts short the windows for project ("" for the global ones) that
+// haven't ended by now, dropping those that haven't started. It returns
+// how many it changed.
+func End(project string, now time.Time) (int, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	windows, err := load()
+	if err != nil {
+		return 0, err
+	}
+	kept := windows[:0]
+	n := 0
+	for _, w := range windows {
+		if w.Project != project || !now.Before(w.End) {
+			kept = append(kept, w)
+			continue
+		}
+		n++
+		if now.After(w.Start) {
+			w.End = now
+			kept = append(kept, w)
+		}
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return n, save(kept)
+}
+
+// Covering returns the window project is in at t, or nil
+func Covering(windows []Window, project string, t time.Time) *Window {
+	for i := range windows {
+		if windows[i].Covers(project, t) {
+			return &windows[i]
+		}
+	}
+	return nil
+}
+
+// Mute adds the windows in effect at now to a snooze set, so everything
+// that honors snoozes holds back the alerts a window mutes
+func Mute(s snooze.Set, windows []Window, now time.Time) {
+	for _, w := range windows {
+		if !w.Active(now) {
+			continue
+		}
+		for _, alert := range Alerts {
+			s.Mute(w.Project, alert, w.End)
+		}
+	}
+}
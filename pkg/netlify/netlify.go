@@ -0,0 +1,175 @@
+// Package netlify talks to the Netlify API, so projects deployed there
+// show their latest deploy like Vercel ones.
+package netlify
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultBaseURL is the Netlify API
+const DefaultBaseURL = "https://api.netlify.com/api/v1"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no netlify token (set tokens.netlify, MC_NETLIFY_TOKEN, or NETLIFY_AUTH_TOKEN, or log in with netlify)")
+
+// Client makes authenticated API requests
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for the Netlify API using token
+func NewClient(token string) *Client {
+	return &Client{httpapi.New("netlify", DefaultBaseURL, token, ErrNoToken)}
+}
+
+// FindToken looks for a token, in order: tokens.netlify (or
+// MC_NETLIFY_TOKEN), NETLIFY_AUTH_TOKEN (which the netlify CLI reads),
+// then the one the CLI saved at login. It returns "" when there is none.
+func FindToken(cfg *config.Config) string {
+	if cfg != nil {
+		if t := cfg.Token("netlify"); t != "" {
+			return t
+		}
+	}
+	if t := os.Getenv("NETLIFY_AUTH_TOKEN"); t != "" {
+		return t
+	}
+	return cliToken()
+}
+
+// cliToken reads the signed-in user's token from the netlify CLI's
+// config.json: ~/Library/Preferences/netlify on macOS,
+// $XDG_CONFIG_HOME/netlify (~/.config) elsewhere
+func cliToken() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	switch {
+	case runtime.GOOS == "darwin":
+		dir = filepath.Join(home, "Library", "Preferences")
+	case dir == "":
+		dir = filepath.Join(home, ".config")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "netlify", "config.json"))
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		UserID string `json:"userId"`
+		Users  map[string]struct {
+			Auth struct {
+				Token string `json:"token"`
+			} `json:"auth"`
+		} `json:"users"`
+	}
+	json.Unmarshal(data, &cfg)
+	return cfg.Users[cfg.UserID].Auth.Token
+}
+
+// Default returns a client using the token FindToken finds, or nil when
+// there is none
+func Default() *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg); token != "" {
+		return NewClient(token)
+	}
+	return nil
+}
+
+// Linked reports whether dir deploys to Netlify: it has a netlify.toml or
+// was linked with `netlify link` (.netlify)
+func Linked(dir string) bool {
+	for _, name := range []string{"netlify.toml", ".netlify"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// LinkedSite reads the site ID `netlify link` saved in
+// .netlify/state.json, or "" when dir isn't linked
+func LinkedSite(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, ".netlify", "state.json"))
+	if err != nil {
+		return ""
+	}
+	var state struct {
+		SiteID string `json:"siteId"`
+	}
+	json.Unmarshal(data, &state)
+	return state.SiteID
+}
+
+// Site is a Netlify site
+type Site struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	URL           string `json:"ssl_url"`
+	AdminURL      string `json:"admin_url"`
+	BuildSettings struct {
+		RepoURL string `json:"repo_url"`
+	} `json:"build_settings"`
+}
+
+// SiteForRepo finds the site built from a repository, for projects with
+// a netlify.toml that were never linked locally. repo is "owner/name".
+func (c *Client) SiteForRepo(repo string) (Site, bool, error) {
+	var sites []Site
+	if err := c.Get("sites?filter=all&per_page=100", &sites); err != nil {
+		return Site{}, false, err
+	}
+	for _, s := range sites {
+		u, err := url.Parse(s.BuildSettings.RepoURL)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), repo) {
+			return s, true, nil
+		}
+	}
+	return Site{}, false, nil
+}
+
+// Deploy is one deploy of a site
+type Deploy struct {
+	ID         string    `json:"id"`
+	State      string    `json:"state"`   // new, enqueued, building, uploading, processing, ready, error, ...
+	Context    string    `json:"context"` // production, deploy-preview, branch-deploy
+	URL        string    `json:"deploy_ssl_url"`
+	AdminURL   string    `json:"admin_url"` // the site's page in the Netlify app
+	Branch     string    `json:"branch"`
+	ReviewID   int       `json:"review_id"` // the pull request a deploy preview is for
+	CommitRef  string    `json:"commit_ref"`
+	Title      string    `json:"title"` // the commit message, for git deploys
+	Committer  string    `json:"committer"`
+	Error      string    `json:"error_message"`
+	DeployTime int       `json:"deploy_time"` // seconds, 0 until it's done
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Deploys lists a site's newest n deploys, newest first
+func (c *Client) Deploys(siteID string, n int) ([]Deploy, error) {
+	var deploys []Deploy
+	err := c.Get("sites/"+url.PathEscape(siteID)+"/deploys?per_page="+strconv.Itoa(n), &deploys)
+	return deploys, err
+}
+
+// RestoreDeploy publishes an earlier deploy of a site again, rolling the
+// site back to it
+func (c *Client) RestoreDeploy(siteID, deployID string) error {
+	return c.Post("sites/"+url.PathEscape(siteID)+"/deploys/"+url.PathEscape(deployID)+"/restore", nil, nil)
+}
@@ -180,6 +180,52 @@ func (c *Client) InvokeTool(tool string, args map[string]interface{}) (map[strin
 	return result.Result, nil
 }
 
+// Complete sends a one-shot prompt to the gateway's OpenAI-compatible
+// chat completions endpoint and returns the reply
+func (c *Client) Complete(prompt string) (string, error) {
+	reqBody := map[string]interface{}{
+		"model":    "openclaw",
+		"messages": []Message{{Role: "user", Content: prompt}},
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest("POST", c.baseURL+"/v1/chat/completions", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("gateway returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("gateway returned no choices")
+	}
+
+	return result.Choices[0].Message.Content, nil
+}
+
 // Ping checks if the gateway is reachable
 func (c *Client) Ping() error {
 	req, err := http.NewRequest("GET", c.baseURL+"/health", nil)
@@ -0,0 +1,358 @@
+// Package ports tracks which local TCP ports projects want and which are
+// actually taken, so two dev servers don't fight over :3000. Claims come
+// from each project's services in config.json; listeners come from lsof
+// (or ss) and are attributed to a project by their working directory.
+package ports
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// Claim is a port a project's service expects to listen on
+type Claim struct {
+	Project string
+	Service string
+	Port    int
+}
+
+// Listener is a process listening on a local TCP port
+type Listener struct {
+	Port    int
+	PID     int
+	Command string
+	Dir     string // working directory, "" when it can't be read
+	Project string // project whose directory Dir is in, "" for none
+}
+
+// String describes the process, e.g. "node (pid 4242, blog)"
+func (l Listener) String() string {
+	owner := l.Project
+	if owner == "" {
+		owner = l.Dir
+	}
+	if owner == "" {
+		return fmt.Sprintf("%s (pid %d)", l.Command, l.PID)
+	}
+	return fmt.Sprintf("%s (pid %d, %s)", l.Command, l.PID, owner)
+}
+
+// ServicePort returns the port a service listens on: its port setting,
+// else the port of a local health check, else 0
+func ServicePort(svc config.Service) int {
+	if svc.Port > 0 {
+		return svc.Port
+	}
+	h := svc.Health
+	if h == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(h); err == nil {
+		return n
+	}
+	host, port := "", ""
+	if u, err := url.Parse(h); err == nil && u.Host != "" {
+		host, port = u.Hostname(), u.Port()
+	} else if hh, pp, err := net.SplitHostPort(h); err == nil {
+		host, port = hh, pp
+	}
+	switch host {
+	case "", "localhost", "127.0.0.1", "::1", "0.0.0.0":
+		n, _ := strconv.Atoi(port)
+		return n
+	}
+	return 0
+}
+
+// Claims lists the ports every project's services claim, by port then
+// project
+func Claims(cfg *config.Config) []Claim {
+	var claims []Claim
+	for name, p := range cfg.Projects {
+		if p == nil {
+			continue
+		}
+		for _, svc := range p.Services {
+			if port := ServicePort(svc); port > 0 {
+				claims = append(claims, Claim{Project: name, Service: svc.Name, Port: port})
+			}
+		}
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		if claims[i].Port != claims[j].Port {
+			return claims[i].Port < claims[j].Port
+		}
+		if claims[i].Project != claims[j].Project {
+			return claims[i].Project < claims[j].Project
+		}
+		return claims[i].Service < claims[j].Service
+	})
+	return claims
+}
+
+// Listening lists processes listening on TCP ports, attributing each to
+// the project (name to directory) its working directory is inside
+func Listening(projects map[string]string) ([]Listener, error) {
+	var listeners []Listener
+	var err error
+	if _, lookErr := exec.LookPath("lsof"); lookErr == nil {
+		listeners, err = lsofListeners()
+	} else if _, lookErr := exec.LookPath("ss"); lookErr == nil {
+		listeners, err = ssListeners()
+	} else {
+		return nil, fmt.Errorf("neither lsof nor ss is installed")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make(map[int]string)
+	for i := range listeners {
+		l := &listeners[i]
+		dir, ok := dirs[l.PID]
+		if !ok {
+			dir = processDir(l.PID)
+			dirs[l.PID] = dir
+		}
+		l.Dir = dir
+		l.Project = projectFor(dir, projects)
+	}
+	sort.Slice(listeners, func(i, j int) bool {
+		if listeners[i].Port != listeners[j].Port {
+			return listeners[i].Port < listeners[j].Port
+		}
+		return listeners[i].PID < listeners[j].PID
+	})
+	return listeners, nil
+}
+
+// lsofListeners parses `lsof -F pcn` output: a p line per process, then
+// its command and one n line per listening socket
+func lsofListeners() ([]Listener, error) {
+	output, err := exec.Command("lsof", "-nP", "-iTCP", "-sTCP:LISTEN", "-F", "pcn").Output()
+	if err != nil && len(output) == 0 {
+		// lsof exits 1 when nothing matches
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var out []Listener
+	seen := make(map[[2]int]bool) // IPv4 and IPv6 sockets of one process
+	pid, command := 0, ""
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, _ = strconv.Atoi(line[1:])
+		case 'c':
+			command = line[1:]
+		case 'n':
+			port := addrPort(line[1:])
+			if port == 0 || seen[[2]int{pid, port}] {
+				continue
+			}
+			seen[[2]int{pid, port}] = true
+			out = append(out, Listener{Port: port, PID: pid, Command: command})
+		}
+	}
+	return out, nil
+}
+
+var ssUser = regexp.MustCompile(`\("([^"]*)",pid=(\d+)`)
+
+// ssListeners parses `ss -ltnpH`, which only names processes the user owns
+func ssListeners() ([]Listener, error) {
+	output, err := exec.Command("ss", "-ltnpH").Output()
+	if err != nil {
+		return nil, err
+	}
+	var out []Listener
+	seen := make(map[[2]int]bool)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		port := addrPort(fields[3])
+		if port == 0 {
+			continue
+		}
+		m := ssUser.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		pid, _ := strconv.Atoi(m[2])
+		if seen[[2]int{pid, port}] {
+			continue
+		}
+		seen[[2]int{pid, port}] = true
+		out = append(out, Listener{Port: port, PID: pid, Command: m[1]})
+	}
+	return out, nil
+}
+
+// addrPort returns the port of "*:3000", "127.0.0.1:5432", or "[::1]:8080"
+func addrPort(addr string) int {
+	i := strings.LastIndex(addr, ":")
+	if i < 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(addr[i+1:])
+	return n
+}
+
+// processDir returns a process's working directory, or "" if it can't be
+// read (another user's process, or the process already exited)
+func processDir(pid int) string {
+	if runtime.GOOS == "linux" {
+		dir, _ := os.Readlink(fmt.Sprintf("/proc/%d/cwd", pid))
+		return dir
+	}
+	output, err := exec.Command("lsof", "-a", "-p", strconv.Itoa(pid), "-d", "cwd", "-Fn").Output()
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "n") {
+			return line[1:]
+		}
+	}
+	return ""
+}
+
+// projectFor returns the project whose directory contains dir, preferring
+// the deepest when projects nest
+func projectFor(dir string, projects map[string]string) string {
+	if dir == "" {
+		return ""
+	}
+	best, bestLen := "", 0
+	for name, root := range projects {
+		root = filepath.Clean(root)
+		if (dir == root || strings.HasPrefix(dir, root+string(filepath.Separator))) && len(root) > bestLen {
+			best, bestLen = name, len(root)
+		}
+	}
+	return best
+}
+
+// Conflict is a port a service about to start can't count on
+type Conflict struct {
+	Port     int
+	Service  string
+	Others   []Claim   // other projects' services claiming the port
+	Listener *Listener // what is listening there now, if anything
+}
+
+// String explains the conflict, e.g. "port 3000 (web) is in use by node
+// (pid 4242, blog)"
+func (c Conflict) String() string {
+	if c.Listener != nil {
+		return fmt.Sprintf("port %d (%s) is in use by %s", c.Port, c.Service, c.Listener)
+	}
+	var others []string
+	for _, o := range c.Others {
+		others = append(others, o.Project+"/"+o.Service)
+	}
+	return fmt.Sprintf("port %d (%s) is also claimed by %s", c.Port, c.Service, strings.Join(others, ", "))
+}
+
+// Check finds the ports a project's services would collide on: taken by
+// a process that isn't the project's own, or claimed by another project
+func Check(project string, svcs []config.Service, claims []Claim, listeners []Listener) []Conflict {
+	var conflicts []Conflict
+	for _, svc := range svcs {
+		port := ServicePort(svc)
+		if port == 0 {
+			continue
+		}
+		c := Conflict{Port: port, Service: svc.Name}
+		for i, l := range listeners {
+			if l.Port == port && l.Project != project {
+				c.Listener = &listeners[i]
+				break
+			}
+		}
+		for _, cl := range claims {
+			if cl.Port == port && cl.Project != project {
+				c.Others = append(c.Others, cl)
+			}
+		}
+		if c.Listener != nil || len(c.Others) > 0 {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// Row is one port in the ports-in-use table
+type Row struct {
+	Port      int
+	Claims    []Claim
+	Listeners []Listener
+}
+
+// Conflict reports whether more than one project wants the port, or it
+// is taken by something other than the project claiming it
+func (r Row) Conflict() bool {
+	projects := make(map[string]bool)
+	for _, c := range r.Claims {
+		projects[c.Project] = true
+	}
+	if len(projects) > 1 {
+		return true
+	}
+	for _, l := range r.Listeners {
+		if len(projects) > 0 && !projects[l.Project] {
+			return true
+		}
+	}
+	return false
+}
+
+// Table merges claims and listeners into one row per port, in port order
+func Table(claims []Claim, listeners []Listener) []Row {
+	byPort := make(map[int]*Row)
+	row := func(port int) *Row {
+		if r, ok := byPort[port]; ok {
+			return r
+		}
+		r := &Row{Port: port}
+		byPort[port] = r
+		return r
+	}
+	for _, c := range claims {
+		r := row(c.Port)
+		r.Claims = append(r.Claims, c)
+	}
+	for _, l := range listeners {
+		r := row(l.Port)
+		r.Listeners = append(r.Listeners, l)
+	}
+
+	rows := make([]Row, 0, len(byPort))
+	for _, r := range byPort {
+		rows = append(rows, *r)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Port < rows[j].Port })
+	return rows
+}
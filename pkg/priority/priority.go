@@ -0,0 +1,258 @@
+// Package priority ranks projects by what most needs working on next,
+// weighing health, deadlines, revenue, and open-issue severity. The
+// ranking is cached and recomputed once a day.
+package priority
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+)
+
+// DeadlineHorizon is how far ahead a deadline starts to count; one due
+// today (or missed) counts in full
+const DeadlineHorizon = 14 * 24 * time.Hour
+
+// Signal names, as used in config weights and reasons
+const (
+	SignalHealth    = "health"
+	SignalDeadlines = "deadlines"
+	SignalRevenue   = "revenue"
+	SignalIssues    = "issues"
+)
+
+// Deadline is something due for a project, from config or a chore
+type Deadline struct {
+	Name string
+	Due  time.Time
+}
+
+// Signals are what a project's priority is computed from
+type Signals struct {
+	Project string
+
+	// Health is 0-100, 100 being nothing wrong; Problems say what's wrong
+	Health   int
+	Problems []string
+
+	Deadlines []Deadline
+
+	// RevenueCents is this month's revenue
+	RevenueCents int
+
+	// Issue counts by severity
+	Critical int
+	Bugs     int
+	Other    int
+}
+
+// Severity weighs open issues: critical 5, bugs 2, the rest 1
+func (s Signals) Severity() int {
+	return 5*s.Critical + 2*s.Bugs + s.Other
+}
+
+// Reason is one signal's contribution to a project's score
+type Reason struct {
+	Signal string  `json:"signal"`
+	Points float64 `json:"points"`
+	Why    string  `json:"why"`
+}
+
+// Rank is a project's place in the ranking and why
+type Rank struct {
+	Project string   `json:"project"`
+	Score   float64  `json:"score"`
+	Reasons []Reason `json:"reasons,omitempty"`
+}
+
+// Ranking is a computed ranking, highest score first
+type Ranking struct {
+	Computed time.Time `json:"computed"`
+	Ranks    []Rank    `json:"ranks"`
+}
+
+// Stale reports whether the ranking was computed before today
+func (r *Ranking) Stale(now time.Time) bool {
+	if r == nil || r.Computed.IsZero() {
+		return true
+	}
+	y, m, d := now.Date()
+	return r.Computed.Before(time.Date(y, m, d, 0, 0, 0, 0, now.Location()))
+}
+
+// weight returns a signal's configured weight: unset counts as 1 and
+// negative as 0
+func weight(w *config.Priority, signal string) float64 {
+	var v float64
+	if w != nil {
+		switch signal {
+		case SignalHealth:
+			v = w.Health
+		case SignalDeadlines:
+			v = w.Deadlines
+		case SignalRevenue:
+			v = w.Revenue
+		case SignalIssues:
+			v = w.Issues
+		}
+	}
+	switch {
+	case v == 0:
+		return 1
+	case v < 0:
+		return 0
+	}
+	return v
+}
+
+// Compute scores every project and ranks them. Each signal scores 0-100
+// before weighting; revenue is scored against the top earner.
+func Compute(signals []Signals, w *config.Priority, now time.Time) *Ranking {
+	topRevenue := 0
+	for _, s := range signals {
+		topRevenue = max(topRevenue, s.RevenueCents)
+	}
+
+	r := &Ranking{Computed: now}
+	for _, s := range signals {
+		rank := Rank{Project: s.Project}
+		add := func(signal string, points float64, why string) {
+			points *= weight(w, signal)
+			if points <= 0 {
+				return
+			}
+			rank.Score += points
+			rank.Reasons = append(rank.Reasons, Reason{Signal: signal, Points: points, Why: why})
+		}
+
+		if s.Health < 100 {
+			add(SignalHealth, float64(100-max(s.Health, 0)), strings.Join(s.Problems, ", "))
+		}
+		if d, ok := nearest(s.Deadlines); ok {
+			left := d.Due.Sub(now)
+			if left < DeadlineHorizon {
+				add(SignalDeadlines, 100*min(1, 1-float64(left)/float64(DeadlineHorizon)), deadlineWhy(d, now))
+			}
+		}
+		if s.RevenueCents > 0 && topRevenue > 0 {
+			add(SignalRevenue, 100*float64(s.RevenueCents)/float64(topRevenue), revenue.FormatCents(s.RevenueCents)+" this month")
+		}
+		if sev := s.Severity(); sev > 0 {
+			add(SignalIssues, float64(min(10*sev, 100)), issuesWhy(s))
+		}
+
+		sort.SliceStable(rank.Reasons, func(i, j int) bool { return rank.Reasons[i].Points > rank.Reasons[j].Points })
+		r.Ranks = append(r.Ranks, rank)
+	}
+
+	sort.SliceStable(r.Ranks, func(i, j int) bool {
+		if r.Ranks[i].Score != r.Ranks[j].Score {
+			return r.Ranks[i].Score > r.Ranks[j].Score
+		}
+		return r.Ranks[i].Project < r.Ranks[j].Project
+	})
+	return r
+}
+
+// nearest returns the soonest deadline
+func nearest(deadlines []Deadline) (Deadline, bool) {
+	if len(deadlines) == 0 {
+		return Deadline{}, false
+	}
+	soonest := deadlines[0]
+	for _, d := range deadlines[1:] {
+		if d.Due.Before(soonest.Due) {
+			soonest = d
+		}
+	}
+	return soonest, true
+}
+
+func deadlineWhy(d Deadline, now time.Time) string {
+	days := int(d.Due.Sub(now).Hours() / 24)
+	switch {
+	case d.Due.Before(now):
+		return fmt.Sprintf("%s overdue since %s", d.Name, d.Due.Format("Jan 2"))
+	case days == 0:
+		return d.Name + " due today"
+	case days == 1:
+		return d.Name + " due tomorrow"
+	}
+	return fmt.Sprintf("%s due in %d days", d.Name, days)
+}
+
+func issuesWhy(s Signals) string {
+	var parts []string
+	for _, c := range []struct {
+		n    int
+		kind string
+	}{{s.Critical, "critical"}, {s.Bugs, "bugs"}, {s.Other, "other"}} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.kind))
+		}
+	}
+	return strings.Join(parts, ", ") + " open"
+}
+
+// IssueSeverity sorts an issue into critical, bug, or other by its labels
+func IssueSeverity(labels []string) string {
+	severity := "other"
+	for _, l := range labels {
+		l = strings.ToLower(l)
+		switch {
+		case strings.Contains(l, "critical"), strings.Contains(l, "security"), strings.Contains(l, "urgent"),
+			l == "p0", strings.HasSuffix(l, ":p0"), strings.Contains(l, "sev1"):
+			return "critical"
+		case strings.Contains(l, "bug"), l == "p1", strings.HasSuffix(l, ":p1"), strings.Contains(l, "regression"):
+			severity = "bug"
+		}
+	}
+	return severity
+}
+
+var mu sync.Mutex
+
+// Path returns the cached ranking file
+func Path() string {
+	return filepath.Join(config.Dir(), "priorities.json")
+}
+
+// Load returns the cached ranking, nil if there is none
+func Load() (*Ranking, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r Ranking
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Save caches a ranking
+func Save(r *Ranking) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
@@ -0,0 +1,188 @@
+// Package railway talks to the Railway GraphQL API, so projects deployed
+// there show their latest deployment like Vercel ones.
+package railway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultURL is the public GraphQL API
+const DefaultURL = "https://backboard.railway.com/graphql/v2"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no railway token (set tokens.railway, MC_RAILWAY_TOKEN, RAILWAY_API_TOKEN, or RAILWAY_TOKEN, or log in with railway)")
+
+// Client makes authenticated GraphQL requests
+type Client struct {
+	*httpapi.Client
+	// ProjectToken marks Token as a project token (RAILWAY_TOKEN), which
+	// is sent in its own header and only reads its project
+	ProjectToken bool
+}
+
+// NewClient returns a client for the Railway API using an account or
+// team token
+func NewClient(token string) *Client {
+	c := &Client{Client: httpapi.New("railway", DefaultURL, token, ErrNoToken)}
+	c.Authorize = func(req *http.Request, token string) {
+		if c.ProjectToken {
+			req.Header.Set("Project-Access-Token", token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	return c
+}
+
+// FindToken looks for a token, in order: tokens.railway (or
+// MC_RAILWAY_TOKEN), RAILWAY_API_TOKEN, RAILWAY_TOKEN (a project token),
+// then the one the railway CLI saved at login. It returns "" when there
+// is none.
+func FindToken(cfg *config.Config) (token string, projectToken bool) {
+	if cfg != nil {
+		if t := cfg.Token("railway"); t != "" {
+			return t, false
+		}
+	}
+	if t := os.Getenv("RAILWAY_API_TOKEN"); t != "" {
+		return t, false
+	}
+	if t := os.Getenv("RAILWAY_TOKEN"); t != "" {
+		return t, true
+	}
+	return readCLIConfig().User.Token, false
+}
+
+// cliConfig is the railway CLI's ~/.railway/config.json: who's logged in
+// and the project each directory is linked to
+type cliConfig struct {
+	Projects map[string]Link `json:"projects"`
+	User     struct {
+		Token string `json:"token"`
+	} `json:"user"`
+}
+
+func readCLIConfig() cliConfig {
+	var cfg cliConfig
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return cfg
+	}
+	if data, err := os.ReadFile(filepath.Join(home, ".railway", "config.json")); err == nil {
+		json.Unmarshal(data, &cfg)
+	}
+	return cfg
+}
+
+// Default returns a client using the token FindToken finds, or nil when
+// there is none
+func Default() *Client {
+	cfg, _ := config.Load()
+	token, projectToken := FindToken(cfg)
+	if token == "" {
+		return nil
+	}
+	c := NewClient(token)
+	c.ProjectToken = projectToken
+	return c
+}
+
+// Query runs a GraphQL query and decodes its data into out
+func (c *Client) Query(query string, vars map[string]any, out any) error {
+	return c.GraphQL("", query, vars, out)
+}
+
+// Link is what a directory deploys to: set by railway link, or just a
+// service ID from the project's settings
+type Link struct {
+	Project     string `json:"project"`
+	Environment string `json:"environment"`
+	Service     string `json:"service"`
+}
+
+// Linked reads the link railway link saved for dir
+func Linked(dir string) (Link, bool) {
+	link, ok := readCLIConfig().Projects[dir]
+	return link, ok && (link.Project != "" || link.Service != "")
+}
+
+// Configured reports whether dir has a railway.json or railway.toml
+func Configured(dir string) bool {
+	for _, name := range []string{"railway.json", "railway.toml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Deployment is one deployment of a service
+type Deployment struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"` // SUCCESS, FAILED, CRASHED, BUILDING, DEPLOYING, INITIALIZING, QUEUED, WAITING, SLEEPING, REMOVED, ...
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	StaticURL string    `json:"staticUrl"`
+	ProjectID string    `json:"projectId"`
+	ServiceID string    `json:"serviceId"`
+	Creator   *struct {
+		Name string `json:"name"`
+	} `json:"creator"`
+	// Meta is what triggered it; for a git push, the commit
+	Meta struct {
+		CommitHash    string `json:"commitHash"`
+		CommitMessage string `json:"commitMessage"`
+		CommitAuthor  string `json:"commitAuthor"`
+	} `json:"meta"`
+}
+
+// LogsURL is the deployment's page on the dashboard, with its build and
+// deploy logs
+func (d Deployment) LogsURL() string {
+	return "https://railway.com/project/" + d.ProjectID + "/service/" + d.ServiceID + "?id=" + d.ID
+}
+
+// Deployments lists the newest n deployments of what link points at,
+// newest first
+func (c *Client) Deployments(link Link, n int) ([]Deployment, error) {
+	input := map[string]any{}
+	if link.Project != "" {
+		input["projectId"] = link.Project
+	}
+	if link.Environment != "" {
+		input["environmentId"] = link.Environment
+	}
+	if link.Service != "" {
+		input["serviceId"] = link.Service
+	}
+	var data struct {
+		Deployments struct {
+			Edges []struct {
+				Node Deployment `json:"node"`
+			} `json:"edges"`
+		} `json:"deployments"`
+	}
+	err := c.Query(`query($first: Int!, $input: DeploymentListInput!) {
+		deployments(first: $first, input: $input) {
+			edges { node { id status createdAt updatedAt staticUrl projectId serviceId creator { name } meta } }
+		}
+	}`, map[string]any{"first": n, "input": input}, &data)
+	deployments := make([]Deployment, 0, len(data.Deployments.Edges))
+	for _, e := range data.Deployments.Edges {
+		deployments = append(deployments, e.Node)
+	}
+	return deployments, err
+}
+
+// Rollback redeploys an earlier deployment of its service
+func (c *Client) Rollback(deploymentID string) error {
+	return c.Query(`mutation($id: String!) { deploymentRollback(id: $id) }`, map[string]any{"id": deploymentID}, nil)
+}
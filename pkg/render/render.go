@@ -0,0 +1,170 @@
+// Package render talks to the Render API, so services deployed there
+// show their latest deploy like Vercel projects.
+package render
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultBaseURL is the Render API
+const DefaultBaseURL = "https://api.render.com/v1"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no render API key (set tokens.render, MC_RENDER_TOKEN, or RENDER_API_KEY)")
+
+// Client makes authenticated API requests
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for the Render API using an API key
+func NewClient(token string) *Client {
+	return &Client{httpapi.New("render", DefaultBaseURL, token, ErrNoToken)}
+}
+
+// FindToken looks for an API key, in order: tokens.render (or
+// MC_RENDER_TOKEN), then RENDER_API_KEY, which the render CLI reads. It
+// returns "" when there is none.
+func FindToken(cfg *config.Config) string {
+	if cfg != nil {
+		if t := cfg.Token("render"); t != "" {
+			return t
+		}
+	}
+	return os.Getenv("RENDER_API_KEY")
+}
+
+// Default returns a client using the key FindToken finds, or nil when
+// there is none
+func Default() *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg); token != "" {
+		return NewClient(token)
+	}
+	return nil
+}
+
+// BlueprintServices reads the names of the services a directory's
+// render.yaml Blueprint defines, or nil when it has none
+func BlueprintServices(dir string) []string {
+	f, err := os.Open(filepath.Join(dir, "render.yaml"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	// services:
+	//   - type: web
+	//     name: api
+	var names []string
+	inServices := false
+	listIndent, keyIndent := -1, -1
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+			inServices = strings.TrimSuffix(trimmed, ":") == "services"
+			continue
+		}
+		if !inServices {
+			continue
+		}
+		// Only a service's own name, not one nested in its env vars
+		lead := len(line) - len(strings.TrimLeft(line, " "))
+		if strings.HasPrefix(trimmed, "- ") {
+			if listIndent < 0 {
+				listIndent = lead
+			}
+			if lead != listIndent {
+				continue
+			}
+			keyIndent = lead + 2
+			trimmed = strings.TrimSpace(trimmed[2:])
+		} else if lead != keyIndent {
+			continue
+		}
+		if v, ok := strings.CutPrefix(trimmed, "name:"); ok {
+			names = append(names, strings.Trim(strings.TrimSpace(v), `"'`))
+		}
+	}
+	return names
+}
+
+// Service is a Render service
+type Service struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"` // web_service, background_worker, static_site, ...
+	DashboardURL   string `json:"dashboardUrl"`
+	ServiceDetails struct {
+		URL string `json:"url"` // web services and static sites only
+	} `json:"serviceDetails"`
+}
+
+// Service reads a service by ID
+func (c *Client) Service(id string) (Service, error) {
+	var s Service
+	err := c.Get("services/"+url.PathEscape(id), &s)
+	return s, err
+}
+
+// ServicesNamed finds the services called name
+func (c *Client) ServicesNamed(name string) ([]Service, error) {
+	var page []struct {
+		Service Service `json:"service"`
+	}
+	if err := c.Get("services?limit=20&name="+url.QueryEscape(name), &page); err != nil {
+		return nil, err
+	}
+	services := make([]Service, 0, len(page))
+	for _, p := range page {
+		services = append(services, p.Service)
+	}
+	return services, nil
+}
+
+// Deploy is one deploy of a service
+type Deploy struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"` // created, build_in_progress, update_in_progress, live, build_failed, update_failed, canceled, deactivated, ...
+	CreatedAt  time.Time `json:"createdAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Commit     struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	} `json:"commit"`
+}
+
+// Deploys lists a service's newest n deploys, newest first
+func (c *Client) Deploys(serviceID string, n int) ([]Deploy, error) {
+	var page []struct {
+		Deploy Deploy `json:"deploy"`
+	}
+	if err := c.Get(fmt.Sprintf("services/%s/deploys?limit=%d", url.PathEscape(serviceID), n), &page); err != nil {
+		return nil, err
+	}
+	deploys := make([]Deploy, 0, len(page))
+	for _, p := range page {
+		deploys = append(deploys, p.Deploy)
+	}
+	return deploys, nil
+}
+
+// Rollback redeploys an earlier deploy of a service
+func (c *Client) Rollback(serviceID, deployID string) error {
+	return c.Post("services/"+url.PathEscape(serviceID)+"/rollbacks", map[string]string{"deployId": deployID}, nil)
+}
@@ -0,0 +1,289 @@
+// Package revenue fetches monthly income per project from the revenue
+// sources configured in config.json (GitHub Sponsors, Stripe, Gumroad).
+package revenue
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// CacheTTL is how long fetched revenue figures are reused
+const CacheTTL = 6 * time.Hour
+
+var (
+	cacheMu    sync.Mutex
+	httpClient = &http.Client{Timeout: 20 * time.Second}
+)
+
+// SourceAmount is one source's income for the month
+type SourceAmount struct {
+	config.RevenueSource
+	Cents int    `json:"cents"`
+	Err   string `json:"error,omitempty"`
+}
+
+// Report is a project's income for one month
+type Report struct {
+	Project    string         `json:"project"`
+	Month      string         `json:"month"` // 2006-01
+	FetchedAt  time.Time      `json:"fetched_at"`
+	Sources    []SourceAmount `json:"sources"`
+	TotalCents int            `json:"total_cents"`
+}
+
+func cachePath() string {
+	return filepath.Join(config.Dir(), "revenue.json")
+}
+
+func loadCache() map[string]*Report {
+	reports := make(map[string]*Report)
+	if data, err := os.ReadFile(cachePath()); err == nil {
+		json.Unmarshal(data, &reports)
+	}
+	return reports
+}
+
+func cacheKey(project, month string) string {
+	return project + "@" + month
+}
+
+// ForProject returns the project's income for the month containing t,
+// using the cached report when it's fresh.
+func ForProject(cfg *config.Config, project string, t time.Time) (*Report, error) {
+	sources := cfg.Project(project).Revenue
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	month := t.Format("2006-01")
+	key := cacheKey(project, month)
+
+	cacheMu.Lock()
+	cached := loadCache()[key]
+	cacheMu.Unlock()
+	if cached != nil && time.Since(cached.FetchedAt) < CacheTTL && len(cached.Sources) == len(sources) {
+		return cached, nil
+	}
+
+	report := &Report{Project: project, Month: month, FetchedAt: time.Now()}
+	for _, src := range sources {
+		amount := SourceAmount{RevenueSource: src}
+		cents, err := fetch(cfg, src, t)
+		if err != nil {
+			amount.Err = err.Error()
+		}
+		amount.Cents = cents
+		report.TotalCents += cents
+		report.Sources = append(report.Sources, amount)
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	reports := loadCache()
+	reports[key] = report
+	if data, err := json.MarshalIndent(reports, "", "  "); err == nil {
+		os.MkdirAll(config.Dir(), 0755)
+		os.WriteFile(cachePath(), data, 0644)
+	}
+
+	return report, nil
+}
+
+func fetch(cfg *config.Config, src config.RevenueSource, t time.Time) (int, error) {
+	switch src.Kind {
+	case config.RevenueGitHubSponsors:
+		return fetchSponsors(src.ID)
+	case config.RevenueStripe:
+		return fetchStripe(cfg.Token("stripe"), src.ID, t)
+	case config.RevenueGumroad:
+		return fetchGumroad(cfg.Token("gumroad"), src.ID, t)
+	}
+	return 0, fmt.Errorf("unknown revenue source %q", src.Kind)
+}
+
+// fetchSponsors reads the estimated monthly Sponsors income for an account.
+// GitHub only exposes this to the account's owner/admins.
+func fetchSponsors(login string) (int, error) {
+	query := `query($login: String!) {
+  repositoryOwner(login: $login) {
+    ... on Sponsorable { monthlyEstimatedSponsorsIncomeInCents }
+  }
+}`
+	cmd := exec.Command("gh", "api", "graphql", "-f", "query="+query, "-F", "login="+login)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("gh api: %w", err)
+	}
+
+	var result struct {
+		Data struct {
+			RepositoryOwner struct {
+				Income int `json:"monthlyEstimatedSponsorsIncomeInCents"`
+			} `json:"repositoryOwner"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return 0, err
+	}
+	return result.Data.RepositoryOwner.Income, nil
+}
+
+// fetchStripe sums paid invoice lines for a product in t's month
+func fetchStripe(key, productID string, t time.Time) (int, error) {
+	if key == "" {
+		return 0, fmt.Errorf("no stripe token (set tokens.stripe or MC_STRIPE_TOKEN)")
+	}
+
+	start, end := monthRange(t)
+	total := 0
+	startingAfter := ""
+
+	for {
+		q := url.Values{}
+		q.Set("status", "paid")
+		q.Set("limit", "100")
+		q.Set("created[gte]", strconv.FormatInt(start.Unix(), 10))
+		q.Set("created[lt]", strconv.FormatInt(end.Unix(), 10))
+		if startingAfter != "" {
+			q.Set("starting_after", startingAfter)
+		}
+
+		req, err := http.NewRequest("GET", "https://api.stripe.com/v1/invoices?"+q.Encode(), nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Authorization", "Bearer "+key)
+
+		var page struct {
+			HasMore bool `json:"has_more"`
+			Data    []struct {
+				ID    string `json:"id"`
+				Lines struct {
+					Data []struct {
+						Amount int `json:"amount"`
+						Price  struct {
+							Product string `json:"product"`
+						} `json:"price"`
+					} `json:"data"`
+				} `json:"lines"`
+			} `json:"data"`
+		}
+		if err := getJSON(req, &page); err != nil {
+			return total, err
+		}
+
+		for _, inv := range page.Data {
+			for _, line := range inv.Lines.Data {
+				if line.Price.Product == productID {
+					total += line.Amount
+				}
+			}
+			startingAfter = inv.ID
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			return total, nil
+		}
+	}
+}
+
+// fetchGumroad sums non-refunded sales of a product in t's month
+func fetchGumroad(token, productID string, t time.Time) (int, error) {
+	if token == "" {
+		return 0, fmt.Errorf("no gumroad token (set tokens.gumroad or MC_GUMROAD_TOKEN)")
+	}
+
+	start, end := monthRange(t)
+	total := 0
+	pageKey := ""
+
+	for {
+		q := url.Values{}
+		q.Set("access_token", token)
+		q.Set("product_id", productID)
+		q.Set("after", start.Format("2006-01-02"))
+		q.Set("before", end.AddDate(0, 0, -1).Format("2006-01-02"))
+		if pageKey != "" {
+			q.Set("page_key", pageKey)
+		}
+
+		req, err := http.NewRequest("GET", "https://api.gumroad.com/v2/sales?"+q.Encode(), nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var page struct {
+			Success     bool   `json:"success"`
+			NextPageKey string `json:"next_page_key"`
+			Sales       []struct {
+				Price    int  `json:"price"`
+				Refunded bool `json:"refunded"`
+			} `json:"sales"`
+		}
+		if err := getJSON(req, &page); err != nil {
+			return total, err
+		}
+		if !page.Success {
+			return total, fmt.Errorf("gumroad request failed")
+		}
+
+		for _, sale := range page.Sales {
+			if !sale.Refunded {
+				total += sale.Price
+			}
+		}
+		if page.NextPageKey == "" {
+			return total, nil
+		}
+		pageKey = page.NextPageKey
+	}
+}
+
+func getJSON(req *http.Request, out interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func monthRange(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// FormatCents renders an amount as whole dollars ("$1,234"), for
+// dashboards; Decimal keeps the cents
+func FormatCents(cents int) string {
+	dollars := cents / 100
+	s := strconv.Itoa(dollars)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return "$" + s
+}
+
+// Decimal renders an amount exactly, as a plain decimal ("1234.50") that
+// spreadsheets and accounting imports parse reliably
+func Decimal(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
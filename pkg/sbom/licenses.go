@@ -0,0 +1,205 @@
+package sbom
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// License classes, from least to most restrictive
+const (
+	Permissive   = "permissive"
+	WeakCopyleft = "weak-copyleft" // LGPL, MPL, EPL: changes to the library itself must be shared
+	Copyleft     = "copyleft"      // GPL, AGPL, SSPL: the whole work must be shared
+	Unknown      = "unknown"       // no license, proprietary, or one not recognized
+)
+
+var classRank = map[string]int{Permissive: 0, WeakCopyleft: 1, Copyleft: 2, Unknown: 3}
+
+// licensePrefixes classify SPDX identifiers by prefix, the first match
+// winning (so CC-BY-SA comes before CC-BY)
+var licensePrefixes = []struct {
+	prefix string
+	class  string
+}{
+	{"AGPL-", Copyleft}, {"GPL-", Copyleft}, {"SSPL-", Copyleft}, {"OSL-", Copyleft},
+	{"EUPL-", Copyleft}, {"CC-BY-SA-", Copyleft}, {"Sleepycat", Copyleft}, {"RPL-", Copyleft},
+	{"LGPL-", WeakCopyleft}, {"MPL-", WeakCopyleft}, {"EPL-", WeakCopyleft}, {"CDDL-", WeakCopyleft},
+	{"CPL-", WeakCopyleft}, {"CC-BY-NC", Unknown}, {"CC-BY-ND", Unknown},
+	{"MIT", Permissive}, {"BSD-", Permissive}, {"0BSD", Permissive}, {"Apache-", Permissive},
+	{"ISC", Permissive}, {"Unlicense", Permissive}, {"Zlib", Permissive}, {"CC0-", Permissive},
+	{"CC-BY-", Permissive}, {"Python-", Permissive}, {"PSF-", Permissive}, {"BlueOak-", Permissive},
+	{"Artistic-2", Permissive}, {"WTFPL", Permissive}, {"BSL-1.0", Permissive}, {"Unicode-", Permissive},
+	{"OFL-", Permissive}, {"X11", Permissive}, {"PostgreSQL", Permissive}, {"Ruby", Permissive},
+}
+
+// Classify sorts a license expression into Permissive, WeakCopyleft,
+// Copyleft, or Unknown. Of alternatives (OR) the least restrictive
+// counts; of licenses that all apply (AND) the most restrictive.
+func Classify(expr string) string {
+	best := ""
+	for _, alt := range alternatives(expr) {
+		worst := ""
+		for _, id := range alt {
+			if c := classifyID(id); worst == "" || classRank[c] > classRank[worst] {
+				worst = c
+			}
+		}
+		if best == "" || classRank[worst] < classRank[best] {
+			best = worst
+		}
+	}
+	if best == "" {
+		return Unknown
+	}
+	return best
+}
+
+// alternatives splits a license expression into the sets of licenses
+// that satisfy it, dropping WITH exceptions. Parentheses are ignored, so
+// a nested expression reads as a flat list of alternatives.
+func alternatives(expr string) [][]string {
+	expr = strings.NewReplacer("(", " ", ")", " ").Replace(expr)
+	var alts [][]string
+	for _, alt := range strings.Split(expr, " OR ") {
+		var ids []string
+		for _, id := range strings.Split(alt, " AND ") {
+			id, _, _ = strings.Cut(strings.TrimSpace(id), " WITH ")
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > 0 {
+			alts = append(alts, ids)
+		}
+	}
+	return alts
+}
+
+// classifyID classifies one license, an SPDX identifier or, from older
+// package metadata, a name such as "Apache License 2.0"
+func classifyID(id string) string {
+	for _, p := range licensePrefixes {
+		if strings.HasPrefix(id, p.prefix) {
+			return p.class
+		}
+	}
+	name := strings.ToLower(id)
+	switch {
+	case strings.Contains(name, "affero"):
+		return Copyleft
+	case strings.Contains(name, "lesser") || strings.Contains(name, "lgpl") || strings.Contains(name, "mozilla"):
+		return WeakCopyleft
+	case strings.Contains(name, "gpl") || strings.Contains(name, "general public"):
+		return Copyleft
+	case strings.HasPrefix(name, "mit") || strings.Contains(name, "apache") || strings.Contains(name, "bsd") ||
+		name == "isc" || strings.Contains(name, "public domain"):
+		return Permissive
+	}
+	return Unknown
+}
+
+// Policy says which licenses a project accepts. Entries are SPDX
+// identifiers, patterns such as "GPL-*", or a class (Copyleft,
+// WeakCopyleft). Allow wins over Deny.
+type Policy struct {
+	Allow []string
+	Deny  []string
+}
+
+// DefaultPolicy denies copyleft licenses, the usual rule for code that
+// ships closed
+var DefaultPolicy = Policy{Deny: []string{Copyleft}}
+
+// denied reports whether the policy rules out one license
+func (p Policy) denied(id string) bool {
+	return p.matches(p.Deny, id) && !p.matches(p.Allow, id)
+}
+
+func (p Policy) matches(patterns []string, id string) bool {
+	class := classifyID(id)
+	for _, pat := range patterns {
+		if strings.EqualFold(pat, class) || strings.EqualFold(pat, id) {
+			return true
+		}
+		if ok, _ := path.Match(pat, id); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Permits reports whether a license expression satisfies the policy:
+// some alternative has no denied license in it. An empty expression is
+// permitted; Report lists it as unlicensed instead.
+func (p Policy) Permits(expr string) bool {
+	alts := alternatives(expr)
+	if len(alts) == 0 {
+		return true
+	}
+	for _, alt := range alts {
+		ok := true
+		for _, id := range alt {
+			if p.denied(id) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// LicenseUse is one license and the components under it
+type LicenseUse struct {
+	License    string
+	Class      string
+	Components []string // name@version
+}
+
+// Report is a license compliance report
+type Report struct {
+	Licenses   []LicenseUse // most used first
+	Denied     []Component  // under a license the policy rules out
+	Unlicensed []Component  // with no license in the SBOM
+}
+
+// Flagged is how many components need a look
+func (r Report) Flagged() int {
+	return len(r.Denied) + len(r.Unlicensed)
+}
+
+// Report groups the SBOM's components by license and flags those the
+// policy denies or that name no license
+func (s *SBOM) Report(p Policy) Report {
+	var r Report
+	byLicense := make(map[string]*LicenseUse)
+	for _, c := range s.Components {
+		license := c.License()
+		if license == "" {
+			r.Unlicensed = append(r.Unlicensed, c)
+			continue
+		}
+		if !p.Permits(license) {
+			r.Denied = append(r.Denied, c)
+		}
+		use := byLicense[license]
+		if use == nil {
+			use = &LicenseUse{License: license, Class: Classify(license)}
+			byLicense[license] = use
+		}
+		use.Components = append(use.Components, c.ID())
+	}
+	for _, use := range byLicense {
+		r.Licenses = append(r.Licenses, *use)
+	}
+	sort.Slice(r.Licenses, func(i, j int) bool {
+		if len(r.Licenses[i].Components) != len(r.Licenses[j].Components) {
+			return len(r.Licenses[i].Components) > len(r.Licenses[j].Components)
+		}
+		return r.Licenses[i].License < r.Licenses[j].License
+	})
+	return r
+}
@@ -0,0 +1,169 @@
+// Package sbom reads software bills of materials in CycloneDX JSON, as
+// syft, cdxgen, npm, and cyclonedx-gomod write them, and turns one into
+// an SPDX document, a license compliance report, and the list of known
+// vulnerabilities a scanner found in its components.
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Formats an SBOM can be exported in
+const (
+	CycloneDX = "cyclonedx"
+	SPDX      = "spdx"
+)
+
+// SBOM is a project's bill of materials
+type SBOM struct {
+	Name       string      `json:"name"` // the project, as the generator named it
+	Version    string      `json:"version,omitempty"`
+	Tool       string      `json:"tool,omitempty"` // what generated it
+	Generated  time.Time   `json:"generated"`
+	Components []Component `json:"components"`
+}
+
+// Component is one dependency
+type Component struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version,omitempty"`
+	Type     string   `json:"type,omitempty"` // library, framework, application, ...
+	PURL     string   `json:"purl,omitempty"` // package URL, e.g. pkg:npm/react@18.2.0
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+// License is the component's license as one SPDX expression, "" when the
+// SBOM doesn't say
+func (c Component) License() string {
+	switch len(c.Licenses) {
+	case 0:
+		return ""
+	case 1:
+		return c.Licenses[0]
+	}
+	// CycloneDX lists every license a package declares; all of them apply
+	parts := make([]string, len(c.Licenses))
+	for i, l := range c.Licenses {
+		parts[i] = l
+		if strings.Contains(l, " ") {
+			parts[i] = "(" + l + ")"
+		}
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// ID is the component as name@version
+func (c Component) ID() string {
+	if c.Version == "" {
+		return c.Name
+	}
+	return c.Name + "@" + c.Version
+}
+
+// cdxDoc is the part of a CycloneDX document an SBOM is read from
+type cdxDoc struct {
+	BOMFormat string `json:"bomFormat"`
+	Metadata  struct {
+		Timestamp time.Time       `json:"timestamp"`
+		Tools     json.RawMessage `json:"tools"`
+		Component *cdxComponent   `json:"component"`
+	} `json:"metadata"`
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxTool struct {
+	Vendor  string `json:"vendor"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Parse reads a CycloneDX JSON document. Nested components (a module's
+// packages) are flattened.
+func Parse(data []byte) (*SBOM, error) {
+	var doc cdxDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.BOMFormat != "CycloneDX" {
+		return nil, errors.New("sbom: not a CycloneDX document")
+	}
+	s := &SBOM{Generated: doc.Metadata.Timestamp, Tool: toolName(doc.Metadata.Tools)}
+	if root := doc.Metadata.Component; root != nil {
+		s.Name, s.Version = qualifiedName(*root), root.Version
+	}
+
+	var walk func([]cdxComponent)
+	walk = func(list []cdxComponent) {
+		for _, c := range list {
+			comp := Component{Name: qualifiedName(c), Version: c.Version, Type: c.Type, PURL: c.PURL}
+			for _, l := range c.Licenses {
+				switch {
+				case l.Expression != "":
+					comp.Licenses = append(comp.Licenses, l.Expression)
+				case l.License.ID != "":
+					comp.Licenses = append(comp.Licenses, l.License.ID)
+				case l.License.Name != "":
+					comp.Licenses = append(comp.Licenses, l.License.Name)
+				}
+			}
+			s.Components = append(s.Components, comp)
+			walk(c.Components)
+		}
+	}
+	walk(doc.Components)
+	return s, nil
+}
+
+// qualifiedName joins a component's group (npm scope, Maven group) to its
+// name
+func qualifiedName(c cdxComponent) string {
+	switch {
+	case c.Group == "":
+		return c.Name
+	case strings.HasPrefix(c.Group, "@"):
+		return c.Group + "/" + c.Name
+	}
+	return c.Group + ":" + c.Name
+}
+
+// toolName reads the generator's name from metadata.tools, a list of
+// tools before CycloneDX 1.5 and an object of components since
+func toolName(raw json.RawMessage) string {
+	var list []cdxTool
+	if json.Unmarshal(raw, &list) != nil {
+		var obj struct {
+			Components []cdxTool `json:"components"`
+		}
+		json.Unmarshal(raw, &obj)
+		list = obj.Components
+	}
+	for _, t := range list {
+		if t.Name == "" {
+			continue
+		}
+		if t.Version != "" {
+			return t.Name + " " + t.Version
+		}
+		return t.Name
+	}
+	return ""
+}
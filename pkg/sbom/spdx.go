@@ -0,0 +1,129 @@
+package sbom
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type spdxDoc struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string       `json:"name"`
+	SPDXID           string       `json:"SPDXID"`
+	VersionInfo      string       `json:"versionInfo,omitempty"`
+	DownloadLocation string       `json:"downloadLocation"`
+	FilesAnalyzed    bool         `json:"filesAnalyzed"`
+	LicenseConcluded string       `json:"licenseConcluded"`
+	LicenseDeclared  string       `json:"licenseDeclared"`
+	CopyrightText    string       `json:"copyrightText"`
+	ExternalRefs     []spdxExtRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExtRef struct {
+	Category string `json:"referenceCategory"`
+	Type     string `json:"referenceType"`
+	Locator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	Element string `json:"spdxElementId"`
+	Type    string `json:"relationshipType"`
+	Related string `json:"relatedSpdxElement"`
+}
+
+// spdxIDUnsafe matches what an SPDX identifier can't contain
+var spdxIDUnsafe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// SPDXJSON writes the SBOM as an SPDX 2.3 JSON document: the project as
+// the described package, depending on each component. Licenses the SBOM
+// doesn't name are NOASSERTION.
+func (s *SBOM) SPDXJSON() ([]byte, error) {
+	name := s.Name
+	if name == "" {
+		name = "project"
+	}
+	nonce := make([]byte, 8)
+	rand.Read(nonce)
+	created := s.Generated
+	if created.IsZero() {
+		created = time.Now()
+	}
+	creators := []string{"Tool: mc"}
+	if s.Tool != "" {
+		creators = append(creators, "Tool: "+s.Tool)
+	}
+
+	doc := spdxDoc{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + spdxIDUnsafe.ReplaceAllString(name, "-") + "-" + hex.EncodeToString(nonce),
+		CreationInfo:      spdxCreationInfo{Created: created.UTC().Format(time.RFC3339), Creators: creators},
+	}
+	root := spdxPackage{Name: name, SPDXID: "SPDXRef-Package-root", VersionInfo: s.Version, DownloadLocation: "NOASSERTION",
+		LicenseConcluded: "NOASSERTION", LicenseDeclared: "NOASSERTION", CopyrightText: "NOASSERTION"}
+	doc.Packages = append(doc.Packages, root)
+	doc.Relationships = append(doc.Relationships, spdxRelationship{"SPDXRef-DOCUMENT", "DESCRIBES", root.SPDXID})
+
+	for i, c := range s.Components {
+		license := c.License()
+		if !isSPDXExpression(license) {
+			license = "NOASSERTION"
+		}
+		p := spdxPackage{
+			Name:             c.Name,
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d-%s", i+1, spdxIDUnsafe.ReplaceAllString(c.Name, "-")),
+			VersionInfo:      c.Version,
+			DownloadLocation: "NOASSERTION",
+			LicenseConcluded: "NOASSERTION",
+			LicenseDeclared:  license,
+			CopyrightText:    "NOASSERTION",
+		}
+		if c.PURL != "" {
+			p.ExternalRefs = []spdxExtRef{{"PACKAGE-MANAGER", "purl", c.PURL}}
+		}
+		doc.Packages = append(doc.Packages, p)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{root.SPDXID, "DEPENDS_ON", p.SPDXID})
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// spdxLicenseID matches an SPDX license or exception identifier
+var spdxLicenseID = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+
+// isSPDXExpression reports whether a license reads as an SPDX expression
+// (identifiers joined by AND, OR, and WITH) rather than a free-text name
+// such as "Apache License 2.0", which SPDX can't take as is
+func isSPDXExpression(license string) bool {
+	tokens := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(license))
+	if len(tokens)%2 == 0 {
+		return false
+	}
+	for i, t := range tokens {
+		operator := t == "AND" || t == "OR" || t == "WITH"
+		if operator != (i%2 == 1) || (!operator && !spdxLicenseID.MatchString(t)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package sbom
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Severities, from most to least severe
+const (
+	Critical = "critical"
+	High     = "high"
+	Medium   = "medium"
+	Low      = "low"
+)
+
+var severityRank = map[string]int{Critical: 0, High: 1, Medium: 2, Low: 3}
+
+// Vuln is a known vulnerability in one of an SBOM's components
+type Vuln struct {
+	ID       string `json:"id"` // CVE, GHSA, ...
+	Package  string `json:"package"`
+	Version  string `json:"version"`
+	Severity string `json:"severity"` // Critical, High, Medium, Low, or "" when unrated
+	FixedIn  string `json:"fixed_in,omitempty"`
+	Summary  string `json:"summary,omitempty"`
+}
+
+// Scan is the result of scanning an SBOM for vulnerabilities
+type Scan struct {
+	Scanner   string    `json:"scanner"`
+	ScannedAt time.Time `json:"scanned_at"`
+	Vulns     []Vuln    `json:"vulns"` // most severe first
+}
+
+// BySeverity counts the vulnerabilities of each severity
+func (s *Scan) BySeverity() map[string]int {
+	counts := make(map[string]int)
+	for _, v := range s.Vulns {
+		counts[v.Severity]++
+	}
+	return counts
+}
+
+// sortVulns orders vulnerabilities most severe first, then by package
+func sortVulns(vulns []Vuln) {
+	rank := func(sev string) int {
+		if r, ok := severityRank[sev]; ok {
+			return r
+		}
+		return len(severityRank)
+	}
+	sort.SliceStable(vulns, func(i, j int) bool {
+		if rank(vulns[i].Severity) != rank(vulns[j].Severity) {
+			return rank(vulns[i].Severity) < rank(vulns[j].Severity)
+		}
+		return vulns[i].Package < vulns[j].Package
+	})
+}
+
+// normalizeSeverity maps a scanner's severity name to one of ours
+func normalizeSeverity(s string) string {
+	switch strings.ToLower(s) {
+	case "critical":
+		return Critical
+	case "high":
+		return High
+	case "medium", "moderate":
+		return Medium
+	case "low", "negligible":
+		return Low
+	}
+	return ""
+}
+
+// cvssSeverity rates a CVSS base score
+func cvssSeverity(score float64) string {
+	switch {
+	case score >= 9:
+		return Critical
+	case score >= 7:
+		return High
+	case score >= 4:
+		return Medium
+	case score > 0:
+		return Low
+	}
+	return ""
+}
+
+// ParseGrype reads `grype sbom:<file> -o json` output
+func ParseGrype(data []byte) ([]Vuln, error) {
+	var out struct {
+		Matches []struct {
+			Vulnerability struct {
+				ID          string `json:"id"`
+				Severity    string `json:"severity"`
+				Description string `json:"description"`
+				Fix         struct {
+					Versions []string `json:"versions"`
+				} `json:"fix"`
+			} `json:"vulnerability"`
+			Artifact struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"artifact"`
+		} `json:"matches"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	vulns := make([]Vuln, 0, len(out.Matches))
+	for _, m := range out.Matches {
+		v := Vuln{
+			ID:       m.Vulnerability.ID,
+			Package:  m.Artifact.Name,
+			Version:  m.Artifact.Version,
+			Severity: normalizeSeverity(m.Vulnerability.Severity),
+			Summary:  m.Vulnerability.Description,
+		}
+		if len(m.Vulnerability.Fix.Versions) > 0 {
+			v.FixedIn = m.Vulnerability.Fix.Versions[0]
+		}
+		vulns = append(vulns, v)
+	}
+	sortVulns(vulns)
+	return vulns, nil
+}
+
+// ParseOSV reads `osv-scanner --format json` output. Aliases of one
+// vulnerability (its CVE and GHSA) are reported once.
+func ParseOSV(data []byte) ([]Vuln, error) {
+	var out struct {
+		Results []struct {
+			Packages []struct {
+				Package struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+				} `json:"package"`
+				Vulnerabilities []struct {
+					ID       string `json:"id"`
+					Summary  string `json:"summary"`
+					Affected []struct {
+						Ranges []struct {
+							Events []struct {
+								Fixed string `json:"fixed"`
+							} `json:"events"`
+						} `json:"ranges"`
+					} `json:"affected"`
+					DatabaseSpecific struct {
+						Severity string `json:"severity"`
+					} `json:"database_specific"`
+				} `json:"vulnerabilities"`
+				Groups []struct {
+					IDs         []string `json:"ids"`
+					MaxSeverity string   `json:"max_severity"`
+				} `json:"groups"`
+			} `json:"packages"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	var vulns []Vuln
+	for _, r := range out.Results {
+		for _, p := range r.Packages {
+			// Each group is one vulnerability under all its IDs; report
+			// it under the first
+			groupOf := make(map[string]int)
+			for i, g := range p.Groups {
+				for _, id := range g.IDs {
+					groupOf[id] = i
+				}
+			}
+			seen := make(map[int]bool)
+			for _, ov := range p.Vulnerabilities {
+				v := Vuln{ID: ov.ID, Package: p.Package.Name, Version: p.Package.Version, Summary: ov.Summary,
+					Severity: normalizeSeverity(ov.DatabaseSpecific.Severity)}
+				if g, ok := groupOf[ov.ID]; ok {
+					if seen[g] {
+						continue
+					}
+					seen[g] = true
+					if score, err := strconv.ParseFloat(p.Groups[g].MaxSeverity, 64); err == nil && v.Severity == "" {
+						v.Severity = cvssSeverity(score)
+					}
+				}
+				for _, a := range ov.Affected {
+					for _, rg := range a.Ranges {
+						for _, e := range rg.Events {
+							if e.Fixed != "" && v.FixedIn == "" {
+								v.FixedIn = e.Fixed
+							}
+						}
+					}
+				}
+				vulns = append(vulns, v)
+			}
+		}
+	}
+	sortVulns(vulns)
+	return vulns, nil
+}
@@ -0,0 +1,170 @@
+// Package secrets tracks when each project's credentials (API keys,
+// deploy tokens, database passwords) were last rotated. Providers report
+// when the secrets they hold last changed; rotations done elsewhere are
+// logged by hand to ~/.hustlemc/secrets.json. A secret older than the
+// rotation policy is stale.
+package secrets
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/chores"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// DefaultPolicy is the rotation interval when config doesn't set one
+const DefaultPolicy = "90d"
+
+// Where a secret's rotation date came from
+const (
+	SourceManual = "manual" // logged with mc secrets rotated
+	SourceGitHub = "github" // GitHub Actions secret
+	SourceVercel = "vercel" // Vercel environment variable
+)
+
+var mu sync.Mutex
+
+// Secret is one credential and when it was last rotated
+type Secret struct {
+	Name    string
+	Source  string
+	Rotated time.Time // zero when never known to be rotated
+}
+
+// Due is when the secret should next be rotated under policy, zero when
+// it has never been rotated (and so is already due)
+func (s Secret) Due(policy string) time.Time {
+	if s.Rotated.IsZero() {
+		return time.Time{}
+	}
+	days, months, err := chores.ParseEvery(policy)
+	if err != nil {
+		days, months, _ = chores.ParseEvery(DefaultPolicy)
+	}
+	return s.Rotated.AddDate(0, months, days)
+}
+
+// Stale reports whether the secret is past due for rotation at now
+func (s Secret) Stale(policy string, now time.Time) bool {
+	return s.Rotated.IsZero() || now.After(s.Due(policy))
+}
+
+// Policy returns a project's rotation interval: its own, else the
+// global one, else DefaultPolicy
+func Policy(cfg *config.Config, project string) string {
+	if every := cfg.Project(project).RotateEvery; every != "" {
+		return every
+	}
+	if cfg.RotateEvery != "" {
+		return cfg.RotateEvery
+	}
+	return DefaultPolicy
+}
+
+// Rotation is a rotation logged by hand
+type Rotation struct {
+	Project string    `json:"project"`
+	Name    string    `json:"name"`
+	At      time.Time `json:"at"`
+}
+
+// Path returns the rotation log path
+func Path() string {
+	return filepath.Join(config.Dir(), "secrets.json")
+}
+
+// Load reads every logged rotation, oldest first
+func Load() ([]Rotation, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() ([]Rotation, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var log []Rotation
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// Log records that a project's secret was rotated at at
+func Log(project, name string, at time.Time) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	log, err := load()
+	if err != nil {
+		return err
+	}
+	log = append(log, Rotation{Project: project, Name: name, At: at})
+
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Inventory merges the secrets providers reported for a project with its
+// logged rotations: a logged rotation newer than what a provider says
+// wins, and secrets that are only logged are listed too. The result is
+// ordered oldest rotation first.
+func Inventory(project string, reported []Secret, log []Rotation) []Secret {
+	byName := make(map[string]*Secret)
+	var names []string
+	for _, s := range reported {
+		if have, ok := byName[s.Name]; ok {
+			// The same name from two providers: the older one is what
+			// needs rotating
+			if s.Rotated.Before(have.Rotated) {
+				*have = s
+			}
+			continue
+		}
+		s := s
+		byName[s.Name] = &s
+		names = append(names, s.Name)
+	}
+	for _, r := range log {
+		if r.Project != project {
+			continue
+		}
+		s, ok := byName[r.Name]
+		if !ok {
+			s = &Secret{Name: r.Name}
+			byName[r.Name] = s
+			names = append(names, r.Name)
+		}
+		if r.At.After(s.Rotated) {
+			s.Rotated, s.Source = r.At, SourceManual
+		}
+	}
+
+	inventory := make([]Secret, 0, len(names))
+	for _, name := range names {
+		inventory = append(inventory, *byName[name])
+	}
+	sort.SliceStable(inventory, func(i, j int) bool {
+		if !inventory[i].Rotated.Equal(inventory[j].Rotated) {
+			return inventory[i].Rotated.Before(inventory[j].Rotated)
+		}
+		return inventory[i].Name < inventory[j].Name
+	})
+	return inventory
+}
@@ -0,0 +1,65 @@
+// Package seen remembers when each project was last opened in mc, so
+// what changed since can be shown the next time it is
+package seen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+var mu sync.Mutex
+
+// Path returns the last-opened markers file
+func Path() string {
+	return filepath.Join(config.Dir(), "seen.json")
+}
+
+// Load returns when each project was last opened
+func Load() (map[string]time.Time, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() (map[string]time.Time, error) {
+	markers := make(map[string]time.Time)
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return markers, nil
+	}
+	if err != nil {
+		return markers, err
+	}
+	if err := json.Unmarshal(data, &markers); err != nil {
+		return make(map[string]time.Time), err
+	}
+	return markers, nil
+}
+
+// Mark records that project was opened at at, returning when it was
+// opened before (zero if never)
+func Mark(project string, at time.Time) (time.Time, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	markers, err := load()
+	if err != nil {
+		return time.Time{}, err
+	}
+	last := markers[project]
+	markers[project] = at
+
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return last, err
+	}
+	data, err := json.MarshalIndent(markers, "", "  ")
+	if err != nil {
+		return last, err
+	}
+	return last, os.WriteFile(Path(), data, 0644)
+}
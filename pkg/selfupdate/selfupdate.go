@@ -0,0 +1,271 @@
+// Package selfupdate checks GitHub releases for a newer mc and replaces
+// the running binary with it.
+//
+// Releases are expected to carry one raw binary per platform, named
+// mc_<GOOS>_<GOARCH>, and a checksums.txt in `sha256sum` format.
+package selfupdate
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// Version is the version mc was built as, set at link time:
+//
+//	go build -ldflags "-X github.com/michaelmonetized/mission-control/pkg/selfupdate.Version=v1.2.0" ./cmd/mc
+var Version = "dev"
+
+// Repo is where releases are published
+const Repo = "michaelmonetized/mission-control"
+
+// CheckTTL is how long a release check is reused before asking GitHub again
+const CheckTTL = 24 * time.Hour
+
+const checksumsAsset = "checksums.txt"
+
+var httpClient = &http.Client{Timeout: 5 * time.Minute}
+
+// Release is a published GitHub release
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// AssetName is the release asset built for this platform
+func AssetName() string {
+	return fmt.Sprintf("mc_%s_%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// Latest fetches the newest published release
+func Latest() (*Release, error) {
+	req, err := http.NewRequest("GET", "https://api.github.com/repos/"+Repo+"/releases/latest", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if cfg, err := config.Load(); err == nil {
+		if token := cfg.Token("github"); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.New("no releases published yet")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+
+	var rel Release
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// check is the cached result of the last release check
+type check struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+func checkPath() string {
+	return filepath.Join(config.Dir(), "update-check.json")
+}
+
+// Available returns the newest release tag if it is newer than this
+// build, or "" if it isn't. GitHub is asked at most once per CheckTTL.
+// Development builds never report an update.
+func Available() (string, error) {
+	if !IsRelease(Version) {
+		return "", nil
+	}
+
+	var c check
+	if data, err := os.ReadFile(checkPath()); err == nil {
+		json.Unmarshal(data, &c)
+	}
+	if time.Since(c.CheckedAt) >= CheckTTL {
+		rel, err := Latest()
+		if err != nil {
+			return "", err
+		}
+		c = check{CheckedAt: time.Now(), Latest: rel.Tag}
+		if data, err := json.Marshal(c); err == nil {
+			os.MkdirAll(config.Dir(), 0755)
+			os.WriteFile(checkPath(), data, 0644)
+		}
+	}
+
+	if Newer(c.Latest, Version) {
+		return c.Latest, nil
+	}
+	return "", nil
+}
+
+// IsRelease reports whether v looks like a release version (v1.2.3)
+func IsRelease(v string) bool {
+	_, ok := parseVersion(v)
+	return ok
+}
+
+// Newer reports whether version a is newer than b. Versions that don't
+// parse are never newer.
+func Newer(a, b string) bool {
+	va, ok := parseVersion(a)
+	if !ok {
+		return false
+	}
+	vb, ok := parseVersion(b)
+	if !ok {
+		return false
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] > vb[i]
+		}
+	}
+	return false
+}
+
+// parseVersion reads "v1.2.3" (the "v" and any trailing parts optional),
+// ignoring pre-release and build suffixes
+func parseVersion(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if v == "" || len(parts) > 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// Apply downloads this platform's binary from rel, verifies it against
+// the release checksums, and swaps it in for the running executable. It
+// returns the path replaced.
+func Apply(rel *Release) (string, error) {
+	var binary, sums *Asset
+	for i, a := range rel.Assets {
+		switch a.Name {
+		case AssetName():
+			binary = &rel.Assets[i]
+		case checksumsAsset:
+			sums = &rel.Assets[i]
+		}
+	}
+	if binary == nil {
+		return "", fmt.Errorf("release %s has no %s binary", rel.Tag, AssetName())
+	}
+	if sums == nil {
+		return "", fmt.Errorf("release %s has no %s; refusing to install an unverified binary", rel.Tag, checksumsAsset)
+	}
+
+	want, err := expectedChecksum(sums.URL, binary.Name)
+	if err != nil {
+		return "", err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	// mc is usually a symlink into a checkout; replace the real file
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	// Download next to the executable so the final rename stays on one
+	// filesystem and is atomic
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".mc-upgrade-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	err = download(binary.URL, io.MultiWriter(tmp, h))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return "", fmt.Errorf("checksum mismatch for %s: got %s, want %s", binary.Name, got, want)
+	}
+
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		return "", err
+	}
+	return exe, nil
+}
+
+// expectedChecksum finds name's SHA-256 in a checksums.txt
+func expectedChecksum(url, name string) (string, error) {
+	var b strings.Builder
+	if err := download(url, &b); err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(b.String()))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// "<sha256>  <name>", with "*<name>" in binary mode
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s is not listed in %s", name, checksumsAsset)
+}
+
+func download(url string, w io.Writer) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s: status %d", url, resp.StatusCode)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
@@ -0,0 +1,145 @@
+// Package sentry reads a project's event volume from the Sentry API, so a
+// sudden rise in errors can set off an incident like a failed deploy or
+// a down probe does.
+package sentry
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultBaseURL is Sentry's SaaS API
+const DefaultBaseURL = "https://sentry.io/api/0"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no Sentry token (set tokens.sentry, MC_SENTRY_TOKEN, or SENTRY_AUTH_TOKEN)")
+
+const (
+	// Baseline is how many hours of events the last hour is compared to
+	Baseline = 24
+	// SpikeFactor is how many times its usual hourly count the last hour
+	// must reach to be a spike
+	SpikeFactor = 3
+	// MinSpikeEvents keeps quiet projects from spiking on a handful of
+	// errors
+	MinSpikeEvents = 10
+)
+
+// Client makes authenticated API requests
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for sentry.io using an auth token
+func NewClient(token string) *Client {
+	return &Client{httpapi.New("sentry", DefaultBaseURL, token, ErrNoToken)}
+}
+
+// FindToken looks for an auth token, in order: tokens.sentry (or
+// MC_SENTRY_TOKEN), then SENTRY_AUTH_TOKEN, which sentry-cli reads. It
+// returns "" when there is none.
+func FindToken(cfg *config.Config) string {
+	if cfg != nil {
+		if t := cfg.Token("sentry"); t != "" {
+			return t
+		}
+	}
+	return os.Getenv("SENTRY_AUTH_TOKEN")
+}
+
+// Default returns a client using the token FindToken finds, or nil when
+// there is none
+func Default() *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg); token != "" {
+		return NewClient(token)
+	}
+	return nil
+}
+
+// Project names a Sentry project by its organization and project slugs
+type Project struct {
+	Org  string
+	Slug string
+}
+
+func (p Project) String() string {
+	return p.Org + "/" + p.Slug
+}
+
+// ParseProject reads a project's "sentry" setting, "org/project"
+func ParseProject(s string) (Project, bool) {
+	org, slug, ok := strings.Cut(strings.Trim(strings.TrimSpace(s), "/"), "/")
+	if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+		return Project{}, false
+	}
+	return Project{Org: org, Slug: slug}, true
+}
+
+// HourlyEvents returns how many events the project received in each of
+// the last n hours, oldest first; the last hour is still in progress
+func (c *Client) HourlyEvents(p Project, n int) ([]int, error) {
+	q := url.Values{
+		"stat":       {"received"},
+		"resolution": {"1h"},
+		"since":      {strconv.FormatInt(time.Now().Add(-time.Duration(n)*time.Hour).Unix(), 10)},
+	}
+	var points [][2]float64 // [unix time, events]
+	if err := c.Get("projects/"+url.PathEscape(p.Org)+"/"+url.PathEscape(p.Slug)+"/stats/?"+q.Encode(), &points); err != nil {
+		return nil, err
+	}
+	counts := make([]int, len(points))
+	for i, pt := range points {
+		counts[i] = int(pt[1])
+	}
+	return counts, nil
+}
+
+// Spike is an hour with far more events than usual
+type Spike struct {
+	Events int     // in the hour
+	Usual  float64 // the average hour before it
+}
+
+func (s Spike) String() string {
+	return fmt.Sprintf("%d events in the last hour (usually %.0f)", s.Events, s.Usual)
+}
+
+// DetectSpike looks at hourly counts, oldest first, for a spike in the
+// last two: the hour in progress and the one before it, compared to the
+// average of the rest
+func DetectSpike(counts []int) (Spike, bool) {
+	if len(counts) < 3 {
+		return Spike{}, false
+	}
+	recent := counts[len(counts)-2:]
+	before := counts[:len(counts)-2]
+	total := 0
+	for _, n := range before {
+		total += n
+	}
+	s := Spike{Events: max(recent[0], recent[1]), Usual: float64(total) / float64(len(before))}
+	if s.Events < MinSpikeEvents || float64(s.Events) < SpikeFactor*s.Usual {
+		return Spike{}, false
+	}
+	return s, true
+}
+
+// CheckSpike reads a project's last day of events and reports whether
+// the last hour spiked
+func (c *Client) CheckSpike(p Project) (Spike, bool, error) {
+	counts, err := c.HourlyEvents(p, Baseline+2)
+	if err != nil {
+		return Spike{}, false, err
+	}
+	s, ok := DetectSpike(counts)
+	return s, ok, nil
+}
@@ -0,0 +1,15 @@
+package services
+
+import "os/exec"
+
+// Windows has no process groups to signal; the shell is stopped directly
+
+func setProcessGroup(*exec.Cmd) {}
+
+func terminate(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+func kill(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,362 @@
+// Package services runs a project's long-running commands (dev servers,
+// docker compose, webhook forwarders) as a group, foreman style: start and
+// stop them together, check their health, and keep their output as one
+// interleaved log. Services are defined per project in config.json and
+// live as long as the Manager's process.
+package services
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/shellenv"
+)
+
+const (
+	// LogLines is how many lines of output a Manager keeps
+	LogLines = 1000
+
+	// StopTimeout is how long a service gets to exit after SIGTERM
+	StopTimeout = 5 * time.Second
+
+	// healthTimeout bounds one health check
+	healthTimeout = 2 * time.Second
+)
+
+// State is where a service is in its lifecycle
+type State int
+
+const (
+	Stopped State = iota
+	Running
+	Exited // ended on its own, cleanly
+	Failed // ended on its own with an error, or never started
+)
+
+// String returns the state as shown in lists
+func (s State) String() string {
+	switch s {
+	case Running:
+		return "running"
+	case Exited:
+		return "exited"
+	case Failed:
+		return "failed"
+	default:
+		return "stopped"
+	}
+}
+
+// Status is a service's current state
+type Status struct {
+	Project string
+	Service config.Service
+	State   State
+	PID     int
+	Started time.Time
+	Ended   time.Time
+	Err     error // why it failed
+
+	// Health is the last check's result: nil when healthy or unchecked
+	// (see Checked)
+	Health  error
+	Checked time.Time
+}
+
+// Line is one line of a service's output
+type Line struct {
+	Project string
+	Service string
+	Time    time.Time
+	Text    string
+}
+
+// proc is a service's latest run
+type proc struct {
+	status Status
+	cmd    *exec.Cmd
+	done   chan struct{} // closed once the process is reaped
+	stop   bool          // Stop was asked for, so exiting isn't a failure
+}
+
+// Manager starts, stops, and watches services
+type Manager struct {
+	// OnLine, when set, is called with each line of output as it is
+	// read, from the reading goroutine
+	OnLine func(Line)
+
+	mu    sync.Mutex
+	procs map[string]*proc // by key(project, service)
+	logs  []Line
+}
+
+// NewManager returns a manager with nothing running
+func NewManager() *Manager {
+	return &Manager{procs: make(map[string]*proc)}
+}
+
+func key(project, service string) string {
+	return project + "\x00" + service
+}
+
+// Start runs svc for the project at dir unless it is already running.
+// The command runs with sh -c in the project's direnv/mise environment.
+func (m *Manager) Start(project, dir string, svc config.Service) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k := key(project, svc.Name)
+	if p, ok := m.procs[k]; ok && p.status.State == Running {
+		return nil
+	}
+
+	cmd := exec.Command("sh", "-c", svc.Command)
+	workDir := dir
+	if svc.Dir != "" {
+		workDir = filepath.Join(dir, svc.Dir)
+	}
+	shellenv.Apply(cmd, workDir)
+	setProcessGroup(cmd)
+
+	p := &proc{
+		status: Status{Project: project, Service: svc, Started: time.Now()},
+		cmd:    cmd,
+		done:   make(chan struct{}),
+	}
+	m.procs[k] = p
+
+	// One pipe for both streams keeps stdout and stderr in order
+	r, w := io.Pipe()
+	cmd.Stdout = w
+	cmd.Stderr = w
+	if err := cmd.Start(); err != nil {
+		w.Close()
+		p.status.State = Failed
+		p.status.Err = err
+		p.status.Ended = time.Now()
+		close(p.done)
+		return err
+	}
+	p.status.State = Running
+	p.status.PID = cmd.Process.Pid
+
+	go m.read(project, svc.Name, r)
+	go func() {
+		err := cmd.Wait()
+		w.Close()
+
+		m.mu.Lock()
+		p.status.Ended = time.Now()
+		switch {
+		case p.stop:
+			p.status.State = Stopped
+		case err != nil:
+			p.status.State = Failed
+			p.status.Err = err
+		default:
+			p.status.State = Exited
+		}
+		m.mu.Unlock()
+		close(p.done)
+	}()
+	return nil
+}
+
+// read collects a service's output into the shared log
+func (m *Manager) read(project, service string, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := Line{Project: project, Service: service, Time: time.Now(), Text: scanner.Text()}
+		m.mu.Lock()
+		m.logs = append(m.logs, line)
+		if len(m.logs) > LogLines {
+			m.logs = append([]Line(nil), m.logs[len(m.logs)-LogLines:]...)
+		}
+		onLine := m.OnLine
+		m.mu.Unlock()
+		if onLine != nil {
+			onLine(line)
+		}
+	}
+	// Drain whatever is left so the writer never blocks
+	io.Copy(io.Discard, r)
+}
+
+// Stop ends a service: SIGTERM to its process group, then SIGKILL if it
+// hasn't exited within StopTimeout
+func (m *Manager) Stop(project, service string) error {
+	m.mu.Lock()
+	p, ok := m.procs[key(project, service)]
+	if !ok || p.status.State != Running {
+		m.mu.Unlock()
+		return nil
+	}
+	p.stop = true
+	m.mu.Unlock()
+
+	if err := terminate(p.cmd); err != nil {
+		return err
+	}
+	select {
+	case <-p.done:
+		return nil
+	case <-time.After(StopTimeout):
+	}
+	if err := kill(p.cmd); err != nil {
+		return err
+	}
+	<-p.done
+	return nil
+}
+
+// StartAll starts each of a project's services, returning the failures
+func (m *Manager) StartAll(project, dir string, svcs []config.Service) error {
+	var errs []error
+	for _, svc := range svcs {
+		if err := m.Start(project, dir, svc); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", svc.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StopAll stops a project's running services side by side, or every
+// project's when project is ""
+func (m *Manager) StopAll(project string) error {
+	m.mu.Lock()
+	var targets [][2]string
+	for _, p := range m.procs {
+		if p.status.State == Running && (project == "" || p.status.Project == project) {
+			targets = append(targets, [2]string{p.status.Project, p.status.Service.Name})
+		}
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(targets))
+	for i, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.Stop(t[0], t[1]); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", t[1], err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Running reports how many of a project's services are running
+func (m *Manager) Running(project string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, p := range m.procs {
+		if p.status.Project == project && p.status.State == Running {
+			n++
+		}
+	}
+	return n
+}
+
+// Statuses returns the state of each configured service, in config
+// order. Services never started are Stopped.
+func (m *Manager) Statuses(project string, svcs []config.Service) []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Status, 0, len(svcs))
+	for _, svc := range svcs {
+		if p, ok := m.procs[key(project, svc.Name)]; ok {
+			s := p.status
+			s.Service = svc
+			out = append(out, s)
+			continue
+		}
+		out = append(out, Status{Project: project, Service: svc})
+	}
+	return out
+}
+
+// Logs returns the newest n lines of a project's service output, oldest
+// first, or every project's when project is ""
+func (m *Manager) Logs(project string, n int) []Line {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Line
+	for i := len(m.logs) - 1; i >= 0 && len(out) < n; i-- {
+		if project == "" || m.logs[i].Project == project {
+			out = append(out, m.logs[i])
+		}
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// CheckHealth runs the health check of each of a project's running
+// services that has one, recording the results for Statuses
+func (m *Manager) CheckHealth(project string) {
+	m.mu.Lock()
+	var checks []*proc
+	for _, p := range m.procs {
+		if p.status.Project == project && p.status.State == Running && p.status.Service.Health != "" {
+			checks = append(checks, p)
+		}
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, p := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := Check(p.status.Service.Health)
+			m.mu.Lock()
+			p.status.Health = err
+			p.status.Checked = time.Now()
+			m.mu.Unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+// Check probes a health target: an http(s) URL must answer below 400,
+// and a port (or host:port) must accept a connection
+func Check(target string) error {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		client := http.Client{Timeout: healthTimeout}
+		resp, err := client.Get(target)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		return nil
+	}
+
+	addr := target
+	if _, err := strconv.Atoi(target); err == nil {
+		addr = net.JoinHostPort("localhost", target)
+	}
+	conn, err := net.DialTimeout("tcp", addr, healthTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,177 @@
+// Package shellenv gives commands mc runs in a project the environment
+// the user's shell would have there: mise and direnv are asked to export
+// their variables, and asdf shims resolve because the command runs from
+// the project directory.
+package shellenv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Managers are the environment managers, in the order they are applied.
+// direnv comes last so an .envrc can build on what mise set up.
+var Managers = []string{"asdf", "mise", "direnv"}
+
+// markers are the files that mean a project uses a manager
+var markers = map[string][]string{
+	"asdf":   {".tool-versions"},
+	"mise":   {"mise.toml", ".mise.toml", "mise.local.toml", ".mise.local.toml", ".config/mise.toml", ".tool-versions"},
+	"direnv": {".envrc"},
+}
+
+// Detect lists the installed managers the project at dir is set up for,
+// in application order. mise takes over .tool-versions from asdf when
+// both are installed.
+func Detect(dir string) []string {
+	var found []string
+	for _, name := range Managers {
+		if _, err := exec.LookPath(name); err != nil {
+			continue
+		}
+		for _, marker := range markers[name] {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				found = append(found, name)
+				break
+			}
+		}
+	}
+	if len(found) > 1 && found[0] == "asdf" && found[1] == "mise" {
+		found = found[1:]
+	}
+	return found
+}
+
+// Applied reports what Apply did, for job logs and status messages
+type Applied struct {
+	Managers []string         // managers whose environment was loaded
+	Errors   map[string]error // managers that failed, leaving the command without them
+}
+
+// String summarizes the result, e.g. "env: mise, direnv (direnv: .envrc is blocked)"
+func (a Applied) String() string {
+	if len(a.Managers) == 0 && len(a.Errors) == 0 {
+		return ""
+	}
+	s := "env: " + strings.Join(a.Managers, ", ")
+	if len(a.Managers) == 0 {
+		s = "env: none"
+	}
+	if len(a.Errors) > 0 {
+		var failed []string
+		for _, name := range Managers {
+			if err, ok := a.Errors[name]; ok {
+				failed = append(failed, name+": "+err.Error())
+			}
+		}
+		s += " (" + strings.Join(failed, "; ") + ")"
+	}
+	return s
+}
+
+// Apply sets cmd up to run in dir with the project's environment. A
+// manager that fails is recorded and skipped rather than stopping the
+// command; the caller decides whether to mention it.
+func Apply(cmd *exec.Cmd, dir string) Applied {
+	cmd.Dir = dir
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+
+	var a Applied
+	for _, name := range Detect(dir) {
+		var vars map[string]*string
+		var err error
+		switch name {
+		case "asdf":
+			// Shims find .tool-versions from the working directory
+			a.Managers = append(a.Managers, name)
+			continue
+		case "mise":
+			vars, err = exportJSON(dir, env, "mise", "env", "--json")
+		case "direnv":
+			vars, err = exportJSON(dir, env, "direnv", "export", "json")
+		}
+		if err != nil {
+			if a.Errors == nil {
+				a.Errors = make(map[string]error)
+			}
+			a.Errors[name] = err
+			continue
+		}
+		env = merge(env, vars)
+		a.Managers = append(a.Managers, name)
+	}
+	cmd.Env = env
+	return a
+}
+
+// exportJSON runs a manager's export command, which prints the variables
+// to set as a JSON object (null values are unset)
+func exportJSON(dir string, env []string, name string, args ...string) (map[string]*string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	output, err := cmd.Output()
+	if err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			if msg := lastLine(string(exit.Stderr)); msg != "" {
+				// Applied.String already names the manager
+				return nil, errors.New(strings.TrimPrefix(msg, name+": "))
+			}
+		}
+		return nil, err
+	}
+	vars := make(map[string]*string)
+	if strings.TrimSpace(string(output)) == "" {
+		// direnv prints nothing when there is nothing to change
+		return vars, nil
+	}
+	if err := json.Unmarshal(output, &vars); err != nil {
+		return nil, fmt.Errorf("unexpected output: %w", err)
+	}
+	return vars, nil
+}
+
+// merge applies vars on top of env
+func merge(env []string, vars map[string]*string) []string {
+	if len(vars) == 0 {
+		return env
+	}
+	out := make([]string, 0, len(env)+len(vars))
+	for _, kv := range env {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, ok := vars[key]; !ok {
+			out = append(out, kv)
+		}
+	}
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// direnv's bookkeeping describes the shell's state, not this process's
+		if strings.HasPrefix(k, "DIRENV_") {
+			continue
+		}
+		if v := vars[k]; v != nil {
+			out = append(out, k+"="+*v)
+		}
+	}
+	return out
+}
+
+// lastLine returns the last non-empty line, where tools put the reason
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
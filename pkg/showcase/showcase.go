@@ -0,0 +1,382 @@
+// Package showcase keeps a web project's README images current: it loads
+// the production site in headless Chrome, saves a screenshot and a short
+// scroll-through GIF into the repository's assets, and points the README's
+// image links at them.
+package showcase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Width and Height are the browser window a screenshot shows
+const (
+	Width  = 1280
+	Height = 800
+)
+
+// pageHeight is how much of the page is rendered for the GIF to scroll
+// through
+const pageHeight = 4 * Height
+
+// captureTimeout bounds one Chrome run, page load included
+const captureTimeout = time.Minute
+
+// ErrNoChrome means no Chrome or Chromium could be found
+var ErrNoChrome = errors.New("no Chrome or Chromium found (install one, or set CHROME_PATH)")
+
+// assetDirs are where repositories keep README images, in the order
+// they're looked for; the first is created when none exist
+var assetDirs = []string{"assets", ".github/assets", "docs/assets", "docs/images", "images"}
+
+// Result is what a capture wrote
+type Result struct {
+	Screenshot string // relative to the project
+	GIF        string
+	Readme     bool // the README's links were changed
+}
+
+// Chrome finds a Chrome or Chromium binary: CHROME_PATH, else one on
+// PATH, else the macOS app bundles
+func Chrome() (string, error) {
+	if p := os.Getenv("CHROME_PATH"); p != "" {
+		return p, nil
+	}
+	for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "chrome"} {
+		if p, err := exec.LookPath(name); err == nil {
+			return p, nil
+		}
+	}
+	if runtime.GOOS == "darwin" {
+		for _, p := range []string{
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+		} {
+			if _, err := os.Stat(p); err == nil {
+				return p, nil
+			}
+		}
+	}
+	return "", ErrNoChrome
+}
+
+// Capture renders url and saves its screenshot and scroll-through GIF in
+// dir's assets, reusing the paths the README already links to. The
+// README gets a screenshot link when it has none.
+func Capture(dir, url string) (*Result, error) {
+	page, err := render(url)
+	if err != nil {
+		return nil, err
+	}
+	readme := filepath.Join(dir, "README.md")
+	text, err := os.ReadFile(readme)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	shot, anim := Targets(dir, string(text))
+	res := &Result{Screenshot: shot, GIF: anim}
+
+	top := image.NewRGBA(image.Rect(0, 0, Width, min(Height, page.Bounds().Dy())))
+	draw.Draw(top, top.Bounds(), page, page.Bounds().Min, draw.Src)
+	if err := writeImage(filepath.Join(dir, shot), func(f *os.File) error { return png.Encode(f, top) }); err != nil {
+		return nil, err
+	}
+	if err := writeImage(filepath.Join(dir, anim), func(f *os.File) error { return gif.EncodeAll(f, scrollThrough(page)) }); err != nil {
+		return nil, err
+	}
+
+	if text != nil {
+		updated := Relink(string(text), shot, anim, filepath.Base(dir))
+		if updated != string(text) {
+			if err := os.WriteFile(readme, []byte(updated), 0644); err != nil {
+				return nil, err
+			}
+			res.Readme = true
+		}
+	}
+	return res, nil
+}
+
+// render loads url in headless Chrome and returns it as rendered in a
+// Width-wide window, cut off below where the page ends
+func render(url string) (image.Image, error) {
+	chrome, err := Chrome()
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp("", "mc-showcase")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	out := filepath.Join(tmp, "page.png")
+
+	ctx, cancel := context.WithTimeout(context.Background(), captureTimeout)
+	defer cancel()
+	args := []string{
+		"--headless=new", "--disable-gpu", "--hide-scrollbars", "--mute-audio",
+		"--user-data-dir=" + filepath.Join(tmp, "profile"),
+		// Let scripts and web fonts settle before the shot
+		"--virtual-time-budget=5000",
+		fmt.Sprintf("--window-size=%d,%d", Width, pageHeight),
+		"--screenshot=" + out,
+	}
+	if os.Geteuid() == 0 {
+		// Chrome refuses to sandbox as root, e.g. in containers
+		args = append(args, "--no-sandbox")
+	}
+	cmd := exec.CommandContext(ctx, chrome, append(args, url)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("chrome: %s timed out", url)
+		}
+		return nil, fmt.Errorf("chrome: %v: %s", err, lastLine(string(output)))
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		return nil, fmt.Errorf("chrome wrote no screenshot: %w", err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	return trimBottom(img), nil
+}
+
+// trimBottom cuts the blank window below a page shorter than it, keeping
+// at least a viewport
+func trimBottom(img image.Image) image.Image {
+	b := img.Bounds()
+	blank := img.At(b.Min.X, b.Max.Y-1)
+	uniform := func(y int) bool {
+		for x := b.Min.X; x < b.Max.X; x += 4 {
+			if !sameColor(img.At(x, y), blank) {
+				return false
+			}
+		}
+		return true
+	}
+	bottom := b.Max.Y
+	for bottom > b.Min.Y+Height && uniform(bottom-1) {
+		bottom--
+	}
+	sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	})
+	if !ok || bottom == b.Max.Y {
+		return img
+	}
+	return sub.SubImage(image.Rect(b.Min.X, b.Min.Y, b.Max.X, bottom))
+}
+
+func sameColor(a, b color.Color) bool {
+	r1, g1, b1, _ := a.RGBA()
+	r2, g2, b2, _ := b.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2
+}
+
+// scrollThrough animates the page scrolling from top to bottom at half
+// size, pausing at each end
+func scrollThrough(page image.Image) *gif.GIF {
+	const (
+		scale  = 2
+		steps  = 30
+		delay  = 6   // hundredths of a second per frame
+		linger = 150 // at the top and bottom
+	)
+	w, h := Width/scale, Height/scale
+	small := quantize(shrink(page, scale))
+	h = min(h, small.Bounds().Dy())
+	scroll := small.Bounds().Dy() - h
+
+	anim := &gif.GIF{}
+	n := steps
+	if scroll == 0 {
+		n = 0
+	}
+	for i := 0; i <= n; i++ {
+		top := 0
+		if n > 0 {
+			top = scroll * i / n
+		}
+		frame := image.NewPaletted(image.Rect(0, 0, w, h), small.Palette)
+		for y := 0; y < h; y++ {
+			copy(frame.Pix[y*frame.Stride:], small.Pix[(top+y)*small.Stride:(top+y)*small.Stride+w])
+		}
+		d := delay
+		if i == 0 || i == n {
+			d = linger
+		}
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, d)
+	}
+	return anim
+}
+
+// shrink box-averages page down to 1/scale of its size
+func shrink(page image.Image, scale int) *image.RGBA {
+	b := page.Bounds()
+	w, h := b.Dx()/scale, b.Dy()/scale
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var r, g, bl, n uint32
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					px, py := b.Min.X+x*scale+dx, b.Min.Y+y*scale+dy
+					cr, cg, cb, _ := page.At(px, py).RGBA()
+					r, g, bl, n = r+cr, g+cg, bl+cb, n+1
+				}
+			}
+			out.Set(x, y, color.RGBA64{uint16(r / n), uint16(g / n), uint16(bl / n), 0xffff})
+		}
+	}
+	return out
+}
+
+// quantize maps an image onto the Plan 9 palette through a 15-bit color
+// lookup table, which is much faster than searching it per pixel. Every
+// frame then shares the same colors, so nothing flickers.
+func quantize(img *image.RGBA) *image.Paletted {
+	pal := color.Palette(palette.Plan9)
+	var table [1 << 15]uint8
+	for i := range table {
+		c := color.RGBA{uint8(i>>10)<<3 | 4, uint8(i>>5&31)<<3 | 4, uint8(i&31)<<3 | 4, 0xff}
+		table[i] = uint8(pal.Index(c))
+	}
+	b := img.Bounds()
+	out := image.NewPaletted(b, pal)
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			p := img.Pix[y*img.Stride+x*4:]
+			out.Pix[y*out.Stride+x] = table[int(p[0]>>3)<<10|int(p[1]>>3)<<5|int(p[2]>>3)]
+		}
+	}
+	return out
+}
+
+func writeImage(file string, encode func(*os.File) error) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := encode(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readmeImagePattern matches Markdown and HTML image links, capturing the
+// path in group 2 or 4
+var readmeImagePattern = regexp.MustCompile(`(!\[[^\]]*\]\()([^)\s]+)|(<img\s[^>]*src=["'])([^"']+)`)
+
+// local reports whether an image link is a file in the repository,
+// relative to the README
+func local(link string) bool {
+	return !strings.Contains(link, "://") && !strings.HasPrefix(link, "/") && !strings.HasPrefix(link, "data:")
+}
+
+// isScreenshot and isDemo tell the README's showcase images from its
+// logos and badges by name
+func isScreenshot(link string) bool {
+	base := strings.ToLower(path.Base(link))
+	return strings.Contains(base, "screenshot") || strings.Contains(base, "preview") || strings.HasPrefix(base, "screen")
+}
+
+func isDemo(link string) bool {
+	return strings.EqualFold(path.Ext(link), ".gif")
+}
+
+// Targets returns where the screenshot and GIF go, relative to dir: the
+// README's existing showcase images (as .png and .gif), else new files in
+// the repository's assets folder
+func Targets(dir, readme string) (screenshot, demo string) {
+	for _, m := range readmeImagePattern.FindAllStringSubmatch(readme, -1) {
+		link := strings.TrimPrefix(m[2]+m[4], "./")
+		if !local(link) {
+			continue
+		}
+		switch {
+		case screenshot == "" && isScreenshot(link) && !isDemo(link):
+			screenshot = strings.TrimSuffix(link, path.Ext(link)) + ".png"
+		case demo == "" && isDemo(link):
+			demo = link
+		}
+	}
+	assets := assetDirs[0]
+	for _, d := range assetDirs {
+		if info, err := os.Stat(filepath.Join(dir, d)); err == nil && info.IsDir() {
+			assets = d
+			break
+		}
+	}
+	if screenshot == "" {
+		screenshot = path.Join(assets, "screenshot.png")
+	}
+	if demo == "" {
+		demo = path.Join(assets, "demo.gif")
+	}
+	return screenshot, demo
+}
+
+// Relink points the README's first screenshot and GIF links at
+// screenshot and demo, adding a screenshot under the title when it shows
+// neither
+func Relink(readme, screenshot, demo, name string) string {
+	var shotLinked, demoLinked bool
+	readme = readmeImagePattern.ReplaceAllStringFunc(readme, func(match string) string {
+		m := readmeImagePattern.FindStringSubmatch(match)
+		prefix, link := m[1]+m[3], m[2]+m[4]
+		if !local(link) {
+			return match
+		}
+		switch {
+		case isDemo(link) && !demoLinked:
+			demoLinked = true
+			return prefix + demo
+		case isScreenshot(link) && !isDemo(link) && !shotLinked:
+			shotLinked = true
+			return prefix + screenshot
+		}
+		return match
+	})
+	if shotLinked || demoLinked {
+		return readme
+	}
+
+	link := fmt.Sprintf("![%s screenshot](%s)\n", name, screenshot)
+	lines := strings.SplitAfter(readme, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "# ") {
+			rest := strings.Join(lines[i+1:], "")
+			return strings.Join(lines[:i+1], "") + "\n" + link + "\n" + strings.TrimLeft(rest, "\n")
+		}
+	}
+	return link + "\n" + readme
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,170 @@
+// Package snippets keeps chat answers worth holding onto, such as a
+// deploy runbook or database restore steps, as named snippets attached
+// to a project (~/.hustlemc/snippets.json).
+package snippets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+var mu sync.Mutex
+
+// Snippet is a saved chat answer
+type Snippet struct {
+	ID      string    `json:"id"`
+	Project string    `json:"project"`
+	Name    string    `json:"name"`
+	Prompt  string    `json:"prompt,omitempty"` // the question it answered
+	Text    string    `json:"text"`
+	Created time.Time `json:"created"`
+}
+
+// Path returns the snippets file path
+func Path() string {
+	return filepath.Join(config.Dir(), "snippets.json")
+}
+
+// Load reads every project's snippets, oldest first
+func Load() ([]Snippet, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() ([]Snippet, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snippets []Snippet
+	if err := json.Unmarshal(data, &snippets); err != nil {
+		return nil, err
+	}
+	return snippets, nil
+}
+
+func save(snippets []Snippet) error {
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snippets, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// ForProject returns a project's snippets by name
+func ForProject(project string) ([]Snippet, error) {
+	all, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	var mine []Snippet
+	for _, s := range all {
+		if s.Project == project {
+			mine = append(mine, s)
+		}
+	}
+	sort.SliceStable(mine, func(i, j int) bool { return strings.ToLower(mine[i].Name) < strings.ToLower(mine[j].Name) })
+	return mine, nil
+}
+
+// Add saves text as a project's snippet called name, replacing one it
+// already has by that name
+func Add(project, name, prompt, text string) (Snippet, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name, text = strings.TrimSpace(name), strings.TrimSpace(text)
+	if name == "" {
+		return Snippet{}, fmt.Errorf("a snippet needs a name")
+	}
+	if text == "" {
+		return Snippet{}, fmt.Errorf("nothing to save")
+	}
+	snippets, err := load()
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	now := time.Now()
+	s := Snippet{
+		ID:      strconv.FormatInt(now.UnixNano(), 36),
+		Project: project,
+		Name:    name,
+		Prompt:  strings.TrimSpace(prompt),
+		Text:    text,
+		Created: now,
+	}
+	kept := snippets[:0]
+	for _, old := range snippets {
+		if old.Project != project || !strings.EqualFold(old.Name, name) {
+			kept = append(kept, old)
+		}
+	}
+	return s, save(append(kept, s))
+}
+
+// Remove deletes a snippet by ID
+func Remove(id string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	snippets, err := load()
+	if err != nil {
+		return err
+	}
+	var kept []Snippet
+	for _, s := range snippets {
+		if s.ID != id {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == len(snippets) {
+		return fmt.Errorf("no snippet %s", id)
+	}
+	return save(kept)
+}
+
+// Search returns the snippets whose name, prompt, or text contains every
+// word of query, ignoring case
+func Search(snippets []Snippet, query string) []Snippet {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return snippets
+	}
+	var found []Snippet
+	for _, s := range snippets {
+		haystack := strings.ToLower(s.Name + "\n" + s.Prompt + "\n" + s.Text)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(haystack, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// Context is a snippet as context for a chat message
+func (s Snippet) Context() string {
+	return fmt.Sprintf("Context, from my saved snippet %q:\n%s", s.Name, s.Text)
+}
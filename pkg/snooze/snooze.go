@@ -0,0 +1,255 @@
+// Package snooze mutes a project, or one of its alerts, until a chosen
+// time so it stops counting toward attention totals. Snoozes are kept in
+// ~/.hustlemc/snooze.json and drop out once they expire.
+package snooze
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// Alerts that can be snoozed on their own. An empty alert snoozes the
+// whole project.
+const (
+	AlertDeploy   = "deploy"   // a deploy failed
+	AlertBuild    = "build"    // Swift build failed
+	AlertBehind   = "behind"   // upstream commits not pulled
+	AlertAhead    = "ahead"    // local commits not pushed
+	AlertDirty    = "dirty"    // staged, untracked, or modified files
+	AlertIssues   = "issues"   // open GitHub issues
+	AlertPRs      = "prs"      // open pull requests
+	AlertCI       = "ci"       // GitHub Actions failing on the default branch
+	AlertDown     = "down"     // production failing its uptime probe
+	AlertSecurity = "security" // open Dependabot or code/secret scanning alerts
+	AlertErrors   = "errors"   // a spike in Sentry errors
+)
+
+// Alerts lists every snoozable alert
+var Alerts = []string{AlertDeploy, AlertBuild, AlertBehind, AlertAhead, AlertDirty, AlertIssues, AlertPRs, AlertCI, AlertDown, AlertSecurity, AlertErrors}
+
+var mu sync.Mutex
+
+// Entry is one snooze
+type Entry struct {
+	Project string    `json:"project"`
+	Alert   string    `json:"alert,omitempty"`
+	Until   time.Time `json:"until"`
+}
+
+// Label names what's snoozed, e.g. "api" or "api deploy"
+func (e Entry) Label() string {
+	if e.Alert == "" {
+		return e.Project
+	}
+	return e.Project + " " + e.Alert
+}
+
+// Path returns the snooze file path
+func Path() string {
+	return filepath.Join(config.Dir(), "snooze.json")
+}
+
+// Load reads all snoozes, including expired ones not yet cleared
+func Load() ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() ([]Entry, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func save(entries []Entry) error {
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Add snoozes a project (alert "") or one of its alerts until the given
+// time, replacing any existing snooze for the same thing
+func Add(project, alert string, until time.Time) error {
+	if alert != "" && !IsAlert(alert) {
+		return fmt.Errorf("unknown alert %q (want one of %s)", alert, strings.Join(Alerts, ", "))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Project != project || e.Alert != alert {
+			kept = append(kept, e)
+		}
+	}
+	return save(append(kept, Entry{Project: project, Alert: alert, Until: until}))
+}
+
+// Remove clears every snooze on a project
+func Remove(project string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Project != project {
+			kept = append(kept, e)
+		}
+	}
+	return save(kept)
+}
+
+// Expire drops snoozes that ended by now and returns them
+func Expire(now time.Time) ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := load()
+	if err != nil {
+		return nil, err
+	}
+	var kept, expired []Entry
+	for _, e := range entries {
+		if now.Before(e.Until) {
+			kept = append(kept, e)
+		} else {
+			expired = append(expired, e)
+		}
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+	return expired, save(kept)
+}
+
+// IsAlert reports whether name is a snoozable alert
+func IsAlert(name string) bool {
+	for _, a := range Alerts {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Set answers "is this muted?" for the snoozes active at a moment
+type Set map[string]time.Time
+
+// Active returns the snoozes still in effect at now
+func Active(entries []Entry, now time.Time) Set {
+	s := make(Set)
+	for _, e := range entries {
+		if now.Before(e.Until) {
+			s[e.Project+"\x00"+e.Alert] = e.Until
+		}
+	}
+	return s
+}
+
+// Mute adds a snooze to the set without saving it, for alerts muted by
+// something else (maintenance windows). An empty project mutes the alert
+// for every project.
+func (s Set) Mute(project, alert string, until time.Time) {
+	key := project + "\x00" + alert
+	if until.After(s[key]) {
+		s[key] = until
+	}
+}
+
+// Project reports whether the whole project is snoozed
+func (s Set) Project(project string) bool {
+	_, ok := s[project+"\x00"]
+	return ok
+}
+
+// Muted reports whether an alert is snoozed, directly or because its
+// project is
+func (s Set) Muted(project, alert string) bool {
+	if s.Project(project) {
+		return true
+	}
+	if _, ok := s["\x00"+alert]; ok {
+		return true
+	}
+	_, ok := s[project+"\x00"+alert]
+	return ok
+}
+
+// ParseUntil parses when a snooze ends: a duration ("30m", "2h", "3d",
+// "1w"), "tomorrow", a weekday ("monday"), or a date ("2026-11-01",
+// optionally with "15:04"). Days without a time resume at 9am.
+func ParseUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	morning := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 9, 0, 0, 0, now.Location())
+	}
+
+	if s == "tomorrow" {
+		return morning(now.AddDate(0, 0, 1)), nil
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			ahead := (int(d) - int(now.Weekday()) + 7) % 7
+			if ahead == 0 {
+				ahead = 7
+			}
+			return morning(now.AddDate(0, 0, ahead)), nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return morning(t), nil
+	}
+
+	if len(s) >= 2 {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n > 0 {
+			switch s[len(s)-1] {
+			case 'm':
+				return now.Add(time.Duration(n) * time.Minute), nil
+			case 'h':
+				return now.Add(time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, n), nil
+			case 'w':
+				return now.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (try 2h, 3d, 1w, tomorrow, monday, or YYYY-MM-DD)", s)
+}
@@ -0,0 +1,364 @@
+// Package sourcehut talks to the sr.ht GraphQL APIs, so projects hosted
+// on git.sr.ht show their todo.sr.ht tickets and builds.sr.ht jobs like
+// GitHub issues and workflow runs.
+package sourcehut
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// Domain is the public sourcehut instance, whose services live at
+// git.sr.ht, todo.sr.ht, builds.sr.ht, and so on
+const Domain = "sr.ht"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no sourcehut token (set tokens.sourcehut, MC_SOURCEHUT_TOKEN, or SRHT_TOKEN, or log in with hut)")
+
+// Client makes authenticated GraphQL requests. Token is a personal access
+// token from meta.sr.ht/oauth2; its grants limit which services answer.
+type Client struct {
+	*httpapi.Client
+	Domain string
+}
+
+// NewClient returns a client for the instance at domain using token
+func NewClient(domain, token string) *Client {
+	return &Client{Client: httpapi.New("sourcehut", "", token, ErrNoToken), Domain: domain}
+}
+
+// FindToken looks for a token, in order: tokens.sourcehut (or
+// MC_SOURCEHUT_TOKEN), SRHT_TOKEN, then the access token hut (the sr.ht
+// CLI) keeps for the instance. It returns "" when there is none.
+func FindToken(cfg *config.Config, domain string) string {
+	if cfg != nil {
+		if t := cfg.Token("sourcehut"); t != "" {
+			return t
+		}
+	}
+	if t := os.Getenv("SRHT_TOKEN"); t != "" {
+		return t
+	}
+	return hutToken(domain)
+}
+
+// hutToken reads hut's config, ~/.config/hut/config:
+//
+//	instance "sr.ht" {
+//		access-token "..."
+//	}
+func hutToken(domain string) string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	f, err := os.Open(filepath.Join(dir, "hut", "config"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	instance := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) >= 2 && fields[0] == "instance":
+			instance = strings.Trim(fields[1], `"`)
+		case len(fields) == 2 && fields[0] == "access-token" && instance == domain:
+			return strings.Trim(fields[1], `"`)
+		case len(fields) == 1 && fields[0] == "}":
+			instance = ""
+		}
+	}
+	return ""
+}
+
+// Default returns a client for the instance at domain using the token
+// FindToken finds, or nil when there is none
+func Default(domain string) *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg, domain); token != "" {
+		return NewClient(domain, token)
+	}
+	return nil
+}
+
+// Query runs a GraphQL query against one service ("todo", "builds", ...)
+// and decodes its data into out
+func (c *Client) Query(service, query string, vars map[string]any, out any) error {
+	return c.GraphQL("https://"+service+"."+c.Domain+"/query", query, vars, out)
+}
+
+// Repo names a repository on git.sr.ht
+type Repo struct {
+	Domain string // sr.ht
+	Owner  string // ~user
+	Name   string
+}
+
+func (r Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// WebURL is the repository's page
+func (r Repo) WebURL() string {
+	return "https://git." + r.Domain + "/" + r.Owner + "/" + url.PathEscape(r.Name)
+}
+
+// TrackerURL is the page of the tracker named after the repository,
+// where its tickets are filed by convention
+func (r Repo) TrackerURL() string {
+	return "https://todo." + r.Domain + "/" + r.Owner + "/" + url.PathEscape(r.Name)
+}
+
+// ParseRemote extracts the repository from a git.sr.ht remote URL:
+// https://git.sr.ht/~user/repo, git@git.sr.ht:~user/repo, or
+// ssh://git@git.sr.ht/~user/repo
+func ParseRemote(remote string) (Repo, bool) {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Repo{}, false
+		}
+		host, path = strings.ToLower(u.Hostname()), u.Path
+	default:
+		h, rest, ok := strings.Cut(remote, ":")
+		if !ok {
+			return Repo{}, false
+		}
+		if _, after, found := strings.Cut(h, "@"); found {
+			h = after
+		}
+		host, path = strings.ToLower(h), rest
+	}
+	if host != "git."+Domain {
+		return Repo{}, false
+	}
+	owner, name, ok := strings.Cut(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	if !ok || !strings.HasPrefix(owner, "~") || len(owner) < 2 || name == "" || strings.Contains(name, "/") {
+		return Repo{}, false
+	}
+	return Repo{Domain: Domain, Owner: owner, Name: name}, true
+}
+
+// Entity is a user as tickets and jobs name them
+type Entity struct {
+	CanonicalName string `json:"canonicalName"` // ~user
+}
+
+// Ticket is a todo.sr.ht ticket
+type Ticket struct {
+	ID        int       `json:"id"`
+	Subject   string    `json:"subject"`
+	Status    string    `json:"status"` // REPORTED, CONFIRMED, IN_PROGRESS, PENDING, RESOLVED
+	Created   time.Time `json:"created"`
+	Submitter Entity    `json:"submitter"`
+	Assignees []Entity  `json:"assignees"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// URL is the ticket's page
+func (t Ticket) URL(r Repo) string {
+	return r.TrackerURL() + "/" + strconv.Itoa(t.ID)
+}
+
+// maxPages bounds how many pages of tickets or jobs a call reads
+const maxPages = 5
+
+// Tickets lists the open tickets of the tracker named after the
+// repository, newest first. A repository without one has none.
+func (c *Client) Tickets(r Repo) ([]Ticket, error) {
+	const query = `query($owner: String!, $tracker: String!, $cursor: Cursor) {
+  trackerByOwner(owner: $owner, tracker: $tracker) {
+    tickets(cursor: $cursor) {
+      results { id subject status created submitter { canonicalName } assignees { canonicalName } labels { name } }
+      cursor
+    }
+  }
+}`
+	var tickets []Ticket
+	var cursor *string
+	for range maxPages {
+		var data struct {
+			Tracker *struct {
+				Tickets struct {
+					Results []Ticket `json:"results"`
+					Cursor  *string  `json:"cursor"`
+				} `json:"tickets"`
+			} `json:"trackerByOwner"`
+		}
+		vars := map[string]any{"owner": r.Owner, "tracker": r.Name, "cursor": cursor}
+		if err := c.Query("todo", query, vars, &data); err != nil {
+			return nil, err
+		}
+		if data.Tracker == nil {
+			return nil, nil
+		}
+		for _, t := range data.Tracker.Tickets.Results {
+			if t.Status != "RESOLVED" {
+				tickets = append(tickets, t)
+			}
+		}
+		if cursor = data.Tracker.Tickets.Cursor; cursor == nil {
+			break
+		}
+	}
+	return tickets, nil
+}
+
+// Me returns the authenticated user's canonical name, ~user
+func (c *Client) Me() (string, error) {
+	var data struct {
+		Me Entity `json:"me"`
+	}
+	err := c.Query("meta", `{ me { canonicalName } }`, nil, &data)
+	return data.Me.CanonicalName, err
+}
+
+// Job is a builds.sr.ht job
+type Job struct {
+	ID      int64     `json:"id"`
+	Status  string    `json:"status"` // PENDING, QUEUED, RUNNING, SUCCESS, FAILED, TIMEOUT, CANCELLED
+	Note    string    `json:"note"`
+	Tags    []string  `json:"tags"`
+	Created time.Time `json:"created"`
+	Owner   Entity    `json:"owner"`
+}
+
+// URL is the job's page
+func (j Job) URL(domain string) string {
+	return "https://builds." + domain + "/" + j.Owner.CanonicalName + "/job/" + strconv.FormatInt(j.ID, 10)
+}
+
+// Name is the job's manifest name: git.sr.ht tags jobs it submits on push
+// with the repository, "commits", the branch, and the manifest
+func (j Job) Name() string {
+	if len(j.Tags) >= 4 {
+		return j.Tags[3]
+	}
+	return strings.Join(j.Tags, "/")
+}
+
+// Branch is the branch a push job built, or ""
+func (j Job) Branch() string {
+	if len(j.Tags) >= 3 && j.Tags[1] == "commits" {
+		return j.Tags[2]
+	}
+	return ""
+}
+
+// Jobs lists the newest n jobs the repository's owner ran for it, on
+// branch unless it is ""
+func (c *Client) Jobs(r Repo, branch string, n int) ([]Job, error) {
+	const query = `query($owner: String!, $cursor: Cursor) {
+  userByName(username: $owner) {
+    jobs(cursor: $cursor) {
+      results { id status note tags created owner { canonicalName } }
+      cursor
+    }
+  }
+}`
+	var jobs []Job
+	var cursor *string
+	for range maxPages {
+		var data struct {
+			User *struct {
+				Jobs struct {
+					Results []Job   `json:"results"`
+					Cursor  *string `json:"cursor"`
+				} `json:"jobs"`
+			} `json:"userByName"`
+		}
+		vars := map[string]any{"owner": strings.TrimPrefix(r.Owner, "~"), "cursor": cursor}
+		if err := c.Query("builds", query, vars, &data); err != nil {
+			return nil, err
+		}
+		if data.User == nil {
+			return nil, nil
+		}
+		for _, j := range data.User.Jobs.Results {
+			if len(j.Tags) == 0 || j.Tags[0] != r.Name || (branch != "" && j.Branch() != branch) {
+				continue
+			}
+			if jobs = append(jobs, j); len(jobs) == n {
+				return jobs, nil
+			}
+		}
+		if cursor = data.User.Jobs.Cursor; cursor == nil {
+			break
+		}
+	}
+	return jobs, nil
+}
+
+// SubmitTicket files a ticket on the tracker named after the repository
+// and returns its page
+func (c *Client) SubmitTicket(r Repo, subject, body string) (string, error) {
+	var tracker struct {
+		Tracker *struct {
+			ID int `json:"id"`
+		} `json:"trackerByOwner"`
+	}
+	vars := map[string]any{"owner": r.Owner, "tracker": r.Name}
+	if err := c.Query("todo", `query($owner: String!, $tracker: String!) { trackerByOwner(owner: $owner, tracker: $tracker) { id } }`, vars, &tracker); err != nil {
+		return "", err
+	}
+	if tracker.Tracker == nil {
+		return "", fmt.Errorf("no tracker named %s", r)
+	}
+
+	const mutation = `mutation($tracker: Int!, $input: SubmitTicketInput!) {
+  submitTicket(trackerId: $tracker, input: $input) { id }
+}`
+	var data struct {
+		Ticket Ticket `json:"submitTicket"`
+	}
+	vars = map[string]any{"tracker": tracker.Tracker.ID, "input": map[string]string{"subject": subject, "body": body}}
+	if err := c.Query("todo", mutation, vars, &data); err != nil {
+		return "", err
+	}
+	return data.Ticket.URL(r), nil
+}
+
+// Resubmit runs a job again from its manifest, tags, and note
+func (c *Client) Resubmit(id int64) error {
+	var job struct {
+		Job *struct {
+			Manifest string   `json:"manifest"`
+			Tags     []string `json:"tags"`
+			Note     *string  `json:"note"`
+		} `json:"job"`
+	}
+	if err := c.Query("builds", `query($id: Int!) { job(id: $id) { manifest tags note } }`, map[string]any{"id": id}, &job); err != nil {
+		return err
+	}
+	if job.Job == nil {
+		return fmt.Errorf("no job %d", id)
+	}
+
+	const mutation = `mutation($manifest: String!, $tags: [String!], $note: String) {
+  submit(manifest: $manifest, tags: $tags, note: $note) { id }
+}`
+	vars := map[string]any{"manifest": job.Job.Manifest, "tags": job.Job.Tags, "note": job.Job.Note}
+	return c.Query("builds", mutation, vars, nil)
+}
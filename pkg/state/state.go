@@ -0,0 +1,278 @@
+// Package state bundles mission-control's configuration and user state
+// (everything under ~/.hustlemc) into one archive, and restores it on
+// another machine. Caches that are cheap to rebuild are left out.
+package state
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// Caches are the files and directories under config.Dir() left out of a
+// bundle because mc rebuilds them. Everything else there is user state and
+// travels, including locale overrides and anything a new feature adds. A
+// name ending in "/" is a directory.
+var Caches = []string{
+	"projects.json",     // discovery results
+	"revenue.json",      // Stripe and Gumroad totals
+	"costs.json",        // hosting bills
+	"update-check.json", // latest release seen
+	"pids/",             // dev servers of this machine
+	"logs/",             // sync output
+}
+
+// FormatVersion is the bundle layout version written to the manifest
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+// Manifest describes a bundle
+type Manifest struct {
+	Version    int       `json:"version"`
+	Created    time.Time `json:"created"`
+	Host       string    `json:"host"`
+	Files      []string  `json:"files"`
+	WithTokens bool      `json:"with_tokens"`
+}
+
+// Export writes every state file to w as a gzipped tar. API
+// tokens are stripped from config.json unless withTokens is set.
+func Export(w io.Writer, withTokens bool) (*Manifest, error) {
+	host, _ := os.Hostname()
+	m := &Manifest{Version: FormatVersion, Created: time.Now(), Host: host, WithTokens: withTokens}
+
+	contents := make(map[string][]byte)
+	for _, name := range stateFiles() {
+		data, err := os.ReadFile(filepath.Join(config.Dir(), filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if name == "config.json" && !withTokens {
+			if data, err = stripTokens(data); err != nil {
+				return nil, fmt.Errorf("config.json: %w", err)
+			}
+		}
+		contents[name] = data
+		m.Files = append(m.Files, name)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(tw, manifestName, manifest, m.Created); err != nil {
+		return nil, err
+	}
+	for _, name := range m.Files {
+		if err := writeFile(tw, name, contents[name], m.Created); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// stateFiles lists the files under config.Dir() that aren't caches,
+// backups, or half-written, as slash-separated relative paths
+func stateFiles() []string {
+	var names []string
+	dir := config.Dir()
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		name := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if !known(name + "/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && known(name) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	return names
+}
+
+// known reports whether a bundled path is state: local to config.Dir()
+// and not a cache, an import backup, or a temporary file
+func known(name string) bool {
+	if !filepath.IsLocal(name) || strings.HasPrefix(name, "backup-") || strings.HasSuffix(name, ".tmp") {
+		return false
+	}
+	for _, c := range Caches {
+		if name == c || (strings.HasSuffix(c, "/") && strings.HasPrefix(name, c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, mtime time.Time) error {
+	hdr := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: mtime}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// stripTokens removes API credentials from a config file
+func stripTokens(data []byte) ([]byte, error) {
+	var cfg config.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	cfg.Tokens = nil
+	return json.MarshalIndent(&cfg, "", "  ")
+}
+
+// Import restores a bundle read from r. Files it replaces are first
+// copied to a backup directory, whose path is returned. When the bundle
+// carries no tokens, the tokens already on this machine are kept.
+func Import(r io.Reader) (*Manifest, string, error) {
+	m, contents, err := read(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if data, ok := contents["config.json"]; ok && !m.WithTokens {
+		if contents["config.json"], err = keepLocalTokens(data); err != nil {
+			return nil, "", fmt.Errorf("config.json: %w", err)
+		}
+	}
+
+	dir := config.Dir()
+	backup := filepath.Join(dir, "backup-"+time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, "", err
+	}
+	for _, name := range m.Files {
+		old, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		saved := filepath.Join(backup, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(saved), 0700); err != nil {
+			return nil, "", err
+		}
+		if err := os.WriteFile(saved, old, 0600); err != nil {
+			return nil, "", err
+		}
+	}
+
+	for _, name := range m.Files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, "", err
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, contents[name], 0600); err != nil {
+			return nil, "", err
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if _, err := os.Stat(backup); err != nil {
+		backup = ""
+	}
+	return m, backup, nil
+}
+
+// read unpacks a bundle, accepting only known state files
+func read(r io.Reader) (*Manifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not an mc state bundle: %w", err)
+	}
+	defer gz.Close()
+
+	var m *Manifest
+	contents := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case hdr.Name == manifestName:
+			m = &Manifest{}
+			if err := json.Unmarshal(data, m); err != nil {
+				return nil, nil, fmt.Errorf("manifest: %w", err)
+			}
+		case known(hdr.Name):
+			contents[hdr.Name] = data
+		}
+		// Anything else is ignored rather than written somewhere unexpected
+	}
+
+	if m == nil {
+		return nil, nil, errors.New("not an mc state bundle: no manifest")
+	}
+	if m.Version > FormatVersion {
+		return nil, nil, fmt.Errorf("bundle format %d is newer than this mc supports (%d); upgrade mc first", m.Version, FormatVersion)
+	}
+
+	var files []string
+	for _, name := range m.Files {
+		if _, ok := contents[name]; ok {
+			files = append(files, name)
+		}
+	}
+	m.Files = files
+	return m, contents, nil
+}
+
+// keepLocalTokens carries this machine's tokens into an imported config
+func keepLocalTokens(data []byte) ([]byte, error) {
+	var cfg config.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	local, err := config.Load()
+	if err != nil || len(local.Tokens) == 0 {
+		return data, nil
+	}
+	cfg.Tokens = local.Tokens
+	return json.MarshalIndent(&cfg, "", "  ")
+}
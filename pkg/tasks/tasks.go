@@ -0,0 +1,266 @@
+// Package tasks reads markdown checklist tasks from a project's PLAN.md
+// and TODO.md, sorts them into kanban columns, and writes moves back.
+//
+// A task's column comes from, in order: a checked box ("- [x]" is done),
+// an in-progress box ("- [/]" or "- [~]"), a label ("#doing", "#wip",
+// "(in progress)", ...), or the heading it sits under ("## Doing",
+// "## Done", "## Backlog", ...). Anything else is todo.
+package tasks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Status is a kanban column
+type Status int
+
+const (
+	Todo Status = iota
+	Doing
+	Done
+)
+
+// Statuses lists the columns in board order
+var Statuses = []Status{Todo, Doing, Done}
+
+// String returns the column title
+func (s Status) String() string {
+	switch s {
+	case Doing:
+		return "Doing"
+	case Done:
+		return "Done"
+	default:
+		return "Todo"
+	}
+}
+
+// Files are the markdown files scanned for tasks, in display order
+var Files = []string{"TODO.md", "PLAN.md"}
+
+// Task is one checklist item
+type Task struct {
+	Project string
+	File    string // absolute path of the markdown file
+	Line    int    // 0-based line index
+	Raw     string // the line as read, used to detect concurrent edits
+	Text    string // task text without checkbox or labels
+	Section string // nearest heading above the task
+	Status  Status
+}
+
+var (
+	taskRe    = regexp.MustCompile(`^(\s*[-*+]\s+)\[([ xX/~-])\]\s+(.*)$`)
+	headingRe = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	labelRe   = regexp.MustCompile(`(?i)\s*(#(doing|wip|in-progress|done)\b|\((doing|wip|in progress)\)|\[(doing|wip|in progress)\])`)
+)
+
+// Column names a heading can use, matched as whole words so "Abandoned"
+// isn't Done and "Nextjs" isn't Todo
+var (
+	doingHeadingRe = regexp.MustCompile(`(?i)\b(doing|in[ -]progress|wip)\b`)
+	doneHeadingRe  = regexp.MustCompile(`(?i)\b(done|completed?|shipped)\b`)
+	todoHeadingRe  = regexp.MustCompile(`(?i)\b(todo|to[ -]do|backlog|next)\b`)
+)
+
+// sectionStatus maps a heading to a column, if it names one. Top-level
+// headings are document titles ("# Project TODO"), not columns.
+func sectionStatus(level, heading string) (Status, bool) {
+	if len(level) < 2 {
+		return Todo, false
+	}
+	switch {
+	case doingHeadingRe.MatchString(heading):
+		return Doing, true
+	case doneHeadingRe.MatchString(heading):
+		return Done, true
+	case todoHeadingRe.MatchString(heading):
+		return Todo, true
+	}
+	return Todo, false
+}
+
+// labelStatus returns the column named by an inline label
+func labelStatus(text string) (Status, bool) {
+	m := labelRe.FindStringSubmatch(text)
+	if m == nil {
+		return Todo, false
+	}
+	if strings.EqualFold(m[2], "done") {
+		return Done, true
+	}
+	return Doing, true
+}
+
+// Load reads tasks from a project's task files
+func Load(project, projectPath string) ([]Task, error) {
+	var all []Task
+	for _, name := range Files {
+		path := filepath.Join(projectPath, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return all, err
+		}
+		all = append(all, parse(project, path, string(data))...)
+	}
+	return all, nil
+}
+
+func parse(project, path, content string) []Task {
+	var tasks []Task
+	section := ""
+	sectionCol, sectionKnown := Todo, false
+	inFence := false
+
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := headingRe.FindStringSubmatch(line); m != nil {
+			section = m[2]
+			sectionCol, sectionKnown = sectionStatus(m[1], section)
+			continue
+		}
+
+		m := taskRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		t := Task{Project: project, File: path, Line: i, Raw: line, Section: section}
+		box := m[2]
+		text := m[3]
+		t.Text = strings.TrimSpace(labelRe.ReplaceAllString(text, ""))
+
+		switch {
+		case box == "x" || box == "X":
+			t.Status = Done
+		case box == "/" || box == "~" || box == "-":
+			t.Status = Doing
+		default:
+			if col, ok := labelStatus(text); ok {
+				t.Status = col
+			} else if sectionKnown {
+				t.Status = sectionCol
+			}
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+// indent returns the width of a line's leading whitespace
+func indent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// blockEnd returns the index just past the task at start and everything
+// indented under it: sub-tasks, notes, and blank lines between them
+func blockEnd(lines []string, start int) int {
+	base := indent(lines[start])
+	end := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" {
+			continue
+		}
+		if indent(lines[i]) <= base {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// Move rewrites a task's source file so the task lands in column to.
+// If the file has a heading for that column the task moves under it,
+// taking its indented sub-items and notes along; otherwise the task is
+// updated in place (checkbox, plus a #doing label for in-progress work).
+func Move(t Task, to Status) error {
+	data, err := os.ReadFile(t.File)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(data), "\n")
+	if t.Line >= len(lines) || lines[t.Line] != t.Raw {
+		return fmt.Errorf("%s changed on disk; refresh and try again", filepath.Base(t.File))
+	}
+
+	m := taskRe.FindStringSubmatch(t.Raw)
+	if m == nil {
+		return fmt.Errorf("line %d of %s is no longer a task", t.Line+1, filepath.Base(t.File))
+	}
+	prefix := m[1]
+
+	box := " "
+	if to == Done {
+		box = "x"
+	}
+
+	// Find the target column's heading, if the file is laid out that way
+	target := -1
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if h := headingRe.FindStringSubmatch(line); h != nil {
+			if col, ok := sectionStatus(h[1], h[2]); ok && col == to {
+				target = i
+				break
+			}
+		}
+	}
+
+	text := strings.TrimSpace(labelRe.ReplaceAllString(m[3], ""))
+	if target < 0 && to == Doing {
+		text += " #doing"
+	}
+	newLine := fmt.Sprintf("%s[%s] %s", prefix, box, text)
+
+	if target < 0 {
+		lines[t.Line] = newLine
+	} else {
+		// Take the task and its sub-items and notes out, then put them
+		// back after the last task of the target section
+		end := blockEnd(lines, t.Line)
+		block := append([]string{newLine}, lines[t.Line+1:end]...)
+		lines = append(lines[:t.Line], lines[end:]...)
+		if target > t.Line {
+			target -= len(block)
+		}
+		insert := target + 1
+		for i := target + 1; i < len(lines); {
+			if headingRe.MatchString(lines[i]) {
+				break
+			}
+			if taskRe.MatchString(lines[i]) {
+				insert = blockEnd(lines, i)
+				i = insert
+				continue
+			}
+			i++
+		}
+		lines = append(lines[:insert], append(block, lines[insert:]...)...)
+	}
+
+	info, err := os.Stat(t.File)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.File, []byte(strings.Join(lines, "\n")), info.Mode().Perm())
+}
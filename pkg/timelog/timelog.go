@@ -0,0 +1,159 @@
+// Package timelog records time spent on projects. Entries are appended
+// to timelog.json in the config directory; at most one timer runs at a time.
+package timelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+var mu sync.Mutex
+
+// Entry is one tracked span of work. End is zero while the timer runs.
+type Entry struct {
+	Project string    `json:"project"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end,omitempty"`
+	Task    string    `json:"task,omitempty"` // milestone or issue being worked on
+	Note    string    `json:"note,omitempty"`
+}
+
+// Duration returns the entry's length, counting a running timer up to now
+func (e Entry) Duration() time.Duration {
+	if e.End.IsZero() {
+		return time.Since(e.Start)
+	}
+	return e.End.Sub(e.Start)
+}
+
+// Running reports whether the entry's timer is still going
+func (e Entry) Running() bool {
+	return e.End.IsZero()
+}
+
+// Path returns the time log file path
+func Path() string {
+	return filepath.Join(config.Dir(), "timelog.json")
+}
+
+// Load reads all entries (oldest first)
+func Load() ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() ([]Entry, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func save(entries []Entry) error {
+	if err := os.MkdirAll(filepath.Dir(Path()), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Active returns the running entry, or nil
+func Active() (*Entry, error) {
+	entries, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Running() {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Start begins a timer for project, stopping any other running timer
+func Start(project string) error {
+	return StartTask(project, "")
+}
+
+// StartTask begins a timer for a task (milestone or issue) within project,
+// stopping any other running timer
+func StartTask(project, task string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range entries {
+		if entries[i].Running() {
+			if entries[i].Project == project && entries[i].Task == task {
+				return fmt.Errorf("timer already running for %s", project)
+			}
+			entries[i].End = now
+		}
+	}
+
+	entries = append(entries, Entry{Project: project, Task: task, Start: now})
+	return save(entries)
+}
+
+// Stop ends the running timer and returns it, or nil if none was running
+func Stop() (*Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Running() {
+			entries[i].End = time.Now()
+			stopped := entries[i]
+			return &stopped, save(entries)
+		}
+	}
+	return nil, nil
+}
+
+// Total sums tracked time per project for entries starting in [from, to)
+func Total(entries []Entry, from, to time.Time) map[string]time.Duration {
+	totals := make(map[string]time.Duration)
+	for _, e := range entries {
+		if e.Start.Before(from) || !e.Start.Before(to) {
+			continue
+		}
+		totals[e.Project] += e.Duration()
+	}
+	return totals
+}
+
+// MonthRange returns the start of t's month and the start of the next
+func MonthRange(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 1, 0)
+}
@@ -0,0 +1,100 @@
+// Package totp generates and checks RFC 6238 time-based one-time
+// passwords, the 6-digit codes authenticator apps show, so high-risk
+// actions can ask for one before running.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Step is how long each code is valid
+const Step = 30 * time.Second
+
+// Digits is the length of a code
+const Digits = 6
+
+// skew is how many steps either side of now a code is still accepted,
+// allowing for clock drift and slow typing
+const skew = 1
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret returns a random base32 secret to load into an authenticator
+func NewSecret() (string, error) {
+	key := make([]byte, 20)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(key), nil
+}
+
+// decode reads a base32 secret as apps show it: any case, spaces and
+// padding allowed
+func decode(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := encoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("invalid TOTP secret (want base32)")
+	}
+	return key, nil
+}
+
+// code computes the code for one time step
+func code(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	n := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, n%1000000)
+}
+
+// Code returns the code for secret at t
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, uint64(t.Unix())/uint64(Step/time.Second)), nil
+}
+
+// Validate reports whether entered is the code for secret at t, or for a
+// step either side of it
+func Validate(secret, entered string, t time.Time) (bool, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return false, err
+	}
+	entered = strings.ReplaceAll(strings.TrimSpace(entered), " ", "")
+	if len(entered) != Digits {
+		return false, nil
+	}
+	counter := int64(t.Unix()) / int64(Step/time.Second)
+	for i := int64(-skew); i <= skew; i++ {
+		if subtle.ConstantTimeCompare([]byte(code(key, uint64(counter+i))), []byte(entered)) == 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// URI returns the otpauth:// link authenticator apps import, usually as
+// a QR code
+func URI(secret, account string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", "mission-control")
+	return "otpauth://totp/" + url.PathEscape("mission-control:"+account) + "?" + q.Encode()
+}
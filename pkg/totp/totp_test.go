@@ -0,0 +1,63 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed of RFC 6238 appendix B,
+// "12345678901234567890", as base32
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFC6238(t *testing.T) {
+	// The RFC's 8-digit codes; a 6-digit code is their last six digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		got, err := Code(rfc6238Secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("Code(%d): %v", tt.unix, err)
+		}
+		if want := tt.want[len(tt.want)-Digits:]; got != want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	tests := []struct {
+		entered string
+		want    bool
+	}{
+		{"050471", true},
+		{" 050 471 ", true}, // spaces as apps group the digits
+		{"081804", true},    // the step before, within skew
+		{"287082", false},   // long expired
+		{"05047", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		got, err := Validate(rfc6238Secret, tt.entered, now)
+		if err != nil {
+			t.Fatalf("Validate(%q): %v", tt.entered, err)
+		}
+		if got != tt.want {
+			t.Errorf("Validate(%q) = %v, want %v", tt.entered, got, tt.want)
+		}
+	}
+
+	if _, err := Validate("not base32!", "123456", now); err == nil {
+		t.Error("Validate accepted an invalid secret")
+	}
+}
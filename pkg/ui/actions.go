@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// actionsRow is one project's Actions secret or variable
+type actionsRow struct {
+	project string
+	path    string
+	discover.ActionsEntry
+}
+
+// actionsView lists Actions secrets and variables across projects
+// against what their workflows reference
+type actionsView struct {
+	rows    []actionsRow
+	errs    map[string]string // by project
+	all     bool              // show settings that are fine too
+	idx     int
+	loading bool
+}
+
+type actionsInventoryMsg struct {
+	rows []actionsRow
+	errs map[string]string
+}
+
+// loadActionsInventoryCmd reads every project's Actions settings and
+// workflow references
+func loadActionsInventoryCmd(projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		msg := actionsInventoryMsg{errs: make(map[string]string)}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, secretsBatch)
+		for _, p := range projects {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				var entries []discover.ActionsEntry
+				var err error
+				scheduleGitHub(p.Name, func() {
+					entries, err = discover.ActionsInventory(p.Path)
+				})
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					// Only worth mentioning for projects that have workflows
+					if _, werr := discover.WorkflowRefs(expandPath(p.Path)); werr == nil {
+						msg.errs[p.Name] = err.Error()
+					}
+					return
+				}
+				for _, e := range entries {
+					msg.rows = append(msg.rows, actionsRow{project: p.Name, path: p.Path, ActionsEntry: e})
+				}
+			}()
+		}
+		wg.Wait()
+
+		// Missing first, then unused, across projects
+		rank := func(r actionsRow) int {
+			switch {
+			case r.Missing():
+				return 0
+			case r.Unused():
+				return 1
+			}
+			return 2
+		}
+		sort.SliceStable(msg.rows, func(i, j int) bool {
+			a, b := msg.rows[i], msg.rows[j]
+			if rank(a) != rank(b) {
+				return rank(a) < rank(b)
+			}
+			if a.project != b.project {
+				return a.project < b.project
+			}
+			return a.Name < b.Name
+		})
+		return msg
+	}
+}
+
+// openActionsInventory shows Actions settings across projects, only the
+// missing and unused ones to start with
+func (m Model) openActionsInventory() (tea.Model, tea.Cmd) {
+	m.actions = actionsView{loading: true}
+	m.viewMode = ActionsView
+	return m, loadActionsInventoryCmd(m.projects)
+}
+
+// visible returns the rows shown: problems only unless all is set
+func (v actionsView) visible() []actionsRow {
+	if v.all {
+		return v.rows
+	}
+	var out []actionsRow
+	for _, r := range v.rows {
+		if r.Missing() || r.Unused() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// problems counts missing and unused settings
+func (v actionsView) problems() (missing, unused int) {
+	for _, r := range v.rows {
+		switch {
+		case r.Missing():
+			missing++
+		case r.Unused():
+			unused++
+		}
+	}
+	return missing, unused
+}
+
+func (m Model) handleActionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.actions
+	rows := v.visible()
+	last := maxInt(len(rows)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "a":
+		v.all = !v.all
+		v.idx = 0
+	case "enter":
+		if v.idx < len(rows) {
+			r := rows[v.idx]
+			if url := discover.ActionsSettingsURL(r.path, r.Kind); url != "" {
+				return m, openURLCmd(url, r.project+" "+r.Kind+"s")
+			}
+		}
+	case "ctrl+r":
+		v.loading = true
+		return m, loadActionsInventoryCmd(m.projects)
+	}
+	return m, nil
+}
+
+// renderActions lists Actions secrets and variables with where they're
+// set and which workflows use them, flagging missing and unused ones
+func (m Model) renderActions(height int) string {
+	v := m.actions
+	var b strings.Builder
+
+	missing, unused := v.problems()
+	b.WriteString(i18n.T("\n  %s Actions secrets & variables — %d missing, %d unused  (a all/problems, enter settings, ctrl+r reload, esc back)\n\n",
+		IconSecret, missing, unused))
+	if len(v.errs) > 0 {
+		var names []string
+		for name := range v.errs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString(i18n.T("  %s Couldn't read %d projects: %s\n", IconX, len(names), truncate(strings.Join(names, ", "), maxInt(m.width-30, 20))))
+	}
+
+	rows := v.visible()
+	if len(rows) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(i18n.T("  Loading Actions settings...\n"))
+		case v.all:
+			b.WriteString(i18n.T("  No Actions secrets or variables found.\n"))
+		default:
+			b.WriteString(i18n.T("  Nothing missing or unused (a shows everything).\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("     %-20s %-8s %-28s %-18s %-11s %s\n", "Project", "Kind", "Name", "Set on", "Updated", "Used by"))
+	shown := maxInt(height-6, 1)
+	start := maxInt(v.idx-shown+1, 0)
+	for i := start; i < len(rows) && i < start+shown; i++ {
+		r := rows[i]
+		mark, scopes, updated, used := " ", strings.Join(r.Scopes, ","), "-", strings.Join(r.Workflows, ", ")
+		switch {
+		case r.Missing():
+			mark, scopes = IconX, i18n.T("missing")
+		case r.Unused():
+			mark, used = IconConflict, i18n.T("unused")
+		}
+		if !r.Updated.IsZero() {
+			updated = r.Updated.Format("2006-01-02")
+		}
+		line := fmt.Sprintf("  %s  %-20s %-8s %-28s %-18s %-11s %s", mark, truncate(r.project, 20), r.Kind,
+			truncate(r.Name, 28), truncate(scopes, 18), updated, truncate(used, maxInt(m.width-100, 12)))
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+// activityChartHeight is how many rows the detail view's chart uses
+const activityChartHeight = 5
+
+type activityMsg struct {
+	name   string
+	counts []int
+}
+
+func loadActivityCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		counts, _ := discover.CommitActivity(path)
+		return activityMsg{name: name, counts: counts}
+	}
+}
+
+// brailleSparkline draws two days per braille cell, each as a bar up to
+// four dots tall, scaled to the busiest day
+func brailleSparkline(counts []int) string {
+	// Dot bits for each column, bottom up
+	left := [4]rune{0x40, 0x04, 0x02, 0x01}
+	right := [4]rune{0x80, 0x20, 0x10, 0x08}
+
+	peak := maxCount(counts)
+	level := func(i int) int {
+		if i >= len(counts) || counts[i] == 0 || peak == 0 {
+			return 0
+		}
+		return (counts[i]*4 + peak - 1) / peak
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(counts); i += 2 {
+		cell := rune(0x2800)
+		for d := 0; d < level(i); d++ {
+			cell |= left[d]
+		}
+		for d := 0; d < level(i+1); d++ {
+			cell |= right[d]
+		}
+		b.WriteRune(cell)
+	}
+	return b.String()
+}
+
+// renderActivity draws a block chart of commits per day for the detail
+// view, one column per day
+func (m Model) renderActivity(p *Project) string {
+	if len(p.Activity) == 0 {
+		return ""
+	}
+
+	total := 0
+	for _, c := range p.Activity {
+		total += c
+	}
+	peak := maxCount(p.Activity)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("  Activity: %d commits in %d days (busiest day: %d)\n", total, len(p.Activity), peak))
+	if total == 0 {
+		return b.String()
+	}
+
+	blocks := []rune(" ▁▂▃▄▅▆▇█")
+	steps := activityChartHeight * 8
+	for row := activityChartHeight - 1; row >= 0; row-- {
+		b.WriteString("    ")
+		for _, c := range p.Activity {
+			// Eighths of a cell filled in this row
+			fill := (c*steps+peak-1)/peak - row*8
+			switch {
+			case c == 0 || fill <= 0:
+				b.WriteRune(blocks[0])
+			case fill >= 8:
+				b.WriteRune(blocks[8])
+			default:
+				b.WriteRune(blocks[fill])
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(fmt.Sprintf("    %-*s%s\n", len(p.Activity)-5, fmt.Sprintf("-%dd", len(p.Activity)-1), "today"))
+	return b.String()
+}
+
+func maxCount(counts []int) int {
+	peak := 0
+	for _, c := range counts {
+		peak = maxInt(peak, c)
+	}
+	return peak
+}
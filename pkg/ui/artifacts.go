@@ -0,0 +1,379 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// artifactsView lists a project's latest release assets and workflow
+// artifacts, to download and verify
+type artifactsView struct {
+	project   string
+	path      string
+	release   *github.Release
+	artifacts []github.Artifact
+	idx       int
+	loading   bool
+	err       string
+
+	// Downloads so far, by row key, and the ones under way
+	downloads   map[string]discover.Download
+	downloading map[string]bool
+}
+
+// artifactRow is a release asset or a workflow artifact
+type artifactRow struct {
+	asset    *github.ReleaseAsset
+	artifact *github.Artifact
+}
+
+// key identifies a row across reloads
+func (r artifactRow) key() string {
+	if r.asset != nil {
+		return fmt.Sprintf("asset %d", r.asset.ID)
+	}
+	return fmt.Sprintf("artifact %d", r.artifact.ID)
+}
+
+type artifactsMsg struct {
+	project   string
+	release   *github.Release
+	artifacts []github.Artifact
+	err       error
+}
+
+type artifactDownloadMsg struct {
+	project string
+	key     string
+	name    string
+	d       discover.Download
+	err     error
+}
+
+// loadArtifactsCmd reads the latest release and recent artifacts; either
+// failing alone isn't an error
+func loadArtifactsCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := artifactsMsg{project: name}
+		var relErr, artErr error
+		scheduleGitHub(name, func() {
+			msg.release, relErr = discover.LatestReleaseAssets(path)
+			msg.artifacts, artErr = discover.Artifacts(path)
+		})
+		if relErr != nil && artErr != nil {
+			msg.err = artErr
+		}
+		return msg
+	}
+}
+
+// downloadCmd fetches a row into the project's download folder and checks
+// its checksum
+func downloadCmd(project, path, dir string, rel *github.Release, row artifactRow) tea.Cmd {
+	return func() tea.Msg {
+		msg := artifactDownloadMsg{project: project, key: row.key()}
+		if row.asset != nil {
+			msg.name = row.asset.Name
+			msg.d, msg.err = discover.DownloadAsset(path, rel, *row.asset, dir)
+		} else {
+			msg.name = row.artifact.Name
+			msg.d, msg.err = discover.DownloadArtifact(path, *row.artifact, dir)
+		}
+		return msg
+	}
+}
+
+// verifyCmd hashes a downloaded file again, catching changes since
+func verifyCmd(project, key, name string, d discover.Download) tea.Cmd {
+	return func() tea.Msg {
+		sum, err := discover.HashFile(d.File)
+		d.SHA256 = sum
+		return artifactDownloadMsg{project: project, key: key, name: name, d: d, err: err}
+	}
+}
+
+// openArtifacts shows a project's release assets and workflow artifacts
+func (m Model) openArtifacts(p Project) (tea.Model, tea.Cmd) {
+	if m.artifacts.project != p.Name {
+		m.artifacts = artifactsView{project: p.Name, path: p.Path}
+	}
+	m.artifacts.loading = true
+	m.viewMode = ArtifactsView
+	return m, loadArtifactsCmd(p.Name, p.Path)
+}
+
+// setArtifacts stores a loaded release and artifact list
+func (m *Model) setArtifacts(msg artifactsMsg) {
+	v := &m.artifacts
+	if v.project != msg.project {
+		return
+	}
+	v.loading = false
+	v.err = ""
+	if msg.err != nil {
+		v.err = msg.err.Error()
+		return
+	}
+	v.release, v.artifacts = msg.release, msg.artifacts
+	v.idx = maxInt(min(v.idx, len(v.rows())-1), 0)
+}
+
+// setDownload records a finished download or verification
+func (m *Model) setDownload(msg artifactDownloadMsg) {
+	v := &m.artifacts
+	if v.project == msg.project {
+		delete(v.downloading, msg.key)
+	}
+	m.statusMsgTime = time.Now()
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Download %s failed: %v", msg.name, msg.err)
+		return
+	}
+	if v.project == msg.project {
+		if v.downloads == nil {
+			v.downloads = make(map[string]discover.Download)
+		}
+		v.downloads[msg.key] = msg.d
+	}
+	switch msg.d.Result() {
+	case discover.ChecksumOK:
+		m.statusMsg = i18n.T("%s saved to %s, checksum verified", msg.name, msg.d.File)
+	case discover.ChecksumMismatch:
+		m.statusMsg = i18n.T("%s checksum MISMATCH: got %s, published %s; discarded", msg.name, shortSum(msg.d.SHA256), shortSum(msg.d.Expected))
+	default:
+		m.statusMsg = i18n.T("%s saved to %s; no published checksum to verify against", msg.name, msg.d.File)
+	}
+}
+
+// renderArtifactSummary counts the project's release assets and artifacts
+// in the detail view, once they're loaded
+func (m Model) renderArtifactSummary(name string) string {
+	v := m.artifacts
+	if v.project != name || (v.release == nil && len(v.artifacts) == 0) {
+		return ""
+	}
+	var parts []string
+	if v.release != nil {
+		parts = append(parts, i18n.T("release %s with %d assets", v.release.Tag, len(v.release.Assets)))
+	}
+	if len(v.artifacts) > 0 {
+		parts = append(parts, i18n.T("%d workflow artifacts", len(v.artifacts)))
+	}
+	return i18n.T("  Downloads: %s (E to browse)\n", strings.Join(parts, ", "))
+}
+
+// rows returns the release assets, then the artifacts
+func (v artifactsView) rows() []artifactRow {
+	var rows []artifactRow
+	if v.release != nil {
+		for i := range v.release.Assets {
+			rows = append(rows, artifactRow{asset: &v.release.Assets[i]})
+		}
+	}
+	for i := range v.artifacts {
+		rows = append(rows, artifactRow{artifact: &v.artifacts[i]})
+	}
+	return rows
+}
+
+func (m Model) handleArtifactsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.artifacts
+	rows := v.rows()
+	last := maxInt(len(rows)-1, 0)
+	cfg, _ := config.Load()
+	dir := cfg.DownloadDir(v.project)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "enter", "d":
+		if v.idx >= len(rows) {
+			return m, nil
+		}
+		row := rows[v.idx]
+		if row.artifact != nil && row.artifact.Expired {
+			m.statusMsg = i18n.T("%s has expired", row.artifact.Name)
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		if v.downloading[row.key()] {
+			return m, nil
+		}
+		if m.dryRun {
+			var api, file string
+			repo := discover.GitHubRepo(v.path)
+			if row.asset != nil {
+				api, file = github.AssetPath(repo, row.asset.ID), row.asset.Name
+			} else {
+				api, file = github.ArtifactPath(repo, row.artifact.ID), row.artifact.Name+".zip"
+			}
+			return m.showPlan("Download "+file,
+				shellCommand("gh", "api", "-H", "Accept: application/octet-stream", api)+" > "+shellCommand(filepath.Join(dir, file)),
+				"compare its SHA-256 with the published checksum")
+		}
+		if v.downloading == nil {
+			v.downloading = make(map[string]bool)
+		}
+		v.downloading[row.key()] = true
+		return m, downloadCmd(v.project, v.path, dir, v.release, row)
+	case "v":
+		if v.idx >= len(rows) {
+			return m, nil
+		}
+		row := rows[v.idx]
+		d, ok := v.downloads[row.key()]
+		if !ok {
+			m.statusMsg = i18n.T("Download it first (enter)")
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		if d.Result() == discover.ChecksumMismatch {
+			m.statusMsg = i18n.T("The mismatched download was discarded; download it again (enter)")
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		name := filepath.Base(d.File)
+		return m, verifyCmd(v.project, row.key(), name, d)
+	case "o":
+		return m, openURLCmd(dir, dir)
+	case "w":
+		if v.idx < len(rows) {
+			row := rows[v.idx]
+			if row.asset != nil && v.release != nil {
+				return m, openURLCmd(v.release.URL, v.release.Tag)
+			}
+			if row.artifact != nil {
+				if repo := discover.GitHubRepo(v.path); repo != "" {
+					return m, openURLCmd(fmt.Sprintf("https://github.com/%s/actions/runs/%d", repo, row.artifact.Run.ID), row.artifact.Name)
+				}
+			}
+		}
+	case "ctrl+r":
+		v.loading = true
+		return m, loadArtifactsCmd(v.project, v.path)
+	}
+	return m, nil
+}
+
+// formatSize renders a byte count for humans
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// shortSum abbreviates a SHA-256 for display
+func shortSum(sum string) string {
+	if len(sum) > 12 {
+		return sum[:12]
+	}
+	return sum
+}
+
+// renderArtifacts lists release assets and artifacts with their sizes and
+// what became of any download
+func (m Model) renderArtifacts(height int) string {
+	v := m.artifacts
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s Artifacts & release assets — %s  (enter download, v verify again, o open folder, w open on GitHub, ctrl+r reload, esc back)\n\n",
+		IconPackage, v.project))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	rows := v.rows()
+	if len(rows) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(i18n.T("  Loading artifacts...\n"))
+		case v.err == "":
+			b.WriteString(i18n.T("  No release assets or workflow artifacts.\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	// What happened to each row's download, if anything
+	state := func(row artifactRow) string {
+		if v.downloading[row.key()] {
+			return i18n.T("downloading...")
+		}
+		d, ok := v.downloads[row.key()]
+		if !ok {
+			return ""
+		}
+		switch d.Result() {
+		case discover.ChecksumOK:
+			return IconCheck + " " + i18n.T("verified")
+		case discover.ChecksumMismatch:
+			return IconX + " " + i18n.T("checksum mismatch")
+		}
+		return i18n.T("saved, no checksum")
+	}
+
+	var lines []string
+	index := make([]int, 0, len(rows)) // line of each row
+	if v.release != nil {
+		title := v.release.Tag
+		if v.release.Name != "" && v.release.Name != v.release.Tag {
+			title += " " + v.release.Name
+		}
+		lines = append(lines, i18n.T("  Release %s, published %s ago", title, strings.TrimSpace(formatTimeSince(v.release.PublishedAt))))
+		if len(v.release.Assets) == 0 {
+			lines = append(lines, i18n.T("    no assets"))
+		}
+	}
+	for i, row := range rows {
+		if row.artifact != nil && (i == 0 || rows[i-1].artifact == nil) {
+			lines = append(lines, "", i18n.T("  Workflow artifacts"))
+		}
+		var line string
+		if a := row.asset; a != nil {
+			line = fmt.Sprintf("    %-40s %10s  %6d ↓  %s", truncate(a.Name, 40), formatSize(a.Size), a.Downloads, state(row))
+		} else {
+			a := row.artifact
+			when := strings.TrimSpace(formatTimeSince(a.CreatedAt))
+			if a.Expired {
+				when = i18n.T("expired")
+			}
+			line = fmt.Sprintf("    %-40s %10s  %-16s %6s  %s", truncate(a.Name, 40), formatSize(a.Size),
+				truncate(a.Run.Branch, 16), when, state(row))
+		}
+		index = append(index, len(lines))
+		lines = append(lines, line)
+	}
+
+	shown := maxInt(height-4, 1)
+	start := maxInt(index[min(v.idx, len(index)-1)]-shown+1, 0)
+	for i := start; i < len(lines) && i < start+shown; i++ {
+		line := lines[i]
+		if v.idx < len(index) && i == index[v.idx] {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// audienceColumns are the optional columns for open source projects, in
+// the order they're shown
+var audienceColumns = []string{"stars", "forks", "watchers"}
+
+// compactCount shortens a count for a narrow column: 950, 1.2k, 48k
+func compactCount(n int) string {
+	switch {
+	case n < 1000:
+		return strconv.Itoa(n)
+	case n < 10000:
+		return strconv.FormatFloat(float64(n)/1000, 'f', 1, 64) + "k"
+	default:
+		return strconv.Itoa(n/1000) + "k"
+	}
+}
+
+// renderAudience is the row's stars (with the week's new ones), forks,
+// and watchers, for whichever of those columns are on
+func (m Model) renderAudience(p Project) string {
+	var seg string
+	if slices.Contains(m.columns, "stars") {
+		week := ""
+		if p.StarsWeek > 0 {
+			week = "+" + compactCount(p.StarsWeek)
+		}
+		seg += fmt.Sprintf(" %s%-5s%-5s", IconStar, compactCount(p.Stars), week)
+	}
+	if slices.Contains(m.columns, "forks") {
+		seg += fmt.Sprintf(" %s%-5s", IconFork, compactCount(p.Forks))
+	}
+	if slices.Contains(m.columns, "watchers") {
+		seg += fmt.Sprintf(" %s%-5s", IconWatch, compactCount(p.Watchers))
+	}
+	return seg
+}
+
+// toggleAudience turns the audience columns on, or off when any are, and
+// saves the choice
+func (m Model) toggleAudience() (tea.Model, tea.Cmd) {
+	if len(m.columns) > 0 {
+		m.columns = nil
+		m.statusMsg = i18n.T("Stars, forks, and watchers hidden")
+	} else {
+		m.columns = audienceColumns
+		m.statusMsg = i18n.T("Showing stars (+ this week), forks, and watchers")
+	}
+	m.statusMsgTime = time.Now()
+	columns := m.columns
+	return m, func() tea.Msg {
+		config.Update(func(cfg *config.Config) { cfg.Columns = columns })
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+// auditedActions maps actionResultMsg actions that change something to
+// the name they're recorded under
+var auditedActions = map[string]string{
+	"git_add":           "stage",
+	"git_commit":        "commit",
+	"git_checkout":      "checkout",
+	"git_stash":         "stash",
+	"git_rebase":        "rebase",
+	"git_branch_delete": "branch-cleanup",
+	"git_cherry_pick":   "cherry-pick",
+	"service":           "service",
+	"rerun":             "rerun",
+	"merge":             "merge",
+	"pr_merge":          "merge",
+	"deploy":            "deploy",
+	"chore":             "chore",
+	"incident":          "incident",
+	"rotate":            "rotate",
+	"login":             "login",
+	"board_move":        "board-move",
+	"dispatch":          "dispatch",
+	"rollback":          "rollback",
+	"repo_metadata":     "repo-metadata",
+}
+
+type auditMsg struct {
+	entries []audit.Entry
+	err     error
+}
+
+func loadAuditCmd() tea.Msg {
+	entries, err := audit.Load()
+	return auditMsg{entries: entries, err: err}
+}
+
+// auditCmd records an action in the background. Failing to write the log
+// never blocks the action itself.
+func auditCmd(project, action, detail string, err error) tea.Cmd {
+	return func() tea.Msg {
+		audit.Record(project, action, detail, err)
+		return nil
+	}
+}
+
+// auditResultCmd records an actionResultMsg if its action is audited
+func auditResultCmd(msg actionResultMsg) tea.Cmd {
+	action, ok := auditedActions[msg.action]
+	if !ok {
+		return nil
+	}
+	var err error
+	if !msg.success {
+		err = errors.New(msg.message)
+	}
+	return auditCmd(msg.project, action, msg.message, err)
+}
+
+// exportAuditCmd writes the log as CSV next to the config
+func exportAuditCmd(entries []audit.Entry) tea.Cmd {
+	return func() tea.Msg {
+		path := filepath.Join(config.Dir(), "audit-"+time.Now().Format("2006-01-02")+".csv")
+		f, err := os.Create(path)
+		if err == nil {
+			err = audit.WriteCSV(f, entries)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if err != nil {
+			return actionResultMsg{action: "audit_export", message: "Export failed: " + err.Error()}
+		}
+		return actionResultMsg{action: "audit_export", success: true, message: "Exported audit log to " + path}
+	}
+}
+
+func (m Model) handleAuditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.auditIdx < len(m.auditLog)-1 {
+			m.auditIdx++
+		}
+	case "k", "up":
+		if m.auditIdx > 0 {
+			m.auditIdx--
+		}
+	case "g":
+		m.auditIdx = 0
+	case "G":
+		m.auditIdx = maxInt(len(m.auditLog)-1, 0)
+	case "e":
+		// Export oldest first, the order the log is kept in
+		entries := make([]audit.Entry, len(m.auditLog))
+		for i, e := range m.auditLog {
+			entries[len(entries)-1-i] = e
+		}
+		return m, exportAuditCmd(entries)
+	case "ctrl+r":
+		return m, loadAuditCmd
+	}
+	return m, nil
+}
+
+// setAuditLog stores entries newest first for display
+func (m *Model) setAuditLog(msg auditMsg) {
+	m.auditErr = ""
+	if msg.err != nil {
+		m.auditErr = msg.err.Error()
+	}
+	m.auditLog = make([]audit.Entry, len(msg.entries))
+	for i, e := range msg.entries {
+		m.auditLog[len(msg.entries)-1-i] = e
+	}
+	if m.auditIdx >= len(m.auditLog) {
+		m.auditIdx = maxInt(len(m.auditLog)-1, 0)
+	}
+}
+
+// renderAudit lists recorded actions, newest first
+func (m Model) renderAudit(height int) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("\n  %s Audit log (%d)  (e: export CSV)\n\n", IconTime, len(m.auditLog)))
+	if m.auditErr != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, m.auditErr))
+	}
+	if len(m.auditLog) == 0 {
+		b.WriteString("  Nothing recorded yet. Pushes, merges, deploys, issues, and config changes show up here.\n")
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("     %-16s %-20s %-18s %-10s %s\n", "When", "Who", "Project", "Action", "Detail"))
+
+	rows := height - 5
+	start := maxInt(m.auditIdx-rows+1, 0)
+	for i := start; i < len(m.auditLog) && i < start+rows; i++ {
+		e := m.auditLog[i]
+		mark := IconCheck
+		detail := e.Detail
+		if e.Result != audit.ResultOK {
+			mark = IconX
+			if e.Error != "" && e.Error != e.Detail {
+				detail = strings.TrimSpace(detail + " " + e.Error)
+			}
+		}
+		line := fmt.Sprintf("  %s  %-16s %-20s %-18s %-10s %s", mark, e.Time.Format("2006-01-02 15:04"),
+			truncate(e.Who, 20), truncate(e.Project, 18), truncate(e.Action, 10), truncate(detail, maxInt(m.width-84, 20)))
+		if i == m.auditIdx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return padLines(b.String(), height)
+}
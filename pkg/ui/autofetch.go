@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/core"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+const (
+	// autoFetchCheckInterval is how often projects due a fetch are looked for
+	autoFetchCheckInterval = time.Minute
+
+	// autoFetchBatch caps fetches per check (run side by side) so a large
+	// tree is spread out rather than hitting every remote at once
+	autoFetchBatch = 3
+)
+
+type autoFetchTickMsg struct{}
+
+// autoFetchMsg reports one round of background fetches. errs holds the
+// failure (or nil) for each project fetched.
+type autoFetchMsg struct {
+	errs map[string]error
+}
+
+func autoFetchTickCmd() tea.Cmd {
+	return tea.Tick(autoFetchCheckInterval, func(time.Time) tea.Msg {
+		return autoFetchTickMsg{}
+	})
+}
+
+// autoFetchCmd fetches the projects whose last fetch is older than the
+// configured interval, stalest first, skipping opted-out ones and any in
+// skip (already pushing or pulling)
+func autoFetchCmd(projects []Project, skip map[string]bool) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil || cfg.AutoFetch == nil || !cfg.AutoFetch.Enabled {
+			return autoFetchMsg{}
+		}
+		interval := cfg.AutoFetch.Interval()
+
+		type due struct {
+			p    Project
+			last time.Time
+		}
+		var queue []due
+		for _, p := range projects {
+			if skip[p.Name] || cfg.Project(p.Name).NoAutoFetch {
+				continue
+			}
+			last := discover.LastFetch(p.Path)
+			if time.Since(last) >= interval {
+				queue = append(queue, due{p, last})
+			}
+		}
+		sort.Slice(queue, func(i, j int) bool { return queue[i].last.Before(queue[j].last) })
+
+		var mu sync.Mutex
+		errs := make(map[string]error)
+		jobs := core.NewJobs(autoFetchBatch)
+		jobs.OnDone = func(j core.Job) {
+			mu.Lock()
+			errs[j.Key] = j.Err
+			mu.Unlock()
+		}
+		for i := 0; i < len(queue) && i < autoFetchBatch; i++ {
+			jobs.Fetch(context.Background(), core.Project{Name: queue[i].p.Name, Path: queue[i].p.Path})
+		}
+		jobs.Wait()
+		return autoFetchMsg{errs: errs}
+	}
+}
+
+// startAutoFetch begins a round unless one is still running
+func (m Model) startAutoFetch() (Model, tea.Cmd) {
+	if m.autoFetching || len(m.projects) == 0 {
+		return m, nil
+	}
+	skip := make(map[string]bool, len(m.syncs))
+	for name := range m.syncs {
+		skip[name] = true
+	}
+	m.autoFetching = true
+	return m, autoFetchCmd(m.projects, skip)
+}
+
+// setAutoFetch records fetch failures and reloads the fetched projects'
+// ahead/behind counts
+func (m *Model) setAutoFetch(msg autoFetchMsg) tea.Cmd {
+	m.autoFetching = false
+	var cmds []tea.Cmd
+	for name, err := range msg.errs {
+		if err != nil {
+			m.fetchErrs[name] = err.Error()
+		} else {
+			delete(m.fetchErrs, name)
+		}
+		for _, p := range m.projects {
+			if p.Name == name {
+				cmds = append(cmds, loadGitStatusCmd(p.Name, p.Path))
+				break
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
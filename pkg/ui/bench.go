@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/core"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type benchMsg struct {
+	project string
+	report  *discover.BenchReport
+	err     error
+	ran     bool // from % rather than bench.json
+	busy    bool // the benchmarks were already running
+}
+
+// loadBenchCmd reads a project's latest recorded benchmark run
+func loadBenchCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		report, err := discover.LoadBenchmarks(path)
+		return benchMsg{project: name, report: report, err: err}
+	}
+}
+
+// runBenchCmd runs a project's benchmarks as a job. The manager runs one
+// at a time, since benchmarks sharing the CPU would slow each other down.
+func runBenchCmd(jobs *core.Jobs, name, path string) tea.Cmd {
+	return func() tea.Msg {
+		done := make(chan benchMsg, 1)
+		started := jobs.Start(context.Background(), name, "bench", func(ctx context.Context) error {
+			report, err := discover.RunBenchmarks(ctx, path)
+			done <- benchMsg{project: name, report: report, err: err, ran: true}
+			return err
+		})
+		if !started {
+			return benchMsg{project: name, ran: true, busy: true}
+		}
+		return <-done
+	}
+}
+
+// startBench runs the project's benchmarks on demand (% in the detail
+// view)
+func (m Model) startBench(p *Project) (tea.Model, tea.Cmd) {
+	args, err := discover.BenchCommand(p.Path)
+	if err != nil {
+		m.statusMsg = err.Error()
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	if m.dryRun {
+		return m.showPlan("Benchmark "+p.Name, "cd "+shellCommand(expandPath(p.Path))+" && "+shellCommand(args[0], args[1:]...))
+	}
+	m.statusMsg = i18n.T("Running %s benchmarks...", p.Name)
+	m.statusMsgTime = time.Now()
+	return m, runBenchCmd(m.benchJobs, p.Name, p.Path)
+}
+
+// setBench keeps a project's benchmark report, reporting on a run
+func (m Model) setBench(msg benchMsg) Model {
+	if msg.report != nil {
+		if m.benches == nil {
+			m.benches = make(map[string]*discover.BenchReport)
+		}
+		m.benches[msg.project] = msg.report
+	}
+	if !msg.ran {
+		return m
+	}
+	m.statusMsgTime = time.Now()
+	switch {
+	case msg.busy:
+		m.statusMsg = i18n.T("%s benchmarks are already running", msg.project)
+	case msg.err != nil:
+		m.statusMsg = i18n.T("Benchmarks for %s failed: %v", msg.project, msg.err)
+	case len(msg.report.Regressions()) > 0:
+		m.statusMsg = i18n.T("%s: %d benchmarks regressed", msg.project, len(msg.report.Regressions()))
+	default:
+		m.statusMsg = i18n.T("%s: %d benchmarks, no regressions", msg.project, len(msg.report.Results))
+	}
+	return m
+}
+
+// benchChange is "+23%" or "-5%" against the baseline, "" without one
+func benchChange(r discover.BenchResult) string {
+	if r.Baseline == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+.0f%%", r.Change()*100)
+}
+
+// renderBench shows the perf badge in the detail view: the latest run's
+// regressions against its baseline, worst first
+func (m Model) renderBench(p *Project) string {
+	report := m.benches[p.Name]
+	if report == nil {
+		return ""
+	}
+	if report.Commit == "" && len(report.Results) == 0 {
+		return i18n.T("  Perf: no benchmark runs yet (% runs them)\n")
+	}
+	line := i18n.T("%d benchmarks at %s, %s ago", len(report.Results), report.Commit, strings.TrimSpace(formatTimeSince(report.At)))
+	if report.Baseline == "" {
+		return i18n.T("  Perf: %s, no baseline yet\n", line)
+	}
+	line += i18n.T(" vs %s", report.Baseline)
+	slower := report.Regressions()
+	if len(slower) == 0 {
+		return i18n.T("  Perf: %s %s\n", IconCheck, line)
+	}
+	names := make([]string, 0, 3)
+	for _, r := range slower[:min(len(slower), 3)] {
+		names = append(names, fmt.Sprintf("%s %s (%s)", r.Name, benchChange(r), time.Duration(r.NsPerOp)))
+	}
+	if len(slower) > 3 {
+		names = append(names, "...")
+	}
+	badge := driftStyle.Render(fmt.Sprintf("%s %s", IconConflict, i18n.T("%d regressed: %s", len(slower), strings.Join(names, ", "))))
+	return i18n.T("  Perf: %s  %s\n", badge, line)
+}
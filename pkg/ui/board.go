@@ -0,0 +1,356 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// noStatus labels board items without a Status
+const noStatus = "No Status"
+
+// boardView is the configured GitHub Projects board, column by column
+type boardView struct {
+	board   *github.Board
+	repos   map[string]string // project name -> owner/name
+	err     string
+	loading bool
+	col     int
+	idx     int
+	only    string // owner/name the items are narrowed to, "" for all
+}
+
+type boardMsg struct {
+	board *github.Board
+	repos map[string]string
+	err   error
+}
+
+// loadBoardCmd reads the board set as "board" in config, and which
+// repository each project is, to count its items. Nothing is loaded
+// without a board configured.
+func loadBoardCmd(projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil || cfg.Board == "" {
+			return boardMsg{err: err}
+		}
+		msg := boardMsg{repos: make(map[string]string)}
+		for _, p := range projects {
+			if repo := discover.GitHubRepo(p.Path); repo != "" {
+				msg.repos[p.Name] = repo
+			}
+		}
+		scheduleGitHub(ghBatchKey, func() {
+			msg.board, msg.err = discover.Board(cfg.Board)
+		})
+		return msg
+	}
+}
+
+// moveBoardItemCmd sets an item's Status on GitHub; project is the
+// item's project in mc, if it's one
+func moveBoardItemCmd(b *github.Board, project string, item github.BoardItem, column github.BoardColumn) tea.Cmd {
+	return func() tea.Msg {
+		label := boardItemLabel(item)
+		if err := discover.MoveBoardItem(b, item.ID, column.ID); err != nil {
+			return actionResultMsg{action: "board_move", project: project, message: i18n.T("Moving %s failed: %v", label, err)}
+		}
+		return actionResultMsg{action: "board_move", project: project, success: true, message: i18n.T("Moved %s to %s", label, column.Name)}
+	}
+}
+
+// setBoard stores a loaded board
+func (m *Model) setBoard(msg boardMsg) {
+	v := &m.board
+	v.loading = false
+	v.err = ""
+	if msg.err != nil {
+		v.err = msg.err.Error()
+		return
+	}
+	if msg.board != nil {
+		v.board = msg.board
+		v.repos = msg.repos
+	}
+}
+
+// project returns the project a repository is, "" when it isn't one
+func (v boardView) project(repo string) string {
+	for name, r := range v.repos {
+		if r == repo && repo != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// boardItemLabel names an item: "owner/name#12", or a draft's title
+func boardItemLabel(item github.BoardItem) string {
+	if item.Number == 0 {
+		return fmt.Sprintf("%q", truncate(item.Title, 30))
+	}
+	return fmt.Sprintf("%s#%d", item.Repo, item.Number)
+}
+
+// columns returns the board's columns as shown, "No Status" first when
+// any item lacks one
+func (v boardView) columns() []string {
+	var names []string
+	for _, it := range v.items("") {
+		if it.Status == "" || v.board.Column(it.Status) < 0 {
+			names = append(names, noStatus)
+			break
+		}
+	}
+	for _, c := range v.board.Columns {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// items returns the open items in a column ("" for every column), narrowed
+// to v.only when it's set
+func (v boardView) items(column string) []github.BoardItem {
+	var out []github.BoardItem
+	for _, it := range v.board.Items {
+		if it.Closed || (v.only != "" && it.Repo != v.only) {
+			continue
+		}
+		status := it.Status
+		if status == "" || v.board.Column(status) < 0 {
+			status = noStatus
+		}
+		if column == "" || status == column {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// boardCounts returns a project's open items per column, in board order
+func (v boardView) boardCounts(repo string) (counts []string, total int) {
+	if v.board == nil || repo == "" {
+		return nil, 0
+	}
+	narrowed := v
+	narrowed.only = repo
+	for _, name := range narrowed.columns() {
+		if n := len(narrowed.items(name)); n > 0 {
+			counts = append(counts, fmt.Sprintf("%s %d", name, n))
+			total += n
+		}
+	}
+	return counts, total
+}
+
+// renderBoardCounts shows the project's items on the board by column
+// for the detail view
+func (m Model) renderBoardCounts(name string) string {
+	counts, total := m.board.boardCounts(m.board.repos[name])
+	if total == 0 {
+		return ""
+	}
+	return i18n.T("  Board %s: %s (Y to triage)\n", m.board.board.Title, strings.Join(counts, " · "))
+}
+
+// openBoard shows the board, narrowed to the selected project's
+// repository when opened from its detail view
+func (m Model) openBoard() (tea.Model, tea.Cmd) {
+	if m.board.board == nil && !m.board.loading {
+		cfg, _ := config.Load()
+		if cfg.Board == "" {
+			m.statusMsg = i18n.T("No board configured: set \"board\" in config.json to owner/number or the project URL")
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+	}
+	m.board.col, m.board.idx, m.board.only = 0, 0, ""
+	if m.viewMode == DetailView && m.currentProject != nil {
+		m.board.only = m.board.repos[m.currentProject.Name]
+	}
+	m.viewMode = BoardView
+	if m.board.board == nil {
+		m.board.loading = true
+		return m, loadBoardCmd(m.projects)
+	}
+	return m, nil
+}
+
+func (m Model) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.board
+	if v.board == nil {
+		if msg.String() == "ctrl+r" {
+			v.loading = true
+			return m, loadBoardCmd(m.projects)
+		}
+		return m, nil
+	}
+	columns := v.columns()
+	v.col = min(v.col, maxInt(len(columns)-1, 0))
+	var items []github.BoardItem
+	if len(columns) > 0 {
+		items = v.items(columns[v.col])
+	}
+	last := maxInt(len(items)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "h", "left":
+		v.col = maxInt(v.col-1, 0)
+		v.idx = 0
+	case "l", "right":
+		v.col = min(v.col+1, maxInt(len(columns)-1, 0))
+		v.idx = 0
+	case "H", "L":
+		if v.idx >= len(items) {
+			return m, nil
+		}
+		step := 1
+		if msg.String() == "H" {
+			step = -1
+		}
+		target := v.col + step
+		if target < 0 || target >= len(columns) || columns[target] == noStatus {
+			return m, nil
+		}
+		item := items[v.idx]
+		column := v.board.Columns[v.board.Column(columns[target])]
+		if m.dryRun {
+			return m.showPlan("Move "+boardItemLabel(item), fmt.Sprintf("set Status of %s on %s to %q", boardItemLabel(item), v.board.Title, column.Name))
+		}
+		// Move it here straight away; a failure reloads the board
+		for i := range v.board.Items {
+			if v.board.Items[i].ID == item.ID {
+				v.board.Items[i].Status = column.Name
+			}
+		}
+		v.col = target
+		for i, it := range v.items(column.Name) {
+			if it.ID == item.ID {
+				v.idx = i
+			}
+		}
+		return m, moveBoardItemCmd(v.board, v.project(item.Repo), item, column)
+	case "f":
+		// Narrow to the project selected in the list, or back to everything
+		if v.only != "" {
+			v.only = ""
+		} else if m.selectedIdx < len(m.filtered) {
+			v.only = v.repos[m.filtered[m.selectedIdx].Name]
+		}
+		v.idx = 0
+	case "enter":
+		if v.idx < len(items) && items[v.idx].URL != "" {
+			return m, openURLCmd(items[v.idx].URL, boardItemLabel(items[v.idx]))
+		}
+	case "ctrl+r":
+		v.loading = true
+		return m, loadBoardCmd(m.projects)
+	}
+	return m, nil
+}
+
+// renderBoard lays the board out in columns, the selected one's items
+// scrolled to the selection
+func (m Model) renderBoard(height int) string {
+	v := m.board
+	var b strings.Builder
+
+	if v.board == nil {
+		b.WriteString(i18n.T("\n  %s Board\n\n", IconProjects))
+		switch {
+		case v.err != "":
+			b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+		default:
+			b.WriteString(i18n.T("  Loading board...\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	scope := i18n.T("all repos")
+	if v.only != "" {
+		scope = v.only
+	}
+	b.WriteString(i18n.T("\n  %s %s — %s  (h/l column, H/L move item, f this project/all, enter open, ctrl+r reload, esc back)\n",
+		IconProjects, v.board.Title, scope))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	b.WriteString("\n")
+
+	columns := v.columns()
+	if len(columns) == 0 {
+		b.WriteString(i18n.T("  The board has no Status columns.\n"))
+		return padLines(b.String(), height)
+	}
+	width := maxInt((m.width-4)/len(columns)-1, 12)
+
+	// Keep the selection in view
+	rows := maxInt(height-7, 1)
+	start := maxInt(v.idx-rows+1, 0)
+
+	cells := make([][]string, len(columns))
+	for c, name := range columns {
+		items := v.items(name)
+		header := truncate(fmt.Sprintf("%s (%d)", name, len(items)), width)
+		if c == v.col {
+			header = fmt.Sprintf("\033[1m%-*s\033[0m", width, header)
+		} else {
+			header = fmt.Sprintf("%-*s", width, header)
+		}
+		cells[c] = append(cells[c], header, strings.Repeat("─", width))
+		for i := start; i < len(items) && i < start+rows; i++ {
+			it := items[i]
+			label := it.Title
+			if it.Number > 0 {
+				label = fmt.Sprintf("#%d %s", it.Number, it.Title)
+				if v.only == "" {
+					label = fmt.Sprintf("%s#%d %s", repoName(it.Repo), it.Number, it.Title)
+				}
+			}
+			cell := fmt.Sprintf("%-*s", width, truncate(label, width))
+			if c == v.col && i == v.idx {
+				cell = fmt.Sprintf("\033[30;48;5;6m%s\033[0m", cell)
+			}
+			cells[c] = append(cells[c], cell)
+		}
+	}
+
+	lines := 0
+	for _, col := range cells {
+		lines = max(lines, len(col))
+	}
+	for row := 0; row < lines; row++ {
+		var parts []string
+		for _, col := range cells {
+			if row < len(col) {
+				parts = append(parts, col[row])
+			} else {
+				parts = append(parts, strings.Repeat(" ", width))
+			}
+		}
+		b.WriteString("  " + strings.Join(parts, " ") + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
+
+// repoName drops the owner from "owner/name"
+func repoName(repo string) string {
+	if i := strings.LastIndex(repo, "/"); i >= 0 {
+		return repo[i+1:]
+	}
+	return repo
+}
@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// branchPicker holds state for the branch switcher
+type branchPicker struct {
+	project  string
+	path     string
+	branches []discover.Branch
+	idx      int
+	offset   int
+	loading  bool
+	err      string
+}
+
+type branchesMsg struct {
+	project  string
+	branches []discover.Branch
+	err      error
+}
+
+func loadBranchesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := discover.ListBranches(path)
+		return branchesMsg{project: name, branches: branches, err: err}
+	}
+}
+
+// checkoutArgs returns the git arguments that switch to branch
+func checkoutArgs(projectPath string, branch discover.Branch, branches []discover.Branch) []string {
+	if !branch.Remote {
+		return []string{"-C", projectPath, "checkout", branch.Name}
+	}
+	for _, b := range branches {
+		if !b.Remote && b.Name == branch.LocalName() {
+			// Local branch already exists - just switch to it
+			return []string{"-C", projectPath, "checkout", b.Name}
+		}
+	}
+	return []string{"-C", projectPath, "checkout", "--track", branch.Name}
+}
+
+// gitCheckoutCmd checks out a branch. Remote branches without a local
+// counterpart get a new tracking branch.
+func gitCheckoutCmd(projectName, projectPath string, branch discover.Branch, branches []discover.Branch) tea.Cmd {
+	return func() tea.Msg {
+		args := checkoutArgs(projectPath, branch, branches)
+
+		output, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			msg := strings.TrimSpace(string(output))
+			if msg == "" {
+				msg = err.Error()
+			}
+			// git's first line is the useful one ("error: Your local changes...")
+			msg, _, _ = strings.Cut(msg, "\n")
+			return actionResultMsg{
+				action:  "git_checkout",
+				project: projectName,
+				success: false,
+				message: fmt.Sprintf("checkout failed: %s", msg),
+			}
+		}
+
+		return actionResultMsg{
+			action:  "git_checkout",
+			project: projectName,
+			success: true,
+			message: fmt.Sprintf("Switched %s to %s", projectName, branch.LocalName()),
+		}
+	}
+}
+
+// rebaseArgs returns the git arguments for an interactive rebase onto base
+func rebaseArgs(projectPath, base string) []string {
+	return []string{"-C", projectPath, "rebase", "-i", base}
+}
+
+// interactiveRebaseCmd hands the terminal to git (and its editor) for an
+// interactive rebase of the current branch onto base
+func interactiveRebaseCmd(projectName, projectPath, base string) tea.Cmd {
+	cmd := exec.Command("git", rebaseArgs(projectPath, base)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return actionResultMsg{
+				action:  "git_rebase",
+				project: projectName,
+				message: fmt.Sprintf("rebase onto %s stopped: resolve it in the terminal or press l for lazygit", base),
+			}
+		}
+		return actionResultMsg{
+			action:  "git_rebase",
+			project: projectName,
+			success: true,
+			message: fmt.Sprintf("Rebased %s onto %s", projectName, base),
+		}
+	})
+}
+
+// startRebase begins an interactive rebase onto the default branch
+func (m Model) startRebase(name, path string) (tea.Model, tea.Cmd) {
+	base := discover.DefaultBranch(path)
+	current := ""
+	for _, b := range m.branchPicker.branches {
+		if b.Current {
+			current = b.Name
+		}
+	}
+	switch {
+	case base == "":
+		m.statusMsg = i18n.T("%s has no main or master branch", name)
+	case current == "":
+		m.statusMsg = i18n.T("Check out a branch before rebasing")
+	case current == base:
+		m.statusMsg = i18n.T("Already on %s; check out the branch to rebase first", base)
+	default:
+		m.viewMode = ListView
+		if m.dryRun {
+			return m.showPlan("Rebase "+current+" onto "+base+" in "+name,
+				shellCommand("git", rebaseArgs(expandPath(path), base)...))
+		}
+		return m, interactiveRebaseCmd(name, expandPath(path), base)
+	}
+	m.statusMsgTime = time.Now()
+	return m, nil
+}
+
+func (m Model) handleBranchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	bp := &m.branchPicker
+	height := m.getListHeight() - 3
+
+	switch msg.String() {
+	case "j", "down":
+		if bp.idx < len(bp.branches)-1 {
+			bp.idx++
+		}
+	case "k", "up":
+		if bp.idx > 0 {
+			bp.idx--
+		}
+	case "g":
+		bp.idx = 0
+	case "G":
+		bp.idx = maxInt(len(bp.branches)-1, 0)
+	case "i":
+		if bp.loading {
+			return m, nil
+		}
+		return m.startRebase(bp.project, bp.path)
+	case "X":
+		return m.openBranchCleanup(bp.project, bp.path)
+	case "enter":
+		if len(bp.branches) == 0 {
+			return m, nil
+		}
+		branch := bp.branches[bp.idx]
+		m.viewMode = ListView
+		if branch.Current {
+			return m, nil
+		}
+		if m.dryRun {
+			return m.showPlan("Check out "+branch.LocalName()+" in "+bp.project,
+				shellCommand("git", checkoutArgs(expandPath(bp.path), branch, bp.branches)...))
+		}
+		m.statusMsg = i18n.T("Checking out %s in %s...", branch.LocalName(), bp.project)
+		m.statusMsgTime = time.Now()
+		return m, gitCheckoutCmd(bp.project, expandPath(bp.path), branch, bp.branches)
+	}
+
+	// Keep the selection in view
+	if bp.idx < bp.offset {
+		bp.offset = bp.idx
+	} else if bp.idx >= bp.offset+height {
+		bp.offset = bp.idx - height + 1
+	}
+
+	return m, nil
+}
+
+func (m Model) renderBranchPicker(height int) string {
+	bp := m.branchPicker
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("\n  %s Switch branch — %s  (enter: checkout, i: rebase -i onto main, X: clean merged, esc: cancel)\n\n", IconBranch, bp.project))
+
+	switch {
+	case bp.loading:
+		b.WriteString("  Loading branches...\n")
+	case bp.err != "":
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, bp.err))
+	case len(bp.branches) == 0:
+		b.WriteString("  No branches found\n")
+	}
+
+	rows := height - 3
+	for i := bp.offset; i < len(bp.branches) && i < bp.offset+rows; i++ {
+		br := bp.branches[i]
+		marker := " "
+		if br.Current {
+			marker = "*"
+		}
+		kind := "local "
+		if br.Remote {
+			kind = "remote"
+		}
+		line := fmt.Sprintf("  %s %s  %s", marker, kind, br.Name)
+		if i == bp.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return padLines(b.String(), height)
+}
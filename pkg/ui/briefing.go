@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/inbox"
+	"github.com/michaelmonetized/mission-control/pkg/openclaw"
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+)
+
+// briefingIdle is how long a project must sit untouched before opening
+// it assembles a re-entry briefing automatically
+const briefingIdle = 14 * 24 * time.Hour
+
+// briefing is a "where did I leave off" summary for a dormant project
+type briefing struct {
+	idle    time.Duration
+	commits []discover.Commit
+	prs     []discover.PullRequest
+	failing []discover.WorkflowRun
+	notes   []string
+	summary string
+	loading bool
+}
+
+type briefingMsg struct {
+	project string
+	b       *briefing
+}
+
+// needsBriefing reports whether a project has been idle long enough to
+// warrant a briefing
+func needsBriefing(p *Project) bool {
+	return !p.LastCommit.IsZero() && time.Since(p.LastCommit) > briefingIdle
+}
+
+// loadBriefingCmd gathers recent history, open PRs, failing checks, and
+// notes, then asks OpenClaw to summarize them. Every source is best-effort.
+func loadBriefingCmd(client *openclaw.Client, p Project) tea.Cmd {
+	return func() tea.Msg {
+		b := &briefing{}
+		if !p.LastCommit.IsZero() {
+			b.idle = time.Since(p.LastCommit)
+		}
+		b.commits, _ = discover.RecentCommits(p.Path, 5)
+		b.prs, _ = discover.ListPullRequests(p.Path)
+		b.failing, _ = discover.FailingRuns(p.Path)
+		b.notes = projectNotes(p.Name)
+
+		if client != nil {
+			if summary, err := client.Complete(briefingPrompt(p.Name, b)); err == nil {
+				b.summary = strings.TrimSpace(summary)
+			}
+		}
+		return briefingMsg{project: p.Name, b: b}
+	}
+}
+
+// projectNotes collects the newest time-log notes and inbox items
+// captured for a project
+func projectNotes(project string) []string {
+	var notes []string
+	entries, _ := timelog.Load()
+	for i := len(entries) - 1; i >= 0 && len(notes) < 3; i-- {
+		e := entries[i]
+		if e.Project != project || (e.Note == "" && e.Task == "") {
+			continue
+		}
+		note := e.Note
+		if e.Task != "" {
+			note = strings.TrimSpace(e.Task + ": " + e.Note)
+			note = strings.TrimSuffix(note, ":")
+		}
+		notes = append(notes, e.Start.Format("Jan 2")+" "+note)
+	}
+
+	items, _ := inbox.Load()
+	for _, item := range items {
+		if item.Project == project {
+			notes = append(notes, "inbox: "+item.Text)
+		}
+	}
+	return notes
+}
+
+// briefingPrompt asks for a short where-I-left-off summary built from
+// what the briefing already gathered
+func briefingPrompt(project string, b *briefing) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "I'm returning to %s after %s away. In three sentences or fewer, tell me where I left off and what to do next.\n", project, formatIdle(b.idle))
+	sb.WriteString("\nRecent commits:\n")
+	for _, c := range b.commits {
+		fmt.Fprintf(&sb, "- %s %s\n", c.When.Format("2006-01-02"), c.Subject)
+	}
+	if len(b.prs) > 0 {
+		sb.WriteString("\nOpen PRs:\n")
+		for _, pr := range b.prs {
+			fmt.Fprintf(&sb, "- #%d %s\n", pr.Number, pr.Title)
+		}
+	}
+	if len(b.failing) > 0 {
+		sb.WriteString("\nFailing checks:\n")
+		for _, r := range b.failing {
+			fmt.Fprintf(&sb, "- %s on %s\n", r.Name, r.Branch)
+		}
+	}
+	if len(b.notes) > 0 {
+		sb.WriteString("\nMy notes:\n")
+		for _, n := range b.notes {
+			fmt.Fprintf(&sb, "- %s\n", n)
+		}
+	}
+	return sb.String()
+}
+
+// formatIdle renders a long absence in days, weeks, or months
+func formatIdle(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days >= 60:
+		return fmt.Sprintf("%d months", days/30)
+	case days >= 14:
+		return fmt.Sprintf("%d weeks", days/7)
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+// startBriefing marks a briefing as loading and returns the command that
+// assembles it
+func (m *Model) startBriefing(p *Project) tea.Cmd {
+	m.briefings[p.Name] = &briefing{loading: true}
+	return loadBriefingCmd(m.clawClient, *p)
+}
+
+// renderBriefing renders the re-entry briefing for the detail view
+func (m Model) renderBriefing(name string) string {
+	b := m.briefings[name]
+	if b == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	if b.loading {
+		sb.WriteString(fmt.Sprintf("\n  %s Re-entry briefing: assembling...\n", IconTime))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\n  %s Re-entry briefing (untouched for %s)\n", IconTime, formatIdle(b.idle)))
+	if b.summary != "" {
+		for _, line := range strings.Split(b.summary, "\n") {
+			sb.WriteString("    " + truncate(line, maxInt(m.width-8, 20)) + "\n")
+		}
+	}
+	for _, c := range b.commits {
+		sb.WriteString(fmt.Sprintf("    %s %4s  %s\n", c.ShortHash(), strings.TrimSpace(formatTimeSince(c.When)), truncate(c.Subject, maxInt(m.width-24, 20))))
+	}
+	for _, pr := range b.prs {
+		draft := ""
+		if pr.IsDraft {
+			draft = " (draft)"
+		}
+		sb.WriteString(fmt.Sprintf("    PR #%d %s%s\n", pr.Number, truncate(pr.Title, maxInt(m.width-24, 20)), draft))
+	}
+	for _, r := range b.failing {
+		sb.WriteString(fmt.Sprintf("    %s %s failing on %s\n", IconX, r.Name, r.Branch))
+	}
+	for _, n := range b.notes {
+		sb.WriteString(fmt.Sprintf("    %s %s\n", IconTodo, truncate(n, maxInt(m.width-12, 20))))
+	}
+	return sb.String()
+}
@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/core"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// bulkConcurrency caps how many projects a bulk operation touches at once
+const bulkConcurrency = 4
+
+// bulkResult is how one project fared in a bulk operation
+type bulkResult struct {
+	project string
+	err     error
+	skipped string // why it wasn't attempted
+}
+
+// bulkSummary collects a bulk operation's results. It is shown once
+// nothing is left running, until a key is pressed.
+type bulkSummary struct {
+	op      string
+	results []bulkResult
+}
+
+type bulkProgressMsg struct {
+	op      string
+	project string
+	path    string
+	err     error
+	ch      <-chan tea.Msg
+}
+
+type bulkDoneMsg struct {
+	op string
+}
+
+// bulkOps are the operations offered, by key
+var bulkOps = map[string]string{
+	"f": "fetch",
+	"u": "pull",
+	"p": "push",
+}
+
+// toggleMark marks or unmarks a project for bulk operations
+func (m *Model) toggleMark(name string) {
+	if m.marked[name] {
+		delete(m.marked, name)
+	} else {
+		m.marked[name] = true
+	}
+}
+
+// toggleMarkAll marks every visible project, or clears the marks when
+// they all are already
+func (m *Model) toggleMarkAll() {
+	all := true
+	for _, p := range m.filtered {
+		if !m.marked[p.Name] {
+			all = false
+			break
+		}
+	}
+	for _, p := range m.filtered {
+		if all {
+			delete(m.marked, p.Name)
+		} else {
+			m.marked[p.Name] = true
+		}
+	}
+}
+
+// markedProjects returns the marked projects in list order
+func (m Model) markedProjects() []Project {
+	var out []Project
+	for _, p := range m.projects {
+		if m.marked[p.Name] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// bulkArgs returns the git arguments for a bulk operation on one project
+func bulkArgs(projectPath, op string) []string {
+	if op == "fetch" {
+		return []string{"-C", projectPath, "fetch", "--all", "--prune", "--quiet"}
+	}
+	return syncArgs(projectPath, op)
+}
+
+// bulkSkip explains why a project sits out a bulk operation, or is ""
+func bulkSkip(p Project, op string) string {
+	if op == "push" && p.Staged+p.Modified+p.Untracked > 0 {
+		return "uncommitted changes"
+	}
+	return ""
+}
+
+// runBulkGit runs one project's git command, returning git's error line
+// as the error when it fails
+func runBulkGit(projectPath, op string) error {
+	if op == "fetch" {
+		return discover.Fetch(projectPath)
+	}
+	cmd := exec.Command("git", bulkArgs(projectPath, op)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	// Progress output comes first; the reason is the fatal/error/rejected
+	// line, or failing that the last one
+	var reason string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "fatal:") || strings.HasPrefix(line, "error:") || strings.HasPrefix(line, "! ") {
+			reason = line
+			break
+		}
+		if line != "" {
+			reason = line
+		}
+	}
+	if reason != "" {
+		return errors.New(reason)
+	}
+	return err
+}
+
+// startBulkCmd runs op across projects, bulkConcurrency at a time,
+// reporting each as a bulkProgressMsg and finishing with a bulkDoneMsg
+func startBulkCmd(op string, projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg, len(projects)+1)
+		go func() {
+			jobs := core.NewJobs(bulkConcurrency)
+			paths := make(map[string]string, len(projects))
+			for _, p := range projects {
+				paths[p.Name] = p.Path
+			}
+			jobs.OnDone = func(j core.Job) {
+				ch <- bulkProgressMsg{op: op, project: j.Key, path: paths[j.Key], err: j.Err, ch: ch}
+			}
+			for _, p := range projects {
+				path := expandPath(p.Path)
+				jobs.Start(context.Background(), p.Name, op, func(context.Context) error {
+					return runBulkGit(path, op)
+				})
+			}
+			jobs.Wait()
+			ch <- bulkDoneMsg{op: op}
+		}()
+		return <-ch
+	}
+}
+
+// startBulk runs op on the marked projects
+func (m Model) startBulk(op string) (tea.Model, tea.Cmd) {
+	marked := m.markedProjects()
+
+	var run []Project
+	m.bulk = &bulkSummary{op: op}
+	for _, p := range marked {
+		if _, busy := m.syncs[p.Name]; busy {
+			m.bulk.results = append(m.bulk.results, bulkResult{project: p.Name, skipped: "already syncing"})
+		} else if why := bulkSkip(p, op); why != "" {
+			m.bulk.results = append(m.bulk.results, bulkResult{project: p.Name, skipped: why})
+		} else {
+			run = append(run, p)
+		}
+	}
+
+	if m.dryRun {
+		m.bulk = nil
+		var steps []string
+		for _, p := range run {
+			steps = append(steps, shellCommand("git", bulkArgs(expandPath(p.Path), op)...))
+		}
+		return m.showPlan(fmt.Sprintf("%s %d marked projects", op, len(run)), steps...)
+	}
+	if len(run) == 0 {
+		// Everything was skipped - straight to the summary
+		return m, nil
+	}
+
+	for _, p := range run {
+		m.syncs[p.Name] = syncState{op: op, line: "queued"}
+	}
+	m.bulkRunning = len(run)
+	return m, startBulkCmd(op, run)
+}
+
+// handleBulkKey picks the operation once the bulk menu is open
+func (m Model) handleBulkKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.bulkPending = false
+	if msg.String() == "m" {
+		return m.openMilestones()
+	}
+	op, ok := bulkOps[msg.String()]
+	if !ok {
+		return m, nil
+	}
+	if op != "push" {
+		return m.startBulk(op)
+	}
+	// A bulk push is held like a single one
+	target := fmt.Sprintf("%d projects", len(m.markedProjects()))
+	return m.requireSecondFactor(op, target, func(m Model) (tea.Model, tea.Cmd) {
+		return m.startBulk(op)
+	})
+}
+
+// openBulkMenu asks which operation to run on the marked projects
+func (m Model) openBulkMenu() (tea.Model, tea.Cmd) {
+	switch {
+	case m.bulkRunning > 0:
+		m.statusMsg = i18n.T("A bulk %s is still running", m.bulk.op)
+	case len(m.markedProjects()) == 0:
+		m.statusMsg = i18n.T("Mark projects with space (V marks all) first")
+	default:
+		m.bulkPending = true
+		return m, nil
+	}
+	m.statusMsgTime = time.Now()
+	return m, nil
+}
+
+// setBulkProgress records one project's result and refreshes its status
+func (m *Model) setBulkProgress(msg bulkProgressMsg) tea.Cmd {
+	delete(m.syncs, msg.project)
+	m.bulkRunning--
+	if m.bulk != nil {
+		m.bulk.results = append(m.bulk.results, bulkResult{project: msg.project, err: msg.err})
+	}
+	detail := msg.op + " ok"
+	if msg.err != nil {
+		detail = msg.op + " failed: " + msg.err.Error()
+	}
+	return tea.Batch(
+		waitSyncCmd(msg.ch),
+		loadGitStatusCmd(msg.project, expandPath(msg.path)),
+		auditCmd(msg.project, msg.op, detail, msg.err),
+	)
+}
+
+// renderBulkMenu is the prompt shown while choosing an operation
+func (m Model) renderBulkMenu() string {
+	return i18n.T("%s %d marked: f fetch all · u pull all · p push all clean · m milestones · any other key cancels",
+		IconProjects, len(m.markedProjects()))
+}
+
+// renderBulkSummary lists each project's outcome, failures first
+func (m Model) renderBulkSummary(height int) string {
+	results := append([]bulkResult(nil), m.bulk.results...)
+	rank := func(r bulkResult) int {
+		switch {
+		case r.err != nil:
+			return 0
+		case r.skipped != "":
+			return 2
+		default:
+			return 1
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if rank(results[i]) != rank(results[j]) {
+			return rank(results[i]) < rank(results[j])
+		}
+		return results[i].project < results[j].project
+	})
+
+	var ok, failed, skipped int
+	for _, r := range results {
+		switch rank(r) {
+		case 0:
+			failed++
+		case 1:
+			ok++
+		default:
+			skipped++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(i18n.T("\n  Bulk %s — %d ok, %d failed, %d skipped\n\n", m.bulk.op, ok, failed, skipped))
+	rows := height - 6
+	for i, r := range results {
+		if i >= rows {
+			b.WriteString(i18n.T("  ...and %d more\n", len(results)-rows))
+			break
+		}
+		var line string
+		switch rank(r) {
+		case 0:
+			line = fmt.Sprintf("  %s %s: %s", IconX, r.project, r.err)
+		case 1:
+			line = fmt.Sprintf("  %s %s", IconCheck, r.project)
+		default:
+			line = "  - " + r.project + ": " + i18n.T("skipped (%s)", i18n.T(r.skipped))
+		}
+		b.WriteString(truncate(line, maxInt(m.width-4, 20)) + "\n")
+	}
+	b.WriteString(i18n.T("\n  Press any key to dismiss.\n"))
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/core"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type releasePlanMsg struct {
+	project string
+	plan    *discover.ReleasePlan
+	err     error
+}
+
+type releaseFlowMsg struct {
+	project string
+	path    string
+	step    string
+	output  string
+	err     error
+	busy    bool // another step was still running
+}
+
+// loadReleasePlanCmd reads a project's changesets or version plans
+func loadReleasePlanCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		plan, err := discover.LoadReleasePlan(path)
+		return releasePlanMsg{project: name, plan: plan, err: err}
+	}
+}
+
+// releaseFlowCmd runs a step of a project's release flow as a job, so
+// a project never versions and publishes at once
+func releaseFlowCmd(jobs *core.Jobs, name, path string, plan *discover.ReleasePlan, step string) tea.Cmd {
+	return func() tea.Msg {
+		done := make(chan releaseFlowMsg, 1)
+		started := jobs.Start(context.Background(), name, step, func(context.Context) error {
+			output, err := discover.RunReleaseFlow(path, plan, step)
+			done <- releaseFlowMsg{project: name, path: path, step: step, output: output, err: err}
+			return err
+		})
+		if !started {
+			return releaseFlowMsg{project: name, path: path, step: step, busy: true}
+		}
+		return <-done
+	}
+}
+
+// setReleasePlan keeps a project's release plan
+func (m Model) setReleasePlan(msg releasePlanMsg) Model {
+	if msg.err != nil {
+		return m
+	}
+	if m.releasePlans == nil {
+		m.releasePlans = make(map[string]*discover.ReleasePlan)
+	}
+	m.releasePlans[msg.project] = msg.plan
+	return m
+}
+
+// startReleaseFlow runs the version (v) or publish (P) step of the
+// packages view's project. Publishing is held by the confirmation policy
+// like a deploy.
+func (m Model) startReleaseFlow(step string) (tea.Model, tea.Cmd) {
+	v := m.pkgView
+	plan := m.releasePlans[v.project]
+	if plan == nil {
+		m.statusMsg = i18n.T("%s has no changesets, nx release, or lerna.json", v.project)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	if step == discover.ReleaseStepVersion && len(plan.Pending) == 0 {
+		m.statusMsg = i18n.T("Nothing pending release in %s", v.project)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	args := discover.ReleaseFlowCommand(v.path, plan, step)
+	if m.dryRun {
+		return m.showPlan(fmt.Sprintf("%s %s (%s)", strings.Title(step), v.project, plan.Tool),
+			"cd "+shellCommand(expandPath(v.path))+" && "+shellCommand(args[0], args[1:]...))
+	}
+	run := func(m Model) (tea.Model, tea.Cmd) {
+		m.statusMsg = i18n.T("Running %s in %s...", strings.Join(args, " "), v.project)
+		m.statusMsgTime = time.Now()
+		return m, releaseFlowCmd(m.releaseJobs, v.project, v.path, plan, step)
+	}
+	if step == discover.ReleaseStepPublish {
+		return m.requireSecondFactor("publish", v.project, run)
+	}
+	return run(m)
+}
+
+// finishReleaseFlow reports a finished step and rereads what's left to
+// release
+func (m Model) finishReleaseFlow(msg releaseFlowMsg) (Model, tea.Cmd) {
+	m.statusMsgTime = time.Now()
+	if msg.busy {
+		m.statusMsg = i18n.T("A release step is already running in %s", msg.project)
+		return m, nil
+	}
+	err := msg.err
+	if err != nil {
+		last := msg.output
+		if i := strings.LastIndex(last, "\n"); i >= 0 {
+			last = last[i+1:]
+		}
+		if last = strings.TrimSpace(last); last != "" {
+			err = errors.New(last)
+		}
+		m.statusMsg = i18n.T("%s failed in %s: %v", strings.Title(msg.step), msg.project, err)
+	} else if msg.step == discover.ReleaseStepPublish {
+		m.statusMsg = i18n.T("Published %s", msg.project)
+	} else {
+		m.statusMsg = i18n.T("Versioned %s: review and commit the bumps", msg.project)
+	}
+	return m, tea.Batch(
+		auditCmd(msg.project, msg.step, msg.output, err),
+		loadReleasePlanCmd(msg.project, msg.path),
+		loadPackagesCmd(msg.project, msg.path),
+	)
+}
+
+// releasePlanSummary is "3 packages pending release (changesets, 4
+// changesets)", or "" when the project has no release plan
+func (m Model) releasePlanSummary(project string) string {
+	plan := m.releasePlans[project]
+	if plan == nil {
+		return ""
+	}
+	line := i18n.T("%d packages pending release (%s", len(plan.Pending), plan.Tool)
+	if plan.Plans > 0 {
+		line += i18n.T(", %d plan files", plan.Plans)
+	}
+	if plan.Pre != "" {
+		line += i18n.T(", pre-release %s", plan.Pre)
+	}
+	line += ")"
+	if job := m.releaseJob(project); job != "" {
+		line += " " + driftStyle.Render(i18n.T("%s running...", job))
+	}
+	return line
+}
+
+// releaseJob is the release step running in a project, or ""
+func (m Model) releaseJob(project string) string {
+	for _, j := range m.releaseJobs.Running() {
+		if j.Key == project {
+			return j.Name
+		}
+	}
+	return ""
+}
+
+// renderReleasePlan shows a project's pending releases in the detail view
+func (m Model) renderReleasePlan(p *Project) string {
+	summary := m.releasePlanSummary(p.Name)
+	if summary == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("  Release plan: %s", summary))
+	if plan := m.releasePlans[p.Name]; len(plan.Pending) > 0 {
+		names := make([]string, 0, 4)
+		for _, r := range plan.Pending[:min(len(plan.Pending), 4)] {
+			if r.Bump != "" {
+				names = append(names, r.Package+" "+r.Bump)
+			} else {
+				names = append(names, r.Package)
+			}
+		}
+		if len(plan.Pending) > 4 {
+			names = append(names, "...")
+		}
+		b.WriteString(": " + strings.Join(names, ", "))
+	}
+	b.WriteString(i18n.T(" (J, then v versions or P publishes)\n"))
+	return b.String()
+}
+
+// pendingBump is the bump a package's release plan gives it, "" when
+// none, and "next" when the tool decides it
+func (m Model) pendingBump(project, pkg string) string {
+	if plan := m.releasePlans[project]; plan != nil {
+		for _, r := range plan.Pending {
+			if r.Package == pkg {
+				if r.Bump == "" {
+					return "next"
+				}
+				return r.Bump
+			}
+		}
+	}
+	return ""
+}
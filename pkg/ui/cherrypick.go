@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// cherryPick holds state for the guided cherry-pick: first a source
+// branch is chosen, then commits from it that HEAD doesn't have
+type cherryPick struct {
+	project  string
+	path     string
+	branches []discover.Branch
+	branch   string // chosen source; "" while choosing
+	commits  []discover.Commit
+	selected map[string]bool
+	idx      int
+	loading  bool
+	err      string
+}
+
+type cherryBranchesMsg struct {
+	project  string
+	branches []discover.Branch
+	err      error
+}
+
+type cherryCommitsMsg struct {
+	project string
+	branch  string
+	commits []discover.Commit
+	err     error
+}
+
+func loadCherryBranchesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := discover.ListBranches(path)
+		var others []discover.Branch
+		for _, b := range branches {
+			if !b.Current {
+				others = append(others, b)
+			}
+		}
+		return cherryBranchesMsg{project: name, branches: others, err: err}
+	}
+}
+
+func loadCherryCommitsCmd(name, path, branch string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := discover.CommitsNotInHead(path, branch)
+		return cherryCommitsMsg{project: name, branch: branch, commits: commits, err: err}
+	}
+}
+
+// openCherryPick starts the flow for the detail view's project
+func (m Model) openCherryPick() (tea.Model, tea.Cmd) {
+	p := m.currentProject
+	if p.Operation != "" {
+		m.statusMsg = i18n.T("Finish the %s in progress first", p.Operation)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	m.cherry = cherryPick{project: p.Name, path: p.Path, loading: true}
+	m.viewMode = CherryPickView
+	return m, loadCherryBranchesCmd(p.Name, p.Path)
+}
+
+// picks returns the selected commits oldest first, the order they apply in
+func (c *cherryPick) picks() []string {
+	var out []string
+	for i := len(c.commits) - 1; i >= 0; i-- {
+		if c.selected[c.commits[i].Hash] {
+			out = append(out, c.commits[i].Hash)
+		}
+	}
+	return out
+}
+
+// cherryPickArgs returns the git arguments that apply hashes to HEAD.
+// -x notes where each commit came from.
+func cherryPickArgs(projectPath string, hashes []string) []string {
+	return append([]string{"-C", projectPath, "cherry-pick", "-x"}, hashes...)
+}
+
+func cherryPickCmd(projectName, projectPath, branch string, hashes []string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := exec.Command("git", cherryPickArgs(expandPath(projectPath), hashes)...)
+		cmd.Env = append(os.Environ(), "GIT_EDITOR=true", "GIT_TERMINAL_PROMPT=0")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return stoppedResult("git_cherry_pick", projectName, projectPath, "cherry-pick", output, err)
+		}
+		return actionResultMsg{
+			action:  "git_cherry_pick",
+			project: projectName,
+			success: true,
+			message: fmt.Sprintf("Cherry-picked %d commits from %s into %s", len(hashes), branch, projectName),
+		}
+	}
+}
+
+func (m Model) handleCherryPickKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.cherry
+	n := len(c.branches)
+	if c.branch != "" {
+		n = len(c.commits)
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		if c.idx < n-1 {
+			c.idx++
+		}
+	case "k", "up":
+		if c.idx > 0 {
+			c.idx--
+		}
+	case "g":
+		c.idx = 0
+	case "G":
+		c.idx = maxInt(n-1, 0)
+	case " ", "x":
+		if c.branch != "" && c.idx < len(c.commits) {
+			h := c.commits[c.idx].Hash
+			c.selected[h] = !c.selected[h]
+		}
+	case "backspace", "h":
+		// Back to choosing a branch
+		if c.branch != "" {
+			c.branch, c.commits, c.idx, c.err = "", nil, 0, ""
+		}
+	case "enter":
+		if c.branch == "" {
+			if c.idx >= len(c.branches) {
+				return m, nil
+			}
+			c.branch = c.branches[c.idx].Name
+			c.idx = 0
+			c.loading = true
+			c.selected = make(map[string]bool)
+			return m, loadCherryCommitsCmd(c.project, c.path, c.branch)
+		}
+		picks := c.picks()
+		if len(picks) == 0 {
+			return m, nil
+		}
+		if m.dryRun {
+			return m.showPlan(fmt.Sprintf("Cherry-pick %d commits from %s into %s", len(picks), c.branch, c.project),
+				shellCommand("git", cherryPickArgs(expandPath(c.path), picks)...))
+		}
+		project, path, branch := c.project, c.path, c.branch
+		return m.requireSecondFactor("cherry-pick", project, func(m Model) (tea.Model, tea.Cmd) {
+			m.viewMode = DetailView
+			m.statusMsg = i18n.T("Cherry-picking %d commits into %s...", len(picks), project)
+			m.statusMsgTime = time.Now()
+			return m, cherryPickCmd(project, path, branch, picks)
+		})
+	}
+	return m, nil
+}
+
+// setCherryBranches stores the branches commits can be picked from
+func (m *Model) setCherryBranches(msg cherryBranchesMsg) {
+	c := &m.cherry
+	if c.project != msg.project || c.branch != "" {
+		return
+	}
+	c.loading = false
+	c.branches = msg.branches
+	if msg.err != nil {
+		c.err = msg.err.Error()
+	}
+}
+
+// setCherryCommits stores the commits a chosen branch offers
+func (m *Model) setCherryCommits(msg cherryCommitsMsg) {
+	c := &m.cherry
+	if c.project != msg.project || c.branch != msg.branch {
+		return
+	}
+	c.loading = false
+	c.commits = msg.commits
+	if msg.err != nil {
+		c.err = msg.err.Error()
+	}
+}
+
+func (m Model) renderCherryPick(height int) string {
+	c := m.cherry
+	var b strings.Builder
+
+	if c.branch == "" {
+		b.WriteString(i18n.T("\n  %s Cherry-pick into %s — choose the branch to pick from  (enter choose, esc cancel)\n\n", IconBranch, c.project))
+	} else {
+		b.WriteString(i18n.T("\n  %s Cherry-pick from %s into %s  (space select, enter apply oldest first, h back)\n\n", IconBranch, c.branch, c.project))
+	}
+
+	switch {
+	case c.loading:
+		b.WriteString(i18n.T("  Loading...\n"))
+		return padLines(b.String(), height)
+	case c.err != "":
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, c.err))
+		return padLines(b.String(), height)
+	case c.branch == "" && len(c.branches) == 0:
+		b.WriteString(i18n.T("  No other branches\n"))
+		return padLines(b.String(), height)
+	case c.branch != "" && len(c.commits) == 0:
+		b.WriteString(i18n.T("  %s %s has nothing this branch doesn't\n", IconCheck, c.branch))
+		return padLines(b.String(), height)
+	}
+
+	var lines []string
+	if c.branch == "" {
+		for _, br := range c.branches {
+			kind := "local "
+			if br.Remote {
+				kind = "remote"
+			}
+			lines = append(lines, fmt.Sprintf("  %s  %s", kind, br.Name))
+		}
+	} else {
+		for _, cm := range c.commits {
+			box := "[ ]"
+			if c.selected[cm.Hash] {
+				box = "[x]"
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s  %-16s %s", box, cm.ShortHash(), truncate(cm.Author, 16),
+				truncate(cm.Subject, maxInt(m.width-40, 20))))
+		}
+	}
+
+	rows := height - 4
+	start := 0
+	if c.idx >= rows {
+		start = c.idx - rows + 1
+	}
+	for i := start; i < len(lines) && i < start+rows; i++ {
+		line := lines[i]
+		if i == c.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/chores"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+)
+
+type choresMsg struct {
+	due []chores.Due
+	err error
+}
+
+func loadChoresCmd() tea.Msg {
+	cfg, err := config.Load()
+	if err != nil {
+		return choresMsg{err: err}
+	}
+	done, err := chores.History()
+	return choresMsg{due: chores.Schedule(cfg, done), err: err}
+}
+
+func markChoreDoneCmd(d chores.Due) tea.Cmd {
+	return func() tea.Msg {
+		if err := chores.MarkDone(d.Project, d.Chore.Name, time.Now()); err != nil {
+			return actionResultMsg{action: "chore", project: d.Project, message: "Chore: " + err.Error()}
+		}
+		return actionResultMsg{
+			action:  "chore",
+			project: d.Project,
+			success: true,
+			message: fmt.Sprintf("Done: %s (%s)", d.Chore.Name, d.Project),
+		}
+	}
+}
+
+// choreCounts returns how many chores are overdue and due soon
+func (m Model) choreCounts() (overdue, soon int) {
+	now := time.Now()
+	for _, d := range m.chores {
+		switch {
+		case d.Overdue(now):
+			overdue++
+		case d.Upcoming(now):
+			soon++
+		}
+	}
+	return overdue, soon
+}
+
+func (m Model) handleChoresKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.choresIdx < len(m.chores)-1 {
+			m.choresIdx++
+		}
+	case "k", "up":
+		if m.choresIdx > 0 {
+			m.choresIdx--
+		}
+	case "enter", " ", "d":
+		if m.choresIdx < len(m.chores) {
+			d := m.chores[m.choresIdx]
+			if m.dryRun {
+				return m.showPlan("Mark chore done", fmt.Sprintf("append %s / %s to %s", d.Project, d.Chore.Name, chores.Path()))
+			}
+			return m, markChoreDoneCmd(d)
+		}
+	}
+	return m, nil
+}
+
+// renderChores lists chores soonest first with overdue ones flagged
+func (m Model) renderChores(height int) string {
+	var b strings.Builder
+
+	overdue, soon := m.choreCounts()
+	b.WriteString(fmt.Sprintf("\n  %s Maintenance — %d overdue, %d due this week  (d: mark done)\n\n", IconWrench, overdue, soon))
+	if m.choresErr != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, m.choresErr))
+	}
+	if len(m.chores) == 0 {
+		b.WriteString("  No chores. Add one with: mc chore add <project> \"renew domain\" yearly\n")
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("     %-10s %-20s %-28s %-10s %s\n", "Due", "Project", "Chore", "Every", "Last done"))
+
+	now := time.Now()
+	rows := height - 5
+	start := maxInt(m.choresIdx-rows+1, 0)
+	for i := start; i < len(m.chores) && i < start+rows; i++ {
+		d := m.chores[i]
+		mark := " "
+		switch {
+		case d.Overdue(now):
+			mark = IconX
+		case d.Upcoming(now):
+			mark = IconTime
+		}
+		last := "never"
+		if !d.LastDone.IsZero() {
+			last = d.LastDone.Format("2006-01-02")
+		}
+		line := fmt.Sprintf("  %s  %-10s %-20s %-28s %-10s %s", mark, d.Due.Format("2006-01-02"),
+			truncate(d.Project, 20), truncate(d.Chore.Name, 28), d.Chore.Every, last)
+		if i == m.choresIdx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return padLines(b.String(), height)
+}
+
+// renderProjectChores lists one project's chores for the detail view
+func (m Model) renderProjectChores(name string) string {
+	var b strings.Builder
+	now := time.Now()
+	for _, d := range m.chores {
+		if d.Project != name {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(fmt.Sprintf("\n  %s Chores\n", IconWrench))
+		}
+		state := ""
+		switch {
+		case d.Overdue(now):
+			state = " (overdue)"
+		case d.Upcoming(now):
+			state = " (soon)"
+		}
+		b.WriteString(fmt.Sprintf("    %-28s due %s%s\n", truncate(d.Chore.Name, 28), d.Due.Format("2006-01-02"), state))
+	}
+	return b.String()
+}
@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// branchCleanup holds state for deleting branches already merged into
+// the default branch
+type branchCleanup struct {
+	project  string
+	path     string
+	base     string
+	branches []string
+	selected map[string]bool
+	idx      int
+	loading  bool
+	err      string
+	confirm  bool // waiting for y/n before deleting
+}
+
+type mergedBranchesMsg struct {
+	project  string
+	branches []string
+	err      error
+}
+
+func loadMergedBranchesCmd(name, path, base string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := discover.MergedBranches(path, base)
+		return mergedBranchesMsg{project: name, branches: branches, err: err}
+	}
+}
+
+// deleteBranchesArgs returns the git arguments that delete branches.
+// They were checked against base, not HEAD, so -D: -d would refuse
+// branches merged into main while another branch is checked out.
+func deleteBranchesArgs(projectPath string, branches []string) []string {
+	return append([]string{"-C", projectPath, "branch", "-D"}, branches...)
+}
+
+// stillMerged keeps the branches that are still merged into base. The list
+// was read when the view opened; a branch that has gained commits since
+// must not be force-deleted with them.
+func stillMerged(projectPath, base string, branches []string) (keep, skipped []string, err error) {
+	merged, err := discover.MergedBranches(projectPath, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, b := range branches {
+		if slices.Contains(merged, b) {
+			keep = append(keep, b)
+		} else {
+			skipped = append(skipped, b)
+		}
+	}
+	return keep, skipped, nil
+}
+
+func deleteBranchesCmd(projectName, projectPath, base string, branches []string) tea.Cmd {
+	return func() tea.Msg {
+		fail := func(msg string) tea.Msg {
+			msg, _, _ = strings.Cut(msg, "\n")
+			return actionResultMsg{
+				action:  "git_branch_delete",
+				project: projectName,
+				message: fmt.Sprintf("branch cleanup failed: %s", msg),
+			}
+		}
+		branches, skipped, err := stillMerged(projectPath, base, branches)
+		if err != nil {
+			return fail(err.Error())
+		}
+		note := ""
+		if len(skipped) > 0 {
+			note = fmt.Sprintf(" (kept %s: no longer merged)", strings.Join(skipped, ", "))
+		}
+		if len(branches) == 0 {
+			return fail("no selected branch is still merged into " + base + note)
+		}
+
+		output, err := exec.Command("git", deleteBranchesArgs(projectPath, branches)...).CombinedOutput()
+		if err != nil {
+			msg := strings.TrimSpace(string(output))
+			if msg == "" {
+				msg = err.Error()
+			}
+			return fail(msg)
+		}
+		return actionResultMsg{
+			action:  "git_branch_delete",
+			project: projectName,
+			success: true,
+			message: fmt.Sprintf("Deleted %d merged branches in %s: %s%s", len(branches), projectName, strings.Join(branches, ", "), note),
+		}
+	}
+}
+
+// openBranchCleanup lists the project's merged branches, all selected
+func (m Model) openBranchCleanup(name, path string) (tea.Model, tea.Cmd) {
+	base := discover.DefaultBranch(path)
+	if base == "" {
+		m.statusMsg = i18n.T("%s has no main or master branch", name)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	m.cleanup = branchCleanup{project: name, path: path, base: base, loading: true}
+	m.viewMode = CleanupView
+	return m, loadMergedBranchesCmd(name, path, base)
+}
+
+// chosen returns the selected branches in list order
+func (c *branchCleanup) chosen() []string {
+	var out []string
+	for _, b := range c.branches {
+		if c.selected[b] {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (m Model) handleCleanupKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.cleanup
+
+	if c.confirm {
+		c.confirm = false
+		if msg.String() != "y" {
+			return m, nil
+		}
+		branches := c.chosen()
+		if m.dryRun {
+			return m.showPlan("Delete merged branches in "+c.project,
+				shellCommand("git", deleteBranchesArgs(expandPath(c.path), branches)...))
+		}
+		project, path, base := c.project, expandPath(c.path), c.base
+		return m.requireSecondFactor("branch-cleanup", project, func(m Model) (tea.Model, tea.Cmd) {
+			m.viewMode = ListView
+			m.statusMsg = i18n.T("Deleting %d branches in %s...", len(branches), project)
+			m.statusMsgTime = time.Now()
+			return m, deleteBranchesCmd(project, path, base, branches)
+		})
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		if c.idx < len(c.branches)-1 {
+			c.idx++
+		}
+	case "k", "up":
+		if c.idx > 0 {
+			c.idx--
+		}
+	case "g":
+		c.idx = 0
+	case "G":
+		c.idx = maxInt(len(c.branches)-1, 0)
+	case " ", "x":
+		if c.idx < len(c.branches) {
+			b := c.branches[c.idx]
+			c.selected[b] = !c.selected[b]
+		}
+	case "a":
+		// Select all, or none when everything already is
+		all := len(c.chosen()) < len(c.branches)
+		for _, b := range c.branches {
+			c.selected[b] = all
+		}
+	case "d", "enter":
+		if len(c.chosen()) > 0 {
+			c.confirm = true
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderCleanup(height int) string {
+	c := m.cleanup
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s Merged into %s — %s  (space select, a all, d delete, esc cancel)\n\n", IconBranch, c.base, c.project))
+
+	switch {
+	case c.loading:
+		b.WriteString(i18n.T("  Finding merged branches...\n"))
+		return padLines(b.String(), height)
+	case c.err != "":
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, c.err))
+		return padLines(b.String(), height)
+	case len(c.branches) == 0:
+		b.WriteString(i18n.T("  %s No merged branches to clean up\n", IconCheck))
+		return padLines(b.String(), height)
+	}
+
+	rows := height - 5
+	start := 0
+	if c.idx >= rows {
+		start = c.idx - rows + 1
+	}
+	for i := start; i < len(c.branches) && i < start+rows; i++ {
+		box := "[ ]"
+		if c.selected[c.branches[i]] {
+			box = "[x]"
+		}
+		line := fmt.Sprintf("  %s %s", box, c.branches[i])
+		if i == c.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if c.confirm {
+		b.WriteString(i18n.T("\n  Delete %d branches? (y/n)\n", len(c.chosen())))
+	}
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type commitFilesMsg struct {
+	project string
+	files   []discover.FileChange
+	err     error
+}
+
+func loadCommitFilesCmd(projectPath string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := discover.ChangedFiles(projectPath)
+		return commitFilesMsg{project: projectPath, files: files, err: err}
+	}
+}
+
+// toggleStageCmd stages a file with unstaged changes, or unstages it
+// stageArgs returns the git arguments that stage an unstaged file or
+// unstage a staged one
+func stageArgs(projectPath string, f discover.FileChange) []string {
+	switch {
+	case f.Unstaged():
+		return []string{"add", "-A", "--", f.Path}
+	case exec.Command("git", "-C", projectPath, "rev-parse", "-q", "--verify", "HEAD").Run() != nil:
+		// No commits yet, so there's nothing to reset to
+		return []string{"rm", "--cached", "-r", "-q", "--", f.Path}
+	}
+	args := []string{"reset", "-q", "--", f.Path}
+	if f.OrigPath != "" {
+		args = append(args, f.OrigPath)
+	}
+	return args
+}
+
+func toggleStageCmd(projectPath string, f discover.FileChange) tea.Cmd {
+	return func() tea.Msg {
+		args := stageArgs(projectPath, f)
+
+		output, err := exec.Command("git", append([]string{"-C", projectPath}, args...)...).CombinedOutput()
+		if err != nil {
+			files, _ := discover.ChangedFiles(projectPath)
+			return commitFilesMsg{project: projectPath, files: files, err: fmt.Errorf("%s", firstLine(output, err))}
+		}
+
+		files, err := discover.ChangedFiles(projectPath)
+		return commitFilesMsg{project: projectPath, files: files, err: err}
+	}
+}
+
+// firstLine returns the first line of a command's output, or err
+func firstLine(output []byte, err error) string {
+	msg := strings.TrimSpace(string(output))
+	if msg == "" {
+		return err.Error()
+	}
+	msg, _, _ = strings.Cut(msg, "\n")
+	return msg
+}
+
+// openCommitModal starts the stage-and-commit flow for p
+func (m Model) openCommitModal(p Project) (tea.Model, tea.Cmd) {
+	m.viewMode = CommitMode
+	m.commitProject = p.Path
+	m.commitFiles = nil
+	m.commitIdx = 0
+	m.commitErr = ""
+	m.commitLoading = true
+	m.commitInput.SetValue("")
+	m.commitInput.Focus()
+	return m, tea.Batch(textinput.Blink, loadCommitFilesCmd(expandPath(p.Path)))
+}
+
+func (m Model) handleCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	projectPath := expandPath(m.commitProject)
+
+	switch msg.String() {
+	case "esc":
+		m.viewMode = ListView
+		m.commitInput.SetValue("")
+		m.commitInput.Blur()
+		return m, nil
+	case "tab", "shift+tab":
+		// Toggle focus between the file list and the message input
+		if m.commitInput.Focused() {
+			m.commitInput.Blur()
+			return m, nil
+		}
+		m.commitInput.Focus()
+		return m, textinput.Blink
+	case "enter":
+		if !m.commitInput.Focused() {
+			m.commitInput.Focus()
+			return m, textinput.Blink
+		}
+		message := strings.TrimSpace(m.commitInput.Value())
+		if message == "" {
+			return m, nil
+		}
+		if !m.hasStagedFiles() {
+			m.commitErr = "Nothing staged - tab to the file list and press space to stage"
+			return m, nil
+		}
+
+		m.commitInput.SetValue("")
+		m.commitInput.Blur()
+		m.viewMode = ListView
+		if m.dryRun {
+			return m.showPlan("Commit in "+filepath.Base(m.commitProject), shellCommand("git", "-C", projectPath, "commit", "-m", message))
+		}
+		m.statusMsg = i18n.T("Committing...")
+		m.statusMsgTime = time.Now()
+
+		// Get project name from path
+		projectName := filepath.Base(m.commitProject)
+		return m, gitCommitCmd(projectName, projectPath, message)
+	}
+
+	if m.commitInput.Focused() {
+		var cmd tea.Cmd
+		m.commitInput, cmd = m.commitInput.Update(msg)
+		return m, cmd
+	}
+
+	// File list keys
+	switch msg.String() {
+	case "j", "down":
+		if m.commitIdx < len(m.commitFiles)-1 {
+			m.commitIdx++
+		}
+	case "k", "up":
+		if m.commitIdx > 0 {
+			m.commitIdx--
+		}
+	case " ", "space":
+		if m.commitIdx < len(m.commitFiles) {
+			f := m.commitFiles[m.commitIdx]
+			if m.dryRun {
+				return m.showPlan("Toggle staging of "+f.Path, shellCommand("git", append([]string{"-C", projectPath}, stageArgs(projectPath, f)...)...))
+			}
+			return m, toggleStageCmd(projectPath, f)
+		}
+	case "a":
+		if m.dryRun {
+			return m.showPlan("Stage all", shellCommand("git", "-C", projectPath, "add", "-A"))
+		}
+		return m, func() tea.Msg {
+			output, err := exec.Command("git", "-C", projectPath, "add", "-A").CombinedOutput()
+			files, _ := discover.ChangedFiles(projectPath)
+			if err != nil {
+				return commitFilesMsg{project: projectPath, files: files, err: fmt.Errorf("%s", firstLine(output, err))}
+			}
+			return commitFilesMsg{project: projectPath, files: files}
+		}
+	}
+	return m, nil
+}
+
+// hasStagedFiles reports whether the commit modal has anything to commit
+func (m Model) hasStagedFiles() bool {
+	for _, f := range m.commitFiles {
+		if f.Staged() {
+			return true
+		}
+	}
+	return false
+}
+
+// renderCommitModal lists changed files with their staged state
+func (m Model) renderCommitModal(height int) string {
+	var b strings.Builder
+
+	hint := "tab: file list, enter: commit"
+	if !m.commitInput.Focused() {
+		hint = "space: stage/unstage, a: stage all, tab: message"
+	}
+	b.WriteString(fmt.Sprintf("\n  %s Commit %s  (%s)\n", IconModified, filepath.Base(m.commitProject), hint))
+	if m.commitErr != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, m.commitErr))
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.commitLoading:
+		b.WriteString("  Loading changes...\n")
+	case len(m.commitFiles) == 0:
+		b.WriteString("  Working tree clean\n")
+	}
+
+	rows := height - 4
+	start := maxInt(m.commitIdx-rows+1, 0)
+	for i := start; i < len(m.commitFiles) && i < start+rows; i++ {
+		f := m.commitFiles[i]
+		box := "[ ]"
+		switch {
+		case f.Staged() && f.Unstaged():
+			box = "[~]"
+		case f.Staged():
+			box = "[x]"
+		}
+		name := f.Path
+		if f.OrigPath != "" {
+			name = f.OrigPath + " → " + f.Path
+		}
+		line := fmt.Sprintf("  %s %c%c %s", box, f.Index, f.Worktree, name)
+		if i == m.commitIdx && !m.commitInput.Focused() {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/totp"
+)
+
+// riskyActions maps the buttons a confirmation policy can hold to the
+// action name the policy lists them under
+var riskyActions = map[ButtonAction]string{
+	ActionPush:   "push",
+	ActionMerge:  "merge",
+	ActionDeploy: "deploy",
+}
+
+// secondFactor is a high-risk action held until its second factor is
+// entered in the prompt
+type secondFactor struct {
+	action string // as named in the policy
+	target string // project name, or what a bulk action covers
+	method string
+	phrase string // what to type for ConfirmPhrase
+	secret string // for ConfirmTOTP
+	run    func(Model) (tea.Model, tea.Cmd)
+}
+
+// requireSecondFactor runs an action straight away unless the confirmation
+// policy in effect holds it, in which case it prompts first. Dry runs only
+// show a plan, so they're never held.
+func (m Model) requireSecondFactor(action, target string, run func(Model) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	if m.dryRun {
+		return run(m)
+	}
+	cfg, _ := config.Load()
+	policy := cfg.ConfirmPolicy()
+	if !policy.Requires(action) {
+		return run(m)
+	}
+
+	f := &secondFactor{action: action, target: target, method: policy.Method, phrase: policy.Phrase, run: run}
+	switch f.method {
+	case config.ConfirmTOTP:
+		if f.secret = cfg.Token("totp"); f.secret == "" {
+			m.statusMsg = i18n.T("%s needs a TOTP code but none is set up: run mc confirm totp-setup", action)
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+	default:
+		f.method = config.ConfirmPhrase
+		if f.phrase == "" {
+			f.phrase = target
+		}
+	}
+
+	m.secondFactor = f
+	m.factorInput.SetValue("")
+	m.factorInput.EchoMode = textinput.EchoNormal
+	if f.method == config.ConfirmTOTP {
+		m.factorInput.EchoMode = textinput.EchoPassword
+	}
+	m.factorInput.Focus()
+	return m, textinput.Blink
+}
+
+// check reports whether entered satisfies the factor
+func (f *secondFactor) check(entered string) error {
+	if f.method == config.ConfirmTOTP {
+		ok, err := totp.Validate(f.secret, entered, time.Now())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("wrong TOTP code")
+		}
+		return nil
+	}
+	if strings.TrimSpace(entered) != f.phrase {
+		return fmt.Errorf("typed %q, expected %q", strings.TrimSpace(entered), f.phrase)
+	}
+	return nil
+}
+
+func (m Model) handleSecondFactorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.secondFactor = nil
+		m.factorInput.Blur()
+		m.statusMsg = i18n.T("Cancelled")
+		m.statusMsgTime = time.Now()
+		return m, nil
+	case "enter":
+		f := m.secondFactor
+		m.secondFactor = nil
+		m.factorInput.Blur()
+		if err := f.check(m.factorInput.Value()); err != nil {
+			// Failed attempts go in the audit log; the action doesn't run
+			m.statusMsg = i18n.T("%s %s not confirmed: %v", strings.Title(f.action), f.target, err)
+			m.statusMsgTime = time.Now()
+			return m, auditCmd(f.target, "confirm", f.action, err)
+		}
+		return f.run(m)
+	}
+
+	var cmd tea.Cmd
+	m.factorInput, cmd = m.factorInput.Update(msg)
+	return m, cmd
+}
+
+// renderSecondFactor is the prompt shown in the chat box
+func (m Model) renderSecondFactor() string {
+	f := m.secondFactor
+	if f.method == config.ConfirmTOTP {
+		return i18n.T("%s TOTP code to %s %s: %s", IconSecret, f.action, f.target, m.factorInput.View())
+	}
+	return i18n.T("%s Type %q to %s: %s", IconSecret, f.phrase, f.action, m.factorInput.View())
+}
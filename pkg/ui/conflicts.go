@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderConflicts shows an in-progress merge/rebase and the files it left
+// conflicted for the detail view
+func (m Model) renderConflicts(p *Project) string {
+	if p.Operation == "" && len(p.Conflicts) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	op := p.Operation
+	if op == "" {
+		op = "merge"
+	}
+	if len(p.Conflicts) == 0 {
+		b.WriteString(fmt.Sprintf("  %s %s in progress, no conflicts left (continue or abort it)\n", IconConflict, op))
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("  %s %s in progress with %d conflicted file(s):\n", IconConflict, op, len(p.Conflicts)))
+	for _, path := range p.Conflicts {
+		b.WriteString(fmt.Sprintf("    %s %s\n", IconX, path))
+	}
+	return b.String()
+}
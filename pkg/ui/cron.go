@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type cronMsg struct {
+	project string
+	jobs    []discover.CronJob
+}
+
+// loadCronCmd reads a project's scheduled jobs and their last runs
+func loadCronCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		var jobs []discover.CronJob
+		scheduleGitHub(name, func() {
+			jobs, _ = discover.CronJobs(path)
+		})
+		return cronMsg{project: name, jobs: jobs}
+	}
+}
+
+// cronStateIcon is a job's icon: stopped, its last run's outcome, or
+// blank when that's unknown
+func cronStateIcon(job discover.CronJob) string {
+	if job.Stopped {
+		return IconConflict
+	}
+	return envStateIcon(job.LastState)
+}
+
+// renderCron lists a project's scheduled jobs in the detail view with
+// when each last ran and runs next, jobs that stopped running first
+func (m Model) renderCron(name string) string {
+	jobs := m.cronJobs[name]
+	if len(jobs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("  Scheduled jobs:\n"))
+
+	nameWidth, schedWidth := 0, 0
+	for _, job := range jobs {
+		nameWidth = max(nameWidth, len(job.Name))
+		schedWidth = max(schedWidth, len(job.Schedule))
+	}
+	nameWidth, schedWidth = min(nameWidth, 28), min(schedWidth, 16)
+	for _, job := range jobs {
+		last := i18n.T("never seen")
+		if !job.LastRun.IsZero() {
+			last = i18n.T("ran %s ago", strings.TrimSpace(formatTimeSince(job.LastRun)))
+		}
+		line := fmt.Sprintf("    %s %-*s  %-*s  %-8s  %s", cronStateIcon(job), nameWidth, truncate(job.Name, nameWidth),
+			schedWidth, truncate(job.Schedule, schedWidth), job.Source, last)
+		if !job.Next.IsZero() {
+			line += ", " + i18n.T("next in %s", formatUntil(job.Next))
+		}
+		switch {
+		case job.Stopped && strings.HasPrefix(job.Err, "disabled"):
+			line = driftStyle.Render(line + "  " + i18n.T("schedule disabled by GitHub (%s)", job.Err))
+		case job.Stopped:
+			line = driftStyle.Render(line + "  " + i18n.T("stopped running"))
+		case job.Err != "":
+			line += "  " + IconX + " " + job.Err
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// formatUntil is how long until t, as "5m", "3h", or "2d"
+func formatUntil(t time.Time) string {
+	d := time.Until(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", max(int(d.Minutes()), 1))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// ghBatchKey is the scheduler key of the batched startup query, which
+// covers every project and so runs ahead of the per-project loads
+const ghBatchKey = "github-batch"
+
+type debugTickMsg struct{}
+
+// debugTickCmd redraws the debug view while it's open
+func debugTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return debugTickMsg{} })
+}
+
+// scheduleGitHub runs fn through the GitHub scheduler under a project's
+// name, so it waits its turn behind what's on screen and the rate limit
+func scheduleGitHub(name string, fn func()) {
+	github.DefaultScheduler().Do(name, fn)
+}
+
+// githubPriority orders projects for the GitHub scheduler: the one open
+// or selected, then the rest of the rows on screen
+func (m Model) githubPriority() []string {
+	keys := []string{ghBatchKey}
+	if m.viewMode != ListView && m.currentProject != nil {
+		keys = append(keys, m.currentProject.Name)
+	}
+	if m.selectedIdx < len(m.filtered) {
+		keys = append(keys, m.filtered[m.selectedIdx].Name)
+	}
+	end := min(m.scrollOffset+m.getListHeight(), len(m.filtered))
+	for i := m.scrollOffset; i < end; i++ {
+		keys = append(keys, m.filtered[i].Name)
+	}
+	return keys
+}
+
+// prioritizeGitHub hands the scheduler the projects now in view
+func prioritizeGitHub(next tea.Model) {
+	if m, ok := next.(Model); ok {
+		github.DefaultScheduler().Prioritize(m.githubPriority())
+	}
+}
+
+// openDebug shows API quota and refresh state
+func (m Model) openDebug() (tea.Model, tea.Cmd) {
+	m.viewMode = DebugView
+	return m, debugTickCmd()
+}
+
+// renderDebug lists each GitHub quota, the scheduler's queue, and when
+// each provider last refreshed
+func (m Model) renderDebug(height int) string {
+	sched := github.DefaultScheduler()
+	stats := sched.Stats()
+	now := time.Now()
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s GitHub API — %d queued, %d running  (esc back)\n", IconGitHub, stats.Queued, stats.InFlight))
+	if !stats.BackoffUntil.IsZero() {
+		b.WriteString(i18n.T("  Backing off for %s\n", stats.BackoffUntil.Sub(now).Round(time.Second).String()))
+	}
+	b.WriteString(m.renderLogin())
+	b.WriteString("\n")
+
+	limits := sched.RateLimits()
+	if len(limits) == 0 {
+		if github.Default() == nil {
+			b.WriteString(i18n.T("  No GitHub token: requests go through gh, which doesn't report its quota\n"))
+		} else {
+			b.WriteString(i18n.T("  No API responses yet\n"))
+		}
+	} else {
+		b.WriteString(fmt.Sprintf("  %-20s %11s %11s %10s\n", "Resource", "Remaining", "Limit", "Resets in"))
+		for _, r := range limits {
+			mark := " "
+			if r.Low() {
+				mark = IconX
+			}
+			resets := "-"
+			if r.Reset.After(now) {
+				resets = r.Reset.Sub(now).Round(time.Second).String()
+			}
+			b.WriteString(fmt.Sprintf("%s %-20s %11d %11d %10s\n", mark, r.Resource, r.Remaining, r.Limit, resets))
+		}
+	}
+
+	if len(m.refreshedAt) > 0 {
+		b.WriteString(i18n.T("\n  Last refreshed\n"))
+		var providers []string
+		for p := range m.refreshedAt {
+			providers = append(providers, p)
+		}
+		sort.Strings(providers)
+		for _, p := range providers {
+			b.WriteString(fmt.Sprintf("  %-20s %s\n", p, strings.TrimSpace(formatTimeSince(m.refreshedAt[p]))))
+		}
+	}
+	return padLines(b.String(), height)
+}
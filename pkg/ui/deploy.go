@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+)
+
+// flyDeployCommand deploys the app in fly.toml, answering its prompts
+// since nothing can answer them behind the TUI
+const flyDeployCommand = "fly deploy --yes"
+
+// deployWatchInterval is how often a running deploy is checked for its
+// end
+const deployWatchInterval = time.Second
+
+type deployStartMsg struct {
+	project string
+	err     error
+}
+
+type deployTickMsg struct {
+	project string
+	command string
+	action  string // deploy or rollback, as audited
+}
+
+// deployCommand returns the platform a project deploys to and the command
+// that deploys it to production in the foreground, so its build logs can
+// be followed: its deploy setting, else one for the platform, else ""
+func deployCommand(name, dir string) (platform, command string) {
+	cfg, _ := config.Load()
+	settings := cfg.Project(name)
+	if settings.Deploy != "" {
+		return "", settings.Deploy
+	}
+	has := func(f string) bool {
+		_, err := os.Stat(filepath.Join(dir, f))
+		return err == nil
+	}
+	switch {
+	case discover.IsFly(dir):
+		return "Fly.io", flyDeployCommand
+	case has(".vercel"):
+		return "Vercel", "vercel deploy --prod --yes"
+	case discover.IsNetlify(dir):
+		return "Netlify", "netlify deploy --prod --build"
+	case settings.Render != "":
+		return "Render", shellCommand("render", "deploys", "create", settings.Render, "--wait", "--confirm", "--output", "text")
+	case settings.Railway != "":
+		return "Railway", shellCommand("railway", "up", "--ci", "--service", settings.Railway)
+	case has("railway.json"), has("railway.toml"):
+		return "Railway", "railway up --ci"
+	}
+	return "", ""
+}
+
+func startDeployCmd(mgr *services.Manager, project, dir, command string) tea.Cmd {
+	return func() tea.Msg {
+		err := mgr.Start(project, dir, config.Service{Name: deployService, Command: command})
+		return deployStartMsg{project: project, err: err}
+	}
+}
+
+func deployTickCmd(project, command, action string) tea.Cmd {
+	return tea.Tick(deployWatchInterval, func(time.Time) tea.Msg {
+		return deployTickMsg{project: project, command: command, action: action}
+	})
+}
+
+// startDeploy deploys a project, streaming the build's output into the
+// logs pane. The deploy carries on if the pane is left; when it ends it's
+// audited and the project's deploy state reread.
+func (m Model) startDeploy(p Project) (tea.Model, tea.Cmd) {
+	dir := expandPath(p.Path)
+	platform, command := deployCommand(p.Name, dir)
+	if command == "" {
+		m.statusMsg = i18n.T("No deploy command for %s (no fly.toml, .vercel, Netlify, Render, or Railway link; set \"deploy\" in config.json)", p.Name)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	title := "Deploy " + p.Name
+	if platform != "" {
+		title += " to " + platform
+	}
+	return m.streamDeploy(p, "deploy", title, command)
+}
+
+// streamDeploy runs a deploy command (action is deploy or rollback) and
+// follows it in the logs pane, or shows the one already running
+func (m Model) streamDeploy(p Project, action, title, command string) (tea.Model, tea.Cmd) {
+	dir := expandPath(p.Path)
+	if m.dryRun {
+		return m.showPlan(title, envPlan(dir, "cd "+shellCommand(dir)+" && "+command)...)
+	}
+	since := time.Now()
+	status := m.deploys.Statuses(p.Name, []config.Service{{Name: deployService}})[0]
+	if status.State == services.Running {
+		// Already deploying: just show it
+		since = status.Started
+	}
+	m.logTail = logTail{project: p.Name, command: command, deploy: true, since: since}
+	m.logFilter.SetValue("")
+	m.logFilter.Blur()
+	m.viewMode = LogsView
+	cmds := []tea.Cmd{tailTickCmd(p.Name)}
+	if status.State != services.Running {
+		cmds = append(cmds, startDeployCmd(m.deploys, p.Name, dir, command), deployTickCmd(p.Name, command, action))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleDeployTick waits for a deploy to end, then reports it
+func (m Model) handleDeployTick(msg deployTickMsg) (tea.Model, tea.Cmd) {
+	status := m.deploys.Statuses(msg.project, []config.Service{{Name: deployService}})[0]
+	if status.State == services.Running {
+		return m, deployTickCmd(msg.project, msg.command, msg.action)
+	}
+	err := status.Err
+	took := deployDuration(status.Ended.Sub(status.Started))
+	if status.State == services.Failed {
+		// The last line says why better than the exit status does
+		if lines := m.deploys.Logs(msg.project, 1); len(lines) > 0 && strings.TrimSpace(plainText(lines[0].Text)) != "" {
+			err = errors.New(strings.TrimSpace(plainText(lines[0].Text)))
+		}
+		if msg.action == "rollback" {
+			m.statusMsg = i18n.T("Rollback of %s failed after %s: %v", msg.project, took, err)
+		} else {
+			m.statusMsg = i18n.T("Deploy of %s failed after %s: %v", msg.project, took, err)
+		}
+	} else if msg.action == "rollback" {
+		m.statusMsg = i18n.T("Rolled back %s in %s", msg.project, took)
+	} else {
+		m.statusMsg = i18n.T("Deployed %s in %s", msg.project, took)
+	}
+	m.statusMsgTime = time.Now()
+	cmds := []tea.Cmd{auditCmd(msg.project, msg.action, msg.command, err)}
+	if p := m.getProjectByName(msg.project); p != nil {
+		cmds = append(cmds, loadDeployStateCmd(p.Name, p.Path))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// loadDeployStateCmd rereads a project's deploy state on every provider
+// and its deployment history, after a deploy or rollback
+func loadDeployStateCmd(name, path string) tea.Cmd {
+	return tea.Batch(
+		loadVercelStatusCmd(name, path),
+		loadNetlifyStatusCmd(name, path),
+		loadRenderStatusCmd(name, path),
+		loadRailwayStatusCmd(name, path),
+		loadFlyStatusCmd(name, path),
+		loadDeployHistoryCmd(name, path))
+}
+
+// deployElapsed is how long the deploy the pane follows has run, or ran
+func (m Model) deployElapsed() time.Duration {
+	status := m.deploys.Statuses(m.logTail.project, []config.Service{{Name: deployService}})[0]
+	if status.Started.IsZero() || status.Started.Before(m.logTail.since.Add(-time.Second)) {
+		return 0
+	}
+	if status.State == services.Running {
+		return time.Since(status.Started)
+	}
+	return status.Ended.Sub(status.Started)
+}
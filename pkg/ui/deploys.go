@@ -0,0 +1,328 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// deployHistoryLen is how many deployments the timeline shows
+const deployHistoryLen = 10
+
+type deployHistoryMsg struct {
+	project string
+	deploys []discover.Deployment
+	err     error
+}
+
+// deploysView is a project's deployment history, one selectable
+type deploysView struct {
+	project string
+	path    string
+	idx     int
+	loading bool
+
+	// The deployment a rollback waits on confirmation to go back to
+	rollback *discover.Deployment
+}
+
+// loadDeployHistoryCmd reads a project's latest deployments across the
+// providers it deploys to
+func loadDeployHistoryCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		deploys, err := discover.DeployHistory(name, path, deployHistoryLen)
+		return deployHistoryMsg{project: name, deploys: deploys, err: err}
+	}
+}
+
+// setDeployHistory keeps a project's deployment history
+func (m Model) setDeployHistory(msg deployHistoryMsg) Model {
+	if m.deployHistory == nil {
+		m.deployHistory = make(map[string]deployHistoryMsg)
+	}
+	if m.deploysView.project == msg.project {
+		m.deploysView.loading = false
+		m.deploysView.idx = maxInt(min(m.deploysView.idx, len(msg.deploys)-1), 0)
+	}
+	if len(msg.deploys) == 0 && msg.err == nil {
+		delete(m.deployHistory, msg.project)
+		return m
+	}
+	m.deployHistory[msg.project] = msg
+	return m
+}
+
+// openDeploys lists a project's deployments to pick one from
+func (m Model) openDeploys(p Project) (tea.Model, tea.Cmd) {
+	m.deploysView = deploysView{project: p.Name, path: p.Path, loading: true}
+	m.viewMode = DeploysView
+	return m, loadDeployHistoryCmd(p.Name, p.Path)
+}
+
+func (m Model) handleDeploysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.deploysView
+	deploys := m.deployHistory[v.project].deploys
+	last := maxInt(len(deploys)-1, 0)
+
+	if v.rollback != nil {
+		return m.handleRollbackKey(msg)
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "enter", "w":
+		if v.idx < len(deploys) && deploys[v.idx].URL != "" {
+			d := deploys[v.idx]
+			return m, openURLCmd("https://"+d.URL, d.URL)
+		}
+	case "b":
+		if v.idx < len(deploys) && deploys[v.idx].LogsURL != "" {
+			d := deploys[v.idx]
+			return m, openURLCmd(d.LogsURL, i18n.T("%s build logs", d.Provider))
+		}
+	case "y":
+		if v.idx < len(deploys) && deploys[v.idx].URL != "" {
+			return m, copyToClipboardCmd("https://"+deploys[v.idx].URL, "deployment URL")
+		}
+	case "R":
+		if v.idx >= len(deploys) {
+			return m, nil
+		}
+		target, err := rollbackTarget(deploys, v.idx)
+		if err != nil {
+			m.statusMsg = err.Error()
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		v.rollback = &target
+	case "ctrl+r":
+		v.loading = true
+		return m, loadDeployHistoryCmd(v.project, v.path)
+	}
+	return m, nil
+}
+
+// rollbackTarget is the deployment rolling back from the selected one goes
+// to: the selection itself, or the one before it when it's what's live
+func rollbackTarget(deploys []discover.Deployment, idx int) (discover.Deployment, error) {
+	d := deploys[idx]
+	live := func(c discover.Deployment) bool {
+		return c.Provider == d.Provider && c.Service == d.Service && c.Target == "production" && c.State == "ready"
+	}
+	if !live(d) {
+		return d, errors.New(i18n.T("Only ready production deploys can be rolled back to"))
+	}
+	if d.Provider == "fly" && d.Image == "" {
+		return d, errors.New(i18n.T("Fly.io didn't say which image that release ran"))
+	}
+	for i, c := range deploys {
+		if !live(c) {
+			continue
+		}
+		if i < idx {
+			// Something newer is live: go back to the selection
+			return d, nil
+		}
+		break
+	}
+	for _, c := range deploys[idx+1:] {
+		if live(c) && (c.Provider != "fly" || c.Image != "") {
+			return c, nil
+		}
+	}
+	return d, errors.New(i18n.T("No earlier deploy to roll back to (the history shows the last %d)", deployHistoryLen))
+}
+
+// handleRollbackKey answers the rollback confirmation: y rolls back,
+// anything else cancels
+func (m Model) handleRollbackKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.deploysView
+	d := *v.rollback
+	v.rollback = nil
+	if msg.String() != "y" {
+		return m, nil
+	}
+	p := m.getProjectByName(v.project)
+	if p == nil {
+		return m, nil
+	}
+	project := *p
+	return m.requireSecondFactor("rollback", project.Name, func(m Model) (tea.Model, tea.Cmd) {
+		if d.Provider == "fly" {
+			command := shellCommand("fly", "deploy", "--app", d.Site, "--image", d.Image, "--yes")
+			return m.streamDeploy(project, "rollback", "Roll "+project.Name+" back on Fly.io", command)
+		}
+		if m.dryRun {
+			return m.showPlan("Roll "+project.Name+" back on "+d.Provider, rollbackPlan(d))
+		}
+		m.statusMsg = i18n.T("Rolling %s back to %s...", project.Name, deployLabel(d))
+		m.statusMsgTime = time.Now()
+		return m, rollbackCmd(project, d)
+	})
+}
+
+// rollbackPlan says what a rollback asks the provider to do
+func rollbackPlan(d discover.Deployment) string {
+	switch d.Provider {
+	case "vercel":
+		return "promote deployment " + d.ID + " to production"
+	case "netlify":
+		return "restore deploy " + d.ID + " of site " + d.Site
+	case "render":
+		return "roll service " + d.Site + " back to deploy " + d.ID
+	}
+	return "roll back to deployment " + d.ID
+}
+
+// rollbackCmd puts an earlier deployment back live, reporting through
+// actionResultMsg so it lands in the audit log
+func rollbackCmd(p Project, d discover.Deployment) tea.Cmd {
+	return func() tea.Msg {
+		if err := discover.Rollback(p.Name, p.Path, d); err != nil {
+			return actionResultMsg{action: "rollback", project: p.Name, message: i18n.T("Rollback of %s failed: %v", p.Name, err)}
+		}
+		return actionResultMsg{action: "rollback", project: p.Name, success: true, message: i18n.T("Rolled %s back to %s", p.Name, deployLabel(d))}
+	}
+}
+
+// deployLabel names a deployment in a sentence: its commit, else its ID
+func deployLabel(d discover.Deployment) string {
+	label := d.SHA
+	if len(label) > 7 {
+		label = label[:7]
+	}
+	if label == "" {
+		label = d.ID
+	}
+	if d.Message != "" {
+		label += " (" + d.Message + ")"
+	}
+	return label
+}
+
+// deployIcon marks a deployment's state
+func deployIcon(state string) string {
+	switch state {
+	case "ready":
+		return IconCheck
+	case "failed":
+		return IconX
+	case "building", "queued":
+		return IconBuilding
+	}
+	return "-"
+}
+
+// deployDuration is a deploy's length to the second, "" when unknown
+func deployDuration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return d.Round(time.Second).String()
+}
+
+// deployLine is one deployment in the timeline: state, provider, age,
+// duration, commit, author, and message
+func deployLine(d discover.Deployment) string {
+	provider := d.Provider
+	if d.Service != "" {
+		provider += "/" + d.Service
+	}
+	if d.Target != "production" {
+		provider += " " + i18n.T("preview")
+	}
+	sha := d.SHA
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	line := fmt.Sprintf("%s %-8s %-16s %4s %7s  %-7s", deployIcon(d.State), d.State, provider,
+		strings.TrimSpace(formatTimeSince(d.Created)), deployDuration(d.Duration), sha)
+	if d.Author != "" {
+		line += "  " + d.Author
+	}
+	if d.Message != "" {
+		line += "  " + d.Message
+	}
+	return line
+}
+
+// renderDeployHistory is the detail view's deployment timeline
+func (m Model) renderDeployHistory(p *Project) string {
+	msg, ok := m.deployHistory[p.Name]
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("  Deploys (h to open one or its build logs):\n"))
+	if msg.err != nil {
+		b.WriteString(fmt.Sprintf("    %s %v\n", IconX, msg.err))
+	}
+	for _, d := range msg.deploys {
+		b.WriteString(truncate("    "+deployLine(d), maxInt(m.width-4, 20)) + "\n")
+	}
+	return b.String()
+}
+
+// renderDeploys lists a project's deployments with the selected one
+// highlighted
+func (m Model) renderDeploys(height int) string {
+	v := m.deploysView
+	msg := m.deployHistory[v.project]
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s Deploys — %s: last %d  (enter/w open, b build logs, y copy URL, R roll back, ctrl+r reload, esc back)\n\n",
+		IconRocket, v.project, len(msg.deploys)))
+	if msg.err != nil {
+		b.WriteString(fmt.Sprintf("  %s %v\n", IconX, msg.err))
+	}
+	if len(msg.deploys) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(i18n.T("  Loading deploys...\n"))
+		case msg.err != nil:
+		default:
+			b.WriteString(i18n.T("  No deploys on Vercel, Netlify, Fly.io, Render, or Railway\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	rows := maxInt(height-4, 1)
+	start := maxInt(v.idx-rows+1, 0)
+	for i := start; i < len(msg.deploys) && i < start+rows; i++ {
+		d := msg.deploys[i]
+		line := "  " + deployLine(d)
+		if d.URL != "" {
+			line += "  " + d.URL
+		}
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	if d := v.rollback; d != nil {
+		provider := d.Provider
+		if d.Service != "" {
+			provider += "/" + d.Service
+		}
+		b.WriteString(i18n.T("\n  %s Roll %s production on %s back to %s from %s ago?\n", IconConflict, v.project, provider,
+			truncate(deployLabel(*d), maxInt(m.width-50, 20)), strings.TrimSpace(formatTimeSince(d.Created))))
+		b.WriteString(i18n.T("  y to roll back, any other key cancels\n"))
+	}
+	return padLines(b.String(), height)
+}
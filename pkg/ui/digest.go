@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/incident"
+	"github.com/michaelmonetized/mission-control/pkg/maintenance"
+	"github.com/michaelmonetized/mission-control/pkg/seen"
+	"github.com/michaelmonetized/mission-control/pkg/uptime"
+)
+
+// digestAfter is how long a project must go unopened before opening it
+// shows what changed; coming back after lunch isn't catching up
+const digestAfter = time.Hour
+
+// digestItems caps how many of each kind of change are listed
+const digestItems = 5
+
+// digest is everything that happened to a project since it was last
+// opened in mc
+type digest struct {
+	since     time.Time
+	commits   []discover.Commit
+	issues    []discover.Issue       // opened since
+	prs       []discover.PullRequest // updated since
+	deploys   []discover.Deployment
+	failed    []discover.WorkflowRun
+	incidents []incident.Incident
+	outages   []uptime.Window
+	planned   []maintenance.Window
+}
+
+type digestMsg struct {
+	project string
+	d       *digest // nil when there's nothing to show
+}
+
+// empty reports whether nothing changed
+func (d *digest) empty() bool {
+	return len(d.commits)+len(d.issues)+len(d.prs)+len(d.deploys)+len(d.failed)+len(d.incidents)+len(d.outages)+len(d.planned) == 0
+}
+
+// markSeenCmd records that a project was opened now and, if it had gone
+// unopened for a while, gathers what changed since. Every source is
+// best-effort.
+func markSeenCmd(p Project) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		since, err := seen.Mark(p.Name, now)
+		if err != nil || since.IsZero() || now.Sub(since) < digestAfter {
+			return digestMsg{project: p.Name}
+		}
+
+		d := &digest{since: since}
+		commits, _ := discover.RecentCommits(p.Path, 100)
+		for _, c := range commits {
+			if c.When.After(since) {
+				d.commits = append(d.commits, c)
+			}
+		}
+		scheduleGitHub(p.Name, func() {
+			issues, _ := discover.ListIssues(p.Path)
+			for _, is := range issues {
+				if is.CreatedAt.After(since) {
+					d.issues = append(d.issues, is)
+				}
+			}
+			prs, _ := discover.ListPullRequests(p.Path)
+			for _, pr := range prs {
+				if pr.UpdatedAt.After(since) {
+					d.prs = append(d.prs, pr)
+				}
+			}
+			runs, _ := discover.RecentRuns(p.Path, 30)
+			for _, r := range runs {
+				if r.Failed() && r.CreatedAt.After(since) {
+					d.failed = append(d.failed, r)
+				}
+			}
+		})
+		deploys, _ := discover.RecentDeploys(p.Path, 20)
+		for _, dep := range deploys {
+			if dep.Created.After(since) {
+				d.deploys = append(d.deploys, dep)
+			}
+		}
+		incidents, _ := incident.Load()
+		for _, inc := range incidents {
+			if inc.Project == p.Name && inc.Started.After(since) {
+				d.incidents = append(d.incidents, inc)
+			}
+		}
+		windows, _ := maintenance.Load()
+		for _, w := range windows {
+			if w.Overlaps(p.Name, since, now) {
+				d.planned = append(d.planned, w)
+			}
+		}
+		if probes, err := uptime.Load(p.Name, since, now); err == nil && len(probes) > 0 {
+			d.outages = uptime.Summarize(p.Name, since, probes, now).Windows
+		}
+		return digestMsg{project: p.Name, d: d}
+	}
+}
+
+// renderDigest summarizes what changed since the project was last opened
+// for the detail view
+func (m Model) renderDigest(name string) string {
+	d := m.digests[name]
+	if d == nil {
+		return ""
+	}
+	ago := strings.TrimSpace(formatTimeSince(d.since))
+	if d.empty() {
+		return i18n.T("\n  %s Nothing new since you last looked (%s ago)\n", IconTime, ago)
+	}
+
+	var counts []string
+	add := func(n int, one, many string) {
+		switch {
+		case n == 1:
+			counts = append(counts, "1 "+one)
+		case n > 1:
+			counts = append(counts, fmt.Sprintf("%d %s", n, many))
+		}
+	}
+	add(len(d.commits), "commit", "commits")
+	add(len(d.issues), "new issue", "new issues")
+	add(len(d.prs), "PR updated", "PRs updated")
+	add(len(d.deploys), "deploy", "deploys")
+	add(len(d.failed), "failed run", "failed runs")
+	add(len(d.incidents), "incident", "incidents")
+	add(len(d.outages), "outage", "outages")
+	add(len(d.planned), "maintenance window", "maintenance windows")
+
+	var b strings.Builder
+	b.WriteString(i18n.T("\n  %s Since you last looked (%s ago): %s\n", IconTime, ago, strings.Join(counts, ", ")))
+	width := maxInt(m.width-24, 20)
+	for i, c := range d.commits {
+		if i == digestItems {
+			b.WriteString(fmt.Sprintf("    ... %d more commits\n", len(d.commits)-digestItems))
+			break
+		}
+		b.WriteString(fmt.Sprintf("    %s %-8s %s\n", c.ShortHash(), truncate(c.Author, 8), truncate(c.Subject, width)))
+	}
+	for i, is := range d.issues {
+		if i == digestItems {
+			break
+		}
+		b.WriteString(fmt.Sprintf("    %s #%d %s\n", IconIssue, is.Number, truncate(is.Title, width)))
+	}
+	for i, pr := range d.prs {
+		if i == digestItems {
+			break
+		}
+		b.WriteString(fmt.Sprintf("    %s #%d %s\n", IconPR, pr.Number, truncate(pr.Title, width)))
+	}
+	for i, dep := range d.deploys {
+		if i == digestItems {
+			break
+		}
+		target := "preview"
+		if dep.Target != "" {
+			target = dep.Target
+		}
+		b.WriteString(fmt.Sprintf("    %s %s %s %s\n", IconDeploy, dep.Created.Format("Jan 2 15:04"), target, dep.State))
+	}
+	for i, r := range d.failed {
+		if i == digestItems {
+			break
+		}
+		b.WriteString(fmt.Sprintf("    %s %s failed on %s, %s\n", IconX, r.Name, r.Branch, r.CreatedAt.Format("Jan 2 15:04")))
+	}
+	for _, inc := range d.incidents {
+		b.WriteString(fmt.Sprintf("    %s %s, %s\n", IconIncident, inc.Trigger, inc.Started.Format("Jan 2 15:04")))
+	}
+	for _, w := range d.outages {
+		b.WriteString(fmt.Sprintf("    %s down %s for %s\n", IconX, w.Start.Format("Jan 2 15:04"), formatDuration(w.End.Sub(w.Start))))
+	}
+	for _, w := range d.planned {
+		b.WriteString(fmt.Sprintf("    %s maintenance %s\n", IconMaintain, w.Label()))
+	}
+	return b.String()
+}
@@ -0,0 +1,442 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+const (
+	// dispatchPollInterval is how often a dispatched run is checked on
+	dispatchPollInterval = 3 * time.Second
+	// dispatchWait is how long to look for the run a dispatch started
+	// before giving up on it showing up
+	dispatchWait = 2 * time.Minute
+)
+
+// dispatchView starts a project's workflow_dispatch workflows: pick one,
+// fill in its inputs, then watch the run it started
+type dispatchView struct {
+	project   string
+	path      string
+	workflows []discover.DispatchWorkflow
+	idx       int
+
+	// The inputs form: the ref to run on, then each workflow input
+	form   bool
+	fields []textinput.Model
+	field  int
+
+	// The run being watched
+	watching string // workflow name, "" when nothing was dispatched
+	ref      string
+	since    time.Time
+	run      *discover.WorkflowRun
+	jobs     []github.RunJob
+	err      string
+}
+
+type dispatchWorkflowsMsg struct {
+	project   string
+	workflows []discover.DispatchWorkflow
+}
+
+type dispatchTickMsg struct {
+	since time.Time
+}
+
+type dispatchRunMsg struct {
+	since time.Time
+	run   *discover.WorkflowRun
+	jobs  []github.RunJob
+	err   error
+}
+
+// loadDispatchWorkflowsCmd finds the workflows a project can run by hand
+func loadDispatchWorkflowsCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		workflows, _ := discover.DispatchWorkflows(path)
+		return dispatchWorkflowsMsg{project: name, workflows: workflows}
+	}
+}
+
+// runWorkflowCmd dispatches a workflow, reporting through actionResultMsg
+// so it lands in the audit log
+func runWorkflowCmd(project, path string, wf discover.DispatchWorkflow, ref string, inputs map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		if err := discover.RunWorkflow(path, wf.File, ref, inputs); err != nil {
+			return actionResultMsg{action: "dispatch", project: project, message: i18n.T("Run %s failed: %v", wf.Name, err)}
+		}
+		return actionResultMsg{action: "dispatch", project: project, success: true, message: i18n.T("Started %s on %s", wf.Name, ref)}
+	}
+}
+
+func dispatchTickCmd(since time.Time) tea.Cmd {
+	return tea.Tick(dispatchPollInterval, func(time.Time) tea.Msg {
+		return dispatchTickMsg{since: since}
+	})
+}
+
+// watchDispatchCmd checks on a dispatched run: finds it first, then reads
+// its jobs
+func watchDispatchCmd(v dispatchView) tea.Cmd {
+	return func() tea.Msg {
+		msg := dispatchRunMsg{since: v.since, run: v.run}
+		if v.run == nil {
+			if msg.run, msg.err = discover.DispatchedRun(v.path, v.watching, v.ref, v.since); msg.run == nil {
+				return msg
+			}
+		} else {
+			runs, err := discover.RecentRuns(v.path, 10)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			for _, r := range runs {
+				if r.ID == v.run.ID {
+					msg.run = &r
+				}
+			}
+		}
+		msg.jobs, msg.err = discover.RunJobs(v.path, msg.run.ID)
+		return msg
+	}
+}
+
+// setDispatchRun applies a check on the watched run, polling again until
+// it completes
+func (m *Model) setDispatchRun(msg dispatchRunMsg) tea.Cmd {
+	v := &m.dispatch
+	if v.watching == "" || !msg.since.Equal(v.since) {
+		return nil
+	}
+	v.err = ""
+	if msg.err != nil {
+		v.err = msg.err.Error()
+	}
+	if msg.run != nil {
+		v.run = msg.run
+	}
+	if msg.jobs != nil {
+		v.jobs = msg.jobs
+	}
+	switch {
+	case v.run != nil && v.run.Status == "completed":
+		m.statusMsg = i18n.T("%s finished: %s", v.run.Name, i18n.T(v.run.State()))
+		m.statusMsgTime = time.Now()
+		if p := m.getProjectByName(v.project); p != nil {
+			return loadCICmd(p.Name, p.Path)
+		}
+		return nil
+	case v.run == nil && time.Since(v.since) > dispatchWait:
+		v.err = i18n.T("No run of %s showed up on %s; it may have been skipped", v.watching, v.ref)
+		return nil
+	}
+	return dispatchTickCmd(v.since)
+}
+
+// openDispatch lists the workflows the project can run by hand, going
+// straight to the inputs when there's only one
+func (m Model) openDispatch(p Project) (tea.Model, tea.Cmd) {
+	workflows, loaded := m.dispatchable[p.Name]
+	if m.dispatch.project != p.Name {
+		m.dispatch = dispatchView{project: p.Name, path: p.Path}
+	}
+	m.dispatch.workflows = workflows
+	m.dispatch.form = false
+	m.viewMode = DispatchView
+	if !loaded {
+		return m, loadDispatchWorkflowsCmd(p.Name, p.Path)
+	}
+	if len(workflows) == 1 && m.dispatch.watching == "" {
+		return m.startDispatchForm()
+	}
+	return m, nil
+}
+
+// setDispatchWorkflows stores a project's dispatchable workflows
+func (m *Model) setDispatchWorkflows(msg dispatchWorkflowsMsg) {
+	if m.dispatchable == nil {
+		m.dispatchable = make(map[string][]discover.DispatchWorkflow)
+	}
+	m.dispatchable[msg.project] = msg.workflows
+	if m.dispatch.project == msg.project {
+		m.dispatch.workflows = msg.workflows
+		m.dispatch.idx = maxInt(min(m.dispatch.idx, len(msg.workflows)-1), 0)
+	}
+}
+
+// startDispatchForm opens the inputs form for the selected workflow, the
+// ref defaulting to the checked-out branch
+func (m Model) startDispatchForm() (tea.Model, tea.Cmd) {
+	v := &m.dispatch
+	if v.idx >= len(v.workflows) {
+		return m, nil
+	}
+	wf := v.workflows[v.idx]
+	ref := textinput.New()
+	ref.Prompt = ""
+	ref.Placeholder = "main"
+	if p := m.getProjectByName(v.project); p != nil && p.Branch != "" {
+		ref.SetValue(p.Branch)
+	}
+	v.fields = []textinput.Model{ref}
+	for _, in := range wf.Inputs {
+		f := textinput.New()
+		f.Prompt = ""
+		f.Placeholder = in.Description
+		f.CharLimit = 500
+		value := in.Default
+		if value == "" {
+			switch {
+			case in.Type == "boolean":
+				value = "false"
+			case in.Type == "choice" && len(in.Options) > 0:
+				value = in.Options[0]
+			}
+		}
+		f.SetValue(value)
+		v.fields = append(v.fields, f)
+	}
+	v.field = 0
+	v.fields[0].Focus()
+	v.form = true
+	return m, textinput.Blink
+}
+
+// fieldOptions returns what a form field can be set to by cycling, nil
+// for fields that are typed in
+func (v dispatchView) fieldOptions(field int) []string {
+	if field == 0 || v.idx >= len(v.workflows) {
+		return nil
+	}
+	in := v.workflows[v.idx].Inputs[field-1]
+	switch in.Type {
+	case "boolean":
+		return []string{"true", "false"}
+	case "choice":
+		return in.Options
+	}
+	return nil
+}
+
+// focusField moves the form's focus by step, wrapping around
+func (v *dispatchView) focusField(step int) {
+	v.fields[v.field].Blur()
+	v.field = (v.field + step + len(v.fields)) % len(v.fields)
+	v.fields[v.field].Focus()
+}
+
+func (m Model) handleDispatchFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.dispatch
+	options := v.fieldOptions(v.field)
+
+	switch msg.String() {
+	case "esc":
+		v.fields[v.field].Blur()
+		v.form = false
+		return m, nil
+	case "tab", "down":
+		v.focusField(1)
+		return m, nil
+	case "shift+tab", "up":
+		v.focusField(-1)
+		return m, nil
+	case "left", "right", " ":
+		if len(options) == 0 {
+			break
+		}
+		step := 1
+		if msg.String() == "left" {
+			step = -1
+		}
+		current := 0
+		for i, o := range options {
+			if o == v.fields[v.field].Value() {
+				current = i
+			}
+		}
+		v.fields[v.field].SetValue(options[(current+step+len(options))%len(options)])
+		return m, nil
+	case "enter":
+		if v.field < len(v.fields)-1 {
+			v.focusField(1)
+			return m, nil
+		}
+		return m.submitDispatch()
+	}
+	if len(options) > 0 {
+		// Choices are picked, not typed
+		return m, nil
+	}
+	var cmd tea.Cmd
+	v.fields[v.field], cmd = v.fields[v.field].Update(msg)
+	return m, cmd
+}
+
+// submitDispatch checks the form and starts the workflow, then watches
+// the run it starts
+func (m Model) submitDispatch() (tea.Model, tea.Cmd) {
+	v := m.dispatch
+	wf := v.workflows[v.idx]
+	ref := strings.TrimSpace(v.fields[0].Value())
+	if ref == "" {
+		m.statusMsg = i18n.T("Enter a branch or tag to run %s on", wf.Name)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	inputs := make(map[string]string)
+	for i, in := range wf.Inputs {
+		value := strings.TrimSpace(v.fields[i+1].Value())
+		if value == "" {
+			if in.Required {
+				m.statusMsg = i18n.T("%s is required", in.Name)
+				m.statusMsgTime = time.Now()
+				return m, nil
+			}
+			continue
+		}
+		inputs[in.Name] = value
+	}
+
+	if m.dryRun {
+		return m.showPlan("Run "+wf.Name+" in "+v.project,
+			shellCommand("gh", append(discover.RunWorkflowArgs(wf.File, ref, inputs), "-R", discover.GitHubRepo(v.path))...))
+	}
+	return m.requireSecondFactor("dispatch", v.project, func(m Model) (tea.Model, tea.Cmd) {
+		v := &m.dispatch
+		v.fields[v.field].Blur()
+		v.form = false
+		v.watching, v.ref, v.since = wf.Name, ref, time.Now()
+		v.run, v.jobs, v.err = nil, nil, ""
+		return m, runWorkflowCmd(v.project, v.path, wf, ref, inputs)
+	})
+}
+
+func (m Model) handleDispatchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.dispatch
+	last := maxInt(len(v.workflows)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "enter":
+		return m.startDispatchForm()
+	case "w":
+		if v.run != nil {
+			return m, openURLCmd(v.run.URL, v.run.Name)
+		}
+	case "ctrl+r":
+		return m, loadDispatchWorkflowsCmd(v.project, v.path)
+	}
+	return m, nil
+}
+
+// renderDispatchable names the workflows a project can run by hand in the
+// detail view
+func (m Model) renderDispatchable(name string) string {
+	workflows := m.dispatchable[name]
+	if len(workflows) == 0 {
+		return ""
+	}
+	names := make([]string, len(workflows))
+	for i, wf := range workflows {
+		names[i] = wf.Name
+	}
+	return i18n.T("  Run by hand: %s (X to run)\n", truncate(strings.Join(names, ", "), maxInt(m.width-30, 20)))
+}
+
+// jobIcon is a job's or step's state icon, blank while it waits to start
+func jobIcon(status, conclusion string) string {
+	if status == "queued" || status == "pending" || status == "waiting" {
+		return " "
+	}
+	return ciIcon(discover.WorkflowRun{Status: status, Conclusion: conclusion}.State())
+}
+
+// renderDispatch shows the workflow list or the inputs form, and the
+// dispatched run's progress below
+func (m Model) renderDispatch(height int) string {
+	v := m.dispatch
+	var b strings.Builder
+
+	if v.form {
+		wf := v.workflows[v.idx]
+		b.WriteString(i18n.T("\n  %s Run %s — %s  (tab next field, ←/→ choose, enter next/run, esc cancel)\n\n", IconPlay, wf.Name, v.project))
+		labels := []string{"ref"}
+		for _, in := range wf.Inputs {
+			labels = append(labels, in.Name)
+		}
+		width := 0
+		for _, l := range labels {
+			width = max(width, len(l))
+		}
+		for i, f := range v.fields {
+			marker, label := " ", labels[i]
+			if i > 0 && wf.Inputs[i-1].Required {
+				label += "*"
+			}
+			if i == v.field {
+				marker = "›"
+			}
+			value := f.View()
+			if options := v.fieldOptions(i); options != nil {
+				value = "< " + f.Value() + " >"
+			}
+			b.WriteString(fmt.Sprintf("  %s %-*s  %s\n", marker, width+1, label, value))
+			if i > 0 && wf.Inputs[i-1].Description != "" && i == v.field {
+				b.WriteString(fmt.Sprintf("    %-*s  %s\n", width+1, "", truncate(strings.ReplaceAll(wf.Inputs[i-1].Description, "\n", " "), maxInt(m.width-width-12, 20))))
+			}
+		}
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(i18n.T("\n  %s Run a workflow — %s  (enter fill in inputs, w open run, ctrl+r reload, esc back)\n\n", IconPlay, v.project))
+	if len(v.workflows) == 0 {
+		b.WriteString(i18n.T("  No workflows with a workflow_dispatch trigger.\n"))
+	}
+	for i, wf := range v.workflows {
+		line := fmt.Sprintf("  %-28s %s", truncate(wf.File, 28), wf.Name)
+		if n := len(wf.Inputs); n > 0 {
+			line += i18n.T("  (%d inputs)", n)
+		}
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.watching == "" {
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString("\n")
+	switch {
+	case v.run == nil && v.err == "":
+		b.WriteString(i18n.T("  %s Waiting for %s to start on %s...\n", IconBuilding, v.watching, v.ref))
+	case v.run != nil:
+		b.WriteString(i18n.T("  %s %s on %s: %s, started %s ago\n", ciIcon(v.run.State()), v.run.Name, v.ref,
+			i18n.T(v.run.State()), strings.TrimSpace(formatTimeSince(v.run.CreatedAt))))
+	}
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	for _, job := range v.jobs {
+		b.WriteString(fmt.Sprintf("    %s %s\n", jobIcon(job.Status, job.Conclusion), job.Name))
+		// Steps only for jobs still going or that went wrong
+		if job.Status == "completed" && job.Conclusion == "success" {
+			continue
+		}
+		for _, step := range job.Steps {
+			b.WriteString(fmt.Sprintf("        %s %s\n", jobIcon(step.Status, step.Conclusion), truncate(step.Name, maxInt(m.width-16, 20))))
+		}
+	}
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// dryRunPlan is what an action would have done, shown instead of doing it
+type dryRunPlan struct {
+	title string
+	steps []string
+}
+
+// WithDryRun returns the model with dry-run mode set, so `mc --dry-run`
+// can start with it on
+func (m Model) WithDryRun(on bool) Model {
+	m.dryRun = on
+	return m
+}
+
+// showPlan stands in for an action in dry-run mode, listing the steps it
+// would have taken
+func (m Model) showPlan(title string, steps ...string) (tea.Model, tea.Cmd) {
+	m.plan = &dryRunPlan{title: title, steps: steps}
+	return m, nil
+}
+
+// shellCommand renders a command the way it could be pasted into a shell
+func shellCommand(name string, args ...string) string {
+	parts := []string{name}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'$`\\|&;<>()*?[]{}~#") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderPlan shows a dry-run plan until any key is pressed
+func (m Model) renderPlan(height int) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("\n  DRY RUN — %s\n\n", m.plan.title))
+	b.WriteString(i18n.T("  Nothing was executed. This action would run:\n\n"))
+	for i, step := range m.plan.steps {
+		b.WriteString(fmt.Sprintf("  %d. %s\n", i+1, truncate(step, maxInt(m.width-8, 20))))
+	}
+	b.WriteString(i18n.T("\n  Press any key to dismiss. D turns dry-run mode off.\n"))
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// driftStyle highlights environments that aren't running the same code
+var driftStyle = lipgloss.NewStyle().Foreground(ColorYellow)
+
+type environmentsMsg struct {
+	project string
+	matrix  *discover.EnvMatrix
+}
+
+// loadEnvironmentsCmd reads what each of a project's environments runs
+func loadEnvironmentsCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		var matrix *discover.EnvMatrix
+		scheduleGitHub(name, func() {
+			matrix, _ = discover.EnvironmentMatrix(path)
+		})
+		return environmentsMsg{project: name, matrix: matrix}
+	}
+}
+
+// envStateIcon is a deployment state's icon
+func envStateIcon(state string) string {
+	switch state {
+	case "success", "ready":
+		return IconCheck
+	case "failure", "error", "failed":
+		return IconX
+	case "in_progress", "queued", "pending", "building":
+		return IconBuilding
+	}
+	return " "
+}
+
+// renderEnvironments shows the environment × deployed commit matrix in the
+// detail view, with drift between environments highlighted
+func (m Model) renderEnvironments(name string) string {
+	matrix := m.envs[name]
+	if matrix == nil || len(matrix.Envs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("  Environments:\n"))
+
+	nameWidth, refWidth := 0, 0
+	for _, env := range matrix.Envs {
+		nameWidth = max(nameWidth, len(env.Environment))
+		refWidth = max(refWidth, len(env.Ref))
+	}
+	nameWidth, refWidth = min(nameWidth, 20), min(refWidth, 24)
+	for _, env := range matrix.Envs {
+		sha := env.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		line := fmt.Sprintf("    %s %-*s  %-7s  %-*s  %4s", envStateIcon(env.State), nameWidth, truncate(env.Environment, nameWidth),
+			sha, refWidth, truncate(env.Ref, refWidth), strings.TrimSpace(formatTimeSince(env.Created)))
+		if behind, ok := matrix.Behind[env.Environment]; ok && behind > 0 {
+			line += "  " + i18n.T("%d behind %s", behind, matrix.Branch)
+		}
+		b.WriteString(line + "\n")
+	}
+	for _, d := range matrix.Drift {
+		if d.Ahead == 0 && d.Behind == 0 {
+			continue
+		}
+		b.WriteString("    " + driftStyle.Render(IconConflict+" "+driftText(d)) + "\n")
+	}
+	return b.String()
+}
+
+// driftText says how far apart two environments are: "staging is 14
+// commits ahead of production"
+func driftText(d discover.EnvDrift) string {
+	switch {
+	case d.Behind == 0:
+		return i18n.T("%s is %s ahead of %s", d.From, commitCount(d.Ahead), d.To)
+	case d.Ahead == 0:
+		return i18n.T("%s is %s behind %s", d.From, commitCount(d.Behind), d.To)
+	}
+	return i18n.T("%s and %s have diverged: %s ahead, %s behind", d.From, d.To, commitCount(d.Ahead), commitCount(d.Behind))
+}
+
+// commitCount is "1 commit" or "n commits"
+func commitCount(n int) string {
+	if n == 1 {
+		return i18n.T("1 commit")
+	}
+	return i18n.T("%d commits", n)
+}
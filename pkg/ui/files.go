@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// fileList is the detail view's files tab: the current project's changed
+// paths, staged first, then modified, then untracked
+type fileList struct {
+	project string
+	files   []discover.FileChange
+	idx     int
+	loading bool
+	err     string
+}
+
+type fileListMsg struct {
+	project string
+	files   []discover.FileChange
+	err     error
+}
+
+func loadFileListCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := discover.ChangedFiles(path)
+		return fileListMsg{project: name, files: files, err: err}
+	}
+}
+
+// fileGroup is the section a change is listed under
+func fileGroup(f discover.FileChange) int {
+	switch {
+	case f.Staged():
+		return 0
+	case f.Untracked():
+		return 2
+	default:
+		return 1
+	}
+}
+
+var fileGroupNames = []string{"Staged", "Modified", "Untracked"}
+
+// deleted reports whether the path no longer exists in the working tree
+func deleted(f discover.FileChange) bool {
+	return f.Worktree == 'D' || (f.Index == 'D' && f.Worktree == ' ')
+}
+
+// openFileList switches the detail view to the files tab, reloading it so
+// it matches the counts
+func (m Model) openFileList() (tea.Model, tea.Cmd) {
+	p := m.currentProject
+	m.detailTab = detailFiles
+	idx := 0
+	if m.files.project == p.Name {
+		idx = m.files.idx
+	}
+	m.files = fileList{project: p.Name, idx: idx, loading: true}
+	return m, loadFileListCmd(p.Name, p.Path)
+}
+
+// setFileList stores a loaded list, grouped for display
+func (m *Model) setFileList(msg fileListMsg) {
+	l := &m.files
+	if l.project != msg.project {
+		return
+	}
+	l.loading = false
+	if msg.err != nil {
+		l.err = msg.err.Error()
+		return
+	}
+	l.err = ""
+	l.files = msg.files
+	sort.SliceStable(l.files, func(i, j int) bool {
+		return fileGroup(l.files[i]) < fileGroup(l.files[j])
+	})
+	l.idx = maxInt(min(l.idx, len(l.files)-1), 0)
+}
+
+func (m Model) handleFilesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.currentProject
+	l := &m.files
+
+	switch msg.String() {
+	case "j", "down":
+		if l.idx < len(l.files)-1 {
+			l.idx++
+		}
+		return m, nil
+	case "k", "up":
+		if l.idx > 0 {
+			l.idx--
+		}
+		return m, nil
+	case "g":
+		l.idx = 0
+		return m, nil
+	case "G":
+		l.idx = maxInt(len(l.files)-1, 0)
+		return m, nil
+	case "enter":
+		if l.idx >= len(l.files) {
+			return m, nil
+		}
+		f := l.files[l.idx]
+		if deleted(f) {
+			m.statusMsg = i18n.T("%s was deleted", f.Path)
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		return m, openInEditorCmd(p.Path, f.Path)
+	case "ctrl+r":
+		return m.openFileList()
+	}
+	return m.handleListKey(msg)
+}
+
+// fileState describes a change in words for the files tab
+func fileState(f discover.FileChange) string {
+	var parts []string
+	switch f.Index {
+	case 'A':
+		parts = append(parts, "added")
+	case 'D':
+		parts = append(parts, "deleted")
+	case 'R':
+		parts = append(parts, "renamed")
+	case 'C':
+		parts = append(parts, "copied")
+	case 'M', 'T':
+		parts = append(parts, "modified")
+	}
+	if f.Staged() && f.Unstaged() {
+		parts = append(parts, "then")
+	}
+	switch f.Worktree {
+	case 'M', 'T':
+		parts = append(parts, "modified")
+	case 'D':
+		parts = append(parts, "deleted")
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderFileList shows the current project's changes for the files tab
+func (m Model) renderFileList(height int) string {
+	p := m.currentProject
+	l := m.files
+	var b strings.Builder
+
+	b.WriteString(m.renderDetailTabs())
+	b.WriteString(i18n.T("\n  %s %s — %d staged, %d modified, %d untracked  (enter open in editor, ctrl+r reload)\n\n",
+		IconModified, p.Name, p.Staged, p.Modified, p.Untracked))
+
+	if l.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, l.err))
+	}
+	if len(l.files) == 0 {
+		if l.loading {
+			b.WriteString(i18n.T("  Loading changes...\n"))
+		} else if l.err == "" {
+			b.WriteString(i18n.T("  %s Working tree clean\n", IconCheck))
+		}
+		return padLines(b.String(), height)
+	}
+
+	// Section headings are interleaved with files, so track rows
+	// rather than indexes to keep the selection on screen
+	type row struct {
+		text string
+		file int // -1 for a heading
+	}
+	var rows []row
+	width := maxInt(m.width-28, 20)
+	group := -1
+	for i, f := range l.files {
+		if g := fileGroup(f); g != group {
+			group = g
+			rows = append(rows, row{text: "  " + i18n.T(fileGroupNames[g]), file: -1})
+		}
+		name := f.Path
+		if f.OrigPath != "" {
+			name = f.OrigPath + " → " + f.Path
+		}
+		text := fmt.Sprintf("    %-*s", width, truncate(name, width))
+		if state := fileState(f); state != "" {
+			text += "  " + i18n.T(state)
+		}
+		rows = append(rows, row{text: strings.TrimRight(text, " "), file: i})
+	}
+
+	selected := 0
+	for r, row := range rows {
+		if row.file == l.idx {
+			selected = r
+			break
+		}
+	}
+	visible := height - 5
+	start := 0
+	if selected >= visible {
+		start = selected - visible + 1
+	}
+	for r := start; r < len(rows) && r < start+visible; r++ {
+		line := rows[r].text
+		if rows[r].file == l.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/flags"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// flagsShown caps the flags listed in the detail view, stale ones first
+const flagsShown = 10
+
+type flagsMsg struct {
+	project string
+	report  *flags.Report
+	err     error
+}
+
+// loadFlagsCmd reads a project's feature flags from its flag service,
+// when it has one configured
+func loadFlagsCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil {
+			return flagsMsg{project: name, err: err}
+		}
+		report, err := flags.ForProject(cfg, name)
+		return flagsMsg{project: name, report: report, err: err}
+	}
+}
+
+// renderFlags counts a project's enabled and stale flags for the detail
+// view and lists them, stale first
+func (m Model) renderFlags(name string) string {
+	if err, ok := m.flagErrs[name]; ok {
+		return i18n.T("  Flags: %s %s\n", IconX, err)
+	}
+	report := m.flags[name]
+	if report == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(i18n.T("  Flags (%s): %d enabled, %d stale of %d\n", report.Source.Kind, report.Enabled(), report.Stale(), len(report.Flags)))
+	keyWidth := 0
+	for _, f := range report.Flags[:min(len(report.Flags), flagsShown)] {
+		keyWidth = max(keyWidth, len(f.Key))
+	}
+	keyWidth = min(keyWidth, 32)
+	for _, f := range report.Flags[:min(len(report.Flags), flagsShown)] {
+		state := i18n.T("off")
+		if f.Enabled {
+			state = i18n.T("on")
+		}
+		line := fmt.Sprintf("    %-*s  %-3s  %s", keyWidth, truncate(f.Key, keyWidth), state, strings.TrimSpace(formatTimeSince(f.Changed)))
+		if f.Stale {
+			line = driftStyle.Render(line + "  " + i18n.T("stale"))
+		}
+		b.WriteString(line + "\n")
+	}
+	if more := len(report.Flags) - flagsShown; more > 0 {
+		b.WriteString(i18n.T("    ...and %d more\n", more))
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/fly"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type flyStatusMsg struct {
+	project string
+	status  *discover.FlyStatus
+	err     error
+}
+
+// loadFlyStatusCmd reads the Fly.io app a project's fly.toml names
+func loadFlyStatusCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		status, err := discover.GetFlyStatus(path)
+		return flyStatusMsg{project: name, status: status, err: err}
+	}
+}
+
+// setFlyStatus keeps a project's Fly.io app status
+func (m Model) setFlyStatus(msg flyStatusMsg) Model {
+	if m.flyStatus == nil {
+		m.flyStatus = make(map[string]flyStatusMsg)
+	}
+	if msg.status == nil && msg.err == nil {
+		delete(m.flyStatus, msg.project)
+		return m
+	}
+	m.flyStatus[msg.project] = msg
+	return m
+}
+
+// renderFly shows a Fly.io app's deployed release and machine health in
+// the detail view
+func (m Model) renderFly(p *Project) string {
+	msg, ok := m.flyStatus[p.Name]
+	if !ok {
+		return ""
+	}
+	s := msg.status
+	if s == nil || (msg.err != nil && s.Status == "") {
+		app := ""
+		if s != nil {
+			app = s.App + " "
+		}
+		if errors.Is(msg.err, fly.ErrNoToken) {
+			return i18n.T("  Fly: %s(no token: set tokens.fly or log in with fly)\n", app)
+		}
+		return i18n.T("  Fly: %s(%v)\n", app, msg.err)
+	}
+
+	line := s.App
+	if s.Version > 0 {
+		line += i18n.T(" v%d %s %s ago", s.Version, s.Release, strings.TrimSpace(formatTimeSince(s.ReleasedAt)))
+	} else {
+		line += " " + s.Status
+	}
+	if s.Machines > 0 {
+		line += i18n.T(", %d/%d machines started", s.Started, s.Machines)
+		if len(s.Regions) > 0 {
+			line += i18n.T(" in %s", strings.Join(s.Regions, ", "))
+		}
+		if s.Checks > 0 {
+			line += i18n.T(", checks %d/%d passing", s.Passing, s.Checks)
+		}
+	}
+	if !s.Healthy() || s.Release == "failed" {
+		line = driftStyle.Render(fmt.Sprintf("%s %s", IconConflict, line))
+	}
+	if m.deploys.Running(p.Name) > 0 {
+		line += " " + driftStyle.Render(i18n.T("deploying..."))
+	}
+	return i18n.T("  Fly: %s (y deploys)\n", line)
+}
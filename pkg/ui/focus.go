@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/focus"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// focusCheckInterval is how often the daemon is asked for focus while following;
+// switching tmux windows should feel immediate
+const focusCheckInterval = time.Second
+
+type focusTickMsg struct{}
+
+type focusMsg struct {
+	report focus.Report
+	err    error
+}
+
+func focusTickCmd() tea.Cmd {
+	return tea.Tick(focusCheckInterval, func(time.Time) tea.Msg {
+		return focusTickMsg{}
+	})
+}
+
+func loadFocusCmd() tea.Msg {
+	r, err := focus.Get()
+	return focusMsg{report: r, err: err}
+}
+
+// toggleFollow turns focus-follow on or off. Turning it on jumps to the
+// latest report straight away.
+func (m Model) toggleFollow() (tea.Model, tea.Cmd) {
+	m.following = !m.following
+	m.statusMsgTime = time.Now()
+	if !m.following {
+		m.statusMsg = i18n.T("Focus-follow off")
+		return m, nil
+	}
+	m.statusMsg = i18n.T("Focus-follow on: selecting the project tmux or your editor reports (mc focus hooks)")
+	m.focusAt = time.Time{}
+	m.focusErr = false
+	return m, loadFocusCmd
+}
+
+// followFocus selects the project a new focus report is in and points
+// chat at it; an open detail view switches to it. Reports wait while
+// something is being typed or confirmed. A daemon that can't be reached
+// is mentioned once rather than every poll.
+func (m Model) followFocus(r focus.Report, err error) (tea.Model, tea.Cmd) {
+	if err != nil {
+		if m.following && !m.focusErr {
+			m.focusErr = true
+			m.statusMsg = i18n.T("Focus-follow: %v", err)
+			m.statusMsgTime = time.Now()
+		}
+		return m, nil
+	}
+	m.focusErr = false
+	if !m.following || !r.At.After(m.focusAt) || len(m.projects) == 0 {
+		return m, nil
+	}
+	if m.viewMode == SearchMode || m.secondFactor != nil || m.snoozeProject != "" {
+		return m, nil
+	}
+	m.focusAt = r.At
+
+	// The innermost project wins, for projects nested in others
+	idx, best := -1, 0
+	for i, p := range m.filtered {
+		root := expandPath(p.Path)
+		if real, err := filepath.EvalSymlinks(root); err == nil {
+			root = real
+		}
+		if focus.Within(r.Dir, root) && len(root) > best {
+			idx, best = i, len(root)
+		}
+	}
+	if idx < 0 {
+		return m, nil
+	}
+	p := m.filtered[idx]
+	m.chatCwd = expandPath(p.Path)
+	if idx == m.selectedIdx && (m.viewMode != DetailView || m.currentProject == nil || m.currentProject.Name == p.Name) {
+		return m, nil
+	}
+
+	m.selectedIdx = idx
+	m.ensureVisible(m.getListHeight())
+	source := r.Source
+	if source == "" {
+		source = "focus"
+	}
+	m.statusMsg = i18n.T("Following %s: %s", source, p.Name)
+	m.statusMsgTime = time.Now()
+	if m.viewMode == DetailView && (m.currentProject == nil || m.currentProject.Name != p.Name) {
+		return m.openProject()
+	}
+	return m, nil
+}
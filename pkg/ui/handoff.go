@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/handoff"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/openclaw"
+)
+
+// handoffPath is where a project's handoff document is written today
+func handoffPath(project string) string {
+	return filepath.Join(config.Dir(), "handoff", handoff.Filename(project, time.Now()))
+}
+
+// handoffCmd assembles a project's handoff bundle, with the architecture
+// summary drafted by OpenClaw when it's running, and writes it as markdown
+func handoffCmd(client *openclaw.Client, p Project) tea.Cmd {
+	return func() tea.Msg {
+		cfg, _ := config.Load()
+		var complete func(string) (string, error)
+		if client != nil {
+			complete = client.Complete
+		}
+		b := handoff.Assemble(cfg, p.Name, p.Path, complete)
+
+		path := handoffPath(p.Name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(b.Markdown()), 0644)
+		}
+		if err != nil {
+			return actionResultMsg{action: "handoff", project: p.Name, message: i18n.T("Handoff failed: %v", err)}
+		}
+		return actionResultMsg{action: "handoff", project: p.Name, success: true, message: i18n.T("Wrote handoff for %s to %s", p.Name, path)}
+	}
+}
+
+// startHandoff builds the handoff document for p in the background
+func (m Model) startHandoff(p Project) (tea.Model, tea.Cmd) {
+	if m.dryRun {
+		return m.showPlan("Handoff "+p.Name,
+			"read layout, manifests, README, env files, and toolchain pins in "+expandPath(p.Path),
+			"list open issues on "+discover.ForgeRepo(p.Path),
+			"POST OpenClaw /v1/chat/completions (architecture summary)",
+			"write "+handoffPath(p.Name))
+	}
+	m.statusMsg = i18n.T("Assembling handoff for %s...", p.Name)
+	m.statusMsgTime = time.Now()
+	return m, handoffCmd(m.clawClient, p)
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type healthMsg struct {
+	project string
+	health  *discover.RepoHealth
+}
+
+func loadHealthCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		h, _ := discover.CheckRepoHealth(path)
+		return healthMsg{project: name, health: h}
+	}
+}
+
+// renderHealth summarizes a repo's housekeeping for the detail view,
+// listing each problem with the command that fixes it
+func (m Model) renderHealth(name string) string {
+	h := m.health[name]
+	if h == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	problems := h.Problems()
+	mark := IconCheck
+	if len(problems) > 0 {
+		mark = IconX
+	}
+	b.WriteString(i18n.T("  Repo health: %s %s on disk, %d loose objects\n", mark, discover.FormatBytes(h.Size()), h.LooseObjects))
+	for _, p := range problems {
+		b.WriteString(fmt.Sprintf("    %s %s → %s\n", IconX, p.Summary, p.Fix))
+	}
+	return b.String()
+}
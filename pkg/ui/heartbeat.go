@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// staleAfter is how old a provider's last successful refresh can get
+// before the bottom bar warns that its data may be out of date
+const staleAfter = 30 * time.Minute
+
+// noteRefresh records a successful refresh from a provider (git, github,
+// vercel)
+func (m *Model) noteRefresh(provider string) {
+	m.refreshedAt[provider] = time.Now()
+}
+
+// renderFreshness says how long ago data last refreshed successfully,
+// naming any provider that has gone stale so old data isn't mistaken for
+// current data
+func (m Model) renderFreshness() string {
+	var latest time.Time
+	var stale []string
+	for provider, at := range m.refreshedAt {
+		if at.After(latest) {
+			latest = at
+		}
+		if time.Since(at) > staleAfter {
+			stale = append(stale, provider)
+		}
+	}
+	if latest.IsZero() {
+		return ""
+	}
+
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		oldest := m.refreshedAt[stale[0]]
+		for _, p := range stale {
+			if m.refreshedAt[p].Before(oldest) {
+				oldest = m.refreshedAt[p]
+			}
+		}
+		return i18n.T("%s %s stale (%s ago)", IconConflict, strings.Join(stale, ", "), strings.TrimSpace(formatTimeSince(oldest)))
+	}
+	return i18n.T("%s refreshed %s ago", IconTime, strings.TrimSpace(formatTimeSince(latest)))
+}
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/inbox"
+)
+
+type inboxMsg struct {
+	items []inbox.Item
+	err   error
+}
+
+// inboxRoutedMsg reports the outcome of capturing or routing an item.
+// dest is set only when an item was routed somewhere.
+type inboxRoutedMsg struct {
+	status  string
+	dest    string
+	project string
+	text    string
+	err     error
+}
+
+func loadInboxCmd() tea.Msg {
+	items, err := inbox.Load()
+	return inboxMsg{items: items, err: err}
+}
+
+func captureCmd(text, project string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := inbox.Add(text, project); err != nil {
+			return inboxRoutedMsg{err: err}
+		}
+		return inboxRoutedMsg{status: "Captured to inbox"}
+	}
+}
+
+// routeInboxCmd sends an item to TODO.md ("todo"), an issue on the
+// project's forge ("issue"), or Linear ("linear") and removes it from the inbox on success
+func routeInboxCmd(item inbox.Item, p Project, dest string) tea.Cmd {
+	return func() tea.Msg {
+		path := expandPath(p.Path)
+		var status string
+		var err error
+
+		switch dest {
+		case "todo":
+			err = inbox.ToTodo(item, path)
+			status = "Added to " + p.Name + "/TODO.md"
+		case "issue":
+			var url string
+			url, err = inbox.ToIssue(item, path)
+			status = "Opened " + url
+		case "linear":
+			var cfg *config.Config
+			if cfg, err = config.Load(); err == nil {
+				var id string
+				id, err = inbox.ToLinear(cfg, item, p.Name)
+				status = "Created Linear issue " + id
+			}
+		}
+
+		if err == nil {
+			err = inbox.Remove(item.ID)
+		}
+		return inboxRoutedMsg{status: status, dest: dest, project: p.Name, text: item.Text, err: err}
+	}
+}
+
+// routeSteps describes what routing an item does, for dry-run mode
+func routeSteps(item inbox.Item, p Project, dest string) []string {
+	path := expandPath(p.Path)
+	var steps []string
+	switch dest {
+	case "todo":
+		steps = append(steps, fmt.Sprintf("append %q to %s", "- [ ] "+item.Text, filepath.Join(path, "TODO.md")))
+	case "issue":
+		steps = append(steps, fmt.Sprintf("open issue %q on %s: %q", item.Text, discover.ForgeRepo(path), inbox.IssueBody(item)))
+	case "linear":
+		steps = append(steps, fmt.Sprintf("POST https://api.linear.app/graphql issueCreate(teamId: linear_team of %s, title: %q)", p.Name, item.Text))
+	}
+	return append(steps, "remove item "+item.ID+" from "+inbox.Path())
+}
+
+// startCapture opens the capture input over whatever view is showing
+func (m Model) startCapture() (tea.Model, tea.Cmd) {
+	m.capturing = true
+	m.captureInput.SetValue("")
+	m.captureInput.Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) handleCaptureKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.capturing = false
+		m.captureInput.Blur()
+		return m, nil
+	case "enter":
+		text := strings.TrimSpace(m.captureInput.Value())
+		m.capturing = false
+		m.captureInput.Blur()
+		if text == "" {
+			return m, nil
+		}
+		// Remember which project was in focus
+		project := ""
+		if m.viewMode == DetailView && m.currentProject != nil {
+			project = m.currentProject.Name
+		} else if m.selectedIdx < len(m.filtered) {
+			project = m.filtered[m.selectedIdx].Name
+		}
+		return m, captureCmd(text, project)
+	}
+
+	var cmd tea.Cmd
+	m.captureInput, cmd = m.captureInput.Update(msg)
+	return m, cmd
+}
+
+// inboxTarget returns the project an item will be routed to
+func (m Model) inboxTarget(item inbox.Item) (Project, bool) {
+	name := item.Project
+	if t, ok := m.inboxTargets[item.ID]; ok {
+		name = t
+	}
+	if p := m.getProjectByName(name); p != nil {
+		return *p, true
+	}
+	return Project{}, false
+}
+
+// cycleInboxTarget moves the selected item's target project by delta
+func (m *Model) cycleInboxTarget(delta int) {
+	if m.inboxIdx >= len(m.inbox) || len(m.projects) == 0 {
+		return
+	}
+	item := m.inbox[m.inboxIdx]
+	idx := -1
+	if p, ok := m.inboxTarget(item); ok {
+		for i := range m.projects {
+			if m.projects[i].Name == p.Name {
+				idx = i
+				break
+			}
+		}
+	}
+	idx = ((idx+delta)%len(m.projects) + len(m.projects)) % len(m.projects)
+	m.inboxTargets[item.ID] = m.projects[idx].Name
+}
+
+func (m Model) handleInboxKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.inboxIdx < len(m.inbox)-1 {
+			m.inboxIdx++
+		}
+	case "k", "up":
+		if m.inboxIdx > 0 {
+			m.inboxIdx--
+		}
+	case "[", "h":
+		m.cycleInboxTarget(-1)
+	case "]", "l":
+		m.cycleInboxTarget(1)
+	case "t", "g", "L":
+		if m.inboxIdx >= len(m.inbox) {
+			return m, nil
+		}
+		item := m.inbox[m.inboxIdx]
+		p, ok := m.inboxTarget(item)
+		if !ok {
+			m.statusMsg = i18n.T("Pick a project with [ / ] first")
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		dest := map[string]string{"t": "todo", "g": "issue", "L": "linear"}[msg.String()]
+		if m.dryRun {
+			return m.showPlan("Route inbox item to "+p.Name, routeSteps(item, p, dest)...)
+		}
+		m.statusMsg = i18n.T("Routing to %s (%s)...", p.Name, dest)
+		m.statusMsgTime = time.Now()
+		return m, routeInboxCmd(item, p, dest)
+	case "x":
+		if m.inboxIdx < len(m.inbox) {
+			id := m.inbox[m.inboxIdx].ID
+			return m, func() tea.Msg {
+				return inboxRoutedMsg{status: "Discarded", err: inbox.Remove(id)}
+			}
+		}
+	case "ctrl+n":
+		return m.startCapture()
+	}
+	return m, nil
+}
+
+// renderInbox lists captured items with their routing target
+func (m Model) renderInbox(height int) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("\n  %s Inbox (%d)  [/] project, t TODO.md, g issue, L Linear, x discard\n\n", IconTodo, len(m.inbox)))
+	if m.inboxErr != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, m.inboxErr))
+	}
+	if len(m.inbox) == 0 {
+		b.WriteString("  Inbox zero. Capture with ctrl+n anywhere, or mc todo \"...\"\n")
+		return padLines(b.String(), height)
+	}
+
+	rows := height - 4
+	start := maxInt(m.inboxIdx-rows+1, 0)
+	for i := start; i < len(m.inbox) && i < start+rows; i++ {
+		item := m.inbox[i]
+		target := "(no project)"
+		if p, ok := m.inboxTarget(item); ok {
+			target = "→ " + p.Name
+		}
+		line := fmt.Sprintf("  %4s  %-*s %s", strings.TrimSpace(formatTimeSince(item.Created)),
+			maxInt(m.width-36, 20), truncate(item.Text, maxInt(m.width-36, 20)), truncate(target, 24))
+		if i == m.inboxIdx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return padLines(b.String(), height)
+}
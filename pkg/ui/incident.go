@@ -0,0 +1,366 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/incident"
+	"github.com/michaelmonetized/mission-control/pkg/openclaw"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+	"github.com/michaelmonetized/mission-control/pkg/snooze"
+)
+
+// incidentView is the incident panel: the project's open incident with
+// what's known about the outage gathered around its timeline
+type incidentView struct {
+	project string
+	path    string
+	inc     incident.Incident
+
+	deploys []discover.Deployment
+	runs    []discover.WorkflowRun
+	loading bool
+
+	draft    string // status update drafted by OpenClaw
+	drafting bool
+}
+
+type incidentsMsg struct {
+	incidents []incident.Incident
+	err       error
+}
+
+// incidentMsg carries an incident just started, noted, or resolved
+type incidentMsg struct {
+	inc incident.Incident
+	err error
+}
+
+type incidentContextMsg struct {
+	project string
+	deploys []discover.Deployment
+	runs    []discover.WorkflowRun
+}
+
+type incidentDraftMsg struct {
+	project string
+	draft   string
+	err     error
+}
+
+func loadIncidentsCmd() tea.Msg {
+	incidents, err := incident.Load()
+	return incidentsMsg{incidents: incidents, err: err}
+}
+
+func startIncidentCmd(project, trigger string) tea.Cmd {
+	return func() tea.Msg {
+		inc, err := incident.Start(project, trigger)
+		return incidentMsg{inc: inc, err: err}
+	}
+}
+
+func noteIncidentCmd(id, text string) tea.Cmd {
+	return func() tea.Msg {
+		inc, err := incident.Note(id, text)
+		return incidentMsg{inc: inc, err: err}
+	}
+}
+
+// resolveIncidentCmd closes an incident, reporting through actionResultMsg
+// so it lands in the audit log
+func resolveIncidentCmd(inc incident.Incident) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := incident.Resolve(inc.ID); err != nil {
+			return actionResultMsg{action: "incident", project: inc.Project, message: "Resolve incident: " + err.Error()}
+		}
+		return actionResultMsg{action: "incident", project: inc.Project, success: true,
+			message: fmt.Sprintf("Resolved %s incident after %s", inc.Project, formatDuration(inc.Duration()))}
+	}
+}
+
+// loadIncidentContextCmd gathers recent deploys and CI runs. Both are
+// best-effort.
+func loadIncidentContextCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		deploys, _ := discover.RecentDeploys(path, 5)
+		runs, _ := discover.RecentRuns(path, 5)
+		return incidentContextMsg{project: name, deploys: deploys, runs: runs}
+	}
+}
+
+// draftStatusCmd asks OpenClaw for a status page update
+func draftStatusCmd(client *openclaw.Client, prompt, project string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return incidentDraftMsg{project: project, err: fmt.Errorf("OpenClaw is not configured")}
+		}
+		draft, err := client.Complete(prompt)
+		return incidentDraftMsg{project: project, draft: strings.TrimSpace(draft), err: err}
+	}
+}
+
+// redAlerts lists what's wrong with a project in production, leaving out
+// snoozed alerts
+func (m Model) redAlerts(p *Project) []string {
+	var alerts []string
+	if p.deployFailed() && !m.snoozed.Muted(p.Name, snooze.AlertDeploy) {
+		alerts = append(alerts, "deploy failed")
+	}
+	if p.CI.State() == discover.CIFailing && !m.snoozed.Muted(p.Name, snooze.AlertCI) {
+		alerts = append(alerts, "CI failing on "+p.CI.Branch)
+	}
+	if err, ok := m.down[p.Name]; ok && !m.snoozed.Muted(p.Name, snooze.AlertDown) {
+		alerts = append(alerts, "production down: "+err)
+	}
+	if spike, ok := m.errorSpikes[p.Name]; ok && !m.snoozed.Muted(p.Name, snooze.AlertErrors) {
+		alerts = append(alerts, "Sentry error spike: "+spike)
+	}
+	return alerts
+}
+
+// setIncidents applies a freshly loaded incident list
+func (m *Model) setIncidents(msg incidentsMsg) {
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Incidents: %v", msg.err)
+		m.statusMsgTime = time.Now()
+		return
+	}
+	m.incidents = incident.Active(msg.incidents)
+	for _, inc := range msg.incidents {
+		if inc.ID == m.incident.inc.ID {
+			m.incident.inc = inc
+		}
+	}
+	m.syncFiltered()
+}
+
+// openIncident shows the project's open incident, starting one for its
+// alerts if there isn't one yet
+func (m Model) openIncident(p Project) (tea.Model, tea.Cmd) {
+	m.incident = incidentView{project: p.Name, path: p.Path, loading: true}
+	m.incidentNote.Blur()
+	m.viewMode = IncidentView
+	load := loadIncidentContextCmd(p.Name, p.Path)
+	if inc, ok := m.incidents[p.Name]; ok {
+		m.incident.inc = inc
+		return m, load
+	}
+	trigger := strings.Join(m.redAlerts(&p), "; ")
+	if trigger == "" {
+		trigger = "opened by hand"
+	}
+	return m, tea.Batch(startIncidentCmd(p.Name, trigger), load)
+}
+
+// setIncident applies an incident that was just started or noted
+func (m *Model) setIncident(msg incidentMsg) {
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Incident: %v", msg.err)
+		m.statusMsgTime = time.Now()
+		return
+	}
+	if m.incidents == nil {
+		m.incidents = make(map[string]incident.Incident)
+	}
+	m.incidents[msg.inc.Project] = msg.inc
+	if m.incident.project == msg.inc.Project {
+		m.incident.inc = msg.inc
+	}
+	m.syncFiltered()
+}
+
+// statusPrompt asks for a status page update from the incident so far
+func (m Model) statusPrompt() string {
+	v := m.incident
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Draft a short, calm status page update for an ongoing incident affecting %s. Say what's affected and what we're doing; no speculation about causes we haven't confirmed. Plain text, three sentences or fewer.\n", v.project)
+	fmt.Fprintf(&sb, "\nStarted %s (%s ago), triggered by: %s\n", v.inc.Started.Format("15:04 MST"), formatDuration(v.inc.Duration()), v.inc.Trigger)
+	sb.WriteString("\nTimeline:\n")
+	for _, e := range m.incidentTimeline() {
+		fmt.Fprintf(&sb, "- %s %s\n", e.Time.Format("15:04"), e.Text)
+	}
+	if len(v.deploys) > 0 {
+		sb.WriteString("\nRecent deploys:\n")
+		for _, d := range v.deploys {
+			fmt.Fprintf(&sb, "- %s %s %s\n", d.Created.Format("15:04"), d.State, d.Target)
+		}
+	}
+	return sb.String()
+}
+
+func (m Model) handleIncidentKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.incident
+	switch msg.String() {
+	case "n":
+		if v.inc.ID == "" {
+			return m, nil
+		}
+		m.incidentNote.SetValue("")
+		m.incidentNote.Focus()
+		return m, textinput.Blink
+	case "d":
+		if v.inc.ID == "" || v.drafting {
+			return m, nil
+		}
+		v.drafting = true
+		return m, draftStatusCmd(m.clawClient, m.statusPrompt(), v.project)
+	case "y":
+		if v.draft != "" {
+			return m, copyToClipboardCmd(v.draft, i18n.T("status update"))
+		}
+		var lines []string
+		for _, e := range m.incidentTimeline() {
+			lines = append(lines, e.Time.Format("15:04")+" "+e.Text)
+		}
+		if len(lines) > 0 {
+			return m, copyToClipboardCmd(strings.Join(lines, "\n"), i18n.T("timeline"))
+		}
+	case "x":
+		if v.inc.ID != "" && v.inc.Open() {
+			return m, resolveIncidentCmd(v.inc)
+		}
+	case "L":
+		if p := m.getProjectByName(v.project); p != nil {
+			return m.openLogs(*p)
+		}
+	case "w":
+		if p := m.getProjectByName(v.project); p != nil {
+			return m.openRuns(*p)
+		}
+	case "ctrl+r":
+		v.loading = true
+		return m, loadIncidentContextCmd(v.project, v.path)
+	}
+	return m, nil
+}
+
+// handleIncidentNoteKey edits a timeline note: enter adds it, esc drops it
+func (m Model) handleIncidentNoteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		text := m.incidentNote.Value()
+		m.incidentNote.Blur()
+		if strings.TrimSpace(text) == "" {
+			return m, nil
+		}
+		return m, noteIncidentCmd(m.incident.inc.ID, text)
+	case "esc":
+		m.incidentNote.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.incidentNote, cmd = m.incidentNote.Update(msg)
+	return m, cmd
+}
+
+// renderIncidentSummary offers an incident in the detail view when a
+// project is red, or points at the one already open
+func (m Model) renderIncidentSummary(p *Project) string {
+	if inc, ok := m.incidents[p.Name]; ok {
+		return i18n.T("  %s Incident open for %s: %s (! to view)\n", IconIncident, formatDuration(inc.Duration()), inc.Trigger)
+	}
+	if alerts := m.redAlerts(p); len(alerts) > 0 {
+		return i18n.T("  %s %s (! to open an incident)\n", IconIncident, strings.Join(alerts, "; "))
+	}
+	return ""
+}
+
+// renderIncident lays out the incident: timeline and draft first, then
+// live alerts, deploys, CI, and the newest log lines
+func (m Model) renderIncident(height int) string {
+	v := m.incident
+	var b strings.Builder
+
+	state := i18n.T("starting...")
+	if v.inc.ID != "" {
+		state = i18n.T("open %s", formatDuration(v.inc.Duration()))
+		if !v.inc.Open() {
+			state = i18n.T("resolved after %s", formatDuration(v.inc.Duration()))
+		}
+	}
+	b.WriteString(i18n.T("\n  %s Incident — %s  %s  (n note, d draft update, y copy, L logs, w runs, x resolve, esc back)\n", IconIncident, v.project, state))
+	if v.inc.Trigger != "" {
+		b.WriteString(i18n.T("  Trigger: %s\n", v.inc.Trigger))
+	}
+
+	b.WriteString("\n" + i18n.T("  Timeline") + "\n")
+	for _, e := range m.incidentTimeline() {
+		b.WriteString(truncate(fmt.Sprintf("    %s  %s", e.Time.Format("Jan 2 15:04"), e.Text), maxInt(m.width-4, 20)) + "\n")
+	}
+	if m.incidentNote.Focused() {
+		b.WriteString(fmt.Sprintf("    %s\n", m.incidentNote.View()))
+	}
+
+	switch {
+	case v.drafting:
+		b.WriteString("\n" + i18n.T("  Drafting status update...") + "\n")
+	case v.draft != "":
+		b.WriteString("\n" + i18n.T("  Status update draft (y to copy)") + "\n")
+		for _, line := range strings.Split(v.draft, "\n") {
+			b.WriteString(truncate("    "+line, maxInt(m.width-4, 20)) + "\n")
+		}
+	}
+
+	if p := m.getProjectByName(v.project); p != nil {
+		if alerts := m.redAlerts(p); len(alerts) > 0 {
+			b.WriteString("\n" + i18n.T("  Alerts") + "\n")
+			for _, a := range alerts {
+				b.WriteString(fmt.Sprintf("    %s %s\n", IconX, a))
+			}
+		}
+	}
+
+	if len(v.deploys) > 0 {
+		b.WriteString("\n" + i18n.T("  Recent deploys") + "\n")
+		for _, d := range v.deploys {
+			icon := IconCheck
+			switch d.State {
+			case "failed":
+				icon = IconX
+			case "building", "queued":
+				icon = IconBuilding
+			}
+			target := d.Target
+			if target == "" {
+				target = "preview"
+			}
+			line := fmt.Sprintf("    %s %-8s %-10s %4s  %s", icon, d.State, target, strings.TrimSpace(formatTimeSince(d.Created)), d.URL)
+			b.WriteString(truncate(line, maxInt(m.width-4, 20)) + "\n")
+		}
+	}
+	if len(v.runs) > 0 {
+		b.WriteString("\n" + i18n.T("  Recent CI runs") + "\n")
+		for _, r := range v.runs {
+			line := fmt.Sprintf("    %s %s on %s  %s", ciIcon(r.State()), r.Name, r.Branch, strings.TrimSpace(formatTimeSince(r.CreatedAt)))
+			b.WriteString(truncate(line, maxInt(m.width-4, 20)) + "\n")
+		}
+	}
+	if v.loading {
+		b.WriteString("\n" + i18n.T("  Loading deploys and CI runs...") + "\n")
+	}
+
+	// Whatever is being tailed, else the project's services
+	logs := m.tails.Logs(v.project, 8)
+	if len(logs) == 0 {
+		logs = m.svcs.Logs(v.project, 8)
+	}
+	b.WriteString("\n" + i18n.T("  Logs") + "\n")
+	if len(logs) == 0 {
+		b.WriteString(i18n.T("    Nothing captured yet (L to tail production logs)") + "\n")
+	}
+	for _, l := range logs {
+		b.WriteString(truncate(logLine(l), maxInt(m.width-2, 20)) + "\n")
+	}
+	return padLines(b.String(), height)
+}
+
+// logLine formats a captured line of output for the incident view
+func logLine(l services.Line) string {
+	return fmt.Sprintf("    %s  %s", l.Time.Format("15:04:05"), plainText(l.Text))
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// issueList is a project's open issues
+type issueList struct {
+	project string
+	path    string
+	issues  []discover.Issue
+	idx     int
+	loading bool
+	err     string
+}
+
+type issuesMsg struct {
+	project string
+	issues  []discover.Issue
+	err     error
+}
+
+func loadIssuesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		issues, err := discover.ListIssues(path)
+		return issuesMsg{project: name, issues: issues, err: err}
+	}
+}
+
+// openURLCmd opens a URL in the default browser
+func openURLCmd(url, label string) tea.Cmd {
+	return func() tea.Msg {
+		opener := "xdg-open"
+		if runtime.GOOS == "darwin" {
+			opener = "open"
+		}
+		if err := exec.Command(opener, url).Run(); err != nil {
+			return actionResultMsg{action: "browse", message: opener + ": " + err.Error()}
+		}
+		return actionResultMsg{action: "browse", success: true, message: "Opened " + label}
+	}
+}
+
+// openIssues lists a project's open issues
+func (m Model) openIssues(p Project) (tea.Model, tea.Cmd) {
+	m.issues = issueList{project: p.Name, path: p.Path, loading: true}
+	m.viewMode = IssuesView
+	return m, loadIssuesCmd(p.Name, p.Path)
+}
+
+// setIssues stores a loaded issue list
+func (m *Model) setIssues(msg issuesMsg) {
+	l := &m.issues
+	if l.project != msg.project {
+		return
+	}
+	l.loading = false
+	if msg.err != nil {
+		l.err = msg.err.Error()
+		return
+	}
+	l.err = ""
+	l.issues = msg.issues
+	l.idx = maxInt(min(l.idx, len(l.issues)-1), 0)
+}
+
+func (m Model) handleIssuesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	l := &m.issues
+	page := maxInt(m.getListHeight()-4, 1)
+
+	switch msg.String() {
+	case "j", "down":
+		l.idx = min(l.idx+1, maxInt(len(l.issues)-1, 0))
+	case "k", "up":
+		l.idx = maxInt(l.idx-1, 0)
+	case "ctrl+d", "pgdown":
+		l.idx = min(l.idx+page, maxInt(len(l.issues)-1, 0))
+	case "ctrl+u", "pgup":
+		l.idx = maxInt(l.idx-page, 0)
+	case "g":
+		l.idx = 0
+	case "G":
+		l.idx = maxInt(len(l.issues)-1, 0)
+	case "enter", "w":
+		if l.idx < len(l.issues) {
+			is := l.issues[l.idx]
+			return m, openURLCmd(is.URL, fmt.Sprintf("#%d", is.Number))
+		}
+	case "y":
+		if l.idx < len(l.issues) {
+			is := l.issues[l.idx]
+			return m, copyToClipboardCmd(is.URL, fmt.Sprintf("#%d URL", is.Number))
+		}
+	case "ctrl+r":
+		l.loading = true
+		return m, loadIssuesCmd(l.project, l.path)
+	}
+	return m, nil
+}
+
+// renderIssues lists open issues with their labels and age
+func (m Model) renderIssues(height int) string {
+	l := m.issues
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s Issues — %s  (enter/w open in browser, y copy URL, ctrl+r reload, esc back)\n\n", IconIssue, l.project))
+	if l.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, l.err))
+	}
+	if len(l.issues) == 0 {
+		if l.loading {
+			b.WriteString(i18n.T("  Loading issues...\n"))
+		} else if l.err == "" {
+			b.WriteString(i18n.T("  No open issues\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	numWidth := 0
+	for _, is := range l.issues {
+		numWidth = max(numWidth, len(fmt.Sprint(is.Number)))
+	}
+
+	// Keep the selection on screen
+	rows := maxInt(height-4, 1)
+	start := maxInt(l.idx-rows+1, 0)
+
+	for i := start; i < len(l.issues) && i < start+rows; i++ {
+		is := l.issues[i]
+		age := strings.TrimSpace(formatTimeSince(is.CreatedAt))
+		labels := ""
+		if names := is.LabelNames(); len(names) > 0 {
+			labels = "  [" + strings.Join(names, ", ") + "]"
+		}
+		titleWidth := maxInt(m.width-numWidth-len(labels)-16, 20)
+		line := fmt.Sprintf("  #%-*d  %5s  %s%s", numWidth, is.Number, age, truncate(is.Title, titleWidth), labels)
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == l.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if l.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
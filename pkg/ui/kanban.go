@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/tasks"
+)
+
+// kanbanBoard holds state for the PLAN.md / TODO.md kanban view
+type kanbanBoard struct {
+	columns [3][]tasks.Task // indexed by tasks.Status
+	col     int
+	row     [3]int
+	loading bool
+	err     string
+
+	// follow re-selects a task after it moves and the board reloads
+	follow string
+}
+
+type kanbanMsg struct {
+	tasks []tasks.Task
+	err   error
+}
+
+type kanbanMovedMsg struct {
+	project string
+	text    string
+	follow  string
+	to      tasks.Status
+	err     error
+}
+
+func loadKanbanCmd(projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		var all []tasks.Task
+		var firstErr error
+		for _, p := range projects {
+			t, err := tasks.Load(p.Name, expandPath(p.Path))
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", p.Name, err)
+			}
+			all = append(all, t...)
+		}
+		return kanbanMsg{tasks: all, err: firstErr}
+	}
+}
+
+func moveTaskCmd(t tasks.Task, to tasks.Status) tea.Cmd {
+	return func() tea.Msg {
+		return kanbanMovedMsg{project: t.Project, text: t.Text, follow: taskKey(t), to: to, err: tasks.Move(t, to)}
+	}
+}
+
+// openTaskCmd opens a task's source file in nvim at the task's line
+func openTaskCmd(t tasks.Task) tea.Cmd {
+	return tea.ExecProcess(exec.Command("nvim", "+"+strconv.Itoa(t.Line+1), t.File), nil)
+}
+
+func taskKey(t tasks.Task) string {
+	return t.Project + "\x00" + t.Text
+}
+
+// setTasks sorts tasks into columns, keeping the selection in range
+func (k *kanbanBoard) setTasks(all []tasks.Task) {
+	k.columns = [3][]tasks.Task{}
+	for _, t := range all {
+		k.columns[t.Status] = append(k.columns[t.Status], t)
+	}
+
+	if k.follow != "" {
+		for i, t := range k.columns[k.col] {
+			if taskKey(t) == k.follow {
+				k.row[k.col] = i
+				break
+			}
+		}
+		k.follow = ""
+	}
+
+	for c := range k.columns {
+		if k.row[c] >= len(k.columns[c]) {
+			k.row[c] = maxInt(len(k.columns[c])-1, 0)
+		}
+	}
+}
+
+// selected returns the highlighted task, if the column has any
+func (k *kanbanBoard) selected() (tasks.Task, bool) {
+	col := k.columns[k.col]
+	if len(col) == 0 {
+		return tasks.Task{}, false
+	}
+	return col[k.row[k.col]], true
+}
+
+func (m Model) handleKanbanKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := &m.kanban
+
+	switch msg.String() {
+	case "h", "left":
+		k.col = maxInt(k.col-1, 0)
+	case "l", "right":
+		k.col = min(k.col+1, len(tasks.Statuses)-1)
+	case "j", "down":
+		if k.row[k.col] < len(k.columns[k.col])-1 {
+			k.row[k.col]++
+		}
+	case "k", "up":
+		if k.row[k.col] > 0 {
+			k.row[k.col]--
+		}
+	case "g":
+		k.row[k.col] = 0
+	case "G":
+		k.row[k.col] = maxInt(len(k.columns[k.col])-1, 0)
+	case "H", "shift+left", "L", "shift+right":
+		t, ok := k.selected()
+		if !ok {
+			return m, nil
+		}
+		to := int(t.Status) + 1
+		if key := msg.String(); key == "H" || key == "shift+left" {
+			to = int(t.Status) - 1
+		}
+		if to < 0 || to >= len(tasks.Statuses) {
+			return m, nil
+		}
+		if m.dryRun {
+			return m.showPlan("Move task", fmt.Sprintf("edit %s:%d: move %q from %s to %s", t.File, t.Line+1, t.Text, t.Status, tasks.Status(to)))
+		}
+		return m, moveTaskCmd(t, tasks.Status(to))
+	case "enter", "o":
+		if t, ok := k.selected(); ok {
+			return m, openTaskCmd(t)
+		}
+	case "ctrl+r":
+		k.loading = true
+		return m, loadKanbanCmd(m.projects)
+	}
+
+	return m, nil
+}
+
+// renderKanban renders todo/doing/done columns side by side
+func (m Model) renderKanban(height int) string {
+	k := m.kanban
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("\n  %s Tasks — PLAN.md / TODO.md  (h/l column, j/k task, H/L move, enter open)\n", IconTodo))
+	if k.loading {
+		b.WriteString("\n  Loading tasks...\n")
+		return padLines(b.String(), height)
+	}
+	if k.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, k.err))
+	} else {
+		b.WriteString("\n")
+	}
+
+	colWidth := maxInt((m.width-4)/len(tasks.Statuses), 12)
+	rows := height - 5
+
+	// Column headers
+	b.WriteString("  ")
+	for _, s := range tasks.Statuses {
+		title := fmt.Sprintf("%s (%d)", s, len(k.columns[s]))
+		b.WriteString(fmt.Sprintf("%-*s", colWidth, title))
+	}
+	b.WriteString("\n")
+
+	// Each column scrolls independently to keep its selection visible
+	var offsets [3]int
+	for c := range k.columns {
+		if k.row[c] >= rows {
+			offsets[c] = k.row[c] - rows + 1
+		}
+	}
+
+	for r := 0; r < rows; r++ {
+		b.WriteString("  ")
+		for c := range k.columns {
+			i := offsets[c] + r
+			cell := ""
+			if i < len(k.columns[c]) {
+				t := k.columns[c][i]
+				cell = truncate(t.Project+": "+t.Text, colWidth-2)
+			}
+			cell = fmt.Sprintf("%-*s", colWidth-1, cell)
+			if c == k.col && i == k.row[c] && i < len(k.columns[c]) {
+				cell = fmt.Sprintf("\033[30;48;5;6m%s\033[0m", cell)
+			}
+			b.WriteString(cell + " ")
+		}
+		b.WriteString("\n")
+	}
+
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+// logPageSize is how many commits one page of the log tab shows
+const logPageSize = 20
+
+// detailTab is the section shown in the detail view
+type detailTab int
+
+const (
+	detailOverview detailTab = iota
+	detailLog
+	detailFiles
+)
+
+// commitLog is the detail view's history tab for the current project
+type commitLog struct {
+	project string
+	commits []discover.Commit
+	idx     int
+	more    bool // history continues past what's loaded
+	loading bool
+	want    int // selection to restore once a fetch lands
+	err     string
+}
+
+type commitLogMsg struct {
+	project string
+	commits []discover.Commit
+	n       int
+	err     error
+}
+
+// loadCommitLogCmd loads the newest n commits. Paging reloads from HEAD
+// with a larger n, which is cheap with the native walker.
+func loadCommitLogCmd(name, path string, n int) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := discover.RecentCommits(path, n)
+		return commitLogMsg{project: name, commits: commits, n: n, err: err}
+	}
+}
+
+// copyToClipboardCmd copies text with whichever clipboard tool is installed
+func copyToClipboardCmd(text, label string) tea.Cmd {
+	return func() tea.Msg {
+		tools := [][]string{
+			{"pbcopy"},
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+		for _, t := range tools {
+			if _, err := exec.LookPath(t[0]); err != nil {
+				continue
+			}
+			cmd := exec.Command(t[0], t[1:]...)
+			cmd.Stdin = strings.NewReader(text)
+			if err := cmd.Run(); err != nil {
+				return actionResultMsg{action: "copy", message: "Copy failed: " + err.Error()}
+			}
+			return actionResultMsg{action: "copy", success: true, message: "Copied " + label}
+		}
+		return actionResultMsg{action: "copy", message: "No clipboard tool found (pbcopy, wl-copy, xclip, xsel)"}
+	}
+}
+
+// browseCommitCmd opens a commit on the project's forge
+func browseCommitCmd(projectName, projectPath string, c discover.Commit) tea.Cmd {
+	url := discover.CommitURL(projectPath, c.Hash)
+	if url == "" {
+		return func() tea.Msg {
+			return actionResultMsg{action: "browse", project: projectName, message: "No forge remote to open " + c.ShortHash() + " on"}
+		}
+	}
+	return openURLCmd(url, c.ShortHash())
+}
+
+// openCommitLog switches the detail view to the log tab, loading the
+// first page if this project's history isn't loaded yet
+func (m Model) openCommitLog() (tea.Model, tea.Cmd) {
+	m.detailTab = detailLog
+	p := m.currentProject
+	if m.commitLog.project == p.Name && m.commitLog.commits != nil {
+		return m, nil
+	}
+	m.commitLog = commitLog{project: p.Name, loading: true}
+	return m, loadCommitLogCmd(p.Name, p.Path, logPageSize)
+}
+
+// moveLogSelection moves the selection by delta, fetching another page
+// when it runs past the loaded commits
+func (m Model) moveLogSelection(delta int) (tea.Model, tea.Cmd) {
+	l := &m.commitLog
+	target := maxInt(l.idx+delta, 0)
+	l.idx = maxInt(min(target, len(l.commits)-1), 0)
+	if target >= len(l.commits) && l.more && !l.loading {
+		l.loading = true
+		l.want = target
+		p := m.currentProject
+		return m, loadCommitLogCmd(p.Name, p.Path, len(l.commits)+logPageSize)
+	}
+	return m, nil
+}
+
+func (m Model) handleLogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.currentProject
+	l := m.commitLog
+
+	switch msg.String() {
+	case "j", "down":
+		return m.moveLogSelection(1)
+	case "k", "up":
+		return m.moveLogSelection(-1)
+	case "n", "ctrl+d", "pgdown":
+		return m.moveLogSelection(logPageSize)
+	case "p", "ctrl+u", "pgup":
+		return m.moveLogSelection(-logPageSize)
+	case "g":
+		m.commitLog.idx = 0
+		return m, nil
+	case "y":
+		if l.idx < len(l.commits) {
+			return m, copyToClipboardCmd(l.commits[l.idx].Hash, l.commits[l.idx].ShortHash())
+		}
+		return m, nil
+	case "w":
+		if l.idx < len(l.commits) {
+			return m, browseCommitCmd(p.Name, expandPath(p.Path), l.commits[l.idx])
+		}
+		return m, nil
+	case "i":
+		return m.openRebasePlan()
+	case "C":
+		return m.openCherryPick()
+	case "ctrl+r":
+		m.commitLog = commitLog{project: p.Name, loading: true}
+		return m, loadCommitLogCmd(p.Name, p.Path, maxInt(len(l.commits), logPageSize))
+	}
+	return m.handleListKey(msg)
+}
+
+// setCommitLog stores a loaded page, restoring a selection that ran ahead
+func (m *Model) setCommitLog(msg commitLogMsg) {
+	l := &m.commitLog
+	if l.project != msg.project {
+		return
+	}
+	l.loading = false
+	if msg.err != nil {
+		l.err = msg.err.Error()
+		return
+	}
+	l.err = ""
+	l.commits = msg.commits
+	l.more = len(msg.commits) == msg.n
+	if l.want > 0 {
+		l.idx = l.want
+		l.want = 0
+	}
+	l.idx = maxInt(min(l.idx, len(l.commits)-1), 0)
+}
+
+// renderDetailTabs renders the tab strip at the top of the detail view
+func (m Model) renderDetailTabs() string {
+	tabs := []string{"Overview", "Log", "Files"}
+	var parts []string
+	for i, t := range tabs {
+		if detailTab(i) == m.detailTab {
+			parts = append(parts, "\033[30;48;5;6m "+t+" \033[0m")
+		} else {
+			parts = append(parts, " "+t+" ")
+		}
+	}
+	return "\n  " + strings.Join(parts, " ") + "  (tab to switch)\n"
+}
+
+// renderCommitLog shows one page of history for the detail view's log tab
+func (m Model) renderCommitLog(height int) string {
+	p := m.currentProject
+	l := m.commitLog
+	var b strings.Builder
+
+	b.WriteString(m.renderDetailTabs())
+	page := l.idx/logPageSize + 1
+	b.WriteString(fmt.Sprintf("\n  %s %s — page %d  (n/p page, y copy hash, w open on GitHub, i rebase from here, C cherry-pick)\n\n", IconBranch, p.Name, page))
+
+	if l.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, l.err))
+	}
+	if len(l.commits) == 0 {
+		if l.loading {
+			b.WriteString("  Loading history...\n")
+		} else if l.err == "" {
+			b.WriteString("  No commits yet\n")
+		}
+		return padLines(b.String(), height)
+	}
+
+	start := (page - 1) * logPageSize
+	rows := min(logPageSize, height-6)
+	start = maxInt(start, l.idx-rows+1)
+	for i := start; i < len(l.commits) && i < start+rows; i++ {
+		c := l.commits[i]
+		line := fmt.Sprintf("  %s  %-18s %7s  %s", c.ShortHash(), truncate(c.Author, 18),
+			strings.TrimSpace(formatTimeSince(c.When))+" ago", truncate(c.Subject, maxInt(m.width-46, 20)))
+		if i == l.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if l.loading {
+		b.WriteString("  Loading more...\n")
+	}
+
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// deviceCodeMsg starts the wait for the user to approve a login
+type deviceCodeMsg struct {
+	clientID string
+	code     *github.DeviceCode
+	err      error
+}
+
+// loginCmd asks GitHub for a device code for the configured OAuth app
+func loginCmd() tea.Msg {
+	cfg, _ := config.Load()
+	code, err := github.RequestDeviceCode(cfg.GitHubClientID)
+	return deviceCodeMsg{clientID: cfg.GitHubClientID, code: code, err: err}
+}
+
+// pollLoginCmd waits for the code to be approved, then stores the token
+// and switches the native client over to it
+func pollLoginCmd(clientID string, code *github.DeviceCode) tea.Cmd {
+	return func() tea.Msg {
+		token, err := github.PollToken(clientID, code)
+		if err != nil {
+			return actionResultMsg{action: "login", message: i18n.T("GitHub login failed: %v", err)}
+		}
+		github.UseToken(token)
+		where, err := github.SaveToken(token)
+		if err != nil {
+			return actionResultMsg{action: "login", success: true, message: i18n.T("Logged in to GitHub for this session only; couldn't save the token: %v", err)}
+		}
+		return actionResultMsg{action: "login", success: true, message: i18n.T("Logged in to GitHub; token saved to %s", where)}
+	}
+}
+
+// handleDebugKey handles keys in the debug view: l starts a GitHub login
+func (m Model) handleDebugKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "l" && m.login == nil {
+		m.statusMsg = i18n.T("Asking GitHub for a login code...")
+		m.statusMsgTime = time.Now()
+		return m, loginCmd
+	}
+	return m, nil
+}
+
+// startLogin shows the code, opens the verification page with the code on
+// the clipboard, and starts polling
+func (m Model) startLogin(msg deviceCodeMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = i18n.T("GitHub login failed: %v", msg.err)
+		return m, nil
+	}
+	m.login = msg.code
+	return m, tea.Batch(
+		copyToClipboardCmd(msg.code.UserCode, "login code"),
+		openURLCmd(msg.code.VerificationURI, "GitHub login"),
+		pollLoginCmd(msg.clientID, msg.code),
+	)
+}
+
+// renderLogin is the debug view's login prompt
+func (m Model) renderLogin() string {
+	if m.login == nil {
+		if github.Default() == nil {
+			return i18n.T("\n  l log in to GitHub (device flow)\n")
+		}
+		return ""
+	}
+	return i18n.T("\n  %s Enter %s at %s to log in (waiting...)\n", IconGitHub, m.login.UserCode, m.login.VerificationURI)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/incident"
+	"github.com/michaelmonetized/mission-control/pkg/maintenance"
+)
+
+// inMaintenance returns the maintenance window a project is in now, or nil
+func (m Model) inMaintenance(project string) *maintenance.Window {
+	return maintenance.Covering(m.maintenance, project, time.Now())
+}
+
+// renderMaintenance notes a window in effect for the detail view
+func (m Model) renderMaintenance(name string) string {
+	w := m.inMaintenance(name)
+	if w == nil {
+		return ""
+	}
+	return i18n.T("  %s Maintenance until %s: alerts held (%s)\n", IconMaintain, w.End.Format("Jan 2 15:04"), w.Label())
+}
+
+// incidentTimeline is the open incident's timeline with the maintenance
+// windows that overlapped it marked where they started and ended
+func (m Model) incidentTimeline() []incident.Event {
+	inc := m.incident.inc
+	events := append([]incident.Event(nil), inc.Timeline...)
+	if inc.ID == "" {
+		return events
+	}
+	end := inc.Resolved
+	if inc.Open() {
+		end = time.Now()
+	}
+	for _, w := range m.maintenance {
+		if !w.Overlaps(inc.Project, inc.Started, end) {
+			continue
+		}
+		events = append(events, incident.Event{Time: w.Start, Text: "Maintenance started: " + w.Label()})
+		if !w.End.After(end) {
+			events = append(events, incident.Event{Time: w.End, Text: "Maintenance ended"})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
@@ -0,0 +1,296 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// metadataView audits how the projects' GitHub repositories present
+// themselves, editing what's missing in place
+type metadataView struct {
+	repos   []discover.RepoMetadata
+	idx     int
+	all     bool // every repository, not just public ones with gaps
+	loading bool
+	err     string
+	field   string // the field being edited ("description", "topics", or "homepage"), "" when none
+}
+
+type metadataMsg struct {
+	repos []discover.RepoMetadata
+	err   error
+}
+
+func loadMetadataCmd(projects []Project) tea.Cmd {
+	paths := make([]string, len(projects))
+	for i, p := range projects {
+		paths[i] = p.Path
+	}
+	return func() tea.Msg {
+		repos, err := discover.AuditMetadata(paths)
+		return metadataMsg{repos: repos, err: err}
+	}
+}
+
+// editMetadataCmd sets one field of a repository
+func editMetadataCmd(project string, md discover.RepoMetadata, field, value string) tea.Cmd {
+	return func() tea.Msg {
+		if err := discover.EditMetadata(md, field, value); err != nil {
+			return actionResultMsg{action: "repo_metadata", project: project, message: i18n.T("Setting the %s of %s failed: %v", field, md.Repo, err)}
+		}
+		return actionResultMsg{action: "repo_metadata", project: project, success: true,
+			message: i18n.T("Set the %s of %s", field, md.Repo)}
+	}
+}
+
+// openMetadata audits every project's GitHub repository
+func (m Model) openMetadata() (tea.Model, tea.Cmd) {
+	m.metadata = metadataView{repos: m.metadata.repos, all: m.metadata.all, loading: true}
+	m.viewMode = MetadataView
+	return m, loadMetadataCmd(m.projects)
+}
+
+// setMetadata keeps the audit
+func (m *Model) setMetadata(msg metadataMsg) {
+	v := &m.metadata
+	v.loading = false
+	if msg.err != nil {
+		v.err = msg.err.Error()
+		return
+	}
+	v.err = ""
+	v.repos = msg.repos
+	v.idx = maxInt(min(v.idx, len(v.visible())-1), 0)
+}
+
+// visible returns the repositories shown: public ones missing something,
+// the most missing first, or all of them
+func (v metadataView) visible() []discover.RepoMetadata {
+	if v.all {
+		return v.repos
+	}
+	var gaps []discover.RepoMetadata
+	for _, md := range v.repos {
+		if md.Public() && len(md.Missing()) > 0 {
+			gaps = append(gaps, md)
+		}
+	}
+	sort.SliceStable(gaps, func(i, j int) bool { return len(gaps[i].Missing()) > len(gaps[j].Missing()) })
+	return gaps
+}
+
+// projectAt returns the project at a path, nil for none
+func (m Model) projectAt(path string) *Project {
+	for i := range m.projects {
+		if m.projects[i].Path == path {
+			return &m.projects[i]
+		}
+	}
+	return nil
+}
+
+func (m Model) handleMetadataKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.metadata
+	repos := v.visible()
+	last := maxInt(len(repos)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "a":
+		v.all = !v.all
+		v.idx = 0
+	case "enter", "o":
+		if v.idx < len(repos) && repos[v.idx].URL != "" {
+			return m, openURLCmd(repos[v.idx].URL, repos[v.idx].Repo)
+		}
+	case "s":
+		// There's no API for the social preview image, only this page
+		if v.idx < len(repos) {
+			owner, name, _ := strings.Cut(repos[v.idx].Repo, "/")
+			return m, openURLCmd(github.SettingsURL(github.Repo{Owner: owner, Name: name}), repos[v.idx].Repo+" settings")
+		}
+	case "d", "t", "h":
+		if v.idx >= len(repos) {
+			return m, nil
+		}
+		md := repos[v.idx]
+		switch msg.String() {
+		case "d":
+			v.field = "description"
+			m.metadataInput.SetValue(md.Description)
+		case "t":
+			v.field = "topics"
+			m.metadataInput.SetValue(strings.Join(md.Topics, ", "))
+		case "h":
+			v.field = "homepage"
+			m.metadataInput.SetValue(md.Homepage)
+			if p := m.projectAt(md.Path); md.Homepage == "" && p != nil {
+				// Suggest the site it deploys to
+				m.metadataInput.SetValue(m.productionURL(p))
+			}
+		}
+		m.metadataInput.CursorEnd()
+		m.metadataInput.Focus()
+		return m, textinput.Blink
+	case "ctrl+r":
+		v.loading = true
+		return m, loadMetadataCmd(m.projects)
+	}
+	return m, nil
+}
+
+// handleMetadataInputKey edits a field: enter saves it to GitHub, esc
+// cancels
+func (m Model) handleMetadataInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.metadata
+	switch msg.String() {
+	case "esc":
+		v.field = ""
+		m.metadataInput.Blur()
+		return m, nil
+	case "enter":
+		field, value := v.field, strings.TrimSpace(m.metadataInput.Value())
+		v.field = ""
+		m.metadataInput.Blur()
+		repos := v.visible()
+		if v.idx >= len(repos) {
+			return m, nil
+		}
+		md := repos[v.idx]
+		if field == "topics" {
+			value = strings.Join(discover.ParseTopics(value), ", ")
+		}
+		if m.dryRun {
+			call := "PATCH repos/" + md.Repo + " " + field + "=" + fmt.Sprintf("%q", value)
+			if field == "topics" {
+				call = "PUT repos/" + md.Repo + "/topics names=[" + value + "]"
+			}
+			return m.showPlan("Set the "+field+" of "+md.Repo, call)
+		}
+		project := md.Repo
+		if p := m.projectAt(md.Path); p != nil {
+			project = p.Name
+		}
+		m.statusMsg = i18n.T("Setting the %s of %s...", field, md.Repo)
+		m.statusMsgTime = time.Now()
+		return m, editMetadataCmd(project, md, field, value)
+	}
+	var cmd tea.Cmd
+	m.metadataInput, cmd = m.metadataInput.Update(msg)
+	return m, cmd
+}
+
+// metadataMark marks a field as filled in or missing
+func metadataMark(ok bool) string {
+	if ok {
+		return IconCheck
+	}
+	return IconX
+}
+
+// metadataVisibility describes who sees a repository and whether it's
+// the owner's own
+func metadataVisibility(md discover.RepoMetadata) string {
+	switch {
+	case md.Archived:
+		return i18n.T("archived")
+	case md.Fork:
+		return i18n.T("fork")
+	case md.Private:
+		return i18n.T("private")
+	}
+	return i18n.T("public")
+}
+
+// renderMetadata lists the repositories with what each fills in, and the
+// selected one's fields below
+func (m Model) renderMetadata(height int) string {
+	v := m.metadata
+	repos := v.visible()
+	var b strings.Builder
+
+	gaps := 0
+	for _, md := range v.repos {
+		if md.Public() && len(md.Missing()) > 0 {
+			gaps++
+		}
+	}
+	b.WriteString(i18n.T("\n  %s GitHub metadata — %d repositories, %d public with gaps  (d description, t topics, h homepage, s social preview, enter open, a all/gaps, ctrl+r reload, esc back)\n\n",
+		IconGitHub, len(v.repos), gaps))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	if len(repos) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(i18n.T("  Reading repository metadata...\n"))
+		case v.err != "":
+		case len(v.repos) > 0:
+			b.WriteString(i18n.T("  %s Every public repository has a description, topics, homepage, and social preview (a shows all)\n", IconCheck))
+		default:
+			b.WriteString(i18n.T("  No projects with a github.com remote\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("    %-36s %-9s %-11s %-6s %-8s %s\n", "Repository", "", "Description", "Topics", "Homepage", "Preview"))
+	rows := maxInt(height-12, 1)
+	start := maxInt(v.idx-rows+1, 0)
+	for i := start; i < len(repos) && i < start+rows; i++ {
+		md := repos[i]
+		line := fmt.Sprintf("    %-36s %-9s %-11s %-6s %-8s %s", truncate(md.Repo, 36), metadataVisibility(md),
+			metadataMark(md.Description != ""), metadataMark(len(md.Topics) > 0), metadataMark(md.Homepage != ""), metadataMark(md.SocialPreview))
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+
+	if v.idx < len(repos) {
+		md := repos[v.idx]
+		preview := i18n.T("none (s opens settings to upload one)")
+		if md.SocialPreview {
+			preview = i18n.T("custom image")
+		}
+		fields := []struct{ name, label, value string }{
+			{"description", i18n.T("Description"), md.Description},
+			{"topics", i18n.T("Topics"), strings.Join(md.Topics, ", ")},
+			{"homepage", i18n.T("Homepage"), md.Homepage},
+			{"", i18n.T("Social preview"), preview},
+		}
+		b.WriteString("\n")
+		for _, f := range fields {
+			value := f.value
+			if value == "" {
+				value = "-"
+			}
+			if f.name != "" && f.name == v.field {
+				b.WriteString(fmt.Sprintf("  %-15s %s\n", f.label+":", m.metadataInput.View()))
+				continue
+			}
+			b.WriteString(truncate(fmt.Sprintf("  %-15s %s", f.label+":", value), maxInt(m.width-4, 20)) + "\n")
+		}
+		if v.field != "" {
+			b.WriteString(i18n.T("  enter saves to GitHub, esc cancels\n"))
+		}
+	}
+	return padLines(b.String(), height)
+}
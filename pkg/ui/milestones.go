@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type milestonesMsg struct {
+	project    string
+	milestones []github.Milestone
+	err        error
+}
+
+// milestoneRollup is open milestones across the marked projects, with
+// same-named ones ("launch week") combined
+type milestoneRollup struct {
+	projects []string
+	pending  int // projects still loading
+	idx      int
+}
+
+// milestoneGroup is one milestone title and every project that has it
+type milestoneGroup struct {
+	title   string
+	closed  int
+	total   int
+	due     *time.Time // soonest
+	entries []milestoneEntry
+}
+
+type milestoneEntry struct {
+	project string
+	github.Milestone
+}
+
+func loadMilestonesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		var found []github.Milestone
+		var err error
+		scheduleGitHub(name, func() {
+			found, err = discover.Milestones(path)
+		})
+		return milestonesMsg{project: name, milestones: found, err: err}
+	}
+}
+
+// setMilestones stores a project's milestones and counts it loaded for
+// the roll-up
+func (m *Model) setMilestones(msg milestonesMsg) {
+	if msg.err == nil {
+		m.milestones[msg.project] = msg.milestones
+	}
+	for _, name := range m.rollup.projects {
+		if name == msg.project && m.rollup.pending > 0 {
+			m.rollup.pending--
+		}
+	}
+}
+
+// milestoneDue renders a due date with how far off it is
+func milestoneDue(due *time.Time) string {
+	if due == nil {
+		return i18n.T("no due date")
+	}
+	left := time.Until(*due)
+	if left < 0 {
+		return i18n.T("overdue since %s", due.Format("Jan 2"))
+	}
+	return i18n.T("due %s (%dd)", due.Format("Jan 2"), int(math.Ceil(left.Hours()/24)))
+}
+
+// milestonePercent is how much of a milestone is closed
+func milestonePercent(closed, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return closed * 100 / total
+}
+
+// renderMilestone shows the active milestone's progress for the detail
+// view
+func (m Model) renderMilestone(name string) string {
+	ms := discover.ActiveMilestone(m.milestones[name])
+	if ms == nil {
+		return ""
+	}
+	line := i18n.T("  Milestone: %s %s %d/%d closed (%d%%), %s\n", ms.Title, RenderProgressBar(ms.ClosedIssues, ms.Total(), 20),
+		ms.ClosedIssues, ms.Total(), milestonePercent(ms.ClosedIssues, ms.Total()), milestoneDue(ms.DueOn))
+	if others := len(m.milestones[name]) - 1; others > 0 {
+		line = strings.TrimSuffix(line, "\n") + i18n.T(" · %d more open\n", others)
+	}
+	return line
+}
+
+// openMilestones rolls up milestones across the marked projects
+func (m Model) openMilestones() (tea.Model, tea.Cmd) {
+	marked := m.markedProjects()
+	m.rollup = milestoneRollup{pending: len(marked)}
+	var cmds []tea.Cmd
+	for _, p := range marked {
+		m.rollup.projects = append(m.rollup.projects, p.Name)
+		cmds = append(cmds, loadMilestonesCmd(p.Name, p.Path))
+	}
+	m.viewMode = MilestonesView
+	return m, tea.Batch(cmds...)
+}
+
+// milestoneGroups combines the roll-up's milestones by title, the ones
+// shared by the most projects first, then the soonest due
+func (m Model) milestoneGroups() []milestoneGroup {
+	byTitle := make(map[string]*milestoneGroup)
+	var groups []*milestoneGroup
+	for _, name := range m.rollup.projects {
+		for _, ms := range m.milestones[name] {
+			key := strings.ToLower(strings.TrimSpace(ms.Title))
+			g := byTitle[key]
+			if g == nil {
+				g = &milestoneGroup{title: ms.Title}
+				byTitle[key] = g
+				groups = append(groups, g)
+			}
+			g.closed += ms.ClosedIssues
+			g.total += ms.Total()
+			if ms.DueOn != nil && (g.due == nil || ms.DueOn.Before(*g.due)) {
+				g.due = ms.DueOn
+			}
+			g.entries = append(g.entries, milestoneEntry{project: name, Milestone: ms})
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if len(a.entries) != len(b.entries) {
+			return len(a.entries) > len(b.entries)
+		}
+		if (a.due == nil) != (b.due == nil) {
+			return a.due != nil
+		}
+		return a.due != nil && a.due.Before(*b.due)
+	})
+	out := make([]milestoneGroup, len(groups))
+	for i, g := range groups {
+		out[i] = *g
+	}
+	return out
+}
+
+// rollupEntries flattens the groups into the rows that can be selected
+func rollupEntries(groups []milestoneGroup) []milestoneEntry {
+	var entries []milestoneEntry
+	for _, g := range groups {
+		entries = append(entries, g.entries...)
+	}
+	return entries
+}
+
+func (m Model) handleMilestonesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := rollupEntries(m.milestoneGroups())
+	last := maxInt(len(entries)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		m.rollup.idx = min(m.rollup.idx+1, last)
+	case "k", "up":
+		m.rollup.idx = maxInt(m.rollup.idx-1, 0)
+	case "g":
+		m.rollup.idx = 0
+	case "G":
+		m.rollup.idx = last
+	case "enter":
+		if m.rollup.idx < len(entries) {
+			e := entries[m.rollup.idx]
+			return m, openURLCmd(e.URL, fmt.Sprintf("%s %s", e.project, e.Title))
+		}
+	case "ctrl+r":
+		m.rollup.pending = len(m.rollup.projects)
+		var cmds []tea.Cmd
+		for _, name := range m.rollup.projects {
+			if p := m.getProjectByName(name); p != nil {
+				cmds = append(cmds, loadMilestonesCmd(p.Name, p.Path))
+			}
+		}
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+// renderMilestones shows each milestone's combined progress across the
+// marked projects, then each project's share of it
+func (m Model) renderMilestones(height int) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("\n  %s Milestones across %d projects  (enter open, ctrl+r reload, esc back)\n\n", IconMilestone, len(m.rollup.projects)))
+
+	groups := m.milestoneGroups()
+	if len(groups) == 0 {
+		if m.rollup.pending > 0 {
+			b.WriteString(i18n.T("  Loading milestones...\n"))
+		} else {
+			b.WriteString(i18n.T("  No open milestones on the marked projects.\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	var lines []string
+	selectedLine, n := 0, 0
+	for _, g := range groups {
+		shared := i18n.T("1 project")
+		if len(g.entries) > 1 {
+			shared = i18n.T("%d projects", len(g.entries))
+		}
+		lines = append(lines, fmt.Sprintf("  %-24s %s %3d/%-3d %3d%%  %s · %s", truncate(g.title, 24),
+			RenderProgressBar(g.closed, g.total, 20), g.closed, g.total, milestonePercent(g.closed, g.total),
+			milestoneDue(g.due), shared))
+		for _, e := range g.entries {
+			line := fmt.Sprintf("      %-20s %s %3d/%-3d %3d%%  %s", truncate(e.project, 20),
+				RenderProgressBar(e.ClosedIssues, e.Total(), 20), e.ClosedIssues, e.Total(),
+				milestonePercent(e.ClosedIssues, e.Total()), milestoneDue(e.DueOn))
+			if n == m.rollup.idx {
+				selectedLine = len(lines)
+				line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+			}
+			lines = append(lines, line)
+			n++
+		}
+	}
+
+	rows := maxInt(height-5, 1)
+	start := maxInt(selectedLine-rows+2, 0)
+	for i := start; i < len(lines) && i < start+rows; i++ {
+		b.WriteString(lines[i] + "\n")
+	}
+	if m.rollup.pending > 0 {
+		b.WriteString(i18n.T("  Loading %d more...\n", m.rollup.pending))
+	}
+	return padLines(b.String(), height)
+}
@@ -5,15 +5,35 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelmonetized/mission-control/pkg/audit"
+	"github.com/michaelmonetized/mission-control/pkg/chores"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/core"
 	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/estimate"
+	"github.com/michaelmonetized/mission-control/pkg/fixture"
+	"github.com/michaelmonetized/mission-control/pkg/flags"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/incident"
+	"github.com/michaelmonetized/mission-control/pkg/inbox"
+	"github.com/michaelmonetized/mission-control/pkg/maintenance"
 	"github.com/michaelmonetized/mission-control/pkg/openclaw"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+	"github.com/michaelmonetized/mission-control/pkg/shellenv"
+	"github.com/michaelmonetized/mission-control/pkg/snippets"
+	"github.com/michaelmonetized/mission-control/pkg/snooze"
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+	"github.com/michaelmonetized/mission-control/pkg/uptime"
+	"github.com/michaelmonetized/mission-control/pkg/workers"
 )
 
 // =============================================================================
@@ -57,17 +77,65 @@ type Project struct {
 	LastCommit    time.Time // Time since last commit
 
 	// Git status
+	Branch    string // checked-out branch (empty when detached)
 	Staged    int
 	Untracked int
 	Modified  int
+	Ahead     int // commits not yet pushed to upstream
+	Behind    int // upstream commits not yet pulled
+	Stashes   int
+
+	// DirtySince is when the oldest uncommitted change was made (zero
+	// when clean)
+	DirtySince time.Time
+
+	// Newest tag on the default branch and commits since (nil if untagged)
+	Release *discover.Release
+
+	// Remotes, with ahead/behind against each
+	Remotes []discover.Remote
+
+	// Submodules, with how many are dirty or out of sync
+	Submodules          []discover.Submodule
+	SubmodulesDirty     int
+	SubmodulesOutOfDate int
+
+	// Commits per day over the last 30 days, oldest first
+	Activity []int
+
+	// Merge/rebase in progress and the files it left conflicted
+	Operation string
+	Conflicts []string
 
 	// GitHub status
 	Issues int
 	PRs    int
+	CI     *discover.BranchCI // latest Actions runs on the default branch
+
+	// Open issues assigned to me and PRs asking for my review
+	Assigned        int
+	ReviewRequested int
+
+	// Stars (and this week's), forks, and watchers, for the OSS columns
+	Stars     int
+	StarsWeek int
+	Forks     int
+	Watchers  int
+
+	// Open Dependabot, code scanning, and secret scanning alerts (nil
+	// until loaded or when they can't be read)
+	Security *github.SecurityAlerts
 
 	// Vercel status
 	VercelState string // ready, building, queued, failed
 
+	// Netlify status: the latest production deploy, named as Vercel's
+	NetlifyState string
+
+	// Render and Railway status, named as Vercel's and counted with it
+	RenderState  string
+	RailwayState string
+
 	// Swift status
 	SwiftClean  int
 	SwiftFailed int
@@ -76,14 +144,31 @@ type Project struct {
 	Running bool
 }
 
+// deployFailed reports whether the project's latest deploy failed on any
+// platform it deploys to
+func (p Project) deployFailed() bool {
+	for _, state := range []string{p.VercelState, p.NetlifyState, p.RenderState, p.RailwayState} {
+		if state == "failed" {
+			return true
+		}
+	}
+	return false
+}
+
 // Stats holds aggregate counts for the status bar
 type Stats struct {
-	// Vercel
+	// Vercel, with Render and Railway
 	VercelReady    int
 	VercelBuilding int
 	VercelQueued   int
 	VercelFailed   int
 
+	// Netlify
+	NetlifyReady    int
+	NetlifyBuilding int
+	NetlifyQueued   int
+	NetlifyFailed   int
+
 	// Swift
 	SwiftClean  int
 	SwiftFailed int
@@ -92,10 +177,17 @@ type Stats struct {
 	TotalStaged    int
 	TotalUntracked int
 	TotalModified  int
+	TotalAhead     int
+	TotalBehind    int
+	ProjectsBehind int
 
 	// GitHub
-	TotalIssues int
-	TotalPRs    int
+	TotalIssues   int
+	TotalPRs      int
+	TotalSecurity int // open security alerts
+	CIPassing   int
+	CIFailing   int
+	CIRunning   int
 
 	TotalProjects int
 }
@@ -108,10 +200,94 @@ const (
 	DetailView
 	SearchMode
 	ChatMode
-	CommitMode // For entering commit message
+	CommitMode // Stage files and enter a commit message
 	HelpMode
+	PortfolioView  // Revenue vs costs and tracked time (P&L)
+	BranchMode     // Branch picker for the selected project
+	KanbanView     // PLAN.md / TODO.md tasks across projects
+	InboxView      // Quick-capture inbox processing
+	ChoresView     // Recurring maintenance across projects
+	AuditView      // Log of changes mc has made
+	CleanupView    // Merged branches to delete
+	RebaseView     // Interactive rebase planner
+	CherryPickView // Commits from another branch to cherry-pick
+	ServicesView   // A project's long-running services and their logs
+	IssuesView     // A project's open GitHub issues
+	PortsView      // Ports claimed by services and in use
+	PullsView      // A project's open pull requests and whether they're ready
+	RunsView       // A project's recent GitHub Actions runs
+	LogsView       // A project's production logs, tailed live
+	IncidentView   // A project's open incident: timeline, alerts, deploys, logs
+	NotifView      // Forge notifications across projects
+	SecretsView    // credentials and when they were last rotated
+	DebugView      // GitHub API quota, request queue, and refresh times
+	PrioritiesView // Projects ranked by what to work on next
+	MilestonesView // Open milestones rolled up across marked projects
+	ActionsView    // Actions secrets and variables against what workflows use
+	BoardView      // GitHub Projects board, column by column
+	DispatchView   // Start a workflow_dispatch workflow and watch its run
+	ArtifactsView  // A project's release assets and workflow artifacts
+	OrgsView       // Project stats summed by GitHub owner or client
+	PackagesView   // A monorepo's packages, their checks, and changes since release
+	ReviewsView    // Unresolved review comments waiting on me, per project or across all
+	DeploysView    // A project's latest deployments across providers
+	SnippetsView   // A project's saved chat answers
+	MetadataView   // GitHub description, topics, homepage, and social preview across projects
+)
+
+// FilterMode narrows the project list beyond the search query
+type FilterMode int
+
+const (
+	FilterNone       FilterMode = iota
+	FilterBehind                // projects behind their upstream
+	FilterToolchain             // projects missing a pinned runtime version
+	FilterUnreleased            // projects with commits since their latest tag
+	FilterMine                  // projects with issues or reviews waiting on me
+	filterModeCount
 )
 
+// String returns the label shown in the search box
+func (f FilterMode) String() string {
+	switch f {
+	case FilterBehind:
+		return "behind origin"
+	case FilterToolchain:
+		return "toolchain problems"
+	case FilterUnreleased:
+		return "unreleased commits"
+	case FilterMine:
+		return "waiting on me"
+	default:
+		return ""
+	}
+}
+
+// SortMode orders the project list
+type SortMode int
+
+const (
+	SortDefault SortMode = iota // discovery order
+	SortDirty                   // longest-uncommitted changes first
+	SortStars                   // most GitHub stars first
+	SortTrending                // most stars this week first
+	sortModeCount
+)
+
+// String returns the label shown in the search box
+func (s SortMode) String() string {
+	switch s {
+	case SortDirty:
+		return "dirty longest"
+	case SortStars:
+		return "stars"
+	case SortTrending:
+		return "stars this week"
+	default:
+		return ""
+	}
+}
+
 // =============================================================================
 // ASYNC MESSAGES
 // =============================================================================
@@ -128,6 +304,25 @@ type ghStatusMsg struct {
 	status *discover.GitHubStatus
 }
 
+// ghBatchMsg carries the GitHub status of every project the batched query
+// covered, keyed by name; the rest are loaded one by one
+type ghBatchMsg struct {
+	statuses map[string]*discover.GitHubStatus
+	rest     []Project
+}
+
+// platformStatusMsg is a Render or Railway deploy state
+type platformStatusMsg struct {
+	name     string
+	platform string // render or railway
+	state    string
+}
+
+type netlifyStatusMsg struct {
+	name  string
+	state string
+}
+
 type vercelStatusMsg struct {
 	name  string
 	state string
@@ -144,6 +339,16 @@ type languageMsg struct {
 	language string
 }
 
+type trafficMsg struct {
+	name  string
+	stats *discover.TrafficStats
+}
+
+type estimatesMsg struct {
+	name    string
+	summary *estimate.Summary
+}
+
 type chatResponseMsg struct {
 	response string
 	err      error
@@ -160,6 +365,7 @@ type actionResultMsg struct {
 type runningStateMsg struct {
 	project string
 	running bool
+	env     string // environment managers applied, see shellenv.Applied
 }
 
 // =============================================================================
@@ -182,6 +388,9 @@ const (
 	ActionChat
 	ActionGitAdd    // Click on untracked count
 	ActionGitCommit // Click on modified count
+	ActionIssues    // Click on issues count
+	ActionPulls     // Click on PR count
+	ActionRuns      // Click on CI state
 )
 
 // ButtonBounds tracks clickable button regions
@@ -204,6 +413,8 @@ type Model struct {
 	currentProject *Project
 
 	searchInput textinput.Model
+	filterMode  FilterMode
+	sortMode    SortMode
 	chatInput   textinput.Model
 	chatCwd     string // ~/Projects or selected project path
 
@@ -214,14 +425,15 @@ type Model struct {
 	motionNum string
 
 	// Loading state
-	loading       bool
-	statusLoading sync.Map
+	loading bool
 
 	// OpenClaw
 	clawClient   *openclaw.Client
 	chatResponse string
 	chatLoading  bool
 	chatError    string
+	chatPrompt   string            // the message chatResponse answers
+	chatContext  *snippets.Snippet // attached to the next message, nil when none
 
 	// Clickable buttons
 	buttonBounds []ButtonBounds
@@ -230,13 +442,272 @@ type Model struct {
 	// Commit mode
 	commitInput   textinput.Model
 	commitProject string // Project path for pending commit
+	commitFiles   []discover.FileChange
+	commitIdx     int
+	commitLoading bool
+	commitErr     string
 
 	// Status message (brief feedback on actions)
 	statusMsg     string
 	statusMsgTime time.Time
 
+	// In-flight pushes/pulls (project name -> progress)
+	syncs map[string]syncState
+
+	// Projects marked for bulk operations, the operation menu, and the
+	// results of the latest run (bulkRunning projects still going)
+	marked      map[string]bool
+	bulkPending bool
+	bulk        *bulkSummary
+	bulkRunning int
+
+	// Background fetching: whether a round is running, and the last
+	// failure per project
+	autoFetching bool
+	fetchErrs    map[string]string
+
+	// This month's uptime per probed project, and the probe error of each
+	// one currently down
+	uptimes map[string]uptime.Report
+	down    map[string]string
+
+	// The Sentry error spike of each project whose errors just jumped
+	errorSpikes map[string]string
+
+	// Pinned runtime versions per project, checked against what's installed
+	toolchains map[string][]discover.ToolRequirement
+
+	// Repository housekeeping per project, loaded on detail view
+	health map[string]*discover.RepoHealth
+
+	// Open milestones by project, soonest due first, and the roll-up
+	// across marked projects
+	milestones map[string][]github.Milestone
+	rollup     milestoneRollup
+
+	// Long-running services (shared across Model copies) and the panel
+	svcs    *services.Manager
+	svcView serviceView
+
+	// Production log tails (shared across Model copies), the pane, and
+	// its filter
+	tails     *services.Manager
+	logTail   logTail
+	logFilter textinput.Model
+
+	// Deploys streaming into the logs pane (shared across Model copies),
+	// kept apart from the tails so leaving the pane doesn't stop them
+	deploys *services.Manager
+
+	// Fly.io app status, loaded with each project's detail view
+	flyStatus map[string]flyStatusMsg
+
+	// Latest deployments across providers, loaded on detail view, and the
+	// list to pick one from
+	deployHistory map[string]deployHistoryMsg
+	deploysView   deploysView
+
+	// README checks by project, loaded on detail view; OpenClaw drafts
+	// fixing stale ones, and the project being drafted for ("" when none)
+	readmeChecks   map[string]*discover.ReadmeCheck
+	readmeDrafts   map[string]string
+	readmeDrafting string
+
+	// Open incidents by project (pinned to the top of the list), the
+	// incident panel, and its note prompt
+	incidents    map[string]incident.Incident
+	incident     incidentView
+	incidentNote textinput.Model
+
+	// Open issues and pull requests of the project being drilled into
+	issues issueList
+	pulls  prList
+	runs   runList
+
+	// Workflows each project can run by hand, and the dispatch form
+	dispatchable map[string][]discover.DispatchWorkflow
+	dispatch     dispatchView
+
+	// Release assets and workflow artifacts of the project last opened
+	artifacts artifactsView
+
+	// Stats by GitHub owner or client
+	orgs orgsView
+
+	// GitHub metadata audit across projects, and its field editor
+	metadata      metadataView
+	metadataInput textinput.Model
+
+	// What each project's environments run, loaded with its detail view
+	envs map[string]*discover.EnvMatrix
+
+	// Feature flags of projects with a flag service configured, and the
+	// errors reading them
+	flags    map[string]*flags.Report
+	flagErrs map[string]string
+
+	// Releases published on each project's forge, loaded with its detail
+	// view
+	releases map[string][]forge.Release
+
+	// Scheduled jobs, loaded with each project's detail view
+	cronJobs map[string][]discover.CronJob
+
+	// Monorepo packages, the last result of each one's checks, and the
+	// tests that flaked in them, loaded with each project's detail view
+	packages       map[string][]discover.Package
+	packageResults map[string]map[string]map[string]discover.CheckResult
+	flakyTests     map[string][]discover.FlakyTest
+	pkgView        packagesView
+
+	// JS monorepos' changesets or version plans, and the version and
+	// publish steps running through them (one per project at a time)
+	releasePlans map[string]*discover.ReleasePlan
+	releaseJobs  *core.Jobs
+
+	// Built artifact sizes and their history, measured with each
+	// project's detail view
+	sizes map[string]*discover.SizeReport
+
+	// Latest benchmark runs against their baselines, and the job manager
+	// running them (%)
+	benches   map[string]*discover.BenchReport
+	benchJobs *core.Jobs
+
+	// Cached SBOMs and their vulnerability scans, loaded with each
+	// project's detail view and regenerated with m
+	sboms map[string]*discover.SBOMInfo
+
+	// The last worker and queue poll of projects declaring them in .mc.toml
+	workerStatus map[string]workers.Status
+
+	// Notifications panel
+	notifs notifView
+
+	// Review-comment digest
+	reviews reviewsView
+
+	// Credential rotation tracker
+	rotation rotationView
+
+	// Actions secrets and variables inventory
+	actions actionsView
+
+	// GitHub Projects board
+	board boardView
+
+	// "Work on these next" ranking
+	prio prioritiesView
+
+	// Ports-in-use panel
+	portsView portsView
+
+	// Newer release tag, shown in the bottom bar ("" when up to date)
+	updateAvailable string
+
+	// Last successful refresh per provider (git, github, vercel)
+	refreshedAt map[string]time.Time
+
 	// Running servers (project name -> true if running)
 	runningServers map[string]bool
+
+	// GitHub traffic history (project name -> stats), loaded on detail view
+	traffic map[string]*discover.TrafficStats
+
+	// Stash list per project, loaded on detail view
+	stashes          map[string][]discover.Stash
+	stashIdx         int
+	stashDropPending bool
+
+	// Re-entry briefings for dormant projects, assembled on detail view
+	briefings map[string]*briefing
+
+	// What changed since each project was last opened
+	digests map[string]*digest
+
+	// Worktrees per project, loaded on detail view
+	worktrees   map[string][]discover.Worktree
+	worktreeIdx int
+
+	// Detail view tab, commit history for the log tab, and changed files
+	// for the files tab
+	detailTab detailTab
+	commitLog commitLog
+	files     fileList
+
+	// Estimate vs actual per project, loaded on detail view
+	estimates map[string]*estimate.Summary
+
+	// Running time tracker, if any
+	activeTimer *timelog.Entry
+
+	// Portfolio P&L view
+	portfolio        []portfolioRow
+	portfolioMonth   time.Time
+	portfolioLoading bool
+	portfolioErr     string
+	portfolioScroll  int // first row shown
+
+	// Branch switcher
+	branchPicker branchPicker
+	cleanup      branchCleanup
+	rebase       rebasePlan
+	cherry       cherryPick
+
+	// Task board
+	kanban kanbanBoard
+
+	// Quick capture (overlays the current view) and inbox processing
+	capturing    bool
+	captureInput textinput.Model
+	inbox        []inbox.Item
+	inboxIdx     int
+	inboxErr     string
+	inboxTargets map[string]string // item ID -> project chosen while processing
+
+	// Saved chat answers per project, loaded on detail view; the browser,
+	// its search, and the name prompt for saving one ("" when closed)
+	projectSnippets map[string][]snippets.Snippet
+	snippetsView    snippetsView
+	snippetFilter   textinput.Model
+	snippetProject  string
+	snippetName     textinput.Model
+
+	// Snoozed projects/alerts and the snooze prompt ("" when closed)
+	snoozed       snooze.Set
+	maintenance   []maintenance.Window // declared, including past and future
+	snoozeProject string
+	snoozeInput   textinput.Model
+
+	// High-risk action waiting on its second factor (nil when none)
+	secondFactor *secondFactor
+	factorInput  textinput.Model
+
+	// GitHub device-flow login awaiting approval (nil when none)
+	login *github.DeviceCode
+
+	// Optional list columns (config columns)
+	columns []string
+
+	// Focus-follow: select the project tmux/the editor reports, as of
+	// the last report acted on
+	following bool
+	focusAt   time.Time
+	focusErr  bool // the daemon was unreachable at the last poll
+
+	// Audit log, newest first
+	auditLog []audit.Entry
+	auditIdx int
+	auditErr string
+
+	// Recurring maintenance, soonest first
+	chores    []chores.Due
+	choresIdx int
+	choresErr string
+
+	// Dry-run mode shows what an action would do instead of doing it
+	dryRun bool
+	plan   *dryRunPlan
 }
 
 // =============================================================================
@@ -252,30 +723,100 @@ func NewModel() Model {
 	chat.Placeholder = "type C to chat in ~/Projects c to chat in selected project"
 	chat.CharLimit = 500
 
+	capture := textinput.New()
+	capture.Placeholder = "Capture a thought..."
+	capture.CharLimit = 300
+
+	snoozeIn := textinput.New()
+	snoozeIn.Placeholder = "[alert] until: 3d, deploy monday, 2026-11-01..."
+	snoozeIn.CharLimit = 60
+
+	factor := textinput.New()
+	factor.CharLimit = 100
+
 	commit := textinput.New()
 	commit.Placeholder = "Enter commit message..."
 	commit.CharLimit = 200
 
+	logFilter := textinput.New()
+	logFilter.Placeholder = "filter lines..."
+	logFilter.CharLimit = 100
+
+	incidentNote := textinput.New()
+	incidentNote.Placeholder = "What happened?"
+	incidentNote.CharLimit = 300
+
+	snippetFilter := textinput.New()
+	snippetFilter.Placeholder = "search snippets..."
+	snippetFilter.CharLimit = 100
+
+	snippetName := textinput.New()
+	snippetName.Placeholder = "deploy runbook, db restore steps..."
+	snippetName.CharLimit = 60
+
+	metadataInput := textinput.New()
+	metadataInput.CharLimit = 350
+
 	clawClient, _ := openclaw.NewClientFromConfig()
+	cfg, _ := config.Load()
 
 	homeDir, _ := os.UserHomeDir()
 
+	viewMode := ListView
+	if cfg.StartView == "priorities" {
+		viewMode = PrioritiesView
+	}
+
 	return Model{
 		projects:       []Project{},
 		filtered:       []Project{},
 		searchInput:    search,
 		chatInput:      chat,
 		commitInput:    commit,
+		captureInput:   capture,
+		snoozeInput:    snoozeIn,
+		factorInput:    factor,
+		logFilter:      logFilter,
+		incidentNote:   incidentNote,
+		snippetFilter:  snippetFilter,
+		snippetName:    snippetName,
+		metadataInput:  metadataInput,
+		inboxTargets:   make(map[string]string),
 		chatCwd:        filepath.Join(homeDir, "Projects"),
-		viewMode:       ListView,
+		following:      cfg.FocusFollow,
+		columns:        cfg.Columns,
+		viewMode:       viewMode,
 		loading:        true,
 		clawClient:     clawClient,
 		runningServers: make(map[string]bool),
+		traffic:        make(map[string]*discover.TrafficStats),
+		estimates:      make(map[string]*estimate.Summary),
+		stashes:        make(map[string][]discover.Stash),
+		briefings:      make(map[string]*briefing),
+		digests:        make(map[string]*digest),
+		worktrees:      make(map[string][]discover.Worktree),
+		syncs:          make(map[string]syncState),
+		marked:         make(map[string]bool),
+		fetchErrs:      make(map[string]string),
+		toolchains:     make(map[string][]discover.ToolRequirement),
+		health:         make(map[string]*discover.RepoHealth),
+		milestones:     make(map[string][]github.Milestone),
+		svcs:           services.NewManager(),
+		tails:          services.NewManager(),
+		deploys:        services.NewManager(),
+		releaseJobs:    core.NewJobs(2),
+		benchJobs:      core.NewJobs(1),
+		refreshedAt:    make(map[string]time.Time),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return loadProjectsCmd
+	cmds := []tea.Cmd{loadProjectsCmd, loadTimerCmd, loadChoresCmd, loadSnoozeCmd, loadIncidentsCmd, probeUptimeCmd, checkErrorSpikesCmd, snoozeTickCmd(), autoFetchTickCmd(), uptimeTickCmd(), workersTickCmd(), focusTickCmd(), checkUpdateCmd}
+	if m.viewMode == PrioritiesView {
+		// Landing on the ranking: show yesterday's until today's is computed
+		cmds = append(cmds, loadPrioritiesCmd)
+	}
+	return tea.Batch(cmds...)
 }
 
 // =============================================================================
@@ -283,7 +824,7 @@ func (m Model) Init() tea.Cmd {
 // =============================================================================
 
 func loadProjectsCmd() tea.Msg {
-	discovered, err := discover.LoadProjects()
+	discovered, err := core.NewScanner().Discover()
 	if err != nil {
 		return projectsLoadedMsg{}
 	}
@@ -318,11 +859,39 @@ func loadGitStatusCmd(name, path string) tea.Cmd {
 
 func loadGHStatusCmd(name, path string) tea.Cmd {
 	return func() tea.Msg {
-		status, _ := discover.GetGitHubStatus(path)
+		var status *discover.GitHubStatus
+		scheduleGitHub(name, func() {
+			status, _ = discover.GetGitHubStatus(path)
+		})
 		return ghStatusMsg{name: name, status: status}
 	}
 }
 
+// loadGHBatchCmd fetches every project's GitHub counts in a few batched
+// queries rather than a round trip per project
+func loadGHBatchCmd(projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		paths := make([]string, len(projects))
+		for i, p := range projects {
+			paths[i] = p.Path
+		}
+		var found map[string]*discover.GitHubStatus
+		scheduleGitHub(ghBatchKey, func() {
+			found = discover.BatchGitHubStatus(paths)
+		})
+
+		msg := ghBatchMsg{statuses: make(map[string]*discover.GitHubStatus)}
+		for _, p := range projects {
+			if status, ok := found[p.Path]; ok {
+				msg.statuses[p.Name] = status
+			} else {
+				msg.rest = append(msg.rest, p)
+			}
+		}
+		return msg
+	}
+}
+
 func loadVercelStatusCmd(name, path string) tea.Cmd {
 	return func() tea.Msg {
 		state, _ := discover.GetVercelStatus(path)
@@ -330,6 +899,33 @@ func loadVercelStatusCmd(name, path string) tea.Cmd {
 	}
 }
 
+// loadNetlifyStatusCmd reads the latest Netlify deploy, "" for projects
+// not on Netlify
+func loadNetlifyStatusCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		state, _ := discover.GetNetlifyStatus(path)
+		return netlifyStatusMsg{name: name, state: state}
+	}
+}
+
+// loadRenderStatusCmd reads the latest Render deploy, "" for projects not
+// on Render
+func loadRenderStatusCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		state, _ := discover.GetRenderStatus(name, path)
+		return platformStatusMsg{name: name, platform: "render", state: state}
+	}
+}
+
+// loadRailwayStatusCmd reads the latest Railway deployment, "" for
+// projects not on Railway
+func loadRailwayStatusCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		state, _ := discover.GetRailwayStatus(name, path)
+		return platformStatusMsg{name: name, platform: "railway", state: state}
+	}
+}
+
 func loadGitTimesCmd(name, path string) tea.Cmd {
 	return func() tea.Msg {
 		first, last := discover.GetGitTimes(path)
@@ -344,6 +940,24 @@ func loadLanguageCmd(name, path string) tea.Cmd {
 	}
 }
 
+func loadTrafficCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		stats, _ := discover.GetTrafficStats(path)
+		return trafficMsg{name: name, stats: stats}
+	}
+}
+
+func loadEstimatesCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil {
+			return estimatesMsg{name: name}
+		}
+		entries, _ := timelog.Load()
+		return estimatesMsg{name: name, summary: estimate.ForProject(cfg, entries, name)}
+	}
+}
+
 func sendChatCmd(client *openclaw.Client, message, cwd string) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
@@ -361,10 +975,20 @@ func sendChatCmd(client *openclaw.Client, message, cwd string) tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		next, cmd := m.handleKey(msg)
+		prioritizeGitHub(next)
+		return next, cmd
 
 	case tea.MouseMsg:
-		return m.handleMouse(msg)
+		next, cmd := m.handleMouse(msg)
+		prioritizeGitHub(next)
+		return next, cmd
+
+	case debugTickMsg:
+		if m.viewMode == DebugView {
+			return m, debugTickCmd()
+		}
+		return m, nil
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -378,46 +1002,114 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.stats.TotalProjects = len(m.projects)
 
 		// Start loading stats incrementally (non-blocking)
-		var cmds []tea.Cmd
+		cmds := []tea.Cmd{loadGHBatchCmd(m.projects), loadBoardCmd(m.projects), pollWorkersCmd(m.projects)}
 		for _, p := range m.projects {
 			cmds = append(cmds, loadGitStatusCmd(p.Name, p.Path))
 			cmds = append(cmds, loadGitTimesCmd(p.Name, p.Path))
+			cmds = append(cmds, loadActivityCmd(p.Name, p.Path))
 			cmds = append(cmds, loadLanguageCmd(p.Name, p.Path))
+			cmds = append(cmds, loadToolchainCmd(p.Name, p.Path))
 			if p.Type == TypeVercel {
 				cmds = append(cmds, loadVercelStatusCmd(p.Name, p.Path))
 			}
-			cmds = append(cmds, loadGHStatusCmd(p.Name, p.Path))
+			cmds = append(cmds, loadNetlifyStatusCmd(p.Name, p.Path))
+			cmds = append(cmds, loadRenderStatusCmd(p.Name, p.Path))
+			cmds = append(cmds, loadRailwayStatusCmd(p.Name, p.Path))
+			cmds = append(cmds, loadCICmd(p.Name, p.Path))
+			cmds = append(cmds, loadSecurityCmd(p.Name, p.Path))
 		}
+		prioritizeGitHub(m)
 		return m, tea.Batch(cmds...)
 
 	case gitStatusMsg:
 		for i := range m.projects {
 			if m.projects[i].Name == msg.name && msg.status != nil {
+				m.projects[i].Branch = msg.status.Branch
 				m.projects[i].Staged = msg.status.Staged
 				m.projects[i].Untracked = msg.status.Untracked
 				m.projects[i].Modified = msg.status.Modified
+				m.projects[i].Ahead = msg.status.Ahead
+				m.projects[i].Behind = msg.status.Behind
+				m.projects[i].Stashes = msg.status.Stashes
+				m.projects[i].DirtySince = msg.status.DirtySince
+				m.projects[i].Release = msg.status.Release
+				m.projects[i].Remotes = msg.status.Remotes
+				m.projects[i].Submodules = msg.status.Submodules
+				m.projects[i].SubmodulesDirty = msg.status.SubmodulesDirty
+				m.projects[i].SubmodulesOutOfDate = msg.status.SubmodulesOutOfDate
+				m.projects[i].Operation = msg.status.Operation
+				m.projects[i].Conflicts = msg.status.Conflicts
+				m.noteRefresh("git")
 				break
 			}
 		}
 		m.updateStats()
 		m.syncFiltered()
+		// Keep an open files tab in step with the counts
+		if m.viewMode == DetailView && m.detailTab == detailFiles && m.files.project == msg.name && !m.files.loading {
+			return m.openFileList()
+		}
 		return m, nil
 
 	case ghStatusMsg:
+		m.setGHStatus(msg.name, msg.status)
+		m.updateStats()
+		return m, nil
+
+	case ghBatchMsg:
+		for name, status := range msg.statuses {
+			m.setGHStatus(name, status)
+		}
+		m.updateStats()
+		var cmds []tea.Cmd
+		for _, p := range msg.rest {
+			cmds = append(cmds, loadGHStatusCmd(p.Name, p.Path))
+		}
+		model, cmd := m.refreshPriorities()
+		return model, tea.Batch(append(cmds, cmd)...)
+
+	case prioritiesMsg:
+		return m.setPriorities(msg)
+
+	case vercelStatusMsg:
 		for i := range m.projects {
-			if m.projects[i].Name == msg.name && msg.status != nil {
-				m.projects[i].Issues = msg.status.Issues
-				m.projects[i].PRs = msg.status.PRs
+			if m.projects[i].Name == msg.name {
+				m.projects[i].VercelState = msg.state
+				if msg.state != "" {
+					m.noteRefresh("vercel")
+				}
 				break
 			}
 		}
 		m.updateStats()
+		m.syncFiltered()
 		return m, nil
 
-	case vercelStatusMsg:
+	case platformStatusMsg:
 		for i := range m.projects {
 			if m.projects[i].Name == msg.name {
-				m.projects[i].VercelState = msg.state
+				if msg.platform == "render" {
+					m.projects[i].RenderState = msg.state
+				} else {
+					m.projects[i].RailwayState = msg.state
+				}
+				if msg.state != "" {
+					m.noteRefresh(msg.platform)
+				}
+				break
+			}
+		}
+		m.updateStats()
+		m.syncFiltered()
+		return m, nil
+
+	case netlifyStatusMsg:
+		for i := range m.projects {
+			if m.projects[i].Name == msg.name {
+				m.projects[i].NetlifyState = msg.state
+				if msg.state != "" {
+					m.noteRefresh("netlify")
+				}
 				break
 			}
 		}
@@ -436,6 +1128,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.syncFiltered()
 		return m, nil
 
+	case activityMsg:
+		if p := m.getProjectByName(msg.name); p != nil {
+			p.Activity = msg.counts
+		}
+		m.syncFiltered()
+		return m, nil
+
 	case languageMsg:
 		for i := range m.projects {
 			if m.projects[i].Name == msg.name {
@@ -447,6 +1146,503 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.syncFiltered()
 		return m, nil
 
+	case timerMsg:
+		m.activeTimer = msg.active
+		if msg.status != "" {
+			m.statusMsg = msg.status
+			m.statusMsgTime = time.Now()
+		}
+		return m, nil
+
+	case portfolioMsg:
+		m.portfolioLoading = false
+		m.portfolioMonth = msg.month
+		m.portfolio = msg.rows
+		m.portfolioErr = ""
+		if msg.err != nil {
+			m.portfolioErr = msg.err.Error()
+		}
+		return m, nil
+
+	case mergedBranchesMsg:
+		if msg.project != m.cleanup.project {
+			return m, nil
+		}
+		m.cleanup.loading = false
+		m.cleanup.branches = msg.branches
+		m.cleanup.selected = make(map[string]bool, len(msg.branches))
+		for _, b := range msg.branches {
+			m.cleanup.selected[b] = true
+		}
+		if msg.err != nil {
+			m.cleanup.err = msg.err.Error()
+		}
+		return m, nil
+
+	case cherryBranchesMsg:
+		m.setCherryBranches(msg)
+		return m, nil
+
+	case cherryCommitsMsg:
+		m.setCherryCommits(msg)
+		return m, nil
+
+	case branchesMsg:
+		if msg.project != m.branchPicker.project {
+			return m, nil
+		}
+		m.branchPicker.loading = false
+		m.branchPicker.branches = msg.branches
+		if msg.err != nil {
+			m.branchPicker.err = msg.err.Error()
+		}
+		// Start on the checked-out branch
+		for i, b := range msg.branches {
+			if b.Current {
+				m.branchPicker.idx = i
+				break
+			}
+		}
+		return m, nil
+
+	case trafficMsg:
+		if msg.stats != nil {
+			m.traffic[msg.name] = msg.stats
+		}
+		return m, nil
+
+	case choresMsg:
+		m.chores = msg.due
+		m.choresErr = ""
+		if msg.err != nil {
+			m.choresErr = msg.err.Error()
+		}
+		if m.choresIdx >= len(m.chores) {
+			m.choresIdx = maxInt(len(m.chores)-1, 0)
+		}
+		return m, nil
+
+	case inboxMsg:
+		m.inbox = msg.items
+		m.inboxErr = ""
+		if msg.err != nil {
+			m.inboxErr = msg.err.Error()
+		}
+		if m.inboxIdx >= len(m.inbox) {
+			m.inboxIdx = maxInt(len(m.inbox)-1, 0)
+		}
+		return m, nil
+
+	case inboxRoutedMsg:
+		m.statusMsg = msg.status
+		if msg.err != nil {
+			m.statusMsg = i18n.T("Inbox: %s", msg.err)
+		}
+		m.statusMsgTime = time.Now()
+		var record tea.Cmd
+		switch msg.dest {
+		case "todo":
+			record = auditCmd(msg.project, "todo", msg.text, msg.err)
+		case "issue", "linear":
+			record = auditCmd(msg.project, "issue", msg.dest+": "+msg.text, msg.err)
+		}
+		if m.viewMode == InboxView {
+			return m, tea.Batch(record, loadInboxCmd)
+		}
+		return m, record
+
+	case commitFilesMsg:
+		if m.viewMode != CommitMode || msg.project != expandPath(m.commitProject) {
+			return m, nil
+		}
+		m.commitLoading = false
+		m.commitFiles = msg.files
+		m.commitErr = ""
+		if msg.err != nil {
+			m.commitErr = msg.err.Error()
+		}
+		if m.commitIdx >= len(m.commitFiles) {
+			m.commitIdx = maxInt(len(m.commitFiles)-1, 0)
+		}
+		return m, nil
+
+	case syncProgressMsg:
+		m.syncs[msg.project] = syncState{op: msg.op, line: msg.line}
+		return m, waitSyncCmd(msg.ch)
+
+	case bulkProgressMsg:
+		return m, m.setBulkProgress(msg)
+
+	case bulkDoneMsg:
+		m.bulkRunning = 0
+		return m, nil
+
+	case syncDoneMsg:
+		delete(m.syncs, msg.project)
+		m.statusMsg = syncResultMessage(msg)
+		m.statusMsgTime = time.Now()
+		record := auditCmd(msg.project, msg.op, m.statusMsg, msg.err)
+		if msg.status != nil {
+			status := msg.status
+			return m, tea.Batch(record, func() tea.Msg { return gitStatusMsg{name: msg.project, status: status} })
+		}
+		return m, record
+
+	case kanbanMsg:
+		m.kanban.loading = false
+		m.kanban.err = ""
+		if msg.err != nil {
+			m.kanban.err = msg.err.Error()
+		}
+		m.kanban.setTasks(msg.tasks)
+		return m, nil
+
+	case kanbanMovedMsg:
+		record := auditCmd(msg.project, "task", fmt.Sprintf("%s -> %s", msg.text, msg.to), msg.err)
+		if msg.err != nil {
+			m.statusMsg = i18n.T("Move failed: %s", msg.err)
+			m.statusMsgTime = time.Now()
+			return m, tea.Batch(record, loadKanbanCmd(m.projects))
+		}
+		m.kanban.col = int(msg.to)
+		m.kanban.follow = msg.follow
+		return m, tea.Batch(record, loadKanbanCmd(m.projects))
+
+	case stashesMsg:
+		m.stashes[msg.project] = msg.stashes
+		if m.stashIdx >= len(msg.stashes) {
+			m.stashIdx = maxInt(len(msg.stashes)-1, 0)
+		}
+		return m, nil
+
+	case worktreesMsg:
+		m.setWorktrees(msg)
+		return m, nil
+
+	case auditMsg:
+		m.setAuditLog(msg)
+		return m, nil
+
+	case snoozeMsg:
+		m.setSnoozes(msg)
+		return m, nil
+
+	case snoozeTickMsg:
+		return m, tea.Batch(loadSnoozeCmd, snoozeTickCmd())
+
+	case focusTickMsg:
+		if m.following {
+			return m, tea.Batch(loadFocusCmd, focusTickCmd())
+		}
+		return m, focusTickCmd()
+
+	case focusMsg:
+		return m.followFocus(msg.report, msg.err)
+
+	case autoFetchTickMsg:
+		next, cmd := m.startAutoFetch()
+		return next, tea.Batch(cmd, autoFetchTickCmd())
+
+	case autoFetchMsg:
+		return m, m.setAutoFetch(msg)
+
+	case uptimeTickMsg:
+		return m, tea.Batch(probeUptimeCmd, checkErrorSpikesCmd, uptimeTickCmd())
+
+	case workersTickMsg:
+		return m, tea.Batch(pollWorkersCmd(m.projects), workersTickCmd())
+
+	case workersMsg:
+		m.workerStatus = msg
+		return m, nil
+
+	case uptimeMsg:
+		m.uptimes, m.down = msg.reports, msg.down
+		return m, nil
+
+	case errorSpikesMsg:
+		m.errorSpikes = msg.spikes
+		return m, nil
+
+	case updateMsg:
+		m.updateAvailable = msg.latest
+		return m, nil
+
+	case digestMsg:
+		if msg.d == nil {
+			delete(m.digests, msg.project)
+		} else {
+			m.digests[msg.project] = msg.d
+		}
+		return m, nil
+
+	case briefingMsg:
+		m.briefings[msg.project] = msg.b
+		return m, nil
+
+	case commitLogMsg:
+		m.setCommitLog(msg)
+		return m, nil
+
+	case fileListMsg:
+		m.setFileList(msg)
+		return m, nil
+
+	case issuesMsg:
+		m.setIssues(msg)
+		return m, nil
+
+	case pullsMsg:
+		m.setPulls(msg)
+		return m, nil
+
+	case previewsMsg:
+		m.setPreviews(msg)
+		return m, nil
+
+	case metadataMsg:
+		m.setMetadata(msg)
+		return m, nil
+
+	case notificationsMsg:
+		m.setNotifications(msg)
+		return m, nil
+
+	case notifReadMsg:
+		m.setNotifRead(msg)
+		return m, nil
+
+	case reviewsMsg:
+		m.setReviews(msg)
+		return m, nil
+
+	case secretsMsg:
+		m.setSecrets(msg)
+		return m, nil
+
+	case boardMsg:
+		m.setBoard(msg)
+		return m, nil
+
+	case actionsInventoryMsg:
+		m.actions.rows, m.actions.errs = msg.rows, msg.errs
+		m.actions.loading = false
+		m.actions.idx = maxInt(min(m.actions.idx, len(m.actions.visible())-1), 0)
+		return m, nil
+
+	case rotationLogMsg:
+		if msg.err == nil {
+			m.rotation.rebuild(msg.log)
+		}
+		return m, nil
+
+	case incidentsMsg:
+		m.setIncidents(msg)
+		return m, nil
+
+	case incidentMsg:
+		m.setIncident(msg)
+		return m, nil
+
+	case incidentContextMsg:
+		if m.incident.project == msg.project {
+			m.incident.loading = false
+			m.incident.deploys = msg.deploys
+			m.incident.runs = msg.runs
+		}
+		return m, nil
+
+	case incidentDraftMsg:
+		if m.incident.project == msg.project {
+			m.incident.drafting = false
+			if msg.err != nil {
+				m.statusMsg = i18n.T("Draft failed: %v", msg.err)
+				m.statusMsgTime = time.Now()
+			} else {
+				m.incident.draft = msg.draft
+			}
+		}
+		return m, nil
+
+	case tailStartMsg:
+		if msg.err != nil {
+			m.statusMsg = i18n.T("Logs for %s failed: %v", msg.project, msg.err)
+			m.statusMsgTime = time.Now()
+		}
+		return m, nil
+
+	case tailTickMsg:
+		return m.handleTailTick(msg)
+
+	case deployStartMsg:
+		if msg.err != nil {
+			m.statusMsg = i18n.T("Deploy of %s failed: %v", msg.project, msg.err)
+			m.statusMsgTime = time.Now()
+		}
+		return m, nil
+
+	case deployTickMsg:
+		return m.handleDeployTick(msg)
+
+	case flyStatusMsg:
+		return m.setFlyStatus(msg), nil
+
+	case deployHistoryMsg:
+		return m.setDeployHistory(msg), nil
+
+	case snippetsMsg:
+		return m.setSnippets(msg), nil
+
+	case readmeCheckMsg:
+		return m.setReadmeCheck(msg), nil
+
+	case readmeDraftMsg:
+		return m.setReadmeDraft(msg), nil
+
+	case readmeProposedMsg:
+		return m.setReadmeProposed(msg)
+
+	case showcaseMsg:
+		return m.setShowcase(msg)
+
+	case snippetSavedMsg:
+		return m.setSnippetSaved(msg)
+
+	case runsMsg:
+		m.setRuns(msg)
+		return m, nil
+
+	case dispatchWorkflowsMsg:
+		m.setDispatchWorkflows(msg)
+		return m, nil
+
+	case dispatchTickMsg:
+		// Keeps watching from other views, to report when the run ends
+		if m.dispatch.watching == "" || !msg.since.Equal(m.dispatch.since) {
+			return m, nil
+		}
+		return m, watchDispatchCmd(m.dispatch)
+
+	case dispatchRunMsg:
+		return m, m.setDispatchRun(msg)
+
+	case environmentsMsg:
+		if m.envs == nil {
+			m.envs = make(map[string]*discover.EnvMatrix)
+		}
+		m.envs[msg.project] = msg.matrix
+		return m, nil
+
+	case releasesMsg:
+		if m.releases == nil {
+			m.releases = make(map[string][]forge.Release)
+		}
+		m.releases[msg.project] = msg.releases
+		return m, nil
+
+	case packagesMsg:
+		return m.setPackages(msg), nil
+
+	case packageCheckMsg:
+		return m.setPackageCheck(msg), nil
+
+	case releasePlanMsg:
+		return m.setReleasePlan(msg), nil
+
+	case releaseFlowMsg:
+		return m.finishReleaseFlow(msg)
+
+	case sizesMsg:
+		return m.setSizes(msg), nil
+
+	case benchMsg:
+		return m.setBench(msg), nil
+
+	case sbomMsg:
+		return m.setSBOM(msg), nil
+
+	case cronMsg:
+		if m.cronJobs == nil {
+			m.cronJobs = make(map[string][]discover.CronJob)
+		}
+		m.cronJobs[msg.project] = msg.jobs
+		return m, nil
+
+	case flagsMsg:
+		if m.flags == nil {
+			m.flags = make(map[string]*flags.Report)
+			m.flagErrs = make(map[string]string)
+		}
+		m.flags[msg.project] = msg.report
+		delete(m.flagErrs, msg.project)
+		if msg.err != nil {
+			m.flagErrs[msg.project] = msg.err.Error()
+		}
+		return m, nil
+
+	case artifactsMsg:
+		m.setArtifacts(msg)
+		return m, nil
+
+	case artifactDownloadMsg:
+		m.setDownload(msg)
+		return m, nil
+
+	case ciMsg:
+		if p := m.getProjectByName(msg.name); p != nil && msg.ci != nil {
+			p.CI = msg.ci
+			m.noteRefresh("github")
+		}
+		m.updateStats()
+		return m, nil
+
+	case securityMsg:
+		if p := m.getProjectByName(msg.name); p != nil && msg.alerts != nil {
+			p.Security = msg.alerts
+		}
+		m.updateStats()
+		return m, nil
+
+	case portsMsg:
+		m.portsView.loading = false
+		m.portsView.err = ""
+		if msg.err != nil {
+			m.portsView.err = msg.err.Error()
+		}
+		m.portsView.rows = msg.rows
+		m.portsView.idx = maxInt(min(m.portsView.idx, len(msg.rows)-1), 0)
+		return m, nil
+
+	case portCheckMsg:
+		return m.handlePortCheck(msg)
+
+	case servicesTickMsg:
+		if m.viewMode == ServicesView && m.svcView.project == msg.project {
+			return m, servicesTickCmd(m.svcs, msg.project)
+		}
+		return m, nil
+
+	case milestonesMsg:
+		m.setMilestones(msg)
+		return m, nil
+
+	case healthMsg:
+		if msg.health != nil {
+			m.health[msg.project] = msg.health
+		}
+		return m, nil
+
+	case toolchainMsg:
+		m.toolchains[msg.project] = msg.reqs
+		m.syncFiltered()
+		return m, nil
+
+	case estimatesMsg:
+		if msg.summary != nil {
+			m.estimates[msg.name] = msg.summary
+		}
+		return m, nil
+
 	case chatResponseMsg:
 		m.chatLoading = false
 		if msg.err != nil {
@@ -456,16 +1652,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case deviceCodeMsg:
+		m.statusMsgTime = time.Now()
+		return m.startLogin(msg)
+
 	case actionResultMsg:
+		if msg.action == "login" {
+			m.login = nil
+		}
 		m.statusMsg = msg.message
 		m.statusMsgTime = time.Now()
-		// Refresh git status for the project after git actions
-		if msg.action == "git_add" || msg.action == "git_commit" {
-			if p := m.getProjectByName(msg.project); p != nil {
-				return m, loadGitStatusCmd(msg.project, expandPath(p.Path))
-			}
-		}
-		return m, nil
+		next, cmd := m.refreshAfterAction(msg)
+		return next, tea.Batch(cmd, auditResultCmd(msg))
 
 	case runningStateMsg:
 		m.runningServers[msg.project] = msg.running
@@ -477,12 +1675,100 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.syncFiltered()
+		if msg.running && msg.env != "" {
+			m.statusMsg = i18n.T("Started %s (%s)", msg.project, msg.env)
+			m.statusMsgTime = time.Now()
+		}
 		return m, nil
 	}
 
 	return m, nil
 }
 
+// refreshAfterAction reloads whatever an action changed
+func (m Model) refreshAfterAction(msg actionResultMsg) (tea.Model, tea.Cmd) {
+	// Refresh git status for the project after git actions
+	switch msg.action {
+	case "git_add", "git_commit", "git_checkout", "git_rebase", "git_branch_delete", "git_cherry_pick":
+		if p := m.getProjectByName(msg.project); p != nil {
+			if msg.action == "git_commit" {
+				return m, tea.Batch(loadGitStatusCmd(msg.project, expandPath(p.Path)), loadActivityCmd(msg.project, p.Path))
+			}
+			// History was rewritten under an open log
+			if (msg.action == "git_rebase" || msg.action == "git_cherry_pick") && m.commitLog.project == msg.project {
+				m.commitLog = commitLog{project: p.Name, loading: true}
+				return m, tea.Batch(loadGitStatusCmd(msg.project, expandPath(p.Path)), loadCommitLogCmd(p.Name, p.Path, logPageSize))
+			}
+			return m, loadGitStatusCmd(msg.project, expandPath(p.Path))
+		}
+	}
+	if msg.action == "chore" {
+		return m, loadChoresCmd
+	}
+	if msg.action == "dispatch" && msg.project == m.dispatch.project && m.dispatch.watching != "" {
+		if !msg.success {
+			m.dispatch.watching = ""
+			return m, nil
+		}
+		return m, dispatchTickCmd(m.dispatch.since)
+	}
+	if msg.action == "board_move" && !msg.success {
+		// Undo the move shown ahead of GitHub
+		m.board.loading = true
+		return m, loadBoardCmd(m.projects)
+	}
+	if msg.action == "rerun" && msg.success {
+		if p := m.getProjectByName(msg.project); p != nil {
+			cmds := []tea.Cmd{loadCICmd(p.Name, p.Path)}
+			if m.viewMode == RunsView && m.runs.project == p.Name {
+				m.runs.loading = true
+				cmds = append(cmds, loadRunsCmd(p.Name, p.Path))
+			}
+			return m, tea.Batch(cmds...)
+		}
+	}
+	if msg.action == "snooze" {
+		return m, loadSnoozeCmd
+	}
+	if msg.action == "repo_metadata" && msg.success && m.viewMode == MetadataView {
+		m.metadata.loading = true
+		return m, loadMetadataCmd(m.projects)
+	}
+	if msg.action == "rollback" && msg.success {
+		if p := m.getProjectByName(msg.project); p != nil {
+			return m, loadDeployStateCmd(p.Name, p.Path)
+		}
+	}
+	if msg.action == "pr_merge" && msg.success {
+		if p := m.getProjectByName(msg.project); p != nil {
+			cmds := []tea.Cmd{loadGHStatusCmd(p.Name, p.Path), loadGitStatusCmd(p.Name, expandPath(p.Path))}
+			if m.viewMode == PullsView && m.pulls.project == p.Name {
+				m.pulls.loading = true
+				cmds = append(cmds, loadPullsCmd(p.Name, p.Path))
+			}
+			return m, tea.Batch(cmds...)
+		}
+	}
+	if msg.action == "login" && msg.success {
+		return m, loadGHBatchCmd(m.projects)
+	}
+	if msg.action == "incident" {
+		return m, loadIncidentsCmd
+	}
+	if msg.action == "rotate" {
+		return m, loadRotationLogCmd
+	}
+	if msg.action == "git_stash" {
+		if p := m.getProjectByName(msg.project); p != nil {
+			return m, tea.Batch(
+				loadGitStatusCmd(msg.project, expandPath(p.Path)),
+				loadStashesCmd(msg.project, p.Path),
+			)
+		}
+	}
+	return m, nil
+}
+
 // getProjectByName finds a project by name
 func (m *Model) getProjectByName(name string) *Project {
 	for i := range m.projects {
@@ -493,28 +1779,101 @@ func (m *Model) getProjectByName(name string) *Project {
 	return nil
 }
 
+// setGHStatus stores a project's GitHub counts
+func (m *Model) setGHStatus(name string, status *discover.GitHubStatus) {
+	if status == nil {
+		return
+	}
+	for i := range m.projects {
+		if m.projects[i].Name == name {
+			m.projects[i].Issues = status.Issues
+			m.projects[i].PRs = status.PRs
+			m.projects[i].Assigned = status.Assigned
+			m.projects[i].ReviewRequested = status.ReviewRequested
+			m.projects[i].Stars = status.Stars
+			m.projects[i].StarsWeek = status.StarsWeek
+			m.projects[i].Forks = status.Forks
+			m.projects[i].Watchers = status.Watchers
+			m.noteRefresh("github")
+			return
+		}
+	}
+}
+
 func (m *Model) updateStats() {
 	var s Stats
 	s.TotalProjects = len(m.projects)
 
 	for _, p := range m.projects {
-		s.TotalStaged += p.Staged
-		s.TotalUntracked += p.Untracked
-		s.TotalModified += p.Modified
-		s.TotalIssues += p.Issues
-		s.TotalPRs += p.PRs
+		// Snoozed alerts stay visible on the row but don't add to totals
+		muted := func(alert string) bool { return m.snoozed.Muted(p.Name, alert) }
+		if !muted(snooze.AlertDirty) {
+			s.TotalStaged += p.Staged
+			s.TotalUntracked += p.Untracked
+			s.TotalModified += p.Modified
+		}
+		if !muted(snooze.AlertAhead) {
+			s.TotalAhead += p.Ahead
+		}
+		if !muted(snooze.AlertBehind) {
+			s.TotalBehind += p.Behind
+			if p.Behind > 0 {
+				s.ProjectsBehind++
+			}
+		}
+		if !muted(snooze.AlertIssues) {
+			s.TotalIssues += p.Issues
+		}
+		if !muted(snooze.AlertPRs) {
+			s.TotalPRs += p.PRs
+		}
+		if p.Security != nil && !muted(snooze.AlertSecurity) {
+			s.TotalSecurity += p.Security.Total()
+		}
+		switch p.CI.State() {
+		case discover.CIPassing:
+			s.CIPassing++
+		case discover.CIFailing:
+			if !muted(snooze.AlertCI) {
+				s.CIFailing++
+			}
+		case discover.CIRunning:
+			s.CIRunning++
+		}
 		s.SwiftClean += p.SwiftClean
-		s.SwiftFailed += p.SwiftFailed
+		if !muted(snooze.AlertBuild) {
+			s.SwiftFailed += p.SwiftFailed
+		}
 
-		switch p.VercelState {
+		for _, state := range []string{p.VercelState, p.RenderState, p.RailwayState} {
+			if state == "failed" && muted(snooze.AlertDeploy) {
+				state = ""
+			}
+			switch state {
+			case "ready":
+				s.VercelReady++
+			case "building":
+				s.VercelBuilding++
+			case "queued":
+				s.VercelQueued++
+			case "failed":
+				s.VercelFailed++
+			}
+		}
+
+		state := p.NetlifyState
+		if state == "failed" && muted(snooze.AlertDeploy) {
+			state = ""
+		}
+		switch state {
 		case "ready":
-			s.VercelReady++
+			s.NetlifyReady++
 		case "building":
-			s.VercelBuilding++
+			s.NetlifyBuilding++
 		case "queued":
-			s.VercelQueued++
+			s.NetlifyQueued++
 		case "failed":
-			s.VercelFailed++
+			s.NetlifyFailed++
 		}
 	}
 
@@ -524,16 +1883,74 @@ func (m *Model) updateStats() {
 func (m *Model) syncFiltered() {
 	// Re-sync filtered with updated project data
 	query := strings.ToLower(m.searchInput.Value())
-	if query == "" {
+	if query == "" && m.filterMode == FilterNone {
 		m.filtered = m.projects
 	} else {
 		m.filtered = nil
 		for _, p := range m.projects {
-			if strings.Contains(strings.ToLower(p.Name), query) {
+			if strings.Contains(strings.ToLower(p.Name), query) && m.matchesFilter(p) {
 				m.filtered = append(m.filtered, p)
 			}
 		}
 	}
+
+	if m.sortMode == SortDirty {
+		sorted := make([]Project, len(m.filtered))
+		copy(sorted, m.filtered)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, b := sorted[i].DirtySince, sorted[j].DirtySince
+			if a.IsZero() || b.IsZero() {
+				return !a.IsZero() && b.IsZero()
+			}
+			return a.Before(b)
+		})
+		m.filtered = sorted
+	}
+	if m.sortMode == SortStars || m.sortMode == SortTrending {
+		sorted := make([]Project, len(m.filtered))
+		copy(sorted, m.filtered)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			if m.sortMode == SortTrending && a.StarsWeek != b.StarsWeek {
+				return a.StarsWeek > b.StarsWeek
+			}
+			return a.Stars > b.Stars
+		})
+		m.filtered = sorted
+	}
+
+	// Projects with an open incident are pinned to the top
+	if len(m.incidents) > 0 {
+		pinned := make([]Project, len(m.filtered))
+		copy(pinned, m.filtered)
+		sort.SliceStable(pinned, func(i, j int) bool {
+			_, a := m.incidents[pinned[i].Name]
+			_, b := m.incidents[pinned[j].Name]
+			return a && !b
+		})
+		m.filtered = pinned
+	}
+
+	// Keep the selection in range as the list shrinks
+	if m.selectedIdx >= len(m.filtered) {
+		m.selectedIdx = maxInt(len(m.filtered)-1, 0)
+	}
+}
+
+// matchesFilter reports whether a project passes the active filter mode
+func (m *Model) matchesFilter(p Project) bool {
+	switch m.filterMode {
+	case FilterBehind:
+		return p.Behind > 0
+	case FilterToolchain:
+		return m.toolchainIssues(p.Name) > 0
+	case FilterUnreleased:
+		return p.Release.Unreleased()
+	case FilterMine:
+		return p.Assigned+p.ReviewRequested > 0
+	default:
+		return true
+	}
 }
 
 // detectProjectType determines project type from language, path, and markers
@@ -626,11 +2043,71 @@ func detectProjectType(p Project) ProjectType {
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	if m.capturing {
+		return m.handleCaptureKey(msg)
+	}
+	if m.secondFactor != nil {
+		return m.handleSecondFactorKey(msg)
+	}
+	if m.snoozeProject != "" {
+		return m.handleSnoozeKey(msg)
+	}
+	if m.snippetProject != "" {
+		return m.handleSnippetNameKey(msg)
+	}
+	if m.bulkPending {
+		return m.handleBulkKey(msg)
+	}
+	if m.viewMode == LogsView && m.logFilter.Focused() {
+		return m.handleLogFilterKey(msg)
+	}
+	if m.viewMode == IncidentView && m.incidentNote.Focused() {
+		return m.handleIncidentNoteKey(msg)
+	}
+	if m.viewMode == SnippetsView && m.snippetFilter.Focused() {
+		return m.handleSnippetFilterKey(msg)
+	}
+	if m.viewMode == MetadataView && m.metadataInput.Focused() {
+		return m.handleMetadataInputKey(msg)
+	}
+	if m.plan != nil {
+		// Any key dismisses a dry-run plan; D also leaves dry-run mode
+		m.plan = nil
+		if key == "D" {
+			m.dryRun = false
+			m.statusMsg = i18n.T("Dry-run mode off")
+			m.statusMsgTime = time.Now()
+		}
+		return m, nil
+	}
+	if m.bulk != nil && m.bulkRunning == 0 {
+		// Any key dismisses a bulk summary
+		m.bulk = nil
+		return m, nil
+	}
+	if m.viewMode == DispatchView && m.dispatch.form {
+		return m.handleDispatchFormKey(msg)
+	}
+
 	// Global keys
 	switch key {
+	case "ctrl+n":
+		return m.startCapture()
 	case "q", "ctrl+c":
 		if m.viewMode == ListView {
-			return m, tea.Quit
+			// Services, log tails, and deploys are children of mc, so they
+			// go with it
+			svcs, tails, deploys := m.svcs, m.tails, m.deploys
+			return m, tea.Sequence(func() tea.Msg {
+				tails.StopAll("")
+				deploys.StopAll("")
+				svcs.StopAll("")
+				return nil
+			}, tea.Quit)
+		}
+		// q is just a letter while typing a commit message
+		if key == "q" && m.viewMode == CommitMode && m.commitInput.Focused() {
+			break
 		}
 		m.viewMode = ListView
 		return m, nil
@@ -639,20 +2116,85 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.viewMode = ListView
 			m.searchInput.SetValue("")
 			m.chatInput.SetValue("")
-			m.filtered = m.projects
+			m.syncFiltered()
 			m.chatResponse = ""
 			m.chatError = ""
+			m.chatContext = nil
 		}
 		return m, nil
 	}
 
 	switch m.viewMode {
+	case PortfolioView:
+		return m.handlePortfolioKey(msg)
 	case SearchMode:
 		return m.handleSearchKey(msg)
 	case ChatMode:
 		return m.handleChatKey(msg)
 	case CommitMode:
 		return m.handleCommitKey(msg)
+	case BranchMode:
+		return m.handleBranchKey(msg)
+	case DetailView:
+		return m.handleDetailKey(msg)
+	case KanbanView:
+		return m.handleKanbanKey(msg)
+	case InboxView:
+		return m.handleInboxKey(msg)
+	case ChoresView:
+		return m.handleChoresKey(msg)
+	case AuditView:
+		return m.handleAuditKey(msg)
+	case CleanupView:
+		return m.handleCleanupKey(msg)
+	case RebaseView:
+		return m.handleRebaseKey(msg)
+	case CherryPickView:
+		return m.handleCherryPickKey(msg)
+	case ServicesView:
+		return m.handleServicesKey(msg)
+	case IssuesView:
+		return m.handleIssuesKey(msg)
+	case PortsView:
+		return m.handlePortsKey(msg)
+	case PullsView:
+		return m.handlePullsKey(msg)
+	case RunsView:
+		return m.handleRunsKey(msg)
+	case LogsView:
+		return m.handleLogsKey(msg)
+	case IncidentView:
+		return m.handleIncidentKey(msg)
+	case NotifView:
+		return m.handleNotificationsKey(msg)
+	case ReviewsView:
+		return m.handleReviewsKey(msg)
+	case DeploysView:
+		return m.handleDeploysKey(msg)
+	case SnippetsView:
+		return m.handleSnippetsKey(msg)
+	case SecretsView:
+		return m.handleSecretsKey(msg)
+	case PrioritiesView:
+		return m.handlePrioritiesKey(msg)
+	case MilestonesView:
+		return m.handleMilestonesKey(msg)
+	case ActionsView:
+		return m.handleActionsKey(msg)
+	case BoardView:
+		return m.handleBoardKey(msg)
+	case DispatchView:
+		return m.handleDispatchKey(msg)
+	case ArtifactsView:
+		return m.handleArtifactsKey(msg)
+	case OrgsView:
+		return m.handleOrgsKey(msg)
+	case MetadataView:
+		return m.handleMetadataKey(msg)
+	case PackagesView:
+		return m.handlePackagesKey(msg)
+	case DebugView:
+		return m.handleDebugKey(msg)
 	default:
 		return m.handleListKey(msg)
 	}
@@ -678,6 +2220,9 @@ func (m Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Guard against empty list — navigation on zero items would panic
 	if len(m.filtered) == 0 {
 		switch key {
+		case "f":
+			m.cycleFilter()
+			return m, nil
 		case "/":
 			m.viewMode = SearchMode
 			m.searchInput.Focus()
@@ -732,8 +2277,7 @@ func (m Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, textinput.Blink
 	case "enter":
 		if len(m.filtered) > 0 {
-			m.currentProject = &m.filtered[m.selectedIdx]
-			m.viewMode = DetailView
+			return m.openProject()
 		}
 	case "o":
 		if len(m.filtered) > 0 {
@@ -766,6 +2310,130 @@ func (m Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, openProductionCmd(p.Name)
 			}
 		}
+	case "b":
+		p := m.filtered[m.selectedIdx]
+		m.branchPicker = branchPicker{project: p.Name, path: p.Path, loading: true}
+		m.viewMode = BranchMode
+		return m, loadBranchesCmd(p.Name, p.Path)
+	case "s":
+		return m.openCommitModal(m.filtered[m.selectedIdx])
+	case "P":
+		return m.executeAction(ActionPush, m.filtered[m.selectedIdx])
+	case "u":
+		return m.startSync(m.filtered[m.selectedIdx], "pull")
+	case "M":
+		m.viewMode = ChoresView
+		return m, loadChoresCmd
+	case "A":
+		m.viewMode = AuditView
+		m.auditIdx = 0
+		return m, loadAuditCmd
+	case "I":
+		m.viewMode = InboxView
+		m.inboxIdx = 0
+		return m, loadInboxCmd
+	case "B":
+		m.viewMode = KanbanView
+		m.kanban.loading = true
+		return m, loadKanbanCmd(m.projects)
+	case "f":
+		m.cycleFilter()
+	case "S":
+		m.cycleSort()
+	case "*":
+		return m.toggleAudience()
+	case "T":
+		return m, toggleTimerCmd(m.filtered[m.selectedIdx].Name)
+	case "$":
+		m.viewMode = PortfolioView
+		m.portfolioLoading = true
+		m.portfolioScroll = 0
+		m.portfolioMonth = time.Now()
+		return m, loadPortfolioCmd(m.projects)
+	case "D":
+		m.dryRun = !m.dryRun
+		m.statusMsg = i18n.T("Dry-run mode off")
+		if m.dryRun {
+			m.statusMsg = i18n.T("Dry-run mode on: actions show what they would run")
+		}
+		m.statusMsgTime = time.Now()
+	case "a":
+		return m.toggleFollow()
+	case " ":
+		// Mark and move on, so a run of projects is quick to select
+		m.toggleMark(m.filtered[m.selectedIdx].Name)
+		m.selectedIdx = min(m.selectedIdx+1, len(m.filtered)-1)
+		m.ensureVisible(listHeight)
+	case "V":
+		m.toggleMarkAll()
+	case "x":
+		return m.openBulkMenu()
+	case "F":
+		return m.openServices()
+	case "O":
+		return m.openPorts()
+	case "i":
+		if len(m.filtered) > 0 {
+			return m.openIssues(m.filtered[m.selectedIdx])
+		}
+	case "v":
+		if len(m.filtered) > 0 {
+			return m.openPulls(m.filtered[m.selectedIdx])
+		}
+	case "w":
+		if len(m.filtered) > 0 {
+			return m.openRuns(m.filtered[m.selectedIdx])
+		}
+	case "X":
+		if len(m.filtered) > 0 {
+			return m.openDispatch(m.filtered[m.selectedIdx])
+		}
+	case "E":
+		if len(m.filtered) > 0 {
+			return m.openArtifacts(m.filtered[m.selectedIdx])
+		}
+	case "@":
+		return m.openOrgs()
+	case "m":
+		return m.openMetadata()
+	case "J":
+		if len(m.filtered) > 0 {
+			return m.openPackages(m.filtered[m.selectedIdx])
+		}
+	case "L":
+		if len(m.filtered) > 0 {
+			return m.openLogs(m.filtered[m.selectedIdx])
+		}
+	case "!":
+		if len(m.filtered) > 0 {
+			return m.openIncident(m.filtered[m.selectedIdx])
+		}
+	case "N":
+		return m.openNotifications()
+	case "e":
+		if len(m.filtered) > 0 {
+			return m.openReviews(m.filtered[m.selectedIdx])
+		}
+	case "K":
+		return m.openSecrets()
+	case "Q":
+		return m.openPriorities()
+	case "Y":
+		return m.openBoard()
+	case "U":
+		return m.openDebug()
+	case "H":
+		if len(m.filtered) > 0 {
+			return m.startHandoff(m.filtered[m.selectedIdx])
+		}
+	case "z":
+		if len(m.filtered) > 0 {
+			return m.startSnooze(m.filtered[m.selectedIdx])
+		}
+	case "Z":
+		if len(m.filtered) > 0 {
+			return m, removeSnoozeCmd(m.filtered[m.selectedIdx].Name)
+		}
 	case "?":
 		m.viewMode = HelpMode
 	case "ctrl+r":
@@ -776,6 +2444,61 @@ func (m Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// cycleSort advances to the next sort mode and re-sorts the list
+func (m *Model) cycleSort() {
+	m.sortMode = (m.sortMode + 1) % sortModeCount
+	m.syncFiltered()
+	m.selectedIdx = 0
+	m.scrollOffset = 0
+}
+
+// cycleFilter advances to the next filter mode and re-filters the list
+func (m *Model) cycleFilter() {
+	m.filterMode = (m.filterMode + 1) % filterModeCount
+	m.syncFiltered()
+	m.selectedIdx = 0
+	m.scrollOffset = 0
+}
+
+// openProject opens the selected project's detail view
+func (m Model) openProject() (tea.Model, tea.Cmd) {
+	m.currentProject = &m.filtered[m.selectedIdx]
+	m.viewMode = DetailView
+	m.stashIdx = 0
+	m.detailTab = detailOverview
+	cmds := []tea.Cmd{
+		loadTrafficCmd(m.currentProject.Name, m.currentProject.Path),
+		loadEstimatesCmd(m.currentProject.Name),
+		loadStashesCmd(m.currentProject.Name, m.currentProject.Path),
+		loadWorktreesCmd(m.currentProject.Name, m.currentProject.Path),
+		loadHealthCmd(m.currentProject.Name, m.currentProject.Path),
+		loadMilestonesCmd(m.currentProject.Name, m.currentProject.Path),
+		loadDispatchWorkflowsCmd(m.currentProject.Name, m.currentProject.Path),
+		loadEnvironmentsCmd(m.currentProject.Name, m.currentProject.Path),
+		loadFlagsCmd(m.currentProject.Name),
+		loadCronCmd(m.currentProject.Name, m.currentProject.Path),
+		loadReleasesCmd(m.currentProject.Name, m.currentProject.Path),
+		loadSBOMCmd(m.currentProject.Name, m.currentProject.Path),
+		loadPackagesCmd(m.currentProject.Name, m.currentProject.Path),
+		loadReleasePlanCmd(m.currentProject.Name, m.currentProject.Path),
+		loadSizesCmd(m.currentProject.Name, m.currentProject.Path),
+		loadBenchCmd(m.currentProject.Name, m.currentProject.Path),
+		loadFlyStatusCmd(m.currentProject.Name, m.currentProject.Path),
+		loadDeployHistoryCmd(m.currentProject.Name, m.currentProject.Path),
+		loadSnippetsCmd(m.currentProject.Name),
+		loadReadmeCmd(m.currentProject.Name, m.currentProject.Path, m.currentProject.CI.State() == discover.CIFailing),
+		markSeenCmd(*m.currentProject),
+	}
+	if m.artifacts.project != m.currentProject.Name {
+		m.artifacts = artifactsView{project: m.currentProject.Name, path: m.currentProject.Path}
+		cmds = append(cmds, loadArtifactsCmd(m.currentProject.Name, m.currentProject.Path))
+	}
+	if needsBriefing(m.currentProject) && m.briefings[m.currentProject.Name] == nil {
+		cmds = append(cmds, m.startBriefing(m.currentProject))
+	}
+	return m, tea.Batch(cmds...)
+}
+
 func (m *Model) ensureVisible(listHeight int) {
 	if m.selectedIdx < m.scrollOffset {
 		m.scrollOffset = m.selectedIdx
@@ -797,7 +2520,7 @@ func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		m.viewMode = ListView
 		m.searchInput.SetValue("")
-		m.filtered = m.projects
+		m.syncFiltered()
 		return m, nil
 	}
 
@@ -805,17 +2528,7 @@ func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	m.searchInput, cmd = m.searchInput.Update(msg)
 
 	// Filter projects
-	query := strings.ToLower(m.searchInput.Value())
-	if query == "" {
-		m.filtered = m.projects
-	} else {
-		m.filtered = nil
-		for _, p := range m.projects {
-			if strings.Contains(strings.ToLower(p.Name), query) {
-				m.filtered = append(m.filtered, p)
-			}
-		}
-	}
+	m.syncFiltered()
 	m.selectedIdx = 0
 	m.scrollOffset = 0
 
@@ -861,35 +2574,47 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) executeAction(action ButtonAction, p Project) (tea.Model, tea.Cmd) {
+	if name, ok := riskyActions[action]; ok {
+		return m.requireSecondFactor(name, p.Name, func(m Model) (tea.Model, tea.Cmd) {
+			return m.runAction(action, p)
+		})
+	}
+	return m.runAction(action, p)
+}
+
+// runAction performs a button's action once any second factor is given
+func (m Model) runAction(action ButtonAction, p Project) (tea.Model, tea.Cmd) {
 	expandedPath := expandPath(p.Path)
 	home, _ := os.UserHomeDir()
 	binDir := filepath.Join(home, "Projects", "mission-control", "bin")
 
 	switch action {
 	case ActionPush:
-		m.statusMsg = "Pushing " + p.Name + "..."
-		m.statusMsgTime = time.Now()
-		return m, runScriptWithFeedback(filepath.Join(binDir, "mc-push"), p.Name, "push", expandedPath)
+		return m.startSync(p, "push")
 
 	case ActionMerge:
-		m.statusMsg = "Opening PR for " + p.Name + "..."
+		if m.dryRun {
+			return m.showPlan("Merge "+p.Name, envPlan(expandedPath, shellCommand(filepath.Join(binDir, "mc-merge"), expandedPath))...)
+		}
+		m.statusMsg = i18n.T("Opening PR for %s...", p.Name)
 		m.statusMsgTime = time.Now()
-		return m, runScriptWithFeedback(filepath.Join(binDir, "mc-merge"), p.Name, "merge", expandedPath)
+		return m, runScriptWithFeedback(filepath.Join(binDir, "mc-merge"), p.Name, expandedPath, "merge")
 
 	case ActionRun:
+		if m.dryRun {
+			return m.showPlan("Start/stop "+p.Name, envPlan(expandedPath, shellCommand(filepath.Join(binDir, "mc-run"), expandedPath))...)
+		}
 		// Check if already running - toggle stop
 		if m.isProjectRunning(p.Name) {
-			m.statusMsg = "Stopping " + p.Name + "..."
+			m.statusMsg = i18n.T("Stopping %s...", p.Name)
 		} else {
-			m.statusMsg = "Starting " + p.Name + "..."
+			m.statusMsg = i18n.T("Starting %s...", p.Name)
 		}
 		m.statusMsgTime = time.Now()
 		return m, runServerCmd(filepath.Join(binDir, "mc-run"), p.Name, expandedPath)
 
 	case ActionDeploy:
-		m.statusMsg = "Deploying " + p.Name + "..."
-		m.statusMsgTime = time.Now()
-		return m, runScriptWithFeedback(filepath.Join(binDir, "mc-deploy"), p.Name, "deploy", expandedPath)
+		return m.startDeploy(p)
 
 	case ActionReadme:
 		return m, runScriptCmd(filepath.Join(binDir, "mc-edit"), expandedPath, "README.md")
@@ -907,22 +2632,43 @@ func (m Model) executeAction(action ButtonAction, p Project) (tea.Model, tea.Cmd
 		return m, runScriptCmd(filepath.Join(binDir, "mc-chat"), expandedPath)
 
 	case ActionGitAdd:
-		m.statusMsg = "Staging files in " + p.Name + "..."
+		if m.dryRun {
+			return m.showPlan("Stage all in "+p.Name, shellCommand("git", "-C", expandedPath, "add", "-A"))
+		}
+		m.statusMsg = i18n.T("Staging files in %s...", p.Name)
 		m.statusMsgTime = time.Now()
 		return m, gitAddCmd(p.Name, expandedPath)
 
 	case ActionGitCommit:
-		// Enter commit mode
-		m.viewMode = CommitMode
-		m.commitProject = p.Path
-		m.commitInput.SetValue("")
-		m.commitInput.Focus()
-		return m, textinput.Blink
+		return m.openCommitModal(p)
+
+	case ActionIssues:
+		return m.openIssues(p)
+
+	case ActionPulls:
+		return m.openPulls(p)
+
+	case ActionRuns:
+		return m.openRuns(p)
 	}
 
 	return m, nil
 }
 
+// startSync begins a push or pull unless one is already running for p
+func (m Model) startSync(p Project, op string) (tea.Model, tea.Cmd) {
+	if s, ok := m.syncs[p.Name]; ok {
+		m.statusMsg = i18n.T("%s already running for %s", strings.Title(s.op), p.Name)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	if m.dryRun {
+		return m.showPlan("Sync "+p.Name+" ("+op+")", shellCommand("git", syncArgs(expandPath(p.Path), op)...))
+	}
+	m.syncs[p.Name] = syncState{op: op, line: "starting..."}
+	return m, startSyncCmd(p.Name, expandPath(p.Path), op)
+}
+
 // isProjectRunning checks if a dev server is running for the project
 func (m *Model) isProjectRunning(projectName string) bool {
 	// Check map first
@@ -970,16 +2716,18 @@ func runScriptCmd(script string, args ...string) tea.Cmd {
 	}
 }
 
-// runScriptWithFeedback runs a script and returns feedback message
-func runScriptWithFeedback(script, projectName, action string, args ...string) tea.Cmd {
+// runScriptWithFeedback runs a script on a project, in the project's
+// direnv/mise environment, and returns feedback message
+func runScriptWithFeedback(script, projectName, projectPath, action string) tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command(script, args...)
+		cmd := exec.Command(script, projectPath)
+		env := withEnv(shellenv.Apply(cmd, projectPath))
 		if err := cmd.Start(); err != nil {
 			return actionResultMsg{
 				action:  action,
 				project: projectName,
 				success: false,
-				message: fmt.Sprintf("Failed to %s %s: %v", action, projectName, err),
+				message: fmt.Sprintf("Failed to %s %s: %v%s", action, projectName, err, env),
 			}
 		}
 		// Reap in background, report success immediately
@@ -990,15 +2738,32 @@ func runScriptWithFeedback(script, projectName, action string, args ...string) t
 			action:  action,
 			project: projectName,
 			success: true,
-			message: fmt.Sprintf("%s started for %s", strings.Title(action), projectName),
+			message: fmt.Sprintf("%s started for %s%s", strings.Title(action), projectName, env),
 		}
 	}
 }
 
+// withEnv formats applied environment managers as a message suffix
+func withEnv(a shellenv.Applied) string {
+	if s := a.String(); s != "" {
+		return " (" + s + ")"
+	}
+	return ""
+}
+
+// envPlan prefixes a dry-run plan with the environment it would load
+func envPlan(projectPath string, steps ...string) []string {
+	if managers := shellenv.Detect(projectPath); len(managers) > 0 {
+		return append([]string{"# in " + projectPath + " with " + strings.Join(managers, ", ") + " environment"}, steps...)
+	}
+	return steps
+}
+
 // runServerCmd runs the dev server script and updates running state
 func runServerCmd(script, projectName, projectPath string) tea.Cmd {
 	return func() tea.Msg {
 		cmd := exec.Command(script, projectPath)
+		applied := shellenv.Apply(cmd, projectPath)
 		output, err := cmd.CombinedOutput()
 		
 		// Determine if started or stopped based on output
@@ -1010,7 +2775,7 @@ func runServerCmd(script, projectName, projectPath string) tea.Cmd {
 				action:  "run",
 				project: projectName,
 				success: false,
-				message: fmt.Sprintf("Run failed for %s: %v", projectName, err),
+				message: fmt.Sprintf("Run failed for %s: %v%s", projectName, err, withEnv(applied)),
 			}
 		}
 		
@@ -1018,6 +2783,7 @@ func runServerCmd(script, projectName, projectPath string) tea.Cmd {
 		return runningStateMsg{
 			project: projectName,
 			running: running,
+			env:     applied.String(),
 		}
 	}
 }
@@ -1050,14 +2816,14 @@ func gitAddCmd(projectName, projectPath string) tea.Cmd {
 func gitCommitCmd(projectName, projectPath, message string) tea.Cmd {
 	return func() tea.Msg {
 		cmd := exec.Command("git", "-C", projectPath, "commit", "-m", message)
-		err := cmd.Run()
+		output, err := cmd.CombinedOutput()
 		
 		if err != nil {
 			return actionResultMsg{
 				action:  "git_commit",
 				project: projectName,
 				success: false,
-				message: fmt.Sprintf("git commit failed: %v", err),
+				message: fmt.Sprintf("git commit failed: %s", firstLine(output, err)),
 			}
 		}
 		
@@ -1082,8 +2848,15 @@ func (m Model) handleChatKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.chatLoading = true
 		m.chatResponse = ""
 		m.chatError = ""
+		m.chatPrompt = message
 
+		if m.chatContext != nil {
+			message = m.chatContext.Context() + "\n\n" + message
+			m.chatContext = nil
+		}
 		return m, sendChatCmd(m.clawClient, message, m.chatCwd)
+	case "ctrl+s":
+		return m.startSaveSnippet()
 	case "esc":
 		m.viewMode = ListView
 		m.chatResponse = ""
@@ -1096,33 +2869,6 @@ func (m Model) handleChatKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Model) handleCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
-		message := m.commitInput.Value()
-		if message == "" {
-			return m, nil
-		}
-
-		m.commitInput.SetValue("")
-		m.viewMode = ListView
-		m.statusMsg = "Committing..."
-		m.statusMsgTime = time.Now()
-
-		// Get project name from path
-		projectName := filepath.Base(m.commitProject)
-		return m, gitCommitCmd(projectName, expandPath(m.commitProject), message)
-	case "esc":
-		m.viewMode = ListView
-		m.commitInput.SetValue("")
-		return m, nil
-	}
-
-	var cmd tea.Cmd
-	m.commitInput, cmd = m.commitInput.Update(msg)
-	return m, cmd
-}
-
 // =============================================================================
 // VIEW
 // =============================================================================
@@ -1171,7 +2917,7 @@ func (m Model) renderTopStatus() string {
 	titleCapL := lipgloss.NewStyle().Foreground(ColorMint).Render(PLLeftHalfCircle)
 	titleCapR := lipgloss.NewStyle().Foreground(ColorMint).Render(PLLowerLeftTriangle)
 
-	// Vercel segment: yellow
+	// Vercel segment, with Render and Railway: yellow
 	vercel := fmt.Sprintf(" %s %d%s %d%s %d%s %d%s ",
 		IconVercel,
 		m.stats.VercelReady, IconReady,
@@ -1182,6 +2928,17 @@ func (m Model) renderTopStatus() string {
 	vercelCapL := lipgloss.NewStyle().Foreground(ColorVercel).Render(PLUpperRightTriangle)
 	vercelCapR := lipgloss.NewStyle().Foreground(ColorVercel).Render(PLLowerLeftTriangle)
 
+	// Netlify segment: blue
+	netlify := fmt.Sprintf(" %s %d%s %d%s %d%s %d%s ",
+		IconNetlify,
+		m.stats.NetlifyReady, IconReady,
+		m.stats.NetlifyBuilding, IconBuilding,
+		m.stats.NetlifyQueued, IconQueued,
+		m.stats.NetlifyFailed, IconX)
+	netlifySeg := lipgloss.NewStyle().Foreground(ColorBlack).Background(ColorNetlify).Render(netlify)
+	netlifyCapL := lipgloss.NewStyle().Foreground(ColorNetlify).Render(PLUpperRightTriangle)
+	netlifyCapR := lipgloss.NewStyle().Foreground(ColorNetlify).Render(PLLowerLeftTriangle)
+
 	// Swift segment: magenta
 	swift := fmt.Sprintf(" %s %d%s %d%s ",
 		IconSwift,
@@ -1192,24 +2949,30 @@ func (m Model) renderTopStatus() string {
 	swiftCapR := lipgloss.NewStyle().Foreground(ColorSwift).Render(PLFlameThick)
 
 	// Calculate elastic gap
-	leftPart := titleCapL + titleSeg + titleCapR + vercelCapL + vercelSeg + vercelCapR + swiftCapL + swiftSeg + swiftCapR
+	leftPart := titleCapL + titleSeg + titleCapR + vercelCapL + vercelSeg + vercelCapR + netlifyCapL + netlifySeg + netlifyCapR + swiftCapL + swiftSeg + swiftCapR
 	leftLen := lipgloss.Width(leftPart)
 
 	// Git segment: cyan
-	git := fmt.Sprintf(" %s %s%d %s%d %s%d ",
+	git := fmt.Sprintf(" %s %s%d %s%d %s%d %s%d %s%d ",
 		IconGit,
 		IconStaged, m.stats.TotalStaged,
 		IconUntracked, m.stats.TotalUntracked,
-		IconModified, m.stats.TotalModified)
+		IconModified, m.stats.TotalModified,
+		IconAhead, m.stats.TotalAhead,
+		IconBehind, m.stats.TotalBehind)
 	gitSeg := lipgloss.NewStyle().Foreground(ColorBlack).Background(ColorGit).Render(git)
 	gitCapL := lipgloss.NewStyle().Foreground(ColorGit).Render(PLFlameThickMirrored)
 	gitCapR := lipgloss.NewStyle().Foreground(ColorGit).Render(PLRightHardDivider)
 
 	// GitHub segment: green
-	gh := fmt.Sprintf(" %s %s%d %s%d ",
+	gh := fmt.Sprintf(" %s %s%d %s%d %s%d %d%s %d%s %d%s ",
 		IconGitHub,
 		IconIssue, m.stats.TotalIssues,
-		IconPR, m.stats.TotalPRs)
+		IconPR, m.stats.TotalPRs,
+		IconSecurity, m.stats.TotalSecurity,
+		m.stats.CIPassing, IconCheck,
+		m.stats.CIRunning, IconBuilding,
+		m.stats.CIFailing, IconX)
 	ghSeg := lipgloss.NewStyle().Foreground(ColorBlack).Background(ColorGH).Render(gh)
 	ghCapL := lipgloss.NewStyle().Foreground(ColorGH).Render(PLLeftHardDivider)
 	ghCapR := lipgloss.NewStyle().Foreground(ColorGH).Render(PLRightHalfCircle)
@@ -1235,6 +2998,12 @@ func (m Model) renderSearchBox() string {
 	if m.viewMode != SearchMode {
 		content = fmt.Sprintf("%s %s", IconSearch, m.searchInput.Placeholder)
 	}
+	if m.filterMode != FilterNone {
+		content += fmt.Sprintf("  [%s: %d]", m.filterMode, len(m.filtered))
+	}
+	if m.sortMode != SortDefault {
+		content += fmt.Sprintf("  [sort: %s]", m.sortMode)
+	}
 
 	box := SearchBoxStyle.Width(m.width - 4).Render(content)
 	return box
@@ -1245,12 +3014,114 @@ func (m Model) renderSearchBox() string {
 // =============================================================================
 
 func (m *Model) renderProjectList(height int) string {
+	if m.plan != nil {
+		return m.renderPlan(height)
+	}
+	if m.bulk != nil && m.bulkRunning == 0 {
+		return m.renderBulkSummary(height)
+	}
 	if m.viewMode == HelpMode {
 		return m.renderHelp(height)
 	}
 	if m.viewMode == DetailView {
 		return m.renderDetailView(height)
 	}
+	if m.viewMode == PortfolioView {
+		return m.renderPortfolio(height)
+	}
+	if m.viewMode == BranchMode {
+		return m.renderBranchPicker(height)
+	}
+	if m.viewMode == KanbanView {
+		return m.renderKanban(height)
+	}
+	if m.viewMode == CommitMode {
+		return m.renderCommitModal(height)
+	}
+	if m.viewMode == InboxView {
+		return m.renderInbox(height)
+	}
+	if m.viewMode == ChoresView {
+		return m.renderChores(height)
+	}
+	if m.viewMode == AuditView {
+		return m.renderAudit(height)
+	}
+	if m.viewMode == CleanupView {
+		return m.renderCleanup(height)
+	}
+	if m.viewMode == RebaseView {
+		return m.renderRebasePlan(height)
+	}
+	if m.viewMode == CherryPickView {
+		return m.renderCherryPick(height)
+	}
+	if m.viewMode == ServicesView {
+		return m.renderServices(height)
+	}
+	if m.viewMode == IssuesView {
+		return m.renderIssues(height)
+	}
+	if m.viewMode == PortsView {
+		return m.renderPorts(height)
+	}
+	if m.viewMode == PullsView {
+		return m.renderPulls(height)
+	}
+	if m.viewMode == RunsView {
+		return m.renderRuns(height)
+	}
+	if m.viewMode == LogsView {
+		return m.renderLogs(height)
+	}
+	if m.viewMode == IncidentView {
+		return m.renderIncident(height)
+	}
+	if m.viewMode == NotifView {
+		return m.renderNotifications(height)
+	}
+	if m.viewMode == ReviewsView {
+		return m.renderReviews(height)
+	}
+	if m.viewMode == DeploysView {
+		return m.renderDeploys(height)
+	}
+	if m.viewMode == SnippetsView {
+		return m.renderSnippets(height)
+	}
+	if m.viewMode == SecretsView {
+		return m.renderSecrets(height)
+	}
+	if m.viewMode == PrioritiesView {
+		return m.renderPriorities(height)
+	}
+	if m.viewMode == MilestonesView {
+		return m.renderMilestones(height)
+	}
+	if m.viewMode == ActionsView {
+		return m.renderActions(height)
+	}
+	if m.viewMode == BoardView {
+		return m.renderBoard(height)
+	}
+	if m.viewMode == DispatchView {
+		return m.renderDispatch(height)
+	}
+	if m.viewMode == ArtifactsView {
+		return m.renderArtifacts(height)
+	}
+	if m.viewMode == OrgsView {
+		return m.renderOrgs(height)
+	}
+	if m.viewMode == MetadataView {
+		return m.renderMetadata(height)
+	}
+	if m.viewMode == PackagesView {
+		return m.renderPackages(height)
+	}
+	if m.viewMode == DebugView {
+		return m.renderDebug(height)
+	}
 
 	var rows []string
 	listWidth := m.width - 3 // Leave room for scrollbar
@@ -1292,20 +3163,48 @@ func (m *Model) renderProjectList(height int) string {
 func (m *Model) renderProjectRow(p Project, idx int, width int, isOdd bool, isSelected bool, rowNum int) string {
 	// Type icon based on detected language/type
 	typeIcon := getTypeIcon(p.Type)
+	if m.snoozed.Project(p.Name) {
+		typeIcon = IconSnooze
+	}
+	if m.inMaintenance(p.Name) != nil {
+		typeIcon = IconMaintain
+	}
+	if m.toolchainIssues(p.Name) > 0 {
+		typeIcon = IconToolchain
+	}
+	if len(p.Conflicts) > 0 {
+		typeIcon = IconConflict
+	}
+	if _, ok := m.incidents[p.Name]; ok {
+		typeIcon = IconIncident
+	}
 
 	// Time formatting with icons
 	projectAge := formatTimeSince(p.FirstCommit)
 	lastCommit := formatTimeSince(p.LastCommit)
 
 	// Build content - track positions of clickable git stats
-	seg1 := fmt.Sprintf("%s %-18s", typeIcon, truncate(p.Name, 18))
+	mark := " "
+	if m.marked[p.Name] {
+		mark = IconCheck
+	}
+	seg1 := fmt.Sprintf("%s%s%-18s", typeIcon, mark, truncate(p.Name, 18))
+	branch := p.Branch
+	if branch == "" {
+		branch = "-"
+	}
+	if p.Operation != "" {
+		// Like a shell prompt: main|MERGE
+		branch += "|" + strings.ToUpper(p.Operation)
+	}
+	seg1b := fmt.Sprintf(" %s %-14s", IconBranch, truncate(branch, 14))
 	seg2 := fmt.Sprintf(" %s%4s %s%4s ", IconCommitStart, projectAge, IconCommitEnd, lastCommit)
 	
 	// Git stats - make untracked and modified clickable
 	seg3 := fmt.Sprintf(" %s%-2d %s%-2d %s%-2d ", IconStaged, p.Staged, IconUntracked, p.Untracked, IconModified, p.Modified)
 	
 	// Track positions for git stat clicks using actual terminal width
-	seg1Len := terminalWidth(seg1)
+	seg1Len := terminalWidth(seg1 + seg1b)
 	seg2Len := terminalWidth(seg2)
 	gitStatsStart := seg1Len + seg2Len
 	
@@ -1335,7 +3234,79 @@ func (m *Model) renderProjectRow(p Project, idx int, width int, isOdd bool, isSe
 		})
 	}
 	
-	seg4 := fmt.Sprintf(" %s%-2d %s%-2d", IconIssue, p.Issues, IconPR, p.PRs)
+	// How long changes have sat uncommitted
+	seg3d := strings.Repeat(" ", 5)
+	if !p.DirtySince.IsZero() {
+		seg3d = fmt.Sprintf("%s%s ", IconTime, formatTimeSince(p.DirtySince))
+	}
+
+	// Ahead/behind upstream - blank when in sync so drift stands out
+	seg3b := strings.Repeat(" ", 8)
+	if p.Ahead > 0 || p.Behind > 0 {
+		seg3b = fmt.Sprintf("%s%-2d%s%-2d", IconAhead, p.Ahead, IconBehind, p.Behind)
+	}
+
+	// Stash count - easy to forget, so only shown when there are some
+	seg3c := strings.Repeat(" ", 5)
+	if p.Stashes > 0 {
+		seg3c = fmt.Sprintf(" %s%-2d", IconStash, p.Stashes)
+	}
+
+	seg4 := fmt.Sprintf(" %s%-2d %s%-2d %s", IconIssue, p.Issues, IconPR, p.PRs, ciIcon(p.CI.State()))
+
+	// Open security alerts, shown in red
+	seg4s := securityBadge(p)
+
+	// Background workers and queue depth, red when unhealthy
+	seg4w := m.workersBadge(p)
+
+	// Latest release and commits since - "v1.4.0+12" needs a version bump
+	seg4b := strings.Repeat(" ", 15)
+	if p.Release != nil {
+		release := p.Release.Tag
+		if p.Release.Since > 0 {
+			release = fmt.Sprintf("%s+%d", truncate(release, 8), p.Release.Since)
+		}
+		seg4b = fmt.Sprintf(" %s%-12s", IconTag, truncate(release, 12))
+	}
+
+	// The issue and PR counts open their lists
+	issuesStart := terminalWidth(seg1+seg1b+seg2+seg3+seg3d+seg3b+seg3c) + 1
+	issuesEnd := issuesStart + terminalWidth(fmt.Sprintf("%s%-2d", IconIssue, p.Issues))
+	if p.Issues > 0 {
+		m.buttonBounds = append(m.buttonBounds, ButtonBounds{
+			StartX: issuesStart,
+			EndX:   issuesEnd,
+			Action: ActionIssues,
+			Row:    rowNum,
+		})
+	}
+	pullsEnd := issuesEnd + 1 + terminalWidth(fmt.Sprintf("%s%-2d", IconPR, p.PRs))
+	if p.PRs > 0 {
+		m.buttonBounds = append(m.buttonBounds, ButtonBounds{
+			StartX: issuesEnd + 1,
+			EndX:   pullsEnd,
+			Action: ActionPulls,
+			Row:    rowNum,
+		})
+	}
+	if p.CI != nil {
+		m.buttonBounds = append(m.buttonBounds, ButtonBounds{
+			StartX: pullsEnd + 1,
+			EndX:   pullsEnd + 1 + terminalWidth(ciIcon(p.CI.State())),
+			Action: ActionRuns,
+			Row:    rowNum,
+		})
+	}
+
+	// Optional audience columns for open source projects
+	seg4o := m.renderAudience(p)
+
+	// Commit activity over the last 30 days
+	seg5 := ""
+	if len(p.Activity) > 0 {
+		seg5 = " " + brailleSparkline(p.Activity)
+	}
 	
 	// Determine play/pause icon based on running state
 	runIcon := IconPlay
@@ -1371,7 +3342,7 @@ func (m *Model) renderProjectRow(p Project, idx int, width int, isOdd bool, isSe
 	actions := actionsBuilder.String()
 
 	// Combine content
-	content := seg1 + seg2 + seg3 + seg4
+	content := seg1 + seg1b + seg2 + seg3 + seg3d + seg3b + seg3c + seg4 + seg4s + seg4w + seg4b + seg4o + seg5
 	contentWidth := terminalWidth(content)
 	actionsWidth := terminalWidth(actions)
 	
@@ -1404,6 +3375,14 @@ func (m *Model) renderProjectRow(p Project, idx int, width int, isOdd bool, isSe
 		fullRow += strings.Repeat(" ", width-currentWidth)
 	}
 
+	// Color the security badge now that widths are worked out
+	if strings.TrimSpace(seg4s) != "" && !m.snoozed.Muted(p.Name, snooze.AlertSecurity) {
+		fullRow = strings.Replace(fullRow, seg4s, redBadge(seg4s, isSelected), 1)
+	}
+	if s, ok := m.workerStatus[p.Name]; ok && !s.Healthy() {
+		fullRow = strings.Replace(fullRow, seg4w, redBadge(seg4w, isSelected), 1)
+	}
+
 	// Apply ANSI background color directly (bypassing lipgloss to avoid icon issues)
 	// Very subtle striping: no bg (even) vs 233 (odd) - barely visible
 	if isSelected {
@@ -1538,6 +3517,35 @@ func (m Model) renderChatBox() string {
 		return box
 	}
 
+	// Quick capture input overlays everything else
+	if m.capturing {
+		content = fmt.Sprintf("%s Capture: %s", IconTodo, m.captureInput.View())
+		box := ChatBoxStyle.Width(m.width - 4).Render(content)
+		return box
+	}
+
+	if m.secondFactor != nil {
+		return ChatBoxStyle.Width(m.width - 4).Render(m.renderSecondFactor())
+	}
+	if m.snoozeProject != "" {
+		content = fmt.Sprintf("%s Snooze %s: %s", IconSnooze, m.snoozeProject, m.snoozeInput.View())
+		box := ChatBoxStyle.Width(m.width - 4).Render(content)
+		return box
+	}
+	if m.snippetProject != "" {
+		content = i18n.T("%s Save answer as a %s snippet named: %s", IconTodo, m.snippetProject, m.snippetName.View())
+		return ChatBoxStyle.Width(m.width - 4).Render(content)
+	}
+	if m.bulkPending {
+		return ChatBoxStyle.Width(m.width - 4).Render(m.renderBulkMenu())
+	}
+
+	// In-flight pushes/pulls take the pane until they finish
+	if len(m.syncs) > 0 {
+		box := ChatBoxStyle.Width(m.width - 4).Render(m.renderSyncs())
+		return box
+	}
+
 	// Show recent status message (within 5 seconds)
 	if m.statusMsg != "" && time.Since(m.statusMsgTime) < 5*time.Second {
 		content = fmt.Sprintf("%s %s", IconCheck, m.statusMsg)
@@ -1558,6 +3566,9 @@ func (m Model) renderChatBox() string {
 		content = fmt.Sprintf("%s %s", IconChat, resp)
 	} else if m.viewMode == ChatMode {
 		content = fmt.Sprintf("%s %s", IconChat, m.chatInput.View())
+		if m.chatContext != nil {
+			content = fmt.Sprintf("%s [%s] %s", IconChat, m.chatContext.Name, m.chatInput.View())
+		}
 	} else {
 		cwdDisplay := "~/Projects"
 		if m.chatCwd != "" && !strings.HasSuffix(m.chatCwd, "/Projects") {
@@ -1578,6 +3589,33 @@ func (m Model) renderBottomStatus() string {
 	// Left side: project count + add
 	left := fmt.Sprintf("%s %d  %s",
 		IconProjects, m.stats.TotalProjects, IconPlus)
+	if m.dryRun {
+		left += "  " + i18n.T("DRY RUN")
+	}
+	if m.following {
+		left += "  " + i18n.T("FOLLOW")
+	}
+	if m.inMaintenance("") != nil {
+		left += "  " + i18n.T("MAINTENANCE")
+	}
+	if fixture.Replaying() {
+		left += "  " + i18n.T("REPLAY")
+	}
+	if err := fixture.Err(); err != nil {
+		left += "  " + i18n.T("RECORD FAILED: %v", err)
+	}
+	if m.activeTimer != nil {
+		left += fmt.Sprintf("  %s %s %s", IconTime, m.activeTimer.Project, formatDuration(m.activeTimer.Duration()))
+	}
+	if overdue, soon := m.choreCounts(); overdue > 0 || soon > 0 {
+		left += i18n.T("  %s %d overdue %d soon", IconWrench, overdue, soon)
+	}
+	if fresh := m.renderFreshness(); fresh != "" {
+		left += "  " + fresh
+	}
+	if m.updateAvailable != "" {
+		left += i18n.T("  %s %s available (mc upgrade)", IconAhead, m.updateAvailable)
+	}
 
 	// Right side: OpenClaw status + model + thinking + tokens
 	connected := IconConnected
@@ -1608,38 +3646,102 @@ func (m Model) renderBottomStatus() string {
 // HELP VIEW
 // =============================================================================
 
+// helpSections is the keyboard reference shown by ?, grouped by topic
+var helpSections = []struct {
+	title string
+	keys  [][2]string // key, description
+}{
+	{"Navigation", [][2]string{
+		{"j/k", "Move down/up"},
+		{"g/G", "Go to top/bottom"},
+		{"Ctrl+d/u", "Page down/up"},
+		{"/", "Search projects"},
+		{"f", "Cycle filters (behind origin, toolchain problems, unreleased commits, waiting on me)"},
+		{"S", "Cycle sort (longest-dirty first, most stars, most stars this week)"},
+		{"*", "Toggle the stars, forks, and watchers columns"},
+		{"Enter", "Select project"},
+	}},
+	{"Actions", [][2]string{
+		{"o", "Open project in nvim"},
+		{"l", "Open lazygit"},
+		{"s", "Stage files and commit"},
+		{"P/u", "Push/pull (fast-forward only) with progress"},
+		{"b", "Switch branch (local + remote)"},
+		{"b i/X", "Branch picker: rebase -i onto main / clean up merged branches"},
+		{"Tab i", "Commit log: plan an interactive rebase back to the selected commit"},
+		{"Tab C", "Commit log: cherry-pick commits from another branch"},
+		{"Space/V", "Mark project / mark all visible for bulk operations"},
+		{"x", "Bulk on marked: fetch all, pull all, push all clean, m milestone roll-up"},
+		{"F", "Services: start/stop a project's long-running commands, health, and logs"},
+		{"O", "Ports in use: what services claim and what is listening, with conflicts flagged"},
+		{"i", "Open issues (or click the issue count); Enter opens in browser, y copies URL"},
+		{"w", "GitHub Actions runs (or click the CI state); r re-runs, R re-runs failed jobs"},
+		{"X", "Run a workflow_dispatch workflow: fill in its inputs, then watch the run's jobs and steps"},
+		{"J", "Monorepo packages: version, last release, commits since, pending changesets, build/test/lint results, and flaky tests; r runs a package's checks, R every changed one's, v versions, P publishes"},
+		{"@", "Projects by GitHub owner (c: by client): open issues, PRs, failing CI, and dirty repos per org; enter lists its projects"},
+		{"E", "Latest release assets and workflow artifacts: enter downloads to the project's folder (\"downloads\", default ~/Downloads) and verifies the checksum"},
+		{"!", "Incident mode for a red project (failed deploy, failing CI, down, or a Sentry error spike): pinned, with a timeline (n notes), alerts, deploys, logs, and a drafted status update (d)"},
+		{"L", "Tail production logs (vercel, fly, kubectl, or \"logs\" in config.json); / filters, space pauses"},
+		{"e", "Review comments waiting on you: unresolved threads on the project's pull requests, stale ones flagged; a shows every project, enter jumps to the comment, p to the PR"},
+		{"N", "Notifications (mentions, review requests, assignments) from GitHub and other forges, by project; r marks read, a shows all"},
+		{"K", "Secrets: when each credential was last rotated, stale ones flagged; r logs a rotation, a shows Actions secrets/variables missing or unused by workflows"},
+		{"Q", "Work on these next: projects ranked by health, deadlines, revenue, and issue severity, with reasons (start_view \"priorities\" lands here)"},
+		{"Y", "GitHub Projects board (\"board\" in config): items by Status column, H/L moves an item, f narrows to the selected project"},
+		{"H", "Write a handoff document: architecture, checked setup, issues, env var names, runbook"},
+		{"U", "Debug: GitHub API quota left, queued requests, last refresh per provider (l to log in)"},
+		{"m", "GitHub metadata audit: public repos missing a description, topics, homepage, or social preview; d/t/h edit them in place via the API, s opens settings to upload a preview, a shows every repo"},
+		{"v", "Open pull requests with review, CI, and merge state and their Vercel/Netlify previews (or click the PR count); f shows only ready, p opens the preview"},
+		{"a/x", "Apply/drop selected stash (detail view)"},
+		{"n", "Snippets: saved chat answers for the project, / searches, enter inserts one into the next chat message (detail view; ctrl+s in a project's chat saves the answer)"},
+		{"h", "Deploys across Vercel, Netlify, Fly.io, Render, and Railway: open one or its build logs, R rolls production back to it (detail view)"},
+		{"=", "Detail view: README disagreeing with tags, go.mod, the remote, or CI gets an OpenClaw-drafted update; = again opens it as a pull request"},
+		{"#", "Detail view: capture the production site in headless Chrome as a screenshot and scroll-through GIF in the repo's assets, and update README image links"},
+		{"[/]", "Select a worktree; o/l then open it (detail view)"},
+		{"Tab", "Detail view: cycle overview, commit log (y copies hash, w opens on GitHub), changed files"},
+		{"Tab Enter", "Changed files: open the selected file in the editor"},
+		{"W", "Detail view: re-entry briefing (automatic after 2 weeks idle)"},
+		{"m", "Detail view: generate the SBOM (syft, cdxgen, npm, cyclonedx-gomod) for licenses and vulnerabilities; mc sbom exports it"},
+		{"%", "Detail view: run the Go or Rust benchmarks, flagging ones 10% slower than the last commit's run"},
+		{"y", "Detail view: deploy to Fly.io, Vercel, Netlify, Render, or Railway (or the deploy setting), streaming build logs into the logs pane"},
+		{"d", "Open production URL (Vercel)"},
+		{"T", "Start/stop time tracking on project"},
+		{"$", "Portfolio P&L (revenue vs cloud costs and tracked time)"},
+		{"Ctrl+n", "Quick-capture a thought to the inbox (any view)"},
+		{"I", "Process inbox (route to TODO.md, GitHub, Linear)"},
+		{"M", "Maintenance chores (d marks done)"},
+		{"A", "Audit log of changes mc made (e exports CSV)"},
+		{"z/Z", "Snooze project or one alert (e.g. \"3d\", \"deploy monday\") / unsnooze"},
+		{"B", "Task board from PLAN.md / TODO.md (H/L moves a task)"},
+		{"D", "Toggle dry-run mode (actions show their commands instead)"},
+		{"a", "Toggle focus-follow: select the project tmux or your editor is in (mc focus hooks)"},
+	}},
+	{"Files", [][2]string{
+		{"r", "Edit README.md"},
+		{"R", "Edit ROADMAP.md"},
+		{"p", "Edit PLAN.md"},
+		{"t", "Edit TODO.md"},
+	}},
+	{"Chat", [][2]string{
+		{"C", "Chat in ~/Projects"},
+		{"c", "Chat in selected project"},
+	}},
+	{"Other", [][2]string{
+		{"Ctrl+r", "Refresh all"},
+		{"?", "Show this help"},
+		{"q/Esc", "Back/Quit"},
+	}},
+}
+
 func (m Model) renderHelp(height int) string {
-	help := `
-  Mission Control - Keyboard Shortcuts
-
-  Navigation
-    j/k        Move down/up
-    g/G        Go to top/bottom
-    Ctrl+d/u   Page down/up
-    /          Search projects
-    Enter      Select project
-
-  Actions
-    o          Open project in nvim
-    l          Open lazygit
-    d          Open production URL (Vercel)
-
-  Files
-    r          Edit README.md
-    R          Edit ROADMAP.md
-    p          Edit PLAN.md
-    t          Edit TODO.md
-
-  Chat
-    C          Chat in ~/Projects
-    c          Chat in selected project
-
-  Other
-    Ctrl+r     Refresh all
-    ?          Show this help
-    q/Esc      Back/Quit
-`
-	return help
+	var b strings.Builder
+	b.WriteString("\n  " + i18n.T("Mission Control - Keyboard Shortcuts") + "\n")
+	for _, s := range helpSections {
+		b.WriteString("\n  " + i18n.T(s.title) + "\n")
+		for _, k := range s.keys {
+			b.WriteString(fmt.Sprintf("    %-10s %s\n", k[0], i18n.T(k[1])))
+		}
+	}
+	return b.String()
 }
 
 // =============================================================================
@@ -1648,23 +3750,156 @@ func (m Model) renderHelp(height int) string {
 
 func (m Model) renderDetailView(height int) string {
 	if m.currentProject == nil {
-		return "No project selected\n\nPress 'q' or 'esc' to go back"
+		return i18n.T("No project selected\n\nPress 'q' or 'esc' to go back")
+	}
+
+	switch m.detailTab {
+	case detailLog:
+		return m.renderCommitLog(height)
+	case detailFiles:
+		return m.renderFileList(height)
 	}
 
 	p := m.currentProject
 	var b strings.Builder
 
-	b.WriteString(fmt.Sprintf("\n  Project: %s\n", p.Name))
-	b.WriteString(fmt.Sprintf("  Path: %s\n", p.Path))
-	b.WriteString(fmt.Sprintf("  Type: %s\n", p.Type))
-	b.WriteString(fmt.Sprintf("  State: %s\n", p.VercelState))
-	b.WriteString(fmt.Sprintf("\n  Git: %d staged, %d untracked, %d modified\n", p.Staged, p.Untracked, p.Modified))
-	b.WriteString(fmt.Sprintf("  GitHub: %d issues, %d PRs\n", p.Issues, p.PRs))
+	b.WriteString(m.renderDetailTabs())
+	b.WriteString(i18n.T("\n  Project: %s\n", p.Name))
+	b.WriteString(i18n.T("  Path: %s\n", p.Path))
+	b.WriteString(i18n.T("  Type: %s\n", p.Type))
+	b.WriteString(i18n.T("  State: %s\n", p.VercelState))
+	if p.NetlifyState != "" {
+		b.WriteString(i18n.T("  Netlify: %s\n", p.NetlifyState))
+	}
+	if p.RenderState != "" {
+		b.WriteString(i18n.T("  Render: %s\n", p.RenderState))
+	}
+	if p.RailwayState != "" {
+		b.WriteString(i18n.T("  Railway: %s\n", p.RailwayState))
+	}
+	b.WriteString(m.renderFly(p))
+	b.WriteString(m.renderDeployHistory(p))
+	b.WriteString(i18n.T("\n  Git: %d staged, %d untracked, %d modified\n", p.Staged, p.Untracked, p.Modified))
+	if !p.DirtySince.IsZero() {
+		b.WriteString(i18n.T("  Dirty for %s (oldest uncommitted change: %s)\n",
+			strings.TrimSpace(formatTimeSince(p.DirtySince)), p.DirtySince.Format("Jan 2 15:04")))
+	}
+	b.WriteString(m.renderConflicts(p))
+	b.WriteString(i18n.T("  Branch: %s\n", p.Branch))
+	b.WriteString(i18n.T("  Upstream: %d ahead, %d behind\n", p.Ahead, p.Behind))
+	if r := p.Release; r != nil {
+		b.WriteString(i18n.T("  Release: %s (%s), %d commits since\n", r.Tag, strings.TrimSpace(formatTimeSince(r.Date)), r.Since))
+	}
+	b.WriteString(m.renderPublished(p))
+	b.WriteString(m.renderPackageSummary(p))
+	b.WriteString(m.renderReleasePlan(p))
+	b.WriteString(m.renderReadme(p))
+	b.WriteString(m.renderRemotes(p))
+	if err, ok := m.fetchErrs[p.Name]; ok {
+		b.WriteString(i18n.T("  %s Auto-fetch failed: %s\n", IconX, err))
+	}
+	b.WriteString(m.renderSubmodules(p))
+	b.WriteString(m.renderToolchain(p.Name))
+	b.WriteString(m.renderHealth(p.Name))
+	b.WriteString(m.renderUptime(p.Name))
+	b.WriteString(m.renderWorkers(p.Name))
+	b.WriteString(m.renderMaintenance(p.Name))
+	b.WriteString(m.renderServiceSummary(p.Name))
+	b.WriteString(m.renderActivity(p))
+	b.WriteString(i18n.T("  GitHub: %d issues (i to list), %d PRs (v to review)\n", p.Issues, p.PRs))
+	if p.Assigned > 0 || p.ReviewRequested > 0 {
+		b.WriteString(i18n.T("  Waiting on me: %d issues assigned, %d reviews requested\n", p.Assigned, p.ReviewRequested))
+	}
+	b.WriteString(m.renderSecurity(p))
+	b.WriteString(m.renderSBOM(p))
+	b.WriteString(m.renderCI(p))
+	b.WriteString(m.renderDispatchable(p.Name))
+	b.WriteString(m.renderArtifactSummary(p.Name))
+	b.WriteString(m.renderSizes(p.Name))
+	b.WriteString(m.renderBench(p))
+	b.WriteString(m.renderMilestone(p.Name))
+	b.WriteString(m.renderBoardCounts(p.Name))
+	b.WriteString(m.renderEnvironments(p.Name))
+	b.WriteString(m.renderFlags(p.Name))
+	b.WriteString(m.renderCron(p.Name))
+	b.WriteString(m.renderIncidentSummary(p))
+	b.WriteString(m.renderDigest(p.Name))
+	b.WriteString(m.renderBriefing(p.Name))
+	b.WriteString(m.renderSnippetSummary(p.Name))
+	b.WriteString(m.renderStashes(p.Name))
+	b.WriteString(m.renderWorktrees(p.Name))
+	b.WriteString(m.renderTraffic(p.Name))
+	b.WriteString(m.renderEstimates(p.Name))
+	b.WriteString(m.renderProjectChores(p.Name))
 	b.WriteString("\n  Press 'q' or 'esc' to go back\n")
 
 	return b.String()
 }
 
+// trafficChartDays is the window charted in the detail view
+const trafficChartDays = 30
+
+// renderTraffic renders GitHub traffic trends for the detail view
+func (m Model) renderTraffic(name string) string {
+	t, ok := m.traffic[name]
+	if !ok || len(t.Days) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	views, clones := t.Totals(trafficChartDays)
+	viewSeries, cloneSeries := t.Series(trafficChartDays)
+
+	b.WriteString(fmt.Sprintf("\n  Traffic (%dd): %d views, %d clones  (updated %s ago)\n",
+		trafficChartDays, views, clones, strings.TrimSpace(formatTimeSince(t.FetchedAt))))
+	b.WriteString(fmt.Sprintf("    views  %s\n", RenderSparkline(viewSeries)))
+	b.WriteString(fmt.Sprintf("    clones %s\n", RenderSparkline(cloneSeries)))
+
+	if len(t.Referrers) > 0 {
+		var refs []string
+		for i, r := range t.Referrers {
+			if i == 5 {
+				break
+			}
+			refs = append(refs, fmt.Sprintf("%s %d", r.Source, r.Count))
+		}
+		b.WriteString(fmt.Sprintf("    referrers: %s\n", strings.Join(refs, ", ")))
+	}
+
+	return b.String()
+}
+
+// renderEstimates shows estimate vs actual per task and the project's
+// estimation accuracy
+func (m Model) renderEstimates(name string) string {
+	s, ok := m.estimates[name]
+	if !ok || len(s.Tasks) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	accuracy := "-"
+	if s.Samples > 0 {
+		accuracy = fmt.Sprintf("%.0f%% over %d done (actual %.2fx estimate)", s.Accuracy*100, s.Samples, s.Bias)
+	}
+	b.WriteString(fmt.Sprintf("\n  Estimates: accuracy %s\n", accuracy))
+
+	for i, t := range s.Tasks {
+		if i == 8 {
+			b.WriteString(fmt.Sprintf("    … %d more (mc estimate list %s)\n", len(s.Tasks)-i, name))
+			break
+		}
+		mark := " "
+		if t.Done {
+			mark = IconCheck
+		}
+		b.WriteString(fmt.Sprintf("    %s %-24s %6s est %6s actual\n",
+			mark, truncate(t.Name, 24), formatDuration(t.Estimate), formatDuration(t.Actual)))
+	}
+
+	return b.String()
+}
+
 // =============================================================================
 // EXTERNAL COMMANDS
 // =============================================================================
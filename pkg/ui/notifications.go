@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// notifReasons are the notifications shown unless everything is asked
+// for: being mentioned, asked for a review, or assigned
+var notifReasons = map[string]bool{
+	"mention":          true,
+	"team_mention":     true,
+	"review_requested": true,
+	"assign":           true,
+}
+
+// notification is a forge notification with the project it belongs to
+type notification struct {
+	forge.Notification
+	project string // "" when the repository isn't a local project
+}
+
+// group is what the notification is listed under: its project, else its
+// repository
+func (n notification) group() string {
+	if n.project != "" {
+		return n.project
+	}
+	return n.Repo
+}
+
+// notifView is the notifications panel, across GitHub and the other
+// forges projects live on
+type notifView struct {
+	items   []notification
+	idx     int
+	all     bool            // every reason, not just mentions, reviews, and assignments
+	quiet   map[string]bool // projects in maintenance, held back unless all
+	loading bool
+	err     string
+}
+
+type notificationsMsg struct {
+	items []notification
+	err   error
+}
+
+type notifReadMsg struct {
+	n   forge.Notification
+	err error
+}
+
+// loadNotificationsCmd fetches unread notifications and matches their
+// repositories to projects (name to path)
+func loadNotificationsCmd(projects map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		paths := make([]string, 0, len(projects))
+		repos := make(map[string]string)
+		for name, path := range projects {
+			paths = append(paths, path)
+			if repo := discover.ForgeRepo(path); repo != "" {
+				repos[strings.ToLower(repo)] = name
+			}
+		}
+		ns, err := discover.ListNotifications(paths)
+		if err != nil {
+			return notificationsMsg{err: err}
+		}
+		items := make([]notification, 0, len(ns))
+		for _, n := range ns {
+			items = append(items, notification{Notification: n, project: repos[strings.ToLower(n.Host+"/"+n.Repo)]})
+		}
+		// Local projects first, then other repositories, each newest first
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			if (a.project == "") != (b.project == "") {
+				return a.project != ""
+			}
+			if a.group() != b.group() {
+				return a.group() < b.group()
+			}
+			return a.UpdatedAt.After(b.UpdatedAt)
+		})
+		return notificationsMsg{items: items}
+	}
+}
+
+func markReadCmd(n forge.Notification) tea.Cmd {
+	return func() tea.Msg {
+		return notifReadMsg{n: n, err: discover.MarkNotificationRead(n)}
+	}
+}
+
+// openNotifications shows unread notifications across projects
+func (m Model) openNotifications() (tea.Model, tea.Cmd) {
+	m.notifs = notifView{loading: true, all: m.notifs.all}
+	m.viewMode = NotifView
+	return m, loadNotificationsCmd(m.projectPaths())
+}
+
+// projectPaths maps project names to their paths
+func (m Model) projectPaths() map[string]string {
+	paths := make(map[string]string, len(m.projects))
+	for _, p := range m.projects {
+		paths[p.Name] = p.Path
+	}
+	return paths
+}
+
+// setNotifications stores loaded notifications
+func (m *Model) setNotifications(msg notificationsMsg) {
+	v := &m.notifs
+	v.loading = false
+	if msg.err != nil {
+		v.err = msg.err.Error()
+		return
+	}
+	v.err = ""
+	v.items = msg.items
+	v.quiet = make(map[string]bool)
+	for _, n := range v.items {
+		if n.project != "" && m.inMaintenance(n.project) != nil {
+			v.quiet[n.project] = true
+		}
+	}
+	v.idx = maxInt(min(v.idx, len(v.visible())-1), 0)
+}
+
+// setNotifRead drops a notification once it's marked read
+func (m *Model) setNotifRead(msg notifReadMsg) {
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Mark read failed: %v", msg.err)
+		m.statusMsgTime = time.Now()
+		return
+	}
+	v := &m.notifs
+	for i, n := range v.items {
+		if n.ID == msg.n.ID && n.Host == msg.n.Host {
+			v.items = append(v.items[:i:i], v.items[i+1:]...)
+			break
+		}
+	}
+	v.idx = maxInt(min(v.idx, len(v.visible())-1), 0)
+}
+
+// visible returns the notifications shown: mentions, review requests, and
+// assignments outside maintenance windows, or all of them
+func (v notifView) visible() []notification {
+	if v.all {
+		return v.items
+	}
+	var shown []notification
+	for _, n := range v.items {
+		if notifReasons[n.Reason] && !v.quiet[n.project] {
+			shown = append(shown, n)
+		}
+	}
+	return shown
+}
+
+func (m Model) handleNotificationsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.notifs
+	items := v.visible()
+	last := maxInt(len(items)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "a":
+		v.all = !v.all
+		v.idx = 0
+	case "enter", "w":
+		if v.idx < len(items) {
+			n := items[v.idx]
+			return m, openURLCmd(n.URL, n.Title)
+		}
+	case "y":
+		if v.idx < len(items) {
+			n := items[v.idx]
+			return m, copyToClipboardCmd(n.URL, n.Title+" URL")
+		}
+	case "r":
+		if v.idx < len(items) {
+			n := items[v.idx]
+			if m.dryRun {
+				return m.showPlan("Mark read: "+n.Title, n.ReadStep)
+			}
+			return m, markReadCmd(n.Notification)
+		}
+	case "ctrl+r":
+		v.loading = true
+		return m, loadNotificationsCmd(m.projectPaths())
+	}
+	return m, nil
+}
+
+// notifReason describes why a notification arrived in a word
+func notifReason(reason string) string {
+	switch reason {
+	case "mention", "team_mention":
+		return i18n.T("mention")
+	case "review_requested":
+		return i18n.T("review")
+	case "assign":
+		return i18n.T("assigned")
+	}
+	return strings.ReplaceAll(reason, "_", " ")
+}
+
+// renderNotifications lists notifications under a heading per project
+func (m Model) renderNotifications(height int) string {
+	v := m.notifs
+	items := v.visible()
+	var b strings.Builder
+
+	scope := i18n.T("mentions, reviews, assignments")
+	if v.all {
+		scope = i18n.T("all")
+	}
+	b.WriteString(i18n.T("\n  %s Notifications — %d unread, %s  (enter/w open, r mark read, a all/relevant, y copy URL, ctrl+r reload, esc back)\n\n",
+		IconGitHub, len(v.items), scope))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	if len(items) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(i18n.T("  Loading notifications...\n"))
+		case v.err != "":
+		case len(v.items) > 0:
+			b.WriteString(i18n.T("  No mentions, review requests, or assignments (a shows all)\n"))
+		default:
+			b.WriteString(i18n.T("  No unread notifications\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	// Lay out headings and items together, then window around the
+	// selection
+	type row struct {
+		text string
+		item int // index into items, -1 for a heading
+	}
+	var rows []row
+	selected := 0
+	for i, n := range items {
+		if i == 0 || n.group() != items[i-1].group() {
+			rows = append(rows, row{text: "  " + n.group(), item: -1})
+		}
+		if i == v.idx {
+			selected = len(rows)
+		}
+		line := fmt.Sprintf("    %-11s %-11s %4s  %s", notifReason(n.Reason), n.Type,
+			strings.TrimSpace(formatTimeSince(n.UpdatedAt)), n.Title)
+		rows = append(rows, row{text: line, item: i})
+	}
+
+	shown := maxInt(height-4, 1)
+	start := maxInt(selected-shown+1, 0)
+	for i := start; i < len(rows) && i < start+shown; i++ {
+		r := rows[i]
+		line := truncate(r.text, maxInt(m.width-4, 20))
+		if r.item == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
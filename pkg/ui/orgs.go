@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// orgsView sums project stats by GitHub owner, or by client
+type orgsView struct {
+	byClient bool
+	clients  map[string]string // project name -> client, read when opened
+	cfg      *config.Config    // for the Gitea instances, read when opened
+	expanded map[string]bool
+	idx      int
+}
+
+// orgGroup is one owner's (or client's) projects and their totals
+type orgGroup struct {
+	name     string
+	projects []*Project
+	issues   int
+	prs      int
+	failing  int // projects with CI failing on the default branch
+	dirty    int // projects with uncommitted changes
+}
+
+// projectOwner returns the owner (GitHub user or organization, GitLab
+// group, Bitbucket workspace, Azure DevOps organization, sourcehut
+// ~user) of the forge repository a project pushes to: the upstream
+// remote's, then origin's, then any other's
+func projectOwner(p *Project, cfg *config.Config) string {
+	rank := func(r discover.Remote) int {
+		switch {
+		case r.Upstream:
+			return 0
+		case r.Name == "origin":
+			return 1
+		}
+		return 2
+	}
+	owner, best := "", 3
+	for _, r := range p.Remotes {
+		if rank(r) >= best {
+			continue
+		}
+		if repo, ok := forge.ParseRemote(r.URL, cfg); ok {
+			owner, best = repo.Owner, rank(r)
+		}
+	}
+	return owner
+}
+
+// groups sums the projects by owner or client, busiest first; projects
+// with neither land in a group of their own at the end
+func (v orgsView) groups(projects []Project) []orgGroup {
+	none := i18n.T("(no hosted remote)")
+	if v.byClient {
+		none = i18n.T("(no client)")
+	}
+	byName := make(map[string]*orgGroup)
+	for i := range projects {
+		p := &projects[i]
+		name := projectOwner(p, v.cfg)
+		if v.byClient {
+			name = v.clients[p.Name]
+		}
+		if name == "" {
+			name = none
+		}
+		g := byName[name]
+		if g == nil {
+			g = &orgGroup{name: name}
+			byName[name] = g
+		}
+		g.projects = append(g.projects, p)
+		g.issues += p.Issues
+		g.prs += p.PRs
+		if p.CI.State() == discover.CIFailing {
+			g.failing++
+		}
+		if p.Staged+p.Modified+p.Untracked > 0 {
+			g.dirty++
+		}
+	}
+
+	groups := make([]orgGroup, 0, len(byName))
+	for _, g := range byName {
+		sort.Slice(g.projects, func(i, j int) bool { return g.projects[i].Name < g.projects[j].Name })
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if (a.name == none) != (b.name == none) {
+			return b.name == none
+		}
+		if len(a.projects) != len(b.projects) {
+			return len(a.projects) > len(b.projects)
+		}
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	})
+	return groups
+}
+
+// openOrgs shows project stats summed by GitHub owner
+func (m Model) openOrgs() (tea.Model, tea.Cmd) {
+	m.orgs.clients = make(map[string]string)
+	cfg, err := config.Load()
+	m.orgs.cfg = cfg
+	if err == nil {
+		for name, pc := range cfg.Projects {
+			if pc != nil && pc.Client != nil {
+				m.orgs.clients[name] = pc.Client.Name
+			}
+		}
+	}
+	if m.orgs.expanded == nil {
+		m.orgs.expanded = make(map[string]bool)
+	}
+	m.viewMode = OrgsView
+	return m, nil
+}
+
+func (m Model) handleOrgsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.orgs
+	groups := v.groups(m.projects)
+	last := maxInt(len(groups)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "enter", " ":
+		if v.idx < len(groups) {
+			name := groups[v.idx].name
+			v.expanded[name] = !v.expanded[name]
+		}
+	case "c":
+		v.byClient = !v.byClient
+		v.idx = 0
+	}
+	return m, nil
+}
+
+// renderOrgs lists each owner's totals, with an expanded owner's
+// projects beneath it
+func (m Model) renderOrgs(height int) string {
+	v := m.orgs
+	var b strings.Builder
+
+	by := i18n.T("owner")
+	if v.byClient {
+		by = i18n.T("client")
+	}
+	b.WriteString(i18n.T("\n  %s Projects by %s  (enter expand, c owner/client, esc back)\n\n", IconGitHub, by))
+
+	groups := v.groups(m.projects)
+	if len(groups) == 0 {
+		b.WriteString(i18n.T("  No projects yet.\n"))
+		return padLines(b.String(), height)
+	}
+
+	header := fmt.Sprintf("    %-28s %8s %7s %5s %10s %6s", strings.ToUpper(by[:1])+by[1:], "Projects", "Issues", "PRs", "Failing CI", "Dirty")
+	b.WriteString(header + "\n")
+	var lines []string
+	selected := 0
+	for i, g := range groups {
+		marker := "▸"
+		if v.expanded[g.name] {
+			marker = "▾"
+		}
+		line := fmt.Sprintf("  %s %-28s %8d %7d %5d %10s %6s", marker, truncate(g.name, 28), len(g.projects), g.issues, g.prs,
+			orgCount(g.failing), orgCount(g.dirty))
+		if i == v.idx {
+			selected = len(lines)
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		lines = append(lines, line)
+		if !v.expanded[g.name] {
+			continue
+		}
+		for _, p := range g.projects {
+			failing, dirty := 0, 0
+			if p.CI.State() == discover.CIFailing {
+				failing = 1
+			}
+			if p.Staged+p.Modified+p.Untracked > 0 {
+				dirty = 1
+			}
+			lines = append(lines, fmt.Sprintf("      %-26s %8s %7d %5d %10s %6s", truncate(p.Name, 26), "", p.Issues, p.PRs, orgCount(failing), orgCount(dirty)))
+		}
+	}
+
+	shown := maxInt(height-5, 1)
+	start := maxInt(selected-shown+1, 0)
+	for i := start; i < len(lines) && i < start+shown; i++ {
+		b.WriteString(lines[i] + "\n")
+	}
+	return padLines(b.String(), height)
+}
+
+// orgCount renders a count, "-" at zero so problems stand out
+func orgCount(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
@@ -0,0 +1,323 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// packagesView is a monorepo's packages with their checks and what
+// changed in each since its last release
+type packagesView struct {
+	project string
+	path    string
+	idx     int
+	loading bool
+	err     string
+	running map[string]bool // package + "\x00" + check
+}
+
+type packagesMsg struct {
+	project  string
+	packages []discover.Package
+	results  map[string]map[string]discover.CheckResult
+	flaky    []discover.FlakyTest
+	err      error
+}
+
+type packageCheckMsg struct {
+	project string
+	result  discover.CheckResult
+	flaky   []discover.FlakyTest // reread after a test check
+	err     error
+}
+
+// loadPackagesCmd lists a project's packages, if it's a monorepo, with
+// the last result of each check and its flaky tests
+func loadPackagesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		pkgs, err := discover.Packages(path)
+		return packagesMsg{project: name, packages: pkgs, results: discover.PackageCheckResults(path), flaky: discover.FlakyTests(path), err: err}
+	}
+}
+
+// packageCheckCmd runs one check of one package
+func packageCheckCmd(name, path string, p discover.Package, check string) tea.Cmd {
+	return func() tea.Msg {
+		r, err := discover.RunPackageCheck(path, p, check)
+		msg := packageCheckMsg{project: name, result: r, err: err}
+		if check == discover.CheckTest {
+			msg.flaky = discover.FlakyTests(path)
+		}
+		return msg
+	}
+}
+
+// openPackages lists the selected project's packages
+func (m Model) openPackages(p Project) (tea.Model, tea.Cmd) {
+	m.pkgView = packagesView{project: p.Name, path: p.Path, loading: true, running: make(map[string]bool)}
+	m.viewMode = PackagesView
+	return m, tea.Batch(loadPackagesCmd(p.Name, p.Path), loadReleasePlanCmd(p.Name, p.Path))
+}
+
+// setPackages keeps a project's packages, for the detail view and the
+// packages view
+func (m Model) setPackages(msg packagesMsg) Model {
+	if m.packages == nil {
+		m.packages = make(map[string][]discover.Package)
+		m.packageResults = make(map[string]map[string]map[string]discover.CheckResult)
+		m.flakyTests = make(map[string][]discover.FlakyTest)
+	}
+	m.packages[msg.project] = msg.packages
+	m.packageResults[msg.project] = msg.results
+	m.flakyTests[msg.project] = msg.flaky
+	if v := &m.pkgView; v.project == msg.project {
+		v.loading = false
+		v.err = ""
+		if msg.err != nil {
+			v.err = msg.err.Error()
+		}
+		v.idx = maxInt(min(v.idx, len(msg.packages)-1), 0)
+	}
+	return m
+}
+
+// setPackageCheck records a finished check
+func (m Model) setPackageCheck(msg packageCheckMsg) Model {
+	r := msg.result
+	delete(m.pkgView.running, r.Package+"\x00"+r.Check)
+	if msg.err != nil && r.Package == "" {
+		m.pkgView.err = msg.err.Error()
+		return m
+	}
+	results := m.packageResults[msg.project]
+	if results == nil {
+		results = make(map[string]map[string]discover.CheckResult)
+		if m.packageResults == nil {
+			m.packageResults = make(map[string]map[string]map[string]discover.CheckResult)
+		}
+		m.packageResults[msg.project] = results
+	}
+	if results[r.Package] == nil {
+		results[r.Package] = make(map[string]discover.CheckResult)
+	}
+	results[r.Package][r.Check] = r
+	if r.Check == discover.CheckTest {
+		if m.flakyTests == nil {
+			m.flakyTests = make(map[string][]discover.FlakyTest)
+		}
+		m.flakyTests[msg.project] = msg.flaky
+	}
+	return m
+}
+
+// runPackageChecks runs the checks of the given packages one after
+// another, so builds don't compete for the machine
+func (m Model) runPackageChecks(pkgs []discover.Package) (tea.Model, tea.Cmd) {
+	v := &m.pkgView
+	if m.dryRun {
+		var steps []string
+		for _, p := range pkgs {
+			for _, check := range p.Checks {
+				dir, args := discover.PackageCheckCommand(v.path, p, check)
+				steps = append(steps, shellCommand("cd", dir)+" && "+shellCommand(args[0], args[1:]...))
+			}
+		}
+		return m.showPlan("Run package checks in "+v.project, steps...)
+	}
+	var cmds []tea.Cmd
+	for _, p := range pkgs {
+		for _, check := range p.Checks {
+			key := p.Name + "\x00" + check
+			if v.running[key] {
+				continue
+			}
+			v.running[key] = true
+			cmds = append(cmds, packageCheckCmd(v.project, v.path, p, check))
+		}
+	}
+	return m, tea.Sequence(cmds...)
+}
+
+func (m Model) handlePackagesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.pkgView
+	pkgs := m.packages[v.project]
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, maxInt(len(pkgs)-1, 0))
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = maxInt(len(pkgs)-1, 0)
+	case "r":
+		if v.idx < len(pkgs) {
+			return m.runPackageChecks(pkgs[v.idx : v.idx+1])
+		}
+	case "R":
+		// Everything that would go out in the next release
+		var changed []discover.Package
+		for _, p := range pkgs {
+			if p.Changed > 0 {
+				changed = append(changed, p)
+			}
+		}
+		return m.runPackageChecks(changed)
+	case "v":
+		return m.startReleaseFlow(discover.ReleaseStepVersion)
+	case "P":
+		return m.startReleaseFlow(discover.ReleaseStepPublish)
+	case "o":
+		if v.idx < len(pkgs) {
+			return m, openInEditorCmd(expandPath(v.path)+"/"+pkgs[v.idx].Dir, "")
+		}
+	case "ctrl+r":
+		v.loading = true
+		return m, tea.Batch(loadPackagesCmd(v.project, v.path), loadReleasePlanCmd(v.project, v.path))
+	}
+	return m, nil
+}
+
+// checkCell shows one check's last result in the packages table
+func (m Model) checkCell(p discover.Package, results map[string]discover.CheckResult, check string) string {
+	has := false
+	for _, c := range p.Checks {
+		has = has || c == check
+	}
+	switch r, ran := results[check]; {
+	case m.pkgView.running[p.Name+"\x00"+check]:
+		return "…"
+	case !has:
+		return "-"
+	case !ran:
+		return "?"
+	case r.OK:
+		return IconCheck
+	}
+	return IconX
+}
+
+// renderPackages lists a monorepo's packages: version, last release,
+// commits since, and the last build, test, and lint results
+func (m Model) renderPackages(height int) string {
+	v := m.pkgView
+	pkgs := m.packages[v.project]
+	results := m.packageResults[v.project]
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  Packages in %s  (r runs checks, R on every changed package, o opens, ctrl+r reload, esc back)\n\n", v.project))
+	if summary := m.releasePlanSummary(v.project); summary != "" {
+		b.WriteString(i18n.T("  Release plan: %s  (v versions, P publishes)\n\n", summary))
+	}
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	if len(pkgs) == 0 {
+		if v.loading {
+			b.WriteString(i18n.T("  Reading workspaces...\n"))
+		} else if v.err == "" {
+			b.WriteString(i18n.T("  Not a monorepo: no npm, pnpm, yarn, or bun workspaces, go.work, or Cargo workspace with two or more packages\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("    %-30s %-10s %-24s %-14s %-5s %-5s %s\n",
+		i18n.T("PACKAGE"), i18n.T("VERSION"), i18n.T("RELEASED AS"), i18n.T("CHANGES"), i18n.T("BUILD"), i18n.T("TEST"), i18n.T("LINT")))
+	rows := maxInt(height-16, 1)
+	start := maxInt(v.idx-rows+1, 0)
+	for i := start; i < len(pkgs) && i < start+rows; i++ {
+		p := pkgs[i]
+		release := p.Release
+		if release == "" {
+			release = i18n.T("never")
+		}
+		changes := "-"
+		if p.Changed > 0 {
+			changes = fmt.Sprintf("%d", p.Changed)
+		}
+		if bump := m.pendingBump(v.project, p.Name); bump != "" {
+			changes += " -> " + bump
+		}
+		line := fmt.Sprintf("    %-30s %-10s %-24s %-14s %-5s %-5s %s", truncate(p.Name, 30), truncate(p.Version, 10), truncate(release, 24), changes,
+			m.checkCell(p, results[p.Name], discover.CheckBuild), m.checkCell(p, results[p.Name], discover.CheckTest), m.checkCell(p, results[p.Name], discover.CheckLint))
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		} else if p.Changed > 0 {
+			line = driftStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	// The selected package's failures
+	if v.idx < len(pkgs) {
+		p := pkgs[v.idx]
+		b.WriteString(fmt.Sprintf("\n    %s (%s)\n", p.Dir, p.Ecosystem))
+		for _, check := range discover.PackageChecks {
+			r, ok := results[p.Name][check]
+			if !ok || r.OK {
+				continue
+			}
+			b.WriteString(i18n.T("    %s %s failed at %s, %s ago:\n", IconX, check, r.Commit, strings.TrimSpace(formatTimeSince(r.At))))
+			lines := strings.Split(r.Output, "\n")
+			for _, l := range lines[maxInt(len(lines)-6, 0):] {
+				b.WriteString("      " + truncate(l, maxInt(m.width-10, 20)) + "\n")
+			}
+		}
+	}
+	b.WriteString(m.renderFlakyTests(v.project))
+	return padLines(b.String(), height)
+}
+
+// renderFlakyTests lists the project's flakiest tests: those that both
+// passed and failed in test checks of the same code
+func (m Model) renderFlakyTests(project string) string {
+	flaky := m.flakyTests[project]
+	if len(flaky) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("\n    Flaky tests (%d)\n", len(flaky)))
+	const shown = 5
+	for _, f := range flaky[:min(len(flaky), shown)] {
+		line := i18n.T("%s %s: flipped %dx on unchanged code, failed %d of %d runs, last %s ago",
+			f.Package, f.Test, f.Flips, f.Failures, f.Runs, strings.TrimSpace(formatTimeSince(f.Last)))
+		b.WriteString("    " + driftStyle.Render(truncate(line, maxInt(m.width-8, 20))) + "\n")
+	}
+	if more := len(flaky) - shown; more > 0 {
+		b.WriteString(fmt.Sprintf("    … %d more\n", more))
+	}
+	return b.String()
+}
+
+// renderPackageSummary sums up a monorepo's packages for the detail view
+func (m Model) renderPackageSummary(p *Project) string {
+	pkgs := m.packages[p.Name]
+	if len(pkgs) == 0 {
+		return ""
+	}
+	changed, failing := 0, 0
+	for _, pkg := range pkgs {
+		if pkg.Changed > 0 {
+			changed++
+		}
+		for _, r := range m.packageResults[p.Name][pkg.Name] {
+			if !r.OK {
+				failing++
+				break
+			}
+		}
+	}
+	line := i18n.T("  Packages: %d, %d changed since their last release (J to list)", len(pkgs), changed)
+	if failing > 0 {
+		line += " " + driftStyle.Render(i18n.T("%s %d with failing checks", IconX, failing))
+	}
+	if n := len(m.flakyTests[p.Name]); n > 0 {
+		line += " " + driftStyle.Render(i18n.T("%d flaky tests", n))
+	}
+	return line + "\n"
+}
@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/cost"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+	"github.com/michaelmonetized/mission-control/pkg/timelog"
+)
+
+// idleAfter is how long without a commit before a project that's still
+// billing is flagged as idle
+const idleAfter = 90 * 24 * time.Hour
+
+// portfolioRow is one project's revenue vs cost and effort for the month
+type portfolioRow struct {
+	name      string
+	cents     int
+	costCents int
+	estimate  bool // some of the cost is estimated
+	tracked   time.Duration
+	idle      time.Time // last commit, when the project is billing but idle
+	errors    []string
+}
+
+type portfolioMsg struct {
+	month time.Time
+	rows  []portfolioRow
+	err   error
+}
+
+type timerMsg struct {
+	active *timelog.Entry
+	status string
+}
+
+// loadPortfolioCmd gathers this month's revenue, cloud spend, and tracked
+// time per project
+func loadPortfolioCmd(projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		cfg, err := config.Load()
+		if err != nil {
+			return portfolioMsg{month: now, err: err}
+		}
+
+		entries, _ := timelog.Load()
+		from, to := timelog.MonthRange(now)
+		tracked := timelog.Total(entries, from, to)
+
+		var rows []portfolioRow
+		for _, p := range projects {
+			row := portfolioRow{name: p.Name, tracked: tracked[p.Name]}
+			if report, _ := revenue.ForProject(cfg, p.Name, now); report != nil {
+				row.cents = report.TotalCents
+				for _, src := range report.Sources {
+					if src.Err != "" {
+						row.errors = append(row.errors, src.Kind+": "+src.Err)
+					}
+				}
+			}
+			if report, _ := cost.ForProject(cfg, p.Name, now); report != nil {
+				row.costCents = report.TotalCents
+				row.estimate = report.Estimated()
+				for _, src := range report.Sources {
+					if src.Err != "" {
+						row.errors = append(row.errors, src.Kind+": "+src.Err)
+					}
+				}
+			}
+			if row.costCents > 0 && !p.LastCommit.IsZero() && time.Since(p.LastCommit) > idleAfter {
+				row.idle = p.LastCommit
+			}
+			pc := cfg.Project(p.Name)
+			if row.cents > 0 || row.costCents > 0 || row.tracked > 0 || len(pc.Revenue) > 0 || len(pc.Costs) > 0 {
+				rows = append(rows, row)
+			}
+		}
+
+		sort.Slice(rows, func(i, j int) bool {
+			if rows[i].cents != rows[j].cents {
+				return rows[i].cents > rows[j].cents
+			}
+			if rows[i].tracked != rows[j].tracked {
+				return rows[i].tracked > rows[j].tracked
+			}
+			return rows[i].costCents > rows[j].costCents
+		})
+
+		return portfolioMsg{month: now, rows: rows}
+	}
+}
+
+// toggleTimerCmd starts a timer on project, or stops it if it's running
+func toggleTimerCmd(project string) tea.Cmd {
+	return func() tea.Msg {
+		active, err := timelog.Active()
+		if err != nil {
+			return timerMsg{status: "Timer error: " + err.Error()}
+		}
+
+		if active != nil && active.Project == project {
+			stopped, err := timelog.Stop()
+			if err != nil {
+				return timerMsg{status: "Timer error: " + err.Error()}
+			}
+			return timerMsg{status: fmt.Sprintf("Stopped timer for %s (%s)", project, formatDuration(stopped.Duration()))}
+		}
+
+		if err := timelog.Start(project); err != nil {
+			return timerMsg{active: active, status: "Timer error: " + err.Error()}
+		}
+		active, _ = timelog.Active()
+		return timerMsg{active: active, status: "Tracking time on " + project}
+	}
+}
+
+// loadTimerCmd reads the running timer (if any) on startup
+func loadTimerCmd() tea.Msg {
+	active, _ := timelog.Active()
+	return timerMsg{active: active}
+}
+
+// formatDuration renders a duration as "3h05m"
+func formatDuration(d time.Duration) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	return fmt.Sprintf("%dh%02dm", h, m)
+}
+
+// portfolioRows is how many project rows fit under the P&L's header
+// and above its total
+func portfolioRows(height int) int {
+	return maxInt(height-6, 1)
+}
+
+// handlePortfolioKey scrolls the P&L and reloads it. Every other key is
+// swallowed: list bindings would act on the project hidden behind it.
+func (m Model) handlePortfolioKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := maxInt(len(m.portfolio)-portfolioRows(m.getListHeight()), 0)
+	switch msg.String() {
+	case "j", "down":
+		m.portfolioScroll = min(m.portfolioScroll+1, last)
+	case "k", "up":
+		m.portfolioScroll = maxInt(m.portfolioScroll-1, 0)
+	case "g":
+		m.portfolioScroll = 0
+	case "G":
+		m.portfolioScroll = last
+	case "ctrl+r":
+		m.portfolioLoading = true
+		return m, loadPortfolioCmd(m.projects)
+	}
+	return m, nil
+}
+
+// renderPortfolio renders the revenue vs effort (P&L) table
+func (m Model) renderPortfolio(height int) string {
+	var b strings.Builder
+
+	month := m.portfolioMonth.Format("January 2006")
+	b.WriteString(fmt.Sprintf("\n  %s Portfolio P&L — %s  (j/k scroll, ctrl+r reload, esc back)\n\n", IconCoins, month))
+
+	if m.portfolioLoading {
+		b.WriteString("  Loading revenue...\n")
+		return padLines(b.String(), height)
+	}
+	if m.portfolioErr != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, m.portfolioErr))
+	}
+	if len(m.portfolio) == 0 {
+		b.WriteString("  No revenue sources, cloud costs, or tracked time this month.\n")
+		b.WriteString("  Attach sources with: mc revenue add <project> <github_sponsors|stripe|gumroad> <id>\n")
+		b.WriteString("  Attach costs with: mc cost add <project> <vercel|fly|aws> <id>\n")
+		b.WriteString("  Track time with T on a project row.\n")
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("  %-24s %12s %10s %10s %10s %10s\n", "Project", "Revenue", "Cost", "Net", "Tracked", "$/hour"))
+
+	var totalCents, totalCost int
+	var totalTracked time.Duration
+	for _, row := range m.portfolio {
+		totalCents += row.cents
+		totalCost += row.costCents
+		totalTracked += row.tracked
+	}
+	rows := portfolioRows(height)
+	start := min(m.portfolioScroll, maxInt(len(m.portfolio)-rows, 0))
+	for _, row := range m.portfolio[start:min(start+rows, len(m.portfolio))] {
+		line := fmt.Sprintf("  %-24s %12s %10s %10s %10s %10s",
+			truncate(row.name, 24), revenue.FormatCents(row.cents), costLabel(row.costCents, row.estimate),
+			netLabel(row.cents-row.costCents), formatDuration(row.tracked), hourlyRate(row.cents, row.tracked))
+		if !row.idle.IsZero() {
+			line += fmt.Sprintf("  %s idle, last commit %s ago", IconConflict, strings.TrimSpace(formatTimeSince(row.idle)))
+		}
+		if len(row.errors) > 0 {
+			line += "  " + IconX + " " + strings.Join(row.errors, "; ")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString(fmt.Sprintf("  %-24s %12s %10s %10s %10s %10s\n", "Total",
+		revenue.FormatCents(totalCents), costLabel(totalCost, false), netLabel(totalCents-totalCost),
+		formatDuration(totalTracked), hourlyRate(totalCents, totalTracked)))
+
+	return padLines(b.String(), height)
+}
+
+// costLabel renders spend, marking estimates with "~"
+func costLabel(cents int, estimate bool) string {
+	if estimate {
+		return "~" + revenue.FormatCents(cents)
+	}
+	return revenue.FormatCents(cents)
+}
+
+// netLabel renders revenue less cost, with a sign when it's a loss
+func netLabel(cents int) string {
+	if cents < 0 {
+		return "-" + revenue.FormatCents(-cents)
+	}
+	return revenue.FormatCents(cents)
+}
+
+// hourlyRate renders revenue per tracked hour
+func hourlyRate(cents int, tracked time.Duration) string {
+	if tracked < time.Minute {
+		return "-"
+	}
+	return revenue.FormatCents(int(float64(cents) / tracked.Hours()))
+}
+
+// padLines pads (or trims) rendered content to exactly height lines
+func padLines(s string, height int) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/ports"
+)
+
+// portsView is the ports-in-use panel
+type portsView struct {
+	rows    []ports.Row
+	idx     int
+	loading bool
+	err     string
+}
+
+type portsMsg struct {
+	rows []ports.Row
+	err  error
+}
+
+// portCheckMsg carries the conflicts found before starting services
+type portCheckMsg struct {
+	project   string
+	op        string
+	defs      []config.Service
+	conflicts []ports.Conflict
+}
+
+// projectDirs maps project names to their directories, for attributing
+// listening processes
+func (m Model) projectDirs() map[string]string {
+	dirs := make(map[string]string, len(m.projects))
+	for _, p := range m.projects {
+		dirs[p.Name] = expandPath(p.Path)
+	}
+	return dirs
+}
+
+func loadPortsCmd(dirs map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		cfg, _ := config.Load()
+		listeners, err := ports.Listening(dirs)
+		return portsMsg{rows: ports.Table(ports.Claims(cfg), listeners), err: err}
+	}
+}
+
+// checkPortsCmd looks for ports the services would collide on before
+// they are started
+func checkPortsCmd(dirs map[string]string, project, op string, defs []config.Service) tea.Cmd {
+	return func() tea.Msg {
+		cfg, _ := config.Load()
+		listeners, _ := ports.Listening(dirs)
+		return portCheckMsg{project: project, op: op, defs: defs,
+			conflicts: ports.Check(project, defs, ports.Claims(cfg), listeners)}
+	}
+}
+
+// openPorts shows which ports are claimed and which are in use
+func (m Model) openPorts() (tea.Model, tea.Cmd) {
+	m.portsView = portsView{loading: true}
+	m.viewMode = PortsView
+	return m, loadPortsCmd(m.projectDirs())
+}
+
+// handlePortCheck starts services once their ports are checked. A port
+// something else is listening on holds the start until it's asked for
+// again; a port another stopped project claims is only mentioned.
+func (m Model) handlePortCheck(msg portCheckMsg) (tea.Model, tea.Cmd) {
+	v := &m.svcView
+	if v.project != msg.project {
+		return m, nil
+	}
+	var taken, claimed []string
+	for _, c := range msg.conflicts {
+		if c.Listener != nil {
+			taken = append(taken, c.String())
+		} else {
+			claimed = append(claimed, c.String())
+		}
+	}
+	if len(taken) > 0 {
+		v.portsChecked = true
+		m.statusMsg = i18n.T("%s %s; press again to start anyway", IconX, strings.Join(taken, "; "))
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	if len(claimed) > 0 {
+		m.statusMsg = i18n.T("Note: %s", strings.Join(claimed, "; "))
+		m.statusMsgTime = time.Now()
+	}
+	return m, serviceCmd(m.svcs, msg.op, v.project, v.path, msg.defs)
+}
+
+func (m Model) handlePortsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.portsView
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, maxInt(len(v.rows)-1, 0))
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = maxInt(len(v.rows)-1, 0)
+	case "ctrl+r":
+		v.loading = true
+		return m, loadPortsCmd(m.projectDirs())
+	}
+	return m, nil
+}
+
+// renderPorts lists each claimed or listening port with who wants it and
+// who has it, flagging collisions
+func (m Model) renderPorts(height int) string {
+	v := m.portsView
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  Ports in use  (claims from services in config.json; ctrl+r reload, esc back)\n\n"))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	if len(v.rows) == 0 {
+		if v.loading {
+			b.WriteString(i18n.T("  Checking ports...\n"))
+		} else if v.err == "" {
+			b.WriteString(i18n.T("  No ports claimed or listening\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("      %-6s %-28s %s\n", i18n.T("PORT"), i18n.T("CLAIMED BY"), i18n.T("LISTENING")))
+	rows := maxInt(height-6, 1)
+	start := maxInt(v.idx-rows+1, 0)
+	for i := start; i < len(v.rows) && i < start+rows; i++ {
+		r := v.rows[i]
+		mark := " "
+		if r.Conflict() {
+			mark = IconX
+		}
+
+		var claims []string
+		for _, c := range r.Claims {
+			claims = append(claims, c.Project+"/"+c.Service)
+		}
+		claimed := strings.Join(claims, ", ")
+		if claimed == "" {
+			claimed = "-"
+		}
+
+		var listeners []string
+		for _, l := range r.Listeners {
+			listeners = append(listeners, l.String())
+		}
+		listening := strings.Join(listeners, ", ")
+		if listening == "" {
+			listening = i18n.T("free")
+		}
+
+		line := fmt.Sprintf("  %s   %-6d %-28s %s", mark, r.Port, truncate(claimed, 28), listening)
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/priority"
+	"github.com/michaelmonetized/mission-control/pkg/revenue"
+)
+
+// prioritiesView is the "work on these next" ranking
+type prioritiesView struct {
+	ranking *priority.Ranking
+	idx     int
+	loading bool
+	err     string
+}
+
+type prioritiesMsg struct {
+	ranking *priority.Ranking
+	err     error
+}
+
+// loadPrioritiesCmd reads the cached ranking
+func loadPrioritiesCmd() tea.Msg {
+	r, err := priority.Load()
+	return prioritiesMsg{ranking: r, err: err}
+}
+
+// healthScore rates a project 0-100 from what the list already knows,
+// saying what took points off
+func (m Model) healthScore(p Project) (int, []string) {
+	score := 100
+	var problems []string
+	dock := func(points int, problem string) {
+		score -= points
+		problems = append(problems, problem)
+	}
+	if _, ok := m.incidents[p.Name]; ok {
+		dock(40, "open incident")
+	}
+	if p.CI.State() == discover.CIFailing {
+		dock(30, "CI failing")
+	}
+	if p.deployFailed() {
+		dock(20, "deploy failed")
+	}
+	if p.Security != nil && p.Security.Total() > 0 {
+		dock(min(10*p.Security.Total(), 30), fmt.Sprintf("%d security alerts", p.Security.Total()))
+	}
+	if p.Operation != "" {
+		dock(20, p.Operation+" in progress")
+	}
+	if p.Behind > 0 {
+		dock(10, fmt.Sprintf("%d behind origin", p.Behind))
+	}
+	if !p.DirtySince.IsZero() && time.Since(p.DirtySince) > 7*24*time.Hour {
+		dock(10, "uncommitted for "+strings.TrimSpace(formatTimeSince(p.DirtySince)))
+	}
+	return max(score, 0), problems
+}
+
+// computePrioritiesCmd gathers every project's signals, ranks them, and
+// caches the ranking. Revenue and issue labels are fetched; the rest is
+// what the list already shows.
+func (m Model) computePrioritiesCmd() tea.Cmd {
+	now := time.Now()
+	signals := make([]priority.Signals, len(m.projects))
+	for i, p := range m.projects {
+		s := priority.Signals{Project: p.Name}
+		s.Health, s.Problems = m.healthScore(p)
+		signals[i] = s
+	}
+	for _, d := range m.chores {
+		for i := range signals {
+			if signals[i].Project == d.Project {
+				signals[i].Deadlines = append(signals[i].Deadlines, priority.Deadline{Name: d.Chore.Name, Due: d.Due})
+			}
+		}
+	}
+	projects := m.projects
+
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil {
+			return prioritiesMsg{err: err}
+		}
+		for i, p := range projects {
+			s := &signals[i]
+			pc := cfg.Project(p.Name)
+			for _, d := range pc.Deadlines {
+				if due, err := time.ParseInLocation("2006-01-02", d.Date, time.Local); err == nil {
+					s.Deadlines = append(s.Deadlines, priority.Deadline{Name: d.Name, Due: due})
+				}
+			}
+			if len(pc.Revenue) > 0 {
+				if report, _ := revenue.ForProject(cfg, p.Name, now); report != nil {
+					s.RevenueCents = report.TotalCents
+				}
+			}
+			if p.Issues > 0 {
+				scheduleGitHub(p.Name, func() {
+					issues, _ := discover.ListIssues(p.Path)
+					for _, is := range issues {
+						switch priority.IssueSeverity(is.LabelNames()) {
+						case "critical":
+							s.Critical++
+						case "bug":
+							s.Bugs++
+						default:
+							s.Other++
+						}
+					}
+				})
+			}
+		}
+
+		r := priority.Compute(signals, cfg.Priority, now)
+		return prioritiesMsg{ranking: r, err: priority.Save(r)}
+	}
+}
+
+// openPriorities shows the ranking, recomputing it when it's from before
+// today
+func (m Model) openPriorities() (tea.Model, tea.Cmd) {
+	m.viewMode = PrioritiesView
+	if !m.prio.ranking.Stale(time.Now()) {
+		return m, nil
+	}
+	m.prio.loading = true
+	return m, m.computePrioritiesCmd()
+}
+
+// setPriorities stores a loaded or computed ranking. A stale cached one is
+// shown while today's is computed, once there are projects to rank.
+func (m Model) setPriorities(msg prioritiesMsg) (tea.Model, tea.Cmd) {
+	v := &m.prio
+	v.loading = false
+	v.err = ""
+	if msg.err != nil {
+		v.err = msg.err.Error()
+	}
+	if msg.ranking != nil {
+		v.ranking = msg.ranking
+		v.idx = maxInt(min(v.idx, len(v.ranking.Ranks)-1), 0)
+	}
+	return m, nil
+}
+
+// refreshPriorities recomputes a stale ranking while it's on screen, e.g.
+// when mc starts on it and the GitHub status has come in
+func (m Model) refreshPriorities() (tea.Model, tea.Cmd) {
+	if m.viewMode != PrioritiesView || m.prio.loading || len(m.projects) == 0 || !m.prio.ranking.Stale(time.Now()) {
+		return m, nil
+	}
+	m.prio.loading = true
+	return m, m.computePrioritiesCmd()
+}
+
+func (m Model) handlePrioritiesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.prio
+	last := 0
+	if v.ranking != nil {
+		last = maxInt(len(v.ranking.Ranks)-1, 0)
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "enter":
+		if v.ranking == nil || v.idx >= len(v.ranking.Ranks) {
+			return m, nil
+		}
+		name := v.ranking.Ranks[v.idx].Project
+		if m.getProjectByName(name) == nil {
+			return m, nil
+		}
+		m.searchInput.SetValue("")
+		m.filterMode = FilterNone
+		m.syncFiltered()
+		for i, p := range m.filtered {
+			if p.Name == name {
+				m.selectedIdx = i
+				m.ensureVisible(m.getListHeight())
+				return m.openProject()
+			}
+		}
+	case "ctrl+r":
+		if len(m.projects) > 0 {
+			v.loading = true
+			return m, m.computePrioritiesCmd()
+		}
+	}
+	return m, nil
+}
+
+// renderPriorities ranks projects by what to work on next, each with the
+// reasons behind its score
+func (m Model) renderPriorities(height int) string {
+	v := m.prio
+	var b strings.Builder
+
+	computed := ""
+	if v.ranking != nil {
+		computed = v.ranking.Computed.Format("Jan 2 15:04")
+	}
+	b.WriteString(i18n.T("\n  %s Work on these next — computed %s  (enter open, ctrl+r recompute, esc back)\n\n", IconPriority, computed))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	if v.ranking == nil || len(v.ranking.Ranks) == 0 {
+		if v.loading || v.ranking == nil {
+			b.WriteString(i18n.T("  Ranking projects...\n"))
+		} else {
+			b.WriteString(i18n.T("  Nothing to rank yet.\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	width := maxInt(m.width-40, 20)
+	var lines []string
+	selectedLine := 0
+	for i, r := range v.ranking.Ranks {
+		if i == v.idx {
+			selectedLine = len(lines)
+		}
+		line := fmt.Sprintf("  %3d. %-24s %5.0f", i+1, truncate(r.Project, 24), r.Score)
+		if len(r.Reasons) == 0 {
+			line += "  " + i18n.T("nothing pressing")
+		}
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		lines = append(lines, line)
+		for _, reason := range r.Reasons {
+			lines = append(lines, fmt.Sprintf("        %-10s %+5.0f  %s", reason.Signal, reason.Points, truncate(reason.Why, width)))
+		}
+	}
+
+	rows := maxInt(height-5, 1)
+	start := maxInt(selectedLine-rows/2, 0)
+	for i := start; i < len(lines) && i < start+rows; i++ {
+		b.WriteString(lines[i] + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Recomputing...\n"))
+	}
+	return padLines(b.String(), height)
+}
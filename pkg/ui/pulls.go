@@ -0,0 +1,403 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// prList is a project's open pull requests
+type prList struct {
+	project   string
+	path      string
+	prs       []discover.PullRequest
+	idx       int
+	readyOnly bool
+	loading   bool
+	err       string
+
+	// Preview deployments on Vercel and Netlify, newest first
+	previews []discover.Deployment
+
+	// Merge confirmation for the selected pull request
+	merging bool
+	method  string // one of discover.MergeMethods
+}
+
+// previewDeploysLen is how many preview deployments are searched for
+// open pull requests
+const previewDeploysLen = 50
+
+type pullsMsg struct {
+	project string
+	prs     []discover.PullRequest
+	err     error
+}
+
+type previewsMsg struct {
+	project  string
+	previews []discover.Deployment
+}
+
+func loadPullsCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := discover.ListPullRequests(path)
+		return pullsMsg{project: name, prs: prs, err: err}
+	}
+}
+
+// loadPreviewsCmd reads the preview deployments to match to pull
+// requests; a provider failing just leaves its previews out
+func loadPreviewsCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		previews, _ := discover.PreviewDeploys(name, path, previewDeploysLen)
+		return previewsMsg{project: name, previews: previews}
+	}
+}
+
+// openPulls lists a project's open pull requests
+func (m Model) openPulls(p Project) (tea.Model, tea.Cmd) {
+	m.pulls = prList{project: p.Name, path: p.Path, loading: true}
+	m.viewMode = PullsView
+	return m, tea.Batch(loadPullsCmd(p.Name, p.Path), loadPreviewsCmd(p.Name, p.Path))
+}
+
+// mergePRCmd merges a pull request, then fetches so ahead/behind counts
+// see the new base branch
+func mergePRCmd(projectName, projectPath string, pr discover.PullRequest, method string) tea.Cmd {
+	return func() tea.Msg {
+		if err := discover.MergePullRequest(projectPath, pr.Number, method); err != nil {
+			return actionResultMsg{action: "pr_merge", project: projectName, message: fmt.Sprintf("Merge #%d failed: %v", pr.Number, err)}
+		}
+		discover.Fetch(projectPath)
+		return actionResultMsg{action: "pr_merge", project: projectName, success: true,
+			message: fmt.Sprintf("Merged #%d (%s): %s", pr.Number, method, pr.Title)}
+	}
+}
+
+// setPulls stores a loaded pull request list
+func (m *Model) setPulls(msg pullsMsg) {
+	l := &m.pulls
+	if l.project != msg.project {
+		return
+	}
+	l.loading = false
+	if msg.err != nil {
+		l.err = msg.err.Error()
+		return
+	}
+	l.err = ""
+	l.prs = msg.prs
+	l.idx = maxInt(min(l.idx, len(l.visible())-1), 0)
+}
+
+// setPreviews stores the preview deployments for the listed pull
+// requests
+func (m *Model) setPreviews(msg previewsMsg) {
+	if m.pulls.project == msg.project {
+		m.pulls.previews = msg.previews
+	}
+}
+
+// visible returns the pull requests shown, all or only the ready ones
+func (l prList) visible() []discover.PullRequest {
+	if !l.readyOnly {
+		return l.prs
+	}
+	var ready []discover.PullRequest
+	for _, pr := range l.prs {
+		if pr.Ready() {
+			ready = append(ready, pr)
+		}
+	}
+	return ready
+}
+
+func (m Model) handlePullsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	l := &m.pulls
+	prs := l.visible()
+	last := maxInt(len(prs)-1, 0)
+
+	if l.merging {
+		return m.handleMergeKey(msg)
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		l.idx = min(l.idx+1, last)
+	case "k", "up":
+		l.idx = maxInt(l.idx-1, 0)
+	case "g":
+		l.idx = 0
+	case "G":
+		l.idx = last
+	case "f":
+		l.readyOnly = !l.readyOnly
+		l.idx = 0
+	case "enter", "w":
+		if l.idx < len(prs) {
+			pr := prs[l.idx]
+			return m, openURLCmd(pr.URL, fmt.Sprintf("#%d", pr.Number))
+		}
+	case "y":
+		if l.idx < len(prs) {
+			pr := prs[l.idx]
+			return m, copyToClipboardCmd(pr.URL, fmt.Sprintf("#%d URL", pr.Number))
+		}
+	case "p":
+		if l.idx >= len(prs) {
+			return m, nil
+		}
+		pr := prs[l.idx]
+		d, ok := discover.PreviewFor(l.previews, pr)
+		if !ok || d.URL == "" {
+			m.statusMsg = i18n.T("No preview deployment for #%d", pr.Number)
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		return m, openURLCmd("https://"+d.URL, fmt.Sprintf("#%d preview", pr.Number))
+	case "m":
+		if l.idx >= len(prs) {
+			return m, nil
+		}
+		if prs[l.idx].IsDraft {
+			m.statusMsg = i18n.T("#%d is a draft; mark it ready for review first", prs[l.idx].Number)
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		l.merging = true
+		if l.method == "" {
+			l.method = discover.MergeMethods[0]
+		}
+	case "ctrl+r":
+		l.loading = true
+		return m, tea.Batch(loadPullsCmd(l.project, l.path), loadPreviewsCmd(l.project, l.path))
+	}
+	return m, nil
+}
+
+// handleMergeKey answers the merge confirmation: tab or m/s/r picks the
+// method, y merges, anything else cancels
+func (m Model) handleMergeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	l := &m.pulls
+	switch key := msg.String(); key {
+	case "tab":
+		i := slices.Index(discover.MergeMethods, l.method)
+		l.method = discover.MergeMethods[(i+1)%len(discover.MergeMethods)]
+		return m, nil
+	case "m", "s", "r":
+		for _, method := range discover.MergeMethods {
+			if method[:1] == key {
+				l.method = method
+			}
+		}
+		return m, nil
+	case "y":
+	default:
+		l.merging = false
+		return m, nil
+	}
+
+	l.merging = false
+	prs := l.visible()
+	if l.idx >= len(prs) {
+		return m, nil
+	}
+	pr, method, project, path := prs[l.idx], l.method, l.project, expandPath(l.path)
+	if m.dryRun {
+		return m.showPlan(fmt.Sprintf("Merge #%d in %s", pr.Number, project),
+			fmt.Sprintf("merge %s (%s) through %s", pr.URL, method, discover.ForgeRepo(path)))
+	}
+	return m.requireSecondFactor("merge", project, func(m Model) (tea.Model, tea.Cmd) {
+		m.statusMsg = i18n.T("Merging #%d...", pr.Number)
+		m.statusMsgTime = time.Now()
+		return m, mergePRCmd(project, path, pr, method)
+	})
+}
+
+// prBlockers lists why a pull request isn't ready, for the merge prompt
+func prBlockers(pr discover.PullRequest) []string {
+	var why []string
+	switch pr.ReviewDecision {
+	case "CHANGES_REQUESTED":
+		why = append(why, i18n.T("changes requested"))
+	case "REVIEW_REQUIRED":
+		why = append(why, i18n.T("review required"))
+	}
+	switch pr.Checks {
+	case "FAILURE":
+		why = append(why, i18n.T("CI failing"))
+	case "PENDING":
+		why = append(why, i18n.T("CI running"))
+	}
+	switch pr.Mergeable {
+	case "CONFLICTING":
+		why = append(why, i18n.T("conflicts"))
+	case "UNKNOWN", "":
+		why = append(why, i18n.T("mergeability unknown"))
+	}
+	return why
+}
+
+// renderMergePrompt is the merge confirmation under the list
+func (m Model) renderMergePrompt(pr discover.PullRequest) string {
+	var methods []string
+	for _, method := range discover.MergeMethods {
+		if method == m.pulls.method {
+			method = "[" + method + "]"
+		}
+		methods = append(methods, method)
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("\n  Merge #%d %q into its base?\n", pr.Number, truncate(pr.Title, maxInt(m.width-30, 20))))
+	if why := prBlockers(pr); len(why) > 0 {
+		b.WriteString(i18n.T("  %s Not ready: %s\n", IconX, strings.Join(why, ", ")))
+	}
+	b.WriteString(i18n.T("  Method: %s  (tab or m/s/r to change, y to merge, any other key cancels)\n", strings.Join(methods, " ")))
+	return b.String()
+}
+
+// prReview describes a review decision in a word
+func prReview(pr discover.PullRequest) string {
+	switch pr.ReviewDecision {
+	case "APPROVED":
+		return IconCheck + " " + i18n.T("approved")
+	case "CHANGES_REQUESTED":
+		return IconX + " " + i18n.T("changes")
+	case "REVIEW_REQUIRED":
+		return "… " + i18n.T("review")
+	}
+	return "- " + i18n.T("no review")
+}
+
+// prChecks describes CI on the head commit
+func prChecks(pr discover.PullRequest) string {
+	switch pr.Checks {
+	case "SUCCESS":
+		return IconCheck + " CI"
+	case "FAILURE":
+		return IconX + " CI"
+	case "PENDING":
+		return "… CI"
+	}
+	return "- CI"
+}
+
+// prMergeable describes whether the branch merges cleanly
+func prMergeable(pr discover.PullRequest) string {
+	switch pr.Mergeable {
+	case "MERGEABLE":
+		return IconCheck + " " + i18n.T("merges")
+	case "CONFLICTING":
+		return IconX + " " + i18n.T("conflicts")
+	}
+	return "? " + i18n.T("unknown")
+}
+
+// prPreview describes a pull request's preview deployment in a column,
+// "-" with none
+func prPreview(d discover.Deployment, ok bool) string {
+	if !ok {
+		return "- " + i18n.T("preview")
+	}
+	return deployIcon(d.State) + " " + d.State
+}
+
+// renderPreview is the selected pull request's preview under the list
+func (m Model) renderPreview(pr discover.PullRequest) string {
+	d, ok := discover.PreviewFor(m.pulls.previews, pr)
+	if !ok {
+		return ""
+	}
+	line := fmt.Sprintf("  %s #%d preview: https://%s  %s %s, %s", IconDeploy, pr.Number, d.URL, d.Provider, d.State,
+		strings.TrimSpace(formatTimeSince(d.Created)))
+	if d.State == "failed" && d.LogsURL != "" {
+		line += "  " + d.LogsURL
+	}
+	return "\n" + truncate(line, maxInt(m.width-4, 20)) + "\n"
+}
+
+// renderPulls lists open pull requests with their draft, review, CI, and
+// merge state, marking those ready to merge
+func (m Model) renderPulls(height int) string {
+	l := m.pulls
+	prs := l.visible()
+	var b strings.Builder
+
+	ready := 0
+	for _, pr := range l.prs {
+		if pr.Ready() {
+			ready++
+		}
+	}
+	b.WriteString(i18n.T("\n  %s Pull requests — %s: %d open, %d ready  (enter/w open, p open preview, m merge, y copy URL, f ready only, ctrl+r reload, esc back)\n\n",
+		IconPR, l.project, len(l.prs), ready))
+	if l.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, l.err))
+	}
+	if len(prs) == 0 {
+		switch {
+		case l.loading:
+			b.WriteString(i18n.T("  Loading pull requests...\n"))
+		case l.err != "":
+		case l.readyOnly:
+			b.WriteString(i18n.T("  No pull requests ready to merge (f shows all)\n"))
+		default:
+			b.WriteString(i18n.T("  No open pull requests\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	numWidth := 0
+	for _, pr := range prs {
+		numWidth = max(numWidth, len(fmt.Sprint(pr.Number)))
+	}
+
+	rows := maxInt(height-4, 1)
+	if len(l.previews) > 0 {
+		rows = maxInt(rows-2, 1)
+	}
+	if l.merging {
+		rows = maxInt(rows-4, 1)
+	}
+	start := maxInt(l.idx-rows+1, 0)
+	for i := start; i < len(prs) && i < start+rows; i++ {
+		pr := prs[i]
+		mark := " "
+		if pr.Ready() {
+			mark = IconCheck
+		}
+		state := fmt.Sprintf("%-12s %-6s %-12s", prReview(pr), prChecks(pr), prMergeable(pr))
+		if pr.IsDraft {
+			state = fmt.Sprintf("%-32s", i18n.T("draft"))
+		}
+		if len(l.previews) > 0 {
+			state += fmt.Sprintf(" %-12s", prPreview(discover.PreviewFor(l.previews, pr)))
+		}
+		age := strings.TrimSpace(formatTimeSince(pr.UpdatedAt))
+		line := fmt.Sprintf("  %s #%-*d  %s  %4s  %s", mark, numWidth, pr.Number, state, age, pr.Title)
+		if pr.Author.Login != "" {
+			line += "  @" + pr.Author.Login
+		}
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == l.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if l.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	if len(l.previews) > 0 && l.idx < len(prs) && !l.merging {
+		b.WriteString(m.renderPreview(prs[l.idx]))
+	}
+	if l.merging && l.idx < len(prs) {
+		b.WriteString(m.renderMergePrompt(prs[l.idx]))
+	}
+	return padLines(b.String(), height)
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/openclaw"
+)
+
+type readmeCheckMsg struct {
+	project string
+	check   *discover.ReadmeCheck
+}
+
+type readmeDraftMsg struct {
+	project string
+	draft   string
+	err     error
+}
+
+type readmeProposedMsg struct {
+	project string
+	url     string
+	err     error
+}
+
+// loadReadmeCmd checks a project's README against the repository
+func loadReadmeCmd(name, path string, ciFailing bool) tea.Cmd {
+	return func() tea.Msg {
+		check, _ := discover.CheckReadme(path, ciFailing)
+		return readmeCheckMsg{project: name, check: check}
+	}
+}
+
+// readmePrompt asks for the README with what's stale fixed and nothing
+// else changed
+func readmePrompt(name, readme string, check *discover.ReadmeCheck) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "The %s of the project %s is out of date:\n", check.Path, name)
+	for _, f := range check.Findings {
+		fmt.Fprintf(&b, "- %s\n", f)
+	}
+	b.WriteString("\nRewrite it with those fixed. Change nothing else: keep its wording, structure, and formatting. ")
+	b.WriteString("Reply with the complete file only, no commentary and no code fence around it.\n\n")
+	b.WriteString(readme)
+	return b.String()
+}
+
+// draftReadmeCmd asks OpenClaw for a corrected README
+func draftReadmeCmd(client *openclaw.Client, name, path string, check *discover.ReadmeCheck) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return readmeDraftMsg{project: name, err: fmt.Errorf("OpenClaw is not configured")}
+		}
+		readme, err := discover.ReadReadme(path, check)
+		if err != nil {
+			return readmeDraftMsg{project: name, err: err}
+		}
+		draft, err := client.Complete(readmePrompt(name, readme, check))
+		return readmeDraftMsg{project: name, draft: unfence(draft), err: err}
+	}
+}
+
+// unfence strips the code fence a model wraps a whole file in despite
+// being asked not to
+func unfence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") {
+		return s + "\n"
+	}
+	_, body, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(strings.TrimSuffix(body, "```")) + "\n"
+}
+
+// proposeReadmeCmd opens a pull request with the drafted README
+func proposeReadmeCmd(name, path string, check *discover.ReadmeCheck, draft string) tea.Cmd {
+	return func() tea.Msg {
+		url, err := discover.ProposeReadme(path, check, draft)
+		return readmeProposedMsg{project: name, url: url, err: err}
+	}
+}
+
+// setReadmeCheck keeps a project's README check, dropping a draft made
+// for findings that have changed
+func (m Model) setReadmeCheck(msg readmeCheckMsg) Model {
+	if m.readmeChecks == nil {
+		m.readmeChecks = make(map[string]*discover.ReadmeCheck)
+	}
+	if old := m.readmeChecks[msg.project]; old == nil || !msg.check.Stale() || len(old.Findings) != len(msg.check.Findings) {
+		delete(m.readmeDrafts, msg.project)
+	}
+	m.readmeChecks[msg.project] = msg.check
+	return m
+}
+
+// setReadmeDraft keeps a drafted README until it's proposed
+func (m Model) setReadmeDraft(msg readmeDraftMsg) Model {
+	if m.readmeDrafting == msg.project {
+		m.readmeDrafting = ""
+	}
+	m.statusMsgTime = time.Now()
+	if msg.err != nil {
+		m.statusMsg = i18n.T("README draft failed: %v", msg.err)
+		return m
+	}
+	if m.readmeDrafts == nil {
+		m.readmeDrafts = make(map[string]string)
+	}
+	m.readmeDrafts[msg.project] = msg.draft
+	m.statusMsg = i18n.T("README draft ready for %s: = opens a pull request", msg.project)
+	return m
+}
+
+// setReadmeProposed reports the pull request and records it in the audit
+// log
+func (m Model) setReadmeProposed(msg readmeProposedMsg) (tea.Model, tea.Cmd) {
+	m.statusMsgTime = time.Now()
+	if msg.err != nil {
+		m.statusMsg = i18n.T("README pull request failed: %v", msg.err)
+		return m, auditCmd(msg.project, "readme-pr", "", msg.err)
+	}
+	delete(m.readmeDrafts, msg.project)
+	m.statusMsg = i18n.T("Opened %s", msg.url)
+	return m, tea.Batch(auditCmd(msg.project, "readme-pr", msg.url, nil), openURLCmd(msg.url, "README pull request"))
+}
+
+// fixReadme drafts a stale README's update with OpenClaw, then on the
+// next press opens it as a pull request
+func (m Model) fixReadme(p *Project) (tea.Model, tea.Cmd) {
+	check := m.readmeChecks[p.Name]
+	if !check.Stale() || m.readmeDrafting == p.Name {
+		return m, nil
+	}
+	draft, ok := m.readmeDrafts[p.Name]
+	if !ok {
+		m.readmeDrafting = p.Name
+		m.statusMsg = i18n.T("Drafting a README update with OpenClaw...")
+		m.statusMsgTime = time.Now()
+		return m, draftReadmeCmd(m.clawClient, p.Name, p.Path, check)
+	}
+	if m.dryRun {
+		return m.showPlan("Propose README update for "+p.Name,
+			fmt.Sprintf("commit the drafted %s (%d lines) to a new branch", check.Path, strings.Count(draft, "\n")),
+			"open a pull request against "+discover.DefaultBranch(p.Path))
+	}
+	return m, proposeReadmeCmd(p.Name, p.Path, check, draft)
+}
+
+// renderReadme lists what a stale README gets wrong in the detail view
+func (m Model) renderReadme(p *Project) string {
+	check := m.readmeChecks[p.Name]
+	if !check.Stale() {
+		return ""
+	}
+	var b strings.Builder
+	action := i18n.T("= drafts an update")
+	switch _, drafted := m.readmeDrafts[p.Name]; {
+	case m.readmeDrafting == p.Name:
+		action = i18n.T("drafting...")
+	case drafted:
+		action = i18n.T("draft ready, = opens a pull request")
+	}
+	b.WriteString(i18n.T("  %s %s is stale (%s):\n", IconConflict, check.Path, action))
+	for _, f := range check.Findings {
+		b.WriteString(truncate("    "+f.String(), maxInt(m.width-4, 20)) + "\n")
+	}
+	return b.String()
+}
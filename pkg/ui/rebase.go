@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// rebaseActions are the todo commands the planner offers, by key
+var rebaseActions = map[string]string{
+	"p": "pick",
+	"s": "squash",
+	"f": "fixup",
+	"d": "drop",
+}
+
+// rebaseStep is one line of an interactive rebase todo
+type rebaseStep struct {
+	action string
+	commit discover.Commit
+}
+
+// rebasePlan holds state for the guided interactive rebase: the commits
+// after base, oldest first as git's todo lists them
+type rebasePlan struct {
+	project string
+	path    string
+	base    string // the commit rebased onto; "" rebases from the root
+	steps   []rebaseStep
+	idx     int
+	err     string
+}
+
+// openRebasePlan plans a rebase of every commit from HEAD down to and
+// including the log's selected commit
+func (m Model) openRebasePlan() (tea.Model, tea.Cmd) {
+	p := m.currentProject
+	l := m.commitLog
+	if l.idx >= len(l.commits) {
+		return m, nil
+	}
+	if p.Operation != "" {
+		m.statusMsg = i18n.T("Finish the %s in progress first", p.Operation)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+
+	plan := rebasePlan{project: p.Name, path: p.Path}
+	for i := l.idx; i >= 0; i-- {
+		plan.steps = append(plan.steps, rebaseStep{action: "pick", commit: l.commits[i]})
+	}
+	oldest := l.commits[l.idx].Hash
+	if out, err := exec.Command("git", "-C", expandPath(p.Path), "rev-parse", "--verify", "-q", oldest+"^").Output(); err == nil {
+		plan.base = strings.TrimSpace(string(out))
+	}
+
+	m.rebase = plan
+	m.viewMode = RebaseView
+	return m, nil
+}
+
+// todo renders the plan as a git-rebase-todo file
+func (r rebasePlan) todo() string {
+	var b strings.Builder
+	for _, s := range r.steps {
+		fmt.Fprintf(&b, "%s %s %s\n", s.action, s.commit.Hash, s.commit.Subject)
+	}
+	return b.String()
+}
+
+// validate catches todos git would reject
+func (r rebasePlan) validate() error {
+	for _, s := range r.steps {
+		switch s.action {
+		case "drop":
+			continue
+		case "squash", "fixup":
+			return fmt.Errorf("the first kept commit can't %s: there is nothing before it to fold into", s.action)
+		}
+		return nil
+	}
+	return fmt.Errorf("every commit is dropped")
+}
+
+// args returns the git arguments that start the planned rebase
+func (r rebasePlan) args() []string {
+	args := []string{"-C", expandPath(r.path), "rebase", "-i"}
+	if r.base == "" {
+		return append(args, "--root")
+	}
+	return append(args, r.base)
+}
+
+// runRebasePlanCmd runs the rebase with the planned todo in place of the
+// editor. Squashes keep git's combined message rather than opening one.
+func runRebasePlanCmd(r rebasePlan) tea.Cmd {
+	return func() tea.Msg {
+		todo, err := os.CreateTemp("", "mc-rebase-todo-*")
+		if err != nil {
+			return actionResultMsg{action: "git_rebase", project: r.project, message: "rebase: " + err.Error()}
+		}
+		defer os.Remove(todo.Name())
+		todo.WriteString(r.todo())
+		todo.Close()
+
+		cmd := exec.Command("git", r.args()...)
+		cmd.Env = append(os.Environ(),
+			"GIT_SEQUENCE_EDITOR="+shellCommand("cp", todo.Name()),
+			"GIT_EDITOR=true",
+			"GIT_TERMINAL_PROMPT=0",
+		)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return stoppedResult("git_rebase", r.project, r.path, "rebase", output, err)
+		}
+		return actionResultMsg{
+			action:  "git_rebase",
+			project: r.project,
+			success: true,
+			message: fmt.Sprintf("Rebased %d commits in %s", len(r.steps), r.project),
+		}
+	}
+}
+
+// stoppedResult reports a rebase or cherry-pick that failed, saying so
+// when it stopped on conflicts rather than refusing to start
+func stoppedResult(action, project, path, verb string, output []byte, err error) actionResultMsg {
+	msg := actionResultMsg{action: action, project: project}
+	if status, _ := discover.GetGitStatus(path); status != nil && status.Operation != "" {
+		if n := len(status.Conflicts); n > 0 {
+			msg.message = fmt.Sprintf("%s stopped with %d conflicted files in %s: fix them, then git %s --continue (or --abort)",
+				verb, n, project, verb)
+		} else {
+			msg.message = fmt.Sprintf("%s paused in %s: git %s --continue when ready", verb, project, verb)
+		}
+		return msg
+	}
+	msg.message = fmt.Sprintf("%s failed: %s", verb, firstLine(output, err))
+	return msg
+}
+
+func (m Model) handleRebaseKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	r := &m.rebase
+	key := msg.String()
+
+	if action, ok := rebaseActions[key]; ok {
+		if r.idx < len(r.steps) {
+			r.steps[r.idx].action = action
+		}
+		r.err = ""
+		return m, nil
+	}
+
+	switch key {
+	case "j", "down":
+		if r.idx < len(r.steps)-1 {
+			r.idx++
+		}
+	case "k", "up":
+		if r.idx > 0 {
+			r.idx--
+		}
+	case "J", "shift+down":
+		// Move the commit later in history
+		if r.idx < len(r.steps)-1 {
+			r.steps[r.idx], r.steps[r.idx+1] = r.steps[r.idx+1], r.steps[r.idx]
+			r.idx++
+		}
+	case "K", "shift+up":
+		if r.idx > 0 {
+			r.steps[r.idx], r.steps[r.idx-1] = r.steps[r.idx-1], r.steps[r.idx]
+			r.idx--
+		}
+	case "enter":
+		if err := r.validate(); err != nil {
+			r.err = err.Error()
+			return m, nil
+		}
+		plan := *r
+		plan.steps = append([]rebaseStep(nil), r.steps...)
+		if m.dryRun {
+			steps := []string{shellCommand("git", plan.args()...)}
+			for _, line := range strings.Split(strings.TrimSpace(plan.todo()), "\n") {
+				steps = append(steps, "  "+line)
+			}
+			return m.showPlan("Interactive rebase in "+plan.project, steps...)
+		}
+		return m.requireSecondFactor("rebase", plan.project, func(m Model) (tea.Model, tea.Cmd) {
+			m.viewMode = DetailView
+			m.statusMsg = i18n.T("Rebasing %s...", plan.project)
+			m.statusMsgTime = time.Now()
+			return m, runRebasePlanCmd(plan)
+		})
+	}
+	return m, nil
+}
+
+func (m Model) renderRebasePlan(height int) string {
+	r := m.rebase
+	var b strings.Builder
+
+	onto := r.base
+	if onto == "" {
+		onto = "root"
+	} else if len(onto) > 7 {
+		onto = onto[:7]
+	}
+	b.WriteString(i18n.T("\n  %s Interactive rebase — %s onto %s\n", IconBranch, r.project, onto))
+	b.WriteString(i18n.T("  p pick · s squash · f fixup · d drop · J/K move · enter run · esc cancel\n\n"))
+	if r.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n\n", IconX, r.err))
+	}
+
+	rows := height - 6
+	start := 0
+	if r.idx >= rows {
+		start = r.idx - rows + 1
+	}
+	for i := start; i < len(r.steps) && i < start+rows; i++ {
+		s := r.steps[i]
+		line := fmt.Sprintf("  %-6s %s  %s", s.action, s.commit.ShortHash(), truncate(s.commit.Subject, maxInt(m.width-24, 20)))
+		switch {
+		case i == r.idx:
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		case s.action == "drop":
+			// Struck through
+			line = "\033[9m" + line + "\033[0m"
+		}
+		b.WriteString(line + "\n")
+	}
+	return padLines(b.String(), height)
+}
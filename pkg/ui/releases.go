@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/forge"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// releasesRead is how many of a project's published releases are
+// searched for its latest tag
+const releasesRead = 10
+
+type releasesMsg struct {
+	project  string
+	releases []forge.Release
+}
+
+// loadReleasesCmd reads the releases published on a project's forge
+func loadReleasesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		var releases []forge.Release
+		scheduleGitHub(name, func() {
+			releases, _ = discover.ListReleases(path, releasesRead)
+		})
+		return releasesMsg{project: name, releases: releases}
+	}
+}
+
+// renderPublished says whether the latest tag was published as a release
+// on the project's forge, for the detail view. It is blank until the
+// releases load, and for forges without any.
+func (m Model) renderPublished(p *Project) string {
+	releases, ok := m.releases[p.Name]
+	if !ok || p.Release == nil || len(releases) == 0 {
+		return ""
+	}
+	for _, r := range releases {
+		if r.Tag != p.Release.Tag {
+			continue
+		}
+		if r.Draft {
+			return "  " + driftStyle.Render(i18n.T("%s is still a draft release", r.Tag)) + "\n"
+		}
+		return i18n.T("  Published %s ago\n", strings.TrimSpace(formatTimeSince(r.PublishedAt)))
+	}
+	return "  " + driftStyle.Render(i18n.T("%s is tagged but not published as a release (latest: %s)", p.Release.Tag, releases[0].Tag)) + "\n"
+}
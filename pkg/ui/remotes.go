@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderRemotes lists remotes for the detail view when a project has
+// more than one, so drift between e.g. GitHub and a private mirror shows
+func (m Model) renderRemotes(p *Project) string {
+	if len(p.Remotes) < 2 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("  Remotes: %d\n", len(p.Remotes)))
+	for _, r := range p.Remotes {
+		state := "no copy of this branch"
+		if r.Tracked {
+			state = fmt.Sprintf("%s%d %s%d", IconAhead, r.Ahead, IconBehind, r.Behind)
+		}
+
+		var roles []string
+		if r.Upstream {
+			roles = append(roles, "upstream")
+		}
+		if r.PushDefault {
+			roles = append(roles, "push default")
+		}
+		line := fmt.Sprintf("    %-10s %-14s %s", truncate(r.Name, 10), state, truncate(r.URL, maxInt(m.width-50, 20)))
+		if len(roles) > 0 {
+			line += " (" + strings.Join(roles, ", ") + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
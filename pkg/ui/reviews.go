@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// reviewStale is how long a review comment can wait before it's flagged
+const reviewStale = 3 * 24 * time.Hour
+
+// reviewComment is an unresolved review thread with the project it
+// belongs to
+type reviewComment struct {
+	github.ReviewComment
+	project string // "" when the repository isn't a local project
+}
+
+// group is what the comment is listed under: its project, else its
+// repository
+func (c reviewComment) group() string {
+	if c.project != "" {
+		return c.project
+	}
+	return c.Repo
+}
+
+// reviewsView is the review-comment digest: unresolved threads waiting
+// on me, for one project or across all of them
+type reviewsView struct {
+	project string // the project it was opened on
+	all     bool   // every project's, not just project's
+	items   []reviewComment
+	idx     int
+	loading bool
+	err     string
+}
+
+type reviewsMsg struct {
+	items []reviewComment
+	err   error
+}
+
+// loadReviewsCmd fetches review comments waiting on me and matches their
+// repositories to projects (name to path)
+func loadReviewsCmd(projects map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		repos := make(map[string]string)
+		for name, path := range projects {
+			if repo := discover.GitHubRepo(path); repo != "" {
+				repos[strings.ToLower(repo)] = name
+			}
+		}
+		comments, err := discover.ReviewComments()
+		if err != nil {
+			return reviewsMsg{err: err}
+		}
+		items := make([]reviewComment, 0, len(comments))
+		for _, c := range comments {
+			items = append(items, reviewComment{ReviewComment: c, project: repos[strings.ToLower(c.Repo)]})
+		}
+		// Local projects first, then other repositories, each oldest first
+		// so what's rotting leads
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			if (a.project == "") != (b.project == "") {
+				return a.project != ""
+			}
+			return a.group() < b.group()
+		})
+		return reviewsMsg{items: items}
+	}
+}
+
+// openReviews lists the review comments waiting on me in a project; a
+// widens it to every project
+func (m Model) openReviews(p Project) (tea.Model, tea.Cmd) {
+	m.reviews = reviewsView{project: p.Name, loading: true, all: m.reviews.all}
+	m.viewMode = ReviewsView
+	return m, loadReviewsCmd(m.projectPaths())
+}
+
+// setReviews stores loaded review comments
+func (m *Model) setReviews(msg reviewsMsg) {
+	v := &m.reviews
+	v.loading = false
+	if msg.err != nil {
+		v.err = msg.err.Error()
+		return
+	}
+	v.err = ""
+	v.items = msg.items
+	v.idx = maxInt(min(v.idx, len(v.visible())-1), 0)
+}
+
+// visible returns the comments shown: the project's, or all of them
+func (v reviewsView) visible() []reviewComment {
+	if v.all {
+		return v.items
+	}
+	var shown []reviewComment
+	for _, c := range v.items {
+		if c.project == v.project {
+			shown = append(shown, c)
+		}
+	}
+	return shown
+}
+
+func (m Model) handleReviewsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.reviews
+	items := v.visible()
+	last := maxInt(len(items)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "a":
+		v.all = !v.all
+		v.idx = 0
+	case "enter", "w":
+		if v.idx < len(items) {
+			c := items[v.idx]
+			return m, openURLCmd(c.URL, fmt.Sprintf("#%d comment", c.Number))
+		}
+	case "p":
+		if v.idx < len(items) {
+			c := items[v.idx]
+			return m, openURLCmd(c.PRURL, fmt.Sprintf("#%d", c.Number))
+		}
+	case "y":
+		if v.idx < len(items) {
+			c := items[v.idx]
+			return m, copyToClipboardCmd(c.URL, fmt.Sprintf("#%d comment URL", c.Number))
+		}
+	case "ctrl+r":
+		v.loading = true
+		return m, loadReviewsCmd(m.projectPaths())
+	}
+	return m, nil
+}
+
+// reviewLocation is where in the diff a thread sits
+func reviewLocation(c reviewComment) string {
+	loc := c.Path
+	if c.Line > 0 {
+		loc += fmt.Sprintf(":%d", c.Line)
+	}
+	if c.Outdated {
+		loc += " " + i18n.T("(outdated)")
+	}
+	return loc
+}
+
+// renderReviews lists review comments under a heading per project, each
+// with its pull request, place in the diff, and the latest reply
+func (m Model) renderReviews(height int) string {
+	v := m.reviews
+	items := v.visible()
+	var b strings.Builder
+
+	scope := v.project
+	if v.all {
+		scope = i18n.T("all projects")
+	}
+	stale := 0
+	for _, c := range items {
+		if time.Since(c.At) > reviewStale {
+			stale++
+		}
+	}
+	b.WriteString(i18n.T("\n  %s Review comments — %s: %d unresolved, %d stale  (enter/w open comment, p open PR, a this project/all, y copy URL, ctrl+r reload, esc back)\n\n",
+		IconPR, scope, len(items), stale))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	if len(items) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(i18n.T("  Loading review comments...\n"))
+		case v.err != "":
+		case len(v.items) > 0:
+			b.WriteString(i18n.T("  No review comments waiting on you here (a shows all projects)\n"))
+		default:
+			b.WriteString(i18n.T("  No review comments waiting on you\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	// Lay out headings and comments together, then window around the
+	// selection
+	type row struct {
+		text string
+		item int // index into items, -1 for a heading
+	}
+	var rows []row
+	selected := 0
+	for i, c := range items {
+		if i == 0 || c.group() != items[i-1].group() {
+			rows = append(rows, row{text: "  " + c.group(), item: -1})
+		}
+		if i == v.idx {
+			selected = len(rows)
+		}
+		mark := " "
+		if time.Since(c.At) > reviewStale {
+			mark = IconConflict
+		}
+		body := strings.Join(strings.Fields(c.Body), " ")
+		line := fmt.Sprintf("  %s #%-5d %4s  @%s on %s: %s", mark, c.Number,
+			strings.TrimSpace(formatTimeSince(c.At)), c.Author, reviewLocation(c), body)
+		rows = append(rows, row{text: line, item: i})
+	}
+
+	shown := maxInt(height-5, 1)
+	start := maxInt(selected-shown+1, 0)
+	for i := start; i < len(rows) && i < start+shown; i++ {
+		r := rows[i]
+		line := truncate(r.text, maxInt(m.width-4, 20))
+		if r.item == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.idx < len(items) {
+		c := items[v.idx]
+		b.WriteString(i18n.T("\n  #%d %s (%d comments in thread)\n", c.Number, truncate(c.PRTitle, maxInt(m.width-30, 20)), c.Comments))
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
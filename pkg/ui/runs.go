@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// runsShown is how many recent workflow runs the runs view lists
+const runsShown = 30
+
+type ciMsg struct {
+	name string
+	ci   *discover.BranchCI
+}
+
+func loadCICmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		var ci *discover.BranchCI
+		scheduleGitHub(name, func() {
+			ci, _ = discover.DefaultBranchCI(path)
+		})
+		return ciMsg{name: name, ci: ci}
+	}
+}
+
+// runList is a project's recent GitHub Actions runs
+type runList struct {
+	project string
+	path    string
+	runs    []discover.WorkflowRun
+	idx     int
+	loading bool
+	err     string
+}
+
+type runsMsg struct {
+	project string
+	runs    []discover.WorkflowRun
+	err     error
+}
+
+func loadRunsCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		runs, err := discover.RecentRuns(path, runsShown)
+		return runsMsg{project: name, runs: runs, err: err}
+	}
+}
+
+// rerunCmd re-runs a workflow run, reporting through actionResultMsg so it
+// lands in the audit log
+func rerunCmd(projectName, projectPath string, run discover.WorkflowRun, failedOnly bool) tea.Cmd {
+	return func() tea.Msg {
+		what := run.Name
+		if failedOnly {
+			what += " (failed jobs)"
+		}
+		if err := discover.RerunWorkflow(projectPath, run, failedOnly); err != nil {
+			return actionResultMsg{action: "rerun", project: projectName, message: fmt.Sprintf("Re-run %s failed: %v", what, err)}
+		}
+		return actionResultMsg{action: "rerun", project: projectName, success: true,
+			message: fmt.Sprintf("Re-running %s on %s", what, run.Branch)}
+	}
+}
+
+// openRuns lists a project's recent workflow runs
+func (m Model) openRuns(p Project) (tea.Model, tea.Cmd) {
+	m.runs = runList{project: p.Name, path: p.Path, loading: true}
+	m.viewMode = RunsView
+	return m, loadRunsCmd(p.Name, p.Path)
+}
+
+// setRuns stores loaded runs
+func (m *Model) setRuns(msg runsMsg) {
+	l := &m.runs
+	if l.project != msg.project {
+		return
+	}
+	l.loading = false
+	if msg.err != nil {
+		l.err = msg.err.Error()
+		return
+	}
+	l.err = ""
+	l.runs = msg.runs
+	l.idx = maxInt(min(l.idx, len(l.runs)-1), 0)
+}
+
+func (m Model) handleRunsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	l := &m.runs
+	last := maxInt(len(l.runs)-1, 0)
+
+	switch key := msg.String(); key {
+	case "j", "down":
+		l.idx = min(l.idx+1, last)
+	case "k", "up":
+		l.idx = maxInt(l.idx-1, 0)
+	case "g":
+		l.idx = 0
+	case "G":
+		l.idx = last
+	case "enter", "w":
+		if l.idx < len(l.runs) {
+			r := l.runs[l.idx]
+			return m, openURLCmd(r.URL, r.Name)
+		}
+	case "y":
+		if l.idx < len(l.runs) {
+			return m, copyToClipboardCmd(l.runs[l.idx].URL, l.runs[l.idx].Name+" URL")
+		}
+	case "r", "R":
+		if l.idx >= len(l.runs) {
+			return m, nil
+		}
+		r := l.runs[l.idx]
+		if r.Status != "completed" {
+			m.statusMsg = i18n.T("%s is still running", r.Name)
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		failedOnly := key == "R"
+		if m.dryRun {
+			step := "re-run " + r.URL
+			if failedOnly {
+				step += " (failed jobs only)"
+			}
+			return m.showPlan("Re-run "+r.Name+" in "+l.project, step)
+		}
+		return m, rerunCmd(l.project, expandPath(l.path), r, failedOnly)
+	case "ctrl+r":
+		l.loading = true
+		return m, loadRunsCmd(l.project, l.path)
+	}
+	return m, nil
+}
+
+// ciIcon is a CI state's icon, blank when unknown
+func ciIcon(state string) string {
+	switch state {
+	case discover.CIPassing:
+		return IconCheck
+	case discover.CIFailing:
+		return IconX
+	case discover.CIRunning:
+		return IconBuilding
+	}
+	return " "
+}
+
+// renderCI summarizes the default branch's CI in the detail view
+func (m Model) renderCI(p *Project) string {
+	if p.CI == nil || len(p.CI.Runs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("  CI on %s: %s %s (w for recent runs)\n", p.CI.Branch, ciIcon(p.CI.State()), i18n.T(p.CI.State())))
+	for _, r := range p.CI.Runs {
+		if r.State() != discover.CIPassing {
+			b.WriteString(fmt.Sprintf("    %s %s  %s\n", ciIcon(r.State()), r.Name, strings.TrimSpace(formatTimeSince(r.CreatedAt))))
+		}
+	}
+	return b.String()
+}
+
+// renderRuns lists recent workflow runs
+func (m Model) renderRuns(height int) string {
+	l := m.runs
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s Workflow runs — %s  (enter/w open, y copy URL, r re-run, R re-run failed jobs, ctrl+r reload, esc back)\n\n", IconGitHub, l.project))
+	if l.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, l.err))
+	}
+	if len(l.runs) == 0 {
+		if l.loading {
+			b.WriteString(i18n.T("  Loading runs...\n"))
+		} else if l.err == "" {
+			b.WriteString(i18n.T("  No workflow runs\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	nameWidth, branchWidth := 0, 0
+	for _, r := range l.runs {
+		nameWidth = max(nameWidth, len(r.Name))
+		branchWidth = max(branchWidth, len(r.Branch))
+	}
+	nameWidth, branchWidth = min(nameWidth, 30), min(branchWidth, 24)
+
+	rows := maxInt(height-4, 1)
+	start := maxInt(l.idx-rows+1, 0)
+	for i := start; i < len(l.runs) && i < start+rows; i++ {
+		r := l.runs[i]
+		line := fmt.Sprintf("  %s %-10s %-*s  %-*s  %4s", ciIcon(r.State()), i18n.T(r.State()),
+			nameWidth, truncate(r.Name, nameWidth), branchWidth, truncate(r.Branch, branchWidth),
+			strings.TrimSpace(formatTimeSince(r.CreatedAt)))
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == l.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if l.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
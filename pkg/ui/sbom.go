@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/sbom"
+)
+
+type sbomMsg struct {
+	project   string
+	info      *discover.SBOMInfo
+	err       error
+	generated bool // from m rather than the cache
+}
+
+// loadSBOMCmd reads a project's cached SBOM, generating nothing
+func loadSBOMCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := discover.LoadSBOM(path)
+		return sbomMsg{project: name, info: info, err: err}
+	}
+}
+
+// generateSBOMCmd regenerates a project's SBOM and scans it
+func generateSBOMCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := discover.GenerateSBOM(path)
+		return sbomMsg{project: name, info: info, err: err, generated: true}
+	}
+}
+
+// startSBOM generates the project's SBOM on demand (m in the detail
+// view), which can take a while on a large dependency tree
+func (m Model) startSBOM(p *Project) (tea.Model, tea.Cmd) {
+	if m.dryRun {
+		args, err := discover.SBOMCommand(p.Path)
+		if err != nil {
+			m.statusMsg = err.Error()
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		return m.showPlan("Generate SBOM for "+p.Name, shellCommand(args[0], args[1:]...))
+	}
+	m.statusMsg = i18n.T("Generating SBOM for %s...", p.Name)
+	m.statusMsgTime = time.Now()
+	return m, generateSBOMCmd(p.Name, p.Path)
+}
+
+// setSBOM keeps a loaded or generated SBOM, reporting on a generated one
+func (m Model) setSBOM(msg sbomMsg) Model {
+	if msg.info != nil {
+		if m.sboms == nil {
+			m.sboms = make(map[string]*discover.SBOMInfo)
+		}
+		m.sboms[msg.project] = msg.info
+	}
+	if !msg.generated {
+		return m
+	}
+	switch {
+	case msg.info == nil:
+		m.statusMsg = i18n.T("SBOM for %s failed: %v", msg.project, msg.err)
+	case msg.err != nil:
+		m.statusMsg = i18n.T("SBOM for %s: %d components, vulnerability scan failed: %v", msg.project, len(msg.info.SBOM.Components), msg.err)
+	default:
+		m.statusMsg = i18n.T("SBOM for %s: %d components", msg.project, len(msg.info.SBOM.Components))
+	}
+	m.statusMsgTime = time.Now()
+	return m
+}
+
+// renderSBOM shows the project's SBOM for the detail view: how many
+// components it lists, their licenses against the project's policy, and
+// the known vulnerabilities in them
+func (m Model) renderSBOM(p *Project) string {
+	info := m.sboms[p.Name]
+	if info == nil {
+		return i18n.T("  SBOM: none yet (m generates)\n")
+	}
+	bom := info.SBOM
+	var b strings.Builder
+
+	from := bom.Tool
+	if from == "" {
+		from = "?"
+	}
+	b.WriteString(i18n.T("  SBOM: %d components from %s, %s ago", len(bom.Components), from, strings.TrimSpace(formatTimeSince(bom.Generated))))
+	if info.Stale {
+		b.WriteString(" " + driftStyle.Render(i18n.T("(dependencies changed; m regenerates)")))
+	}
+	b.WriteString("\n")
+
+	report := bom.Report(discover.LicensePolicy(p.Path))
+	classes := make(map[string]int)
+	for _, use := range report.Licenses {
+		classes[use.Class] += len(use.Components)
+	}
+	b.WriteString(i18n.T("  Licenses: %d permissive, %d weak copyleft, %d copyleft, %d other\n",
+		classes[sbom.Permissive], classes[sbom.WeakCopyleft], classes[sbom.Copyleft], classes[sbom.Unknown]))
+	if n := len(report.Denied); n > 0 {
+		names := make([]string, 0, 3)
+		for _, c := range report.Denied[:min(n, 3)] {
+			names = append(names, c.ID()+" ("+c.License()+")")
+		}
+		b.WriteString("  " + driftStyle.Render(fmt.Sprintf("%s %s", IconConflict, i18n.T("%d not allowed by the license policy: %s", n, strings.Join(names, ", ")))) + "\n")
+	}
+	if n := len(report.Unlicensed); n > 0 {
+		b.WriteString(i18n.T("  %d components name no license\n", n))
+	}
+
+	if scan := info.Scan; scan != nil {
+		sev := scan.BySeverity()
+		line := i18n.T("Vulnerabilities (%s): %d critical, %d high, %d medium, %d low",
+			scan.Scanner, sev[sbom.Critical], sev[sbom.High], sev[sbom.Medium], sev[sbom.Low]+sev[""])
+		if len(scan.Vulns) > 0 {
+			v := scan.Vulns[0]
+			line += i18n.T("; worst: %s in %s@%s", v.ID, v.Package, v.Version)
+			if v.FixedIn != "" {
+				line += i18n.T(" (fixed in %s)", v.FixedIn)
+			}
+		}
+		if sev[sbom.Critical]+sev[sbom.High] > 0 {
+			line = driftStyle.Render(line)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+	return b.String()
+}
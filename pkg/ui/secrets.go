@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/secrets"
+)
+
+// secretsBatch caps provider lookups run side by side
+const secretsBatch = 6
+
+// secretRow is one project's credential with its rotation policy
+type secretRow struct {
+	project string
+	policy  string
+	secrets.Secret
+}
+
+// stale reports whether the row is past its rotation policy
+func (r secretRow) stale(now time.Time) bool {
+	return r.Stale(r.policy, now)
+}
+
+// rotationView lists every project's credentials, stalest first
+type rotationView struct {
+	reported map[string][]secrets.Secret // by project, as providers report them
+	policies map[string]string
+	rows     []secretRow
+	idx      int
+	loading  bool
+	err      string
+}
+
+type secretsMsg struct {
+	reported map[string][]secrets.Secret
+	policies map[string]string
+	log      []secrets.Rotation
+	err      error
+}
+
+type rotationLogMsg struct {
+	log []secrets.Rotation
+	err error
+}
+
+// loadSecretsCmd asks each project's providers for its secrets and reads
+// the rotation log
+func loadSecretsCmd(projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil {
+			return secretsMsg{err: err}
+		}
+		log, err := secrets.Load()
+		if err != nil {
+			return secretsMsg{err: err}
+		}
+
+		msg := secretsMsg{
+			reported: make(map[string][]secrets.Secret),
+			policies: make(map[string]string),
+			log:      log,
+		}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, secretsBatch)
+		for _, p := range projects {
+			msg.policies[p.Name] = secrets.Policy(cfg, p.Name)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				found, _ := discover.ProviderSecrets(p.Path)
+				mu.Lock()
+				msg.reported[p.Name] = found
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		return msg
+	}
+}
+
+func loadRotationLogCmd() tea.Msg {
+	log, err := secrets.Load()
+	return rotationLogMsg{log: log, err: err}
+}
+
+// logRotationCmd records that a secret was rotated just now
+func logRotationCmd(r secretRow) tea.Cmd {
+	return func() tea.Msg {
+		if err := secrets.Log(r.project, r.Name, time.Now()); err != nil {
+			return actionResultMsg{action: "rotate", project: r.project, message: i18n.T("Rotation log failed: %v", err)}
+		}
+		return actionResultMsg{action: "rotate", project: r.project, success: true, message: i18n.T("Logged rotation of %s (%s)", r.Name, r.project)}
+	}
+}
+
+// openSecrets shows credentials across projects
+func (m Model) openSecrets() (tea.Model, tea.Cmd) {
+	m.rotation = rotationView{loading: true}
+	m.viewMode = SecretsView
+	return m, loadSecretsCmd(m.projects)
+}
+
+// setSecrets stores what providers reported and builds the list
+func (m *Model) setSecrets(msg secretsMsg) {
+	v := &m.rotation
+	v.loading = false
+	if msg.err != nil {
+		v.err = msg.err.Error()
+		return
+	}
+	v.err = ""
+	v.reported, v.policies = msg.reported, msg.policies
+	v.rebuild(msg.log)
+}
+
+// rebuild merges provider secrets with the rotation log, stale ones first
+// (longest overdue at the top), then the soonest due
+func (v *rotationView) rebuild(log []secrets.Rotation) {
+	v.rows = nil
+	for project, reported := range v.reported {
+		policy := v.policies[project]
+		for _, s := range secrets.Inventory(project, reported, log) {
+			v.rows = append(v.rows, secretRow{project: project, policy: policy, Secret: s})
+		}
+	}
+	sort.SliceStable(v.rows, func(i, j int) bool {
+		a, b := v.rows[i], v.rows[j]
+		if !a.Due(a.policy).Equal(b.Due(b.policy)) {
+			return a.Due(a.policy).Before(b.Due(b.policy))
+		}
+		if a.project != b.project {
+			return a.project < b.project
+		}
+		return a.Name < b.Name
+	})
+	v.idx = maxInt(min(v.idx, len(v.rows)-1), 0)
+}
+
+// staleSecrets counts rows past their rotation policy
+func (v rotationView) staleSecrets(now time.Time) int {
+	n := 0
+	for _, r := range v.rows {
+		if r.stale(now) {
+			n++
+		}
+	}
+	return n
+}
+
+func (m Model) handleSecretsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.rotation
+	last := maxInt(len(v.rows)-1, 0)
+
+	switch msg.String() {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "r":
+		if v.idx < len(v.rows) {
+			r := v.rows[v.idx]
+			if m.dryRun {
+				return m.showPlan("Log rotation: "+r.Name, fmt.Sprintf("append %s / %s to %s", r.project, r.Name, secrets.Path()))
+			}
+			return m, logRotationCmd(r)
+		}
+	case "a":
+		return m.openActionsInventory()
+	case "ctrl+r":
+		v.loading = true
+		return m, loadSecretsCmd(m.projects)
+	}
+	return m, nil
+}
+
+// renderSecrets lists credentials with when they were rotated and when
+// they're due, flagging the stale ones
+func (m Model) renderSecrets(height int) string {
+	v := m.rotation
+	now := time.Now()
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s Secrets — %d stale  (r log rotated now, a Actions inventory, ctrl+r reload, esc back)\n\n", IconSecret, v.staleSecrets(now)))
+	if v.err != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconX, v.err))
+	}
+	if len(v.rows) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(i18n.T("  Loading secrets...\n"))
+		case v.err == "":
+			b.WriteString(i18n.T("  No secrets found. Log one with: mc secrets rotated <project> <name>\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	b.WriteString(fmt.Sprintf("     %-20s %-28s %-7s %-11s %-11s %s\n", "Project", "Secret", "Source", "Rotated", "Due", "Policy"))
+	rows := maxInt(height-5, 1)
+	start := maxInt(v.idx-rows+1, 0)
+	for i := start; i < len(v.rows) && i < start+rows; i++ {
+		r := v.rows[i]
+		mark, rotated, due := " ", "never", "now"
+		if r.stale(now) {
+			mark = IconX
+		}
+		if !r.Rotated.IsZero() {
+			rotated = r.Rotated.Format("2006-01-02")
+			due = r.Due(r.policy).Format("2006-01-02")
+		}
+		source := r.Source
+		if source == "" {
+			source = "-"
+		}
+		line := fmt.Sprintf("  %s  %-20s %-28s %-7s %-11s %-11s %s", mark, truncate(r.project, 20), truncate(r.Name, 28),
+			source, rotated, due, r.policy)
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if v.loading {
+		b.WriteString(i18n.T("  Reloading...\n"))
+	}
+	return padLines(b.String(), height)
+}
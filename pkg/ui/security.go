@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/github"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type securityMsg struct {
+	name   string
+	alerts *github.SecurityAlerts
+}
+
+func loadSecurityCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		var alerts github.SecurityAlerts
+		var err error
+		scheduleGitHub(name, func() {
+			alerts, err = discover.SecurityAlerts(path)
+		})
+		if err != nil {
+			return securityMsg{name: name}
+		}
+		return securityMsg{name: name, alerts: &alerts}
+	}
+}
+
+// securityBadge is the row's open security alert count, blank when there
+// are none so a badge stands out
+func securityBadge(p Project) string {
+	if p.Security == nil || p.Security.Total() == 0 {
+		return strings.Repeat(" ", 6)
+	}
+	return fmt.Sprintf(" %s%-2d", IconSecurity, p.Security.Total())
+}
+
+// redBadge colors a row segment red, going back to the row's foreground
+// (black on the selected row) afterwards
+func redBadge(s string, selected bool) string {
+	reset := "\033[39m"
+	if selected {
+		reset = "\033[30m"
+	}
+	return "\033[31m" + s + reset
+}
+
+// renderSecurity breaks down a project's open security alerts for the
+// detail view
+func (m Model) renderSecurity(p *Project) string {
+	if p.Security == nil || p.Security.Total() == 0 {
+		return ""
+	}
+	a := p.Security
+	return i18n.T("  %s Security: %d Dependabot, %d code scanning, %d secret scanning alerts\n",
+		IconSecurity, a.Dependabot, a.CodeScanning, a.SecretScanning)
+}
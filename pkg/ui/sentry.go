@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/sentry"
+)
+
+// errorSpikesMsg reports a round of Sentry checks: the spike of each
+// project whose errors just jumped
+type errorSpikesMsg struct {
+	spikes map[string]string
+}
+
+// checkErrorSpikesCmd compares the last hour of events of every project
+// with a "sentry" setting to its day so far. It runs with the uptime
+// probes, and does nothing without a Sentry token.
+func checkErrorSpikesCmd() tea.Msg {
+	cfg, err := config.Load()
+	if err != nil {
+		return errorSpikesMsg{}
+	}
+	client := sentry.Default()
+	if client == nil {
+		return errorSpikesMsg{}
+	}
+
+	msg := errorSpikesMsg{spikes: make(map[string]string)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, p := range cfg.Projects {
+		if p == nil {
+			continue
+		}
+		project, ok := sentry.ParseProject(p.Sentry)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			spike, ok, err := client.CheckSpike(project)
+			if err != nil || !ok {
+				return
+			}
+			mu.Lock()
+			msg.spikes[name] = spike.String()
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return msg
+}
@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+)
+
+// servicesTickInterval is how often the services view refreshes its
+// statuses, health, and logs
+const servicesTickInterval = 2 * time.Second
+
+// serviceView is the services panel for one project
+type serviceView struct {
+	project string
+	path    string
+	defs    []config.Service
+	idx     int
+
+	// portsChecked lets the next start go ahead despite the port
+	// conflicts it was warned about
+	portsChecked bool
+}
+
+type servicesTickMsg struct {
+	project string
+}
+
+// servicesTickCmd checks health, then asks for a redraw
+func servicesTickCmd(mgr *services.Manager, project string) tea.Cmd {
+	return tea.Tick(servicesTickInterval, func(time.Time) tea.Msg {
+		mgr.CheckHealth(project)
+		return servicesTickMsg{project: project}
+	})
+}
+
+// serviceCmd starts or stops services off the UI goroutine, reporting
+// through actionResultMsg so it lands in the audit log
+func serviceCmd(mgr *services.Manager, op, project, path string, defs []config.Service) tea.Cmd {
+	return func() tea.Msg {
+		var names []string
+		for _, d := range defs {
+			names = append(names, d.Name)
+		}
+		var err error
+		switch op {
+		case "start":
+			err = mgr.StartAll(project, path, defs)
+		case "stop":
+			for _, d := range defs {
+				if e := mgr.Stop(project, d.Name); e != nil && err == nil {
+					err = fmt.Errorf("%s: %w", d.Name, e)
+				}
+			}
+		case "restart":
+			for _, d := range defs {
+				if e := mgr.Stop(project, d.Name); e != nil && err == nil {
+					err = fmt.Errorf("%s: %w", d.Name, e)
+				}
+			}
+			if err == nil {
+				err = mgr.StartAll(project, path, defs)
+			}
+		}
+		if err != nil {
+			return actionResultMsg{action: "service", project: project,
+				message: fmt.Sprintf("Service %s failed: %v", op, err)}
+		}
+		return actionResultMsg{action: "service", project: project, success: true,
+			message: fmt.Sprintf("%s %s: %s", strings.Title(op), project, strings.Join(names, ", "))}
+	}
+}
+
+// openServices shows the selected project's services
+func (m Model) openServices() (tea.Model, tea.Cmd) {
+	if len(m.filtered) == 0 {
+		return m, nil
+	}
+	p := m.filtered[m.selectedIdx]
+	cfg, _ := config.Load()
+	defs := cfg.Project(p.Name).Services
+	if len(defs) == 0 {
+		m.statusMsg = i18n.T("No services for %s (mc service add %s <name> <command>)", p.Name, p.Name)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	m.svcView = serviceView{project: p.Name, path: expandPath(p.Path), defs: defs}
+	m.viewMode = ServicesView
+	return m, servicesTickCmd(m.svcs, p.Name)
+}
+
+// runServices starts, stops, or restarts defs, or shows the plan in dry-run
+func (m Model) runServices(op string, defs []config.Service) (tea.Model, tea.Cmd) {
+	v := m.svcView
+	if len(defs) == 0 {
+		return m, nil
+	}
+	if m.dryRun {
+		var steps []string
+		for _, d := range defs {
+			dir := v.path
+			if d.Dir != "" {
+				dir = filepath.Join(dir, d.Dir)
+			}
+			if op != "start" {
+				steps = append(steps, fmt.Sprintf("# stop %s (SIGTERM its process group)", d.Name))
+			}
+			if op != "stop" {
+				steps = append(steps, envPlan(dir, "cd "+shellCommand(dir)+" && "+shellCommand("sh", "-c", d.Command))...)
+			}
+		}
+		return m.showPlan(fmt.Sprintf("%s services in %s", strings.Title(op), v.project), steps...)
+	}
+	if op != "stop" && !v.portsChecked {
+		return m, checkPortsCmd(m.projectDirs(), v.project, op, defs)
+	}
+	m.svcView.portsChecked = false
+	return m, serviceCmd(m.svcs, op, v.project, v.path, defs)
+}
+
+func (m Model) handleServicesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.svcView
+	statuses := m.svcs.Statuses(v.project, v.defs)
+
+	switch msg.String() {
+	case "j", "down":
+		if v.idx < len(v.defs)-1 {
+			v.idx++
+			v.portsChecked = false
+		}
+	case "k", "up":
+		if v.idx > 0 {
+			v.idx--
+			v.portsChecked = false
+		}
+	case "enter", "s":
+		// Toggle the selected service
+		if v.idx >= len(statuses) {
+			return m, nil
+		}
+		op := "start"
+		if statuses[v.idx].State == services.Running {
+			op = "stop"
+		}
+		return m.runServices(op, v.defs[v.idx:v.idx+1])
+	case "r":
+		if v.idx < len(v.defs) {
+			return m.runServices("restart", v.defs[v.idx:v.idx+1])
+		}
+	case "a":
+		return m.runServices("start", v.defs)
+	case "x":
+		return m.runServices("stop", v.defs)
+	}
+	return m, nil
+}
+
+// renderServiceSummary notes running services in the detail view
+func (m Model) renderServiceSummary(name string) string {
+	if n := m.svcs.Running(name); n > 0 {
+		return i18n.T("  Services: %d running (F to manage)\n", n)
+	}
+	return ""
+}
+
+// renderServices lists a project's services with their state and health
+// above their interleaved output
+func (m Model) renderServices(height int) string {
+	v := m.svcView
+	statuses := m.svcs.Statuses(v.project, v.defs)
+
+	var b strings.Builder
+	b.WriteString(i18n.T("\n  %s Services — %s  (enter start/stop, r restart, a start all, x stop all, esc back)\n\n", IconPlay, v.project))
+
+	width := 0
+	for _, d := range v.defs {
+		width = max(width, len(d.Name))
+	}
+	for i, s := range statuses {
+		state := i18n.T(s.State.String())
+		switch s.State {
+		case services.Running:
+			state = fmt.Sprintf("%s %s  pid %d, up %s", IconPlay, state, s.PID, strings.TrimSpace(formatTimeSince(s.Started)))
+		case services.Failed:
+			state = fmt.Sprintf("%s %s: %v", IconX, state, s.Err)
+		default:
+			state = IconPause + " " + state
+		}
+		health := ""
+		switch {
+		case s.Service.Health == "" || s.State != services.Running:
+		case s.Checked.IsZero():
+			health = "  " + i18n.T("health: checking")
+		case s.Health != nil:
+			health = fmt.Sprintf("  %s %v", IconX, s.Health)
+		default:
+			health = "  " + IconCheck + " " + i18n.T("healthy")
+		}
+		line := fmt.Sprintf("  %-*s  %s%s", width, s.Service.Name, state, health)
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	// Logs fill the rest, newest at the bottom
+	rows := height - len(statuses) - 6
+	if rows > 0 {
+		b.WriteString("\n")
+		logs := m.svcs.Logs(v.project, rows)
+		if len(logs) == 0 {
+			b.WriteString(i18n.T("  No output yet\n"))
+		}
+		for _, l := range logs {
+			b.WriteString(truncate(fmt.Sprintf("  %-*s | %s", width, l.Service, plainText(l.Text)), maxInt(m.width-2, 20)) + "\n")
+		}
+	}
+	return padLines(b.String(), height)
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// plainText makes a line of command output safe to lay out: colors and
+// other escapes removed, only what follows the last carriage return (the
+// final state of a progress bar), and tabs expanded
+func plainText(s string) string {
+	s = strings.TrimRight(s, "\r")
+	if i := strings.LastIndex(s, "\r"); i >= 0 {
+		s = s[i+1:]
+	}
+	s = ansiPattern.ReplaceAllString(s, "")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case r < ' ' || r == 0x7f:
+			return -1
+		}
+		return r
+	}, s)
+}
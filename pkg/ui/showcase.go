@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/showcase"
+)
+
+type showcaseMsg struct {
+	project string
+	url     string
+	result  *showcase.Result
+	err     error
+}
+
+// productionURL is the site a project serves: the host of its uptime
+// setting, else its latest live production deploy, else its Vercel
+// domain. It is "" for projects with none of them.
+func (m Model) productionURL(p *Project) string {
+	cfg, _ := config.Load()
+	if target := cfg.Project(p.Name).Uptime; target != "" {
+		if !strings.Contains(target, "://") {
+			target = "https://" + target
+		}
+		if u, err := url.Parse(target); err == nil && u.Host != "" {
+			return u.Scheme + "://" + u.Host + "/"
+		}
+	}
+	for _, d := range m.deployHistory[p.Name].deploys {
+		if d.Target == "production" && d.State == "ready" && d.URL != "" {
+			return "https://" + d.URL + "/"
+		}
+	}
+	if p.Type == TypeVercel {
+		// Vercel projects are named after their production domain
+		return "https://" + p.Name + "/"
+	}
+	return ""
+}
+
+func showcaseCmd(name, path, site string) tea.Cmd {
+	return func() tea.Msg {
+		res, err := showcase.Capture(expandPath(path), site)
+		return showcaseMsg{project: name, url: site, result: res, err: err}
+	}
+}
+
+// startShowcase saves a fresh screenshot and scroll-through GIF of the
+// project's production site into its assets and relinks its README
+func (m Model) startShowcase(p *Project) (tea.Model, tea.Cmd) {
+	site := m.productionURL(p)
+	if site == "" {
+		m.statusMsg = i18n.T("No production URL for %s (set \"uptime\" in config.json)", p.Name)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	if m.dryRun {
+		dir := expandPath(p.Path)
+		shot, demo := showcase.Targets(dir, "")
+		return m.showPlan("Capture "+site,
+			fmt.Sprintf("load %s in headless Chrome at %dx%d", site, showcase.Width, showcase.Height),
+			"write the screenshot and a scroll-through GIF into "+shellCommand(dir)+" (the README's images, else "+shot+" and "+demo+")",
+			"point README.md's screenshot and GIF links at them, adding a screenshot when it has none")
+	}
+	m.statusMsg = i18n.T("Capturing %s in headless Chrome...", site)
+	m.statusMsgTime = time.Now()
+	return m, showcaseCmd(p.Name, p.Path, site)
+}
+
+// setShowcase reports what a capture wrote; the files show up as changes
+// to commit
+func (m Model) setShowcase(msg showcaseMsg) (tea.Model, tea.Cmd) {
+	m.statusMsgTime = time.Now()
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Capture of %s failed: %v", msg.url, msg.err)
+		return m, nil
+	}
+	r := msg.result
+	m.statusMsg = i18n.T("Saved %s and %s", r.Screenshot, r.GIF)
+	if r.Readme {
+		m.statusMsg += i18n.T(", README links updated")
+	}
+	p := m.getProjectByName(msg.project)
+	if p == nil {
+		return m, nil
+	}
+	return m, loadGitStatusCmd(p.Name, filepath.Clean(expandPath(p.Path)))
+}
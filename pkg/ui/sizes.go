@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+// sizeTrendBuilds is how many recorded builds the size sparkline covers
+const sizeTrendBuilds = 20
+
+type sizesMsg struct {
+	project string
+	report  *discover.SizeReport
+	err     error
+}
+
+// loadSizesCmd measures a project's built artifacts, recording any
+// rebuilt since they were last measured
+func loadSizesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		report, err := discover.ArtifactSizes(path)
+		return sizesMsg{project: name, report: report, err: err}
+	}
+}
+
+// setSizes keeps a project's artifact sizes, warning when a build just
+// went over its budget
+func (m Model) setSizes(msg sizesMsg) Model {
+	if msg.report == nil {
+		delete(m.sizes, msg.project)
+		return m
+	}
+	if m.sizes == nil {
+		m.sizes = make(map[string]*discover.SizeReport)
+	}
+	m.sizes[msg.project] = msg.report
+	if len(msg.report.Crossed) > 0 {
+		m.statusMsg = i18n.T("%s: %s went over its size budget", msg.project, strings.Join(msg.report.Crossed, ", "))
+		m.statusMsgTime = time.Now()
+	}
+	return m
+}
+
+// sizeTrend is an arrow and the change from the build before: "↑ +1.2
+// MB", "↓ -40.0 KB", or "=" when unchanged or first recorded
+func sizeTrend(a discover.ArtifactSize) string {
+	prev := a.Previous()
+	switch {
+	case prev == 0 || prev == a.Size:
+		return "="
+	case a.Size > prev:
+		return "↑ +" + formatSize(a.Size-prev)
+	}
+	return "↓ -" + formatSize(prev-a.Size)
+}
+
+// sizeSparkline draws the artifact's size over its recent builds, scaled
+// between the smallest and largest so small changes still show
+func sizeSparkline(history []int64) string {
+	history = history[maxInt(len(history)-sizeTrendBuilds, 0):]
+	if len(history) < 2 {
+		return ""
+	}
+	lo := history[0]
+	for _, v := range history {
+		lo = min(lo, v)
+	}
+	values := make([]int, len(history))
+	for i, v := range history {
+		// Keep the smallest visible rather than blank
+		values[i] = int((v-lo)>>10) + 1
+	}
+	return RenderSparkline(values)
+}
+
+// renderSizes shows the sizes of a project's built artifacts in the
+// detail view, with their trend and budget
+func (m Model) renderSizes(name string) string {
+	report := m.sizes[name]
+	if report == nil || len(report.Artifacts) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, a := range report.Artifacts {
+		line := fmt.Sprintf("%s %s  %s", a.Name, formatSize(a.Size), sizeTrend(a))
+		if spark := sizeSparkline(a.History); spark != "" {
+			line += "  " + spark
+		}
+		if a.Budget > 0 {
+			line += i18n.T("  (budget %s)", formatSize(a.Budget))
+		}
+		if a.OverBudget() {
+			line = driftStyle.Render(fmt.Sprintf("%s %s", IconConflict, line+i18n.T(" over budget by %s", formatSize(a.Size-a.Budget))))
+		}
+		b.WriteString(i18n.T("  Size: %s\n", line))
+	}
+	return b.String()
+}
@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/snippets"
+)
+
+// snippetsView browses a project's saved chat answers
+type snippetsView struct {
+	project     string
+	path        string
+	idx         int
+	dropPending bool
+}
+
+type snippetsMsg struct {
+	project string
+	items   []snippets.Snippet
+	err     error
+}
+
+type snippetSavedMsg struct {
+	snippet snippets.Snippet
+	err     error
+}
+
+func loadSnippetsCmd(project string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := snippets.ForProject(project)
+		return snippetsMsg{project: project, items: items, err: err}
+	}
+}
+
+func saveSnippetCmd(project, name, prompt, text string) tea.Cmd {
+	return func() tea.Msg {
+		s, err := snippets.Add(project, name, prompt, text)
+		return snippetSavedMsg{snippet: s, err: err}
+	}
+}
+
+func removeSnippetCmd(s snippets.Snippet) tea.Cmd {
+	return func() tea.Msg {
+		if err := snippets.Remove(s.ID); err != nil {
+			return snippetSavedMsg{err: err}
+		}
+		return loadSnippetsCmd(s.Project)()
+	}
+}
+
+// chatProject returns the project the chat is in, nil in ~/Projects
+func (m Model) chatProject() *Project {
+	for i := range m.projects {
+		if expandPath(m.projects[i].Path) == m.chatCwd {
+			return &m.projects[i]
+		}
+	}
+	return nil
+}
+
+// startSaveSnippet asks for a name to save the chat's answer under
+func (m Model) startSaveSnippet() (tea.Model, tea.Cmd) {
+	if m.chatResponse == "" {
+		return m, nil
+	}
+	p := m.chatProject()
+	if p == nil {
+		m.statusMsg = i18n.T("Snippets belong to a project: chat in one with c to save answers")
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	m.snippetProject = p.Name
+	m.snippetName.SetValue("")
+	m.snippetName.Focus()
+	return m, textinput.Blink
+}
+
+// handleSnippetNameKey reads the new snippet's name
+func (m Model) handleSnippetNameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.snippetProject = ""
+		m.snippetName.Blur()
+		return m, nil
+	case "enter":
+		project, name := m.snippetProject, strings.TrimSpace(m.snippetName.Value())
+		m.snippetProject = ""
+		m.snippetName.Blur()
+		if name == "" {
+			return m, nil
+		}
+		return m, saveSnippetCmd(project, name, m.chatPrompt, m.chatResponse)
+	}
+	var cmd tea.Cmd
+	m.snippetName, cmd = m.snippetName.Update(msg)
+	return m, cmd
+}
+
+// setSnippets keeps a project's snippets
+func (m Model) setSnippets(msg snippetsMsg) Model {
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Snippets: %v", msg.err)
+		m.statusMsgTime = time.Now()
+		return m
+	}
+	if m.projectSnippets == nil {
+		m.projectSnippets = make(map[string][]snippets.Snippet)
+	}
+	m.projectSnippets[msg.project] = msg.items
+	if m.snippetsView.project == msg.project {
+		m.snippetsView.idx = maxInt(min(m.snippetsView.idx, len(m.visibleSnippets())-1), 0)
+	}
+	return m
+}
+
+// setSnippetSaved reports a saved snippet and rereads its project's
+func (m Model) setSnippetSaved(msg snippetSavedMsg) (tea.Model, tea.Cmd) {
+	m.statusMsgTime = time.Now()
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Snippet not saved: %v", msg.err)
+		return m, nil
+	}
+	m.statusMsg = i18n.T("Saved snippet %q to %s", msg.snippet.Name, msg.snippet.Project)
+	return m, loadSnippetsCmd(msg.snippet.Project)
+}
+
+// openSnippets browses a project's snippets
+func (m Model) openSnippets(p Project) (tea.Model, tea.Cmd) {
+	m.snippetsView = snippetsView{project: p.Name, path: p.Path}
+	m.snippetFilter.SetValue("")
+	m.snippetFilter.Blur()
+	m.viewMode = SnippetsView
+	return m, loadSnippetsCmd(p.Name)
+}
+
+// visibleSnippets returns the project's snippets matching the search
+func (m Model) visibleSnippets() []snippets.Snippet {
+	return snippets.Search(m.projectSnippets[m.snippetsView.project], m.snippetFilter.Value())
+}
+
+// insertSnippet opens the chat in the snippet's project with it attached
+// to the next message
+func (m Model) insertSnippet(s snippets.Snippet) (tea.Model, tea.Cmd) {
+	m.chatContext = &s
+	m.chatCwd = expandPath(m.snippetsView.path)
+	m.chatResponse = ""
+	m.chatError = ""
+	m.viewMode = ChatMode
+	m.chatInput.Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) handleSnippetsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.snippetsView
+	items := m.visibleSnippets()
+	last := maxInt(len(items)-1, 0)
+
+	key := msg.String()
+	if key != "x" {
+		v.dropPending = false
+	}
+	switch key {
+	case "j", "down":
+		v.idx = min(v.idx+1, last)
+	case "k", "up":
+		v.idx = maxInt(v.idx-1, 0)
+	case "g":
+		v.idx = 0
+	case "G":
+		v.idx = last
+	case "/":
+		m.snippetFilter.Focus()
+		return m, textinput.Blink
+	case "enter", "i":
+		if v.idx < len(items) {
+			return m.insertSnippet(items[v.idx])
+		}
+	case "y":
+		if v.idx < len(items) {
+			return m, copyToClipboardCmd(items[v.idx].Text, items[v.idx].Name)
+		}
+	case "x":
+		if v.idx >= len(items) {
+			return m, nil
+		}
+		if !v.dropPending {
+			v.dropPending = true
+			m.statusMsg = i18n.T("Press x again to delete %q", items[v.idx].Name)
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		v.dropPending = false
+		return m, removeSnippetCmd(items[v.idx])
+	}
+	return m, nil
+}
+
+// handleSnippetFilterKey edits the snippet search: enter keeps it, esc
+// clears it
+func (m Model) handleSnippetFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.snippetFilter.Blur()
+		return m, nil
+	case "esc":
+		m.snippetFilter.SetValue("")
+		m.snippetFilter.Blur()
+		m.snippetsView.idx = 0
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.snippetFilter, cmd = m.snippetFilter.Update(msg)
+	m.snippetsView.idx = 0
+	return m, cmd
+}
+
+// renderSnippetSummary names a project's snippets in the detail view
+func (m Model) renderSnippetSummary(name string) string {
+	items := m.projectSnippets[name]
+	if len(items) == 0 {
+		return ""
+	}
+	names := make([]string, len(items))
+	for i, s := range items {
+		names[i] = s.Name
+	}
+	return truncate(i18n.T("  Snippets: %s (n to browse)", strings.Join(names, ", ")), maxInt(m.width-4, 20)) + "\n"
+}
+
+// renderSnippets lists a project's snippets with the selected one's text
+// below
+func (m Model) renderSnippets(height int) string {
+	v := m.snippetsView
+	items := m.visibleSnippets()
+	var b strings.Builder
+
+	b.WriteString(i18n.T("\n  %s Snippets — %s: %d  (/ search, enter/i insert into chat, y copy, x delete, esc back)\n",
+		IconTodo, v.project, len(m.projectSnippets[v.project])))
+	if m.snippetFilter.Focused() || m.snippetFilter.Value() != "" {
+		b.WriteString("  / " + m.snippetFilter.View() + "\n")
+	}
+	b.WriteString("\n")
+	if len(items) == 0 {
+		if m.snippetFilter.Value() != "" {
+			b.WriteString(i18n.T("  No snippets match\n"))
+		} else {
+			b.WriteString(i18n.T("  No snippets yet: ask in a project's chat (c), then ctrl+s saves the answer\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	rows := min(len(items), maxInt(height/3, 3))
+	start := maxInt(v.idx-rows+1, 0)
+	for i := start; i < len(items) && i < start+rows; i++ {
+		s := items[i]
+		prompt, _, _ := strings.Cut(s.Prompt, "\n")
+		line := fmt.Sprintf("  %-24s %4s  %s", s.Name, strings.TrimSpace(formatTimeSince(s.Created)), prompt)
+		line = truncate(line, maxInt(m.width-4, 20))
+		if i == v.idx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if v.idx < len(items) {
+		b.WriteString("\n")
+		for _, line := range strings.Split(items[v.idx].Text, "\n") {
+			b.WriteString(truncate("  "+line, maxInt(m.width-4, 20)) + "\n")
+		}
+	}
+	return padLines(b.String(), height)
+}
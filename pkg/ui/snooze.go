@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/maintenance"
+	"github.com/michaelmonetized/mission-control/pkg/snooze"
+)
+
+// snoozeCheckInterval is how often expired snoozes are looked for
+const snoozeCheckInterval = time.Minute
+
+type snoozeMsg struct {
+	entries     []snooze.Entry
+	expired     []snooze.Entry // snoozes that just ran out
+	maintenance []maintenance.Window
+	err         error
+}
+
+type snoozeTickMsg struct{}
+
+func loadSnoozeCmd() tea.Msg {
+	expired, err := snooze.Expire(time.Now())
+	if err != nil {
+		return snoozeMsg{err: err}
+	}
+	entries, err := snooze.Load()
+	if err != nil {
+		return snoozeMsg{err: err}
+	}
+	windows, err := maintenance.Load()
+	return snoozeMsg{entries: entries, expired: expired, maintenance: windows, err: err}
+}
+
+func snoozeTickCmd() tea.Cmd {
+	return tea.Tick(snoozeCheckInterval, func(time.Time) tea.Msg {
+		return snoozeTickMsg{}
+	})
+}
+
+func addSnoozeCmd(project, alert string, until time.Time) tea.Cmd {
+	return func() tea.Msg {
+		if err := snooze.Add(project, alert, until); err != nil {
+			return actionResultMsg{action: "snooze", project: project, message: "Snooze: " + err.Error()}
+		}
+		label := snooze.Entry{Project: project, Alert: alert}.Label()
+		return actionResultMsg{
+			action:  "snooze",
+			project: project,
+			success: true,
+			message: fmt.Sprintf("Snoozed %s until %s", label, until.Format("Mon Jan 2 15:04")),
+		}
+	}
+}
+
+func removeSnoozeCmd(project string) tea.Cmd {
+	return func() tea.Msg {
+		if err := snooze.Remove(project); err != nil {
+			return actionResultMsg{action: "snooze", project: project, message: "Unsnooze: " + err.Error()}
+		}
+		return actionResultMsg{action: "snooze", project: project, success: true, message: "Unsnoozed " + project}
+	}
+}
+
+// setSnoozes applies a freshly loaded snooze list and announces any that
+// just expired
+func (m *Model) setSnoozes(msg snoozeMsg) {
+	if msg.err != nil {
+		m.statusMsg = i18n.T("Snooze: %v", msg.err)
+		m.statusMsgTime = time.Now()
+		return
+	}
+	m.snoozed = snooze.Active(msg.entries, time.Now())
+	// Maintenance windows mute alerts the same way
+	m.maintenance = msg.maintenance
+	maintenance.Mute(m.snoozed, m.maintenance, time.Now())
+	if len(msg.expired) > 0 {
+		labels := make([]string, 0, len(msg.expired))
+		for _, e := range msg.expired {
+			labels = append(labels, e.Label())
+		}
+		m.statusMsg = i18n.T("Snoozed items returned: %s", strings.Join(labels, ", "))
+		m.statusMsgTime = time.Now()
+	}
+	m.updateStats()
+}
+
+// startSnooze opens the snooze prompt for a project
+func (m Model) startSnooze(p Project) (tea.Model, tea.Cmd) {
+	m.snoozeProject = p.Name
+	m.snoozeInput.SetValue("")
+	m.snoozeInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleSnoozeKey reads "[alert] <until>", e.g. "3d" or "deploy monday"
+func (m Model) handleSnoozeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.snoozeProject = ""
+		m.snoozeInput.Blur()
+		return m, nil
+	case "enter":
+		project := m.snoozeProject
+		fields := strings.Fields(m.snoozeInput.Value())
+		m.snoozeProject = ""
+		m.snoozeInput.Blur()
+		if len(fields) == 0 {
+			return m, nil
+		}
+
+		alert := ""
+		if snooze.IsAlert(strings.ToLower(fields[0])) {
+			alert = strings.ToLower(fields[0])
+			fields = fields[1:]
+		}
+		until, err := snooze.ParseUntil(strings.Join(fields, " "), time.Now())
+		if err != nil {
+			m.statusMsg = err.Error()
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		return m, addSnoozeCmd(project, alert, until)
+	}
+
+	var cmd tea.Cmd
+	m.snoozeInput, cmd = m.snoozeInput.Update(msg)
+	return m, cmd
+}
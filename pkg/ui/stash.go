@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type stashesMsg struct {
+	project string
+	stashes []discover.Stash
+}
+
+func loadStashesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		stashes, _ := discover.ListStashes(path)
+		return stashesMsg{project: name, stashes: stashes}
+	}
+}
+
+// gitStashCmd runs `git stash apply|drop <ref>`
+func gitStashCmd(projectName, projectPath, op string, stash discover.Stash) tea.Cmd {
+	return func() tea.Msg {
+		output, err := exec.Command("git", "-C", projectPath, "stash", op, stash.Ref()).CombinedOutput()
+		if err != nil {
+			msg := strings.TrimSpace(string(output))
+			if msg == "" {
+				msg = err.Error()
+			}
+			msg, _, _ = strings.Cut(msg, "\n")
+			return actionResultMsg{
+				action:  "git_stash",
+				project: projectName,
+				success: false,
+				message: fmt.Sprintf("stash %s failed: %s", op, msg),
+			}
+		}
+
+		verb := "Applied"
+		if op == "drop" {
+			verb = "Dropped"
+		}
+		return actionResultMsg{
+			action:  "git_stash",
+			project: projectName,
+			success: true,
+			message: fmt.Sprintf("%s %s in %s", verb, stash.Ref(), projectName),
+		}
+	}
+}
+
+// handleDetailKey handles the detail view's own keys (log tab, stashes,
+// worktrees, briefing, SBOM, benchmarks, deploy); everything else
+// behaves as in the list
+func (m Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.currentProject == nil {
+		return m.handleListKey(msg)
+	}
+	if msg.String() == "tab" {
+		switch m.detailTab {
+		case detailOverview:
+			return m.openCommitLog()
+		case detailLog:
+			return m.openFileList()
+		}
+		m.detailTab = detailOverview
+		return m, nil
+	}
+	switch m.detailTab {
+	case detailLog:
+		return m.handleLogKey(msg)
+	case detailFiles:
+		return m.handleFilesKey(msg)
+	}
+
+	p := m.currentProject
+	stashes := m.stashes[p.Name]
+
+	key := msg.String()
+	if key != "x" {
+		m.stashDropPending = false
+	}
+
+	switch key {
+	case "j", "down":
+		if m.stashIdx < len(stashes)-1 {
+			m.stashIdx++
+		}
+		return m, nil
+	case "k", "up":
+		if m.stashIdx > 0 {
+			m.stashIdx--
+		}
+		return m, nil
+	case "W":
+		return m, m.startBriefing(p)
+	case "m":
+		return m.startSBOM(p)
+	case "%":
+		return m.startBench(p)
+	case "y":
+		return m.executeAction(ActionDeploy, *p)
+	case "h":
+		return m.openDeploys(*p)
+	case "n":
+		return m.openSnippets(*p)
+	case "=":
+		return m.fixReadme(p)
+	case "#":
+		return m.startShowcase(p)
+	case "[", "]":
+		if n := len(m.worktrees[p.Name]); n > 1 {
+			delta := 1
+			if key == "[" {
+				delta = n - 1
+			}
+			m.worktreeIdx = (m.worktreeIdx + delta) % n
+		}
+		return m, nil
+	case "o", "l":
+		if wt, ok := m.selectedWorktree(p.Name); ok && !wt.Missing {
+			if key == "o" {
+				return m, openInEditorCmd(wt.Path, "")
+			}
+			return m, openLazygitCmd(wt.Path)
+		}
+	case "a":
+		if m.stashIdx < len(stashes) {
+			if m.dryRun {
+				return m.showPlan("Apply stash in "+p.Name, shellCommand("git", "-C", expandPath(p.Path), "stash", "apply", stashes[m.stashIdx].Ref()))
+			}
+			return m, gitStashCmd(p.Name, expandPath(p.Path), "apply", stashes[m.stashIdx])
+		}
+		return m, nil
+	case "x":
+		if m.stashIdx >= len(stashes) {
+			return m, nil
+		}
+		// Dropping can't be undone from here - require a second press
+		if !m.stashDropPending {
+			m.stashDropPending = true
+			m.statusMsg = i18n.T("Press x again to drop %s", stashes[m.stashIdx].Ref())
+			m.statusMsgTime = time.Now()
+			return m, nil
+		}
+		m.stashDropPending = false
+		if m.dryRun {
+			return m.showPlan("Drop stash in "+p.Name, shellCommand("git", "-C", expandPath(p.Path), "stash", "drop", stashes[m.stashIdx].Ref()))
+		}
+		return m, gitStashCmd(p.Name, expandPath(p.Path), "drop", stashes[m.stashIdx])
+	}
+
+	return m.handleListKey(msg)
+}
+
+// renderStashes lists the project's stashes with the selection marker
+func (m Model) renderStashes(name string) string {
+	stashes := m.stashes[name]
+	if len(stashes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\n  %s Stashes (%d)  j/k select, a apply, x drop\n", IconStash, len(stashes)))
+	// Show a window of stashes that keeps the selection visible
+	const shown = 8
+	start := maxInt(m.stashIdx-shown+1, 0)
+	for i := start; i < len(stashes) && i < start+shown; i++ {
+		s := stashes[i]
+		cursor := " "
+		if i == m.stashIdx {
+			cursor = ">"
+		}
+		b.WriteString(fmt.Sprintf("  %s %-10s %4s  %s\n",
+			cursor, s.Ref(), strings.TrimSpace(formatTimeSince(s.When)), truncate(s.Message, maxInt(m.width-26, 10))))
+	}
+	if more := len(stashes) - start - shown; more > 0 {
+		b.WriteString(fmt.Sprintf("    … %d more\n", more))
+	}
+	return b.String()
+}
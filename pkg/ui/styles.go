@@ -22,11 +22,12 @@ var (
 	ColorGray    = lipgloss.Color("8")
 
 	// Semantic colors
-	ColorMint   = lipgloss.Color("#98c379") // Title
-	ColorVercel = lipgloss.Color("#e5c07b") // Yellow for Vercel
-	ColorSwift  = lipgloss.Color("#c678dd") // Magenta for Swift
-	ColorGit    = lipgloss.Color("#56b6c2") // Cyan for Git
-	ColorGH     = lipgloss.Color("#98c379") // Green for GitHub
+	ColorMint    = lipgloss.Color("#98c379") // Title
+	ColorVercel  = lipgloss.Color("#e5c07b") // Yellow for Vercel
+	ColorNetlify = lipgloss.Color("#61afef") // Blue for Netlify
+	ColorSwift   = lipgloss.Color("#c678dd") // Magenta for Swift
+	ColorGit     = lipgloss.Color("#56b6c2") // Cyan for Git
+	ColorGH      = lipgloss.Color("#98c379") // Green for GitHub
 )
 
 // =============================================================================
@@ -61,6 +62,7 @@ const (
 
 	// Vercel build status
 	IconVercel       = "\ue8d3"      // U+E8D3 dev-vercel
+	IconNetlify      = "\U000f0167"  // U+F0167 md-cloud_upload
 	IconReady        = "\U000f0063"  // U+F0063 md-arrow_up_drop_circle_outline
 	IconBuilding     = "\U000f1adf"  // U+F1ADF md-timer_pause_outline
 	IconQueued       = "\uead8"      // U+EAD8 cod-debug
@@ -73,14 +75,25 @@ const (
 
 	// Git status
 	IconGit       = "\ue702"      // U+E702 dev-git
+	IconBranch    = "\ue725"      // U+E725 dev-git_branch
 	IconStaged    = "\U000f1a9e"  // U+F1A9E md-file_document_plus_outline
 	IconUntracked = "\uf262"      // U+F262 fa-firstdraft
 	IconModified  = "\uf459"      // U+F459 oct-diff-modified
+	IconAhead     = "\uf431"      // U+F431 oct-arrow_up (unpushed commits)
+	IconBehind    = "\uf433"      // U+F433 oct-arrow_down (unpulled commits)
+	IconStash     = "\uf187"      // U+F187 fa-archive (stash entries)
+	IconTag       = "\uf412"      // U+F412 oct-tag (latest release)
 
 	// GitHub status
-	IconGitHub = "\ueb00" // U+EB00 cod-github_alt
-	IconIssue  = "\uf41b" // U+F41B oct-issue_opened
-	IconPR     = "\uf407" // U+F407 oct-git_pull_request
+	IconGitHub    = "\ueb00" // U+EB00 cod-github_alt
+	IconIssue     = "\uf41b" // U+F41B oct-issue_opened
+	IconPR        = "\uf407" // U+F407 oct-git_pull_request
+	IconMilestone = "\uf45d" // U+F45D oct-milestone
+	IconSecurity  = "\uf49c" // U+F49C oct-shield (open security alerts)
+	IconStar      = "\uf41e" // U+F41E oct-star
+	IconFork      = "\uf402" // U+F402 oct-repo_forked
+	IconWatch     = "\uf441" // U+F441 oct-eye (watchers)
+	IconPackage   = "\uf487" // U+F487 oct-package (artifacts and release assets)
 
 	// Project row action buttons
 	IconPush     = "\uf403" // U+F403 oct-repo_push
@@ -103,8 +116,17 @@ const (
 	IconCoins     = "\uede8" // U+EDE8 fa-coins
 
 	// Misc
-	IconSearch = "\uf422" // U+F422 oct-search
-	IconTime   = "\uf43a" // U+F43A oct-clock
+	IconSearch    = "\uf422"     // U+F422 oct-search
+	IconTime      = "\uf43a"     // U+F43A oct-clock
+	IconWrench    = "\uf0ad"     // U+F0AD fa-wrench (maintenance chores)
+	IconSnooze    = "\U000f04b2" // U+F04B2 md-sleep (snoozed project)
+	IconConflict  = "\uf071"     // U+F071 fa-warning (merge conflicts)
+	IconToolchain = "\U000f1322" // U+F1322 md-hammer_wrench (pinned runtime missing/mismatched)
+	IconIncident  = "\U000f0238" // U+F0238 md-fire (open incident)
+	IconSecret    = "\U000f030b" // U+F030B md-key (credentials)
+	IconMaintain  = "\U000f19a3" // U+F19A3 md-wrench_clock (maintenance window)
+	IconPriority  = "\uf140"     // U+F140 fa-bullseye (work on these next)
+	IconWorker    = "\uf085"     // U+F085 fa-cogs (background workers and queues)
 
 	// Time/commit icons
 	IconCommitStart = "\U000f071d" // U+F071D md-source_commit_start (first commit/project age)
@@ -282,4 +304,36 @@ func RenderScrollbar(current, total, height int) string {
 	return sb
 }
 
+// sparkBlocks are the eighth-height block glyphs used by RenderSparkline
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// RenderSparkline renders values as a one-line block sparkline,
+// scaled to the largest value. Zero renders as a blank cell.
+func RenderSparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		if v <= 0 || peak == 0 {
+			sb.WriteRune(' ')
+			continue
+		}
+		idx := v * (len(sparkBlocks) - 1) / peak
+		sb.WriteRune(sparkBlocks[idx])
+	}
+	return sb.String()
+}
+
+// RenderProgressBar renders done out of total as a bar width cells wide
+func RenderProgressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(done*width/total, width)
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
 // max is a Go 1.21+ builtin - no local helper needed
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderSubmodules lists a project's submodules for the detail view,
+// flagging ones that are dirty or not at the recorded commit
+func (m Model) renderSubmodules(p *Project) string {
+	if len(p.Submodules) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("  Submodules: %d (%d dirty, %d out of date)\n",
+		len(p.Submodules), p.SubmodulesDirty, p.SubmodulesOutOfDate))
+	for _, s := range p.Submodules {
+		var state []string
+		switch {
+		case !s.Initialized:
+			state = append(state, "not initialized")
+		case s.OutOfSync:
+			state = append(state, "checkout differs from recorded commit")
+		}
+		if s.Dirty {
+			state = append(state, "uncommitted changes")
+		}
+
+		mark := IconCheck
+		if len(state) > 0 {
+			mark = IconX
+		}
+		line := fmt.Sprintf("    %s %s", mark, s.Path)
+		if len(state) > 0 {
+			line += " - " + strings.Join(state, ", ")
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
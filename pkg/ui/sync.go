@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+// syncState is an in-flight push or pull
+type syncState struct {
+	op   string // "push" or "pull"
+	line string // latest progress line from git
+}
+
+type syncProgressMsg struct {
+	project string
+	op      string
+	line    string
+	ch      <-chan tea.Msg
+}
+
+type syncDoneMsg struct {
+	project string
+	op      string
+	err     error
+	detail  string // last line of git output, for failures
+	status  *discover.GitStatus
+}
+
+// startSyncCmd runs `git push` or `git pull` for a project, streaming
+// git's progress output back as syncProgressMsgs and finishing with a
+// syncDoneMsg carrying the refreshed status.
+func startSyncCmd(projectName, projectPath, op string) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg, 16)
+		go runSync(ch, projectName, projectPath, op)
+		return <-ch
+	}
+}
+
+// waitSyncCmd receives the next message from a running sync
+func waitSyncCmd(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// syncArgs returns the git arguments for a push or pull
+func syncArgs(projectPath, op string) []string {
+	args := []string{"-C", projectPath, op, "--progress"}
+	switch op {
+	case "push":
+		// First push of a branch: set its upstream
+		if err := exec.Command("git", "-C", projectPath, "rev-parse", "--abbrev-ref", "@{u}").Run(); err != nil {
+			args = append(args, "-u", "origin", "HEAD")
+		}
+	case "pull":
+		// Never leave a merge in progress behind a dashboard keypress
+		args = append(args, "--ff-only")
+	}
+	return args
+}
+
+func runSync(ch chan tea.Msg, projectName, projectPath, op string) {
+	args := syncArgs(projectPath, op)
+
+	pr, pw := io.Pipe()
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	// A credential prompt would hang invisibly behind the TUI
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	ch <- syncProgressMsg{project: projectName, op: op, line: "starting...", ch: ch}
+
+	if err := cmd.Start(); err != nil {
+		ch <- syncDoneMsg{project: projectName, op: op, err: err}
+		return
+	}
+	go func() {
+		pw.CloseWithError(cmd.Wait())
+	}()
+
+	var output bytes.Buffer
+	last := ""
+	scanner := bufio.NewScanner(pr)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		output.WriteString(line + "\n")
+		last = line
+		ch <- syncProgressMsg{project: projectName, op: op, line: line, ch: ch}
+	}
+	err := scanner.Err()
+
+	writeSyncLog(projectName, op, output.Bytes())
+
+	status, _ := discover.GetGitStatus(projectPath)
+	ch <- syncDoneMsg{project: projectName, op: op, err: err, detail: last, status: status}
+}
+
+// scanProgressLines splits on \n and on the \r git uses to redraw progress
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// writeSyncLog keeps the full output at ~/.hustlemc/logs/<op>-<project>.log
+func writeSyncLog(projectName, op string, output []byte) {
+	home, _ := os.UserHomeDir()
+	logDir := filepath.Join(home, ".hustlemc", "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(logDir, op+"-"+projectName+".log"), output, 0644)
+}
+
+// syncResultMessage summarizes a finished sync for the status line
+func syncResultMessage(msg syncDoneMsg) string {
+	verb := strings.Title(msg.op)
+	if msg.err != nil {
+		detail := msg.detail
+		if detail == "" {
+			detail = msg.err.Error()
+		}
+		return fmt.Sprintf("%s failed for %s: %s", verb, msg.project, detail)
+	}
+	if msg.status == nil {
+		return fmt.Sprintf("%sed %s", verb, msg.project)
+	}
+	return fmt.Sprintf("%sed %s — %d ahead, %d behind", verb, msg.project, msg.status.Ahead, msg.status.Behind)
+}
+
+// renderSyncs renders in-flight pushes/pulls for the status pane
+func (m Model) renderSyncs() string {
+	var names []string
+	for name := range m.syncs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		s := m.syncs[name]
+		icon := IconAhead
+		if s.op == "pull" {
+			icon = IconBehind
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %s: %s", icon, s.op, name, s.line))
+	}
+	return truncate(strings.Join(parts, "  ·  "), maxInt(m.width-10, 10))
+}
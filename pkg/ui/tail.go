@@ -0,0 +1,315 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+)
+
+// tailTickInterval is how often the logs pane redraws new output
+const tailTickInterval = 500 * time.Millisecond
+
+// tailService names the tail process in the tails manager
+const tailService = "logs"
+
+// deployService names a deploy in the deploys manager
+const deployService = "deploy"
+
+// logTail is the production logs pane for one project, following either
+// a log tail or a deploy's output
+type logTail struct {
+	project string
+	command string
+	deploy  bool      // a deploy, which carries on when the pane is left
+	since   time.Time // the deploy's start; earlier deploys' output is hidden
+
+	paused bool
+	frozen []services.Line // output as of the pause
+	scroll int             // lines up from the newest
+}
+
+type tailStartMsg struct {
+	project string
+	err     error
+}
+
+type tailTickMsg struct {
+	project string
+}
+
+// logsCommand returns the command that tails a project's production logs:
+// its logs setting, else one for the platform it deploys to, else ""
+func logsCommand(name, dir string) string {
+	cfg, _ := config.Load()
+	if c := cfg.Project(name).Logs; c != "" {
+		return c
+	}
+	has := func(f string) bool {
+		_, err := os.Stat(filepath.Join(dir, f))
+		return err == nil
+	}
+	switch {
+	case has("fly.toml"):
+		return "fly logs"
+	case has(".vercel"):
+		// Vercel projects are named after their production domain
+		return shellCommand("vercel", "logs", name)
+	case has("k8s"), has("kubernetes"), has("kustomization.yaml"):
+		return shellCommand("kubectl", "logs", "-f", "--all-containers", "--prefix", "-l", "app="+name)
+	}
+	return ""
+}
+
+func startTailCmd(mgr *services.Manager, project, dir, command string) tea.Cmd {
+	return func() tea.Msg {
+		err := mgr.Start(project, dir, config.Service{Name: tailService, Command: command})
+		return tailStartMsg{project: project, err: err}
+	}
+}
+
+func tailTickCmd(project string) tea.Cmd {
+	return tea.Tick(tailTickInterval, func(time.Time) tea.Msg {
+		return tailTickMsg{project: project}
+	})
+}
+
+// stopTailCmd ends a project's tail once its pane is closed
+func stopTailCmd(mgr *services.Manager, project string) tea.Cmd {
+	return func() tea.Msg {
+		mgr.StopAll(project)
+		return nil
+	}
+}
+
+// openLogs tails the project's production logs into the logs pane
+func (m Model) openLogs(p Project) (tea.Model, tea.Cmd) {
+	dir := expandPath(p.Path)
+	command := logsCommand(p.Name, dir)
+	if command == "" {
+		m.statusMsg = i18n.T("No logs command for %s (no fly.toml, .vercel, or k8s; set \"logs\" in config.json)", p.Name)
+		m.statusMsgTime = time.Now()
+		return m, nil
+	}
+	m.logTail = logTail{project: p.Name, command: command}
+	m.logFilter.SetValue("")
+	m.logFilter.Blur()
+	m.viewMode = LogsView
+	return m, tea.Batch(startTailCmd(m.tails, p.Name, dir, command), tailTickCmd(p.Name))
+}
+
+// handleTailTick redraws the pane while it's open and stops the tail once
+// it's been left
+func (m Model) handleTailTick(msg tailTickMsg) (tea.Model, tea.Cmd) {
+	if m.viewMode == LogsView && m.logTail.project == msg.project {
+		return m, tailTickCmd(msg.project)
+	}
+	return m, stopTailCmd(m.tails, msg.project)
+}
+
+// tailSource is the manager and service the pane follows
+func (m Model) tailSource() (*services.Manager, string) {
+	if m.logTail.deploy {
+		return m.deploys, deployService
+	}
+	return m.tails, tailService
+}
+
+// tailOutput is everything the pane's process has written, oldest first
+func (m Model) tailOutput() []services.Line {
+	mgr, _ := m.tailSource()
+	lines := mgr.Logs(m.logTail.project, services.LogLines)
+	for i, l := range lines {
+		if !l.Time.Before(m.logTail.since) {
+			return lines[i:]
+		}
+	}
+	return nil
+}
+
+// tailLines returns the tail's output that matches the filter, oldest
+// first; the output as of the pause while paused
+func (m Model) tailLines() []services.Line {
+	t := m.logTail
+	lines := t.frozen
+	if !t.paused {
+		lines = m.tailOutput()
+	}
+	query := strings.ToLower(strings.TrimSpace(m.logFilter.Value()))
+	if query == "" {
+		return lines
+	}
+	var out []services.Line
+	for _, l := range lines {
+		if strings.Contains(strings.ToLower(plainText(l.Text)), query) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// pauseTail freezes the pane so it can be read while output keeps coming
+func (m *Model) pauseTail() {
+	if !m.logTail.paused {
+		m.logTail.frozen = m.tailOutput()
+		m.logTail.paused = true
+	}
+}
+
+// resumeTail follows new output again
+func (m *Model) resumeTail() {
+	m.logTail.paused = false
+	m.logTail.frozen = nil
+	m.logTail.scroll = 0
+}
+
+func (m Model) handleLogsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t := &m.logTail
+	rows := maxInt(m.getListHeight()-4, 1)
+
+	// Scrolling up pauses, so what's on screen stays put
+	scrollBy := func(n int) {
+		if n > 0 {
+			m.pauseTail()
+		}
+		last := maxInt(len(m.tailLines())-rows, 0)
+		t.scroll = min(maxInt(t.scroll+n, 0), last)
+	}
+
+	switch msg.String() {
+	case "/":
+		m.logFilter.Focus()
+		return m, textinput.Blink
+	case " ", "p":
+		if t.paused {
+			m.resumeTail()
+		} else {
+			m.pauseTail()
+		}
+	case "k", "up":
+		scrollBy(1)
+	case "j", "down":
+		scrollBy(-1)
+	case "ctrl+u":
+		scrollBy(rows / 2)
+	case "ctrl+d":
+		scrollBy(-rows / 2)
+	case "g":
+		scrollBy(services.LogLines)
+	case "G":
+		m.resumeTail()
+	case "y":
+		var text []string
+		for _, l := range m.tailLines() {
+			text = append(text, plainText(l.Text))
+		}
+		if len(text) > 0 {
+			return m, copyToClipboardCmd(strings.Join(text, "\n"), i18n.T("%d log lines", len(text)))
+		}
+	case "ctrl+r":
+		if t.deploy {
+			// Deploying again is y, through the confirmation policy
+			break
+		}
+		// Restart the tail, e.g. after it timed out
+		mgr, project, command := m.tails, t.project, t.command
+		dir := ""
+		if p := m.getProjectByName(project); p != nil {
+			dir = expandPath(p.Path)
+		}
+		m.resumeTail()
+		return m, func() tea.Msg {
+			mgr.StopAll(project)
+			return startTailCmd(mgr, project, dir, command)()
+		}
+	}
+	return m, nil
+}
+
+// handleLogFilterKey edits the logs pane's filter: enter keeps it, esc
+// clears it
+func (m Model) handleLogFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.logFilter.Blur()
+		return m, nil
+	case "esc":
+		m.logFilter.SetValue("")
+		m.logFilter.Blur()
+		m.logTail.scroll = 0
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.logFilter, cmd = m.logFilter.Update(msg)
+	m.logTail.scroll = 0
+	return m, cmd
+}
+
+// renderLogs shows the tail's output, newest at the bottom
+func (m Model) renderLogs(height int) string {
+	t := m.logTail
+	var b strings.Builder
+
+	if t.deploy {
+		b.WriteString(i18n.T("\n  %s Deploy — %s  (/ filter, space pause, j/k scroll, G follow, y copy, esc back; it carries on)\n", IconDeploy, t.project))
+	} else {
+		b.WriteString(i18n.T("\n  %s Logs — %s  (/ filter, space pause, j/k scroll, G follow, y copy, ctrl+r restart, esc back)\n", IconPlay, t.project))
+	}
+
+	state := ""
+	mgr, service := m.tailSource()
+	statuses := mgr.Statuses(t.project, []config.Service{{Name: service, Command: t.command}})
+	switch s := statuses[0]; s.State {
+	case services.Running:
+		state = IconPlay + " " + i18n.T("following")
+	case services.Failed:
+		state = fmt.Sprintf("%s %s: %v", IconX, i18n.T("failed"), s.Err)
+	case services.Exited:
+		if t.deploy {
+			state = IconCheck + " " + i18n.T("finished")
+		} else {
+			state = IconPause + " " + i18n.T("exited (ctrl+r to restart)")
+		}
+	default:
+		state = i18n.T("starting...")
+	}
+	if t.paused {
+		state = IconPause + " " + i18n.T("paused")
+	}
+	if t.deploy {
+		if took := deployDuration(m.deployElapsed()); took != "" {
+			state += "  " + took
+		}
+	}
+	b.WriteString(truncate(fmt.Sprintf("  $ %s   %s", t.command, state), maxInt(m.width-4, 20)) + "\n")
+	if m.logFilter.Focused() || m.logFilter.Value() != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n", IconSearch, m.logFilter.View()))
+	} else {
+		b.WriteString("\n")
+	}
+
+	lines := m.tailLines()
+	if len(lines) == 0 {
+		if m.logFilter.Value() != "" {
+			b.WriteString(i18n.T("  No lines match\n"))
+		} else {
+			b.WriteString(i18n.T("  No output yet\n"))
+		}
+		return padLines(b.String(), height)
+	}
+
+	rows := maxInt(height-4, 1)
+	end := maxInt(len(lines)-t.scroll, 0)
+	start := maxInt(end-rows, 0)
+	for _, l := range lines[start:end] {
+		b.WriteString(truncate(fmt.Sprintf("  %s  %s", l.Time.Format("15:04:05"), plainText(l.Text)), maxInt(m.width-2, 20)) + "\n")
+	}
+	return padLines(b.String(), height)
+}
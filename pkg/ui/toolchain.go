@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+)
+
+type toolchainMsg struct {
+	project string
+	reqs    []discover.ToolRequirement
+}
+
+func loadToolchainCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		reqs, _ := discover.CheckToolchain(path)
+		return toolchainMsg{project: name, reqs: reqs}
+	}
+}
+
+// toolchainIssues counts a project's pinned tools that are missing or
+// at the wrong version
+func (m Model) toolchainIssues(name string) int {
+	n := 0
+	for _, r := range m.toolchains[name] {
+		if !r.OK() {
+			n++
+		}
+	}
+	return n
+}
+
+// renderToolchain lists the runtime versions a project pins for the
+// detail view, flagging ones that aren't installed or don't match
+func (m Model) renderToolchain(name string) string {
+	reqs := m.toolchains[name]
+	if len(reqs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(i18n.T("  Toolchain: %d pinned, %d with problems\n", len(reqs), m.toolchainIssues(name)))
+	for _, r := range reqs {
+		var line string
+		switch {
+		case r.Missing():
+			line = fmt.Sprintf("    %s %s %s (%s) - %s", IconX, r.Tool, r.Want, r.Source, i18n.T("not installed"))
+		case r.Mismatched():
+			line = fmt.Sprintf("    %s %s %s (%s) - %s", IconX, r.Tool, r.Want, r.Source, i18n.T("have %s", r.Have))
+		default:
+			line = fmt.Sprintf("    %s %s %s (%s)", IconCheck, r.Tool, r.Have, r.Source)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,18 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/selfupdate"
+)
+
+// updateMsg carries a newer release tag, if there is one
+type updateMsg struct {
+	latest string
+}
+
+// checkUpdateCmd looks for a newer release. Failures stay quiet: being
+// offline shouldn't produce a warning on every start.
+func checkUpdateCmd() tea.Msg {
+	latest, _ := selfupdate.Available()
+	return updateMsg{latest: latest}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/maintenance"
+	"github.com/michaelmonetized/mission-control/pkg/uptime"
+)
+
+type uptimeTickMsg struct{}
+
+// uptimeMsg reports a round of uptime probes: this month's report per
+// probed project and the error of each one found down
+type uptimeMsg struct {
+	reports map[string]uptime.Report
+	down    map[string]string
+}
+
+func uptimeTickCmd() tea.Cmd {
+	return tea.Tick(uptime.Interval, func(time.Time) tea.Msg {
+		return uptimeTickMsg{}
+	})
+}
+
+// probeUptimeCmd probes every project with an uptime URL, records the
+// results, and rebuilds their monthly reports. Failures during a
+// maintenance window are recorded as such and don't count as down.
+func probeUptimeCmd() tea.Msg {
+	cfg, err := config.Load()
+	if err != nil {
+		return uptimeMsg{}
+	}
+
+	windows, _ := maintenance.Load()
+	msg := uptimeMsg{reports: make(map[string]uptime.Report), down: make(map[string]string)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, target := range uptime.Targets(cfg) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := uptime.Check(target)
+			p.Maintenance = maintenance.Covering(windows, name, p.Time) != nil
+			uptime.Record(name, p)
+			r, err := uptime.Monthly(name, time.Now())
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				msg.reports[name] = r
+			}
+			if !p.Up && !p.Maintenance {
+				msg.down[name] = p.Error
+			}
+		}()
+	}
+	wg.Wait()
+	return msg
+}
+
+// renderUptime shows this month's availability for the detail view, when
+// the project is probed
+func (m Model) renderUptime(name string) string {
+	r, ok := m.uptimes[name]
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T("  Uptime: %s this month, %d outages (%s down)\n",
+		uptime.FormatAvailability(r.Availability()), len(r.Windows), formatDuration(r.Downtime)))
+	if err, ok := m.down[name]; ok {
+		b.WriteString(i18n.T("  %s Down: %s\n", IconX, err))
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/i18n"
+	"github.com/michaelmonetized/mission-control/pkg/workers"
+)
+
+type workersTickMsg struct{}
+
+// workersMsg is a round of worker and queue polls, by project name
+type workersMsg map[string]workers.Status
+
+func workersTickCmd() tea.Cmd {
+	return tea.Tick(workers.Interval, func(time.Time) tea.Msg {
+		return workersTickMsg{}
+	})
+}
+
+// pollWorkersCmd polls the workers and queues of every project that
+// declares them in its .mc.toml
+func pollWorkersCmd(projects []Project) tea.Cmd {
+	return func() tea.Msg {
+		msg := make(workersMsg)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, p := range projects {
+			pf, err := config.LoadProjectFile(expandPath(p.Path))
+			if err != nil || pf == nil || pf.Workers == nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s := workers.Check(*pf.Workers)
+				mu.Lock()
+				msg[p.Name] = s
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		return msg
+	}
+}
+
+// workersBadge is the row's worker glyph: the queue's depth, a check
+// when the workers are up with no queue metrics, or an x when they're
+// down. It is blank for projects without workers, and left out entirely
+// when no project has any.
+func (m Model) workersBadge(p Project) string {
+	if len(m.workerStatus) == 0 {
+		return ""
+	}
+	s, ok := m.workerStatus[p.Name]
+	switch {
+	case !ok:
+		return strings.Repeat(" ", 6)
+	case !s.Up:
+		return fmt.Sprintf(" %s%-4s", IconWorker, IconX)
+	case s.QueueErr != "":
+		return fmt.Sprintf(" %s%-4s", IconWorker, "?")
+	case s.Depth >= 0:
+		return fmt.Sprintf(" %s%-4s", IconWorker, compactCount(s.Depth))
+	}
+	return fmt.Sprintf(" %s%-4s", IconWorker, IconCheck)
+}
+
+// renderWorkers shows the last worker and queue poll in the detail view
+func (m Model) renderWorkers(name string) string {
+	s, ok := m.workerStatus[name]
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	health := IconCheck + " " + i18n.T("up")
+	if !s.Up {
+		health = IconX + " " + i18n.T("down: %s", s.Err)
+	}
+	b.WriteString(i18n.T("  Workers: %s", health))
+	switch {
+	case s.QueueErr != "":
+		b.WriteString(i18n.T(", queue unreadable: %s", s.QueueErr))
+	case s.BackedUp():
+		b.WriteString(", " + driftStyle.Render(i18n.T("queue backed up: %d waiting (max %d)", s.Depth, s.MaxDepth)))
+	case s.Depth >= 0:
+		b.WriteString(i18n.T(", %d waiting", s.Depth))
+	}
+	b.WriteString(i18n.T(" (checked %s ago)\n", strings.TrimSpace(formatTimeSince(s.Time))))
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelmonetized/mission-control/pkg/discover"
+)
+
+type worktreesMsg struct {
+	project   string
+	worktrees []discover.Worktree
+}
+
+func loadWorktreesCmd(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		wts, _ := discover.ListWorktrees(path)
+		return worktreesMsg{project: name, worktrees: wts}
+	}
+}
+
+// selectedWorktree returns the worktree picked in the detail view, if the
+// project has more than one
+func (m Model) selectedWorktree(name string) (discover.Worktree, bool) {
+	wts := m.worktrees[name]
+	if len(wts) < 2 || m.worktreeIdx >= len(wts) {
+		return discover.Worktree{}, false
+	}
+	return wts[m.worktreeIdx], true
+}
+
+// setWorktrees stores a project's worktrees, starting the selection on
+// the one the project itself points at
+func (m *Model) setWorktrees(msg worktreesMsg) {
+	m.worktrees[msg.project] = msg.worktrees
+	m.worktreeIdx = 0
+	for i, wt := range msg.worktrees {
+		if wt.Current {
+			m.worktreeIdx = i
+		}
+	}
+}
+
+// renderWorktrees lists a repo's worktrees for the detail view; single
+// worktree repos show nothing
+func (m Model) renderWorktrees(name string) string {
+	wts := m.worktrees[name]
+	if len(wts) < 2 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\n  %s Worktrees (%d)  [/] select, o nvim, l lazygit\n", IconBranch, len(wts)))
+	for i, wt := range wts {
+		branch := wt.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		var state []string
+		if wt.Main {
+			state = append(state, "main")
+		}
+		if wt.Current {
+			state = append(state, "this project")
+		}
+		switch {
+		case wt.Missing:
+			state = append(state, "missing")
+		case wt.Dirty:
+			state = append(state, "dirty")
+		}
+
+		mark := " "
+		if wt.Dirty || wt.Missing {
+			mark = IconModified
+		}
+		line := fmt.Sprintf("    %s %-20s %s", mark, truncate(branch, 20), wt.Path)
+		if len(state) > 0 {
+			line += "  (" + strings.Join(state, ", ") + ")"
+		}
+		if i == m.worktreeIdx {
+			line = fmt.Sprintf("\033[30;48;5;6m%-*s\033[0m", maxInt(m.width-4, 0), line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,279 @@
+// Package uptime records production uptime probes and turns them into
+// monthly availability reports for client SLAs. Probes are appended one
+// JSON object per line to ~/.hustlemc/uptime/<project>.jsonl, so a month
+// of five-minute probes never means rewriting the whole history.
+package uptime
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+)
+
+// Interval is how often a project is probed
+const Interval = 5 * time.Minute
+
+// maxGap is how long one probe's result is taken to hold. Past it (the
+// machine was asleep, or nothing was probing) time counts as unmonitored
+// rather than up or down.
+const maxGap = 3 * Interval
+
+var mu sync.Mutex
+
+// Probe is one check of a project's production URL
+type Probe struct {
+	Time    time.Time `json:"time"`
+	Up      bool      `json:"up"`
+	Latency int64     `json:"latency_ms,omitempty"`
+	Error   string    `json:"error,omitempty"`
+
+	// Maintenance marks a probe taken during a declared maintenance
+	// window; its time counts as planned, not up or down
+	Maintenance bool `json:"maintenance,omitempty"`
+}
+
+// Dir returns the directory probe logs are kept in
+func Dir() string {
+	return filepath.Join(config.Dir(), "uptime")
+}
+
+// Path returns a project's probe log path
+func Path(project string) string {
+	return filepath.Join(Dir(), strings.ReplaceAll(project, "/", "_")+".jsonl")
+}
+
+// Targets returns the URL probed for each project that has one configured
+func Targets(cfg *config.Config) map[string]string {
+	targets := make(map[string]string)
+	for name, p := range cfg.Projects {
+		if p != nil && p.Uptime != "" {
+			targets[name] = p.Uptime
+		}
+	}
+	return targets
+}
+
+// Check probes target once
+func Check(target string) Probe {
+	start := time.Now()
+	err := services.Check(target)
+	p := Probe{Time: start, Up: err == nil, Latency: time.Since(start).Milliseconds()}
+	if err != nil {
+		p.Error = err.Error()
+	}
+	return p
+}
+
+// Record appends a probe to a project's log
+func Record(project string, p Probe) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(Path(project), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads a project's probes in [from, to), oldest first. Lines that
+// don't parse (say, one cut short by a crash) are skipped.
+func Load(project string, from, to time.Time) ([]Probe, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := os.Open(Path(project))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var probes []Probe
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var p Probe
+		if json.Unmarshal(sc.Bytes(), &p) != nil {
+			continue
+		}
+		if p.Time.Before(from) || !p.Time.Before(to) {
+			continue
+		}
+		probes = append(probes, p)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(probes, func(i, j int) bool { return probes[i].Time.Before(probes[j].Time) })
+	return probes, nil
+}
+
+// Window is a stretch of downtime: from the first failed probe to the
+// next one that succeeded
+type Window struct {
+	Start time.Time
+	End   time.Time
+	Error string // the first failure's error
+}
+
+// Duration is how long the window lasted
+func (w Window) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Report is a project's availability over a month
+type Report struct {
+	Project   string
+	Month     time.Time // start of the month
+	Probes    int
+	Monitored time.Duration // time covered by probes
+	Downtime  time.Duration
+	Planned   time.Duration // under maintenance, left out of Monitored
+	Windows   []Window
+}
+
+// Availability is the share of monitored time the project was up, as a
+// percentage, or -1 when nothing was monitored
+func (r Report) Availability() float64 {
+	if r.Monitored <= 0 {
+		return -1
+	}
+	return 100 * float64(r.Monitored-r.Downtime) / float64(r.Monitored)
+}
+
+// FormatAvailability renders an availability percentage, "n/a" when
+// unknown
+func FormatAvailability(pct float64) string {
+	if pct < 0 {
+		return "n/a"
+	}
+	return strconv.FormatFloat(pct, 'f', 3, 64) + "%"
+}
+
+// MonthRange returns the start of t's month and the start of the next
+func MonthRange(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Monthly builds a project's report for t's month. Each probe's result
+// holds until the next probe, up to maxGap, and never past the month's
+// end or now.
+func Monthly(project string, t time.Time) (Report, error) {
+	from, to := MonthRange(t)
+	probes, err := Load(project, from, to)
+	if err != nil {
+		return Report{}, err
+	}
+	until := time.Now()
+	if to.Before(until) {
+		until = to
+	}
+	return Summarize(project, from, probes, until), nil
+}
+
+// Summarize builds a report from probes (oldest first) taken in the month
+// starting at month, counting time up to until
+func Summarize(project string, month time.Time, probes []Probe, until time.Time) Report {
+	r := Report{Project: project, Month: month, Probes: len(probes)}
+	var open *Window
+	for i, p := range probes {
+		end := p.Time.Add(maxGap)
+		if i+1 < len(probes) && probes[i+1].Time.Before(end) {
+			end = probes[i+1].Time
+		}
+		if until.Before(end) {
+			end = until
+		}
+		span := max(end.Sub(p.Time), 0)
+		if !p.Maintenance {
+			r.Monitored += span
+		}
+
+		// An unmonitored gap ends a window too: nobody saw it down then,
+		// and so does maintenance starting
+		if open != nil && (p.Up || p.Maintenance || p.Time.After(open.End)) {
+			r.Windows = append(r.Windows, *open)
+			open = nil
+		}
+		if p.Maintenance {
+			r.Planned += span
+			continue
+		}
+		if p.Up {
+			continue
+		}
+		r.Downtime += span
+		if open == nil {
+			open = &Window{Start: p.Time, Error: p.Error}
+		}
+		open.End = p.Time.Add(span)
+	}
+	if open != nil {
+		r.Windows = append(r.Windows, *open)
+	}
+	return r
+}
+
+// WriteCSV writes the report's downtime windows followed by a summary row
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"project", "start", "end", "minutes", "error"})
+	for _, win := range r.Windows {
+		cw.Write([]string{
+			r.Project,
+			win.Start.Format(time.RFC3339),
+			win.End.Format(time.RFC3339),
+			strconv.FormatFloat(win.Duration().Minutes(), 'f', 1, 64),
+			win.Error,
+		})
+	}
+	cw.Write([]string{
+		r.Project,
+		r.Month.Format("2006-01"),
+		"availability " + FormatAvailability(r.Availability()),
+		strconv.FormatFloat(r.Downtime.Minutes(), 'f', 1, 64),
+		r.probeSummary(),
+	})
+	cw.Flush()
+	return cw.Error()
+}
+
+// probeSummary describes what the report is based on
+func (r Report) probeSummary() string {
+	s := fmt.Sprintf("%d probes over %.1fh monitored", r.Probes, r.Monitored.Hours())
+	if r.Planned > 0 {
+		s += fmt.Sprintf(", %.1fh planned maintenance excluded", r.Planned.Hours())
+	}
+	return s
+}
+
+// Filename returns e.g. "uptime-acme.com-2026-10.csv"
+func (r Report) Filename() string {
+	return fmt.Sprintf("uptime-%s-%s.csv", strings.ReplaceAll(r.Project, "/", "_"), r.Month.Format("2006-01"))
+}
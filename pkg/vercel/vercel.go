@@ -0,0 +1,184 @@
+// Package vercel talks to the Vercel REST API, so a linked project's
+// deployments and environment variables are read without the vercel CLI.
+package vercel
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/httpapi"
+)
+
+// DefaultBaseURL is the Vercel API
+const DefaultBaseURL = "https://api.vercel.com"
+
+// ErrNoToken means no credential was found (see FindToken)
+var ErrNoToken = errors.New("no vercel token (set tokens.vercel, MC_VERCEL_TOKEN, or VERCEL_TOKEN, or log in with vercel)")
+
+// ErrNotLinked means a directory has no .vercel/project.json
+var ErrNotLinked = errors.New("not linked to a Vercel project (run vercel link)")
+
+// Client makes authenticated API requests
+type Client struct {
+	*httpapi.Client
+}
+
+// NewClient returns a client for the Vercel API using token
+func NewClient(token string) *Client {
+	return &Client{httpapi.New("vercel", DefaultBaseURL, token, ErrNoToken)}
+}
+
+// FindToken looks for a token, in order: tokens.vercel (or
+// MC_VERCEL_TOKEN), VERCEL_TOKEN, then the one the vercel CLI saved at
+// login. It returns "" when there is none.
+func FindToken(cfg *config.Config) string {
+	if cfg != nil {
+		if t := cfg.Token("vercel"); t != "" {
+			return t
+		}
+	}
+	if t := os.Getenv("VERCEL_TOKEN"); t != "" {
+		return t
+	}
+	return cliToken()
+}
+
+// cliToken reads the token from the vercel CLI's auth.json, kept in its
+// data directory: ~/Library/Application Support/com.vercel.cli on macOS,
+// $XDG_DATA_HOME/com.vercel.cli (~/.local/share) elsewhere
+func cliToken() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	dir := os.Getenv("XDG_DATA_HOME")
+	switch {
+	case runtime.GOOS == "darwin":
+		dir = filepath.Join(home, "Library", "Application Support")
+	case dir == "":
+		dir = filepath.Join(home, ".local", "share")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "com.vercel.cli", "auth.json"))
+	if err != nil {
+		return ""
+	}
+	var auth struct {
+		Token string `json:"token"`
+	}
+	json.Unmarshal(data, &auth)
+	return auth.Token
+}
+
+// Default returns a client using the token FindToken finds, or nil when
+// there is none
+func Default() *Client {
+	cfg, _ := config.Load()
+	if token := FindToken(cfg); token != "" {
+		return NewClient(token)
+	}
+	return nil
+}
+
+// Link is the project a directory is linked to, from .vercel/project.json
+type Link struct {
+	ProjectID string `json:"projectId"`
+	OrgID     string `json:"orgId"` // team_... for a team, the user's ID otherwise
+}
+
+// ReadLink reads the project dir is linked to
+func ReadLink(dir string) (Link, error) {
+	var link Link
+	data, err := os.ReadFile(filepath.Join(dir, ".vercel", "project.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return link, ErrNotLinked
+	}
+	if err != nil {
+		return link, err
+	}
+	if err := json.Unmarshal(data, &link); err != nil {
+		return link, err
+	}
+	if link.ProjectID == "" {
+		return link, ErrNotLinked
+	}
+	return link, nil
+}
+
+// query is the project's scope as query parameters: its team, when it
+// belongs to one
+func (l Link) query() url.Values {
+	q := url.Values{}
+	if strings.HasPrefix(l.OrgID, "team_") {
+		q.Set("teamId", l.OrgID)
+	}
+	return q
+}
+
+// Deployment is one deployment of a project
+type Deployment struct {
+	UID          string `json:"uid"`
+	URL          string `json:"url"`   // host name, without https://
+	State        string `json:"state"` // READY, BUILDING, INITIALIZING, QUEUED, ERROR, CANCELED
+	Target       string `json:"target"`
+	Created      int64  `json:"created"`    // Unix milliseconds
+	BuildingAt   int64  `json:"buildingAt"` // Unix milliseconds, 0 until it builds
+	Ready        int64  `json:"ready"`      // Unix milliseconds, 0 until it's done
+	InspectorURL string `json:"inspectorUrl"`
+	Creator      struct {
+		Username string `json:"username"`
+	} `json:"creator"`
+	Meta struct {
+		SHA     string `json:"githubCommitSha"`
+		Ref     string `json:"githubCommitRef"`
+		Message string `json:"githubCommitMessage"`
+		Author  string `json:"githubCommitAuthorLogin"`
+		PR      string `json:"githubPrId"` // the pull request a preview is for
+	} `json:"meta"`
+}
+
+// Deployments lists the newest n deployments of the linked project,
+// newest first
+func (c *Client) Deployments(l Link, n int) ([]Deployment, error) {
+	q := l.query()
+	q.Set("projectId", l.ProjectID)
+	q.Set("limit", strconv.Itoa(n))
+	var page struct {
+		Deployments []Deployment `json:"deployments"`
+	}
+	err := c.Get("v6/deployments?"+q.Encode(), &page)
+	return page.Deployments, err
+}
+
+// Promote points the linked project's production domains at a deployment,
+// which is how an earlier one is rolled back to
+func (c *Client) Promote(l Link, deploymentID string) error {
+	path := "v10/projects/" + url.PathEscape(l.ProjectID) + "/promote/" + url.PathEscape(deploymentID)
+	if q := l.query().Encode(); q != "" {
+		path += "?" + q
+	}
+	return c.Post(path, nil, nil)
+}
+
+// EnvVar is one of a project's environment variables, without its value
+type EnvVar struct {
+	Key       string   `json:"key"`
+	Type      string   `json:"type"` // plain, encrypted, sensitive, secret, system
+	Target    []string `json:"target"`
+	UpdatedAt int64    `json:"updatedAt"` // Unix milliseconds
+}
+
+// EnvVars lists the linked project's environment variables
+func (c *Client) EnvVars(l Link) ([]EnvVar, error) {
+	var page struct {
+		Envs []EnvVar `json:"envs"`
+	}
+	err := c.Get("v9/projects/"+url.PathEscape(l.ProjectID)+"/env?"+l.query().Encode(), &page)
+	return page.Envs, err
+}
@@ -0,0 +1,192 @@
+// Package workers polls the background worker health endpoints and queue
+// metrics that backend projects declare in .mc.toml, so a stuck queue
+// shows up next to the code that feeds it.
+package workers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michaelmonetized/mission-control/pkg/config"
+	"github.com/michaelmonetized/mission-control/pkg/services"
+)
+
+// Interval is how often workers and queues are polled
+const Interval = time.Minute
+
+// DefaultMaxDepth is how many waiting jobs count as backed up when a
+// project doesn't say
+const DefaultMaxDepth = 1000
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Status is one poll of a project's workers and queue
+type Status struct {
+	Time     time.Time
+	Up       bool   // the health endpoint answered (true when there is none)
+	Err      string // why it didn't
+	Depth    int    // jobs waiting, -1 without queue metrics
+	QueueErr string // why the metrics couldn't be read
+	MaxDepth int
+}
+
+// BackedUp reports whether more jobs are waiting than MaxDepth
+func (s Status) BackedUp() bool {
+	return s.Depth > s.MaxDepth
+}
+
+// Healthy reports whether the workers are up and the queue is readable
+// and keeping up
+func (s Status) Healthy() bool {
+	return s.Up && s.QueueErr == "" && !s.BackedUp()
+}
+
+// Check polls the health endpoint and queue metrics w declares
+func Check(w config.Workers) Status {
+	s := Status{Time: time.Now(), Up: true, Depth: -1, MaxDepth: w.MaxDepth}
+	if s.MaxDepth <= 0 {
+		s.MaxDepth = DefaultMaxDepth
+	}
+	if w.Health != "" {
+		if err := services.Check(w.Health); err != nil {
+			s.Up, s.Err = false, err.Error()
+		}
+	}
+	if w.Queue != "" {
+		depth, err := QueueDepth(w.Queue)
+		if err != nil {
+			s.QueueErr = err.Error()
+		} else {
+			s.Depth = depth
+		}
+	}
+	return s
+}
+
+// depthNames are the metric and field names that count waiting jobs,
+// across Sidekiq, BullMQ, Celery exporters, RabbitMQ, and hand-rolled
+// endpoints
+var depthNames = []string{"depth", "size", "length", "enqueued", "waiting", "backlog", "pending", "messages_ready"}
+
+// isDepthField reports whether a JSON field counts waiting jobs
+func isDepthField(name string) bool {
+	name = strings.ToLower(name)
+	for _, d := range depthNames {
+		if name == d {
+			return true
+		}
+	}
+	return false
+}
+
+// isDepthMetric reports whether a Prometheus metric counts waiting jobs:
+// a queue, job, task, or message metric ending in one of depthNames, as
+// in sidekiq_queue_enqueued or rabbitmq_queue_messages_ready
+func isDepthMetric(name string) bool {
+	name = strings.ToLower(name)
+	if !strings.Contains(name, "queue") && !strings.Contains(name, "job") &&
+		!strings.Contains(name, "task") && !strings.Contains(name, "message") {
+		return false
+	}
+	for _, d := range depthNames {
+		if strings.HasSuffix(name, "_"+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueueDepth reads the jobs waiting from a metrics URL: JSON, where
+// numeric fields named one of depthNames are summed wherever they nest
+// ({"queues": {"mail": {"depth": 3}}}), or Prometheus text, where the
+// samples of queue metrics ending in one are summed
+func QueueDepth(url string) (int, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return 0, err
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return 0, err
+		}
+		depth, found := jsonDepth(doc, "")
+		if !found {
+			return 0, errors.New("no queue depth in the metrics")
+		}
+		return depth, nil
+	}
+	return prometheusDepth(trimmed)
+}
+
+// jsonDepth sums the depth fields in v, which was found under key
+func jsonDepth(v any, key string) (depth int, found bool) {
+	switch v := v.(type) {
+	case float64:
+		if isDepthField(key) {
+			return int(v), true
+		}
+	case map[string]any:
+		for k, child := range v {
+			d, ok := jsonDepth(child, k)
+			depth, found = depth+d, found || ok
+		}
+	case []any:
+		for _, child := range v {
+			d, ok := jsonDepth(child, key)
+			depth, found = depth+d, found || ok
+		}
+	}
+	return depth, found
+}
+
+// prometheusDepth sums the samples of depth metrics in Prometheus text
+// exposition format
+func prometheusDepth(text string) (int, error) {
+	depth, found := 0, false
+	sc := bufio.NewScanner(strings.NewReader(text))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, rest := line, ""
+		if i := strings.IndexAny(line, "{ "); i >= 0 {
+			name, rest = line[:i], line[i:]
+		}
+		if !isDepthMetric(name) {
+			continue
+		}
+		if i := strings.LastIndex(rest, "}"); i >= 0 {
+			rest = rest[i+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if v, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			depth, found = depth+int(v), true
+		}
+	}
+	if !found {
+		return 0, errors.New("no queue depth in the metrics")
+	}
+	return depth, nil
+}